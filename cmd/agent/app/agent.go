@@ -32,6 +32,7 @@ import (
 	"volcano.sh/volcano/pkg/agent/events"
 	"volcano.sh/volcano/pkg/agent/features"
 	"volcano.sh/volcano/pkg/agent/healthcheck"
+	"volcano.sh/volcano/pkg/agent/numatopology"
 	"volcano.sh/volcano/pkg/agent/utils"
 	"volcano.sh/volcano/pkg/agent/utils/cgroup"
 	"volcano.sh/volcano/pkg/metriccollect"
@@ -94,7 +95,13 @@ func Run(ctx context.Context, opts *options.VolcanoAgentOptions) error {
 		return fmt.Errorf("failed to run event manager: %v", err)
 	}
 
+	if conf.GenericConfiguration.ReportNumaTopology {
+		numatopology.NewReporter(conf.GenericConfiguration.KubeNodeName, conf.GenericConfiguration.KubeClient,
+			conf.GenericConfiguration.VolcanoClient, conf.GenericConfiguration.PodResourcesEndpoint).Run(ctx, numatopology.DefaultReportPeriod)
+	}
+
 	conf.InformerFactory.K8SInformerFactory.Start(ctx.Done())
+	conf.InformerFactory.VCInformerFactory.Start(ctx.Done())
 	RunServer(healthcheck.NewHealthChecker(networkQoSMgr), conf.GenericConfiguration.HealthzAddress, conf.GenericConfiguration.HealthzPort)
 	klog.InfoS("Volcano volcano-agent started")
 	<-ctx.Done()
@@ -33,6 +33,8 @@ import (
 	"k8s.io/klog/v2"
 
 	"volcano.sh/apis/pkg/apis/helpers"
+	vcclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	vcinformers "volcano.sh/apis/pkg/client/informers/externalversions"
 	"volcano.sh/volcano/cmd/agent/app/options"
 	"volcano.sh/volcano/pkg/agent/apis"
 	"volcano.sh/volcano/pkg/agent/healthcheck"
@@ -70,6 +72,13 @@ func NewConfiguration(opts *options.VolcanoAgentOptions) (*config.Configuration,
 	}
 	conf.GenericConfiguration.KubeClient = kubeClient
 
+	vcClient, err := vcclientset.NewForConfig(restclient.AddUserAgent(kubeConfig, utils.Component))
+	if err != nil {
+		return conf, fmt.Errorf("failed to create volcano client: %v", err)
+	}
+	conf.GenericConfiguration.VolcanoClient = vcClient
+	conf.InformerFactory.VCInformerFactory = vcinformers.NewSharedInformerFactory(vcClient, 0)
+
 	broadcaster := record.NewBroadcaster()
 	broadcaster.StartLogging(klog.Infof)
 	broadcaster.StartStructuredLogging(2)
@@ -22,6 +22,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"volcano.sh/volcano/pkg/agent/numatopology"
 	"volcano.sh/volcano/pkg/config"
 )
 
@@ -68,6 +69,10 @@ type VolcanoAgentOptions struct {
 
 	// ExtendResourceMemoryName is the extend resource memory, which is used to calculate overSubscription resources.
 	ExtendResourceMemoryName string
+
+	// ReportNumaTopology determines whether reporting the numa topology got from the kubelet pod resources api at PodResourcesEndpoint.
+	ReportNumaTopology   bool
+	PodResourcesEndpoint string
 }
 
 func NewVolcanoAgentOptions() *VolcanoAgentOptions {
@@ -89,6 +94,8 @@ func (options *VolcanoAgentOptions) AddFlags(c *cobra.Command) {
 	c.Flags().BoolVar(&options.IncludeSystemUsage, "include-system-usage", false, "It determines whether considering system usage when calculate overSubscription resource and evict.")
 	c.Flags().StringVar(&options.ExtendResourceCPUName, "extend-resource-cpu-name", "", "The extended cpu resource name, which is used to calculate oversubscription resources, default to kubernetes.io/batch-cpu")
 	c.Flags().StringVar(&options.ExtendResourceMemoryName, "extend-resource-memory-name", "", "The extended memory resource name, which is used to calculate oversubscription resources, default to kubernetes.io/batch-memory")
+	c.Flags().BoolVar(&options.ReportNumaTopology, "report-numa-topology", false, "Report the numa topology of the node got from the kubelet to its Numatopology, which is used by the numa-aware scheduler plugin")
+	c.Flags().StringVar(&options.PodResourcesEndpoint, "pod-resources-endpoint", numatopology.DefaultPodResourcesEndpoint, "The endpoint of the pod resources api of the kubelet")
 }
 
 func (options *VolcanoAgentOptions) Validate() error {
@@ -111,5 +118,7 @@ func (options *VolcanoAgentOptions) ApplyTo(cfg *config.Configuration) error {
 	cfg.GenericConfiguration.IncludeSystemUsage = options.IncludeSystemUsage
 	cfg.GenericConfiguration.ExtendResourceCPUName = options.ExtendResourceCPUName
 	cfg.GenericConfiguration.ExtendResourceMemoryName = options.ExtendResourceMemoryName
+	cfg.GenericConfiguration.ReportNumaTopology = options.ReportNumaTopology
+	cfg.GenericConfiguration.PodResourcesEndpoint = options.PodResourcesEndpoint
 	return nil
 }
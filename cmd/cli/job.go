@@ -21,6 +21,7 @@ import (
 
 	"volcano.sh/volcano/cmd/cli/util"
 	"volcano.sh/volcano/pkg/cli/job"
+	cliutil "volcano.sh/volcano/pkg/cli/util"
 )
 
 func buildJobCmd() *cobra.Command {
@@ -55,6 +56,13 @@ func buildJobCmd() *cobra.Command {
 			},
 			InitFlags: job.InitViewFlags,
 		},
+		"describe": {
+			Short: "show job information with scheduling timeline",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, job.DescribeJob(cmd.Context(), args))
+			},
+			InitFlags: job.InitDescribeFlags,
+		},
 		"suspend": {
 			Short: "abort a job",
 			RunFunction: func(cmd *cobra.Command, args []string) {
@@ -69,6 +77,20 @@ func buildJobCmd() *cobra.Command {
 			},
 			InitFlags: job.InitResumeFlags,
 		},
+		"hold": {
+			Short: "hold a job by the job-suspend annotation",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, job.HoldJob(cmd.Context()))
+			},
+			InitFlags: job.InitHoldFlags,
+		},
+		"release": {
+			Short: "release a job held by the job-suspend annotation",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, job.ReleaseJob(cmd.Context()))
+			},
+			InitFlags: job.InitReleaseFlags,
+		},
 		"delete": {
 			Short: "delete a job",
 			RunFunction: func(cmd *cobra.Command, args []string) {
@@ -76,6 +98,13 @@ func buildJobCmd() *cobra.Command {
 			},
 			InitFlags: job.InitDeleteFlags,
 		},
+		"validate": {
+			Short: "validate a job manifest locally",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, job.ValidateJob())
+			},
+			InitFlags: job.InitValidateFlags,
+		},
 	}
 
 	for command, config := range jobCommandMap {
@@ -85,6 +114,13 @@ func buildJobCmd() *cobra.Command {
 			Run:   config.RunFunction,
 		}
 		config.InitFlags(cmd)
+		if command != "run" {
+			cliutil.RegisterFlagCompletion(cmd, "name", cliutil.JobNameCompletion)
+		}
+		if command == "describe" {
+			cmd.ValidArgsFunction = cliutil.JobNameCompletion
+		}
+		cliutil.RegisterFlagCompletion(cmd, "queue", cliutil.QueueNameCompletion)
 		jobCmd.AddCommand(cmd)
 	}
 
@@ -69,6 +69,13 @@ func buildJobFlowCmd() *cobra.Command {
 			},
 			InitFlags: jobflow.InitDescribeFlags,
 		},
+		"vis": {
+			Short: "visualize the DAG of a jobflow",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, jobflow.VisJobFlow(cmd.Context()))
+			},
+			InitFlags: jobflow.InitVisFlags,
+		},
 	}
 
 	for command, config := range jobFlowCommandMap {
@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"volcano.sh/volcano/cmd/cli/util"
+	"volcano.sh/volcano/pkg/cli/node"
+)
+
+func buildNodeCmd() *cobra.Command {
+	nodeCmd := &cobra.Command{
+		Use:   "node",
+		Short: "vcctl command line operation node",
+	}
+
+	topCmd := &cobra.Command{
+		Use:   "top",
+		Short: "show allocated and allocatable resources, fragmentation and queues of nodes",
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckError(cmd, node.TopNodes(cmd.Context()))
+		},
+	}
+	node.InitTopFlags(topCmd)
+	nodeCmd.AddCommand(topCmd)
+
+	drainCmd := &cobra.Command{
+		Use:   "drain NODE",
+		Short: "cordon the node and evict the gangs with pods on it as a whole",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckError(cmd, node.DrainNode(cmd.Context(), args[0]))
+		},
+	}
+	node.InitDrainFlags(drainCmd)
+	nodeCmd.AddCommand(drainCmd)
+
+	return nodeCmd
+}
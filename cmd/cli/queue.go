@@ -21,6 +21,7 @@ import (
 
 	"volcano.sh/volcano/cmd/cli/util"
 	"volcano.sh/volcano/pkg/cli/queue"
+	cliutil "volcano.sh/volcano/pkg/cli/util"
 )
 
 func buildQueueCmd() *cobra.Command {
@@ -43,6 +44,14 @@ func buildQueueCmd() *cobra.Command {
 			},
 			InitFlags: queue.InitCreateFlags,
 		},
+		{
+			Use:   "update",
+			Short: "update the weight, parent, guarantee or capability of queue",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, queue.UpdateQueue(cmd.Context()))
+			},
+			InitFlags: queue.InitUpdateFlags,
+		},
 		{
 			Use:   "delete",
 			Short: "delete queue",
@@ -59,6 +68,14 @@ func buildQueueCmd() *cobra.Command {
 			},
 			InitFlags: queue.InitOperateFlags,
 		},
+		{
+			Use:   "reweight",
+			Short: "preview and change the weight of queue",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, queue.ReweightQueue(cmd.Context()))
+			},
+			InitFlags: queue.InitReweightFlags,
+		},
 		{
 			Use:   "list",
 			Short: "lists all the queue",
@@ -84,6 +101,10 @@ func buildQueueCmd() *cobra.Command {
 			Run:   command.RunFunction,
 		}
 		command.InitFlags(cmd)
+		if command.Use != "create" {
+			cliutil.RegisterFlagCompletion(cmd, "name", cliutil.QueueNameCompletion)
+		}
+		cliutil.RegisterFlagCompletion(cmd, "parent", cliutil.QueueNameCompletion)
 		queueCmd.AddCommand(cmd)
 	}
 
@@ -17,27 +17,38 @@ package main
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
 	"k8s.io/component-base/cli"
 
+	cliutil "volcano.sh/volcano/pkg/cli/util"
 	"volcano.sh/volcano/pkg/version"
 )
 
 func main() {
 	rootCmd := cobra.Command{
 		Use: "vcctl",
+		// the commands work in the namespace of the kubeconfig context if the namespace is not specified
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return cliutil.SetDefaultNamespace(cmd)
+		},
 	}
 
-	// tell Cobra not to provide the default completion command
-	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	// vcctl is invoked by kubectl as the plugin of "kubectl vc" when it is installed as kubectl-vc in the PATH
+	if strings.HasPrefix(filepath.Base(os.Args[0]), "kubectl-") {
+		rootCmd.Use = "vc"
+		rootCmd.Annotations = map[string]string{cobra.CommandDisplayNameAnnotation: "kubectl vc"}
+	}
 
 	rootCmd.AddCommand(buildJobCmd())
 	rootCmd.AddCommand(buildQueueCmd())
 	rootCmd.AddCommand(buildJobTemplateCmd())
 	rootCmd.AddCommand(buildJobFlowCmd())
 	rootCmd.AddCommand(buildPodCmd())
+	rootCmd.AddCommand(buildNodeCmd())
 	rootCmd.AddCommand(versionCommand())
 
 	code := cli.Run(&rootCmd)
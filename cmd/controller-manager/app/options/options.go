@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/util/errors"
@@ -43,6 +44,7 @@ const (
 	defaultPodGroupWorkers     = 5
 	defaultQueueWorkers        = 5
 	defaultGCWorkers           = 1
+	defaultJobStatusSyncPeriod = time.Second
 	defaultControllers         = "*"
 )
 
@@ -87,6 +89,12 @@ type ServerOption struct {
 	// WorkerThreadsForGC is the number of threads for recycling jobs
 	// The larger the number, the faster the job recycling, but requires more CPU load.
 	WorkerThreadsForGC uint32
+	// JobStatusSyncPeriod is the window in which the pod events of a job are coalesced into a single sync
+	// and status update of the job, 0 disables the coalescing.
+	JobStatusSyncPeriod time.Duration
+	// JobResourceLabels are the labels added to all the objects created by the job plugins, e.g. the services,
+	// configmaps and secrets, in addition to the labels propagated from the jobs.
+	JobResourceLabels map[string]string
 	// Controllers specify controllers to set up.
 	// Case1: Use '*' for all controllers,
 	// Case2: "+gc-controller,+job-controller,+jobflow-controller,+jobtemplate-controller,+pg-controller,+queue-controller"
@@ -130,6 +138,10 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet, knownControllers []string) {
 	fs.Uint32Var(&s.WorkerThreadsForPG, "worker-threads-for-podgroup", defaultPodGroupWorkers, "The number of threads syncing podgroup operations. The larger the number, the faster the podgroup processing, but requires more CPU load.")
 	fs.Uint32Var(&s.WorkerThreadsForGC, "worker-threads-for-gc", defaultGCWorkers, "The number of threads for recycling jobs. The larger the number, the faster the job recycling, but requires more CPU load.")
 	fs.Uint32Var(&s.WorkerThreadsForQueue, "worker-threads-for-queue", defaultQueueWorkers, "The number of threads syncing queue operations. The larger the number, the faster the queue processing, but requires more CPU load.")
+	fs.DurationVar(&s.JobStatusSyncPeriod, "job-status-sync-period", defaultJobStatusSyncPeriod, "The window in which the pod events of a job are coalesced into a single sync "+
+		"and status update of the job, which cuts the requests to apiserver of large jobs; 0 syncs the job on every pod event.")
+	fs.StringToStringVar(&s.JobResourceLabels, "job-resource-labels", nil, "The labels added to all the objects created by the job plugins, e.g. the services, "+
+		"configmaps and secrets, in addition to the labels propagated from the jobs, e.g. \"team=ml,cost-center=42\".")
 	fs.StringSliceVar(&s.Controllers, "controllers", []string{defaultControllers}, fmt.Sprintf("Specify controller gates. Use '*' for all controllers, all knownController: %s ,and we can use "+
 		"'-' to disable controllers, e.g. \"-job-controller,-queue-controller\" to disable job and queue controllers.", knownControllers))
 }
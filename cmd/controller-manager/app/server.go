@@ -148,6 +148,8 @@ func startControllers(config *rest.Config, opt *options.ServerOption) func(ctx c
 	controllerOpt.WorkerThreadsForPG = opt.WorkerThreadsForPG
 	controllerOpt.WorkerThreadsForQueue = opt.WorkerThreadsForQueue
 	controllerOpt.WorkerThreadsForGC = opt.WorkerThreadsForGC
+	controllerOpt.JobStatusSyncPeriod = opt.JobStatusSyncPeriod
+	controllerOpt.JobResourceLabels = opt.JobResourceLabels
 	controllerOpt.Config = config
 
 	return func(ctx context.Context) {
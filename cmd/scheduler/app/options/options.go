@@ -47,6 +47,7 @@ const (
 	defaultPercentageOfNodesToFind    = 0
 	defaultLockObjectNamespace        = "volcano-system"
 	defaultNodeWorkers                = 20
+	defaultPredicateWorkers           = 16
 )
 
 // ServerOption is the main context object for the controller manager.
@@ -83,6 +84,8 @@ type ServerOption struct {
 	MinNodesToFind             int32
 	MinPercentageOfNodesToFind int32
 	PercentageOfNodesToFind    int32
+	// PredicateWorkers is the number of workers checking the predicates of a task on nodes in parallel
+	PredicateWorkers int32
 
 	NodeSelector      []string
 	CacheDumpFileDir  string
@@ -93,6 +96,15 @@ type ServerOption struct {
 	// not be counted in pod pvc resource request and node.Allocatable, because the spec.drivers of csinode resource
 	// is always null, these provisioners usually are host path csi controllers like rancher.io/local-path and hostpath.csi.k8s.io.
 	IgnoredCSIProvisioners []string
+
+	// PlacementStore is the name of the store persisting the placement records of tasks, records are not
+	// persisted if empty.
+	PlacementStore string
+	// PlacementStoreDataSource is the data source of the placement store, e.g. the dsn of the database.
+	PlacementStoreDataSource string
+	// SchedulerShardName is the name of the scheduler shard the scheduler owns, only the podgroups assigned to
+	// the shard are scheduled if it is set.
+	SchedulerShardName string
 }
 
 // DecryptFunc is custom function to parse ca file
@@ -140,6 +152,9 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet) {
 	// The percentage of nodes that would be scored in each scheduling cycle; if <= 0, an adaptive percentage will be calculated
 	fs.Int32Var(&s.PercentageOfNodesToFind, "percentage-nodes-to-find", defaultPercentageOfNodesToFind, "The percentage of nodes to find and score, if <=0 will be calculated based on the cluster size")
 
+	// The number of workers checking the predicates of a task on nodes in parallel
+	fs.Int32Var(&s.PredicateWorkers, "predicate-workers", defaultPredicateWorkers, "The number of workers checking the predicates of a task on nodes in parallel")
+
 	fs.StringVar(&s.PluginsDir, "plugins-dir", defaultPluginsDir, "vc-scheduler will load custom plugins which are in this directory")
 	fs.BoolVar(&s.EnableCSIStorage, "csi-storage", false,
 		"Enable tracking of available storage capacity that CSI drivers provide; it is false by default")
@@ -151,6 +166,9 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.CacheDumpFileDir, "cache-dump-dir", "/tmp", "The target dir where the json file put at when dump cache info to json file")
 	fs.Uint32Var(&s.NodeWorkerThreads, "node-worker-threads", defaultNodeWorkers, "The number of threads syncing node operations.")
 	fs.StringSliceVar(&s.IgnoredCSIProvisioners, "ignored-provisioners", nil, "The provisioners that will be ignored during pod pvc request computation and preemption.")
+	fs.StringVar(&s.PlacementStore, "placement-store", "", "The name of the store registered to persist the placement records of tasks, e.g. postgres, or sqlite when built with the sqlite tag; records are not persisted if empty")
+	fs.StringVar(&s.PlacementStoreDataSource, "placement-store-dsn", "", "The data source name of the placement store")
+	fs.StringVar(&s.SchedulerShardName, "scheduler-shard-name", "", "The name of the scheduler shard the scheduler owns, only the podgroups with the same volcano.sh/scheduler-shard annotation (\"default\" if not set) are scheduled; usually set with --node-selector so that each shard owns a disjoint node pool")
 }
 
 // CheckOptionOrDie check leader election flag when LeaderElection is enabled.
@@ -82,6 +82,7 @@ func TestAddFlags(t *testing.T) {
 		MinPercentageOfNodesToFind: defaultMinPercentageOfNodesToFind,
 		PercentageOfNodesToFind:    defaultPercentageOfNodesToFind,
 		NodeWorkerThreads:          defaultNodeWorkers,
+		PredicateWorkers:           defaultPredicateWorkers,
 		CacheDumpFileDir:           "/tmp",
 	}
 	expectedFeatureGates := map[featuregate.Feature]bool{
@@ -149,6 +149,9 @@ func startMetricsServer(opt *options.ServerOption) {
 		mux.Handle("/metrics", commonutil.PromHandler())
 	}
 
+	// The tasks pipelined in the last session, waiting for the resources of victims to be released.
+	mux.Handle("/debug/pipelined", framework.PipelinedHandler())
+
 	if opt.EnablePprof {
 		mux.HandleFunc("/debug/pprof/", pprof.Index)
 		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
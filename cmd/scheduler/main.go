@@ -38,6 +38,9 @@ import (
 	_ "volcano.sh/volcano/pkg/scheduler/actions"
 	_ "volcano.sh/volcano/pkg/scheduler/plugins"
 
+	// Import placement stores.
+	_ "volcano.sh/volcano/pkg/scheduler/placement/stores"
+
 	// init assert
 	_ "volcano.sh/volcano/pkg/scheduler/util/assert"
 )
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The simulator replays the job arrival trace against the recorded cluster snapshot with the scheduler in-process,
+// and reports the scheduling throughput and the cycle latency. It exits with 1 if the report violates the thresholds.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/simulator"
+)
+
+func main() {
+	klog.InitFlags(nil)
+
+	fs := pflag.CommandLine
+	s := options.NewServerOption()
+	s.AddFlags(fs)
+	s.RegisterOptions()
+
+	var (
+		snapshotPath string
+		tracePath    string
+		baselinePath string
+		output       string
+		maxCycles    int
+		thresholds   simulator.Thresholds
+	)
+	fs.StringVar(&snapshotPath, "snapshot", "", "The cluster snapshot, a List of the nodes, queues, podgroups and pods, e.g. the output of `kubectl get nodes,queues,podgroups,pods -A -o yaml`")
+	fs.StringVar(&tracePath, "trace", "", "The trace of the jobs arriving at the cluster")
+	fs.StringVar(&output, "output", "text", "The format of the report, text or json")
+	fs.IntVar(&maxCycles, "max-cycles", 10000, "The maximal number of the cycles replayed, 0 for unlimited")
+	fs.Float64Var(&thresholds.MinThroughput, "min-throughput", 0, "The minimal pods scheduled per second of the scheduling time, not checked if 0")
+	fs.DurationVar(&thresholds.MaxP99CycleLatency, "max-p99-cycle-latency", 0, "The maximal p99 latency of the scheduling cycles, not checked if 0")
+	fs.IntVar(&thresholds.MaxPodsPending, "max-pods-pending", -1, "The maximal number of the pods pending at the end of the replay, not checked if negative")
+	fs.StringVar(&baselinePath, "baseline", "", "The json report of the baseline, the throughput and the p99 cycle latency are compared with it if set")
+	fs.Float64Var(&thresholds.Tolerance, "tolerance", 0.2, "The relative regression of the throughput and the p99 cycle latency allowed against the baseline")
+
+	cliflag.InitFlags()
+
+	if snapshotPath == "" || tracePath == "" {
+		fmt.Fprintln(os.Stderr, "both --snapshot and --trace are required")
+		os.Exit(2)
+	}
+	// the schedule period and the scheduler configuration are given by the flags of the scheduler
+	simulatorOptions := simulator.Options{Period: s.SchedulePeriod, MaxCycles: maxCycles}
+	if s.SchedulerConf != "" {
+		data, err := os.ReadFile(s.SchedulerConf)
+		if err != nil {
+			klog.Fatalf("Failed to read scheduler configuration: %v", err)
+		}
+		simulatorOptions.SchedulerConf = string(data)
+	}
+
+	snapshot, err := simulator.LoadSnapshot(snapshotPath)
+	if err != nil {
+		klog.Fatalf("Failed to load snapshot: %v", err)
+	}
+	trace, err := simulator.LoadTrace(tracePath)
+	if err != nil {
+		klog.Fatalf("Failed to load trace: %v", err)
+	}
+	if baselinePath != "" {
+		if thresholds.Baseline, err = simulator.LoadReport(baselinePath); err != nil {
+			klog.Fatalf("Failed to load baseline: %v", err)
+		}
+	}
+	sim, err := simulator.New(simulatorOptions, snapshot, trace)
+	if err != nil {
+		klog.Fatalf("Failed to create simulator: %v", err)
+	}
+	report, err := sim.Run()
+	if err != nil {
+		klog.Fatalf("Failed to replay trace: %v", err)
+	}
+	klog.Flush()
+
+	if output == "json" {
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		fmt.Println(report)
+	}
+	if err := thresholds.Check(report); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/spf13/pflag"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 
 	"volcano.sh/volcano/pkg/kube"
 )
@@ -32,7 +33,9 @@ const (
 	defaultBurst                = 100
 	defaultEnabledAdmission     = "/jobs/mutate,/jobs/validate,/podgroups/mutate,/pods/validate,/pods/mutate,/queues/mutate,/queues/validate"
 	defaultHealthzAddress       = ":11251"
+	defaultMetricsAddress       = ":8080"
 	defaultGracefulShutdownTime = time.Second * 30
+	defaultWebhookResyncPeriod  = time.Minute * 5
 )
 
 // Config admission-controller server config.
@@ -54,11 +57,20 @@ type Config struct {
 	ConfigPath           string
 	EnabledAdmission     string
 	GracefulShutdownTime time.Duration
+	// WebhookFailurePolicy is the failurePolicy expected of the webhook configurations, not checked if empty
+	WebhookFailurePolicy string
+	// WebhookResyncPeriod is the period to patch the drifted webhook configurations, disabled if zero
+	WebhookResyncPeriod time.Duration
 
 	EnableHealthz bool
 	// HealthzBindAddress is the IP address and port for the health check server to serve on
 	// defaulting to :11251
 	HealthzBindAddress string
+
+	EnableMetrics bool
+	// MetricsBindAddress is the IP address and port for the prometheus metrics server to serve on
+	// defaulting to :8080
+	MetricsBindAddress string
 }
 
 type DecryptFunc func(c *Config) error
@@ -91,6 +103,10 @@ func (c *Config) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.ConfigPath, "admission-conf", "", "The configmap file of this webhook")
 	fs.BoolVar(&c.EnableHealthz, "enable-healthz", false, "Enable the health check; it is false by default")
 	fs.StringVar(&c.HealthzBindAddress, "healthz-address", defaultHealthzAddress, "The address to listen on for the health check server.")
+	fs.BoolVar(&c.EnableMetrics, "enable-metrics", false, "Enable the metrics function; it is false by default")
+	fs.StringVar(&c.MetricsBindAddress, "metrics-address", defaultMetricsAddress, "The address to listen on for the prometheus metrics server.")
+	fs.StringVar(&c.WebhookFailurePolicy, "webhook-failure-policy", "", "The failurePolicy expected of the webhook configurations, Fail or Ignore; it is not checked nor patched if empty")
+	fs.DurationVar(&c.WebhookResyncPeriod, "webhook-resync-period", defaultWebhookResyncPeriod, "The period to check and patch the drifted caBundle and failurePolicy of the webhook configurations; 0 disables it")
 	fs.DurationVar(&c.GracefulShutdownTime, "graceful-shutdown-time", defaultGracefulShutdownTime, "The duration to wait during graceful shutdown before forcing termination.")
 }
 
@@ -102,6 +118,15 @@ func (c *Config) CheckPortOrDie() error {
 	return nil
 }
 
+// CheckWebhookFailurePolicy check valid failurePolicy of webhooks.
+func (c *Config) CheckWebhookFailurePolicy() error {
+	switch c.WebhookFailurePolicy {
+	case "", string(admissionregistrationv1.Fail), string(admissionregistrationv1.Ignore):
+		return nil
+	}
+	return fmt.Errorf("the webhook failure policy should be %s or %s", admissionregistrationv1.Fail, admissionregistrationv1.Ignore)
+}
+
 // readCAFiles read data from ca file path
 func (c *Config) readCAFiles() error {
 	var err error
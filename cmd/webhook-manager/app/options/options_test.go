@@ -56,8 +56,10 @@ func TestAddFlags(t *testing.T) {
 		ConfigPath:           "",
 		EnabledAdmission:     defaultEnabledAdmission,
 		GracefulShutdownTime: defaultGracefulShutdownTime,
+		WebhookResyncPeriod:  defaultWebhookResyncPeriod,
 		EnableHealthz:        false,
 		HealthzBindAddress:   defaultHealthzAddress,
+		MetricsBindAddress:   defaultMetricsAddress,
 	}
 
 	if !equality.Semantic.DeepEqual(expected, s) {
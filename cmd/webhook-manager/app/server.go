@@ -22,19 +22,28 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	k8sinformers "k8s.io/client-go/informers"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	schedulinglisters "k8s.io/client-go/listers/scheduling/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
 	"volcano.sh/apis/pkg/apis/helpers"
 	"volcano.sh/apis/pkg/apis/scheduling/scheme"
 	informers "volcano.sh/apis/pkg/client/informers/externalversions"
+	batchlister "volcano.sh/apis/pkg/client/listers/batch/v1alpha1"
 	"volcano.sh/volcano/cmd/webhook-manager/app/options"
 	"volcano.sh/volcano/pkg/kube"
 	"volcano.sh/volcano/pkg/signals"
 	commonutil "volcano.sh/volcano/pkg/util"
+	jobvalidate "volcano.sh/volcano/pkg/webhooks/admission/jobs/validate"
+	queuevalidate "volcano.sh/volcano/pkg/webhooks/admission/queues/validate"
 	wkconfig "volcano.sh/volcano/pkg/webhooks/config"
 	"volcano.sh/volcano/pkg/webhooks/router"
 )
@@ -47,6 +56,22 @@ func Run(config *options.Config) error {
 		}
 	}
 
+	if config.EnableMetrics {
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", commonutil.PromHandler())
+
+			server := &http.Server{
+				Addr:              config.MetricsBindAddress,
+				Handler:           mux,
+				ReadHeaderTimeout: helpers.DefaultReadHeaderTimeout,
+				ReadTimeout:       helpers.DefaultReadTimeout,
+				WriteTimeout:      helpers.DefaultWriteTimeout,
+			}
+			klog.Fatalf("Prometheus Http Server failed: %s", server.ListenAndServe())
+		}()
+	}
+
 	if config.WebhookURL == "" && config.WebhookNamespace == "" && config.WebhookName == "" {
 		return fmt.Errorf("failed to start webhooks as both 'url' and 'namespace/name' of webhook are empty")
 	}
@@ -65,18 +90,61 @@ func Run(config *options.Config) error {
 
 	vClient := getVolcanoClient(restConfig)
 	kubeClient := getKubeClient(restConfig)
+	dynamicClient := getDynamicClient(restConfig)
 	factory := informers.NewSharedInformerFactory(vClient, 0)
 	queueInformer := factory.Scheduling().V1beta1().Queues()
 	queueLister := queueInformer.Lister()
 
+	// the jobs, job policies, nodes, service accounts and priority classes are only watched by the job validating
+	// webhook, which checks the job policies, the preflight, the service accounts and the priority classes of the jobs
+	var jobLister batchlister.JobLister
+	var nodeLister corelisters.NodeLister
+	var serviceAccountLister corelisters.ServiceAccountLister
+	var priorityClassLister schedulinglisters.PriorityClassLister
+	var policyInformer k8sinformers.GenericInformer
+	kubeFactory := k8sinformers.NewSharedInformerFactory(kubeClient, 0)
+	dynamicFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 0)
+	if strings.Contains(config.EnabledAdmission, "/jobs/validate") {
+		jobLister = factory.Batch().V1alpha1().Jobs().Lister()
+		nodeLister = kubeFactory.Core().V1().Nodes().Lister()
+		serviceAccountLister = kubeFactory.Core().V1().ServiceAccounts().Lister()
+		priorityClassLister = kubeFactory.Scheduling().V1().PriorityClasses().Lister()
+		policyInformer = dynamicFactory.ForResource(jobvalidate.JobPolicyResource)
+	}
+	// the namespaces are watched by the mutating webhooks, which default the queues from the namespaces, and by the
+	// job validating webhook, which checks the PodSecurity level of the namespaces
+	var namespaceLister corelisters.NamespaceLister
+	if strings.Contains(config.EnabledAdmission, "/jobs/mutate") || strings.Contains(config.EnabledAdmission, "/podgroups/mutate") ||
+		strings.Contains(config.EnabledAdmission, "/pods/mutate") || strings.Contains(config.EnabledAdmission, "/jobs/validate") {
+		namespaceLister = kubeFactory.Core().V1().Namespaces().Lister()
+	}
+
+	// the podgroups are watched by the queue validating webhook, which denies deleting the queues with active podgroups
+	var podGroupIndexer cache.Indexer
+	if strings.Contains(config.EnabledAdmission, "/queues/validate") {
+		podGroupInformer := factory.Scheduling().V1beta1().PodGroups().Informer()
+		if err := podGroupInformer.AddIndexers(cache.Indexers{queuevalidate.PodGroupQueueIndex: queuevalidate.PodGroupQueueIndexFunc}); err != nil {
+			return fmt.Errorf("failed to add the queue index of podgroups: %v", err)
+		}
+		podGroupIndexer = podGroupInformer.GetIndexer()
+	}
+
 	broadcaster := record.NewBroadcaster()
 	broadcaster.StartRecordingToSink(&corev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
 	recorder := broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: commonutil.GenerateComponentName(config.SchedulerNames)})
+	checker := newAdmissionChecker(kubeClient, config.CaCertData, config.CertData, config.WebhookFailurePolicy)
 	if err := router.ForEachAdmission(config, func(service *router.AdmissionService) error {
 		if service.Config != nil {
 			service.Config.VolcanoClient = vClient
 			service.Config.KubeClient = kubeClient
 			service.Config.QueueLister = queueLister
+			service.Config.JobLister = jobLister
+			service.Config.NodeLister = nodeLister
+			service.Config.NamespaceLister = namespaceLister
+			service.Config.ServiceAccountLister = serviceAccountLister
+			service.Config.PriorityClassLister = priorityClassLister
+			service.Config.PodGroupIndexer = podGroupIndexer
+			service.Config.PolicyInformer = policyInformer
 			service.Config.SchedulerNames = config.SchedulerNames
 			service.Config.Recorder = recorder
 			service.Config.ConfigData = admissionConf
@@ -86,15 +154,17 @@ func Run(config *options.Config) error {
 		http.HandleFunc(service.Path, service.Handler)
 
 		klog.V(3).Infof("Add CaCert for webhook <%s>", service.Path)
-		if err = addCaCertForWebhook(kubeClient, service, config.CaCertData); err != nil {
+		if err = addCaCertForWebhook(kubeClient, service, config.CaCertData, config.WebhookFailurePolicy); err != nil {
 			return fmt.Errorf("failed to add caCert for webhook %v", err)
 		}
+		checker.addService(service)
 		return nil
 	}); err != nil {
 		return err
 	}
 
 	klog.V(3).Infof("Successfully added caCert for all webhooks")
+	http.Handle(admissionHealthzPath, checker)
 
 	webhookServeError := make(chan struct{})
 	ctx := signals.SetupSignalContext()
@@ -105,6 +175,14 @@ func Run(config *options.Config) error {
 			return fmt.Errorf("failed to sync cache: %v", informerType)
 		}
 	}
+	kubeFactory.Start(webhookServeError)
+	for informerType, ok := range kubeFactory.WaitForCacheSync(webhookServeError) {
+		if !ok {
+			return fmt.Errorf("failed to sync cache: %v", informerType)
+		}
+	}
+	// the job policies are not waited for, as the CRD may not be installed, the policies are not checked until synced
+	dynamicFactory.Start(webhookServeError)
 
 	server := &http.Server{
 		Addr:              config.ListenAddress + ":" + strconv.Itoa(config.Port),
@@ -123,6 +201,10 @@ func Run(config *options.Config) error {
 		klog.Info("Volcano Webhook manager stopped.")
 	}()
 
+	if config.WebhookResyncPeriod > 0 {
+		go checker.run(config.WebhookResyncPeriod, ctx.Done())
+	}
+
 	if config.ConfigPath != "" {
 		go wkconfig.WatchAdmissionConf(config.ConfigPath, ctx.Done())
 	}
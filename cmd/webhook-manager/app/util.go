@@ -29,6 +29,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
@@ -40,76 +41,91 @@ import (
 
 const volcanoAdmissionPrefix = "volcano-admission-service"
 
-func addCaCertForWebhook(kubeClient *kubernetes.Clientset, service *router.AdmissionService, caBundle []byte) error {
+// addCaCertForWebhook waits for the webhook configurations of service to be created, and patches their caBundle
+// and failurePolicy.
+func addCaCertForWebhook(kubeClient kubernetes.Interface, service *router.AdmissionService, caBundle []byte, failurePolicy string) error {
+	return wait.PollUntilContextTimeout(context.Background(), time.Second, 5*time.Minute, true, func(_ context.Context) (done bool, err error) {
+		if err := reconcileWebhookConfiguration(kubeClient, service, caBundle, failurePolicy); err != nil {
+			if apierrors.IsNotFound(err) {
+				klog.Errorln(err)
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+// reconcileWebhookConfiguration patches the webhook configurations of service whose caBundle or failurePolicy drift
+// from the configured ones, the failurePolicy is not patched if it is not configured.
+func reconcileWebhookConfiguration(kubeClient kubernetes.Interface, service *router.AdmissionService, caBundle []byte, failurePolicy string) error {
+	webhookName := webhookConfigurationName(service)
 	if service.MutatingConfig != nil {
 		// update MutatingWebhookConfigurations
-		var mutatingWebhookName = volcanoAdmissionPrefix + strings.ReplaceAll(service.Path, "/", "-")
-		var mutatingWebhook *v1.MutatingWebhookConfiguration
-		webhookChanged := false
-		if err := wait.PollUntilContextTimeout(context.Background(), time.Second, 5*time.Minute, true, func(_ context.Context) (done bool, err error) {
-			mutatingWebhook, err = kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(), mutatingWebhookName, metav1.GetOptions{})
-			if err != nil {
-				if apierrors.IsNotFound(err) {
-					klog.Errorln(err)
-					return false, nil
-				}
-				return false, fmt.Errorf("failed to get mutating webhook %v", err)
-			}
-			return true, nil
-		}); err != nil {
-			return fmt.Errorf("failed to get mutating webhook %v", err)
+		mutatingWebhook, err := kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(), webhookName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get mutating webhook %w", err)
 		}
 
-		for index := 0; index < len(mutatingWebhook.Webhooks); index++ {
-			if mutatingWebhook.Webhooks[index].ClientConfig.CABundle == nil ||
-				!bytes.Equal(mutatingWebhook.Webhooks[index].ClientConfig.CABundle, caBundle) {
-				mutatingWebhook.Webhooks[index].ClientConfig.CABundle = caBundle
+		webhookChanged := false
+		for index := range mutatingWebhook.Webhooks {
+			webhook := &mutatingWebhook.Webhooks[index]
+			if fixWebhook(&webhook.ClientConfig, &webhook.FailurePolicy, caBundle, failurePolicy) {
 				webhookChanged = true
 			}
 		}
 		if webhookChanged {
 			if _, err := kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Update(context.TODO(), mutatingWebhook, metav1.UpdateOptions{}); err != nil {
-				return fmt.Errorf("failed to update mutating admission webhooks %v %v", mutatingWebhookName, err)
+				return fmt.Errorf("failed to update mutating admission webhooks %v %v", webhookName, err)
 			}
+			klog.Infof("Patched caBundle and failurePolicy of mutating admission webhooks %v", webhookName)
 		}
 	}
 
 	if service.ValidatingConfig != nil {
 		// update ValidatingWebhookConfigurations
-		var validatingWebhookName = volcanoAdmissionPrefix + strings.ReplaceAll(service.Path, "/", "-")
-		var validatingWebhook *v1.ValidatingWebhookConfiguration
-		webhookChanged := false
-		if err := wait.PollUntilContextTimeout(context.Background(), time.Second, 5*time.Minute, true, func(_ context.Context) (done bool, err error) {
-			validatingWebhook, err = kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(context.TODO(), validatingWebhookName, metav1.GetOptions{})
-			if err != nil {
-				if apierrors.IsNotFound(err) {
-					klog.Errorln(err)
-					return false, nil
-				}
-				return false, fmt.Errorf("failed to get validating webhook %v", err)
-			}
-			return true, nil
-		}); err != nil {
-			return fmt.Errorf("failed to get validating webhook %v", err)
+		validatingWebhook, err := kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(context.TODO(), webhookName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get validating webhook %w", err)
 		}
 
-		for index := 0; index < len(validatingWebhook.Webhooks); index++ {
-			if validatingWebhook.Webhooks[index].ClientConfig.CABundle == nil ||
-				!bytes.Equal(validatingWebhook.Webhooks[index].ClientConfig.CABundle, caBundle) {
-				validatingWebhook.Webhooks[index].ClientConfig.CABundle = caBundle
+		webhookChanged := false
+		for index := range validatingWebhook.Webhooks {
+			webhook := &validatingWebhook.Webhooks[index]
+			if fixWebhook(&webhook.ClientConfig, &webhook.FailurePolicy, caBundle, failurePolicy) {
 				webhookChanged = true
 			}
 		}
 		if webhookChanged {
 			if _, err := kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(context.TODO(), validatingWebhook, metav1.UpdateOptions{}); err != nil {
-				return fmt.Errorf("failed to update validating admission webhooks %v %v", validatingWebhookName, err)
+				return fmt.Errorf("failed to update validating admission webhooks %v %v", webhookName, err)
 			}
+			klog.Infof("Patched caBundle and failurePolicy of validating admission webhooks %v", webhookName)
 		}
 	}
 
 	return nil
 }
 
+// fixWebhook sets the caBundle and the failurePolicy of webhook, it returns true if any of them is changed.
+func fixWebhook(clientConfig *v1.WebhookClientConfig, failurePolicy **v1.FailurePolicyType, caBundle []byte, expectedPolicy string) bool {
+	changed := false
+	if clientConfig.CABundle == nil || !bytes.Equal(clientConfig.CABundle, caBundle) {
+		clientConfig.CABundle = caBundle
+		changed = true
+	}
+	if expectedPolicy != "" && (*failurePolicy == nil || string(**failurePolicy) != expectedPolicy) {
+		policy := v1.FailurePolicyType(expectedPolicy)
+		*failurePolicy = &policy
+		changed = true
+	}
+	return changed
+}
+
+func webhookConfigurationName(service *router.AdmissionService) string {
+	return volcanoAdmissionPrefix + strings.ReplaceAll(service.Path, "/", "-")
+}
+
 // getKubeClient Get a clientset with restConfig.
 func getKubeClient(restConfig *rest.Config) *kubernetes.Clientset {
 	clientset, err := kubernetes.NewForConfig(restConfig)
@@ -128,6 +144,15 @@ func getVolcanoClient(restConfig *rest.Config) *versioned.Clientset {
 	return clientset
 }
 
+// getDynamicClient get a dynamic client for the resources without typed clientset.
+func getDynamicClient(restConfig *rest.Config) *dynamic.DynamicClient {
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	return client
+}
+
 // configTLS is a helper function that generate tls certificates from directly defined tls config or kubeconfig
 // These are passed in as command line for cluster certification. If tls config is passed in, we use the directly
 // defined tls config, else use that defined in kubeconfig.
@@ -0,0 +1,253 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"time"
+
+	v1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/webhooks/router"
+)
+
+const (
+	// admissionHealthzPath is the path of the self check of webhook configurations.
+	admissionHealthzPath = "/healthz/admission"
+
+	probeTimeout = 5 * time.Second
+)
+
+// webhookCheckResult is the result of the self check of a webhook.
+type webhookCheckResult struct {
+	Path    string   `json:"path"`
+	Webhook string   `json:"webhook,omitempty"`
+	Healthy bool     `json:"healthy"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// admissionChecker checks that the webhook configurations of the admission services are reachable and consistent
+// with the serving certificate and the configuration, and patches the drifted ones.
+type admissionChecker struct {
+	kubeClient    kubernetes.Interface
+	services      []*router.AdmissionService
+	caBundle      []byte
+	certData      []byte
+	failurePolicy string
+}
+
+func newAdmissionChecker(kubeClient kubernetes.Interface, caBundle, certData []byte, failurePolicy string) *admissionChecker {
+	return &admissionChecker{
+		kubeClient:    kubeClient,
+		caBundle:      caBundle,
+		certData:      certData,
+		failurePolicy: failurePolicy,
+	}
+}
+
+// addService adds the admission service to check.
+func (c *admissionChecker) addService(service *router.AdmissionService) {
+	c.services = append(c.services, service)
+}
+
+// run patches the drifted webhook configurations periodically until stopCh is closed.
+func (c *admissionChecker) run(period time.Duration, stopCh <-chan struct{}) {
+	wait.Until(func() {
+		for _, service := range c.services {
+			if err := reconcileWebhookConfiguration(c.kubeClient, service, c.caBundle, c.failurePolicy); err != nil {
+				klog.Errorf("Failed to reconcile webhook configuration of <%s>: %v", service.Path, err)
+			}
+		}
+	}, period, stopCh)
+}
+
+// ServeHTTP responds the results of the self check, with status 503 if any webhook is unhealthy.
+func (c *admissionChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	results := c.check(r.Context())
+	status := http.StatusOK
+	for _, result := range results {
+		if !result.Healthy {
+			status = http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		klog.Errorf("Failed to write admission health check result: %v", err)
+	}
+}
+
+// check checks the webhooks of all admission services.
+func (c *admissionChecker) check(ctx context.Context) []webhookCheckResult {
+	var results []webhookCheckResult
+	for _, service := range c.services {
+		name := webhookConfigurationName(service)
+		if service.MutatingConfig != nil {
+			configuration, err := c.kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				results = append(results, webhookCheckResult{Path: service.Path, Errors: []string{err.Error()}})
+			} else {
+				for _, webhook := range configuration.Webhooks {
+					results = append(results, c.checkWebhook(ctx, service.Path, webhook.Name, webhook.ClientConfig, webhook.FailurePolicy))
+				}
+			}
+		}
+		if service.ValidatingConfig != nil {
+			configuration, err := c.kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				results = append(results, webhookCheckResult{Path: service.Path, Errors: []string{err.Error()}})
+			} else {
+				for _, webhook := range configuration.Webhooks {
+					results = append(results, c.checkWebhook(ctx, service.Path, webhook.Name, webhook.ClientConfig, webhook.FailurePolicy))
+				}
+			}
+		}
+	}
+	return results
+}
+
+func (c *admissionChecker) checkWebhook(ctx context.Context, path, name string, clientConfig v1.WebhookClientConfig,
+	failurePolicy *v1.FailurePolicyType) webhookCheckResult {
+	result := webhookCheckResult{Path: path, Webhook: name}
+
+	if !bytes.Equal(clientConfig.CABundle, c.caBundle) {
+		result.Errors = append(result.Errors, "caBundle does not match the CA of the webhook manager")
+	}
+	if err := verifyServingCert(c.certData, clientConfig.CABundle); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("serving certificate is not signed by caBundle: %v", err))
+	}
+	if c.failurePolicy != "" && (failurePolicy == nil || string(*failurePolicy) != c.failurePolicy) {
+		actual := "<nil>"
+		if failurePolicy != nil {
+			actual = string(*failurePolicy)
+		}
+		result.Errors = append(result.Errors, fmt.Sprintf("failurePolicy is %s, expected %s", actual, c.failurePolicy))
+	}
+	if err := probeWebhook(ctx, clientConfig); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("webhook is not reachable: %v", err))
+	}
+
+	result.Healthy = len(result.Errors) == 0
+	return result
+}
+
+// verifyServingCert verifies that the serving certificate is signed by the caBundle.
+func verifyServingCert(certData, caBundle []byte) error {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caBundle) {
+		return fmt.Errorf("no certificate found in caBundle")
+	}
+
+	var certs []*x509.Certificate
+	for rest := certData; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return fmt.Errorf("no serving certificate found")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	return err
+}
+
+// probeWebhook sends an empty admission review to the webhook the way the apiserver calls it, i.e. through the
+// Service or the URL and trusting the caBundle only.
+func probeWebhook(ctx context.Context, clientConfig v1.WebhookClientConfig) error {
+	url, serverName, err := webhookURL(clientConfig)
+	if err != nil {
+		return err
+	}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(clientConfig.CABundle)
+	client := &http.Client{
+		Timeout: probeTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    roots,
+				ServerName: serverName,
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBufferString("{}"))
+	if err != nil {
+		return err
+	}
+	request.Header.Set(router.CONTENTTYPE, router.APPLICATIONJSON)
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", response.Status)
+	}
+	return nil
+}
+
+// webhookURL returns the url and the server name of the webhook called by the apiserver.
+func webhookURL(clientConfig v1.WebhookClientConfig) (string, string, error) {
+	if clientConfig.URL != nil {
+		return *clientConfig.URL, "", nil
+	}
+	if clientConfig.Service == nil {
+		return "", "", fmt.Errorf("neither url nor service is set in clientConfig")
+	}
+
+	service := clientConfig.Service
+	port := int32(443)
+	if service.Port != nil {
+		port = *service.Port
+	}
+	path := ""
+	if service.Path != nil {
+		path = *service.Path
+	}
+	host := fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+	return fmt.Sprintf("https://%s:%d%s", host, port, path), host, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"volcano.sh/volcano/pkg/webhooks/router"
+)
+
+func TestAdmissionChecker(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	// the certificate of the test server is self signed, which is the CA as well
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	service := &router.AdmissionService{
+		Path:             "/jobs/validate",
+		ValidatingConfig: &v1.ValidatingWebhookConfiguration{},
+	}
+	url := server.URL + service.Path
+
+	testCases := []struct {
+		name          string
+		caBundle      []byte
+		failurePolicy *v1.FailurePolicyType
+		url           string
+		expectHealthy bool
+	}{
+		{
+			name:          "healthy",
+			caBundle:      caBundle,
+			failurePolicy: ptrFailurePolicy(v1.Fail),
+			url:           url,
+			expectHealthy: true,
+		},
+		{
+			name:          "caBundle drifted",
+			caBundle:      []byte("stale"),
+			failurePolicy: ptrFailurePolicy(v1.Fail),
+			url:           url,
+		},
+		{
+			name:          "failurePolicy drifted",
+			caBundle:      caBundle,
+			failurePolicy: ptrFailurePolicy(v1.Ignore),
+			url:           url,
+		},
+		{
+			name:          "webhook not reachable",
+			caBundle:      caBundle,
+			failurePolicy: ptrFailurePolicy(v1.Fail),
+			url:           "https://127.0.0.1:1" + service.Path,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeClient := fake.NewSimpleClientset(&v1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: webhookConfigurationName(service)},
+				Webhooks: []v1.ValidatingWebhook{{
+					Name:          "validatejob.volcano.sh",
+					ClientConfig:  v1.WebhookClientConfig{URL: &tc.url, CABundle: tc.caBundle},
+					FailurePolicy: tc.failurePolicy,
+				}},
+			})
+			checker := newAdmissionChecker(kubeClient, caBundle, caBundle, string(v1.Fail))
+			checker.addService(service)
+
+			results := checker.check(context.TODO())
+			if len(results) != 1 || results[0].Healthy != tc.expectHealthy {
+				t.Fatalf("expected healthy %v, got %+v", tc.expectHealthy, results)
+			}
+
+			recorder := httptest.NewRecorder()
+			checker.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, admissionHealthzPath, nil))
+			if (recorder.Code == http.StatusOK) != tc.expectHealthy {
+				t.Errorf("expected healthy %v, got status %d", tc.expectHealthy, recorder.Code)
+			}
+
+			// the drifted caBundle and failurePolicy are patched
+			if err := reconcileWebhookConfiguration(kubeClient, service, caBundle, string(v1.Fail)); err != nil {
+				t.Fatalf("failed to reconcile webhook configuration: %v", err)
+			}
+			results = checker.check(context.TODO())
+			if tc.url == url && !results[0].Healthy {
+				t.Errorf("expected healthy after reconciled, got %+v", results)
+			}
+		})
+	}
+}
+
+func TestWebhookURL(t *testing.T) {
+	path := "/jobs/mutate"
+	url, serverName, err := webhookURL(v1.WebhookClientConfig{
+		Service: &v1.ServiceReference{Name: "volcano-admission-service", Namespace: "volcano-system", Path: &path},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if url != "https://volcano-admission-service.volcano-system.svc:443/jobs/mutate" ||
+		serverName != "volcano-admission-service.volcano-system.svc" {
+		t.Errorf("unexpected url %s and server name %s", url, serverName)
+	}
+	if _, _, err := webhookURL(v1.WebhookClientConfig{}); err == nil {
+		t.Errorf("expected error for clientConfig without url and service")
+	}
+}
+
+func ptrFailurePolicy(policy v1.FailurePolicyType) *v1.FailurePolicyType {
+	return &policy
+}
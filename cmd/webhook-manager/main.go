@@ -25,6 +25,7 @@ import (
 	_ "go.uber.org/automaxprocs"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	cliflag "k8s.io/component-base/cli/flag"
 	"k8s.io/klog/v2"
 
@@ -52,6 +53,7 @@ func main() {
 
 	config := options.NewConfig()
 	config.AddFlags(pflag.CommandLine)
+	utilfeature.DefaultMutableFeatureGate.AddFlag(pflag.CommandLine)
 
 	cliflag.InitFlags()
 
@@ -67,6 +69,10 @@ func main() {
 		klog.Fatalf("Configured port is invalid: %v", err)
 	}
 
+	if err := config.CheckWebhookFailurePolicy(); err != nil {
+		klog.Fatalf("Configured webhook failure policy is invalid: %v", err)
+	}
+
 	if err := config.ParseCAFiles(nil); err != nil {
 		klog.Fatalf("Failed to parse CA file: %v", err)
 	}
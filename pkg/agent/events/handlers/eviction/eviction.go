@@ -20,10 +20,15 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 
+	v1 "k8s.io/api/core/v1"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
 	"k8s.io/klog/v2"
+	v1qos "k8s.io/kubernetes/pkg/apis/core/v1/helper/qos"
 
 	"volcano.sh/volcano/pkg/agent/apis"
+	"volcano.sh/volcano/pkg/agent/apis/extension"
 	"volcano.sh/volcano/pkg/agent/config/api"
 	"volcano.sh/volcano/pkg/agent/events/framework"
 	"volcano.sh/volcano/pkg/agent/events/handlers"
@@ -48,6 +53,7 @@ type manager struct {
 	policy.Interface
 	getNodeFunc utilnode.ActiveNode
 	getPodsFunc utilpod.ActivePods
+	gangChecker eviction.GangChecker
 }
 
 func NewManager(config *config.Configuration, mgr *metriccollect.MetricCollectorManager, cgroupMgr cgroup.CgroupManager) framework.Handle {
@@ -59,6 +65,9 @@ func NewManager(config *config.Configuration, mgr *metriccollect.MetricCollector
 		getNodeFunc: config.GetNode,
 		getPodsFunc: config.GetActivePods,
 	}
+	if config.InformerFactory != nil && config.InformerFactory.VCInformerFactory != nil {
+		m.gangChecker = eviction.NewGangChecker(config.InformerFactory.VCInformerFactory.Scheduling().V1beta1().PodGroups().Lister())
+	}
 	return m
 }
 
@@ -87,7 +96,7 @@ func (m *manager) Handle(event interface{}) error {
 			return err
 		}
 
-		for _, pod := range preemptablePods {
+		for _, pod := range m.orderEvictionCandidates(preemptablePods) {
 			if err = m.DisableSchedule(); err != nil {
 				klog.ErrorS(err, "Failed to add eviction annotation")
 			}
@@ -102,6 +111,59 @@ func (m *manager) Handle(event interface{}) error {
 	return nil
 }
 
+// qosTiers is the eviction order of the kubernetes qos classes, best-effort pods are evicted first.
+var qosTiers = map[v1.PodQOSClass]int{
+	v1.PodQOSBestEffort: 0,
+	v1.PodQOSBurstable:  1,
+	v1.PodQOSGuaranteed: 2,
+}
+
+// orderEvictionCandidates evicts pods of the lowest qos level first, e.g. best-effort pods before
+// latency sensitive ones, then pods of the lowest kubernetes qos class, and then pods of the lowest
+// priority, pods keep the original order within the same tier. Pods whose eviction would break the
+// gang of their podgroup, counting the pods of the podgroup ordered before them, are moved to the
+// end, so that they are only evicted when the node pressure can not be relieved by other pods.
+func (m *manager) orderEvictionCandidates(pods []*v1.Pod) []*v1.Pod {
+	sorted := make([]*v1.Pod, len(pods))
+	copy(sorted, pods)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if li, lj := extension.GetQosLevel(sorted[i]), extension.GetQosLevel(sorted[j]); li != lj {
+			return li < lj
+		}
+		if ti, tj := qosTiers[v1qos.GetPodQOS(sorted[i])], qosTiers[v1qos.GetPodQOS(sorted[j])]; ti != tj {
+			return ti < tj
+		}
+		return corev1helpers.PodPriority(sorted[i]) < corev1helpers.PodPriority(sorted[j])
+	})
+
+	if m.gangChecker == nil {
+		return sorted
+	}
+
+	candidates := make([]*v1.Pod, 0, len(sorted))
+	gangBreakingPods := make([]*v1.Pod, 0)
+	// budgets is the number of pods of each podgroup which can still be evicted
+	budgets := map[string]int32{}
+	for _, pod := range sorted {
+		key, budget, limited := m.gangChecker.EvictionBudget(pod)
+		if !limited {
+			candidates = append(candidates, pod)
+			continue
+		}
+		if _, found := budgets[key]; !found {
+			budgets[key] = budget
+		}
+		if budgets[key] > 0 {
+			budgets[key]--
+			candidates = append(candidates, pod)
+		} else {
+			klog.V(4).InfoS("Defer evicting pod to keep gang minMember", "pod", klog.KObj(pod))
+			gangBreakingPods = append(gangBreakingPods, pod)
+		}
+	}
+	return append(candidates, gangBreakingPods...)
+}
+
 func (m *manager) RefreshCfg(cfg *api.ColocationConfig) error {
 	return nil
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 
@@ -133,3 +134,97 @@ func Test_manager_Handle(t *testing.T) {
 		})
 	}
 }
+
+type fakeGangChecker struct {
+	// podGroups is the podgroup of each pod, and budgets is the eviction budget of each podgroup.
+	podGroups map[string]string
+	budgets   map[string]int32
+}
+
+func (f *fakeGangChecker) EvictionBudget(pod *v1.Pod) (string, int32, bool) {
+	pg, found := f.podGroups[pod.Name]
+	if !found {
+		return "", 0, false
+	}
+	return pg, f.budgets[pg], true
+}
+
+func Test_manager_orderEvictionCandidates(t *testing.T) {
+	makePod := func(name string, priority int32) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1.PodSpec{Priority: &priority},
+		}
+	}
+	pods := []*v1.Pod{makePod("high", 100), makePod("low-1", 10), makePod("gang", 1), makePod("low-2", 10)}
+	guaranteed := makePod("guaranteed", 1)
+	guaranteed.Spec.Containers = []v1.Container{{
+		Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1"), v1.ResourceMemory: resource.MustParse("1Gi")},
+			Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("1"), v1.ResourceMemory: resource.MustParse("1Gi")},
+		},
+	}}
+
+	tests := []struct {
+		name        string
+		pods        []*v1.Pod
+		gangChecker eviction.GangChecker
+		expected    []string
+	}{
+		{
+			name:     "order by priority without gang checker",
+			pods:     pods,
+			expected: []string{"gang", "low-1", "low-2", "high"},
+		},
+		{
+			name:     "guaranteed pods are evicted after pods of lower qos class",
+			pods:     append([]*v1.Pod{guaranteed}, pods...),
+			expected: []string{"gang", "low-1", "low-2", "high", "guaranteed"},
+		},
+		{
+			name: "gang breaking pods are evicted at last",
+			pods: pods,
+			gangChecker: &fakeGangChecker{
+				podGroups: map[string]string{"gang": "ns/pg"},
+				budgets:   map[string]int32{"ns/pg": 0},
+			},
+			expected: []string{"low-1", "low-2", "high", "gang"},
+		},
+		{
+			name: "pods of the same podgroup share the eviction budget",
+			pods: pods,
+			gangChecker: &fakeGangChecker{
+				podGroups: map[string]string{"low-1": "ns/pg", "low-2": "ns/pg"},
+				budgets:   map[string]int32{"ns/pg": 1},
+			},
+			expected: []string{"gang", "low-1", "high", "low-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &manager{gangChecker: tt.gangChecker}
+			var names []string
+			for _, pod := range m.orderEvictionCandidates(tt.pods) {
+				names = append(names, pod.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func Test_manager_orderEvictionCandidatesByQosLevel(t *testing.T) {
+	makePod := func(name, qosLevel string, priority int32) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{apis.PodQosLevelKey: qosLevel}},
+			Spec:       v1.PodSpec{Priority: &priority},
+		}
+	}
+	pods := []*v1.Pod{makePod("ls-low", "LS", 1), makePod("be-high", "BE", 100), makePod("be-low", "BE", 10), makePod("unset", "", 1)}
+
+	m := &manager{}
+	var names []string
+	for _, pod := range m.orderEvictionCandidates(pods) {
+		names = append(names, pod.Name)
+	}
+	assert.Equal(t, []string{"be-low", "be-high", "unset", "ls-low"}, names)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package numatopology
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+	"k8s.io/kubernetes/pkg/kubelet/apis/podresources"
+
+	nodeinfov1alpha1 "volcano.sh/apis/pkg/apis/nodeinfo/v1alpha1"
+	vcclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/agent/utils"
+)
+
+const (
+	// DefaultPodResourcesEndpoint is the endpoint of the pod resources api of the kubelet.
+	DefaultPodResourcesEndpoint = "unix:///var/lib/kubelet/pod-resources/kubelet.sock"
+	// DefaultReportPeriod is the period to report the numa topology of the node.
+	DefaultReportPeriod = 30 * time.Second
+
+	defaultSysDevicesPath = "/sys/devices/system"
+
+	podResourcesTimeout    = 10 * time.Second
+	podResourcesMaxMsgSize = 16 * 1024 * 1024
+)
+
+// Reporter reports the numa topology of the node to its Numatopology, which is used by the numa-aware plugin of
+// the scheduler. The cpus allocatable to the containers are got from the pod resources api of the kubelet, so that
+// the topology keeps up with the reserved cpus and the exclusive cpus assigned by the cpu manager, e.g. after the
+// kubelet is reconfigured, instead of being maintained by hand.
+type Reporter struct {
+	nodeName       string
+	kubeClient     clientset.Interface
+	vcClient       vcclientset.Interface
+	endpoint       string
+	sysDevicesPath string
+
+	client podresourcesapi.PodResourcesListerClient
+}
+
+// NewReporter returns the reporter of the numa topology of the node.
+func NewReporter(nodeName string, kubeClient clientset.Interface, vcClient vcclientset.Interface, endpoint string) *Reporter {
+	if endpoint == "" {
+		endpoint = DefaultPodResourcesEndpoint
+	}
+	return &Reporter{
+		nodeName:       nodeName,
+		kubeClient:     kubeClient,
+		vcClient:       vcClient,
+		endpoint:       endpoint,
+		sysDevicesPath: defaultSysDevicesPath,
+	}
+}
+
+// Run reports the numa topology of the node every period until the context is done.
+func (r *Reporter) Run(ctx context.Context, period time.Duration) {
+	klog.InfoS("Started numa topology reporter", "node", r.nodeName, "endpoint", r.endpoint)
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := r.sync(ctx); err != nil {
+			klog.ErrorS(err, "Failed to report numa topology", "node", r.nodeName)
+		}
+	}, period)
+}
+
+func (r *Reporter) sync(ctx context.Context) error {
+	if r.client == nil {
+		client, _, err := podresources.GetV1Client(r.endpoint, podResourcesTimeout, podResourcesMaxMsgSize)
+		if err != nil {
+			return fmt.Errorf("failed to connect to pod resources api %s: %v", r.endpoint, err)
+		}
+		r.client = client
+	}
+
+	allocatable, err := r.client.GetAllocatableResources(ctx, &podresourcesapi.AllocatableResourcesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get allocatable resources: %v", err)
+	}
+	pods, err := r.client.List(ctx, &podresourcesapi.ListPodResourcesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list pod resources: %v", err)
+	}
+	cpuDetail, err := readCPUDetail(r.sysDevicesPath)
+	if err != nil {
+		return fmt.Errorf("failed to read cpu topology: %v", err)
+	}
+
+	spec := buildSpec(cpuDetail, allocatableCPUs(allocatable, pods), r.policies(ctx))
+	return r.update(ctx, spec)
+}
+
+// update creates or updates the Numatopology of the node, which is owned by the node and garbage collected with it.
+func (r *Reporter) update(ctx context.Context, spec nodeinfov1alpha1.NumatopoSpec) error {
+	numatopologies := r.vcClient.NodeinfoV1alpha1().Numatopologies()
+	current, err := numatopologies.Get(ctx, r.nodeName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		node, err := r.kubeClient.CoreV1().Nodes().Get(ctx, r.nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		numatopology := &nodeinfov1alpha1.Numatopology{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: r.nodeName,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "v1",
+					Kind:       "Node",
+					Name:       node.Name,
+					UID:        node.UID,
+				}},
+			},
+			Spec: spec,
+		}
+		_, err = numatopologies.Create(ctx, numatopology, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(current.Spec, spec) {
+		return nil
+	}
+	klog.V(3).InfoS("Update numa topology", "node", r.nodeName, "cpu", spec.NumaResMap)
+	updated := current.DeepCopy()
+	updated.Spec = spec
+	_, err = numatopologies.Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+type kubeletConfigz struct {
+	KubeletConfig struct {
+		CPUManagerPolicy      string `json:"cpuManagerPolicy"`
+		TopologyManagerPolicy string `json:"topologyManagerPolicy"`
+	} `json:"kubeletconfig"`
+}
+
+// policies returns the cpu manager and topology manager policies of the kubelet from its configz, the cpu manager
+// policy falls back to the one of the cpu manager state file if the configz is not available.
+func (r *Reporter) policies(ctx context.Context) map[nodeinfov1alpha1.PolicyName]string {
+	policies := map[nodeinfov1alpha1.PolicyName]string{}
+	raw, err := r.kubeClient.CoreV1().RESTClient().Get().
+		Resource("nodes").Name(r.nodeName).SubResource("proxy").Suffix("configz").DoRaw(ctx)
+	configz := kubeletConfigz{}
+	if err == nil {
+		err = json.Unmarshal(raw, &configz)
+	}
+	if err != nil {
+		klog.V(3).InfoS("Failed to get kubelet configz, fall back to cpu manager state", "node", r.nodeName, "err", err)
+		configz.KubeletConfig.CPUManagerPolicy = utils.GetCPUManagerPolicy()
+	}
+	if configz.KubeletConfig.CPUManagerPolicy != "" {
+		policies[nodeinfov1alpha1.CPUManagerPolicy] = configz.KubeletConfig.CPUManagerPolicy
+	}
+	if configz.KubeletConfig.TopologyManagerPolicy != "" {
+		policies[nodeinfov1alpha1.TopologyManagerPolicy] = configz.KubeletConfig.TopologyManagerPolicy
+	}
+	return policies
+}
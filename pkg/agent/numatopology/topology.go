@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package numatopology
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+	"k8s.io/utils/cpuset"
+
+	nodeinfov1alpha1 "volcano.sh/apis/pkg/apis/nodeinfo/v1alpha1"
+)
+
+// readCPUDetail reads the numa node, socket and core of the online cpus from the sysfs, keyed by the cpu id.
+func readCPUDetail(sysDevicesPath string) (map[string]nodeinfov1alpha1.CPUInfo, error) {
+	cpuNuma := map[int]int{}
+	nodeDirs, err := filepath.Glob(filepath.Join(sysDevicesPath, "node", "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, nodeDir := range nodeDirs {
+		numaID, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(nodeDir), "node"))
+		if err != nil {
+			continue
+		}
+		cpuList, err := readString(filepath.Join(nodeDir, "cpulist"))
+		if err != nil {
+			return nil, err
+		}
+		cpus, err := cpuset.Parse(cpuList)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpulist of numa node %d: %v", numaID, err)
+		}
+		for _, cpu := range cpus.List() {
+			cpuNuma[cpu] = numaID
+		}
+	}
+
+	cpuDirs, err := filepath.Glob(filepath.Join(sysDevicesPath, "cpu", "cpu[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+	detail := map[string]nodeinfov1alpha1.CPUInfo{}
+	for _, cpuDir := range cpuDirs {
+		cpuID, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(cpuDir), "cpu"))
+		if err != nil {
+			continue
+		}
+		// The offline cpus have no topology.
+		socket, err := readInt(filepath.Join(cpuDir, "topology", "physical_package_id"))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		core, err := readInt(filepath.Join(cpuDir, "topology", "core_id"))
+		if err != nil {
+			return nil, err
+		}
+		detail[strconv.Itoa(cpuID)] = nodeinfov1alpha1.CPUInfo{
+			NUMANodeID: cpuNuma[cpuID],
+			SocketID:   socket,
+			CoreID:     core,
+		}
+	}
+	return detail, nil
+}
+
+// allocatableCPUs returns the cpus which can be allocated exclusively by the cpu manager of the kubelet, i.e. the
+// cpus not reserved by the kubelet and not allocated to the running containers yet.
+func allocatableCPUs(allocatable *podresourcesapi.AllocatableResourcesResponse, pods *podresourcesapi.ListPodResourcesResponse) cpuset.CPUSet {
+	cpus := cpuset.New(toInts(allocatable.GetCpuIds())...)
+	for _, pod := range pods.GetPodResources() {
+		for _, container := range pod.GetContainers() {
+			cpus = cpus.Difference(cpuset.New(toInts(container.GetCpuIds())...))
+		}
+	}
+	return cpus
+}
+
+// buildSpec builds the numa topology of the node from the cpu topology of the sysfs and the resources of the
+// kubelet. The reserved cpus are not reported, as the allocatable cpus reported by the kubelet exclude them.
+func buildSpec(cpuDetail map[string]nodeinfov1alpha1.CPUInfo, allocatable cpuset.CPUSet, policies map[nodeinfov1alpha1.PolicyName]string) nodeinfov1alpha1.NumatopoSpec {
+	return nodeinfov1alpha1.NumatopoSpec{
+		Policies: policies,
+		NumaResMap: map[string]nodeinfov1alpha1.ResourceInfo{
+			string(v1.ResourceCPU): {
+				Allocatable: allocatable.String(),
+				Capacity:    len(cpuDetail),
+			},
+		},
+		CPUDetail: cpuDetail,
+	}
+}
+
+func toInts(ids []int64) []int {
+	ints := make([]int, 0, len(ids))
+	for _, id := range ids {
+		ints = append(ints, int(id))
+	}
+	return ints
+}
+
+func readString(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func readInt(path string) (int, error) {
+	s, err := readString(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(s)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package numatopology
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+	"k8s.io/utils/cpuset"
+
+	nodeinfov1alpha1 "volcano.sh/apis/pkg/apis/nodeinfo/v1alpha1"
+	vcfake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadCPUDetail(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "node", "node0", "cpulist"), "0-1\n")
+	writeFile(t, filepath.Join(root, "node", "node1", "cpulist"), "2-3\n")
+	for cpu, topology := range map[string][2]string{"0": {"0", "0"}, "1": {"0", "1"}, "2": {"1", "0"}, "3": {"1", "1"}} {
+		writeFile(t, filepath.Join(root, "cpu", "cpu"+cpu, "topology", "physical_package_id"), topology[0]+"\n")
+		writeFile(t, filepath.Join(root, "cpu", "cpu"+cpu, "topology", "core_id"), topology[1]+"\n")
+	}
+	// cpu4 is offline, which has no topology.
+	if err := os.MkdirAll(filepath.Join(root, "cpu", "cpu4"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	detail, err := readCPUDetail(root)
+	if err != nil {
+		t.Fatalf("failed to read cpu detail: %v", err)
+	}
+	expected := map[string]nodeinfov1alpha1.CPUInfo{
+		"0": {NUMANodeID: 0, SocketID: 0, CoreID: 0},
+		"1": {NUMANodeID: 0, SocketID: 0, CoreID: 1},
+		"2": {NUMANodeID: 1, SocketID: 1, CoreID: 0},
+		"3": {NUMANodeID: 1, SocketID: 1, CoreID: 1},
+	}
+	if !reflect.DeepEqual(detail, expected) {
+		t.Errorf("expected cpu detail %v, got %v", expected, detail)
+	}
+}
+
+func TestAllocatableCPUs(t *testing.T) {
+	allocatable := &podresourcesapi.AllocatableResourcesResponse{CpuIds: []int64{1, 2, 3, 5, 6, 7}}
+	pods := &podresourcesapi.ListPodResourcesResponse{PodResources: []*podresourcesapi.PodResources{
+		{Name: "p1", Containers: []*podresourcesapi.ContainerResources{{Name: "c1", CpuIds: []int64{2, 3}}, {Name: "c2"}}},
+		{Name: "p2", Containers: []*podresourcesapi.ContainerResources{{Name: "c1", CpuIds: []int64{6}}}},
+	}}
+
+	cpus := allocatableCPUs(allocatable, pods)
+	if expected := cpuset.New(1, 5, 7); !cpus.Equals(expected) {
+		t.Errorf("expected allocatable cpus %v, got %v", expected, cpus)
+	}
+}
+
+func TestReporterUpdate(t *testing.T) {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1", UID: "uid-n1"}}
+	r := NewReporter("n1", kubefake.NewSimpleClientset(node), vcfake.NewSimpleClientset(), "")
+	detail := map[string]nodeinfov1alpha1.CPUInfo{"0": {}, "1": {CoreID: 1}}
+	policies := map[nodeinfov1alpha1.PolicyName]string{
+		nodeinfov1alpha1.CPUManagerPolicy:      "static",
+		nodeinfov1alpha1.TopologyManagerPolicy: "single-numa-node",
+	}
+
+	if err := r.update(context.TODO(), buildSpec(detail, cpuset.New(0, 1), policies)); err != nil {
+		t.Fatalf("failed to create numatopology: %v", err)
+	}
+	numatopology, err := r.vcClient.NodeinfoV1alpha1().Numatopologies().Get(context.TODO(), "n1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get numatopology: %v", err)
+	}
+	if len(numatopology.OwnerReferences) != 1 || numatopology.OwnerReferences[0].UID != node.UID {
+		t.Errorf("expected numatopology owned by node, got %v", numatopology.OwnerReferences)
+	}
+	if got := numatopology.Spec.NumaResMap[string(v1.ResourceCPU)]; got.Allocatable != "0-1" || got.Capacity != 2 {
+		t.Errorf("expected allocatable cpus 0-1 of 2, got %v", got)
+	}
+
+	if err := r.update(context.TODO(), buildSpec(detail, cpuset.New(1), policies)); err != nil {
+		t.Fatalf("failed to update numatopology: %v", err)
+	}
+	numatopology, err = r.vcClient.NodeinfoV1alpha1().Numatopologies().Get(context.TODO(), "n1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get numatopology: %v", err)
+	}
+	if got := numatopology.Spec.NumaResMap[string(v1.ResourceCPU)].Allocatable; got != "1" {
+		t.Errorf("expected allocatable cpus 1 after update, got %v", got)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eviction
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulinglister "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
+)
+
+// GangChecker checks whether pods can be evicted locally without breaking the gang
+// of their podgroup, which is the same minMember constraint the scheduler respects when
+// it reclaims or preempts pods.
+type GangChecker interface {
+	// EvictionBudget returns the key of the podgroup of the pod and the number of its pods
+	// which can be evicted without making the running pods less than minMember. The bool
+	// result is false if the eviction of the pod is not limited by a podgroup.
+	EvictionBudget(pod *corev1.Pod) (string, int32, bool)
+}
+
+type podGroupGangChecker struct {
+	pgLister schedulinglister.PodGroupLister
+}
+
+// NewGangChecker returns a GangChecker which checks the podgroup status maintained by the scheduler.
+func NewGangChecker(pgLister schedulinglister.PodGroupLister) GangChecker {
+	return &podGroupGangChecker{pgLister: pgLister}
+}
+
+func (g *podGroupGangChecker) EvictionBudget(pod *corev1.Pod) (string, int32, bool) {
+	if g.pgLister == nil {
+		return "", 0, false
+	}
+
+	pgName := pod.Annotations[v1beta1.KubeGroupNameAnnotationKey]
+	if pgName == "" {
+		return "", 0, false
+	}
+
+	pg, err := g.pgLister.PodGroups(pod.Namespace).Get(pgName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to get podgroup, regard pod as evictable", "pod", klog.KObj(pod), "podgroup", pgName)
+		}
+		return "", 0, false
+	}
+
+	return pod.Namespace + "/" + pgName, pg.Status.Running - pg.Spec.MinMember, true
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/cli/util"
+)
+
+// jobSuspendAnnotation is the annotation holding a job suspended, see how_to_suspend_job.md.
+const jobSuspendAnnotation = "volcano.sh/job-suspend"
+
+// batchFlags select the jobs a command applies to instead of a single job.
+type batchFlags struct {
+	All           bool
+	AllNamespaces bool
+	QueueName     string
+	Yes           bool
+}
+
+func initBatchFlags(cmd *cobra.Command, flags *batchFlags) {
+	cmd.Flags().BoolVarP(&flags.All, "all", "", false, "apply to all the jobs in the namespace instead of the named job")
+	cmd.Flags().BoolVarP(&flags.AllNamespaces, "all-namespaces", "", false, "with --all, apply to the jobs in all namespaces")
+	cmd.Flags().StringVarP(&flags.QueueName, "queue", "q", "", "with --all, only apply to the jobs of the queue")
+	cmd.Flags().BoolVarP(&flags.Yes, "yes", "y", false, "with --all, apply without asking for confirmation")
+}
+
+// selectJobs lists the jobs selected by the batch flags which the filter accepts.
+func selectJobs(ctx context.Context, jobClient versioned.Interface, namespace string, flags *batchFlags,
+	filter func(job *v1alpha1.Job) bool) ([]*v1alpha1.Job, error) {
+	if flags.AllNamespaces {
+		namespace = ""
+	}
+	jobs, err := jobClient.BatchV1alpha1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var selected []*v1alpha1.Job
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if flags.QueueName != "" && job.Spec.Queue != flags.QueueName {
+			continue
+		}
+		if filter(job) {
+			selected = append(selected, job)
+		}
+	}
+	return selected, nil
+}
+
+// applyToJobs applies the action to the selected jobs after the user confirms, it goes on with the other jobs
+// when the action fails for one of them.
+func applyToJobs(jobs []*v1alpha1.Job, flags *batchFlags, verb string, in io.Reader, out io.Writer,
+	action func(job *v1alpha1.Job) error) error {
+	if len(jobs) == 0 {
+		fmt.Fprintf(out, "No jobs to %s.\n", verb)
+		return nil
+	}
+	if !flags.Yes {
+		for _, job := range jobs {
+			fmt.Fprintf(out, "%s/%s (queue: %s, phase: %s)\n", job.Namespace, job.Name, job.Spec.Queue, job.Status.State.Phase)
+		}
+		if !util.Confirm(in, out, fmt.Sprintf("Going to %s %d jobs, continue?", verb, len(jobs))) {
+			fmt.Fprintln(out, "Aborted.")
+			return nil
+		}
+	}
+
+	var errs []error
+	for _, job := range jobs {
+		if err := action(job); err != nil {
+			errs = append(errs, fmt.Errorf("failed to %s job %s/%s: %v", verb, job.Namespace, job.Name, err))
+			continue
+		}
+		fmt.Fprintf(out, "job %s/%s: %s\n", job.Namespace, job.Name, verb)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// isFinished returns whether the job has finished and can not be suspended.
+func isFinished(job *v1alpha1.Job) bool {
+	switch job.Status.State.Phase {
+	case v1alpha1.Completed, v1alpha1.Failed, v1alpha1.Terminated:
+		return true
+	}
+	return false
+}
+
+// canAbort returns whether the job can be aborted by vcctl job suspend.
+func canAbort(job *v1alpha1.Job) bool {
+	switch job.Status.State.Phase {
+	case v1alpha1.Aborting, v1alpha1.Aborted:
+		return false
+	}
+	return !isFinished(job)
+}
+
+// isAborted returns whether the job is aborted and can be resumed by vcctl job resume.
+func isAborted(job *v1alpha1.Job) bool {
+	return job.Status.State.Phase == v1alpha1.Aborted
+}
+
+// canHold returns whether the job can be held by vcctl job hold.
+func canHold(job *v1alpha1.Job) bool {
+	return !isFinished(job) && !isHeld(job)
+}
+
+// isHeld returns whether the job is suspended by the annotation.
+func isHeld(job *v1alpha1.Job) bool {
+	return job.Annotations[jobSuspendAnnotation] == "true"
+}
+
+// setHold suspends the job by the annotation, or removes the annotation to resume it.
+func setHold(ctx context.Context, jobClient versioned.Interface, namespace, name string, hold bool) error {
+	var value interface{}
+	if hold {
+		value = "true"
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{jobSuspendAnnotation: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = jobClient.BatchV1alpha1().Jobs(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
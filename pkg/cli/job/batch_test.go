@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/client/clientset/versioned/fake"
+)
+
+func buildBatchJob(namespace, name, queue string, phase v1alpha1.JobPhase, held bool) *v1alpha1.Job {
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       v1alpha1.JobSpec{Queue: queue},
+		Status:     v1alpha1.JobStatus{State: v1alpha1.JobState{Phase: phase}},
+	}
+	if held {
+		job.Annotations = map[string]string{jobSuspendAnnotation: "true"}
+	}
+	return job
+}
+
+func jobNames(jobs []*v1alpha1.Job) []string {
+	var names []string
+	for _, job := range jobs {
+		names = append(names, job.Namespace+"/"+job.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestSelectJobs(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		buildBatchJob("ns1", "running", "q1", v1alpha1.Running, false),
+		buildBatchJob("ns1", "completed", "q1", v1alpha1.Completed, false),
+		buildBatchJob("ns1", "aborted", "q1", v1alpha1.Aborted, false),
+		buildBatchJob("ns1", "held", "q1", v1alpha1.Pending, true),
+		buildBatchJob("ns1", "other-queue", "q2", v1alpha1.Running, false),
+		buildBatchJob("ns2", "running", "q1", v1alpha1.Running, false),
+	)
+
+	testCases := []struct {
+		name      string
+		namespace string
+		flags     batchFlags
+		filter    func(job *v1alpha1.Job) bool
+		expected  string
+	}{
+		{
+			name:      "suspend the jobs of the queue in the namespace",
+			namespace: "ns1",
+			flags:     batchFlags{All: true, QueueName: "q1"},
+			filter:    canAbort,
+			expected:  "ns1/held,ns1/running",
+		},
+		{
+			name:      "suspend the jobs of the queue in all namespaces",
+			namespace: "ns1",
+			flags:     batchFlags{All: true, AllNamespaces: true, QueueName: "q1"},
+			filter:    canAbort,
+			expected:  "ns1/held,ns1/running,ns2/running",
+		},
+		{
+			name:      "resume the aborted jobs",
+			namespace: "ns1",
+			flags:     batchFlags{All: true},
+			filter:    isAborted,
+			expected:  "ns1/aborted",
+		},
+		{
+			name:      "hold the jobs in the namespace",
+			namespace: "ns1",
+			flags:     batchFlags{All: true},
+			filter:    canHold,
+			expected:  "ns1/aborted,ns1/other-queue,ns1/running",
+		},
+		{
+			name:      "release the held jobs",
+			namespace: "ns1",
+			flags:     batchFlags{All: true},
+			filter:    isHeld,
+			expected:  "ns1/held",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jobs, err := selectJobs(context.TODO(), client, tc.namespace, &tc.flags, tc.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := strings.Join(jobNames(jobs), ","); got != tc.expected {
+				t.Errorf("expected jobs %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestApplyToJobs(t *testing.T) {
+	jobs := []*v1alpha1.Job{
+		buildBatchJob("ns1", "job1", "q1", v1alpha1.Running, false),
+		buildBatchJob("ns1", "job2", "q1", v1alpha1.Running, false),
+	}
+
+	testCases := []struct {
+		name     string
+		flags    batchFlags
+		answer   string
+		expected int
+	}{
+		{name: "confirmed", answer: "y\n", expected: 2},
+		{name: "declined", answer: "n\n", expected: 0},
+		{name: "without confirmation", flags: batchFlags{Yes: true}, expected: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			applied := 0
+			out := &bytes.Buffer{}
+			err := applyToJobs(jobs, &tc.flags, "hold", strings.NewReader(tc.answer), out, func(job *v1alpha1.Job) error {
+				applied++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if applied != tc.expected {
+				t.Errorf("expected %d jobs applied, got %d, output: %s", tc.expected, applied, out.String())
+			}
+		})
+	}
+}
+
+func TestSetHold(t *testing.T) {
+	client := fake.NewSimpleClientset(buildBatchJob("ns1", "job1", "q1", v1alpha1.Running, false))
+
+	if err := setHold(context.TODO(), client, "ns1", "job1", true); err != nil {
+		t.Fatalf("failed to hold the job: %v", err)
+	}
+	job, _ := client.BatchV1alpha1().Jobs("ns1").Get(context.TODO(), "job1", metav1.GetOptions{})
+	if !isHeld(job) {
+		t.Errorf("expected the job held, got annotations %v", job.Annotations)
+	}
+
+	if err := setHold(context.TODO(), client, "ns1", "job1", false); err != nil {
+		t.Fatalf("failed to release the job: %v", err)
+	}
+	job, _ = client.BatchV1alpha1().Jobs("ns1").Get(context.TODO(), "job1", metav1.GetOptions{})
+	if _, found := job.Annotations[jobSuspendAnnotation]; found {
+		t.Errorf("expected the annotation removed, got annotations %v", job.Annotations)
+	}
+}
+
+func TestInitBatchFlags(t *testing.T) {
+	var cmd cobra.Command
+	InitHoldFlags(&cmd)
+
+	for _, flag := range []string{"namespace", "name", "all", "all-namespaces", "queue", "yes"} {
+		if cmd.Flag(flag) == nil {
+			t.Errorf("Could not find the flag %s", flag)
+		}
+	}
+}
@@ -46,7 +46,7 @@ func InitDeleteFlags(cmd *cobra.Command) {
 
 // DeleteJob delete the job.
 func DeleteJob(ctx context.Context) error {
-	config, err := util.BuildConfig(deleteJobFlags.Master, deleteJobFlags.Kubeconfig)
+	config, err := util.BuildConfig(deleteJobFlags.Master, deleteJobFlags.Kubeconfig, deleteJobFlags.Context)
 	if err != nil {
 		return err
 	}
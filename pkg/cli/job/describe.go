@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	coreV1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/apis/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/cli/util"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+type describeFlags struct {
+	util.CommonFlags
+
+	Namespace string
+	JobName   string
+	Timeline  bool
+}
+
+var describeJobFlags = &describeFlags{}
+
+// TimelineEntry is one milestone in the lifecycle of a job.
+type TimelineEntry struct {
+	Milestone string
+	Time      *metav1.Time
+}
+
+// InitDescribeFlags init the describe command flags.
+func InitDescribeFlags(cmd *cobra.Command) {
+	util.InitFlags(cmd, &describeJobFlags.CommonFlags)
+
+	cmd.Flags().StringVarP(&describeJobFlags.Namespace, "namespace", "n", "default", "the namespace of job")
+	cmd.Flags().StringVarP(&describeJobFlags.JobName, "name", "N", "", "the name of job")
+	cmd.Flags().BoolVarP(&describeJobFlags.Timeline, "timeline", "", false, "print the scheduling timeline of job")
+}
+
+// DescribeJob gives full details of the job, and the scheduling timeline if required.
+func DescribeJob(ctx context.Context, args []string) error {
+	config, err := util.BuildConfig(describeJobFlags.Master, describeJobFlags.Kubeconfig, describeJobFlags.Context)
+	if err != nil {
+		return err
+	}
+	if describeJobFlags.JobName == "" && len(args) > 0 {
+		describeJobFlags.JobName = args[0]
+	}
+	if describeJobFlags.JobName == "" {
+		return fmt.Errorf("job name (specified by --name or -N) is mandatory to describe a particular job")
+	}
+
+	jobClient := versioned.NewForConfigOrDie(config)
+	job, err := jobClient.BatchV1alpha1().Jobs(describeJobFlags.Namespace).Get(ctx, describeJobFlags.JobName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	PrintJobInfo(job, os.Stdout)
+	if describeJobFlags.Timeline {
+		kubeClient, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return err
+		}
+		pods, err := kubeClient.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", v1alpha1.JobNameKey, job.Name),
+		})
+		if err != nil {
+			return err
+		}
+		podGroup, err := jobClient.SchedulingV1beta1().PodGroups(job.Namespace).Get(ctx, jobhelpers.MakePodGroupName(job), metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		PrintTimeline(BuildTimeline(job, podGroup, pods.Items), os.Stdout)
+	}
+	PrintEvents(GetEvents(ctx, config, job), os.Stdout)
+	return nil
+}
+
+// BuildTimeline reconstructs the milestones of the job from the conditions of the job and its podgroup, and its pods.
+// The admission by the queue is the transition of the Inqueue condition the scheduler records on the podgroup.
+func BuildTimeline(job *v1alpha1.Job, podGroup *schedulingv1beta1.PodGroup, pods []coreV1.Pod) []TimelineEntry {
+	created := job.CreationTimestamp
+	var admitted, lastCreated, firstScheduled, lastScheduled *metav1.Time
+	if podGroup != nil {
+		for i := range podGroup.Status.Conditions {
+			cond := &podGroup.Status.Conditions[i]
+			// the condition is named after the phase
+			if cond.Type == schedulingv1beta1.PodGroupConditionType(schedulingv1beta1.PodGroupInqueue) && cond.Status == coreV1.ConditionTrue {
+				admitted = earliest(admitted, &cond.LastTransitionTime)
+			}
+		}
+	}
+	scheduledCount := 0
+	for i := range pods {
+		pod := &pods[i]
+		lastCreated = latest(lastCreated, &pod.CreationTimestamp)
+		for j := range pod.Status.Conditions {
+			cond := &pod.Status.Conditions[j]
+			if cond.Type == coreV1.PodScheduled && cond.Status == coreV1.ConditionTrue {
+				scheduledCount++
+				firstScheduled = earliest(firstScheduled, &cond.LastTransitionTime)
+				lastScheduled = latest(lastScheduled, &cond.LastTransitionTime)
+			}
+		}
+	}
+	// not all pods are scheduled yet
+	if scheduledCount == 0 || scheduledCount < len(pods) {
+		lastScheduled = nil
+	}
+
+	var running, finished *metav1.Time
+	for _, cond := range job.Status.Conditions {
+		switch cond.Status {
+		case v1alpha1.Running:
+			running = earliest(running, cond.LastTransitionTime)
+		case v1alpha1.Completed, v1alpha1.Failed, v1alpha1.Aborted, v1alpha1.Terminated:
+			finished = latest(finished, cond.LastTransitionTime)
+		}
+	}
+
+	return []TimelineEntry{
+		{Milestone: "Created", Time: &created},
+		{Milestone: "Admitted", Time: admitted},
+		{Milestone: "PodsCreated", Time: lastCreated},
+		{Milestone: "FirstScheduled", Time: firstScheduled},
+		{Milestone: "AllScheduled", Time: lastScheduled},
+		{Milestone: "Running", Time: running},
+		{Milestone: "Finished", Time: finished},
+	}
+}
+
+// PrintTimeline prints the timeline with the duration spent since the previous reached milestone.
+func PrintTimeline(timeline []TimelineEntry, writer io.Writer) {
+	WriteLine(writer, Level0, "Timeline:\n")
+	WriteLine(writer, Level1, "%-15s\t%-30s\t%s\n", "Milestone", "Time", "Duration")
+	var previous *metav1.Time
+	for _, entry := range timeline {
+		if entry.Time == nil {
+			WriteLine(writer, Level1, "%-15s\t%-30s\t%s\n", entry.Milestone, "<none>", "-")
+			continue
+		}
+		duration := "-"
+		if previous != nil {
+			duration = util.HumanDuration(entry.Time.Sub(previous.Time))
+		}
+		WriteLine(writer, Level1, "%-15s\t%-30s\t%s\n", entry.Milestone, entry.Time.Format("2006-01-02 15:04:05"), duration)
+		previous = entry.Time
+	}
+}
+
+func earliest(current, t *metav1.Time) *metav1.Time {
+	if t == nil || t.IsZero() {
+		return current
+	}
+	if current == nil || t.Before(current) {
+		return t
+	}
+	return current
+}
+
+func latest(current, t *metav1.Time) *metav1.Time {
+	if t == nil || t.IsZero() {
+		return current
+	}
+	if current == nil || current.Before(t) {
+		return t
+	}
+	return current
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func TestBuildTimeline(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) metav1.Time {
+		return metav1.NewTime(base.Add(time.Duration(seconds) * time.Second))
+	}
+	timeAt := func(seconds int) *metav1.Time {
+		ts := at(seconds)
+		return &ts
+	}
+	scheduledPod := func(created, scheduled int) coreV1.Pod {
+		return coreV1.Pod{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: at(created)},
+			Status: coreV1.PodStatus{
+				Conditions: []coreV1.PodCondition{
+					{Type: coreV1.PodScheduled, Status: coreV1.ConditionTrue, LastTransitionTime: at(scheduled)},
+				},
+			},
+		}
+	}
+	running := timeAt(50)
+	completed := timeAt(100)
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: at(0)},
+		Status: v1alpha1.JobStatus{
+			Conditions: []v1alpha1.JobCondition{
+				{Status: v1alpha1.Pending, LastTransitionTime: timeAt(0)},
+				{Status: v1alpha1.Running, LastTransitionTime: running},
+				{Status: v1alpha1.Completed, LastTransitionTime: completed},
+			},
+		},
+	}
+
+	admittedPodGroup := &schedulingv1beta1.PodGroup{
+		Status: schedulingv1beta1.PodGroupStatus{
+			Conditions: []schedulingv1beta1.PodGroupCondition{
+				{Type: schedulingv1beta1.PodGroupUnschedulableType, Status: coreV1.ConditionTrue, LastTransitionTime: at(2)},
+				{Type: "Inqueue", Status: coreV1.ConditionTrue, LastTransitionTime: at(5)},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		podGroup *schedulingv1beta1.PodGroup
+		pods     []coreV1.Pod
+		expected map[string]*metav1.Time
+	}{
+		{
+			name:     "all pods scheduled",
+			podGroup: admittedPodGroup,
+			pods:     []coreV1.Pod{scheduledPod(10, 20), scheduledPod(12, 30)},
+			expected: map[string]*metav1.Time{
+				"Created":        timeAt(0),
+				"Admitted":       timeAt(5),
+				"PodsCreated":    timeAt(12),
+				"FirstScheduled": timeAt(20),
+				"AllScheduled":   timeAt(30),
+				"Running":        running,
+				"Finished":       completed,
+			},
+		},
+		{
+			name:     "part of pods scheduled",
+			podGroup: admittedPodGroup,
+			pods:     []coreV1.Pod{scheduledPod(10, 20), {ObjectMeta: metav1.ObjectMeta{CreationTimestamp: at(12)}}},
+			expected: map[string]*metav1.Time{
+				"Created":        timeAt(0),
+				"Admitted":       timeAt(5),
+				"PodsCreated":    timeAt(12),
+				"FirstScheduled": timeAt(20),
+				"AllScheduled":   nil,
+				"Running":        running,
+				"Finished":       completed,
+			},
+		},
+		{
+			name: "podgroup not found",
+			pods: []coreV1.Pod{scheduledPod(10, 20)},
+			expected: map[string]*metav1.Time{
+				"Created":        timeAt(0),
+				"Admitted":       nil,
+				"PodsCreated":    timeAt(10),
+				"FirstScheduled": timeAt(20),
+				"AllScheduled":   timeAt(20),
+				"Running":        running,
+				"Finished":       completed,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			timeline := BuildTimeline(job, tc.podGroup, tc.pods)
+			for _, entry := range timeline {
+				expected := tc.expected[entry.Milestone]
+				if (expected == nil) != (entry.Time == nil) {
+					t.Fatalf("milestone %s: expected %v, got %v", entry.Milestone, expected, entry.Time)
+				}
+				if expected != nil && !expected.Equal(entry.Time) {
+					t.Errorf("milestone %s: expected %v, got %v", entry.Milestone, expected, entry.Time)
+				}
+			}
+
+			var buf bytes.Buffer
+			PrintTimeline(timeline, &buf)
+			if !strings.Contains(buf.String(), "Timeline:") {
+				t.Errorf("expected timeline header in output, got %s", buf.String())
+			}
+		})
+	}
+}
+
+func TestInitDescribeFlags(t *testing.T) {
+	var cmd cobra.Command
+	InitDescribeFlags(&cmd)
+
+	if cmd.Flag("namespace") == nil {
+		t.Errorf("Could not find the flag namespace")
+	}
+	if cmd.Flag("name") == nil {
+		t.Errorf("Could not find the flag name")
+	}
+	if cmd.Flag("timeline") == nil {
+		t.Errorf("Could not find the flag timeline")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/cli/util"
+)
+
+type holdFlags struct {
+	util.CommonFlags
+	batchFlags
+
+	Namespace string
+	JobName   string
+}
+
+var holdJobFlags = &holdFlags{}
+
+// InitHoldFlags init hold command flags.
+func InitHoldFlags(cmd *cobra.Command) {
+	util.InitFlags(cmd, &holdJobFlags.CommonFlags)
+
+	cmd.Flags().StringVarP(&holdJobFlags.Namespace, "namespace", "n", "default", "the namespace of job")
+	cmd.Flags().StringVarP(&holdJobFlags.JobName, "name", "N", "", "the name of job")
+	initBatchFlags(cmd, &holdJobFlags.batchFlags)
+}
+
+// HoldJob holds the job by the job-suspend annotation, its pods are deleted and the scheduler skips it
+// until it is released.
+func HoldJob(ctx context.Context) error {
+	config, err := util.BuildConfig(holdJobFlags.Master, holdJobFlags.Kubeconfig, holdJobFlags.Context)
+	if err != nil {
+		return err
+	}
+	jobClient := versioned.NewForConfigOrDie(config)
+
+	if holdJobFlags.All {
+		if holdJobFlags.JobName != "" {
+			return fmt.Errorf("job name can not be specified with --all")
+		}
+		jobs, err := selectJobs(ctx, jobClient, holdJobFlags.Namespace, &holdJobFlags.batchFlags, canHold)
+		if err != nil {
+			return err
+		}
+		return applyToJobs(jobs, &holdJobFlags.batchFlags, "hold", os.Stdin, os.Stdout, func(job *v1alpha1.Job) error {
+			return setHold(ctx, jobClient, job.Namespace, job.Name, true)
+		})
+	}
+	if holdJobFlags.JobName == "" {
+		return fmt.Errorf("job name is mandatory to hold a particular job, or use --all")
+	}
+
+	return setHold(ctx, jobClient, holdJobFlags.Namespace, holdJobFlags.JobName, true)
+}
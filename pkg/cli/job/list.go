@@ -93,7 +93,7 @@ func InitListFlags(cmd *cobra.Command) {
 
 // ListJobs lists all jobs details.
 func ListJobs(ctx context.Context) error {
-	config, err := util.BuildConfig(listJobFlags.Master, listJobFlags.Kubeconfig)
+	config, err := util.BuildConfig(listJobFlags.Master, listJobFlags.Kubeconfig, listJobFlags.Context)
 	if err != nil {
 		return err
 	}
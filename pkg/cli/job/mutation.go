@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	vcbatch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+// serverManagedFields are the metadata fields set by the apiserver on every object, they are not mutations of webhooks.
+var serverManagedFields = []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "selfLink"}
+
+// mutation is a field of the job changed by the server.
+type mutation struct {
+	path   string
+	before interface{}
+	after  interface{}
+}
+
+// jobMutations returns the fields of the submitted job changed by the server, e.g. the defaults set by mutating webhooks.
+func jobMutations(submitted, created *vcbatch.Job) ([]mutation, error) {
+	before, err := jobContent(submitted)
+	if err != nil {
+		return nil, err
+	}
+	after, err := jobContent(created)
+	if err != nil {
+		return nil, err
+	}
+
+	var mutations []mutation
+	diffValue("", before, after, &mutations)
+	return mutations, nil
+}
+
+// jobContent returns the content of the job to compare, without status and the fields managed by the apiserver.
+func jobContent(job *vcbatch.Job) (map[string]interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert job %s: %v", job.Name, err)
+	}
+	delete(content, "status")
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		for _, field := range serverManagedFields {
+			delete(metadata, field)
+		}
+	}
+	return content, nil
+}
+
+func diffValue(path string, before, after interface{}, mutations *[]mutation) {
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if beforeIsMap && afterIsMap {
+		keys := map[string]struct{}{}
+		for key := range beforeMap {
+			keys[key] = struct{}{}
+		}
+		for key := range afterMap {
+			keys[key] = struct{}{}
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for key := range keys {
+			sortedKeys = append(sortedKeys, key)
+		}
+		sort.Strings(sortedKeys)
+		for _, key := range sortedKeys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			diffValue(childPath, beforeMap[key], afterMap[key], mutations)
+		}
+		return
+	}
+
+	beforeList, beforeIsList := before.([]interface{})
+	afterList, afterIsList := after.([]interface{})
+	if beforeIsList && afterIsList && len(beforeList) == len(afterList) {
+		for i := range beforeList {
+			diffValue(fmt.Sprintf("%s[%d]", path, i), beforeList[i], afterList[i], mutations)
+		}
+		return
+	}
+
+	*mutations = append(*mutations, mutation{path: path, before: before, after: after})
+}
+
+// printMutations prints the mutations in the form of a diff, a field is removed with `-` and added with `+`.
+func printMutations(w io.Writer, name string, mutations []mutation) {
+	if len(mutations) == 0 {
+		fmt.Fprintf(w, "job %v is not mutated\n", name)
+		return
+	}
+
+	fmt.Fprintf(w, "job %v is mutated:\n", name)
+	for _, m := range mutations {
+		fmt.Fprintf(w, "  %s:\n", m.path)
+		if m.before != nil {
+			fmt.Fprintf(w, "  - %s\n", formatValue(m.before))
+		}
+		if m.after != nil {
+			fmt.Fprintf(w, "  + %s\n", formatValue(m.after))
+		}
+	}
+}
+
+func formatValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
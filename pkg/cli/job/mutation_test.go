@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	vcbatch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+func TestJobMutations(t *testing.T) {
+	submitted := &vcbatch.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: vcbatch.JobSpec{
+			MinAvailable: 1,
+			Tasks:        []vcbatch.TaskSpec{{Replicas: 1}},
+		},
+	}
+	created := submitted.DeepCopy()
+	created.UID = types.UID("uid")
+	created.CreationTimestamp = metav1.Now()
+	created.Spec.Queue = "default"
+	created.Spec.MaxRetry = 3
+	created.Spec.Plugins = map[string][]string{"svc": {}}
+	created.Spec.Tasks[0].Name = "default0"
+	created.Status.State.Phase = vcbatch.Pending
+
+	mutations, err := jobMutations(submitted, created)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var paths []string
+	for _, m := range mutations {
+		paths = append(paths, m.path)
+	}
+	expected := []string{"spec.maxRetry", "spec.plugins", "spec.queue", "spec.tasks[0].name"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected mutations of %v, got %v", expected, paths)
+	}
+
+	var out bytes.Buffer
+	printMutations(&out, "test", mutations)
+	for _, line := range []string{"  spec.queue:\n  + \"default\"\n", "  spec.tasks[0].name:\n  + \"default0\"\n", "  + {\"svc\":[]}\n"} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("expected output to contain %q, got:\n%s", line, out.String())
+		}
+	}
+
+	mutations, err = jobMutations(submitted, submitted.DeepCopy())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out.Reset()
+	printMutations(&out, "test", mutations)
+	if out.String() != "job test is not mutated\n" {
+		t.Errorf("expected no mutation, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/cli/util"
+)
+
+type releaseFlags struct {
+	util.CommonFlags
+	batchFlags
+
+	Namespace string
+	JobName   string
+}
+
+var releaseJobFlags = &releaseFlags{}
+
+// InitReleaseFlags init release command flags.
+func InitReleaseFlags(cmd *cobra.Command) {
+	util.InitFlags(cmd, &releaseJobFlags.CommonFlags)
+
+	cmd.Flags().StringVarP(&releaseJobFlags.Namespace, "namespace", "n", "default", "the namespace of job")
+	cmd.Flags().StringVarP(&releaseJobFlags.JobName, "name", "N", "", "the name of job")
+	initBatchFlags(cmd, &releaseJobFlags.batchFlags)
+}
+
+// ReleaseJob releases the job held by the job-suspend annotation.
+func ReleaseJob(ctx context.Context) error {
+	config, err := util.BuildConfig(releaseJobFlags.Master, releaseJobFlags.Kubeconfig, releaseJobFlags.Context)
+	if err != nil {
+		return err
+	}
+	jobClient := versioned.NewForConfigOrDie(config)
+
+	if releaseJobFlags.All {
+		if releaseJobFlags.JobName != "" {
+			return fmt.Errorf("job name can not be specified with --all")
+		}
+		jobs, err := selectJobs(ctx, jobClient, releaseJobFlags.Namespace, &releaseJobFlags.batchFlags, isHeld)
+		if err != nil {
+			return err
+		}
+		return applyToJobs(jobs, &releaseJobFlags.batchFlags, "release", os.Stdin, os.Stdout, func(job *v1alpha1.Job) error {
+			return setHold(ctx, jobClient, job.Namespace, job.Name, false)
+		})
+	}
+	if releaseJobFlags.JobName == "" {
+		return fmt.Errorf("job name is mandatory to release a particular job, or use --all")
+	}
+
+	return setHold(ctx, jobClient, releaseJobFlags.Namespace, releaseJobFlags.JobName, false)
+}
@@ -19,15 +19,19 @@ package job
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	"volcano.sh/apis/pkg/apis/bus/v1alpha1"
+	"volcano.sh/apis/pkg/client/clientset/versioned"
 	"volcano.sh/volcano/pkg/cli/util"
 )
 
 type resumeFlags struct {
 	util.CommonFlags
+	batchFlags
 
 	Namespace string
 	JobName   string
@@ -41,16 +45,29 @@ func InitResumeFlags(cmd *cobra.Command) {
 
 	cmd.Flags().StringVarP(&resumeJobFlags.Namespace, "namespace", "n", "default", "the namespace of job")
 	cmd.Flags().StringVarP(&resumeJobFlags.JobName, "name", "N", "", "the name of job")
+	initBatchFlags(cmd, &resumeJobFlags.batchFlags)
 }
 
 // ResumeJob resumes the job.
 func ResumeJob(ctx context.Context) error {
-	config, err := util.BuildConfig(resumeJobFlags.Master, resumeJobFlags.Kubeconfig)
+	config, err := util.BuildConfig(resumeJobFlags.Master, resumeJobFlags.Kubeconfig, resumeJobFlags.Context)
 	if err != nil {
 		return err
 	}
+	if resumeJobFlags.All {
+		if resumeJobFlags.JobName != "" {
+			return fmt.Errorf("job name can not be specified with --all")
+		}
+		jobs, err := selectJobs(ctx, versioned.NewForConfigOrDie(config), resumeJobFlags.Namespace, &resumeJobFlags.batchFlags, isAborted)
+		if err != nil {
+			return err
+		}
+		return applyToJobs(jobs, &resumeJobFlags.batchFlags, "resume", os.Stdin, os.Stdout, func(job *batchv1alpha1.Job) error {
+			return util.CreateJobCommand(ctx, config, job.Namespace, job.Name, v1alpha1.ResumeJobAction)
+		})
+	}
 	if resumeJobFlags.JobName == "" {
-		err := fmt.Errorf("job name is mandatory to resume a particular job")
+		err := fmt.Errorf("job name is mandatory to resume a particular job, or use --all")
 		return err
 	}
 
@@ -22,13 +22,16 @@ import (
 	"os"
 	"strings"
 
+	"github.com/google/shlex"
 	"github.com/spf13/cobra"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
 	vcbatch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
 	"volcano.sh/apis/pkg/client/clientset/versioned"
 	"volcano.sh/volcano/pkg/cli/util"
 )
@@ -45,9 +48,23 @@ type runFlags struct {
 	Requests      string
 	Limits        string
 	SchedulerName string
+	Queue         string
+	GPU           int
+	Command       string
 	FileName      string
+	DryRun        string
+	ShowMutation  bool
 }
 
+const (
+	// dryRunNone submits the job.
+	dryRunNone = "none"
+	// dryRunClient prints the job to be submitted as yaml without submitting it.
+	dryRunClient = "client"
+	// dryRunServer submits the job to the server without persisting it, the job is admitted by the webhooks.
+	dryRunServer = "server"
+)
+
 var launchJobFlags = &runFlags{}
 
 // InitRunFlags init the run flags.
@@ -57,26 +74,38 @@ func InitRunFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&launchJobFlags.Image, "image", "i", "busybox", "the container image of job")
 	cmd.Flags().StringVarP(&launchJobFlags.Namespace, "namespace", "n", "default", "the namespace of job")
 	cmd.Flags().StringVarP(&launchJobFlags.Name, "name", "N", "", "the name of job")
-	cmd.Flags().IntVarP(&launchJobFlags.MinAvailable, "min", "m", 1, "the minimal available tasks of job")
+	cmd.Flags().IntVarP(&launchJobFlags.MinAvailable, "min", "m", 0, "the minimal available tasks of job, all the tasks are scheduled as a gang if not set")
 	cmd.Flags().IntVarP(&launchJobFlags.Replicas, "replicas", "r", 1, "the total tasks of job")
 	cmd.Flags().StringVarP(&launchJobFlags.Requests, "requests", "R", "cpu=1000m,memory=100Mi", "the resource request of the task")
 	cmd.Flags().StringVarP(&launchJobFlags.Limits, "limits", "L", "cpu=1000m,memory=100Mi", "the resource limit of the task")
 	cmd.Flags().StringVarP(&launchJobFlags.SchedulerName, "scheduler", "S", "volcano", "the scheduler for this job")
+	cmd.Flags().StringVarP(&launchJobFlags.Queue, "queue", "q", "", "the queue of job, the default queue of the namespace if not set")
+	cmd.Flags().IntVar(&launchJobFlags.GPU, "gpu", 0, "the nvidia.com/gpu requested by each task, added to the requests and limits")
+	cmd.Flags().StringVar(&launchJobFlags.Command, "cmd", "", "the command of the tasks, e.g. 'python train.py --epochs 10'")
 	cmd.Flags().StringVarP(&launchJobFlags.FileName, "filename", "f", "", "the yaml file of job")
+	cmd.Flags().StringVar(&launchJobFlags.DryRun, "dry-run", dryRunNone, "must be \"none\", \"client\" or \"server\", "+
+		"if client, print the job as yaml without submitting it, if server, submit the job without persisting it")
+	cmd.Flags().BoolVar(&launchJobFlags.ShowMutation, "show-mutation", false, "print the fields of the job changed by the mutating webhooks")
 }
 
 var jobName = "job.volcano.sh"
 
+const gpuResourceName v1.ResourceName = "nvidia.com/gpu"
+
 // RunJob creates the job.
 func RunJob(ctx context.Context) error {
-	config, err := util.BuildConfig(launchJobFlags.Master, launchJobFlags.Kubeconfig)
-	if err != nil {
+	if launchJobFlags.Name == "" && launchJobFlags.FileName == "" {
+		err := fmt.Errorf("job name cannot be left blank")
 		return err
 	}
 
-	if launchJobFlags.Name == "" && launchJobFlags.FileName == "" {
-		err = fmt.Errorf("job name cannot be left blank")
-		return err
+	createOptions := metav1.CreateOptions{}
+	switch launchJobFlags.DryRun {
+	case "", dryRunNone, dryRunClient:
+	case dryRunServer:
+		createOptions.DryRun = []string{metav1.DryRunAll}
+	default:
+		return fmt.Errorf("invalid dry-run value %q, must be %q, %q or %q", launchJobFlags.DryRun, dryRunNone, dryRunClient, dryRunServer)
 	}
 
 	req, err := util.PopulateResourceListV1(launchJobFlags.Requests)
@@ -95,15 +124,45 @@ func RunJob(ctx context.Context) error {
 	}
 
 	if job == nil {
-		job = constructLaunchJobFlagsJob(launchJobFlags, req, limit)
+		job, err = constructLaunchJobFlagsJob(launchJobFlags, req, limit)
+		if err != nil {
+			return err
+		}
+	}
+
+	if launchJobFlags.DryRun == dryRunClient {
+		return printJobYaml(job)
+	}
+
+	config, err := util.BuildConfig(launchJobFlags.Master, launchJobFlags.Kubeconfig, launchJobFlags.Context)
+	if err != nil {
+		return err
 	}
 
+	submitted := job.DeepCopy()
 	jobClient := versioned.NewForConfigOrDie(config)
-	newJob, err := jobClient.BatchV1alpha1().Jobs(launchJobFlags.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	newJob, err := jobClient.BatchV1alpha1().Jobs(launchJobFlags.Namespace).Create(ctx, job, createOptions)
 	if err != nil {
 		return err
 	}
 
+	if launchJobFlags.ShowMutation {
+		// the namespace is set by the apiserver from the request
+		if submitted.Namespace == "" {
+			submitted.Namespace = newJob.Namespace
+		}
+		mutations, err := jobMutations(submitted, newJob)
+		if err != nil {
+			return err
+		}
+		printMutations(os.Stdout, newJob.Name, mutations)
+	}
+
+	if len(createOptions.DryRun) != 0 {
+		fmt.Printf("run job %v successfully (server dry run)\n", newJob.Name)
+		return nil
+	}
+
 	if newJob.Spec.Queue == "" {
 		newJob.Spec.Queue = "default"
 	}
@@ -135,15 +194,59 @@ func readFile(filename string) (*vcbatch.Job, error) {
 	return &job, nil
 }
 
-func constructLaunchJobFlagsJob(launchJobFlags *runFlags, req, limit v1.ResourceList) *vcbatch.Job {
+// printJobYaml prints the job as a manifest which can be submitted by kubectl or vcctl job run -f.
+func printJobYaml(job *vcbatch.Job) error {
+	job = job.DeepCopy()
+	job.APIVersion = vcbatch.SchemeGroupVersion.String()
+	job.Kind = "Job"
+	out, err := yaml.Marshal(job)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(out))
+	return nil
+}
+
+// constructLaunchJobFlagsJob builds the job of a single task from the flags. All the tasks are scheduled as a gang
+// unless the minimal available tasks are set, and the job is restarted once any of its pods is evicted.
+func constructLaunchJobFlagsJob(launchJobFlags *runFlags, req, limit v1.ResourceList) (*vcbatch.Job, error) {
+	var commands []string
+	if launchJobFlags.Command != "" {
+		var err error
+		if commands, err = shlex.Split(launchJobFlags.Command); err != nil {
+			return nil, err
+		}
+	}
+
+	minAvailable := launchJobFlags.MinAvailable
+	if minAvailable <= 0 {
+		minAvailable = launchJobFlags.Replicas
+	}
+
+	if launchJobFlags.GPU > 0 {
+		gpus := *resource.NewQuantity(int64(launchJobFlags.GPU), resource.DecimalSI)
+		if req == nil {
+			req = v1.ResourceList{}
+		}
+		if limit == nil {
+			limit = v1.ResourceList{}
+		}
+		req[gpuResourceName] = gpus
+		limit[gpuResourceName] = gpus
+	}
+
 	return &vcbatch.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      launchJobFlags.Name,
 			Namespace: launchJobFlags.Namespace,
 		},
 		Spec: vcbatch.JobSpec{
-			MinAvailable:  int32(launchJobFlags.MinAvailable),
+			MinAvailable:  int32(minAvailable),
 			SchedulerName: launchJobFlags.SchedulerName,
+			Queue:         launchJobFlags.Queue,
+			Policies: []vcbatch.LifecyclePolicy{
+				{Event: busv1alpha1.PodEvictedEvent, Action: busv1alpha1.RestartJobAction},
+			},
 			Tasks: []vcbatch.TaskSpec{
 				{
 					Replicas: int32(launchJobFlags.Replicas),
@@ -160,6 +263,7 @@ func constructLaunchJobFlagsJob(launchJobFlags *runFlags, req, limit v1.Resource
 									Image:           launchJobFlags.Image,
 									Name:            launchJobFlags.Name,
 									ImagePullPolicy: v1.PullIfNotPresent,
+									Command:         commands,
 									Resources: v1.ResourceRequirements{
 										Limits:   limit,
 										Requests: req,
@@ -171,5 +275,5 @@ func constructLaunchJobFlagsJob(launchJobFlags *runFlags, req, limit v1.Resource
 				},
 			},
 		},
-	}
+	}, nil
 }
@@ -22,13 +22,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 	"volcano.sh/volcano/pkg/cli/util"
 
 	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
 
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
 )
 
 func TestCreateJob(t *testing.T) {
@@ -91,6 +94,74 @@ func TestCreateJob(t *testing.T) {
 
 }
 
+func TestRunJobDryRun(t *testing.T) {
+	var dryRun []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dryRun = r.URL.Query()["dryRun"]
+		job := v1alpha1.Job{}
+		if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+			t.Errorf("failed to decode job: %v", err)
+		}
+		job.Spec.Queue = "default"
+		w.Header().Set("Content-Type", "application/json")
+		val, err := json.Marshal(job)
+		if err == nil {
+			w.Write(val)
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	testCases := []struct {
+		Name           string
+		DryRun         string
+		ExpectedDryRun []string
+		ExpectErr      bool
+	}{
+		{
+			Name:   "not dry run",
+			DryRun: "none",
+		},
+		{
+			Name:           "server dry run",
+			DryRun:         "server",
+			ExpectedDryRun: []string{"All"},
+		},
+		{
+			Name:   "client dry run",
+			DryRun: "client",
+		},
+		{
+			Name:      "invalid dry run",
+			DryRun:    "local",
+			ExpectErr: true,
+		},
+	}
+
+	for _, testcase := range testCases {
+		dryRun = nil
+		launchJobFlags = &runFlags{
+			CommonFlags: util.CommonFlags{
+				Master: server.URL,
+			},
+			Name:         "test",
+			Namespace:    "test",
+			Requests:     "cpu=1000m,memory=100Mi",
+			DryRun:       testcase.DryRun,
+			ShowMutation: true,
+		}
+
+		err := RunJob(context.TODO())
+		if (err != nil) != testcase.ExpectErr {
+			t.Errorf("case %s: expected error %v, got %v", testcase.Name, testcase.ExpectErr, err)
+		}
+		if strings.Join(dryRun, ",") != strings.Join(testcase.ExpectedDryRun, ",") {
+			t.Errorf("case %s: expected dry run %v, got %v", testcase.Name, testcase.ExpectedDryRun, dryRun)
+		}
+	}
+}
+
 func TestInitRunFlags(t *testing.T) {
 	var cmd cobra.Command
 	InitRunFlags(&cmd)
@@ -116,5 +187,64 @@ func TestInitRunFlags(t *testing.T) {
 	if cmd.Flag("limits") == nil {
 		t.Errorf("Could not find the flag limits")
 	}
+	if cmd.Flag("dry-run") == nil {
+		t.Errorf("Could not find the flag dry-run")
+	}
+	if cmd.Flag("show-mutation") == nil {
+		t.Errorf("Could not find the flag show-mutation")
+	}
+	if cmd.Flag("queue") == nil {
+		t.Errorf("Could not find the flag queue")
+	}
+	if cmd.Flag("gpu") == nil {
+		t.Errorf("Could not find the flag gpu")
+	}
+	if cmd.Flag("cmd") == nil {
+		t.Errorf("Could not find the flag cmd")
+	}
+
+}
+
+func TestConstructLaunchJobFlagsJob(t *testing.T) {
+	flags := &runFlags{
+		Name:      "train",
+		Namespace: "test",
+		Image:     "pytorch",
+		Replicas:  4,
+		Queue:     "q1",
+		GPU:       2,
+		Command:   "python train.py --epochs 'ten epochs'",
+	}
+	req, err := util.PopulateResourceListV1("cpu=1000m,memory=100Mi")
+	if err != nil {
+		t.Fatalf("failed to parse requests: %v", err)
+	}
+	job, err := constructLaunchJobFlagsJob(flags, req, nil)
+	if err != nil {
+		t.Fatalf("failed to construct job: %v", err)
+	}
+
+	if job.Spec.MinAvailable != 4 {
+		t.Errorf("expected all the 4 tasks scheduled as a gang, got minAvailable %d", job.Spec.MinAvailable)
+	}
+	if job.Spec.Queue != "q1" {
+		t.Errorf("expected queue q1, got %s", job.Spec.Queue)
+	}
+	if len(job.Spec.Policies) != 1 || job.Spec.Policies[0].Action != busv1alpha1.RestartJobAction {
+		t.Errorf("expected the job restarted once its pods are evicted, got %v", job.Spec.Policies)
+	}
+	container := job.Spec.Tasks[0].Template.Spec.Containers[0]
+	if got := strings.Join(container.Command, "|"); got != "python|train.py|--epochs|ten epochs" {
+		t.Errorf("unexpected command %q", got)
+	}
+	for _, list := range []v1.ResourceList{container.Resources.Requests, container.Resources.Limits} {
+		if gpus := list[gpuResourceName]; gpus.Value() != 2 {
+			t.Errorf("expected 2 gpus in %v", list)
+		}
+	}
 
+	flags.MinAvailable = 1
+	if job, _ = constructLaunchJobFlagsJob(flags, req, nil); job.Spec.MinAvailable != 1 {
+		t.Errorf("expected the minimal available tasks set by flag, got %d", job.Spec.MinAvailable)
+	}
 }
@@ -19,15 +19,19 @@ package job
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	"volcano.sh/apis/pkg/apis/bus/v1alpha1"
+	"volcano.sh/apis/pkg/client/clientset/versioned"
 	"volcano.sh/volcano/pkg/cli/util"
 )
 
 type suspendFlags struct {
 	util.CommonFlags
+	batchFlags
 
 	Namespace string
 	JobName   string
@@ -41,17 +45,30 @@ func InitSuspendFlags(cmd *cobra.Command) {
 
 	cmd.Flags().StringVarP(&suspendJobFlags.Namespace, "namespace", "n", "default", "the namespace of job")
 	cmd.Flags().StringVarP(&suspendJobFlags.JobName, "name", "N", "", "the name of job")
+	initBatchFlags(cmd, &suspendJobFlags.batchFlags)
 }
 
 // SuspendJob suspends the job.
 func SuspendJob(ctx context.Context) error {
-	config, err := util.BuildConfig(suspendJobFlags.Master, suspendJobFlags.Kubeconfig)
+	config, err := util.BuildConfig(suspendJobFlags.Master, suspendJobFlags.Kubeconfig, suspendJobFlags.Context)
 	if err != nil {
 		return err
 	}
 
+	if suspendJobFlags.All {
+		if suspendJobFlags.JobName != "" {
+			return fmt.Errorf("job name can not be specified with --all")
+		}
+		jobs, err := selectJobs(ctx, versioned.NewForConfigOrDie(config), suspendJobFlags.Namespace, &suspendJobFlags.batchFlags, canAbort)
+		if err != nil {
+			return err
+		}
+		return applyToJobs(jobs, &suspendJobFlags.batchFlags, "suspend", os.Stdin, os.Stdout, func(job *batchv1alpha1.Job) error {
+			return util.CreateJobCommand(ctx, config, job.Namespace, job.Name, v1alpha1.AbortJobAction)
+		})
+	}
 	if suspendJobFlags.JobName == "" {
-		err := fmt.Errorf("job name is mandatory to suspend a particular job")
+		err := fmt.Errorf("job name is mandatory to suspend a particular job, or use --all")
 		return err
 	}
 
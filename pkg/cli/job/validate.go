@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"volcano.sh/volcano/pkg/webhooks/admission/jobs/mutate"
+	"volcano.sh/volcano/pkg/webhooks/admission/jobs/validate"
+)
+
+type validateFlags struct {
+	FileName string
+}
+
+var validateJobFlags = &validateFlags{}
+
+// InitValidateFlags init the validate command flags.
+func InitValidateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&validateJobFlags.FileName, "filename", "f", "", "the yaml file of job")
+}
+
+// ValidateJob validates the job manifest locally with the same rules as the admission webhook,
+// no cluster access is required.
+func ValidateJob() error {
+	if validateJobFlags.FileName == "" {
+		return fmt.Errorf("job file (specified by --filename or -f) is mandatory to validate a job")
+	}
+
+	job, err := readFile(validateJobFlags.FileName)
+	if err != nil {
+		return err
+	}
+
+	// the namespace is set by the apiserver when the job is created
+	if job.Namespace == "" {
+		job.Namespace = "default"
+	}
+
+	// the job is validated as the webhook does after the mutating webhook sets the defaults, e.g. the task names
+	job, err = mutate.DefaultJob(job)
+	if err != nil {
+		return fmt.Errorf("failed to apply the defaults of job %s: %v", validateJobFlags.FileName, err)
+	}
+	if msg := validate.ValidateJob(job); msg != "" {
+		return fmt.Errorf("job %s is invalid: %s", job.Name, strings.TrimSpace(msg))
+	}
+
+	fmt.Printf("job %v is valid\n", job.Name)
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+const validJobManifest = `
+apiVersion: batch.volcano.sh/v1alpha1
+kind: Job
+metadata:
+  name: test-job
+spec:
+  minAvailable: 1
+  queue: default
+  tasks:
+  - name: worker
+    replicas: 1
+    template:
+      spec:
+        restartPolicy: Never
+        containers:
+        - name: worker
+          image: busybox
+`
+
+const invalidJobManifest = `
+apiVersion: batch.volcano.sh/v1alpha1
+kind: Job
+metadata:
+  name: test-job
+spec:
+  minAvailable: 3
+  queue: default
+  tasks:
+  - name: worker
+    replicas: 1
+    template:
+      spec:
+        restartPolicy: Never
+        containers:
+        - name: worker
+          image: busybox
+`
+
+// defaultedJobManifest is valid once the defaults of the mutating webhook are set, e.g. the task names.
+const defaultedJobManifest = `
+apiVersion: batch.volcano.sh/v1alpha1
+kind: Job
+metadata:
+  name: test-job
+spec:
+  tasks:
+  - replicas: 1
+    template:
+      spec:
+        restartPolicy: Never
+        containers:
+        - name: worker
+          image: busybox
+  - replicas: 2
+    template:
+      spec:
+        restartPolicy: Never
+        containers:
+        - name: worker
+          image: busybox
+`
+
+func TestValidateJob(t *testing.T) {
+	dir := t.TempDir()
+	validFile := filepath.Join(dir, "valid.yaml")
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	defaultedFile := filepath.Join(dir, "defaulted.yaml")
+	if err := os.WriteFile(validFile, []byte(validJobManifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(defaultedFile, []byte(defaultedJobManifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(invalidFile, []byte(invalidJobManifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name        string
+		FileName    string
+		ExpectError bool
+	}{
+		{
+			Name:        "no file",
+			ExpectError: true,
+		},
+		{
+			Name:     "valid job",
+			FileName: validFile,
+		},
+		{
+			Name:     "valid job with defaults",
+			FileName: defaultedFile,
+		},
+		{
+			Name:        "minAvailable greater than replicas",
+			FileName:    invalidFile,
+			ExpectError: true,
+		},
+	}
+
+	for i, testcase := range testCases {
+		validateJobFlags = &validateFlags{FileName: testcase.FileName}
+
+		err := ValidateJob()
+		if (err != nil) != testcase.ExpectError {
+			t.Errorf("case %d (%s): expected error: %v, got %v", i, testcase.Name, testcase.ExpectError, err)
+		}
+	}
+}
+
+func TestInitValidateFlags(t *testing.T) {
+	var cmd cobra.Command
+	InitValidateFlags(&cmd)
+
+	if cmd.Flag("filename") == nil {
+		t.Errorf("Could not find the flag filename")
+	}
+}
@@ -62,7 +62,7 @@ func InitViewFlags(cmd *cobra.Command) {
 
 // ViewJob gives full details of the job.
 func ViewJob(ctx context.Context) error {
-	config, err := util.BuildConfig(viewJobFlags.Master, viewJobFlags.Kubeconfig)
+	config, err := util.BuildConfig(viewJobFlags.Master, viewJobFlags.Kubeconfig, viewJobFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -242,7 +242,7 @@ func GetEvents(ctx context.Context, config *rest.Config, job *v1alpha1.Job) []co
 		fmt.Printf("%v\n", err)
 		return nil
 	}
-	events, _ := kubernetes.CoreV1().Events(viewJobFlags.Namespace).List(ctx, metav1.ListOptions{})
+	events, _ := kubernetes.CoreV1().Events(job.Namespace).List(ctx, metav1.ListOptions{})
 	var jobEvents []coreV1.Event
 	for _, v := range events.Items {
 		if strings.HasPrefix(v.ObjectMeta.Name, job.Name+".") {
@@ -48,7 +48,7 @@ func InitCreateFlags(cmd *cobra.Command) {
 
 // CreateJobFlow create a jobflow.
 func CreateJobFlow(ctx context.Context) error {
-	config, err := util.BuildConfig(createJobFlowFlags.Master, createJobFlowFlags.Kubeconfig)
+	config, err := util.BuildConfig(createJobFlowFlags.Master, createJobFlowFlags.Kubeconfig, createJobFlowFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -55,7 +55,7 @@ func InitDeleteFlags(cmd *cobra.Command) {
 
 // DeleteJobFlow is used to delete a jobflow.
 func DeleteJobFlow(ctx context.Context) error {
-	config, err := util.BuildConfig(deleteJobFlowFlags.Master, deleteJobFlowFlags.Kubeconfig)
+	config, err := util.BuildConfig(deleteJobFlowFlags.Master, deleteJobFlowFlags.Kubeconfig, deleteJobFlowFlags.Context)
 	if err != nil {
 		return err
 	}
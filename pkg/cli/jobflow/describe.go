@@ -55,7 +55,7 @@ func InitDescribeFlags(cmd *cobra.Command) {
 
 // DescribeJobFlow is used to get the particular jobflow details.
 func DescribeJobFlow(ctx context.Context) error {
-	config, err := util.BuildConfig(describeJobFlowFlags.Master, describeJobFlowFlags.Kubeconfig)
+	config, err := util.BuildConfig(describeJobFlowFlags.Master, describeJobFlowFlags.Kubeconfig, describeJobFlowFlags.Context)
 	if err != nil {
 		return err
 	}
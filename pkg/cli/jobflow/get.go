@@ -50,7 +50,7 @@ func InitGetFlags(cmd *cobra.Command) {
 
 // GetJobFlow gets a jobflow.
 func GetJobFlow(ctx context.Context) error {
-	config, err := util.BuildConfig(getJobFlowFlags.Master, getJobFlowFlags.Kubeconfig)
+	config, err := util.BuildConfig(getJobFlowFlags.Master, getJobFlowFlags.Kubeconfig, getJobFlowFlags.Context)
 	if err != nil {
 		return err
 	}
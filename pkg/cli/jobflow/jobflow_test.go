@@ -19,6 +19,7 @@ package jobflow
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/spf13/cobra"
 	"io"
 	"net/http"
@@ -31,6 +32,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	flowv1alpha1 "volcano.sh/apis/pkg/apis/flow/v1alpha1"
 )
 
@@ -366,6 +368,88 @@ status:
 	}
 }
 
+func TestVisJobFlow(t *testing.T) {
+	jobFlow := &flowv1alpha1.JobFlow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-jobflow",
+			Namespace: "default",
+		},
+		Spec: flowv1alpha1.JobFlowSpec{
+			Flows: []flowv1alpha1.Flow{
+				{Name: "a"},
+				{Name: "b", DependsOn: &flowv1alpha1.DependsOn{Targets: []string{"a"}}},
+			},
+		},
+		Status: flowv1alpha1.JobFlowStatus{
+			JobStatusList: []flowv1alpha1.JobStatus{
+				{Name: "test-jobflow-a", State: batchv1alpha1.Completed},
+			},
+		},
+	}
+	testCases := []struct {
+		name           string
+		Response       *flowv1alpha1.JobFlow
+		Name           string
+		Format         string
+		ExpectedErr    error
+		ExpectedOutput string
+	}{
+		{
+			name:     "Normal Case, use dot format",
+			Response: jobFlow,
+			Name:     "test-jobflow",
+			Format:   DOT,
+			ExpectedOutput: `digraph "test-jobflow" {
+  rankdir=LR;
+  node [shape=box, style=filled];
+  "a" [label="a\nCompleted", fillcolor="#b5e8b0"];
+  "b" [label="b\nNotCreated", fillcolor="#e0e0e0"];
+  "a" -> "b";
+}`,
+		},
+		{
+			name:     "Normal Case, use mermaid format",
+			Response: jobFlow,
+			Name:     "test-jobflow",
+			Format:   Mermaid,
+			ExpectedOutput: `graph LR
+  f0["a<br/>Completed"]:::completed
+  f1["b<br/>NotCreated"]:::notcreated
+  f0 --> f1
+  classDef completed fill:#b5e8b0
+  classDef notcreated fill:#e0e0e0`,
+		},
+		{
+			name:        "Unsupported format",
+			Response:    jobFlow,
+			Name:        "test-jobflow",
+			Format:      "png",
+			ExpectedErr: fmt.Errorf("unsupported format: png, only dot and mermaid are supported"),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := createTestServer(testCase.Response)
+			defer server.Close()
+			visJobFlowFlags.Master = server.URL
+			visJobFlowFlags.Namespace = "default"
+			visJobFlowFlags.Name = testCase.Name
+			visJobFlowFlags.Format = testCase.Format
+
+			r, oldStdout := redirectStdout()
+			defer r.Close()
+			err := VisJobFlow(context.TODO())
+			gotOutput := captureOutput(r, oldStdout)
+			if !reflect.DeepEqual(err, testCase.ExpectedErr) {
+				t.Fatalf("test case: %s failed: got: %v, want: %v", testCase.name, err, testCase.ExpectedErr)
+			}
+			if gotOutput != testCase.ExpectedOutput {
+				t.Fatalf("test case: %s failed: got: %s, want: %s", testCase.name, gotOutput, testCase.ExpectedOutput)
+			}
+		})
+	}
+}
+
 func createTestServer(response interface{}) *httptest.Server {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -455,6 +539,20 @@ func TestInitDescribeFlags(t *testing.T) {
 	}
 }
 
+func TestInitVisFlags(t *testing.T) {
+	var cmd cobra.Command
+	InitVisFlags(&cmd)
+	if cmd.Flag("name") == nil {
+		t.Errorf("Could not find the flag name")
+	}
+	if cmd.Flag("namespace") == nil {
+		t.Errorf("Could not find the flag namespace")
+	}
+	if cmd.Flag("format") == nil {
+		t.Errorf("Could not find the flag format")
+	}
+}
+
 func TestInitDeleteFlags(t *testing.T) {
 	var cmd cobra.Command
 	InitDeleteFlags(&cmd)
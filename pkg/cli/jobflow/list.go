@@ -63,7 +63,7 @@ func InitListFlags(cmd *cobra.Command) {
 
 // ListJobFlow lists all jobflow.
 func ListJobFlow(ctx context.Context) error {
-	config, err := util.BuildConfig(listJobFlowFlags.Master, listJobFlowFlags.Kubeconfig)
+	config, err := util.BuildConfig(listJobFlowFlags.Master, listJobFlowFlags.Kubeconfig, listJobFlowFlags.Context)
 	if err != nil {
 		return err
 	}
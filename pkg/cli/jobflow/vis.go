@@ -0,0 +1,205 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/apis/flow/v1alpha1"
+	"volcano.sh/apis/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/cli/util"
+)
+
+const (
+	// DOT is the graphviz format of the jobflow graph
+	DOT string = "dot"
+	// Mermaid is the mermaid format of the jobflow graph
+	Mermaid string = "mermaid"
+	// NotCreated is the state of the flows whose job is not created yet
+	NotCreated string = "NotCreated"
+)
+
+type visFlags struct {
+	util.CommonFlags
+	// Name of the jobflow
+	Name string
+	// Namespace of the jobflow
+	Namespace string
+	// Format of the graph: dot or mermaid
+	Format string
+}
+
+var visJobFlowFlags = &visFlags{}
+
+// InitVisFlags is used to init all flags.
+func InitVisFlags(cmd *cobra.Command) {
+	util.InitFlags(cmd, &visJobFlowFlags.CommonFlags)
+	cmd.Flags().StringVarP(&visJobFlowFlags.Name, "name", "N", "", "the name of jobflow")
+	cmd.Flags().StringVarP(&visJobFlowFlags.Namespace, "namespace", "n", "default", "the namespace of jobflow")
+	cmd.Flags().StringVarP(&visJobFlowFlags.Format, "format", "o", DOT, "the format of the graph: dot or mermaid")
+}
+
+// VisJobFlow prints the DAG of a jobflow, colored by the state of the job of each flow.
+func VisJobFlow(ctx context.Context) error {
+	config, err := util.BuildConfig(visJobFlowFlags.Master, visJobFlowFlags.Kubeconfig, visJobFlowFlags.Context)
+	if err != nil {
+		return err
+	}
+
+	if visJobFlowFlags.Name == "" {
+		err := fmt.Errorf("name is mandatory to visualize the particular jobflow")
+		return err
+	}
+	if visJobFlowFlags.Format != DOT && visJobFlowFlags.Format != Mermaid {
+		return fmt.Errorf("unsupported format: %s, only %s and %s are supported", visJobFlowFlags.Format, DOT, Mermaid)
+	}
+
+	jobFlowClient := versioned.NewForConfigOrDie(config)
+	jobFlow, err := jobFlowClient.FlowV1alpha1().JobFlows(visJobFlowFlags.Namespace).Get(ctx, visJobFlowFlags.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	PrintJobFlowGraph(jobFlow, visJobFlowFlags.Format, os.Stdout)
+
+	return nil
+}
+
+// PrintJobFlowGraph prints the DAG of the jobflow in dot or mermaid format.
+func PrintJobFlowGraph(jobFlow *v1alpha1.JobFlow, format string, writer io.Writer) {
+	var graph string
+	switch format {
+	case Mermaid:
+		graph = buildMermaidGraph(jobFlow)
+	default:
+		graph = buildDOTGraph(jobFlow)
+	}
+	if _, err := fmt.Fprint(writer, graph); err != nil {
+		fmt.Printf("Failed to print JobFlow command result: %s.\n", err)
+	}
+}
+
+func buildDOTGraph(jobFlow *v1alpha1.JobFlow) string {
+	states := flowStates(jobFlow)
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", jobFlow.Name)
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=filled];\n")
+	for _, flow := range jobFlow.Spec.Flows {
+		state := states[flow.Name]
+		fmt.Fprintf(&b, "  %q [label=\"%s\\n%s\", fillcolor=%q];\n", flow.Name, flow.Name, state, stateColor(state))
+	}
+	for _, flow := range jobFlow.Spec.Flows {
+		if flow.DependsOn == nil {
+			continue
+		}
+		for _, target := range flow.DependsOn.Targets {
+			fmt.Fprintf(&b, "  %q -> %q;\n", target, flow.Name)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func buildMermaidGraph(jobFlow *v1alpha1.JobFlow) string {
+	states := flowStates(jobFlow)
+	// Mermaid node ids can not contain '-', so the flows are referred to by index.
+	ids := make(map[string]string, len(jobFlow.Spec.Flows))
+	for i, flow := range jobFlow.Spec.Flows {
+		ids[flow.Name] = fmt.Sprintf("f%d", i)
+	}
+	nodeID := func(name string) string {
+		if id, found := ids[name]; found {
+			return id
+		}
+		// The target is not a flow of the jobflow, refer to it by its name.
+		return strings.ReplaceAll(name, "-", "_")
+	}
+
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for _, flow := range jobFlow.Spec.Flows {
+		fmt.Fprintf(&b, "  %s[\"%s<br/>%s\"]:::%s\n", nodeID(flow.Name), flow.Name, states[flow.Name], strings.ToLower(states[flow.Name]))
+	}
+	for _, flow := range jobFlow.Spec.Flows {
+		if flow.DependsOn == nil {
+			continue
+		}
+		for _, target := range flow.DependsOn.Targets {
+			fmt.Fprintf(&b, "  %s --> %s\n", nodeID(target), nodeID(flow.Name))
+		}
+	}
+	for _, state := range usedStates(jobFlow, states) {
+		fmt.Fprintf(&b, "  classDef %s fill:%s\n", strings.ToLower(state), stateColor(state))
+	}
+	return b.String()
+}
+
+// flowStates returns the state of the job of each flow, NotCreated if the job is not created yet.
+func flowStates(jobFlow *v1alpha1.JobFlow) map[string]string {
+	jobStates := make(map[string]string, len(jobFlow.Status.JobStatusList))
+	for _, jobStatus := range jobFlow.Status.JobStatusList {
+		jobStates[jobStatus.Name] = string(jobStatus.State)
+	}
+	states := make(map[string]string, len(jobFlow.Spec.Flows))
+	for _, flow := range jobFlow.Spec.Flows {
+		state, found := jobStates[jobFlow.Name+"-"+flow.Name]
+		if !found || state == "" {
+			state = NotCreated
+		}
+		states[flow.Name] = state
+	}
+	return states
+}
+
+// usedStates returns the distinct states of the flows in the order of the flows.
+func usedStates(jobFlow *v1alpha1.JobFlow, states map[string]string) []string {
+	var used []string
+	seen := map[string]bool{}
+	for _, flow := range jobFlow.Spec.Flows {
+		state := states[flow.Name]
+		if !seen[state] {
+			seen[state] = true
+			used = append(used, state)
+		}
+	}
+	return used
+}
+
+func stateColor(state string) string {
+	switch batchv1alpha1.JobPhase(state) {
+	case batchv1alpha1.Pending:
+		return "#fff2a8"
+	case batchv1alpha1.Running, batchv1alpha1.Restarting, batchv1alpha1.Completing:
+		return "#a8d1ff"
+	case batchv1alpha1.Completed:
+		return "#b5e8b0"
+	case batchv1alpha1.Failed, batchv1alpha1.Terminating, batchv1alpha1.Terminated, batchv1alpha1.Aborting, batchv1alpha1.Aborted:
+		return "#f5a9a9"
+	default:
+		return "#e0e0e0"
+	}
+}
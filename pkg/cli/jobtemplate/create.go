@@ -48,7 +48,7 @@ func InitCreateFlags(cmd *cobra.Command) {
 
 // CreateJobTemplate create a job template.
 func CreateJobTemplate(ctx context.Context) error {
-	config, err := util.BuildConfig(createJobTemplateFlags.Master, createJobTemplateFlags.Kubeconfig)
+	config, err := util.BuildConfig(createJobTemplateFlags.Master, createJobTemplateFlags.Kubeconfig, createJobTemplateFlags.Context)
 	if err != nil {
 		return err
 	}
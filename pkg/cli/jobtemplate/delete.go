@@ -55,7 +55,7 @@ func InitDeleteFlags(cmd *cobra.Command) {
 
 // DeleteJobTemplate is used to delete a job template.
 func DeleteJobTemplate(ctx context.Context) error {
-	config, err := util.BuildConfig(deleteJobTemplateFlags.Master, deleteJobTemplateFlags.Kubeconfig)
+	config, err := util.BuildConfig(deleteJobTemplateFlags.Master, deleteJobTemplateFlags.Kubeconfig, deleteJobTemplateFlags.Context)
 	if err != nil {
 		return err
 	}
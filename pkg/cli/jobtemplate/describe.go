@@ -55,7 +55,7 @@ func InitDescribeFlags(cmd *cobra.Command) {
 
 // DescribeJobTemplate is used to get the particular job template details.
 func DescribeJobTemplate(ctx context.Context) error {
-	config, err := util.BuildConfig(describeJobTemplateFlags.Master, describeJobTemplateFlags.Kubeconfig)
+	config, err := util.BuildConfig(describeJobTemplateFlags.Master, describeJobTemplateFlags.Kubeconfig, describeJobTemplateFlags.Context)
 	if err != nil {
 		return err
 	}
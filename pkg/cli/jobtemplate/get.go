@@ -50,7 +50,7 @@ func InitGetFlags(cmd *cobra.Command) {
 
 // GetJobTemplate gets a job template.
 func GetJobTemplate(ctx context.Context) error {
-	config, err := util.BuildConfig(getJobTemplateFlags.Master, getJobTemplateFlags.Kubeconfig)
+	config, err := util.BuildConfig(getJobTemplateFlags.Master, getJobTemplateFlags.Kubeconfig, getJobTemplateFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -54,7 +54,7 @@ func InitListFlags(cmd *cobra.Command) {
 
 // ListJobTemplate lists all job templates.
 func ListJobTemplate(ctx context.Context) error {
-	config, err := util.BuildConfig(listJobTemplateFlags.Master, listJobTemplateFlags.Kubeconfig)
+	config, err := util.BuildConfig(listJobTemplateFlags.Master, listJobTemplateFlags.Kubeconfig, listJobTemplateFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,317 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclientset "k8s.io/client-go/kubernetes"
+
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/apis/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/cli/util"
+)
+
+type drainFlags struct {
+	util.CommonFlags
+
+	// Restart restarts the Volcano jobs of the gangs instead of evicting their pods
+	Restart bool
+	// DryRun prints the gangs to drain without cordoning the node or evicting any pod
+	DryRun bool
+	// Force drains the gangs in the queues which are not reclaimable
+	Force bool
+	// Yes drains the node without asking for confirmation
+	Yes bool
+}
+
+var drainNodeFlags = &drainFlags{}
+
+// InitDrainFlags init drain command flags.
+func InitDrainFlags(cmd *cobra.Command) {
+	util.InitFlags(cmd, &drainNodeFlags.CommonFlags)
+
+	cmd.Flags().BoolVar(&drainNodeFlags.Restart, "restart", false, "restart the volcano jobs of the gangs instead of evicting their pods")
+	cmd.Flags().BoolVar(&drainNodeFlags.DryRun, "dry-run", false, "only print the gangs to drain")
+	cmd.Flags().BoolVar(&drainNodeFlags.Force, "force", false, "drain the gangs in the queues which are not reclaimable")
+	cmd.Flags().BoolVarP(&drainNodeFlags.Yes, "yes", "y", false, "drain without asking for confirmation")
+}
+
+// DrainGang is a gang with members on the node being drained.
+type DrainGang struct {
+	Namespace string
+	// Name is the name of the PodGroup
+	Name  string
+	Queue string
+	// Job is the name of the Volcano job owning the PodGroup, empty if the PodGroup is not created for a Volcano job
+	Job string
+	// Priority is the highest priority of the pods of the gang
+	Priority int32
+	// Pods are the running pods of the gang on all nodes, as the whole gang is drained
+	Pods []*corev1.Pod
+	// PodsOnNode is the number of the pods of the gang on the node
+	PodsOnNode int
+	// Blocked is the reason the gang can not be drained, the gang is kept running as a whole if not empty
+	Blocked string
+}
+
+// DrainPlan is the gangs to drain from a node in order, and the pods on the node not belonging to any gang.
+type DrainPlan struct {
+	Node  string
+	Gangs []*DrainGang
+	// OtherPods are the pods on the node not scheduled in a gang, which are left to kubectl drain
+	OtherPods []string
+}
+
+// DrainNode cordons the node and drains the gangs with members on the node as a whole.
+func DrainNode(ctx context.Context, nodeName string) error {
+	config, err := util.BuildConfig(drainNodeFlags.Master, drainNodeFlags.Kubeconfig, drainNodeFlags.Context)
+	if err != nil {
+		return err
+	}
+	kubeClient := kubeclientset.NewForConfigOrDie(config)
+	vcClient := versioned.NewForConfigOrDie(config)
+
+	plan, err := PlanDrain(ctx, kubeClient, vcClient, nodeName, drainNodeFlags.Force)
+	if err != nil {
+		return err
+	}
+	PrintDrainPlan(plan, drainNodeFlags.Restart, os.Stdout)
+	if drainNodeFlags.DryRun || len(plan.Gangs) == 0 {
+		return nil
+	}
+	if !drainNodeFlags.Yes && !util.Confirm(os.Stdin, os.Stdout, fmt.Sprintf("Drain %d gangs from node %s?", len(plan.Gangs), nodeName)) {
+		fmt.Printf("Drain of node %s canceled\n", nodeName)
+		return nil
+	}
+
+	if err := CordonNode(ctx, kubeClient, nodeName); err != nil {
+		return err
+	}
+	return ExecuteDrain(ctx, kubeClient, vcClient, plan, drainNodeFlags.Restart, os.Stdout)
+}
+
+// PlanDrain finds the gangs with members on the node, and orders them from the lowest priority. A gang is blocked
+// if evicting all its pods violates a PodDisruptionBudget, or its queue is not reclaimable and force is not set.
+func PlanDrain(ctx context.Context, kubeClient kubeclientset.Interface, vcClient versioned.Interface, nodeName string, force bool) (*DrainPlan, error) {
+	if _, err := kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+	selector := fields.AndSelectors(
+		fields.OneTermEqualSelector("spec.nodeName", nodeName),
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+	)
+	podList, err := kubeClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &DrainPlan{Node: nodeName}
+	gangs := map[types.NamespacedName]*DrainGang{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Spec.NodeName != nodeName || isFinished(pod) {
+			continue
+		}
+		groupName := pod.Annotations[schedulingv1beta1.KubeGroupNameAnnotationKey]
+		if groupName == "" {
+			plan.OtherPods = append(plan.OtherPods, pod.Namespace+"/"+pod.Name)
+			continue
+		}
+		key := types.NamespacedName{Namespace: pod.Namespace, Name: groupName}
+		if _, found := gangs[key]; !found {
+			gangs[key] = &DrainGang{Namespace: pod.Namespace, Name: groupName}
+		}
+		gangs[key].PodsOnNode++
+	}
+
+	for _, gang := range gangs {
+		if err := completeGang(ctx, kubeClient, vcClient, gang, force); err != nil {
+			return nil, err
+		}
+		plan.Gangs = append(plan.Gangs, gang)
+	}
+	sort.Slice(plan.Gangs, func(i, j int) bool {
+		if plan.Gangs[i].Priority != plan.Gangs[j].Priority {
+			return plan.Gangs[i].Priority < plan.Gangs[j].Priority
+		}
+		if plan.Gangs[i].Namespace != plan.Gangs[j].Namespace {
+			return plan.Gangs[i].Namespace < plan.Gangs[j].Namespace
+		}
+		return plan.Gangs[i].Name < plan.Gangs[j].Name
+	})
+	sort.Strings(plan.OtherPods)
+	return plan, nil
+}
+
+// completeGang fills the queue, owner job, pods and priority of the gang, and checks whether it can be drained.
+func completeGang(ctx context.Context, kubeClient kubeclientset.Interface, vcClient versioned.Interface, gang *DrainGang, force bool) error {
+	pg, err := vcClient.SchedulingV1beta1().PodGroups(gang.Namespace).Get(ctx, gang.Name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		gang.Queue = pg.Spec.Queue
+		if owner := metav1.GetControllerOf(pg); owner != nil && owner.Kind == "Job" &&
+			owner.APIVersion == batchv1alpha1.SchemeGroupVersion.String() {
+			gang.Job = owner.Name
+		}
+	}
+
+	podList, err := kubeClient.CoreV1().Pods(gang.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Annotations[schedulingv1beta1.KubeGroupNameAnnotationKey] != gang.Name || isFinished(pod) {
+			continue
+		}
+		gang.Pods = append(gang.Pods, pod)
+		if pod.Spec.Priority != nil && (len(gang.Pods) == 1 || *pod.Spec.Priority > gang.Priority) {
+			gang.Priority = *pod.Spec.Priority
+		}
+	}
+	sort.Slice(gang.Pods, func(i, j int) bool {
+		return gang.Pods[i].Name < gang.Pods[j].Name
+	})
+
+	if gang.Queue != "" && !force {
+		queue, err := vcClient.SchedulingV1beta1().Queues().Get(ctx, gang.Queue, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil && queue.Spec.Reclaimable != nil && !*queue.Spec.Reclaimable {
+			gang.Blocked = fmt.Sprintf("queue %s is not reclaimable, use --force to drain", gang.Queue)
+			return nil
+		}
+	}
+
+	pdbList, err := kubeClient.PolicyV1().PodDisruptionBudgets(gang.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, pdb := range pdbList.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+		var disrupted int32
+		for _, pod := range gang.Pods {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				disrupted++
+			}
+		}
+		if disrupted > pdb.Status.DisruptionsAllowed {
+			gang.Blocked = fmt.Sprintf("draining %d pods exceeds the %d disruptions allowed by PodDisruptionBudget %s",
+				disrupted, pdb.Status.DisruptionsAllowed, pdb.Name)
+			return nil
+		}
+	}
+	return nil
+}
+
+func isFinished(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// CordonNode marks the node unschedulable, so that the drained gangs are not scheduled back to it.
+func CordonNode(ctx context.Context, kubeClient kubeclientset.Interface, nodeName string) error {
+	patch := []byte(`{"spec":{"unschedulable":true}}`)
+	_, err := kubeClient.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// ExecuteDrain drains the gangs of the plan in order. The Volcano jobs of the gangs are restarted if restart is set,
+// the pods of the other gangs are evicted, which respects the PodDisruptionBudgets. The blocked gangs are skipped.
+func ExecuteDrain(ctx context.Context, kubeClient kubeclientset.Interface, vcClient versioned.Interface, plan *DrainPlan, restart bool, out io.Writer) error {
+	var errs []string
+	for _, gang := range plan.Gangs {
+		if gang.Blocked != "" {
+			fmt.Fprintf(out, "Skip gang %s/%s: %s\n", gang.Namespace, gang.Name, gang.Blocked)
+			continue
+		}
+		if restart && gang.Job != "" {
+			if err := util.CreateJobCommandWithClient(ctx, vcClient, gang.Namespace, gang.Job, busv1alpha1.RestartJobAction); err != nil {
+				errs = append(errs, fmt.Sprintf("restart job %s/%s: %v", gang.Namespace, gang.Job, err))
+				continue
+			}
+			fmt.Fprintf(out, "Job %s/%s restarted\n", gang.Namespace, gang.Job)
+			continue
+		}
+		if err := evictGang(ctx, kubeClient, gang); err != nil {
+			errs = append(errs, fmt.Sprintf("evict gang %s/%s: %v", gang.Namespace, gang.Name, err))
+			continue
+		}
+		fmt.Fprintf(out, "Gang %s/%s evicted\n", gang.Namespace, gang.Name)
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("failed to drain node %s: %s", plan.Node, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func evictGang(ctx context.Context, kubeClient kubeclientset.Interface, gang *DrainGang) error {
+	for _, pod := range gang.Pods {
+		eviction := &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		}
+		if err := kubeClient.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintDrainPlan prints the gangs to drain from the node in order.
+func PrintDrainPlan(plan *DrainPlan, restart bool, writer io.Writer) {
+	if len(plan.Gangs) == 0 {
+		fmt.Fprintf(writer, "No gangs found on node %s\n", plan.Node)
+	} else {
+		fmt.Fprintf(writer, "%-40s%-16s%-10s%-14s%s\n", "PodGroup", "Queue", "Priority", "Pods(node)", "Action")
+		for _, gang := range plan.Gangs {
+			action := "evict"
+			if gang.Blocked != "" {
+				action = "skip: " + gang.Blocked
+			} else if restart && gang.Job != "" {
+				action = "restart job " + gang.Job
+			}
+			fmt.Fprintf(writer, "%-40s%-16s%-10d%-14s%s\n", gang.Namespace+"/"+gang.Name, gang.Queue, gang.Priority,
+				fmt.Sprintf("%d(%d)", len(gang.Pods), gang.PodsOnNode), action)
+		}
+	}
+	if len(plan.OtherPods) != 0 {
+		fmt.Fprintf(writer, "Pods not in a gang, left to kubectl drain: %s\n", strings.Join(plan.OtherPods, ","))
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	vcfake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+)
+
+func buildGangPod(name, group, nodeName string, priority int32, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns1",
+			Name:        name,
+			Labels:      labels,
+			Annotations: map[string]string{schedulingv1beta1.KubeGroupNameAnnotationKey: group},
+		},
+		Spec:   corev1.PodSpec{NodeName: nodeName, Priority: &priority},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func buildGangPodGroup(name, queue, job string) *schedulingv1beta1.PodGroup {
+	pg := &schedulingv1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name},
+		Spec:       schedulingv1beta1.PodGroupSpec{Queue: queue},
+	}
+	if job != "" {
+		controller := true
+		pg.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: batchv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "Job",
+			Name:       job,
+			Controller: &controller,
+		}}
+	}
+	return pg
+}
+
+func TestPlanDrain(t *testing.T) {
+	notReclaimable := false
+	kubeClient := kubefake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}},
+		buildGangPod("train-0", "train", "n1", 100, nil),
+		buildGangPod("train-1", "train", "n2", 100, nil),
+		buildGangPod("batch-0", "batch", "n1", 10, nil),
+		buildGangPod("serving-0", "serving", "n1", 10, nil),
+		buildGangPod("guarded-0", "guarded", "n1", 50, map[string]string{"app": "guarded"}),
+		buildGangPod("guarded-1", "guarded", "n2", 50, map[string]string{"app": "guarded"}),
+		buildGangPod("other-n2", "other", "n2", 0, nil),
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "plain"},
+			Spec:       corev1.PodSpec{NodeName: "n1"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "guarded-pdb"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "guarded"}}},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+		},
+	)
+	vcClient := vcfake.NewSimpleClientset(
+		buildGangPodGroup("train", "default", "train"),
+		buildGangPodGroup("batch", "default", ""),
+		buildGangPodGroup("serving", "serving", ""),
+		buildGangPodGroup("guarded", "default", ""),
+		&schedulingv1beta1.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: "serving"},
+			Spec:       schedulingv1beta1.QueueSpec{Reclaimable: &notReclaimable},
+		},
+	)
+
+	plan, err := PlanDrain(context.TODO(), kubeClient, vcClient, "n1", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		name    string
+		pods    int
+		job     string
+		blocked bool
+	}{
+		{name: "batch", pods: 1},
+		{name: "serving", pods: 1, blocked: true},
+		{name: "guarded", pods: 2, blocked: true},
+		{name: "train", pods: 2, job: "train"},
+	}
+	if len(plan.Gangs) != len(expected) {
+		t.Fatalf("expected %d gangs, got %d", len(expected), len(plan.Gangs))
+	}
+	for i, e := range expected {
+		gang := plan.Gangs[i]
+		if gang.Name != e.name || len(gang.Pods) != e.pods || gang.Job != e.job || (gang.Blocked != "") != e.blocked {
+			t.Errorf("expected gang %d to be %+v, got %+v", i, e, gang)
+		}
+	}
+	if len(plan.OtherPods) != 1 || plan.OtherPods[0] != "ns1/plain" {
+		t.Errorf("expected the pod not in a gang to be left, got %v", plan.OtherPods)
+	}
+
+	// the queue which is not reclaimable is drained with force
+	plan, err = PlanDrain(context.TODO(), kubeClient, vcClient, "n1", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plan.Gangs[1].Name != "serving" || plan.Gangs[1].Blocked != "" {
+		t.Errorf("expected gang serving to be drained with force, got %+v", plan.Gangs[1])
+	}
+}
+
+func TestExecuteDrain(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(
+		buildGangPod("batch-0", "batch", "n1", 0, nil),
+		buildGangPod("batch-1", "batch", "n2", 0, nil),
+	)
+	vcClient := vcfake.NewSimpleClientset(&batchv1alpha1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "train"}})
+	plan := &DrainPlan{
+		Node: "n1",
+		Gangs: []*DrainGang{
+			{Namespace: "ns1", Name: "batch", Pods: []*corev1.Pod{
+				buildGangPod("batch-0", "batch", "n1", 0, nil),
+				buildGangPod("batch-1", "batch", "n2", 0, nil),
+			}},
+			{Namespace: "ns1", Name: "train", Job: "train"},
+			{Namespace: "ns1", Name: "serving", Blocked: "queue serving is not reclaimable"},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := ExecuteDrain(context.TODO(), kubeClient, vcClient, plan, true, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	evicted := 0
+	for _, action := range kubeClient.Actions() {
+		if action.GetVerb() == "create" && action.GetSubresource() == "eviction" {
+			evicted++
+		}
+	}
+	if evicted != 2 {
+		t.Errorf("expected the 2 pods of gang batch evicted, got %d evictions", evicted)
+	}
+	commands, err := vcClient.BusV1alpha1().Commands("ns1").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(commands.Items) != 1 || commands.Items[0].TargetObject.Name != "train" {
+		t.Errorf("expected job train restarted, got %v", commands.Items)
+	}
+	expected := "Gang ns1/batch evicted\nJob ns1/train restarted\nSkip gang ns1/serving: queue serving is not reclaimable\n"
+	if out.String() != expected {
+		t.Errorf("expected output %q, got %q", expected, out.String())
+	}
+}
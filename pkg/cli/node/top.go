@@ -0,0 +1,325 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	resourcehelper "k8s.io/component-helpers/resource"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/cli/util"
+)
+
+const (
+	// Name node name
+	Name string = "Name"
+	// CPU node cpu usage
+	CPU string = "CPU(allocated/allocatable)"
+	// Memory node memory usage
+	Memory string = "Memory(allocated/allocatable)"
+	// Accelerators node gpu and npu usage
+	Accelerators string = "Accelerators(allocated/allocatable)"
+	// Fragmentation node fragmentation score
+	Fragmentation string = "Fragmentation"
+	// Queues queues owning the usage of node
+	Queues string = "Queues"
+)
+
+// acceleratorResources are the whole and shared gpu resources shown in the accelerators column.
+var acceleratorResources = map[corev1.ResourceName]struct{}{
+	"nvidia.com/gpu":         {},
+	"volcano.sh/vgpu-number": {},
+	"volcano.sh/vgpu-memory": {},
+	"volcano.sh/gpu-number":  {},
+	"volcano.sh/gpu-memory":  {},
+}
+
+// acceleratorResourcePrefixes are the prefixes of npu resources shown in the accelerators column.
+var acceleratorResourcePrefixes = []string{"huawei.com/"}
+
+type topFlags struct {
+	util.CommonFlags
+
+	// Name is the name of the node to show, all nodes are shown if empty
+	Name string
+	// Selector is the label selector of nodes
+	Selector string
+}
+
+var topNodeFlags = &topFlags{}
+
+// InitTopFlags init top command flags.
+func InitTopFlags(cmd *cobra.Command) {
+	util.InitFlags(cmd, &topNodeFlags.CommonFlags)
+
+	cmd.Flags().StringVarP(&topNodeFlags.Name, "name", "n", "", "the name of node")
+	cmd.Flags().StringVarP(&topNodeFlags.Selector, "selector", "l", "", "label selector of nodes")
+}
+
+// NodeUsage is the resource usage of a node.
+type NodeUsage struct {
+	Name        string
+	Allocatable corev1.ResourceList
+	Allocated   corev1.ResourceList
+	// QueuePods is the number of pods on the node of each queue
+	QueuePods map[string]int
+}
+
+// TopNodes shows allocatable and allocated resources of nodes.
+func TopNodes(ctx context.Context) error {
+	config, err := util.BuildConfig(topNodeFlags.Master, topNodeFlags.Kubeconfig, topNodeFlags.Context)
+	if err != nil {
+		return err
+	}
+	client := kubeclientset.NewForConfigOrDie(config)
+
+	var nodes []corev1.Node
+	if topNodeFlags.Name != "" {
+		node, err := client.CoreV1().Nodes().Get(ctx, topNodeFlags.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, *node)
+	} else {
+		nodeList, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: topNodeFlags.Selector})
+		if err != nil {
+			return err
+		}
+		nodes = nodeList.Items
+	}
+	if len(nodes) == 0 {
+		fmt.Printf("No resources found\n")
+		return nil
+	}
+
+	// the pods of finished phases do not occupy resources
+	selector := fields.AndSelectors(
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+	)
+	if topNodeFlags.Name != "" {
+		selector = fields.AndSelectors(selector, fields.OneTermEqualSelector("spec.nodeName", topNodeFlags.Name))
+	}
+	podList, err := client.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+
+	PrintNodeUsages(BuildNodeUsages(nodes, podList.Items), os.Stdout)
+	return nil
+}
+
+// BuildNodeUsages sums the requests of the pods bound to each node.
+func BuildNodeUsages(nodes []corev1.Node, pods []corev1.Pod) []*NodeUsage {
+	usages := make(map[string]*NodeUsage, len(nodes))
+	result := make([]*NodeUsage, 0, len(nodes))
+	for _, node := range nodes {
+		usage := &NodeUsage{
+			Name:        node.Name,
+			Allocatable: node.Status.Allocatable,
+			Allocated:   corev1.ResourceList{},
+			QueuePods:   map[string]int{},
+		}
+		usages[node.Name] = usage
+		result = append(result, usage)
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		usage, found := usages[pod.Spec.NodeName]
+		if !found || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for name, quantity := range resourcehelper.PodRequests(pod, resourcehelper.PodResourcesOptions{}) {
+			total := usage.Allocated[name]
+			total.Add(quantity)
+			usage.Allocated[name] = total
+		}
+		if queue := podQueue(pod); queue != "" {
+			usage.QueuePods[queue]++
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// podQueue returns the queue of the pod created by vcjob or other workloads.
+func podQueue(pod *corev1.Pod) string {
+	if queue, found := pod.Labels[v1alpha1.QueueNameKey]; found {
+		return queue
+	}
+	return pod.Annotations[schedulingv1beta1.QueueNameAnnotationKey]
+}
+
+func isAcceleratorResource(name corev1.ResourceName) bool {
+	if _, found := acceleratorResources[name]; found {
+		return true
+	}
+	for _, prefix := range acceleratorResourcePrefixes {
+		if strings.HasPrefix(string(name), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// FragmentationScore returns the fragmentation of the node from 0 to 100, that is the difference between
+// the largest and smallest free ratio of cpu, memory and accelerators. A high score means the free resources
+// of some dimensions are stranded, because the other dimensions are used up.
+func (u *NodeUsage) FragmentationScore() int {
+	var names []corev1.ResourceName
+	for name := range u.Allocatable {
+		if name == corev1.ResourceCPU || name == corev1.ResourceMemory || isAcceleratorResource(name) {
+			names = append(names, name)
+		}
+	}
+
+	minFree, maxFree := 1.0, 0.0
+	for _, name := range names {
+		allocatable := u.Allocatable[name]
+		if allocatable.IsZero() {
+			continue
+		}
+		allocated := u.Allocated[name]
+		free := 1 - float64(allocated.MilliValue())/float64(allocatable.MilliValue())
+		free = math.Max(0, math.Min(1, free))
+		minFree = math.Min(minFree, free)
+		maxFree = math.Max(maxFree, free)
+	}
+	if maxFree < minFree {
+		return 0
+	}
+	return int(math.Round((maxFree - minFree) * 100))
+}
+
+func formatUsage(usage *NodeUsage, name corev1.ResourceName) string {
+	allocated := usage.Allocated[name]
+	allocatable := usage.Allocatable[name]
+	percent := 0.0
+	if !allocatable.IsZero() {
+		percent = float64(allocated.MilliValue()) / float64(allocatable.MilliValue()) * 100
+	}
+	return fmt.Sprintf("%s/%s(%.0f%%)", formatQuantity(allocated), formatQuantity(allocatable), percent)
+}
+
+func formatQuantity(quantity resource.Quantity) string {
+	if quantity.Format == resource.BinarySI && quantity.Value() >= 1024*1024 {
+		return fmt.Sprintf("%dMi", quantity.Value()/(1024*1024))
+	}
+	return quantity.String()
+}
+
+func formatAccelerators(usage *NodeUsage) string {
+	names := map[corev1.ResourceName]struct{}{}
+	for name := range usage.Allocatable {
+		if isAcceleratorResource(name) {
+			names[name] = struct{}{}
+		}
+	}
+	for name := range usage.Allocated {
+		if isAcceleratorResource(name) {
+			names[name] = struct{}{}
+		}
+	}
+	if len(names) == 0 {
+		return "-"
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, string(name))
+	}
+	sort.Strings(sorted)
+	items := make([]string, 0, len(sorted))
+	for _, name := range sorted {
+		items = append(items, fmt.Sprintf("%s=%s", name, formatUsage(usage, corev1.ResourceName(name))))
+	}
+	return strings.Join(items, ",")
+}
+
+func formatQueues(usage *NodeUsage) string {
+	if len(usage.QueuePods) == 0 {
+		return "-"
+	}
+	queues := make([]string, 0, len(usage.QueuePods))
+	for queue := range usage.QueuePods {
+		queues = append(queues, queue)
+	}
+	sort.Strings(queues)
+	items := make([]string, 0, len(queues))
+	for _, queue := range queues {
+		items = append(items, fmt.Sprintf("%s(%d)", queue, usage.QueuePods[queue]))
+	}
+	return strings.Join(items, ",")
+}
+
+// PrintNodeUsages prints the resource usage of nodes.
+func PrintNodeUsages(usages []*NodeUsage, writer io.Writer) {
+	rows := [][]string{{Name, CPU, Memory, Accelerators, Fragmentation, Queues}}
+	for _, usage := range usages {
+		rows = append(rows, []string{
+			usage.Name,
+			formatUsage(usage, corev1.ResourceCPU),
+			formatUsage(usage, corev1.ResourceMemory),
+			formatAccelerators(usage),
+			fmt.Sprintf("%d", usage.FragmentationScore()),
+			formatQueues(usage),
+		})
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+	columnSpacing := 4
+	for _, row := range rows {
+		var line strings.Builder
+		for i, cell := range row {
+			if i == len(row)-1 {
+				line.WriteString(cell)
+				continue
+			}
+			line.WriteString(fmt.Sprintf("%-*s", widths[i]+columnSpacing, cell))
+		}
+		if _, err := fmt.Fprintln(writer, line.String()); err != nil {
+			fmt.Printf("Failed to print node information: %s.\n", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func buildNode(name string, allocatable corev1.ResourceList) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     corev1.NodeStatus{Allocatable: allocatable},
+	}
+}
+
+func buildPod(name, nodeName string, phase corev1.PodPhase, labels, annotations map[string]string, requests corev1.ResourceList) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+		Spec: corev1.PodSpec{
+			NodeName:   nodeName,
+			Containers: []corev1.Container{{Name: "c", Resources: corev1.ResourceRequirements{Requests: requests}}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestBuildNodeUsages(t *testing.T) {
+	nodes := []corev1.Node{
+		buildNode("gpu-node", corev1.ResourceList{
+			corev1.ResourceCPU:       resource.MustParse("32"),
+			corev1.ResourceMemory:    resource.MustParse("128Gi"),
+			"volcano.sh/vgpu-number": resource.MustParse("40"),
+		}),
+		buildNode("cpu-node", corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("16"),
+			corev1.ResourceMemory: resource.MustParse("64Gi"),
+		}),
+	}
+	pods := []corev1.Pod{
+		buildPod("vcjob-pod", "gpu-node", corev1.PodRunning,
+			map[string]string{v1alpha1.QueueNameKey: "q1"}, nil,
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8"), "volcano.sh/vgpu-number": resource.MustParse("30")}),
+		buildPod("deployment-pod", "gpu-node", corev1.PodPending,
+			nil, map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "q2"},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("8"), corev1.ResourceMemory: resource.MustParse("32Gi")}),
+		buildPod("finished-pod", "cpu-node", corev1.PodSucceeded,
+			map[string]string{v1alpha1.QueueNameKey: "q1"}, nil,
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("16")}),
+		buildPod("unscheduled-pod", "", corev1.PodPending, nil, nil,
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}),
+	}
+
+	usages := BuildNodeUsages(nodes, pods)
+	if len(usages) != 2 || usages[0].Name != "cpu-node" || usages[1].Name != "gpu-node" {
+		t.Fatalf("expected usages of cpu-node and gpu-node, got %v", usages)
+	}
+
+	cpuNode, gpuNode := usages[0], usages[1]
+	if len(cpuNode.Allocated) != 0 || len(cpuNode.QueuePods) != 0 {
+		t.Errorf("expected finished pods not counted, got %v %v", cpuNode.Allocated, cpuNode.QueuePods)
+	}
+	if cpu := gpuNode.Allocated[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("16")) != 0 {
+		t.Errorf("expected 16 cpu allocated, got %s", cpu.String())
+	}
+	if gpuNode.QueuePods["q1"] != 1 || gpuNode.QueuePods["q2"] != 1 {
+		t.Errorf("expected one pod of q1 and q2, got %v", gpuNode.QueuePods)
+	}
+
+	// free ratio: cpu 50%, memory 75%, vgpu 25%
+	if score := gpuNode.FragmentationScore(); score != 50 {
+		t.Errorf("expected fragmentation score 50, got %d", score)
+	}
+	if score := cpuNode.FragmentationScore(); score != 0 {
+		t.Errorf("expected fragmentation score 0 of idle node, got %d", score)
+	}
+}
+
+func TestPrintNodeUsages(t *testing.T) {
+	usages := []*NodeUsage{
+		{
+			Name: "gpu-node",
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("32"),
+				corev1.ResourceMemory: resource.MustParse("128Gi"),
+				"nvidia.com/gpu":      resource.MustParse("8"),
+			},
+			Allocated: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("8"),
+				corev1.ResourceMemory: resource.MustParse("32Gi"),
+				"nvidia.com/gpu":      resource.MustParse("8"),
+			},
+			QueuePods: map[string]int{"q2": 1, "q1": 2},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintNodeUsages(usages, &buf)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header and one node, got %q", buf.String())
+	}
+	for _, expected := range []string{"gpu-node", "8/32(25%)", "32768Mi/131072Mi(25%)", "nvidia.com/gpu=8/8(100%)", "75", "q1(2),q2(1)"} {
+		if !strings.Contains(lines[1], expected) {
+			t.Errorf("expected %q in %q", expected, lines[1])
+		}
+	}
+}
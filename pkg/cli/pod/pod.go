@@ -84,7 +84,7 @@ func InitListFlags(cmd *cobra.Command) {
 
 // ListPods lists all pods details created by vcjob
 func ListPods(ctx context.Context) error {
-	config, err := util.BuildConfig(listPodFlags.Master, listPodFlags.Kubeconfig)
+	config, err := util.BuildConfig(listPodFlags.Master, listPodFlags.Kubeconfig, listPodFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -18,9 +18,13 @@ package queue
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
@@ -35,6 +39,12 @@ type createFlags struct {
 	Weight int32
 	// State is state of Queue
 	State string
+	// Parent is the parent queue of Queue in the hierarchy
+	Parent string
+	// Guarantee is the guaranteed resources of Queue, e.g. cpu=100,memory=1Ti
+	Guarantee string
+	// Capability is the max resources of Queue, e.g. cpu=100,memory=1Ti
+	Capability string
 }
 
 var createQueueFlags = &createFlags{}
@@ -47,21 +57,38 @@ func InitCreateFlags(cmd *cobra.Command) {
 	cmd.Flags().Int32VarP(&createQueueFlags.Weight, "weight", "w", 1, "the weight of the queue")
 
 	cmd.Flags().StringVarP(&createQueueFlags.State, "state", "S", "Open", "the state of queue")
+	cmd.Flags().StringVar(&createQueueFlags.Parent, "parent", "", "the parent queue of the queue in the hierarchy, root if not set")
+	cmd.Flags().StringVar(&createQueueFlags.Guarantee, "guarantee", "",
+		"the guaranteed resources of the queue, e.g. cpu=100,memory=1Ti,nvidia.com/gpu=64")
+	cmd.Flags().StringVar(&createQueueFlags.Capability, "capability", "",
+		"the max resources of the queue, e.g. cpu=100,memory=1Ti,nvidia.com/gpu=64")
 }
 
 // CreateQueue create queue.
 func CreateQueue(ctx context.Context) error {
-	config, err := util.BuildConfig(createQueueFlags.Master, createQueueFlags.Kubeconfig)
+	config, err := util.BuildConfig(createQueueFlags.Master, createQueueFlags.Kubeconfig, createQueueFlags.Context)
 	if err != nil {
 		return err
 	}
 
+	guarantee, err := parseResourceList(createQueueFlags.Guarantee)
+	if err != nil {
+		return fmt.Errorf("invalid guarantee: %v", err)
+	}
+	capability, err := parseResourceList(createQueueFlags.Capability)
+	if err != nil {
+		return fmt.Errorf("invalid capability: %v", err)
+	}
+
 	queue := &schedulingv1beta1.Queue{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: createQueueFlags.Name,
 		},
 		Spec: schedulingv1beta1.QueueSpec{
-			Weight: createQueueFlags.Weight,
+			Weight:     createQueueFlags.Weight,
+			Parent:     createQueueFlags.Parent,
+			Guarantee:  schedulingv1beta1.Guarantee{Resource: guarantee},
+			Capability: capability,
 		},
 		Status: schedulingv1beta1.QueueStatus{
 			State: schedulingv1beta1.QueueState(createQueueFlags.State),
@@ -75,3 +102,24 @@ func CreateQueue(ctx context.Context) error {
 
 	return nil
 }
+
+// parseResourceList parses the comma separated resources like cpu=100,memory=1Ti,nvidia.com/gpu=64,
+// nil is returned if the value is empty.
+func parseResourceList(value string) (v1.ResourceList, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	rl := v1.ResourceList{}
+	for _, item := range strings.Split(value, ",") {
+		name, quantity, found := strings.Cut(strings.TrimSpace(item), "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid resource %q, expected name=quantity", item)
+		}
+		q, err := resource.ParseQuantity(quantity)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity of resource %s: %v", name, err)
+		}
+		rl[v1.ResourceName(name)] = q
+	}
+	return rl, nil
+}
@@ -46,7 +46,7 @@ func InitDeleteFlags(cmd *cobra.Command) {
 
 // DeleteQueue delete queue.
 func DeleteQueue(ctx context.Context) error {
-	config, err := util.BuildConfig(deleteQueueFlags.Master, deleteQueueFlags.Kubeconfig)
+	config, err := util.BuildConfig(deleteQueueFlags.Master, deleteQueueFlags.Kubeconfig, deleteQueueFlags.Context)
 	if err != nil {
 		return err
 	}
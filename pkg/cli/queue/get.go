@@ -18,18 +18,30 @@ package queue
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 
 	"github.com/spf13/cobra"
 
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/apis/pkg/client/clientset/versioned"
 	"volcano.sh/volcano/pkg/cli/podgroup"
 	"volcano.sh/volcano/pkg/cli/util"
+	commonutil "volcano.sh/volcano/pkg/util"
+)
+
+const (
+	// pendingResourcesAnnotation is the annotation of the queue recording the total requests of its pending tasks,
+	// which is maintained by the scheduler.
+	pendingResourcesAnnotation = "volcano.sh/queue-pending-resources"
+	// pipelinedResourcesAnnotation is the annotation of the queue recording the total requests of its tasks waiting
+	// for the resources of victims to be released, which is maintained by the scheduler.
+	pipelinedResourcesAnnotation = "volcano.sh/queue-pipelined-resources"
 )
 
 type getFlags struct {
@@ -49,7 +61,7 @@ func InitGetFlags(cmd *cobra.Command) {
 
 // GetQueue gets a queue.
 func GetQueue(ctx context.Context) error {
-	config, err := util.BuildConfig(getQueueFlags.Master, getQueueFlags.Kubeconfig)
+	config, err := util.BuildConfig(getQueueFlags.Master, getQueueFlags.Kubeconfig, getQueueFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -99,4 +111,34 @@ func PrintQueue(queue *v1beta1.Queue, pgStats *podgroup.PodGroupStatistics, writ
 	if err != nil {
 		fmt.Printf("Failed to print queue command result: %s.\n", err)
 	}
+
+	// the resources are updated by the scheduler every session
+	if len(queue.Status.Allocated) > 0 {
+		if _, err = fmt.Fprintf(writer, "Allocated: %s\n", commonutil.FormatResourceList(queue.Status.Allocated)); err != nil {
+			fmt.Printf("Failed to print queue command result: %s.\n", err)
+		}
+	}
+	if pending := recordedResources(queue, pendingResourcesAnnotation); len(pending) > 0 {
+		if _, err = fmt.Fprintf(writer, "Pending Resources: %s\n", commonutil.FormatResourceList(pending)); err != nil {
+			fmt.Printf("Failed to print queue command result: %s.\n", err)
+		}
+	}
+	if pipelined := recordedResources(queue, pipelinedResourcesAnnotation); len(pipelined) > 0 {
+		if _, err = fmt.Fprintf(writer, "Pipelined Resources: %s\n", commonutil.FormatResourceList(pipelined)); err != nil {
+			fmt.Printf("Failed to print queue command result: %s.\n", err)
+		}
+	}
+}
+
+// recordedResources returns the total requests of the tasks in the queue recorded by the scheduler in the annotation.
+func recordedResources(queue *v1beta1.Queue, annotation string) v1.ResourceList {
+	value, found := queue.Annotations[annotation]
+	if !found {
+		return nil
+	}
+	var resources v1.ResourceList
+	if err := json.Unmarshal([]byte(value), &resources); err != nil {
+		return nil
+	}
+	return resources
 }
@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -30,10 +32,14 @@ import (
 	"volcano.sh/apis/pkg/client/clientset/versioned"
 	"volcano.sh/volcano/pkg/cli/podgroup"
 	"volcano.sh/volcano/pkg/cli/util"
+	commonutil "volcano.sh/volcano/pkg/util"
 )
 
 type listFlags struct {
 	util.CommonFlags
+
+	// Tree prints the hierarchy of queues as a tree
+	Tree bool
 }
 
 const (
@@ -65,16 +71,21 @@ const (
 	Parent string = "Parent"
 )
 
+// rootQueue is the root of the hierarchy of queues, it is the parent of the queues without parent.
+const rootQueue = "root"
+
 var listQueueFlags = &listFlags{}
 
 // InitListFlags inits all flags.
 func InitListFlags(cmd *cobra.Command) {
 	util.InitFlags(cmd, &listQueueFlags.CommonFlags)
+
+	cmd.Flags().BoolVar(&listQueueFlags.Tree, "tree", false, "print the hierarchy of queues as a tree")
 }
 
 // ListQueue lists all the queue.
 func ListQueue(ctx context.Context) error {
-	config, err := util.BuildConfig(listQueueFlags.Master, listQueueFlags.Kubeconfig)
+	config, err := util.BuildConfig(listQueueFlags.Master, listQueueFlags.Kubeconfig, listQueueFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -90,6 +101,11 @@ func ListQueue(ctx context.Context) error {
 		return nil
 	}
 
+	if listQueueFlags.Tree {
+		PrintQueueTree(queues, os.Stdout)
+		return nil
+	}
+
 	// Although the featuregate called CustomResourceFieldSelectors is enabled by default after v1.31, there are still
 	// users using k8s versions lower than v1.31. Therefore we can only get all the podgroups from kube-apiserver
 	// and then filtering them.
@@ -135,3 +151,58 @@ func PrintQueues(queues *v1beta1.QueueList, queueStats map[string]*podgroup.PodG
 		}
 	}
 }
+
+// PrintQueueTree prints the hierarchy of queues as a tree with the weight, guarantee and capability of each queue.
+// The queues whose parent does not exist are printed at the top level.
+func PrintQueueTree(queues *v1beta1.QueueList, writer io.Writer) {
+	byName := make(map[string]*v1beta1.Queue, len(queues.Items))
+	for i := range queues.Items {
+		byName[queues.Items[i].Name] = &queues.Items[i]
+	}
+
+	children := map[string][]string{}
+	var tops []string
+	for _, queue := range queues.Items {
+		parent := queue.Spec.Parent
+		if parent == "" && queue.Name != rootQueue {
+			parent = rootQueue
+		}
+		if _, found := byName[parent]; !found || parent == queue.Name {
+			tops = append(tops, queue.Name)
+			continue
+		}
+		children[parent] = append(children[parent], queue.Name)
+	}
+	sort.Strings(tops)
+
+	var printQueue func(name, prefix, branch, indent string)
+	printQueue = func(name, prefix, branch, indent string) {
+		if _, err := fmt.Fprintf(writer, "%s%s%s\n", prefix, branch, queueTreeNode(byName[name])); err != nil {
+			fmt.Printf("Failed to print queue command result: %s.\n", err)
+		}
+		names := children[name]
+		sort.Strings(names)
+		for i, child := range names {
+			if i == len(names)-1 {
+				printQueue(child, prefix+indent, "└── ", "    ")
+			} else {
+				printQueue(child, prefix+indent, "├── ", "│   ")
+			}
+		}
+	}
+	for _, name := range tops {
+		printQueue(name, "", "", "")
+	}
+}
+
+// queueTreeNode returns the description of the queue in the tree.
+func queueTreeNode(queue *v1beta1.Queue) string {
+	items := []string{fmt.Sprintf("weight=%d", queue.Spec.Weight)}
+	if len(queue.Spec.Guarantee.Resource) > 0 {
+		items = append(items, fmt.Sprintf("guarantee=%s", commonutil.FormatResourceList(queue.Spec.Guarantee.Resource)))
+	}
+	if len(queue.Spec.Capability) > 0 {
+		items = append(items, fmt.Sprintf("capability=%s", commonutil.FormatResourceList(queue.Spec.Capability)))
+	}
+	return fmt.Sprintf("%s (%s)", queue.Name, strings.Join(items, " "))
+}
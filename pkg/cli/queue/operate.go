@@ -18,16 +18,20 @@ package queue
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
 
 	"volcano.sh/apis/pkg/apis/bus/v1alpha1"
 	"volcano.sh/apis/pkg/client/clientset/versioned"
 	"volcano.sh/volcano/pkg/cli/util"
+	"volcano.sh/volcano/pkg/controllers/queue/state"
 )
 
 const (
@@ -37,6 +41,8 @@ const (
 	ActionClose = "close"
 	// ActionUpdate is `update` action
 	ActionUpdate = "update"
+	// ActionDrain is `drain` action
+	ActionDrain = "drain"
 )
 
 type operateFlags struct {
@@ -48,6 +54,10 @@ type operateFlags struct {
 	Weight int32
 	// Action is operation action of queue
 	Action string
+	// DrainDeadline is the duration after which the remaining jobs of the draining queue are evicted or migrated
+	DrainDeadline time.Duration
+	// FallbackQueue is the queue to migrate the remaining jobs of the draining queue to
+	FallbackQueue string
 }
 
 var operateQueueFlags = &operateFlags{}
@@ -59,12 +69,16 @@ func InitOperateFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&operateQueueFlags.Name, "name", "n", "", "the name of queue")
 	cmd.Flags().Int32VarP(&operateQueueFlags.Weight, "weight", "w", 0, "the weight of the queue")
 	cmd.Flags().StringVarP(&operateQueueFlags.Action, "action", "a", "",
-		"operate action to queue, valid actions are open, close, update")
+		"operate action to queue, valid actions are open, close, update, drain")
+	cmd.Flags().DurationVar(&operateQueueFlags.DrainDeadline, "drain-deadline", 0,
+		"the duration after which the remaining jobs of the draining queue are evicted or migrated, never if 0")
+	cmd.Flags().StringVar(&operateQueueFlags.FallbackQueue, "fallback-queue", "",
+		"the queue to migrate the remaining jobs of the draining queue to, the jobs are evicted if empty")
 }
 
 // OperateQueue operates queue
 func OperateQueue(ctx context.Context) error {
-	config, err := util.BuildConfig(operateQueueFlags.Master, operateQueueFlags.Kubeconfig)
+	config, err := util.BuildConfig(operateQueueFlags.Master, operateQueueFlags.Kubeconfig, operateQueueFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -92,12 +106,48 @@ func OperateQueue(ctx context.Context) error {
 			operateQueueFlags.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
 
 		return err
+	case ActionDrain:
+		if operateQueueFlags.DrainDeadline < 0 {
+			return fmt.Errorf("when %s queue %s, drain deadline must not be negative", ActionDrain, operateQueueFlags.Name)
+		}
+		if operateQueueFlags.FallbackQueue == operateQueueFlags.Name {
+			return fmt.Errorf("when %s queue %s, fallback queue must be another queue", ActionDrain, operateQueueFlags.Name)
+		}
+		if err := updateDrainAnnotations(ctx, config); err != nil {
+			return err
+		}
+		action = state.DrainQueueAction
 	case "":
 		return fmt.Errorf("action can not be null")
 	default:
-		return fmt.Errorf("action %s invalid, valid actions are %s, %s and %s",
-			operateQueueFlags.Action, ActionOpen, ActionClose, ActionUpdate)
+		return fmt.Errorf("action %s invalid, valid actions are %s, %s, %s and %s",
+			operateQueueFlags.Action, ActionOpen, ActionClose, ActionUpdate, ActionDrain)
 	}
 
 	return createQueueCommand(ctx, config, action)
 }
+
+// updateDrainAnnotations sets the drain deadline and fallback queue of the queue, they are removed if not specified.
+func updateDrainAnnotations(ctx context.Context, config *rest.Config) error {
+	annotations := map[string]interface{}{
+		state.DrainDeadlineAnnotationKey:      nil,
+		state.DrainFallbackQueueAnnotationKey: nil,
+	}
+	if operateQueueFlags.DrainDeadline > 0 {
+		annotations[state.DrainDeadlineAnnotationKey] = time.Now().Add(operateQueueFlags.DrainDeadline).UTC().Format(time.RFC3339)
+	}
+	if operateQueueFlags.FallbackQueue != "" {
+		annotations[state.DrainFallbackQueueAnnotationKey] = operateQueueFlags.FallbackQueue
+	}
+	patchBytes, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	})
+	if err != nil {
+		return err
+	}
+
+	queueClient := versioned.NewForConfigOrDie(config)
+	_, err = queueClient.SchedulingV1beta1().Queues().Patch(ctx,
+		operateQueueFlags.Name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+	return err
+}
@@ -24,6 +24,7 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
@@ -52,11 +53,13 @@ func TestOperateQueue(t *testing.T) {
 
 	operateQueueFlags.Master = server.URL
 	testCases := []struct {
-		Name        string
-		QueueName   string
-		Weight      int32
-		Action      string
-		ExpectValue error
+		Name          string
+		QueueName     string
+		Weight        int32
+		Action        string
+		DrainDeadline time.Duration
+		FallbackQueue string
+		ExpectValue   error
 	}{
 		{
 			Name:        "Normal Case Operate Queue Succeed, Action close",
@@ -77,6 +80,30 @@ func TestOperateQueue(t *testing.T) {
 			Weight:      3,
 			ExpectValue: nil,
 		},
+		{
+			Name:          "Normal Case Operate Queue Succeed, Action drain",
+			QueueName:     "normal-case-action-drain",
+			Action:        ActionDrain,
+			DrainDeadline: time.Hour,
+			FallbackQueue: "fallback",
+			ExpectValue:   nil,
+		},
+		{
+			Name:          "Abnormal Case Drain Queue Failed For Negative Deadline",
+			QueueName:     "abnormal-case-negative-deadline",
+			Action:        ActionDrain,
+			DrainDeadline: -time.Hour,
+			ExpectValue: fmt.Errorf("when %s queue %s, drain deadline must not be negative",
+				ActionDrain, "abnormal-case-negative-deadline"),
+		},
+		{
+			Name:          "Abnormal Case Drain Queue Failed For Fallback To Itself",
+			QueueName:     "abnormal-case-fallback-itself",
+			Action:        ActionDrain,
+			FallbackQueue: "abnormal-case-fallback-itself",
+			ExpectValue: fmt.Errorf("when %s queue %s, fallback queue must be another queue",
+				ActionDrain, "abnormal-case-fallback-itself"),
+		},
 		{
 			Name:      "Abnormal Case Update Queue Failed For Invalid Weight",
 			QueueName: "abnormal-case-invalid-weight",
@@ -99,8 +126,8 @@ func TestOperateQueue(t *testing.T) {
 			Name:      "Abnormal Case Operate Queue Failed For Action Invalid",
 			QueueName: "abnormal-case-invalid-action",
 			Action:    "invalid",
-			ExpectValue: fmt.Errorf("action %s invalid, valid actions are %s, %s and %s",
-				"invalid", ActionOpen, ActionClose, ActionUpdate),
+			ExpectValue: fmt.Errorf("action %s invalid, valid actions are %s, %s, %s and %s",
+				"invalid", ActionOpen, ActionClose, ActionUpdate, ActionDrain),
 		},
 	}
 
@@ -108,6 +135,8 @@ func TestOperateQueue(t *testing.T) {
 		operateQueueFlags.Name = testCase.QueueName
 		operateQueueFlags.Action = testCase.Action
 		operateQueueFlags.Weight = testCase.Weight
+		operateQueueFlags.DrainDeadline = testCase.DrainDeadline
+		operateQueueFlags.FallbackQueue = testCase.FallbackQueue
 
 		err := OperateQueue(context.TODO())
 		if false == reflect.DeepEqual(err, testCase.ExpectValue) {
@@ -129,4 +158,10 @@ func TestInitOperateFlags(t *testing.T) {
 	if cmd.Flag("action") == nil {
 		t.Errorf("Could not find the flag action")
 	}
+	if cmd.Flag("drain-deadline") == nil {
+		t.Errorf("Could not find the flag drain-deadline")
+	}
+	if cmd.Flag("fallback-queue") == nil {
+		t.Errorf("Could not find the flag fallback-queue")
+	}
 }
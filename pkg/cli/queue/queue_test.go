@@ -35,6 +35,7 @@ import (
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/cli/podgroup"
 	"volcano.sh/volcano/pkg/cli/util"
+	commonutil "volcano.sh/volcano/pkg/util"
 )
 
 func getTestQueueHTTPServer(t *testing.T) *httptest.Server {
@@ -350,3 +351,119 @@ testQueue2               0                       0       0       0       0
 		t.Errorf("(Test list queues with residual podgroups failed): \nExpect:\n%vGot:\n%v", expectOutput, result)
 	}
 }
+
+func TestParseResourceList(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "empty",
+			value:    "",
+			expected: "",
+		},
+		{
+			name:     "resources",
+			value:    "cpu=100, memory=1Ti,nvidia.com/gpu=64",
+			expected: "cpu=100,memory=1Ti,nvidia.com/gpu=64",
+		},
+		{
+			name:      "missing quantity",
+			value:     "cpu",
+			expectErr: true,
+		},
+		{
+			name:      "invalid quantity",
+			value:     "cpu=many",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rl, err := parseResourceList(tc.value)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if got := commonutil.FormatResourceList(rl); !tc.expectErr && got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPrintQueueTree(t *testing.T) {
+	queue := func(name, parent string, weight int32, guarantee, capability string) v1beta1.Queue {
+		q := v1beta1.Queue{
+			ObjectMeta: v1.ObjectMeta{Name: name},
+			Spec:       v1beta1.QueueSpec{Parent: parent, Weight: weight},
+		}
+		q.Spec.Guarantee.Resource, _ = parseResourceList(guarantee)
+		q.Spec.Capability, _ = parseResourceList(capability)
+		return q
+	}
+	queues := &v1beta1.QueueList{
+		Items: []v1beta1.Queue{
+			queue("team-b", "research", 1, "", ""),
+			queue("root", "", 1, "", ""),
+			queue("research", "root", 2, "cpu=100,nvidia.com/gpu=64", "cpu=200,memory=1Ti"),
+			queue("default", "", 1, "", ""),
+			queue("team-a", "research", 3, "cpu=50", ""),
+			queue("orphan", "missing", 1, "", ""),
+		},
+	}
+
+	expected := `orphan (weight=1)
+root (weight=1)
+├── default (weight=1)
+└── research (weight=2 guarantee=cpu=100,nvidia.com/gpu=64 capability=cpu=200,memory=1Ti)
+    ├── team-a (weight=3 guarantee=cpu=50)
+    └── team-b (weight=1)
+`
+	var buf bytes.Buffer
+	PrintQueueTree(queues, &buf)
+	if got := buf.String(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestPrintQueueResources(t *testing.T) {
+	q := &v1beta1.Queue{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "research",
+			Annotations: map[string]string{
+				pendingResourcesAnnotation:   `{"cpu":"16","nvidia.com/gpu":"8"}`,
+				pipelinedResourcesAnnotation: `{"cpu":"4"}`,
+			},
+		},
+		Spec:   v1beta1.QueueSpec{Weight: 2},
+		Status: v1beta1.QueueStatus{State: v1beta1.QueueStateOpen},
+	}
+	q.Status.Allocated, _ = parseResourceList("cpu=8,memory=32Gi")
+
+	var buf bytes.Buffer
+	PrintQueue(q, &podgroup.PodGroupStatistics{Pending: 2, Running: 1}, &buf)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 lines, got %q", buf.String())
+	}
+	if lines[2] != "Allocated: cpu=8,memory=32Gi" {
+		t.Errorf("unexpected allocated resources %q", lines[2])
+	}
+	if lines[3] != "Pending Resources: cpu=16,nvidia.com/gpu=8" {
+		t.Errorf("unexpected pending resources %q", lines[3])
+	}
+	if lines[4] != "Pipelined Resources: cpu=4" {
+		t.Errorf("unexpected pipelined resources %q", lines[4])
+	}
+
+	buf.Reset()
+	q.Annotations = nil
+	q.Status.Allocated = nil
+	PrintQueue(q, &podgroup.PodGroupStatistics{}, &buf)
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 2 {
+		t.Errorf("expected only the queue table without the resources, got %q", buf.String())
+	}
+}
@@ -0,0 +1,366 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/apis/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/cli/util"
+)
+
+const (
+	// PreviousWeightAnnotation records the weight of the queue before the last reweight, which is restored by undo.
+	PreviousWeightAnnotation = "volcano.sh/previous-weight"
+	// ReweightedAtAnnotation records the time of the last reweight of the queue.
+	ReweightedAtAnnotation = "volcano.sh/reweighted-at"
+
+	defaultUndoWindow = 10 * time.Minute
+)
+
+type reweightFlags struct {
+	util.CommonFlags
+
+	// Name is name of queue
+	Name string
+	// Weight is the new weight of queue
+	Weight int32
+	// Interactive asks for confirmation after the preview
+	Interactive bool
+	// DryRun only prints the preview
+	DryRun bool
+	// Undo restores the weight before the last reweight
+	Undo bool
+	// UndoWindow is how long the last reweight can be undone
+	UndoWindow time.Duration
+}
+
+var reweightQueueFlags = &reweightFlags{}
+
+// InitReweightFlags is used to init all flags during queue reweighting.
+func InitReweightFlags(cmd *cobra.Command) {
+	util.InitFlags(cmd, &reweightQueueFlags.CommonFlags)
+
+	cmd.Flags().StringVarP(&reweightQueueFlags.Name, "name", "n", "", "the name of queue")
+	cmd.Flags().Int32VarP(&reweightQueueFlags.Weight, "weight", "w", 0, "the new weight of the queue")
+	cmd.Flags().BoolVarP(&reweightQueueFlags.Interactive, "interactive", "i", false,
+		"ask for confirmation after previewing the deserved resources of queues")
+	cmd.Flags().BoolVar(&reweightQueueFlags.DryRun, "dry-run", false, "only preview the deserved resources of queues")
+	cmd.Flags().BoolVar(&reweightQueueFlags.Undo, "undo", false, "restore the weight before the last reweight")
+	cmd.Flags().DurationVar(&reweightQueueFlags.UndoWindow, "undo-window", defaultUndoWindow,
+		"how long after the last reweight it can be undone")
+}
+
+// queueShare is the deserved resources of a queue before and after the reweight.
+type queueShare struct {
+	Name      string
+	OldWeight int32
+	NewWeight int32
+	Before    v1.ResourceList
+	After     v1.ResourceList
+}
+
+// ReweightQueue changes the weight of queue after previewing how the deserved resources of all queues shift.
+func ReweightQueue(ctx context.Context) error {
+	config, err := util.BuildConfig(reweightQueueFlags.Master, reweightQueueFlags.Kubeconfig, reweightQueueFlags.Context)
+	if err != nil {
+		return err
+	}
+
+	if len(reweightQueueFlags.Name) == 0 {
+		return fmt.Errorf("queue name must be specified")
+	}
+
+	queueClient := versioned.NewForConfigOrDie(config)
+	queue, err := queueClient.SchedulingV1beta1().Queues().Get(ctx, reweightQueueFlags.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	weight := reweightQueueFlags.Weight
+	if reweightQueueFlags.Undo {
+		if weight, err = previousWeight(queue, now, reweightQueueFlags.UndoWindow); err != nil {
+			return err
+		}
+	} else if weight <= 0 {
+		return fmt.Errorf("weight of queue %s must be specified, the value must be greater than 0", queue.Name)
+	}
+	if weight == queue.Spec.Weight {
+		fmt.Printf("The weight of queue %s is already %d.\n", queue.Name, weight)
+		return nil
+	}
+
+	patchBytes, err := reweightPatch(queue, weight, now, reweightQueueFlags.Undo)
+	if err != nil {
+		return err
+	}
+	// validate the change by the apiserver and the admission webhooks without persisting it
+	if _, err := queueClient.SchedulingV1beta1().Queues().Patch(ctx, queue.Name, types.MergePatchType, patchBytes,
+		metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}}); err != nil {
+		return fmt.Errorf("weight %d of queue %s is rejected: %v", weight, queue.Name, err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	shares, err := previewReweight(ctx, queueClient, kubeClient, queue.Name, weight)
+	if err != nil {
+		return err
+	}
+	printReweightPreview(shares, os.Stdout)
+
+	if reweightQueueFlags.DryRun {
+		return nil
+	}
+	if reweightQueueFlags.Interactive && !util.Confirm(os.Stdin, os.Stdout, fmt.Sprintf("Apply weight %d to queue %s?", weight, queue.Name)) {
+		fmt.Println("Reweight is cancelled.")
+		return nil
+	}
+
+	if _, err := queueClient.SchedulingV1beta1().Queues().Patch(ctx, queue.Name, types.MergePatchType, patchBytes,
+		metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	fmt.Printf("The weight of queue %s is changed from %d to %d.\n", queue.Name, queue.Spec.Weight, weight)
+	if !reweightQueueFlags.Undo {
+		fmt.Printf("Run `vcctl queue reweight -n %s --undo` within %v to restore it.\n", queue.Name, reweightQueueFlags.UndoWindow)
+	}
+	return nil
+}
+
+// previousWeight returns the weight before the last reweight of queue if it is still in the undo window.
+func previousWeight(queue *v1beta1.Queue, now time.Time, window time.Duration) (int32, error) {
+	value, found := queue.Annotations[PreviousWeightAnnotation]
+	if !found {
+		return 0, fmt.Errorf("queue %s has no reweight to undo", queue.Name)
+	}
+	weight, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || weight <= 0 {
+		return 0, fmt.Errorf("invalid annotation %s of queue %s: %q", PreviousWeightAnnotation, queue.Name, value)
+	}
+	reweightedAt, err := time.Parse(time.RFC3339, queue.Annotations[ReweightedAtAnnotation])
+	if err != nil {
+		return 0, fmt.Errorf("invalid annotation %s of queue %s: %v", ReweightedAtAnnotation, queue.Name, err)
+	}
+	if now.Sub(reweightedAt) > window {
+		return 0, fmt.Errorf("the last reweight of queue %s at %s is out of the undo window %v",
+			queue.Name, reweightedAt.Format(time.RFC3339), window)
+	}
+	return int32(weight), nil
+}
+
+// reweightPatch returns the merge patch of the weight, recording the weight before it for undo. The records
+// are removed on undo, so that a reweight is undone only once.
+func reweightPatch(queue *v1beta1.Queue, weight int32, now time.Time, undo bool) ([]byte, error) {
+	annotations := map[string]interface{}{
+		PreviousWeightAnnotation: nil,
+		ReweightedAtAnnotation:   nil,
+	}
+	if !undo {
+		annotations[PreviousWeightAnnotation] = strconv.Itoa(int(queue.Spec.Weight))
+		annotations[ReweightedAtAnnotation] = now.UTC().Format(time.RFC3339)
+	}
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+		"spec":     map[string]interface{}{"weight": weight},
+	})
+}
+
+// previewReweight computes the deserved resources of all queues before and after the weight of queue is changed.
+func previewReweight(ctx context.Context, queueClient versioned.Interface, kubeClient kubernetes.Interface,
+	name string, weight int32) ([]queueShare, error) {
+	queueList, err := queueClient.SchedulingV1beta1().Queues().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pgList, err := queueClient.SchedulingV1beta1().PodGroups("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list podgroups: %v", err)
+	}
+	nodeList, err := kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	total := v1.ResourceList{}
+	for _, node := range nodeList.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		addResourceList(total, node.Status.Allocatable)
+	}
+	requests := map[string]v1.ResourceList{}
+	for _, pg := range pgList.Items {
+		if pg.Status.Phase == v1beta1.PodGroupCompleted || pg.Spec.MinResources == nil {
+			continue
+		}
+		if _, found := requests[pg.Spec.Queue]; !found {
+			requests[pg.Spec.Queue] = v1.ResourceList{}
+		}
+		addResourceList(requests[pg.Spec.Queue], *pg.Spec.MinResources)
+	}
+
+	oldWeights := make(map[string]int32, len(queueList.Items))
+	newWeights := make(map[string]int32, len(queueList.Items))
+	for _, queue := range queueList.Items {
+		oldWeights[queue.Name] = queue.Spec.Weight
+		newWeights[queue.Name] = queue.Spec.Weight
+	}
+	newWeights[name] = weight
+
+	before := deservedShares(queueList.Items, oldWeights, requests, total)
+	after := deservedShares(queueList.Items, newWeights, requests, total)
+	shares := make([]queueShare, 0, len(queueList.Items))
+	for _, queue := range queueList.Items {
+		shares = append(shares, queueShare{
+			Name:      queue.Name,
+			OldWeight: oldWeights[queue.Name],
+			NewWeight: newWeights[queue.Name],
+			Before:    before[queue.Name],
+			After:     after[queue.Name],
+		})
+	}
+	return shares, nil
+}
+
+// deservedShares divides the total resources to queues by weight like the proportion plugin: the remaining
+// resources are divided again among the queues whose deserved resources do not reach their requests or
+// capabilities, until all resources are divided or all queues are satisfied.
+func deservedShares(queues []v1beta1.Queue, weights map[string]int32, requests map[string]v1.ResourceList,
+	total v1.ResourceList) map[string]v1.ResourceList {
+	deserved := make(map[string]v1.ResourceList, len(queues))
+	for _, queue := range queues {
+		deserved[queue.Name] = v1.ResourceList{}
+	}
+
+	for resourceName, quantity := range total {
+		limits := map[string]int64{}
+		for _, queue := range queues {
+			request, found := requests[queue.Name][resourceName]
+			if !found || request.IsZero() || weights[queue.Name] <= 0 {
+				continue
+			}
+			limit := request.MilliValue()
+			if capability, found := queue.Spec.Capability[resourceName]; found && capability.MilliValue() < limit {
+				limit = capability.MilliValue()
+			}
+			limits[queue.Name] = limit
+		}
+
+		shares := map[string]int64{}
+		remaining := quantity.MilliValue()
+		for remaining > 0 && len(limits) > 0 {
+			var totalWeight int64
+			for name := range limits {
+				totalWeight += int64(weights[name])
+			}
+			divided := int64(0)
+			for name, limit := range limits {
+				share := int64(float64(remaining) * float64(weights[name]) / float64(totalWeight))
+				if shares[name]+share >= limit {
+					share = limit - shares[name]
+					delete(limits, name)
+				}
+				shares[name] += share
+				divided += share
+			}
+			remaining -= divided
+			if divided == 0 {
+				break
+			}
+		}
+
+		for name, share := range shares {
+			deserved[name][resourceName] = *resource.NewMilliQuantity(share, quantity.Format)
+		}
+	}
+	return deserved
+}
+
+func addResourceList(list, added v1.ResourceList) {
+	for name, quantity := range added {
+		if value, found := list[name]; found {
+			value.Add(quantity)
+			list[name] = value
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// printReweightPreview prints the weights and the deserved resources of queues before and after the reweight.
+func printReweightPreview(shares []queueShare, writer io.Writer) {
+	resourceSet := map[v1.ResourceName]bool{}
+	for _, share := range shares {
+		for name := range share.Before {
+			resourceSet[name] = true
+		}
+		for name := range share.After {
+			resourceSet[name] = true
+		}
+	}
+	resourceNames := make([]string, 0, len(resourceSet))
+	for name := range resourceSet {
+		resourceNames = append(resourceNames, string(name))
+	}
+	sort.Strings(resourceNames)
+
+	header := fmt.Sprintf("%-25s%-12s", Name, Weight)
+	for _, name := range resourceNames {
+		header += fmt.Sprintf("%-25s", name)
+	}
+	if _, err := fmt.Fprintln(writer, strings.TrimSpace(header)); err != nil {
+		fmt.Printf("Failed to print queue command result: %s.\n", err)
+	}
+
+	for _, share := range shares {
+		weight := strconv.Itoa(int(share.OldWeight))
+		if share.NewWeight != share.OldWeight {
+			weight = fmt.Sprintf("%d->%d", share.OldWeight, share.NewWeight)
+		}
+		line := fmt.Sprintf("%-25s%-12s", share.Name, weight)
+		for _, name := range resourceNames {
+			before, after := share.Before[v1.ResourceName(name)], share.After[v1.ResourceName(name)]
+			cell := before.String()
+			if before.Cmp(after) != 0 {
+				cell = fmt.Sprintf("%s->%s", before.String(), after.String())
+			}
+			line += fmt.Sprintf("%-25s", cell)
+		}
+		if _, err := fmt.Fprintln(writer, strings.TrimSpace(line)); err != nil {
+			fmt.Printf("Failed to print queue command result: %s.\n", err)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	vcfake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+)
+
+func buildQueue(name string, weight int32, capability v1.ResourceList) *v1beta1.Queue {
+	return &v1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1beta1.QueueSpec{Weight: weight, Capability: capability},
+	}
+}
+
+func buildPodGroup(name, queue string, phase v1beta1.PodGroupPhase, minResources v1.ResourceList) *v1beta1.PodGroup {
+	return &v1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       v1beta1.PodGroupSpec{Queue: queue, MinResources: &minResources},
+		Status:     v1beta1.PodGroupStatus{Phase: phase},
+	}
+}
+
+func cpu(value string) v1.ResourceList {
+	return v1.ResourceList{v1.ResourceCPU: resource.MustParse(value)}
+}
+
+func TestDeservedShares(t *testing.T) {
+	total := cpu("12")
+	testCases := []struct {
+		name     string
+		queues   []v1beta1.Queue
+		weights  map[string]int32
+		requests map[string]v1.ResourceList
+		expected map[string]string
+	}{
+		{
+			name:     "divided by weight",
+			queues:   []v1beta1.Queue{*buildQueue("a", 1, nil), *buildQueue("b", 2, nil)},
+			weights:  map[string]int32{"a": 1, "b": 2},
+			requests: map[string]v1.ResourceList{"a": cpu("20"), "b": cpu("20")},
+			expected: map[string]string{"a": "4", "b": "8"},
+		},
+		{
+			name:     "remaining divided again when request is met",
+			queues:   []v1beta1.Queue{*buildQueue("a", 1, nil), *buildQueue("b", 1, nil), *buildQueue("c", 1, nil)},
+			weights:  map[string]int32{"a": 1, "b": 1, "c": 1},
+			requests: map[string]v1.ResourceList{"a": cpu("2"), "b": cpu("20"), "c": cpu("20")},
+			expected: map[string]string{"a": "2", "b": "5", "c": "5"},
+		},
+		{
+			name:     "capped by capability",
+			queues:   []v1beta1.Queue{*buildQueue("a", 3, cpu("3")), *buildQueue("b", 1, nil)},
+			weights:  map[string]int32{"a": 3, "b": 1},
+			requests: map[string]v1.ResourceList{"a": cpu("20"), "b": cpu("20")},
+			expected: map[string]string{"a": "3", "b": "9"},
+		},
+		{
+			name:     "queue without request deserves nothing",
+			queues:   []v1beta1.Queue{*buildQueue("a", 1, nil), *buildQueue("b", 1, nil)},
+			weights:  map[string]int32{"a": 1, "b": 1},
+			requests: map[string]v1.ResourceList{"a": cpu("20")},
+			expected: map[string]string{"a": "12", "b": "0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deserved := deservedShares(tc.queues, tc.weights, tc.requests, total)
+			for name, expected := range tc.expected {
+				got := deserved[name][v1.ResourceCPU]
+				if got.Cmp(resource.MustParse(expected)) != 0 {
+					t.Errorf("expected queue %s deserves %s cpu, got %s", name, expected, got.String())
+				}
+			}
+		})
+	}
+}
+
+func TestPreviewReweight(t *testing.T) {
+	queueClient := vcfake.NewSimpleClientset(
+		buildQueue("a", 1, nil),
+		buildQueue("b", 1, nil),
+		buildPodGroup("pg-a", "a", v1beta1.PodGroupRunning, cpu("20")),
+		buildPodGroup("pg-b", "b", v1beta1.PodGroupPending, cpu("20")),
+		buildPodGroup("pg-done", "b", v1beta1.PodGroupCompleted, cpu("20")),
+	)
+	kubeClient := kubefake.NewSimpleClientset(
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}, Status: v1.NodeStatus{Allocatable: cpu("8")}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n2"}, Status: v1.NodeStatus{Allocatable: cpu("4")}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n3"}, Spec: v1.NodeSpec{Unschedulable: true}, Status: v1.NodeStatus{Allocatable: cpu("4")}},
+	)
+
+	shares, err := previewReweight(context.TODO(), queueClient, kubeClient, "a", 2)
+	if err != nil {
+		t.Fatalf("failed to preview: %v", err)
+	}
+	expected := map[string][2]string{"a": {"6", "8"}, "b": {"6", "4"}}
+	for _, share := range shares {
+		before, after := share.Before[v1.ResourceCPU], share.After[v1.ResourceCPU]
+		if before.Cmp(resource.MustParse(expected[share.Name][0])) != 0 || after.Cmp(resource.MustParse(expected[share.Name][1])) != 0 {
+			t.Errorf("expected queue %s deserves %v cpu, got %s->%s", share.Name, expected[share.Name], before.String(), after.String())
+		}
+	}
+
+	out := &bytes.Buffer{}
+	printReweightPreview(shares, out)
+	if !strings.Contains(out.String(), "1->2") || !strings.Contains(out.String(), "6->8") {
+		t.Errorf("expected weight and deserved changes printed, got:\n%s", out.String())
+	}
+}
+
+func TestReweightUndo(t *testing.T) {
+	now := time.Now()
+	queue := buildQueue("a", 1, nil)
+
+	patch, err := reweightPatch(queue, 5, now, false)
+	if err != nil {
+		t.Fatalf("failed to build patch: %v", err)
+	}
+	var patched struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+		Spec     v1beta1.QueueSpec `json:"spec"`
+	}
+	if err := json.Unmarshal(patch, &patched); err != nil {
+		t.Fatalf("failed to unmarshal patch: %v", err)
+	}
+	if patched.Spec.Weight != 5 {
+		t.Errorf("expected weight 5, got %d", patched.Spec.Weight)
+	}
+	queue.Annotations = patched.Metadata.Annotations
+	queue.Spec.Weight = 5
+
+	if weight, err := previousWeight(queue, now.Add(time.Minute), defaultUndoWindow); err != nil || weight != 1 {
+		t.Errorf("expected previous weight 1, got %d, %v", weight, err)
+	}
+	if _, err := previousWeight(queue, now.Add(time.Hour), defaultUndoWindow); err == nil {
+		t.Errorf("expected undo out of window rejected")
+	}
+	if _, err := previousWeight(buildQueue("b", 1, nil), now, defaultUndoWindow); err == nil {
+		t.Errorf("expected undo without reweight rejected")
+	}
+
+	undoPatch, err := reweightPatch(queue, 1, now, true)
+	if err != nil {
+		t.Fatalf("failed to build undo patch: %v", err)
+	}
+	if !strings.Contains(string(undoPatch), `"volcano.sh/previous-weight":null`) {
+		t.Errorf("expected undo patch removes the previous weight, got %s", undoPatch)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/apis/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/cli/util"
+)
+
+type updateFlags struct {
+	util.CommonFlags
+
+	// Name is name of queue
+	Name string
+	// Weight is the new weight of queue, it is not changed if 0
+	Weight int32
+	// Parent is the new parent queue of queue, it is not changed if empty
+	Parent string
+	// Guarantee is the new guaranteed resources of queue, it is not changed if empty
+	Guarantee string
+	// Capability is the new max resources of queue, it is not changed if empty
+	Capability string
+}
+
+var updateQueueFlags = &updateFlags{}
+
+// InitUpdateFlags is used to init all flags during queue updating.
+func InitUpdateFlags(cmd *cobra.Command) {
+	util.InitFlags(cmd, &updateQueueFlags.CommonFlags)
+
+	cmd.Flags().StringVarP(&updateQueueFlags.Name, "name", "n", "", "the name of queue")
+	cmd.Flags().Int32VarP(&updateQueueFlags.Weight, "weight", "w", 0, "the new weight of the queue")
+	cmd.Flags().StringVar(&updateQueueFlags.Parent, "parent", "", "the new parent queue of the queue in the hierarchy")
+	cmd.Flags().StringVar(&updateQueueFlags.Guarantee, "guarantee", "",
+		"the new guaranteed resources of the queue replacing the current ones, e.g. cpu=100,memory=1Ti,nvidia.com/gpu=64")
+	cmd.Flags().StringVar(&updateQueueFlags.Capability, "capability", "",
+		"the new max resources of the queue replacing the current ones, e.g. cpu=100,memory=1Ti,nvidia.com/gpu=64")
+}
+
+// UpdateQueue updates the weight, parent, guarantee or capability of queue, the ones not specified are kept.
+func UpdateQueue(ctx context.Context) error {
+	config, err := util.BuildConfig(updateQueueFlags.Master, updateQueueFlags.Kubeconfig, updateQueueFlags.Context)
+	if err != nil {
+		return err
+	}
+
+	if len(updateQueueFlags.Name) == 0 {
+		return fmt.Errorf("queue name must be specified")
+	}
+
+	queueClient := versioned.NewForConfigOrDie(config)
+	queue, err := queueClient.SchedulingV1beta1().Queues().Get(ctx, updateQueueFlags.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := applyQueueUpdate(queue, updateQueueFlags); err != nil {
+		return err
+	}
+
+	if _, err := queueClient.SchedulingV1beta1().Queues().Update(ctx, queue, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	fmt.Printf("Queue %s is updated.\n", queue.Name)
+	return nil
+}
+
+// applyQueueUpdate sets the specified fields of the flags to the queue.
+func applyQueueUpdate(queue *v1beta1.Queue, flags *updateFlags) error {
+	if flags.Weight < 0 {
+		return fmt.Errorf("weight of queue %s must be greater than 0", queue.Name)
+	}
+	if flags.Weight == 0 && flags.Parent == "" && flags.Guarantee == "" && flags.Capability == "" {
+		return fmt.Errorf("at least one of weight, parent, guarantee and capability must be specified")
+	}
+
+	if flags.Weight > 0 {
+		queue.Spec.Weight = flags.Weight
+	}
+	if flags.Parent != "" {
+		queue.Spec.Parent = flags.Parent
+	}
+	if flags.Guarantee != "" {
+		guarantee, err := parseResourceList(flags.Guarantee)
+		if err != nil {
+			return fmt.Errorf("invalid guarantee: %v", err)
+		}
+		queue.Spec.Guarantee.Resource = guarantee
+	}
+	if flags.Capability != "" {
+		capability, err := parseResourceList(flags.Capability)
+		if err != nil {
+			return fmt.Errorf("invalid capability: %v", err)
+		}
+		queue.Spec.Capability = capability
+	}
+	return nil
+}
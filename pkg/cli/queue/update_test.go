@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	commonutil "volcano.sh/volcano/pkg/util"
+)
+
+func TestApplyQueueUpdate(t *testing.T) {
+	newQueue := func() *v1beta1.Queue {
+		return &v1beta1.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: "q1"},
+			Spec: v1beta1.QueueSpec{
+				Weight:     1,
+				Parent:     "root",
+				Guarantee:  v1beta1.Guarantee{Resource: v1.ResourceList{v1.ResourceCPU: resource.MustParse("10")}},
+				Capability: v1.ResourceList{v1.ResourceCPU: resource.MustParse("20"), v1.ResourceMemory: resource.MustParse("40Gi")},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		flags              updateFlags
+		expectedWeight     int32
+		expectedParent     string
+		expectedGuarantee  string
+		expectedCapability string
+		expectErr          bool
+	}{
+		{
+			name:               "update weight only",
+			flags:              updateFlags{Weight: 3},
+			expectedWeight:     3,
+			expectedParent:     "root",
+			expectedGuarantee:  "cpu=10",
+			expectedCapability: "cpu=20,memory=40Gi",
+		},
+		{
+			name:               "replace resources and parent",
+			flags:              updateFlags{Parent: "research", Guarantee: "nvidia.com/gpu=8", Capability: "cpu=100"},
+			expectedWeight:     1,
+			expectedParent:     "research",
+			expectedGuarantee:  "nvidia.com/gpu=8",
+			expectedCapability: "cpu=100",
+		},
+		{
+			name:      "nothing to update",
+			flags:     updateFlags{},
+			expectErr: true,
+		},
+		{
+			name:      "negative weight",
+			flags:     updateFlags{Weight: -1},
+			expectErr: true,
+		},
+		{
+			name:      "invalid capability",
+			flags:     updateFlags{Capability: "cpu=lots"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := newQueue()
+			err := applyQueueUpdate(queue, &tc.flags)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+			if queue.Spec.Weight != tc.expectedWeight || queue.Spec.Parent != tc.expectedParent {
+				t.Errorf("expected weight %d and parent %s, got %d and %s",
+					tc.expectedWeight, tc.expectedParent, queue.Spec.Weight, queue.Spec.Parent)
+			}
+			if got := commonutil.FormatResourceList(queue.Spec.Guarantee.Resource); got != tc.expectedGuarantee {
+				t.Errorf("expected guarantee %s, got %s", tc.expectedGuarantee, got)
+			}
+			if got := commonutil.FormatResourceList(queue.Spec.Capability); got != tc.expectedCapability {
+				t.Errorf("expected capability %s, got %s", tc.expectedCapability, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/client/clientset/versioned"
+)
+
+// CompletionFunc is the function completing the flags or the arguments of the commands.
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// JobNameCompletion completes the names of the jobs in the namespace of the command by listing the jobs.
+func JobNameCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, err := completionClient(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	namespace, _ := cmd.Flags().GetString("namespace")
+	jobs, err := client.BatchV1alpha1().Jobs(namespace).List(cmd.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, job := range jobs.Items {
+		names = append(names, job.Name)
+	}
+	return filterCompletion(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// QueueNameCompletion completes the names of the queues by listing the queues.
+func QueueNameCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	client, err := completionClient(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	queues, err := client.SchedulingV1beta1().Queues().List(cmd.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, queue := range queues.Items {
+		names = append(names, queue.Name)
+	}
+	return filterCompletion(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// RegisterFlagCompletion registers the completion function for the flag if the command has the flag.
+func RegisterFlagCompletion(cmd *cobra.Command, flag string, fn CompletionFunc) {
+	if cmd.Flags().Lookup(flag) == nil {
+		return
+	}
+	_ = cmd.RegisterFlagCompletionFunc(flag, fn)
+}
+
+// completionClient builds the client by the common flags of the command, the namespace flag is defaulted to the
+// namespace of the kubeconfig context as the commands do.
+func completionClient(cmd *cobra.Command) (versioned.Interface, error) {
+	if err := SetDefaultNamespace(cmd); err != nil {
+		return nil, err
+	}
+	master, _ := cmd.Flags().GetString("master")
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+	context, _ := cmd.Flags().GetString("context")
+	config, err := BuildConfig(master, kubeconfig, context)
+	if err != nil {
+		return nil, err
+	}
+	return versioned.NewForConfig(config)
+}
+
+func filterCompletion(names []string, toComplete string) []string {
+	var completions []string
+	for _, name := range names {
+		if strings.HasPrefix(name, toComplete) {
+			completions = append(completions, name)
+		}
+	}
+	return completions
+}
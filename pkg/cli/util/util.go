@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -44,26 +45,50 @@ import (
 type CommonFlags struct {
 	Master     string
 	Kubeconfig string
+	Context    string
 }
 
 // InitFlags initializes the common flags for most command lines.
 func InitFlags(cmd *cobra.Command, cf *CommonFlags) {
 	cmd.Flags().StringVarP(&cf.Master, "master", "s", "", "the address of apiserver")
 	cmd.Flags().StringVarP(&cf.Kubeconfig, "kubeconfig", "k", "", "(optional) absolute path to the kubeconfig file")
+	cmd.Flags().StringVar(&cf.Context, "context", "", "(optional) the name of the kubeconfig context to use")
 }
 
 // BuildConfig builds the configuration file for command lines.
-func BuildConfig(master, kubeconfig string) (*rest.Config, error) {
+func BuildConfig(master, kubeconfig, context string) (*rest.Config, error) {
+	return clientConfig(master, kubeconfig, context).ClientConfig()
+}
+
+func clientConfig(master, kubeconfig, context string) clientcmd.ClientConfig {
 	// This will automatically load KUBECONFIG environment variable.
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	if kubeconfig != "" {
 		loadingRules.ExplicitPath = kubeconfig
 	}
-	overrides := &clientcmd.ConfigOverrides{ClusterDefaults: clientcmd.ClusterDefaults}
+	overrides := &clientcmd.ConfigOverrides{ClusterDefaults: clientcmd.ClusterDefaults, CurrentContext: context}
 	if master != "" {
 		overrides.ClusterInfo.Server = master
 	}
-	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+}
+
+// SetDefaultNamespace sets the namespace flag of the command to the namespace of the kubeconfig context if the flag
+// is not set, so that the commands work in the namespace of the context like kubectl.
+func SetDefaultNamespace(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("namespace")
+	if flag == nil || flag.Changed || flag.DefValue != "default" {
+		return nil
+	}
+	master, _ := cmd.Flags().GetString("master")
+	kubeconfig, _ := cmd.Flags().GetString("kubeconfig")
+	context, _ := cmd.Flags().GetString("context")
+	namespace, _, err := clientConfig(master, kubeconfig, context).Namespace()
+	if err != nil || namespace == "" {
+		// the error of the kubeconfig is reported when the command builds the client
+		return nil
+	}
+	return flag.Value.Set(namespace)
 }
 
 // PopulateResourceListV1 takes strings of form <resourceName1>=<value1>,<resourceName2>=<value2> and returns ResourceList.
@@ -119,7 +144,11 @@ func CreateQueueCommand(vcClient *versioned.Clientset, ns, name string, action v
 
 // CreateJobCommand executes a command such as resume/suspend.
 func CreateJobCommand(ctx context.Context, config *rest.Config, ns, name string, action vcbus.Action) error {
-	jobClient := versioned.NewForConfigOrDie(config)
+	return CreateJobCommandWithClient(ctx, versioned.NewForConfigOrDie(config), ns, name, action)
+}
+
+// CreateJobCommandWithClient executes a command such as resume/suspend with the given client.
+func CreateJobCommandWithClient(ctx context.Context, jobClient versioned.Interface, ns, name string, action vcbus.Action) error {
 	job, err := jobClient.BatchV1alpha1().Jobs(ns).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return err
@@ -228,3 +257,17 @@ func CreateTestServer(response interface{}) *httptest.Server {
 	server := httptest.NewServer(handler)
 	return server
 }
+
+// Confirm asks the question and returns true if the answer is yes.
+func Confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
@@ -17,9 +17,15 @@ limitations under the License.
 package util
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"time"
+
+	"github.com/spf13/cobra"
 )
 
 func TestJobUtil(t *testing.T) {
@@ -107,3 +113,84 @@ func TestJobUtil(t *testing.T) {
 		}
 	}
 }
+
+func TestConfirm(t *testing.T) {
+	for answer, expected := range map[string]bool{"y\n": true, "Yes\n": true, "n\n": false, "\n": false, "": false} {
+		if got := Confirm(strings.NewReader(answer), &bytes.Buffer{}, "Apply?"); got != expected {
+			t.Errorf("expected answer %q confirmed %v, got %v", answer, expected, got)
+		}
+	}
+}
+
+func TestSetDefaultNamespace(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	content := `apiVersion: v1
+kind: Config
+clusters:
+- name: c1
+  cluster:
+    server: https://127.0.0.1:6443
+users:
+- name: u1
+contexts:
+- name: dev
+  context: {cluster: c1, user: u1, namespace: team-a}
+- name: prod
+  context: {cluster: c1, user: u1, namespace: team-b}
+current-context: dev
+`
+	if err := os.WriteFile(kubeconfig, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	testCases := []struct {
+		name      string
+		args      []string
+		defValue  string
+		namespace string
+	}{
+		{
+			name:      "namespace of the current context",
+			args:      []string{"--kubeconfig", kubeconfig},
+			defValue:  "default",
+			namespace: "team-a",
+		},
+		{
+			name:      "namespace of the context flag",
+			args:      []string{"--kubeconfig", kubeconfig, "--context", "prod"},
+			defValue:  "default",
+			namespace: "team-b",
+		},
+		{
+			name:      "namespace flag set explicitly",
+			args:      []string{"--kubeconfig", kubeconfig, "-n", "default"},
+			defValue:  "default",
+			namespace: "default",
+		},
+		{
+			name:      "namespace flag without the default namespace",
+			args:      []string{"--kubeconfig", kubeconfig},
+			defValue:  "",
+			namespace: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var cf CommonFlags
+			var namespace string
+			cmd := &cobra.Command{Use: "test"}
+			InitFlags(cmd, &cf)
+			cmd.Flags().StringVarP(&namespace, "namespace", "n", tc.defValue, "the namespace")
+			if err := cmd.Flags().Parse(tc.args); err != nil {
+				t.Fatalf("failed to parse flags: %v", err)
+			}
+			if err := SetDefaultNamespace(cmd); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if namespace != tc.namespace {
+				t.Errorf("expected namespace %q, got %q", tc.namespace, namespace)
+			}
+		})
+	}
+}
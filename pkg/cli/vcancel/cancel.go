@@ -47,7 +47,7 @@ func InitCancelFlags(cmd *cobra.Command) {
 
 // CancelJob cancel the job.
 func CancelJob(ctx context.Context) error {
-	config, err := util.BuildConfig(cancelJobFlags.Master, cancelJobFlags.Kubeconfig)
+	config, err := util.BuildConfig(cancelJobFlags.Master, cancelJobFlags.Kubeconfig, cancelJobFlags.Context)
 	if err != nil {
 		return err
 	}
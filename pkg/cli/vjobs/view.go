@@ -103,7 +103,7 @@ func InitViewFlags(cmd *cobra.Command) {
 
 // ViewJob gives full details of the job.
 func ViewJob(ctx context.Context) error {
-	config, err := util.BuildConfig(viewJobFlags.Master, viewJobFlags.Kubeconfig)
+	config, err := util.BuildConfig(viewJobFlags.Master, viewJobFlags.Kubeconfig, viewJobFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -296,7 +296,7 @@ func WriteLine(writer io.Writer, spaces int, content string, params ...interface
 
 // ListJobs lists all jobs details.
 func ListJobs() error {
-	config, err := util.BuildConfig(viewJobFlags.Master, viewJobFlags.Kubeconfig)
+	config, err := util.BuildConfig(viewJobFlags.Master, viewJobFlags.Kubeconfig, viewJobFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -71,7 +71,7 @@ func InitGetFlags(cmd *cobra.Command) {
 
 // ListQueue lists all the queue.
 func ListQueue() error {
-	config, err := util.BuildConfig(getQueueFlags.Master, getQueueFlags.Kubeconfig)
+	config, err := util.BuildConfig(getQueueFlags.Master, getQueueFlags.Kubeconfig, getQueueFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -110,7 +110,7 @@ func PrintQueues(queues *v1beta1.QueueList, writer io.Writer) {
 
 // GetQueue gets a queue.
 func GetQueue(ctx context.Context) error {
-	config, err := util.BuildConfig(getQueueFlags.Master, getQueueFlags.Kubeconfig)
+	config, err := util.BuildConfig(getQueueFlags.Master, getQueueFlags.Kubeconfig, getQueueFlags.Context)
 	if err != nil {
 		return err
 	}
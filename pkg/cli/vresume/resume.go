@@ -45,7 +45,7 @@ func InitResumeFlags(cmd *cobra.Command) {
 
 // ResumeJob resumes the job.
 func ResumeJob(ctx context.Context) error {
-	config, err := util.BuildConfig(resumeJobFlags.Master, resumeJobFlags.Kubeconfig)
+	config, err := util.BuildConfig(resumeJobFlags.Master, resumeJobFlags.Kubeconfig, resumeJobFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -123,7 +123,7 @@ var jobName = "job.volcano.sh"
 
 // RunJob creates the job.
 func RunJob(ctx context.Context) error {
-	config, err := util.BuildConfig(launchJobFlags.Master, launchJobFlags.Kubeconfig)
+	config, err := util.BuildConfig(launchJobFlags.Master, launchJobFlags.Kubeconfig, launchJobFlags.Context)
 	if err != nil {
 		return err
 	}
@@ -45,7 +45,7 @@ func InitSuspendFlags(cmd *cobra.Command) {
 
 // SuspendJob suspends the job.
 func SuspendJob(ctx context.Context) error {
-	config, err := util.BuildConfig(suspendJobFlags.Master, suspendJobFlags.Kubeconfig)
+	config, err := util.BuildConfig(suspendJobFlags.Master, suspendJobFlags.Kubeconfig, suspendJobFlags.Context)
 	if err != nil {
 		return err
 	}
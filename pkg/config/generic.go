@@ -21,6 +21,8 @@ import (
 	v1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+
+	vcclientset "volcano.sh/apis/pkg/client/clientset/versioned"
 )
 
 type VolcanoAgentConfiguration struct {
@@ -37,6 +39,9 @@ type VolcanoAgentConfiguration struct {
 	// KubeClient is the client to visit k8s
 	KubeClient clientset.Interface
 
+	// VolcanoClient is the client to visit volcano resources, e.g. podgroups.
+	VolcanoClient vcclientset.Interface
+
 	// KubeNodeName is the name of the node which pod is running.
 	KubeNodeName string
 
@@ -80,4 +85,8 @@ type VolcanoAgentConfiguration struct {
 
 	// ExtendResourceMemoryName is the extend resource memory, which is used to calculate overSubscription resources.
 	ExtendResourceMemoryName string
+
+	// ReportNumaTopology determines whether reporting the numa topology got from the kubelet pod resources api at PodResourcesEndpoint.
+	ReportNumaTopology   bool
+	PodResourcesEndpoint string
 }
@@ -18,9 +18,13 @@ package config
 
 import (
 	"k8s.io/client-go/informers"
+
+	vcinformers "volcano.sh/apis/pkg/client/informers/externalversions"
 )
 
 type InformerFactory struct {
 	// K8SInformerFactory gives access to informers of k8s core resource for the controller.
 	K8SInformerFactory informers.SharedInformerFactory
+	// VCInformerFactory gives access to informers of volcano resources, e.g. podgroups.
+	VCInformerFactory vcinformers.SharedInformerFactory
 }
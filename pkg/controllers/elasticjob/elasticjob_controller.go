@@ -0,0 +1,327 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticjob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	schedulinglisterv1 "k8s.io/client-go/listers/scheduling/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	vcclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	versionedscheme "volcano.sh/apis/pkg/client/clientset/versioned/scheme"
+	vcinformer "volcano.sh/apis/pkg/client/informers/externalversions"
+	batchlister "volcano.sh/apis/pkg/client/listers/batch/v1alpha1"
+	schedulinglister "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/framework"
+	"volcano.sh/volcano/pkg/features"
+)
+
+const (
+	// resyncPeriod is the period to re-evaluate all elastic jobs, queue pressure
+	// changes without any event on the job itself.
+	resyncPeriod = 30 * time.Second
+	// scaleCooldown is the minimal interval between two scales of a job, so that the
+	// replicas do not flap while the pods of the last scale are being scheduled.
+	scaleCooldown = 2 * time.Minute
+
+	// ScaleUpReason is added in an event when an elastic task is scaled up.
+	ScaleUpReason = "ElasticScaleUp"
+	// ScaleDownReason is added in an event when an elastic task is scaled down.
+	ScaleDownReason = "ElasticScaleDown"
+)
+
+func init() {
+	framework.RegisterController(&elasticjobcontroller{})
+}
+
+// elasticjobcontroller scales the replicas of elastic tasks, which are marked by
+// ElasticMinReplicasAnnotationKey and ElasticMaxReplicasAnnotationKey on the task
+// template, according to the pressure of the queue the job belongs to. The minAvailable
+// of the job and its elastic tasks is updated along with the replicas, so the job
+// controller creates or deletes pods and updates the podgroup accordingly.
+type elasticjobcontroller struct {
+	kubeClient kubernetes.Interface
+	vcClient   vcclientset.Interface
+
+	informerFactory   informers.SharedInformerFactory
+	vcInformerFactory vcinformer.SharedInformerFactory
+
+	jobLister   batchlister.JobLister
+	queueLister schedulinglister.QueueLister
+	pgLister    schedulinglister.PodGroupLister
+	pcLister    schedulinglisterv1.PriorityClassLister
+
+	// jobs that need to be evaluated.
+	queue workqueue.TypedRateLimitingInterface[string]
+
+	recorder      record.EventRecorder
+	workers       uint32
+	maxRequeueNum int
+}
+
+func (ec *elasticjobcontroller) Name() string {
+	return "elasticjob-controller"
+}
+
+// Initialize creates an instance of elasticjobcontroller.
+func (ec *elasticjobcontroller) Initialize(opt *framework.ControllerOption) error {
+	ec.kubeClient = opt.KubeClient
+	ec.vcClient = opt.VolcanoClient
+	ec.workers = opt.WorkerNum
+	ec.maxRequeueNum = opt.MaxRequeueNum
+	if ec.maxRequeueNum < 0 {
+		ec.maxRequeueNum = -1
+	}
+
+	ec.informerFactory = opt.SharedInformerFactory
+	ec.vcInformerFactory = opt.VCSharedInformerFactory
+
+	jobInformer := ec.vcInformerFactory.Batch().V1alpha1().Jobs()
+	ec.jobLister = jobInformer.Lister()
+	ec.queueLister = ec.vcInformerFactory.Scheduling().V1beta1().Queues().Lister()
+	ec.pgLister = ec.vcInformerFactory.Scheduling().V1beta1().PodGroups().Lister()
+	ec.pcLister = ec.informerFactory.Scheduling().V1().PriorityClasses().Lister()
+
+	ec.queue = workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(klog.Infof)
+	eventBroadcaster.StartRecordingToSink(&corev1.EventSinkImpl{Interface: ec.kubeClient.CoreV1().Events("")})
+	ec.recorder = eventBroadcaster.NewRecorder(versionedscheme.Scheme, v1.EventSource{Component: "vc-controller-manager"})
+
+	jobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: ec.addJob,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			ec.addJob(newObj)
+		},
+	})
+
+	return nil
+}
+
+// Run starts elasticjobcontroller, it does nothing unless the ElasticJobScaling feature is enabled.
+func (ec *elasticjobcontroller) Run(stopCh <-chan struct{}) {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.ElasticJobScaling) {
+		klog.Infof("Feature %s is disabled, elastic job controller will not run", features.ElasticJobScaling)
+		return
+	}
+	defer ec.queue.ShutDown()
+
+	klog.Infof("Starting elastic job controller")
+	defer klog.Infof("Shutting down elastic job controller")
+
+	ec.informerFactory.Start(stopCh)
+	ec.vcInformerFactory.Start(stopCh)
+	for informerType, ok := range ec.informerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("caches failed to sync: %v", informerType)
+			return
+		}
+	}
+	for informerType, ok := range ec.vcInformerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("caches failed to sync: %v", informerType)
+			return
+		}
+	}
+
+	go wait.Until(ec.resync, resyncPeriod, stopCh)
+	for i := 0; i < int(ec.workers); i++ {
+		go wait.Until(ec.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (ec *elasticjobcontroller) addJob(obj interface{}) {
+	job, ok := obj.(*batch.Job)
+	if !ok {
+		klog.Errorf("obj is not Job")
+		return
+	}
+	if hasElasticTask(job) {
+		ec.enqueue(job)
+	}
+}
+
+func (ec *elasticjobcontroller) enqueue(job *batch.Job) {
+	key, err := cache.MetaNamespaceKeyFunc(job)
+	if err != nil {
+		klog.Errorf("couldn't get key for object %#v: %v", job, err)
+		return
+	}
+	ec.queue.Add(key)
+}
+
+// resync enqueues all elastic jobs, as queue pressure changes do not trigger job events.
+func (ec *elasticjobcontroller) resync() {
+	jobs, err := ec.jobLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list jobs: %v", err)
+		return
+	}
+	for _, job := range jobs {
+		if hasElasticTask(job) {
+			ec.enqueue(job)
+		}
+	}
+}
+
+func (ec *elasticjobcontroller) worker() {
+	for ec.processNextWorkItem() {
+	}
+}
+
+func (ec *elasticjobcontroller) processNextWorkItem() bool {
+	key, quit := ec.queue.Get()
+	if quit {
+		return false
+	}
+	defer ec.queue.Done(key)
+
+	err := ec.sync(key)
+	if err == nil {
+		ec.queue.Forget(key)
+		return true
+	}
+
+	if ec.maxRequeueNum == -1 || ec.queue.NumRequeues(key) < ec.maxRequeueNum {
+		klog.V(4).Infof("Error syncing elastic job %s: %v", key, err)
+		ec.queue.AddRateLimited(key)
+		return true
+	}
+
+	klog.V(2).Infof("Dropping elastic job %s out of the queue: %v", key, err)
+	ec.queue.Forget(key)
+	return true
+}
+
+func (ec *elasticjobcontroller) sync(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	job, err := ec.jobLister.Jobs(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	// only scale running jobs, other phases are handled by job controller
+	if job.DeletionTimestamp != nil || job.Status.State.Phase != batch.Running {
+		return nil
+	}
+
+	if remaining := cooldownRemaining(job, scaleCooldown, time.Now()); remaining > 0 {
+		klog.V(4).Infof("Skip scaling job %s in cooldown, %v remaining", key, remaining)
+		return nil
+	}
+
+	queue, err := ec.queueLister.Get(job.Spec.Queue)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	higherPriorityPending, err := ec.hasHigherPriorityPending(job)
+	if err != nil {
+		return err
+	}
+
+	newJob := job.DeepCopy()
+	elasticGang(newJob)
+	idle := idleDeserved(queue.Spec.Deserved, queue.Status.Allocated)
+	index, replicas, ok := decideScale(newJob, idle, higherPriorityPending)
+	if !ok {
+		return nil
+	}
+
+	oldReplicas := newJob.Spec.Tasks[index].Replicas
+	newJob.Spec.Tasks[index].Replicas = replicas
+	if newJob.Annotations == nil {
+		newJob.Annotations = map[string]string{}
+	}
+	newJob.Annotations[ElasticLastScaleTimeAnnotationKey] = time.Now().Format(time.RFC3339)
+	if _, err := ec.vcClient.BatchV1alpha1().Jobs(namespace).Update(context.TODO(), newJob, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to scale task %s of job %s: %v", newJob.Spec.Tasks[index].Name, key, err)
+	}
+
+	reason := ScaleUpReason
+	if replicas < oldReplicas {
+		reason = ScaleDownReason
+	}
+	ec.recorder.Eventf(job, v1.EventTypeNormal, reason, "Scale task %s from %d to %d replicas according to queue %s pressure",
+		newJob.Spec.Tasks[index].Name, oldReplicas, replicas, queue.Name)
+	return nil
+}
+
+// hasHigherPriorityPending returns true if there is any podgroup in the queue of the job
+// which has higher priority than the job and is waiting for resources.
+func (ec *elasticjobcontroller) hasHigherPriorityPending(job *batch.Job) (bool, error) {
+	jobPriority := ec.getPriority(job.Spec.PriorityClassName)
+
+	pgs, err := ec.pgLister.List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	for _, pg := range pgs {
+		if pg.Spec.Queue != job.Spec.Queue {
+			continue
+		}
+		if pg.Status.Phase != scheduling.PodGroupPending && pg.Status.Phase != scheduling.PodGroupInqueue {
+			continue
+		}
+		if ec.getPriority(pg.Spec.PriorityClassName) > jobPriority {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (ec *elasticjobcontroller) getPriority(priorityClassName string) int32 {
+	if priorityClassName == "" {
+		return 0
+	}
+	pc, err := ec.pcLister.Get(priorityClassName)
+	if err != nil {
+		klog.V(4).Infof("Ignore priority class %s: %v", priorityClassName, err)
+		return 0
+	}
+	return pc.Value
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticjob
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/util"
+)
+
+const (
+	// ElasticMinReplicasAnnotationKey is the annotation key on the task template which
+	// declares the minimal replicas an elastic task can be scaled down to.
+	ElasticMinReplicasAnnotationKey = "volcano.sh/elastic-min-replicas"
+	// ElasticMaxReplicasAnnotationKey is the annotation key on the task template which
+	// declares the maximal replicas an elastic task can be scaled up to.
+	ElasticMaxReplicasAnnotationKey = "volcano.sh/elastic-max-replicas"
+	// ElasticLastScaleTimeAnnotationKey is the annotation key on the job which records the
+	// last time an elastic task of the job is scaled, in RFC3339 format.
+	ElasticLastScaleTimeAnnotationKey = "volcano.sh/elastic-last-scale-time"
+)
+
+// elasticRange is the replicas range of an elastic task.
+type elasticRange struct {
+	min int32
+	max int32
+}
+
+// getElasticRange returns the replicas range of the task, the bool result is false
+// if the task is not marked as elastic.
+func getElasticRange(task *batch.TaskSpec) (elasticRange, bool, error) {
+	minStr, minFound := task.Template.Annotations[ElasticMinReplicasAnnotationKey]
+	maxStr, maxFound := task.Template.Annotations[ElasticMaxReplicasAnnotationKey]
+	if !minFound && !maxFound {
+		return elasticRange{}, false, nil
+	}
+	if !minFound || !maxFound {
+		return elasticRange{}, false, fmt.Errorf("task %s must set both %s and %s",
+			task.Name, ElasticMinReplicasAnnotationKey, ElasticMaxReplicasAnnotationKey)
+	}
+
+	minReplicas, err := strconv.ParseInt(minStr, 10, 32)
+	if err != nil {
+		return elasticRange{}, false, fmt.Errorf("invalid %s of task %s: %v", ElasticMinReplicasAnnotationKey, task.Name, err)
+	}
+	maxReplicas, err := strconv.ParseInt(maxStr, 10, 32)
+	if err != nil {
+		return elasticRange{}, false, fmt.Errorf("invalid %s of task %s: %v", ElasticMaxReplicasAnnotationKey, task.Name, err)
+	}
+	if minReplicas < 0 || minReplicas > maxReplicas {
+		return elasticRange{}, false, fmt.Errorf("task %s must satisfy 0 <= %s <= %s",
+			task.Name, ElasticMinReplicasAnnotationKey, ElasticMaxReplicasAnnotationKey)
+	}
+
+	r := elasticRange{min: int32(minReplicas), max: int32(maxReplicas)}
+	// never scale a task below its gang requirement
+	if task.MinAvailable != nil && r.min < *task.MinAvailable {
+		r.min = *task.MinAvailable
+	}
+	return r, true, nil
+}
+
+// hasElasticTask returns true if any task of the job is marked as elastic.
+func hasElasticTask(job *batch.Job) bool {
+	for i := range job.Spec.Tasks {
+		if _, ok, _ := getElasticRange(&job.Spec.Tasks[i]); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// elasticGang sets the minAvailable of the elastic tasks without one to their minimal replicas, and lowers
+// the minAvailable of the job to the sum of the minAvailable of the tasks. The podgroup is updated from
+// them by job controller, so its minMember, minTaskMember and minResources cover the minimal replicas of
+// the elastic tasks rather than their current replicas, which are changed by each scale.
+func elasticGang(job *batch.Job) {
+	var gang int32
+	for i := range job.Spec.Tasks {
+		task := &job.Spec.Tasks[i]
+		if r, ok, err := getElasticRange(task); err == nil && ok && task.MinAvailable == nil {
+			// the task may be created with fewer replicas than its minimal replicas
+			minAvailable := min(r.min, task.Replicas)
+			task.MinAvailable = &minAvailable
+		}
+		if task.MinAvailable != nil {
+			gang += *task.MinAvailable
+		} else {
+			gang += task.Replicas
+		}
+	}
+	if job.Spec.MinAvailable > gang {
+		job.Spec.MinAvailable = gang
+	}
+}
+
+// cooldownRemaining returns how long the job must wait before it is scaled again, so that
+// the scheduler settles the last scale before the queue pressure is evaluated again.
+func cooldownRemaining(job *batch.Job, cooldown time.Duration, now time.Time) time.Duration {
+	value, found := job.Annotations[ElasticLastScaleTimeAnnotationKey]
+	if !found {
+		return 0
+	}
+	last, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0
+	}
+	if remaining := last.Add(cooldown).Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// idleDeserved returns the deserved resources of the queue which are not allocated yet.
+func idleDeserved(deserved, allocated v1.ResourceList) v1.ResourceList {
+	if len(deserved) == 0 {
+		return nil
+	}
+	return quotav1.SubtractWithNonNegativeResult(deserved, quotav1.Mask(allocated, quotav1.ResourceNames(deserved)))
+}
+
+// decideScale returns the index and the new replicas of the task to scale. Only one replica
+// of one task is changed per decision so that the scheduler can react to each step. The
+// bool result is false if nothing needs to be changed.
+//
+// Elastic tasks are scaled down, starting from the last task, when higher priority workloads
+// are pending in the queue; otherwise they are scaled up, starting from the first task, as
+// long as one more replica fits into the idle deserved resources of the queue.
+func decideScale(job *batch.Job, idle v1.ResourceList, higherPriorityPending bool) (int, int32, bool) {
+	var totalReplicas int32
+	for _, task := range job.Spec.Tasks {
+		totalReplicas += task.Replicas
+	}
+
+	if higherPriorityPending {
+		for i := len(job.Spec.Tasks) - 1; i >= 0; i-- {
+			task := &job.Spec.Tasks[i]
+			r, ok, err := getElasticRange(task)
+			if err != nil || !ok {
+				continue
+			}
+			// job minAvailable is kept in sync with podgroup minMember, keep the gang satisfiable
+			if task.Replicas > r.min && totalReplicas-1 >= job.Spec.MinAvailable {
+				return i, task.Replicas - 1, true
+			}
+		}
+		return -1, 0, false
+	}
+
+	if len(idle) == 0 {
+		return -1, 0, false
+	}
+	for i := range job.Spec.Tasks {
+		task := &job.Spec.Tasks[i]
+		r, ok, err := getElasticRange(task)
+		if err != nil || !ok || task.Replicas >= r.max {
+			continue
+		}
+		request := util.GetPodQuotaUsage(&v1.Pod{Spec: task.Template.Spec})
+		if fit, _ := quotav1.LessThanOrEqual(request, idle); fit {
+			return i, task.Replicas + 1, true
+		}
+	}
+	return -1, 0, false
+}
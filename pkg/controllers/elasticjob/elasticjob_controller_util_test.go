@@ -0,0 +1,267 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticjob
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+func buildTask(name string, replicas int32, minAvailable *int32, annotations map[string]string) batch.TaskSpec {
+	return batch.TaskSpec{
+		Name:         name,
+		Replicas:     replicas,
+		MinAvailable: minAvailable,
+		Template: v1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name: "c",
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func elastic(min, max string) map[string]string {
+	return map[string]string{
+		ElasticMinReplicasAnnotationKey: min,
+		ElasticMaxReplicasAnnotationKey: max,
+	}
+}
+
+func TestGetElasticRange(t *testing.T) {
+	testCases := []struct {
+		name        string
+		task        batch.TaskSpec
+		expected    elasticRange
+		expectedOk  bool
+		expectedErr bool
+	}{
+		{
+			name: "not elastic",
+			task: buildTask("worker", 2, nil, nil),
+		},
+		{
+			name:        "only min set",
+			task:        buildTask("worker", 2, nil, map[string]string{ElasticMinReplicasAnnotationKey: "1"}),
+			expectedErr: true,
+		},
+		{
+			name:        "min greater than max",
+			task:        buildTask("worker", 2, nil, elastic("3", "2")),
+			expectedErr: true,
+		},
+		{
+			name:        "invalid number",
+			task:        buildTask("worker", 2, nil, elastic("a", "2")),
+			expectedErr: true,
+		},
+		{
+			name:       "valid range",
+			task:       buildTask("worker", 2, nil, elastic("1", "4")),
+			expected:   elasticRange{min: 1, max: 4},
+			expectedOk: true,
+		},
+		{
+			name:       "min raised to task minAvailable",
+			task:       buildTask("worker", 2, ptr.To[int32](2), elastic("1", "4")),
+			expected:   elasticRange{min: 2, max: 4},
+			expectedOk: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, ok, err := getElasticRange(&tc.task)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if ok != tc.expectedOk || r != tc.expected {
+				t.Errorf("expected %v/%v, got %v/%v", tc.expected, tc.expectedOk, r, ok)
+			}
+		})
+	}
+}
+
+func TestDecideScale(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		tasks                 []batch.TaskSpec
+		minAvailable          int32
+		idle                  v1.ResourceList
+		higherPriorityPending bool
+		expectedIndex         int
+		expectedReplicas      int32
+		expectedOk            bool
+	}{
+		{
+			name:             "scale up when idle deserved fits one replica",
+			tasks:            []batch.TaskSpec{buildTask("master", 1, nil, nil), buildTask("worker", 2, nil, elastic("1", "4"))},
+			minAvailable:     2,
+			idle:             v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+			expectedIndex:    1,
+			expectedReplicas: 3,
+			expectedOk:       true,
+		},
+		{
+			name:          "no scale up when idle deserved is not enough",
+			tasks:         []batch.TaskSpec{buildTask("worker", 2, nil, elastic("1", "4"))},
+			minAvailable:  1,
+			idle:          v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+			expectedIndex: -1,
+		},
+		{
+			name:          "no scale up beyond max",
+			tasks:         []batch.TaskSpec{buildTask("worker", 4, nil, elastic("1", "4"))},
+			minAvailable:  1,
+			idle:          v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+			expectedIndex: -1,
+		},
+		{
+			name:                  "scale down when higher priority workloads are pending",
+			tasks:                 []batch.TaskSpec{buildTask("worker", 3, nil, elastic("1", "4"))},
+			minAvailable:          1,
+			idle:                  v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+			higherPriorityPending: true,
+			expectedIndex:         0,
+			expectedReplicas:      2,
+			expectedOk:            true,
+		},
+		{
+			name:                  "no scale down below job minAvailable",
+			tasks:                 []batch.TaskSpec{buildTask("worker", 3, nil, elastic("1", "4"))},
+			minAvailable:          3,
+			higherPriorityPending: true,
+			expectedIndex:         -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &batch.Job{Spec: batch.JobSpec{MinAvailable: tc.minAvailable, Tasks: tc.tasks}}
+			index, replicas, ok := decideScale(job, tc.idle, tc.higherPriorityPending)
+			if index != tc.expectedIndex || replicas != tc.expectedReplicas || ok != tc.expectedOk {
+				t.Errorf("expected %d/%d/%v, got %d/%d/%v", tc.expectedIndex, tc.expectedReplicas, tc.expectedOk, index, replicas, ok)
+			}
+		})
+	}
+}
+
+func TestElasticGang(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		tasks                []batch.TaskSpec
+		minAvailable         int32
+		expectedMinAvailable int32
+		expectedTaskMin      []*int32
+	}{
+		{
+			name:                 "elastic task requires its minimal replicas",
+			tasks:                []batch.TaskSpec{buildTask("master", 1, nil, nil), buildTask("worker", 3, nil, elastic("1", "4"))},
+			minAvailable:         4,
+			expectedMinAvailable: 2,
+			expectedTaskMin:      []*int32{nil, ptr.To[int32](1)},
+		},
+		{
+			name:                 "explicit minAvailable of task is kept",
+			tasks:                []batch.TaskSpec{buildTask("worker", 3, ptr.To[int32](2), elastic("1", "4"))},
+			minAvailable:         3,
+			expectedMinAvailable: 2,
+			expectedTaskMin:      []*int32{ptr.To[int32](2)},
+		},
+		{
+			name:                 "lower minAvailable of job is kept",
+			tasks:                []batch.TaskSpec{buildTask("worker", 3, nil, elastic("2", "4"))},
+			minAvailable:         1,
+			expectedMinAvailable: 1,
+			expectedTaskMin:      []*int32{ptr.To[int32](2)},
+		},
+		{
+			name:                 "minAvailable of task does not exceed replicas",
+			tasks:                []batch.TaskSpec{buildTask("worker", 1, nil, elastic("2", "4"))},
+			minAvailable:         1,
+			expectedMinAvailable: 1,
+			expectedTaskMin:      []*int32{ptr.To[int32](1)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &batch.Job{Spec: batch.JobSpec{MinAvailable: tc.minAvailable, Tasks: tc.tasks}}
+			elasticGang(job)
+			if job.Spec.MinAvailable != tc.expectedMinAvailable {
+				t.Errorf("expected job minAvailable %d, got %d", tc.expectedMinAvailable, job.Spec.MinAvailable)
+			}
+			for i, expected := range tc.expectedTaskMin {
+				actual := job.Spec.Tasks[i].MinAvailable
+				if (expected == nil) != (actual == nil) || (expected != nil && *expected != *actual) {
+					t.Errorf("expected minAvailable %v of task %d, got %v", expected, i, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestCooldownRemaining(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    time.Duration
+	}{
+		{
+			name: "never scaled",
+		},
+		{
+			name:        "scaled recently",
+			annotations: map[string]string{ElasticLastScaleTimeAnnotationKey: now.Add(-30 * time.Second).Format(time.RFC3339)},
+			expected:    90 * time.Second,
+		},
+		{
+			name:        "cooldown passed",
+			annotations: map[string]string{ElasticLastScaleTimeAnnotationKey: now.Add(-3 * time.Minute).Format(time.RFC3339)},
+		},
+		{
+			name:        "invalid time",
+			annotations: map[string]string{ElasticLastScaleTimeAnnotationKey: "yesterday"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &batch.Job{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			remaining := cooldownRemaining(job, 2*time.Minute, now)
+			// the recorded time is truncated to seconds
+			if remaining < tc.expected-time.Second || remaining > tc.expected {
+				t.Errorf("expected remaining %v, got %v", tc.expected, remaining)
+			}
+		})
+	}
+}
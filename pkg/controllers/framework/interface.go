@@ -17,6 +17,8 @@ limitations under the License.
 package framework
 
 import (
+	"time"
+
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -40,6 +42,10 @@ type ControllerOption struct {
 	WorkerThreadsForPG      uint32
 	WorkerThreadsForQueue   uint32
 	WorkerThreadsForGC      uint32
+	// JobStatusSyncPeriod is the window in which the pod events of a job are coalesced into a single sync
+	JobStatusSyncPeriod time.Duration
+	// JobResourceLabels are the labels added to all the objects created by the job plugins
+	JobResourceLabels map[string]string
 
 	// Config holds the common attributes that can be passed to a Kubernetes client
 	// and controllers registered by the users can use it.
@@ -30,4 +30,6 @@ const (
 	// SuccessfulDeletePodReason is added in an event when a pod for a replica set
 	// is successfully deleted.
 	SuccessfulDeletePodReason = "SuccessfulDelete"
+	// InvalidTaskResultReason is added in an event when the result of a pod is not valid json or too large.
+	InvalidTaskResultReason = "InvalidTaskResult"
 )
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+const (
+	// CheckpointHookAnnotation is the annotation key of the job declaring the hook checkpointing a task before it
+	// is evicted in json, e.g. {"httpGet": {"path": "/checkpoint", "port": 8080}, "path": "/ckpt"}.
+	CheckpointHookAnnotation = "volcano.sh/checkpoint-hook"
+	// CheckpointCompletedAnnotation is the annotation key recording the time the last checkpoint of the job completed.
+	CheckpointCompletedAnnotation = "volcano.sh/checkpoint-completed"
+	// CheckpointPathEnv is the environment variable giving the checkpoint path to the pods to resume from.
+	CheckpointPathEnv = "VC_CHECKPOINT_PATH"
+
+	// DefaultCheckpointTimeoutSeconds is the timeout of the hook if not set.
+	DefaultCheckpointTimeoutSeconds = 30
+)
+
+// CheckpointHook is the hook invoked on a task before it is evicted, so that the task saves its progress to the
+// checkpoint path to resume from when the job is restarted.
+type CheckpointHook struct {
+	// Exec executes the command in the first container of the pod.
+	Exec *v1.ExecAction `json:"exec,omitempty"`
+	// HTTPGet sends the http request to the pod.
+	HTTPGet *v1.HTTPGetAction `json:"httpGet,omitempty"`
+	// TimeoutSeconds is the time to wait for the hook before evicting the task anyway, 30 by default.
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// Path is the checkpoint path given to the pods by VC_CHECKPOINT_PATH once a checkpoint completed.
+	Path string `json:"path,omitempty"`
+}
+
+// Timeout returns the time to wait for the hook.
+func (h *CheckpointHook) Timeout() time.Duration {
+	if h.TimeoutSeconds <= 0 {
+		return DefaultCheckpointTimeoutSeconds * time.Second
+	}
+	return time.Duration(h.TimeoutSeconds) * time.Second
+}
+
+// GetCheckpointHook parses the checkpoint hook declared by the annotations of a job, or of its podgroup or pods
+// which the annotations of the job are copied to.
+func GetCheckpointHook(annotations map[string]string) (*CheckpointHook, error) {
+	value, found := annotations[CheckpointHookAnnotation]
+	if !found || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	hook := &CheckpointHook{}
+	if err := json.Unmarshal([]byte(value), hook); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", CheckpointHookAnnotation, err)
+	}
+	if (hook.Exec == nil) == (hook.HTTPGet == nil) {
+		return nil, fmt.Errorf("invalid annotation %s: exactly one of exec and httpGet must be set", CheckpointHookAnnotation)
+	}
+	if hook.Exec != nil && len(hook.Exec.Command) == 0 {
+		return nil, fmt.Errorf("invalid annotation %s: exec.command must not be empty", CheckpointHookAnnotation)
+	}
+	if hook.HTTPGet != nil && hook.HTTPGet.Port.IntValue() == 0 && hook.HTTPGet.Port.StrVal == "" {
+		return nil, fmt.Errorf("invalid annotation %s: httpGet.port must be set", CheckpointHookAnnotation)
+	}
+	if hook.TimeoutSeconds < 0 {
+		return nil, fmt.Errorf("invalid annotation %s: timeoutSeconds must not be negative", CheckpointHookAnnotation)
+	}
+	return hook, nil
+}
+
+// ApplyCheckpointPath gives the checkpoint path to the containers of the pod by VC_CHECKPOINT_PATH once a
+// checkpoint of the job completed, so that the restarted tasks resume from the checkpoint.
+func ApplyCheckpointPath(pod *v1.Pod, job *batch.Job) error {
+	if job.Annotations[CheckpointCompletedAnnotation] == "" {
+		return nil
+	}
+	hook, err := GetCheckpointHook(job.Annotations)
+	if err != nil || hook == nil || hook.Path == "" {
+		return err
+	}
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if hasEnv(container.Env, CheckpointPathEnv) {
+			continue
+		}
+		container.Env = append(container.Env, v1.EnvVar{Name: CheckpointPathEnv, Value: hook.Path})
+	}
+	return nil
+}
+
+func hasEnv(envs []v1.EnvVar, name string) bool {
+	for _, env := range envs {
+		if env.Name == name {
+			return true
+		}
+	}
+	return false
+}
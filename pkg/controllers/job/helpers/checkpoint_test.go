@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+func TestGetCheckpointHook(t *testing.T) {
+	testCases := []struct {
+		name          string
+		annotation    string
+		expectHook    bool
+		expectTimeout time.Duration
+		expectErr     bool
+	}{
+		{
+			name: "no checkpoint hook",
+		},
+		{
+			name:          "http hook with default timeout",
+			annotation:    `{"httpGet": {"path": "/checkpoint", "port": 8080}, "path": "/ckpt"}`,
+			expectHook:    true,
+			expectTimeout: 30 * time.Second,
+		},
+		{
+			name:          "exec hook with timeout",
+			annotation:    `{"exec": {"command": ["/bin/checkpoint"]}, "timeoutSeconds": 60}`,
+			expectHook:    true,
+			expectTimeout: time.Minute,
+		},
+		{
+			name:       "invalid json",
+			annotation: `{"exec": "checkpoint"}`,
+			expectErr:  true,
+		},
+		{
+			name:       "no handler",
+			annotation: `{"path": "/ckpt"}`,
+			expectErr:  true,
+		},
+		{
+			name:       "both handlers",
+			annotation: `{"exec": {"command": ["/bin/checkpoint"]}, "httpGet": {"port": 8080}}`,
+			expectErr:  true,
+		},
+		{
+			name:       "empty command",
+			annotation: `{"exec": {"command": []}}`,
+			expectErr:  true,
+		},
+		{
+			name:       "no port",
+			annotation: `{"httpGet": {"path": "/checkpoint"}}`,
+			expectErr:  true,
+		},
+		{
+			name:       "negative timeout",
+			annotation: `{"httpGet": {"port": "http"}, "timeoutSeconds": -1}`,
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hook, err := GetCheckpointHook(map[string]string{CheckpointHookAnnotation: tc.annotation})
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if (hook != nil) != tc.expectHook {
+				t.Fatalf("expected hook %v, got %v", tc.expectHook, hook)
+			}
+			if hook != nil && hook.Timeout() != tc.expectTimeout {
+				t.Errorf("expected timeout %v, got %v", tc.expectTimeout, hook.Timeout())
+			}
+		})
+	}
+}
+
+func TestApplyCheckpointPath(t *testing.T) {
+	hook := `{"httpGet": {"path": "/checkpoint", "port": 8080}, "path": "/ckpt"}`
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expectPath  string
+	}{
+		{
+			name:        "no checkpoint completed",
+			annotations: map[string]string{CheckpointHookAnnotation: hook},
+		},
+		{
+			name: "checkpoint completed",
+			annotations: map[string]string{
+				CheckpointHookAnnotation:      hook,
+				CheckpointCompletedAnnotation: "2025-06-01T08:00:00Z",
+			},
+			expectPath: "/ckpt",
+		},
+		{
+			name: "hook without checkpoint path",
+			annotations: map[string]string{
+				CheckpointHookAnnotation:      `{"exec": {"command": ["/bin/checkpoint"]}}`,
+				CheckpointCompletedAnnotation: "2025-06-01T08:00:00Z",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &batch.Job{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "ns1", Annotations: tc.annotations}}
+			pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "main"}, {Name: "sidecar"}}}}
+			if err := ApplyCheckpointPath(pod, job); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, container := range pod.Spec.Containers {
+				path := ""
+				for _, env := range container.Env {
+					if env.Name == CheckpointPathEnv {
+						path = env.Value
+					}
+				}
+				if path != tc.expectPath {
+					t.Errorf("expected %s %q in container %s, got %q", CheckpointPathEnv, tc.expectPath, container.Name, path)
+				}
+			}
+		})
+	}
+}
@@ -35,6 +35,10 @@ const (
 	PodNameFmt = "%s-%s-%d"
 	// persistentVolumeClaimFmt represents persistent volume claim name format
 	persistentVolumeClaimFmt = "%s-pvc-%s"
+	// StablePodIdentityAnnotation opts a job into stable pod identities: the hostname of a pod is always its name
+	// job-task-index and the subdomain the job name, whatever the hostname and subdomain of the task template, so
+	// that the domain name of a pod is kept across restarts and the same in all the plugins.
+	StablePodIdentityAnnotation = "volcano.sh/stable-pod-identity"
 )
 
 // GetPodIndexUnderTask returns task Index.
@@ -83,24 +87,49 @@ func GetTaskSpec(job *batch.Job, taskName string) (batch.TaskSpec, bool) {
 	return batch.TaskSpec{}, false
 }
 
+// StablePodIdentity returns whether the job opts into stable pod identities.
+func StablePodIdentity(job *batch.Job) bool {
+	return job.Annotations[StablePodIdentityAnnotation] == "true"
+}
+
 // MakeDomainName creates task domain name
 func MakeDomainName(ts batch.TaskSpec, job *batch.Job, index int) string {
 	hostName := ts.Template.Spec.Hostname
 	subdomain := ts.Template.Spec.Subdomain
-	if len(hostName) == 0 {
+	if len(hostName) == 0 || StablePodIdentity(job) {
 		hostName = MakePodName(job.Name, ts.Name, index)
 	}
-	if len(subdomain) == 0 {
+	if len(subdomain) == 0 || StablePodIdentity(job) {
 		subdomain = job.Name
 	}
 	return hostName + "." + subdomain
 }
 
+// MakeTaskDomainNames creates the domain names of the pods of the task. All the pods share the hostname of the
+// task template if it is set, so there is only one domain name then unless the job opts into stable pod identities.
+func MakeTaskDomainNames(ts batch.TaskSpec, job *batch.Job) []string {
+	replicas := int(ts.Replicas)
+	if len(ts.Template.Spec.Hostname) != 0 && !StablePodIdentity(job) && replicas > 1 {
+		replicas = 1
+	}
+	names := make([]string, 0, replicas)
+	for i := 0; i < replicas; i++ {
+		names = append(names, MakeDomainName(ts, job, i))
+	}
+	return names
+}
+
 // MakePodName creates pod name.
 func MakePodName(jobName string, taskName string, index int) string {
 	return fmt.Sprintf(PodNameFmt, jobName, taskName, index)
 }
 
+// MakePodGroupName creates the name of the podgroup of the job, the uid of the job is added so that the
+// podgroup of a deleted job is not reused by a new job with the same name.
+func MakePodGroupName(job *batch.Job) string {
+	return fmt.Sprintf("%s-%s", job.Name, string(job.UID))
+}
+
 // GenRandomStr generate random str with specified length l.
 func GenRandomStr(l int) string {
 	str := "0123456789abcdefghijklmnopqrstuvwxyz"
@@ -171,3 +200,17 @@ func GetTaskReplicasUnderJob(taskName string, job *batch.Job) int32 {
 	}
 	return 0
 }
+
+// GetPodOS returns the operating system required by the pod spec, which is set by spec.os
+// or the kubernetes.io/os node selector. Empty means the pod has no requirement.
+func GetPodOS(spec *v1.PodSpec) string {
+	if spec.OS != nil && spec.OS.Name != "" {
+		return string(spec.OS.Name)
+	}
+	return spec.NodeSelector[v1.LabelOSStable]
+}
+
+// IsWindowsPod returns true if the pod is required to run on windows nodes.
+func IsWindowsPod(pod *v1.Pod) bool {
+	return GetPodOS(&pod.Spec) == string(v1.Windows)
+}
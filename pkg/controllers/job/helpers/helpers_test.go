@@ -608,3 +608,66 @@ func TestGetTaskReplicasUnderJob(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTemplateRef(t *testing.T) {
+	testCases := []struct {
+		ref          string
+		expectedName string
+		expectedHash string
+		expectErr    bool
+	}{
+		{ref: "trainer", expectedName: "trainer"},
+		{ref: "trainer@sha256:abc", expectedName: "trainer", expectedHash: "sha256:abc"},
+		{ref: "trainer@abc", expectErr: true},
+		{ref: "@sha256:abc", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		name, hash, err := ParseTemplateRef(tc.ref)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("%s: expected error %v, got %v", tc.ref, tc.expectErr, err)
+			continue
+		}
+		if name != tc.expectedName || hash != tc.expectedHash {
+			t.Errorf("%s: expected %s %s, got %s %s", tc.ref, tc.expectedName, tc.expectedHash, name, hash)
+		}
+	}
+}
+
+func TestMakeTaskDomainNames(t *testing.T) {
+	ts := batch.TaskSpec{
+		Name:     "worker",
+		Replicas: 2,
+		Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Hostname: "trainer", Subdomain: "train"}},
+	}
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    []string
+	}{
+		{
+			name:     "hostname of the template shared by the pods",
+			expected: []string{"trainer.train"},
+		},
+		{
+			name:        "stable pod identities",
+			annotations: map[string]string{StablePodIdentityAnnotation: "true"},
+			expected:    []string{"job1-worker-0.job1", "job1-worker-1.job1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &batch.Job{ObjectMeta: metav1.ObjectMeta{Name: "job1", Annotations: tc.annotations}}
+			names := MakeTaskDomainNames(ts, job)
+			if len(names) != len(tc.expected) {
+				t.Fatalf("expected domain names %v, got %v", tc.expected, names)
+			}
+			for i := range names {
+				if names[i] != tc.expected[i] {
+					t.Errorf("expected domain names %v, got %v", tc.expected, names)
+				}
+			}
+		})
+	}
+}
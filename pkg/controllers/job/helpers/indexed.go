@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+const (
+	// CompletionModeAnnotation is the annotation key of the task template holding the completion mode of the task.
+	CompletionModeAnnotation = "volcano.sh/completion-mode"
+	// IndexedCompletionMode runs the replicas of the task as indexes from 0 to replicas-1, e.g. the trials of a
+	// hyperparameter sweep. A completed index is never run again, and the task completes when all indexes complete.
+	IndexedCompletionMode = "Indexed"
+	// ParallelismAnnotation is the annotation key of the template of an indexed task holding the max number of
+	// its pods running at the same time, all the pods are run at the same time if it is not set.
+	ParallelismAnnotation = "volcano.sh/parallelism"
+	// CompletedIndexesAnnotation is the annotation key of the job holding the completed indexes of its indexed
+	// tasks in json, keyed by the task name, e.g. {"trial": "0-3,5"}.
+	CompletedIndexesAnnotation = "volcano.sh/completed-indexes"
+)
+
+// IsIndexedTask returns whether the task runs in the indexed completion mode.
+func IsIndexedTask(ts *batch.TaskSpec) bool {
+	return ts.Template.Annotations[CompletionModeAnnotation] == IndexedCompletionMode
+}
+
+// GetTaskParallelism returns the max number of pods of the indexed task running at the same time.
+func GetTaskParallelism(ts *batch.TaskSpec) (int, error) {
+	value, found := ts.Template.Annotations[ParallelismAnnotation]
+	if !found {
+		return int(ts.Replicas), nil
+	}
+	parallelism, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || parallelism <= 0 {
+		return 0, fmt.Errorf("invalid parallelism %q, expected a positive integer", value)
+	}
+	if parallelism > int(ts.Replicas) {
+		return int(ts.Replicas), nil
+	}
+	return parallelism, nil
+}
+
+// FormatIndexes formats the indexes as comma separated intervals in ascending order, e.g. "0-3,5".
+func FormatIndexes(indexes sets.Set[int]) string {
+	sorted := sets.List(indexes)
+	var intervals []string
+	for i := 0; i < len(sorted); {
+		j := i
+		for j+1 < len(sorted) && sorted[j+1] == sorted[j]+1 {
+			j++
+		}
+		if i == j {
+			intervals = append(intervals, strconv.Itoa(sorted[i]))
+		} else {
+			intervals = append(intervals, fmt.Sprintf("%d-%d", sorted[i], sorted[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(intervals, ",")
+}
+
+// ParseIndexes parses the comma separated intervals formatted by FormatIndexes.
+func ParseIndexes(value string) (sets.Set[int], error) {
+	indexes := sets.New[int]()
+	for _, interval := range strings.Split(value, ",") {
+		interval = strings.TrimSpace(interval)
+		if interval == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(interval, "-")
+		start, err := strconv.Atoi(first)
+		if err != nil || start < 0 {
+			return nil, fmt.Errorf("invalid interval %q", interval)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(last); err != nil || end < start {
+				return nil, fmt.Errorf("invalid interval %q", interval)
+			}
+		}
+		for i := start; i <= end; i++ {
+			indexes.Insert(i)
+		}
+	}
+	return indexes, nil
+}
+
+// GetCompletedIndexes returns the completed indexes of the indexed tasks recorded in the
+// CompletedIndexesAnnotation of the job, keyed by the task name.
+func GetCompletedIndexes(annotations map[string]string) (map[string]sets.Set[int], error) {
+	completed := map[string]sets.Set[int]{}
+	value, found := annotations[CompletedIndexesAnnotation]
+	if !found || value == "" {
+		return completed, nil
+	}
+	intervals := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &intervals); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", CompletedIndexesAnnotation, err)
+	}
+	for taskName, interval := range intervals {
+		indexes, err := ParseIndexes(interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid completed indexes of task %s: %v", taskName, err)
+		}
+		completed[taskName] = indexes
+	}
+	return completed, nil
+}
+
+// FormatCompletedIndexes formats the completed indexes of the indexed tasks as the value of the
+// CompletedIndexesAnnotation, the tasks without completed indexes are omitted.
+func FormatCompletedIndexes(completed map[string]sets.Set[int]) (string, error) {
+	intervals := map[string]string{}
+	for taskName, indexes := range completed {
+		if indexes.Len() == 0 {
+			continue
+		}
+		intervals[taskName] = FormatIndexes(indexes)
+	}
+	if len(intervals) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(intervals)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+func TestParseIndexes(t *testing.T) {
+	testCases := []struct {
+		value     string
+		expected  []int
+		expectErr bool
+	}{
+		{value: "", expected: []int{}},
+		{value: "3", expected: []int{3}},
+		{value: "0-2, 5,7-8", expected: []int{0, 1, 2, 5, 7, 8}},
+		{value: "2-1", expectErr: true},
+		{value: "-1", expectErr: true},
+		{value: "a", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		indexes, err := ParseIndexes(tc.value)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("%q: expected error %v, got %v", tc.value, tc.expectErr, err)
+			continue
+		}
+		if !tc.expectErr && !indexes.Equal(sets.New(tc.expected...)) {
+			t.Errorf("%q: expected %v, got %v", tc.value, tc.expected, sets.List(indexes))
+		}
+	}
+}
+
+func TestFormatIndexes(t *testing.T) {
+	if value := FormatIndexes(sets.New(8, 0, 1, 2, 5, 7)); value != "0-2,5,7-8" {
+		t.Errorf("unexpected indexes %q", value)
+	}
+	if value := FormatIndexes(sets.New[int]()); value != "" {
+		t.Errorf("expected empty indexes, got %q", value)
+	}
+}
+
+func TestGetTaskParallelism(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expected  int
+		expectErr bool
+	}{
+		{name: "not set", expected: 4},
+		{name: "less than replicas", value: "2", expected: 2},
+		{name: "more than replicas", value: "8", expected: 4},
+		{name: "zero", value: "0", expectErr: true},
+		{name: "invalid", value: "all", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := &batch.TaskSpec{Replicas: 4, Template: v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{CompletionModeAnnotation: IndexedCompletionMode},
+			}}}
+			if tc.value != "" {
+				ts.Template.Annotations[ParallelismAnnotation] = tc.value
+			}
+			parallelism, err := GetTaskParallelism(ts)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if !tc.expectErr && parallelism != tc.expected {
+				t.Errorf("expected parallelism %d, got %d", tc.expected, parallelism)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	apishelpers "volcano.sh/apis/pkg/apis/helpers"
+)
+
+const (
+	// lastAppliedConfigAnnotation is not propagated, it is the configuration of the job but not of the objects.
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+	// internalAnnotationPrefix is the prefix of the annotations used by volcano internally, e.g. the suspend
+	// and elastic annotations, which are not propagated.
+	internalAnnotationPrefix = "volcano.sh/"
+)
+
+// PropagatedAnnotations returns the annotations of the job propagated to the objects created for the job.
+func PropagatedAnnotations(job *batch.Job) map[string]string {
+	annotations := make(map[string]string, len(job.Annotations))
+	for k, v := range job.Annotations {
+		if k == lastAppliedConfigAnnotation || strings.HasPrefix(k, internalAnnotationPrefix) {
+			continue
+		}
+		annotations[k] = v
+	}
+	return annotations
+}
+
+// ObjectMeta returns the metadata of the object created for the job, e.g. the services, configmaps and secrets of
+// the job plugins, so that the object is selected by the labels of the job, e.g. for cost attribution and network
+// policies. The object has the extra labels configured cluster-wide, the labels and annotations of the job which
+// override the extra labels, the labels of the job name and namespace, and the controller reference to the job.
+func ObjectMeta(job *batch.Job, name string, extraLabels map[string]string) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Namespace: job.Namespace,
+		Name:      name,
+		OwnerReferences: []metav1.OwnerReference{
+			*metav1.NewControllerRef(job, apishelpers.JobKind),
+		},
+	}
+	PropagateMetadata(job, &meta, extraLabels)
+	return meta
+}
+
+// PropagateMetadata merges the labels and annotations propagated from the job into the metadata of the object,
+// and returns whether the metadata is changed. The labels and annotations of the object not propagated are kept.
+func PropagateMetadata(job *batch.Job, meta *metav1.ObjectMeta, extraLabels map[string]string) bool {
+	labels := map[string]string{}
+	for k, v := range extraLabels {
+		labels[k] = v
+	}
+	for k, v := range job.Labels {
+		labels[k] = v
+	}
+	labels[batch.JobNameKey] = job.Name
+	labels[batch.JobNamespaceKey] = job.Namespace
+
+	changed := false
+	for k, v := range labels {
+		if value, found := meta.Labels[k]; !found || value != v {
+			if meta.Labels == nil {
+				meta.Labels = map[string]string{}
+			}
+			meta.Labels[k] = v
+			changed = true
+		}
+	}
+	for k, v := range PropagatedAnnotations(job) {
+		if value, found := meta.Annotations[k]; !found || value != v {
+			if meta.Annotations == nil {
+				meta.Annotations = map[string]string{}
+			}
+			meta.Annotations[k] = v
+			changed = true
+		}
+	}
+	return changed
+}
+
+// AuditOwnerReference checks the controller reference of the existing object created for the job, and returns
+// whether it is changed. The object without controller is adopted by the job, and the object left by the former job
+// of the same name is taken over, so that it is not garbage collected with the former job. The object controlled by
+// others is not touched.
+func AuditOwnerReference(job *batch.Job, kind string, meta *metav1.ObjectMeta) (bool, error) {
+	ref := metav1.GetControllerOf(meta)
+	if ref == nil {
+		klog.V(3).Infof("%s %s/%s without controller is adopted by Job <%s/%s>", kind, meta.Namespace, meta.Name, job.Namespace, job.Name)
+		meta.OwnerReferences = append(meta.OwnerReferences, *metav1.NewControllerRef(job, apishelpers.JobKind))
+		return true, nil
+	}
+	if ref.Kind != apishelpers.JobKind.Kind || ref.Name != job.Name {
+		return false, fmt.Errorf("%s %s/%s is controlled by %s %s but not Job %s", kind, meta.Namespace, meta.Name, ref.Kind, ref.Name, job.Name)
+	}
+	if ref.UID == job.UID {
+		return false, nil
+	}
+
+	klog.V(3).Infof("%s %s/%s of the former Job <%s/%s> is taken over", kind, meta.Namespace, meta.Name, job.Namespace, job.Name)
+	for i := range meta.OwnerReferences {
+		if meta.OwnerReferences[i].Controller != nil && *meta.OwnerReferences[i].Controller {
+			meta.OwnerReferences[i] = *metav1.NewControllerRef(job, apishelpers.JobKind)
+		}
+	}
+	return true, nil
+}
+
+// CreateOrUpdateConfigMap creates the configmap of the job if not present, or updates its data and the metadata
+// propagated from the job if necessary.
+func CreateOrUpdateConfigMap(job *batch.Job, kubeClients kubernetes.Interface, extraLabels map[string]string, data map[string]string, cmName string) error {
+	cmOld, err := kubeClients.CoreV1().ConfigMaps(job.Namespace).Get(context.TODO(), cmName, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.V(3).Infof("Failed to get ConfigMap for Job <%s/%s>: %v", job.Namespace, job.Name, err)
+			return err
+		}
+
+		cm := &v1.ConfigMap{
+			ObjectMeta: ObjectMeta(job, cmName, extraLabels),
+			Data:       data,
+		}
+		if _, err := kubeClients.CoreV1().ConfigMaps(job.Namespace).Create(context.TODO(), cm, metav1.CreateOptions{}); err != nil {
+			klog.V(3).Infof("Failed to create ConfigMap for Job <%s/%s>: %v", job.Namespace, job.Name, err)
+			return err
+		}
+		return nil
+	}
+
+	ownerChanged, err := AuditOwnerReference(job, "ConfigMap", &cmOld.ObjectMeta)
+	if err != nil {
+		return err
+	}
+	metaChanged := PropagateMetadata(job, &cmOld.ObjectMeta, extraLabels)
+	if !ownerChanged && !metaChanged && reflect.DeepEqual(cmOld.Data, data) {
+		return nil
+	}
+
+	cmOld.Data = data
+	if _, err := kubeClients.CoreV1().ConfigMaps(job.Namespace).Update(context.TODO(), cmOld, metav1.UpdateOptions{}); err != nil {
+		klog.V(3).Infof("Failed to update ConfigMap for Job <%s/%s>: %v", job.Namespace, job.Name, err)
+		return err
+	}
+	return nil
+}
+
+// CreateOrUpdateSecret creates the secret of the job if not present, or updates its data and the metadata
+// propagated from the job if necessary. The data is only updated if the ssh config changes, so that the keys
+// are kept.
+func CreateOrUpdateSecret(job *batch.Job, kubeClients kubernetes.Interface, extraLabels map[string]string, data map[string][]byte, secretName string) error {
+	secretOld, err := kubeClients.CoreV1().Secrets(job.Namespace).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.V(3).Infof("Failed to get Secret for Job <%s/%s>: %v", job.Namespace, job.Name, err)
+			return err
+		}
+
+		secret := &v1.Secret{
+			ObjectMeta: ObjectMeta(job, secretName, extraLabels),
+			Data:       data,
+		}
+		if _, err := kubeClients.CoreV1().Secrets(job.Namespace).Create(context.TODO(), secret, metav1.CreateOptions{}); err != nil {
+			klog.V(3).Infof("Failed to create Secret for Job <%s/%s>: %v", job.Namespace, job.Name, err)
+			return err
+		}
+		return nil
+	}
+
+	ownerChanged, err := AuditOwnerReference(job, "Secret", &secretOld.ObjectMeta)
+	if err != nil {
+		return err
+	}
+	metaChanged := PropagateMetadata(job, &secretOld.ObjectMeta, extraLabels)
+	const sshConfig = "config"
+	dataChanged := !reflect.DeepEqual(secretOld.Data[sshConfig], data[sshConfig])
+	if !ownerChanged && !metaChanged && !dataChanged {
+		return nil
+	}
+
+	if dataChanged {
+		secretOld.Data = data
+	}
+	if _, err := kubeClients.CoreV1().Secrets(job.Namespace).Update(context.TODO(), secretOld, metav1.UpdateOptions{}); err != nil {
+		klog.V(3).Infof("Failed to update Secret for Job <%s/%s>: %v", job.Namespace, job.Name, err)
+		return err
+	}
+	return nil
+}
+
+// CreateOrUpdateService creates the service of the job if not present, or updates the metadata propagated from
+// the job if necessary. The spec of the existing service is kept.
+func CreateOrUpdateService(job *batch.Job, kubeClients kubernetes.Interface, extraLabels map[string]string, svc *v1.Service) error {
+	svcOld, err := kubeClients.CoreV1().Services(job.Namespace).Get(context.TODO(), svc.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.V(3).Infof("Failed to get Service for Job <%s/%s>: %v", job.Namespace, job.Name, err)
+			return err
+		}
+
+		svc.ObjectMeta = ObjectMeta(job, svc.Name, extraLabels)
+		if _, err := kubeClients.CoreV1().Services(job.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{}); err != nil {
+			klog.V(3).Infof("Failed to create Service for Job <%s/%s>: %v", job.Namespace, job.Name, err)
+			return err
+		}
+		return nil
+	}
+
+	ownerChanged, err := AuditOwnerReference(job, "Service", &svcOld.ObjectMeta)
+	if err != nil {
+		return err
+	}
+	metaChanged := PropagateMetadata(job, &svcOld.ObjectMeta, extraLabels)
+	if !ownerChanged && !metaChanged {
+		return nil
+	}
+
+	if _, err := kubeClients.CoreV1().Services(job.Namespace).Update(context.TODO(), svcOld, metav1.UpdateOptions{}); err != nil {
+		klog.V(3).Infof("Failed to update Service for Job <%s/%s>: %v", job.Namespace, job.Name, err)
+		return err
+	}
+	return nil
+}
+
+// CreateOrUpdateNetworkPolicy creates the network policy of the job if not present, or updates the metadata
+// propagated from the job if necessary. The spec of the existing network policy is kept.
+func CreateOrUpdateNetworkPolicy(job *batch.Job, kubeClients kubernetes.Interface, extraLabels map[string]string, policy *networkingv1.NetworkPolicy) error {
+	policyOld, err := kubeClients.NetworkingV1().NetworkPolicies(job.Namespace).Get(context.TODO(), policy.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.V(3).Infof("Failed to get NetworkPolicy for Job <%s/%s>: %v", job.Namespace, job.Name, err)
+			return err
+		}
+
+		policy.ObjectMeta = ObjectMeta(job, policy.Name, extraLabels)
+		if _, err := kubeClients.NetworkingV1().NetworkPolicies(job.Namespace).Create(context.TODO(), policy, metav1.CreateOptions{}); err != nil {
+			klog.V(3).Infof("Failed to create NetworkPolicy for Job <%s/%s>: %v", job.Namespace, job.Name, err)
+			return err
+		}
+		return nil
+	}
+
+	ownerChanged, err := AuditOwnerReference(job, "NetworkPolicy", &policyOld.ObjectMeta)
+	if err != nil {
+		return err
+	}
+	metaChanged := PropagateMetadata(job, &policyOld.ObjectMeta, extraLabels)
+	if !ownerChanged && !metaChanged {
+		return nil
+	}
+
+	if _, err := kubeClients.NetworkingV1().NetworkPolicies(job.Namespace).Update(context.TODO(), policyOld, metav1.UpdateOptions{}); err != nil {
+		klog.V(3).Infof("Failed to update NetworkPolicy for Job <%s/%s>: %v", job.Namespace, job.Name, err)
+		return err
+	}
+	return nil
+}
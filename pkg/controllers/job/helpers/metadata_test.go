@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	apishelpers "volcano.sh/apis/pkg/apis/helpers"
+)
+
+func newMetadataJob() *batch.Job {
+	return &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "job1",
+			UID:       "uid1",
+			Labels:    map[string]string{"team": "ml"},
+			Annotations: map[string]string{
+				"cost-center":               "42",
+				lastAppliedConfigAnnotation: "{}",
+				"volcano.sh/job-suspend":    "false",
+			},
+		},
+	}
+}
+
+func TestPropagateMetadata(t *testing.T) {
+	extraLabels := map[string]string{"team": "default", "cluster": "c1"}
+	job := newMetadataJob()
+	meta := ObjectMeta(job, "job1-svc", extraLabels)
+	expectedLabels := map[string]string{
+		"team":                "ml",
+		"cluster":             "c1",
+		batch.JobNameKey:      "job1",
+		batch.JobNamespaceKey: "ns1",
+	}
+	if !reflect.DeepEqual(meta.Labels, expectedLabels) {
+		t.Errorf("expected labels %v, got %v", expectedLabels, meta.Labels)
+	}
+	if !reflect.DeepEqual(meta.Annotations, map[string]string{"cost-center": "42"}) {
+		t.Errorf("expected annotations without last applied configuration and internal ones, got %v", meta.Annotations)
+	}
+	if ref := metav1.GetControllerOf(&meta); ref == nil || ref.UID != job.UID {
+		t.Errorf("expected controller reference to job, got %v", meta.OwnerReferences)
+	}
+
+	if PropagateMetadata(job, &meta, extraLabels) {
+		t.Errorf("expected metadata unchanged")
+	}
+	meta.Labels["other"] = "kept"
+	job.Labels["team"] = "infra"
+	if !PropagateMetadata(job, &meta, extraLabels) {
+		t.Errorf("expected metadata changed")
+	}
+	if meta.Labels["team"] != "infra" || meta.Labels["other"] != "kept" {
+		t.Errorf("expected label of job updated and others kept, got %v", meta.Labels)
+	}
+}
+
+func TestAuditOwnerReference(t *testing.T) {
+	job := newMetadataJob()
+	formerJob := newMetadataJob()
+	formerJob.UID = "uid0"
+	otherJob := newMetadataJob()
+	otherJob.Name = "job2"
+
+	testCases := []struct {
+		name          string
+		owner         *batch.Job
+		expectChanged bool
+		expectErr     bool
+	}{
+		{name: "no controller", expectChanged: true},
+		{name: "controlled by job", owner: job},
+		{name: "controlled by former job", owner: formerJob, expectChanged: true},
+		{name: "controlled by other job", owner: otherJob, expectErr: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			meta := &metav1.ObjectMeta{Namespace: "ns1", Name: "job1-svc"}
+			if testCase.owner != nil {
+				meta.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(testCase.owner, apishelpers.JobKind)}
+			}
+			changed, err := AuditOwnerReference(job, "Service", meta)
+			if (err != nil) != testCase.expectErr {
+				t.Fatalf("expected error %v, got %v", testCase.expectErr, err)
+			}
+			if changed != testCase.expectChanged {
+				t.Errorf("expected changed %v, got %v", testCase.expectChanged, changed)
+			}
+			if !testCase.expectErr {
+				if ref := metav1.GetControllerOf(meta); ref == nil || ref.UID != job.UID || len(meta.OwnerReferences) != 1 {
+					t.Errorf("expected single controller reference to job, got %v", meta.OwnerReferences)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateOrUpdateConfigMap(t *testing.T) {
+	job := newMetadataJob()
+	kubeClient := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "job1-svc"},
+		Data:       map[string]string{"hosts": "old"},
+	})
+
+	if err := CreateOrUpdateConfigMap(job, kubeClient, nil, map[string]string{"hosts": "new"}, "job1-svc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cm, err := kubeClient.CoreV1().ConfigMaps("ns1").Get(context.TODO(), "job1-svc", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get configmap: %v", err)
+	}
+	if cm.Data["hosts"] != "new" || cm.Labels["team"] != "ml" || cm.Annotations["cost-center"] != "42" {
+		t.Errorf("expected data and metadata of configmap updated, got %v", cm)
+	}
+	if ref := metav1.GetControllerOf(cm); ref == nil || ref.UID != job.UID {
+		t.Errorf("expected configmap adopted by job, got %v", cm.OwnerReferences)
+	}
+}
+
+func TestCreateOrUpdateService(t *testing.T) {
+	job := newMetadataJob()
+	kubeClient := fake.NewSimpleClientset()
+	newService := func() *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "job1"},
+			Spec:       v1.ServiceSpec{ClusterIP: v1.ClusterIPNone},
+		}
+	}
+
+	if err := CreateOrUpdateService(job, kubeClient, nil, newService()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	job.Labels["team"] = "infra"
+	if err := CreateOrUpdateService(job, kubeClient, nil, newService()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svc, err := kubeClient.CoreV1().Services("ns1").Get(context.TODO(), "job1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get service: %v", err)
+	}
+	if svc.Labels["team"] != "infra" || svc.Spec.ClusterIP != v1.ClusterIPNone {
+		t.Errorf("expected labels of service updated and spec kept, got %v", svc)
+	}
+	if ref := metav1.GetControllerOf(svc); ref == nil || ref.UID != job.UID {
+		t.Errorf("expected service controlled by job, got %v", svc.OwnerReferences)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// TaskResultAnnotation is the annotation key of the pod holding its result in json, which takes precedence
+	// over the termination message of its containers.
+	TaskResultAnnotation = "volcano.sh/task-result"
+	// TaskResultsAnnotation is the annotation key of the job holding the results of its pods in json,
+	// keyed by the task name and then the pod name, e.g. {"train": {"job-train-0": {"accuracy": 0.9}}}.
+	TaskResultsAnnotation = "volcano.sh/task-results"
+
+	// MaxTaskResultSize is the max size of the result of a pod, which is the max size of a termination message.
+	MaxTaskResultSize = 4 * 1024
+	// MaxTaskResultsSize is the max size of the results of a job, so that the annotations of the job stay
+	// far below the limit of the apiserver.
+	MaxTaskResultsSize = 64 * 1024
+)
+
+// TaskResults is the results of the pods of a job, keyed by the task name and then the pod name.
+type TaskResults map[string]map[string]json.RawMessage
+
+// GetPodTaskResult returns the result of the pod, which is the TaskResultAnnotation of the pod, or the first
+// termination message of its containers in json after the pod finishes. Nil is returned if there is no result.
+func GetPodTaskResult(pod *v1.Pod) (json.RawMessage, error) {
+	if value, found := pod.Annotations[TaskResultAnnotation]; found {
+		return parseTaskResult(value)
+	}
+
+	if pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+		return nil, nil
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated == nil || strings.TrimSpace(status.State.Terminated.Message) == "" {
+			continue
+		}
+		// the termination message may be plain text written by the application, which is not a result
+		if result, err := parseTaskResult(status.State.Terminated.Message); err == nil {
+			return result, nil
+		}
+	}
+	return nil, nil
+}
+
+func parseTaskResult(value string) (json.RawMessage, error) {
+	if len(value) > MaxTaskResultSize {
+		return nil, fmt.Errorf("result of %d bytes exceeds the limit of %d bytes", len(value), MaxTaskResultSize)
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(value)); err != nil {
+		return nil, err
+	}
+	return compacted.Bytes(), nil
+}
+
+// Add adds the result of the pod of the task.
+func (r TaskResults) Add(taskName, podName string, result json.RawMessage) {
+	if _, found := r[taskName]; !found {
+		r[taskName] = map[string]json.RawMessage{}
+	}
+	r[taskName][podName] = result
+}
+
+// GetTaskResults returns the results of the pods aggregated in the TaskResultsAnnotation of the job.
+func GetTaskResults(annotations map[string]string) (TaskResults, error) {
+	value, found := annotations[TaskResultsAnnotation]
+	if !found || value == "" {
+		return nil, nil
+	}
+	results := TaskResults{}
+	if err := json.Unmarshal([]byte(value), &results); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", TaskResultsAnnotation, err)
+	}
+	return results, nil
+}
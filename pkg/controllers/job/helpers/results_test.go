@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func buildResultPod(phase v1.PodPhase, annotations map[string]string, messages ...string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "job-task-0", Annotations: annotations},
+		Status:     v1.PodStatus{Phase: phase},
+	}
+	for _, message := range messages {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{
+			State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Message: message}},
+		})
+	}
+	return pod
+}
+
+func TestGetPodTaskResult(t *testing.T) {
+	testCases := []struct {
+		name      string
+		pod       *v1.Pod
+		expected  string
+		expectErr bool
+	}{
+		{
+			name: "no result",
+			pod:  buildResultPod(v1.PodSucceeded, nil),
+		},
+		{
+			name:     "annotation",
+			pod:      buildResultPod(v1.PodRunning, map[string]string{TaskResultAnnotation: `{"accuracy": 0.9}`}),
+			expected: `{"accuracy":0.9}`,
+		},
+		{
+			name:     "annotation takes precedence over termination message",
+			pod:      buildResultPod(v1.PodSucceeded, map[string]string{TaskResultAnnotation: `"annotation"`}, `"message"`),
+			expected: `"annotation"`,
+		},
+		{
+			name:      "invalid annotation",
+			pod:       buildResultPod(v1.PodRunning, map[string]string{TaskResultAnnotation: "accuracy=0.9"}),
+			expectErr: true,
+		},
+		{
+			name:      "too large annotation",
+			pod:       buildResultPod(v1.PodRunning, map[string]string{TaskResultAnnotation: `"` + strings.Repeat("a", MaxTaskResultSize) + `"`}),
+			expectErr: true,
+		},
+		{
+			name:     "first json termination message",
+			pod:      buildResultPod(v1.PodSucceeded, nil, "", "done", `{"model": "s3://bucket/model"}`),
+			expected: `{"model":"s3://bucket/model"}`,
+		},
+		{
+			name: "termination message of running pod",
+			pod:  buildResultPod(v1.PodRunning, nil, `{"model": "s3://bucket/model"}`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := GetPodTaskResult(tc.pod)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if string(result) != tc.expected {
+				t.Errorf("expected result %q, got %q", tc.expected, string(result))
+			}
+		})
+	}
+}
+
+func TestGetTaskResults(t *testing.T) {
+	results, err := GetTaskResults(map[string]string{TaskResultsAnnotation: `{"train": {"job-train-0": {"loss": 0.1}}}`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := string(results["train"]["job-train-0"]); got != `{"loss": 0.1}` {
+		t.Errorf("unexpected result %q", got)
+	}
+
+	if results, err := GetTaskResults(nil); err != nil || results != nil {
+		t.Errorf("expected no results, got %v, %v", results, err)
+	}
+	if _, err := GetTaskResults(map[string]string{TaskResultsAnnotation: "{"}); err == nil {
+		t.Errorf("expected error of invalid annotation")
+	}
+}
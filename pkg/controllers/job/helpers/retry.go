@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// RetryPatchAnnotation is the annotation key of the task template holding a strategic merge patch
+	// in json or yaml, which is applied to the pod template when the pods are created on a retry.
+	RetryPatchAnnotation = "volcano.sh/retry-patch"
+	// RetryResourceScaleAnnotation is the annotation key of the task template holding the factors the
+	// container resources are scaled by on each retry, e.g. "memory=1.2,cpu=1.5".
+	RetryResourceScaleAnnotation = "volcano.sh/retry-resource-scale"
+	// TaskRetryCountsAnnotation is the annotation key of the job holding the restarts of each task by the
+	// RestartTask and RestartPod actions in json, e.g. {"worker":2}.
+	TaskRetryCountsAnnotation = "volcano.sh/task-retry-counts"
+	// MaxRetryResourceScale is the maximal factor the container resources are scaled by however many times
+	// the task is retried.
+	MaxRetryResourceScale = 16
+)
+
+// ParseRetryResourceScale parses the value of RetryResourceScaleAnnotation into the factor of each resource.
+func ParseRetryResourceScale(value string) (map[v1.ResourceName]float64, error) {
+	factors := map[v1.ResourceName]float64{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, factorStr, found := strings.Cut(item, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid resource scale %q, expected <resource>=<factor>", item)
+		}
+		factor, err := strconv.ParseFloat(strings.TrimSpace(factorStr), 64)
+		if err != nil || factor <= 0 || math.IsInf(factor, 0) {
+			return nil, fmt.Errorf("invalid factor of resource %s: %q, expected a positive number", name, factorStr)
+		}
+		factors[v1.ResourceName(strings.TrimSpace(name))] = factor
+	}
+	return factors, nil
+}
+
+// GetTaskRetryCounts returns the restarts of each task recorded in TaskRetryCountsAnnotation, an invalid
+// value is ignored.
+func GetTaskRetryCounts(annotations map[string]string) map[string]int32 {
+	counts := map[string]int32{}
+	if value, found := annotations[TaskRetryCountsAnnotation]; found {
+		if err := json.Unmarshal([]byte(value), &counts); err != nil {
+			return map[string]int32{}
+		}
+	}
+	return counts
+}
+
+// TaskRetryCount returns the retry count of the task, which is the retry count of the job without the
+// restarts of the other tasks, i.e. the restarts of the whole job plus the restarts of the task itself.
+func TaskRetryCount(jobRetryCount int32, taskRetryCounts map[string]int32, task string) int32 {
+	count := jobRetryCount
+	for name, restarts := range taskRetryCounts {
+		if name != task {
+			count -= restarts
+		}
+	}
+	return max(count, 0)
+}
+
+// ApplyRetryStrategy returns the pod template of the task for the given retry count of the task. The retry
+// patch of the template is applied once, and the resources are scaled by factor^retryCount up to
+// MaxRetryResourceScale, so the pods get more resources on each retry, e.g. after they are OOM killed.
+func ApplyRetryStrategy(template *v1.PodTemplateSpec, retryCount int32) (*v1.PodTemplateSpec, error) {
+	if retryCount <= 0 {
+		return template, nil
+	}
+
+	result := template
+	if patch, found := template.Annotations[RetryPatchAnnotation]; found && strings.TrimSpace(patch) != "" {
+		patched, err := applyRetryPatch(template, patch)
+		if err != nil {
+			return template, err
+		}
+		result = patched
+	}
+
+	if value, found := template.Annotations[RetryResourceScaleAnnotation]; found {
+		factors, err := ParseRetryResourceScale(value)
+		if err != nil {
+			return template, err
+		}
+		if result == template {
+			result = template.DeepCopy()
+		}
+		for name, factor := range factors {
+			// the scale is clamped before the resources are converted to integers, which would overflow
+			scale := math.Min(math.Pow(factor, float64(retryCount)), MaxRetryResourceScale)
+			scaleResource(result.Spec.InitContainers, name, scale)
+			scaleResource(result.Spec.Containers, name, scale)
+		}
+	}
+	return result, nil
+}
+
+func applyRetryPatch(template *v1.PodTemplateSpec, patch string) (*v1.PodTemplateSpec, error) {
+	patchJSON, err := yaml.YAMLToJSON([]byte(patch))
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry patch: %v", err)
+	}
+	original, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+	patchedJSON, err := strategicpatch.StrategicMergePatch(original, patchJSON, v1.PodTemplateSpec{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply retry patch: %v", err)
+	}
+	patched := &v1.PodTemplateSpec{}
+	if err := json.Unmarshal(patchedJSON, patched); err != nil {
+		return nil, fmt.Errorf("failed to apply retry patch: %v", err)
+	}
+	return patched, nil
+}
+
+func scaleResource(containers []v1.Container, name v1.ResourceName, factor float64) {
+	for i := range containers {
+		scaleResourceList(containers[i].Resources.Requests, name, factor)
+		scaleResourceList(containers[i].Resources.Limits, name, factor)
+	}
+}
+
+func scaleResourceList(list v1.ResourceList, name v1.ResourceName, factor float64) {
+	quantity, found := list[name]
+	if !found {
+		return
+	}
+	if name == v1.ResourceCPU {
+		list[name] = *resource.NewMilliQuantity(int64(math.Ceil(float64(quantity.MilliValue())*factor)), quantity.Format)
+		return
+	}
+	list[name] = *resource.NewQuantity(int64(math.Ceil(float64(quantity.Value())*factor)), quantity.Format)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func buildRetryTemplate(annotations map[string]string) *v1.PodTemplateSpec {
+	return &v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Annotations: annotations},
+		Spec: v1.PodSpec{
+			NodeSelector: map[string]string{"pool": "default"},
+			Containers: []v1.Container{{
+				Name: "trainer",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m"), v1.ResourceMemory: resource.MustParse("1Gi")},
+					Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			}},
+		},
+	}
+}
+
+func TestParseRetryResourceScale(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expected  map[v1.ResourceName]float64
+		expectErr bool
+	}{
+		{
+			name:     "multiple resources",
+			value:    "memory=1.2, cpu=2",
+			expected: map[v1.ResourceName]float64{v1.ResourceMemory: 1.2, v1.ResourceCPU: 2},
+		},
+		{
+			name:      "missing factor",
+			value:     "memory",
+			expectErr: true,
+		},
+		{
+			name:      "negative factor",
+			value:     "memory=-1",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			factors, err := ParseRetryResourceScale(tc.value)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+			if len(factors) != len(tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, factors)
+			}
+			for name, factor := range tc.expected {
+				if factors[name] != factor {
+					t.Errorf("expected factor %v of %s, got %v", factor, name, factors[name])
+				}
+			}
+		})
+	}
+}
+
+func TestApplyRetryStrategy(t *testing.T) {
+	testCases := []struct {
+		name                string
+		annotations         map[string]string
+		retryCount          int32
+		expectedMemory      string
+		expectedCPU         string
+		expectedMemoryLimit string
+		expectedPool        string
+		expectErr           bool
+	}{
+		{
+			name:                "first attempt is not changed",
+			annotations:         map[string]string{RetryResourceScaleAnnotation: "memory=2"},
+			expectedMemory:      "1Gi",
+			expectedCPU:         "500m",
+			expectedMemoryLimit: "1Gi",
+			expectedPool:        "default",
+		},
+		{
+			name:                "resources scaled on each retry",
+			annotations:         map[string]string{RetryResourceScaleAnnotation: "memory=2,cpu=1.5"},
+			retryCount:          2,
+			expectedMemory:      "4Gi",
+			expectedCPU:         "1125m",
+			expectedMemoryLimit: "4Gi",
+			expectedPool:        "default",
+		},
+		{
+			name:                "scale clamped on many retries",
+			annotations:         map[string]string{RetryResourceScaleAnnotation: "memory=2"},
+			retryCount:          10000,
+			expectedMemory:      "16Gi",
+			expectedCPU:         "500m",
+			expectedMemoryLimit: "16Gi",
+			expectedPool:        "default",
+		},
+		{
+			name: "patch applied on retry",
+			annotations: map[string]string{RetryPatchAnnotation: `
+spec:
+  nodeSelector:
+    pool: highmem`},
+			retryCount:          1,
+			expectedMemory:      "1Gi",
+			expectedCPU:         "500m",
+			expectedMemoryLimit: "1Gi",
+			expectedPool:        "highmem",
+		},
+		{
+			name: "patch and scale applied together",
+			annotations: map[string]string{
+				RetryPatchAnnotation:         `{"spec":{"containers":[{"name":"trainer","resources":{"limits":{"memory":"2Gi"}}}]}}`,
+				RetryResourceScaleAnnotation: "memory=2",
+			},
+			retryCount:          1,
+			expectedMemory:      "2Gi",
+			expectedCPU:         "500m",
+			expectedMemoryLimit: "4Gi",
+			expectedPool:        "default",
+		},
+		{
+			name:        "invalid patch",
+			annotations: map[string]string{RetryPatchAnnotation: "{"},
+			retryCount:  1,
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			template := buildRetryTemplate(tc.annotations)
+			result, err := ApplyRetryStrategy(template, tc.retryCount)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+
+			resources := result.Spec.Containers[0].Resources
+			if memory := resources.Requests[v1.ResourceMemory]; memory.String() != tc.expectedMemory {
+				t.Errorf("expected memory request %s, got %s", tc.expectedMemory, memory.String())
+			}
+			if cpu := resources.Requests[v1.ResourceCPU]; cpu.String() != tc.expectedCPU {
+				t.Errorf("expected cpu request %s, got %s", tc.expectedCPU, cpu.String())
+			}
+			if limit := resources.Limits[v1.ResourceMemory]; limit.String() != tc.expectedMemoryLimit {
+				t.Errorf("expected memory limit %s, got %s", tc.expectedMemoryLimit, limit.String())
+			}
+			if result.Spec.NodeSelector["pool"] != tc.expectedPool {
+				t.Errorf("expected pool %s, got %s", tc.expectedPool, result.Spec.NodeSelector["pool"])
+			}
+			if result.Name != "worker" {
+				t.Errorf("expected template name kept, got %s", result.Name)
+			}
+			if original := template.Spec.Containers[0].Resources.Requests[v1.ResourceMemory]; original.String() != "1Gi" {
+				t.Errorf("expected original template not modified, got %s", original.String())
+			}
+		})
+	}
+}
+
+func TestTaskRetryCount(t *testing.T) {
+	counts := GetTaskRetryCounts(map[string]string{TaskRetryCountsAnnotation: `{"worker":2,"ps":1}`})
+	if len(counts) != 2 || counts["worker"] != 2 || counts["ps"] != 1 {
+		t.Fatalf("unexpected task retry counts %v", counts)
+	}
+	if invalid := GetTaskRetryCounts(map[string]string{TaskRetryCountsAnnotation: "{"}); len(invalid) != 0 {
+		t.Errorf("expected invalid task retry counts ignored, got %v", invalid)
+	}
+
+	// the job is retried 4 times: 1 by restarting the job, 2 by restarting worker and 1 by restarting ps
+	if count := TaskRetryCount(4, counts, "worker"); count != 3 {
+		t.Errorf("expected worker retried 3 times, got %d", count)
+	}
+	if count := TaskRetryCount(4, counts, "ps"); count != 2 {
+		t.Errorf("expected ps retried 2 times, got %d", count)
+	}
+	if count := TaskRetryCount(4, counts, "chief"); count != 1 {
+		t.Errorf("expected chief retried once, got %d", count)
+	}
+	if count := TaskRetryCount(0, counts, "chief"); count != 0 {
+		t.Errorf("expected retry count not negative, got %d", count)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+const (
+	// TaskAffinityAnnotation is the annotation key of the job declaring the affinity between its tasks in json, e.g.
+	// [{"task": "ps", "antiAffinity": ["worker"]}, {"task": "worker", "affinity": ["worker"], "preferred": true}].
+	TaskAffinityAnnotation = "volcano.sh/task-affinity"
+
+	// defaultTaskAffinityTopologyKey places the pods together or apart by node.
+	defaultTaskAffinityTopologyKey = v1.LabelHostname
+	// preferredTaskAffinityWeight is the weight of the preferred pod (anti)affinity terms expanded.
+	preferredTaskAffinityWeight = 100
+)
+
+// TaskAffinity declares the tasks of the same job the pods of a task are placed together with or apart from.
+type TaskAffinity struct {
+	// Task is the task the rule applies to.
+	Task string `json:"task"`
+	// Affinity is the tasks the pods of the task are placed in the same topology domain with.
+	Affinity []string `json:"affinity,omitempty"`
+	// AntiAffinity is the tasks the pods of the task do not share a topology domain with.
+	AntiAffinity []string `json:"antiAffinity,omitempty"`
+	// TopologyKey is the node label of the topology domain, kubernetes.io/hostname by default.
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// Preferred makes the rule a preference of the scheduler instead of a requirement.
+	Preferred bool `json:"preferred,omitempty"`
+}
+
+// GetTaskAffinities parses the task affinities declared by the job.
+func GetTaskAffinities(job *batch.Job) ([]TaskAffinity, error) {
+	value, found := job.Annotations[TaskAffinityAnnotation]
+	if !found || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var affinities []TaskAffinity
+	if err := json.Unmarshal([]byte(value), &affinities); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", TaskAffinityAnnotation, err)
+	}
+	return affinities, nil
+}
+
+// ValidateTaskAffinities checks the task affinities declared by the job only refer to its tasks.
+func ValidateTaskAffinities(job *batch.Job) error {
+	affinities, err := GetTaskAffinities(job)
+	if err != nil {
+		return err
+	}
+	for i, affinity := range affinities {
+		if len(affinity.Affinity) == 0 && len(affinity.AntiAffinity) == 0 {
+			return fmt.Errorf("task affinity %d of task %q has neither affinity nor antiAffinity", i, affinity.Task)
+		}
+		tasks := []string{affinity.Task}
+		tasks = append(tasks, affinity.Affinity...)
+		tasks = append(tasks, affinity.AntiAffinity...)
+		for _, task := range tasks {
+			if GetTaskIndexUnderJob(task, job) == -1 {
+				return fmt.Errorf("task affinity %d refers to task %q not found in the job", i, task)
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyTaskAffinity expands the task affinities of the task into the pod (anti)affinity of its pod. The terms
+// select the pods of the tasks by the job name and task labels set on the pods of the job, so they never match
+// the pods of other jobs, and they are added to the (anti)affinity already in the pod template.
+func ApplyTaskAffinity(pod *v1.Pod, job *batch.Job, taskName string, affinities []TaskAffinity) {
+	for _, affinity := range affinities {
+		if affinity.Task != taskName {
+			continue
+		}
+		if pod.Spec.Affinity == nil {
+			pod.Spec.Affinity = &v1.Affinity{}
+		}
+		if len(affinity.Affinity) != 0 {
+			if pod.Spec.Affinity.PodAffinity == nil {
+				pod.Spec.Affinity.PodAffinity = &v1.PodAffinity{}
+			}
+			term := taskAffinityTerm(job, affinity.Affinity, affinity.TopologyKey)
+			podAffinity := pod.Spec.Affinity.PodAffinity
+			if affinity.Preferred {
+				podAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(podAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+					v1.WeightedPodAffinityTerm{Weight: preferredTaskAffinityWeight, PodAffinityTerm: term})
+			} else {
+				podAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(podAffinity.RequiredDuringSchedulingIgnoredDuringExecution, term)
+			}
+		}
+		if len(affinity.AntiAffinity) != 0 {
+			if pod.Spec.Affinity.PodAntiAffinity == nil {
+				pod.Spec.Affinity.PodAntiAffinity = &v1.PodAntiAffinity{}
+			}
+			term := taskAffinityTerm(job, affinity.AntiAffinity, affinity.TopologyKey)
+			podAntiAffinity := pod.Spec.Affinity.PodAntiAffinity
+			if affinity.Preferred {
+				podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+					v1.WeightedPodAffinityTerm{Weight: preferredTaskAffinityWeight, PodAffinityTerm: term})
+			} else {
+				podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, term)
+			}
+		}
+	}
+}
+
+// taskAffinityTerm returns the term selecting the pods of the tasks of the job in the topology domain.
+func taskAffinityTerm(job *batch.Job, tasks []string, topologyKey string) v1.PodAffinityTerm {
+	if topologyKey == "" {
+		topologyKey = defaultTaskAffinityTopologyKey
+	}
+	return v1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				batch.JobNameKey:      job.Name,
+				batch.JobNamespaceKey: job.Namespace,
+			},
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      batch.TaskSpecKey,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   tasks,
+			}},
+		},
+		TopologyKey: topologyKey,
+	}
+}
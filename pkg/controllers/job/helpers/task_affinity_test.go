@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+func buildAffinityJob(annotation string) *batch.Job {
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf", Namespace: "ns1"},
+		Spec: batch.JobSpec{Tasks: []batch.TaskSpec{
+			{Name: "ps", Replicas: 2},
+			{Name: "worker", Replicas: 4},
+		}},
+	}
+	if annotation != "" {
+		job.Annotations = map[string]string{TaskAffinityAnnotation: annotation}
+	}
+	return job
+}
+
+func TestValidateTaskAffinities(t *testing.T) {
+	testCases := []struct {
+		name       string
+		annotation string
+		expectErr  bool
+	}{
+		{
+			name: "no task affinity",
+		},
+		{
+			name:       "valid task affinity",
+			annotation: `[{"task": "ps", "antiAffinity": ["worker"]}, {"task": "worker", "affinity": ["worker"], "preferred": true}]`,
+		},
+		{
+			name:       "invalid json",
+			annotation: `{"task": "ps"}`,
+			expectErr:  true,
+		},
+		{
+			name:       "unknown task",
+			annotation: `[{"task": "ps", "antiAffinity": ["chief"]}]`,
+			expectErr:  true,
+		},
+		{
+			name:       "no affinity",
+			annotation: `[{"task": "ps"}]`,
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateTaskAffinities(buildAffinityJob(tc.annotation)); (err != nil) != tc.expectErr {
+				t.Errorf("expected error %v, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestApplyTaskAffinity(t *testing.T) {
+	job := buildAffinityJob(`[{"task": "ps", "antiAffinity": ["worker"]},
+		{"task": "worker", "affinity": ["worker"], "topologyKey": "topology.kubernetes.io/zone", "preferred": true}]`)
+	affinities, err := GetTaskAffinities(job)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	selector := func(tasks ...string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{
+			MatchLabels: map[string]string{batch.JobNameKey: "tf", batch.JobNamespaceKey: "ns1"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key: batch.TaskSpecKey, Operator: metav1.LabelSelectorOpIn, Values: tasks,
+			}},
+		}
+	}
+
+	// the anti-affinity of ps is added to the affinity in the template
+	nodeAffinity := &v1.NodeAffinity{}
+	psPod := &v1.Pod{Spec: v1.PodSpec{Affinity: &v1.Affinity{NodeAffinity: nodeAffinity}}}
+	ApplyTaskAffinity(psPod, job, "ps", affinities)
+	expectedPS := &v1.Affinity{
+		NodeAffinity: nodeAffinity,
+		PodAntiAffinity: &v1.PodAntiAffinity{RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{{
+			LabelSelector: selector("worker"),
+			TopologyKey:   v1.LabelHostname,
+		}}},
+	}
+	if !reflect.DeepEqual(psPod.Spec.Affinity, expectedPS) {
+		t.Errorf("expected affinity of ps %+v, got %+v", expectedPS, psPod.Spec.Affinity)
+	}
+
+	workerPod := &v1.Pod{}
+	ApplyTaskAffinity(workerPod, job, "worker", affinities)
+	expectedWorker := &v1.Affinity{
+		PodAffinity: &v1.PodAffinity{PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{{
+			Weight: preferredTaskAffinityWeight,
+			PodAffinityTerm: v1.PodAffinityTerm{
+				LabelSelector: selector("worker"),
+				TopologyKey:   "topology.kubernetes.io/zone",
+			},
+		}}},
+	}
+	if !reflect.DeepEqual(workerPod.Spec.Affinity, expectedWorker) {
+		t.Errorf("expected affinity of worker %+v, got %+v", expectedWorker, workerPod.Spec.Affinity)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// TemplateRefAnnotation is the annotation of the task template referring to a PodTemplate in the
+	// namespace of the job, which is resolved and inlined into the task template on job creation.
+	// The value is the name of the PodTemplate, optionally pinned to its hash, e.g. "trainer@sha256:<hex>".
+	TemplateRefAnnotation = "volcano.sh/template-ref"
+	// TemplateHashAnnotation is the annotation of the task template recording the hash of the
+	// PodTemplate inlined.
+	TemplateHashAnnotation = "volcano.sh/template-hash"
+
+	templateHashPrefix = "sha256:"
+)
+
+// ParseTemplateRef returns the name and the pinned hash of the template ref, the hash is empty if not pinned.
+func ParseTemplateRef(ref string) (name, hash string, err error) {
+	name, hash, pinned := strings.Cut(strings.TrimSpace(ref), "@")
+	if name == "" {
+		return "", "", fmt.Errorf("invalid template ref %q: empty name", ref)
+	}
+	if pinned && (!strings.HasPrefix(hash, templateHashPrefix) || len(hash) == len(templateHashPrefix)) {
+		return "", "", fmt.Errorf("invalid template ref %q: hash must be in the form of %s<hex>", ref, templateHashPrefix)
+	}
+	return name, hash, nil
+}
+
+// PodTemplateHash returns the hash of the template of PodTemplate.
+func PodTemplateHash(template *v1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return templateHashPrefix + hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// VolumeClaimTemplatesAnnotation is the annotation key of the task template declaring the templates of the PVCs
+// created for each replica of the task in json, like the volumeClaimTemplates of StatefulSet, e.g.
+// [{"metadata": {"name": "data"}, "spec": {"accessModes": ["ReadWriteOnce"], "resources": {"requests": {"storage": "10Gi"}}}}].
+const VolumeClaimTemplatesAnnotation = "volcano.sh/volume-claim-templates"
+
+// GetVolumeClaimTemplates returns the PVC templates of the task, nil if it declares none.
+func GetVolumeClaimTemplates(template *v1.PodTemplateSpec) ([]v1.PersistentVolumeClaim, error) {
+	value, found := template.Annotations[VolumeClaimTemplatesAnnotation]
+	if !found {
+		return nil, nil
+	}
+	var claims []v1.PersistentVolumeClaim
+	if err := json.Unmarshal([]byte(value), &claims); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", VolumeClaimTemplatesAnnotation, err)
+	}
+	names := map[string]bool{}
+	for _, claim := range claims {
+		if errs := validation.IsDNS1123Label(claim.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid name %q of volume claim template: %s", claim.Name, strings.Join(errs, ", "))
+		}
+		if names[claim.Name] {
+			return nil, fmt.Errorf("duplicated volume claim template %s", claim.Name)
+		}
+		names[claim.Name] = true
+		if len(claim.Spec.AccessModes) == 0 {
+			return nil, fmt.Errorf("accessModes of volume claim template %s must be set", claim.Name)
+		}
+	}
+	return claims, nil
+}
+
+// VolumeClaimName returns the name of the PVC of the template for the pod. The pod names of a job are stable, so
+// a replica gets back its PVC when it is recreated.
+func VolumeClaimName(claimTemplate, podName string) string {
+	return claimTemplate + "-" + podName
+}
+
+// ApplyVolumeClaimTemplates sets the volumes of the pod named after the PVC templates to its own PVCs, so that the
+// containers mount them by the names of the templates. The volume of the pod template with the same name, e.g. an
+// emptyDir placeholder, is replaced.
+func ApplyVolumeClaimTemplates(pod *v1.Pod, claims []v1.PersistentVolumeClaim) {
+	for _, claim := range claims {
+		volume := v1.Volume{
+			Name: claim.Name,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: VolumeClaimName(claim.Name, pod.Name),
+				},
+			},
+		}
+		replaced := false
+		for i := range pod.Spec.Volumes {
+			if pod.Spec.Volumes[i].Name == claim.Name {
+				pod.Spec.Volumes[i] = volume
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+		}
+	}
+}
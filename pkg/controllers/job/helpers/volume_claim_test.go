@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetVolumeClaimTemplates(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expectNames []string
+		expectErr   bool
+	}{
+		{
+			name: "no volume claim templates",
+		},
+		{
+			name: "valid volume claim templates",
+			annotations: map[string]string{VolumeClaimTemplatesAnnotation: `[
+				{"metadata": {"name": "data"}, "spec": {"accessModes": ["ReadWriteOnce"]}},
+				{"metadata": {"name": "cache"}, "spec": {"accessModes": ["ReadWriteOnce"]}}]`},
+			expectNames: []string{"data", "cache"},
+		},
+		{
+			name:        "invalid json",
+			annotations: map[string]string{VolumeClaimTemplatesAnnotation: `{"metadata": {"name": "data"}}`},
+			expectErr:   true,
+		},
+		{
+			name:        "invalid name",
+			annotations: map[string]string{VolumeClaimTemplatesAnnotation: `[{"metadata": {"name": "Data"}, "spec": {"accessModes": ["ReadWriteOnce"]}}]`},
+			expectErr:   true,
+		},
+		{
+			name: "duplicated names",
+			annotations: map[string]string{VolumeClaimTemplatesAnnotation: `[
+				{"metadata": {"name": "data"}, "spec": {"accessModes": ["ReadWriteOnce"]}},
+				{"metadata": {"name": "data"}, "spec": {"accessModes": ["ReadWriteOnce"]}}]`},
+			expectErr: true,
+		},
+		{
+			name:        "no access modes",
+			annotations: map[string]string{VolumeClaimTemplatesAnnotation: `[{"metadata": {"name": "data"}}]`},
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		template := &v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+		claims, err := GetVolumeClaimTemplates(template)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("%s: expected error %v, got %v", tc.name, tc.expectErr, err)
+			continue
+		}
+		if len(claims) != len(tc.expectNames) {
+			t.Errorf("%s: expected %d templates, got %d", tc.name, len(tc.expectNames), len(claims))
+			continue
+		}
+		for i, claim := range claims {
+			if claim.Name != tc.expectNames[i] {
+				t.Errorf("%s: expected template %s, got %s", tc.name, tc.expectNames[i], claim.Name)
+			}
+		}
+	}
+}
+
+func TestApplyVolumeClaimTemplates(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1-worker-0"},
+		Spec: v1.PodSpec{Volumes: []v1.Volume{
+			{Name: "data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+			{Name: "config", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+		}},
+	}
+	claims := []v1.PersistentVolumeClaim{
+		{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cache"}},
+	}
+
+	ApplyVolumeClaimTemplates(pod, claims)
+
+	expected := map[string]string{"data": "data-job1-worker-0", "cache": "cache-job1-worker-0", "config": ""}
+	if len(pod.Spec.Volumes) != len(expected) {
+		t.Fatalf("expected %d volumes, got %v", len(expected), pod.Spec.Volumes)
+	}
+	for _, volume := range pod.Spec.Volumes {
+		claimName := ""
+		if volume.PersistentVolumeClaim != nil {
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		}
+		if claimName != expected[volume.Name] {
+			t.Errorf("expected volume %s to mount PVC %q, got %q", volume.Name, expected[volume.Name], claimName)
+		}
+	}
+}
@@ -29,10 +29,12 @@ import (
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
+	nodeinformers "k8s.io/client-go/informers/node/v1"
 	kubeschedulinginformers "k8s.io/client-go/informers/scheduling/v1"
 	"k8s.io/client-go/kubernetes"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	nodelisters "k8s.io/client-go/listers/node/v1"
 	kubeschedulinglisters "k8s.io/client-go/listers/scheduling/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -100,6 +102,7 @@ type jobcontroller struct {
 	svcInformer   coreinformers.ServiceInformer
 	cmdInformer   businformer.CommandInformer
 	pcInformer    kubeschedulinginformers.PriorityClassInformer
+	rcInformer    nodeinformers.RuntimeClassInformer
 	queueInformer schedulinginformers.QueueInformer
 
 	informerFactory   informers.SharedInformerFactory
@@ -130,6 +133,10 @@ type jobcontroller struct {
 	pcLister kubeschedulinglisters.PriorityClassLister
 	pcSynced func() bool
 
+	// A store of runtime classes, the overhead of which is counted in the min resources of podgroups
+	rcLister nodelisters.RuntimeClassLister
+	rcSynced func() bool
+
 	queueLister schedulinglisters.QueueLister
 	queueSynced func() bool
 
@@ -143,6 +150,11 @@ type jobcontroller struct {
 	errTasks      workqueue.TypedRateLimitingInterface[any]
 	workers       uint32
 	maxRequeueNum int
+	// statusSyncPeriod is the window in which the pod events of a job are coalesced into a single sync,
+	// no coalescing if it is zero
+	statusSyncPeriod time.Duration
+	// jobResourceLabels are the labels added to all the objects created by the job plugins
+	jobResourceLabels map[string]string
 
 	delayActionMapLock sync.RWMutex
 	// delayActionMap stores delayed actions for jobs, where outer map key is job key (namespace/name),
@@ -178,6 +190,8 @@ func (cc *jobcontroller) Initialize(opt *framework.ControllerOption) error {
 	if cc.maxRequeueNum < 0 {
 		cc.maxRequeueNum = -1
 	}
+	cc.statusSyncPeriod = opt.JobStatusSyncPeriod
+	cc.jobResourceLabels = opt.JobResourceLabels
 
 	var i uint32
 	for i = 0; i < workers; i++ {
@@ -255,6 +269,10 @@ func (cc *jobcontroller) Initialize(opt *framework.ControllerOption) error {
 		cc.pcSynced = cc.pcInformer.Informer().HasSynced
 	}
 
+	cc.rcInformer = sharedInformers.Node().V1().RuntimeClasses()
+	cc.rcLister = cc.rcInformer.Lister()
+	cc.rcSynced = cc.rcInformer.Informer().HasSynced
+
 	cc.queueInformer = factory.Scheduling().V1beta1().Queues()
 	cc.queueLister = cc.queueInformer.Lister()
 	cc.queueSynced = cc.queueInformer.Informer().HasSynced
@@ -370,6 +388,16 @@ func (cc *jobcontroller) processNextReq(count uint32) bool {
 	}
 
 	delayAct := applyPolicies(jobInfo.Job, &req)
+	if delayAct.action == busv1alpha1.SyncJobAction && shouldSuspend(jobInfo.Job) {
+		delayAct.action = busv1alpha1.AbortJobAction
+	}
+	if delayAct.action == busv1alpha1.SyncJobAction && cc.exceedsActiveDeadline(queue, jobInfo.Job) {
+		delayAct.action = busv1alpha1.TerminateJobAction
+	}
+
+	if cc.coalescePodEvent(queue, req, delayAct) {
+		return true
+	}
 
 	if delayAct.delay != 0 {
 		klog.V(3).Infof("Execute <%v> on Job <%s/%s> after %s",
@@ -406,6 +434,27 @@ func (cc *jobcontroller) processNextReq(count uint32) bool {
 	return true
 }
 
+// coalescePodEvent defers the sync of the job caused by a pod event by the status sync period. The deferred
+// requests of a job are the same, so the workqueue merges the pod events of the job in the period into a single
+// sync and status update, instead of one per pod of a large job. The pod events matching a policy are not deferred.
+func (cc *jobcontroller) coalescePodEvent(queue workqueue.TypedRateLimitingInterface[any], req apis.Request, delayAct *delayAction) bool {
+	if cc.statusSyncPeriod <= 0 || len(req.PodName) == 0 ||
+		delayAct.action != busv1alpha1.SyncJobAction || delayAct.delay != 0 {
+		return false
+	}
+
+	queue.Forget(req)
+	queue.AddAfter(apis.Request{
+		Namespace: req.Namespace,
+		JobName:   req.JobName,
+		JobUid:    req.JobUid,
+		Event:     busv1alpha1.OutOfSyncEvent,
+	}, cc.statusSyncPeriod)
+	klog.V(4).Infof("Defer the sync of Job <%s/%s> on event <%s> of Pod <%s> by %s",
+		req.Namespace, req.JobName, req.Event, req.PodName, cc.statusSyncPeriod)
+	return true
+}
+
 // CleanPodDelayActionsIfNeed is used to clean delayed actions for Pod events when the pod phase changed:
 // if the event is not PodPending event:
 //   - cancel corresponding Pod Pending delayed action
@@ -18,6 +18,7 @@ package job
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
@@ -28,6 +29,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
@@ -37,6 +40,7 @@ import (
 	"volcano.sh/volcano/pkg/controllers/apis"
 	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/controllers/job/state"
+	"volcano.sh/volcano/pkg/scheduler/api"
 )
 
 var calMutex sync.Mutex
@@ -61,23 +65,60 @@ func (cc *jobcontroller) getPodGroupByJob(job *batch.Job) (*scheduling.PodGroup,
 }
 
 func (cc *jobcontroller) generateRelatedPodGroupName(job *batch.Job) string {
-	return fmt.Sprintf("%s-%s", job.Name, string(job.UID))
+	return jobhelpers.MakePodGroupName(job)
+}
+
+// jobLogger returns the logger of the job, the messages logged by it carry the job, podgroup and queue fields as
+// the logger of the scheduler does, so that a job can be followed through the logs of both components.
+func (cc *jobcontroller) jobLogger(job *batch.Job) klog.Logger {
+	return klog.LoggerWithValues(klog.Background(),
+		"job", klog.KObj(job),
+		"podgroup", klog.KRef(job.Namespace, cc.generateRelatedPodGroupName(job)),
+		"queue", job.Spec.Queue)
 }
 
 func (cc *jobcontroller) killTarget(jobInfo *apis.JobInfo, target state.Target, updateStatus state.UpdateStatusFn) error {
+	logger := cc.jobLogger(jobInfo.Job)
 	if target.Type == state.TargetTypeTask {
-		klog.V(3).Infof("Killing task <%s> of Job <%s/%s>, current version %d", target.TaskName, jobInfo.Namespace, jobInfo.Name, jobInfo.Job.Status.Version)
-		defer klog.V(3).Infof("Finished task <%s> of Job <%s/%s> killing, current version %d", target.TaskName, jobInfo.Namespace, jobInfo.Name, jobInfo.Job.Status.Version)
+		logger.V(3).Info("Killing task of job", "task", target.TaskName, "version", jobInfo.Job.Status.Version)
+		defer logger.V(3).Info("Finished task of job killing", "task", target.TaskName, "version", jobInfo.Job.Status.Version)
 	} else if target.Type == state.TargetTypePod {
-		klog.V(3).Infof("Killing pod <%s> of Job <%s/%s>, current version %d", target.PodName, jobInfo.Namespace, jobInfo.Name, jobInfo.Job.Status.Version)
-		defer klog.V(3).Infof("Finished pod <%s> of Job <%s/%s> killing, current version %d", target.PodName, jobInfo.Namespace, jobInfo.Name, jobInfo.Job.Status.Version)
+		logger.V(3).Info("Killing pod of job", "pod", klog.KRef(jobInfo.Namespace, target.PodName), "version", jobInfo.Job.Status.Version)
+		defer logger.V(3).Info("Finished pod of job killing", "pod", klog.KRef(jobInfo.Namespace, target.PodName), "version", jobInfo.Job.Status.Version)
 	}
 	return cc.killPods(jobInfo, nil, &target, updateStatus)
 }
 
+// recordTaskRestart increases the restarts of the task in TaskRetryCountsAnnotation of the job, so that the retry
+// strategy of the other tasks is not applied for the restart.
+func (cc *jobcontroller) recordTaskRestart(job *batch.Job, taskName string) (*batch.Job, error) {
+	counts := jobhelpers.GetTaskRetryCounts(job.Annotations)
+	counts[taskName]++
+	data, err := json.Marshal(counts)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{jobhelpers.TaskRetryCountsAnnotation: string(data)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cc.vcClient.BatchV1alpha1().Jobs(job.Namespace).Patch(context.TODO(),
+		job.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+}
+
 func (cc *jobcontroller) killJob(jobInfo *apis.JobInfo, podRetainPhase state.PhaseMap, updateStatus state.UpdateStatusFn) error {
-	klog.V(3).Infof("Killing Job <%s/%s>, current version %d", jobInfo.Namespace, jobInfo.Name, jobInfo.Job.Status.Version)
-	defer klog.V(3).Infof("Finished Job <%s/%s> killing, current version %d", jobInfo.Namespace, jobInfo.Name, jobInfo.Job.Status.Version)
+	logger := cc.jobLogger(jobInfo.Job)
+	logger.V(3).Info("Killing job", "version", jobInfo.Job.Status.Version)
+	defer logger.V(3).Info("Finished job killing", "version", jobInfo.Job.Status.Version)
+
+	if err := cc.syncPodGroupSuspend(jobInfo.Job); err != nil {
+		logger.Error(err, "Failed to sync suspend of PodGroup for job")
+		return err
+	}
 
 	return cc.killPods(jobInfo, podRetainPhase, nil, updateStatus)
 }
@@ -199,7 +240,7 @@ func (cc *jobcontroller) killPods(jobInfo *apis.JobInfo, podRetainPhase state.Ph
 	}
 
 	// Update Job status
-	newJob, err := cc.vcClient.BatchV1alpha1().Jobs(job.Namespace).UpdateStatus(context.TODO(), job, metav1.UpdateOptions{})
+	newJob, err := cc.applyJobStatus(job)
 	if apierrors.IsNotFound(err) {
 		klog.Errorf("Job %v/%v was not found", job.Namespace, job.Name)
 		return nil
@@ -209,6 +250,14 @@ func (cc *jobcontroller) killPods(jobInfo *apis.JobInfo, podRetainPhase state.Ph
 			job.Namespace, job.Name, err)
 		return err
 	}
+	// the restart of a task is recorded after the status, whose apply is rejected once the job is patched
+	if target != nil && job.Status.RetryCount > jobInfo.Job.Status.RetryCount {
+		if patchedJob, err := cc.recordTaskRestart(newJob, target.TaskName); err != nil {
+			klog.Errorf("Failed to record restart of task %s in Job %s/%s: %v", target.TaskName, job.Namespace, job.Name, err)
+		} else {
+			newJob = patchedJob
+		}
+	}
 	if e := cc.cache.Update(newJob); e != nil {
 		klog.Errorf("KillJob - Failed to update Job %v/%v in cache:  %v",
 			newJob.Namespace, newJob.Name, e)
@@ -295,12 +344,12 @@ func (cc *jobcontroller) GetQueueInfo(queue string) (*scheduling.Queue, error) {
 
 func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.UpdateStatusFn) error {
 	job := jobInfo.Job
-	klog.V(3).Infof("Starting to sync up Job <%s/%s>, current version %d", job.Namespace, job.Name, job.Status.Version)
-	defer klog.V(3).Infof("Finished Job <%s/%s> sync up, current version %d", job.Namespace, job.Name, job.Status.Version)
+	logger := cc.jobLogger(job)
+	logger.V(3).Info("Starting to sync up job", "version", job.Status.Version)
+	defer logger.V(3).Info("Finished job sync up", "version", job.Status.Version)
 
 	if jobInfo.Job.DeletionTimestamp != nil {
-		klog.Infof("Job <%s/%s> is terminating, skip management process.",
-			jobInfo.Job.Namespace, jobInfo.Job.Name)
+		logger.Info("Job is terminating, skip management process")
 		return nil
 	}
 
@@ -376,7 +425,7 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 		job.Status.State.LastTransitionTime = metav1.Now()
 		jobCondition = newCondition(job.Status.State.Phase, &job.Status.State.LastTransitionTime)
 		job.Status.Conditions = append(job.Status.Conditions, jobCondition)
-		newJob, err := cc.vcClient.BatchV1alpha1().Jobs(job.Namespace).UpdateStatus(context.TODO(), job, metav1.UpdateOptions{})
+		newJob, err := cc.applyJobStatus(job)
 		if err != nil {
 			klog.Errorf("Failed to update status of Job %v/%v: %v",
 				job.Namespace, job.Name, err)
@@ -392,6 +441,13 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 
 	var running, pending, terminating, succeeded, failed, unknown int32
 	taskStatusCount := make(map[string]batch.TaskState)
+	taskResults := jobhelpers.TaskResults{}
+	indexedTasks := map[string]*indexedTask{}
+	recordedIndexes, err := jobhelpers.GetCompletedIndexes(job.Annotations)
+	if err != nil {
+		klog.Warningf("Ignore the completed indexes of Job <%s/%s>: %v", job.Namespace, job.Name, err)
+		recordedIndexes = map[string]sets.Set[int]{}
+	}
 
 	podToCreate := make(map[string][]*v1.Pod)
 	var podToDelete []*v1.Pod
@@ -407,20 +463,42 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 
 	waitCreationGroup := sync.WaitGroup{}
 
+	taskRetryCounts := jobhelpers.GetTaskRetryCounts(job.Annotations)
 	for _, ts := range job.Spec.Tasks {
 		ts.Template.Name = ts.Name
 		tc := ts.Template.DeepCopy()
 		name := ts.Template.Name
+		retryCount := jobhelpers.TaskRetryCount(job.Status.RetryCount, taskRetryCounts, name)
+		if retryTemplate, err := jobhelpers.ApplyRetryStrategy(tc, retryCount); err != nil {
+			klog.Errorf("Failed to apply retry strategy of task %s in Job %s/%s, use the original template: %v",
+				name, job.Namespace, job.Name, err)
+		} else {
+			tc = retryTemplate
+		}
 
 		pods, found := jobInfo.Pods[name]
 		if !found {
 			pods = map[string]*v1.Pod{}
 		}
 
+		var it *indexedTask
+		if jobhelpers.IsIndexedTask(&ts) {
+			it = newIndexedTask(job, &ts, recordedIndexes[name], pods)
+			indexedTasks[name] = it
+		}
+
 		var podToCreateEachTask []*v1.Pod
 		for i := 0; i < int(ts.Replicas); i++ {
 			podName := fmt.Sprintf(jobhelpers.PodNameFmt, job.Name, name, i)
 			if pod, found := pods[podName]; !found {
+				if it != nil && it.completed.Has(i) {
+					atomic.AddInt32(&succeeded, 1)
+					addUpCompletedIndex(name, taskStatusCount)
+					continue
+				}
+				if it != nil && !it.shouldCreate(i) {
+					continue
+				}
 				newPod := createJobPod(job, tc, ts.TopologyPolicy, i, jobForwarding)
 				if err := cc.pluginOnPodCreate(job, newPod); err != nil {
 					return err
@@ -437,6 +515,7 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 
 				classifyAndAddUpPodBaseOnPhase(pod, &pending, &running, &succeeded, &failed, &unknown)
 				calcPodStatus(pod, taskStatusCount)
+				cc.addTaskResult(job, taskResults, name, pod)
 			}
 		}
 		podToCreate[ts.Name] = podToCreateEachTask
@@ -467,6 +546,11 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 			for _, pod := range podToCreateEachTask {
 				go func(pod *v1.Pod) {
 					defer waitCreationGroup.Done()
+					if err := cc.createVolumeClaimsOfPod(job, &job.Spec.Tasks[taskIndex].Template, pod); err != nil {
+						klog.Errorf("Failed to create volume claims of pod %s for Job %s, err %v", pod.Name, job.Name, err)
+						appendError(&creationErrs, err)
+						return
+					}
 					newPod, err := cc.kubeClient.CoreV1().Pods(pod.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
 					if err != nil && !apierrors.IsAlreadyExists(err) {
 						// Failed to create Pod, waitCreationGroup a moment and then create it again
@@ -524,6 +608,16 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 		return fmt.Errorf("failed to delete %d pods of %d", len(deletionErrs), len(podToDelete))
 	}
 
+	if err := cc.syncTaskResults(job, taskResults); err != nil {
+		klog.Errorf("Failed to update task results of Job %v/%v: %v", job.Namespace, job.Name, err)
+		return err
+	}
+
+	if err := cc.syncCompletedIndexes(job, indexedTasks); err != nil {
+		klog.Errorf("Failed to update completed indexes of Job %v/%v: %v", job.Namespace, job.Name, err)
+		return err
+	}
+
 	newStatus := batch.JobStatus{
 		State: job.Status.State,
 
@@ -553,7 +647,7 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 	job.Status.State.LastTransitionTime = metav1.Now()
 	jobCondition = newCondition(job.Status.State.Phase, &job.Status.State.LastTransitionTime)
 	job.Status.Conditions = append(job.Status.Conditions, jobCondition)
-	newJob, err := cc.vcClient.BatchV1alpha1().Jobs(job.Namespace).UpdateStatus(context.TODO(), job, metav1.UpdateOptions{})
+	newJob, err := cc.applyJobStatus(job)
 	if err != nil {
 		klog.Errorf("Failed to update status of Job %v/%v: %v",
 			job.Namespace, job.Name, err)
@@ -791,7 +885,7 @@ func (cc *jobcontroller) createOrUpdatePodGroup(job *batch.Job) error {
 		return nil
 	}
 
-	_, err = cc.vcClient.SchedulingV1beta1().PodGroups(job.Namespace).Update(context.TODO(), podGroupToUpdate, metav1.UpdateOptions{})
+	_, err = cc.applyPodGroupSpec(podGroupToUpdate)
 	if err != nil {
 		klog.V(3).Infof("Failed to update PodGroup for Job <%s/%s>: %v",
 			job.Namespace, job.Name, err)
@@ -857,6 +951,7 @@ func (cc *jobcontroller) calcPGMinResources(job *batch.Job) *v1.ResourceList {
 	var tasksPriority TasksPriority
 	totalMinAvailable := int32(0)
 	for _, task := range job.Spec.Tasks {
+		task = cc.withPodOverhead(task)
 		tp := TaskPriority{0, task}
 		pc := task.Template.Spec.PriorityClassName
 
@@ -890,6 +985,26 @@ func (cc *jobcontroller) calcPGMinResources(job *batch.Job) *v1.ResourceList {
 	return &minReq
 }
 
+// withPodOverhead sets the overhead of the runtime class of the task to its pod template, as the RuntimeClass
+// admission controller does to the pods created, so that the overhead is counted in the min resources of the
+// podgroup. The init containers are counted by the max rule when the requests of the pod are calculated.
+func (cc *jobcontroller) withPodOverhead(task batch.TaskSpec) batch.TaskSpec {
+	rcName := task.Template.Spec.RuntimeClassName
+	if rcName == nil || *rcName == "" || task.Template.Spec.Overhead != nil || cc.rcLister == nil {
+		return task
+	}
+
+	runtimeClass, err := cc.rcLister.Get(*rcName)
+	if err != nil {
+		klog.Warningf("Ignore the overhead of task %s runtime class %s: %v", task.Name, *rcName, err)
+		return task
+	}
+	if runtimeClass.Overhead != nil && len(runtimeClass.Overhead.PodFixed) != 0 {
+		task.Template.Spec.Overhead = runtimeClass.Overhead.PodFixed.DeepCopy()
+	}
+	return task
+}
+
 func (cc *jobcontroller) initJobStatus(job *batch.Job) (*batch.Job, error) {
 	if job.Status.State.Phase != "" {
 		return job, nil
@@ -900,7 +1015,7 @@ func (cc *jobcontroller) initJobStatus(job *batch.Job) (*batch.Job, error) {
 	job.Status.MinAvailable = job.Spec.MinAvailable
 	jobCondition := newCondition(job.Status.State.Phase, &job.Status.State.LastTransitionTime)
 	job.Status.Conditions = append(job.Status.Conditions, jobCondition)
-	newJob, err := cc.vcClient.BatchV1alpha1().Jobs(job.Namespace).UpdateStatus(context.TODO(), job, metav1.UpdateOptions{})
+	newJob, err := cc.applyJobStatus(job)
 	if err != nil {
 		klog.Errorf("Failed to update status of Job %v/%v: %v",
 			job.Namespace, job.Name, err)
@@ -920,7 +1035,8 @@ func (cc *jobcontroller) recordPodGroupEvent(job *batch.Job, podGroup *schedulin
 
 	// Get the latest condition by timestamp
 	for _, condition := range podGroup.Status.Conditions {
-		if condition.Status == v1.ConditionTrue {
+		// the admission by the queue is not the latest state of scheduling
+		if condition.Status == v1.ConditionTrue && condition.Type != api.PodGroupInqueueType {
 			if latestCondition == nil ||
 				condition.LastTransitionTime.Time.After(latestCondition.LastTransitionTime.Time) {
 				latestCondition = &condition
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	batchapply "volcano.sh/apis/pkg/client/applyconfiguration/batch/v1alpha1"
+	schedulingapply "volcano.sh/apis/pkg/client/applyconfiguration/scheduling/v1beta1"
+)
+
+// fieldManager is the field manager of job controller in server-side apply requests.
+const fieldManager = "job-controller"
+
+// applyJobStatus writes the status of the job by server-side apply. Job controller is the only
+// writer of job status, the resource version is kept as precondition so that a status computed
+// from a stale job is rejected rather than overwriting a newer one.
+func (cc *jobcontroller) applyJobStatus(job *batch.Job) (*batch.Job, error) {
+	return cc.vcClient.BatchV1alpha1().Jobs(job.Namespace).ApplyStatus(context.TODO(),
+		jobStatusApplyConfiguration(job), metav1.ApplyOptions{FieldManager: fieldManager, Force: true})
+}
+
+// applyPodGroupSpec writes the spec fields of the podgroup derived from the job by server-side apply,
+// so that it does not conflict with the status of the podgroup written by the scheduler.
+func (cc *jobcontroller) applyPodGroupSpec(pg *scheduling.PodGroup) (*scheduling.PodGroup, error) {
+	return cc.vcClient.SchedulingV1beta1().PodGroups(pg.Namespace).Apply(context.TODO(),
+		podGroupSpecApplyConfiguration(pg), metav1.ApplyOptions{FieldManager: fieldManager, Force: true})
+}
+
+func jobStatusApplyConfiguration(job *batch.Job) *batchapply.JobApplyConfiguration {
+	status := job.Status
+	// counters are set even if they are zero, so that they are reset rather than left unchanged
+	statusApply := batchapply.JobStatus().
+		WithState(batchapply.JobState().
+			WithPhase(status.State.Phase).
+			WithReason(status.State.Reason).
+			WithMessage(status.State.Message).
+			WithLastTransitionTime(status.State.LastTransitionTime)).
+		WithMinAvailable(status.MinAvailable).
+		WithPending(status.Pending).
+		WithRunning(status.Running).
+		WithSucceeded(status.Succeeded).
+		WithFailed(status.Failed).
+		WithTerminating(status.Terminating).
+		WithUnknown(status.Unknown).
+		WithVersion(status.Version).
+		WithRetryCount(status.RetryCount)
+
+	if status.RunningDuration != nil {
+		statusApply.WithRunningDuration(*status.RunningDuration)
+	}
+	if len(status.ControlledResources) > 0 {
+		statusApply.WithControlledResources(status.ControlledResources)
+	}
+	if len(status.TaskStatusCount) > 0 {
+		taskStatusCount := make(map[string]batchapply.TaskStateApplyConfiguration, len(status.TaskStatusCount))
+		for task, state := range status.TaskStatusCount {
+			taskStatusCount[task] = *batchapply.TaskState().WithPhase(state.Phase)
+		}
+		statusApply.WithTaskStatusCount(taskStatusCount)
+	}
+	for _, cond := range status.Conditions {
+		condApply := batchapply.JobCondition().WithStatus(cond.Status)
+		if cond.LastTransitionTime != nil {
+			condApply.WithLastTransitionTime(*cond.LastTransitionTime)
+		}
+		statusApply.WithConditions(condApply)
+	}
+
+	return batchapply.Job(job.Name, job.Namespace).WithResourceVersion(job.ResourceVersion).WithStatus(statusApply)
+}
+
+// podGroupSpecApplyConfiguration returns the fields of the podgroup owned by job controller. The uid
+// is set so that the podgroup is not re-created by the apply request if it has been deleted.
+func podGroupSpecApplyConfiguration(pg *scheduling.PodGroup) *schedulingapply.PodGroupApplyConfiguration {
+	specApply := schedulingapply.PodGroupSpec().
+		WithMinMember(pg.Spec.MinMember).
+		WithPriorityClassName(pg.Spec.PriorityClassName).
+		WithMinTaskMember(pg.Spec.MinTaskMember)
+	if pg.Spec.MinResources != nil {
+		specApply.WithMinResources(*pg.Spec.MinResources)
+	}
+	return schedulingapply.PodGroup(pg.Name, pg.Namespace).WithUID(pg.UID).WithSpec(specApply)
+}
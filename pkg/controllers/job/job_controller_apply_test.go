@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+func TestJobStatusApplyConfiguration(t *testing.T) {
+	now := metav1.Now()
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "ns1", ResourceVersion: "10"},
+		Status: batch.JobStatus{
+			State:   batch.JobState{Phase: batch.Running, LastTransitionTime: now},
+			Running: 2,
+			TaskStatusCount: map[string]batch.TaskState{
+				"worker": {Phase: map[v1.PodPhase]int32{v1.PodRunning: 2}},
+			},
+			Conditions: []batch.JobCondition{{Status: batch.Running, LastTransitionTime: &now}},
+		},
+	}
+
+	jobApply := jobStatusApplyConfiguration(job)
+	if *jobApply.Name != "job1" || *jobApply.Namespace != "ns1" || *jobApply.ResourceVersion != "10" {
+		t.Fatalf("unexpected metadata of apply configuration: %v/%v/%v", *jobApply.Namespace, *jobApply.Name, *jobApply.ResourceVersion)
+	}
+	status := jobApply.Status
+	if *status.State.Phase != batch.Running || *status.Running != 2 {
+		t.Errorf("expected running phase with 2 running pods, got %v/%v", *status.State.Phase, *status.Running)
+	}
+	// zero counters must be applied to reset previous values
+	if status.Pending == nil || *status.Pending != 0 {
+		t.Errorf("expected pending to be set to 0, got %v", status.Pending)
+	}
+	if status.TaskStatusCount["worker"].Phase[v1.PodRunning] != 2 {
+		t.Errorf("expected 2 running pods of task worker, got %v", status.TaskStatusCount["worker"].Phase)
+	}
+	if len(status.Conditions) != 1 || *status.Conditions[0].Status != batch.Running {
+		t.Errorf("expected one running condition, got %v", status.Conditions)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/apis"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// startTime returns the time the job first started running, the restarts of the job
+// do not reset it. The bool result is false if the job has never been running.
+func startTime(job *batch.Job) (time.Time, bool) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status == batch.Running && condition.LastTransitionTime != nil {
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// activeDeadlineRemaining returns the time left before the job exceeds its active deadline.
+// The bool result is false if the job has no active deadline or has not started yet.
+func activeDeadlineRemaining(job *batch.Job, now time.Time) (time.Duration, bool) {
+	switch job.Status.State.Phase {
+	case batch.Pending, batch.Running:
+	default:
+		return 0, false
+	}
+
+	deadline, err := api.GetJobActiveDeadlineSeconds(job.Annotations)
+	if err != nil || deadline == nil {
+		return 0, false
+	}
+	start, found := startTime(job)
+	if !found {
+		return 0, false
+	}
+	return start.Add(time.Duration(*deadline) * time.Second).Sub(now), true
+}
+
+// exceedsActiveDeadline returns whether the job runs longer than its active deadline, so that it is
+// terminated rather than synced. Otherwise, the job is synced again once the deadline is reached.
+func (cc *jobcontroller) exceedsActiveDeadline(queue workqueue.TypedRateLimitingInterface[any], job *batch.Job) bool {
+	remaining, found := activeDeadlineRemaining(job, time.Now())
+	if !found {
+		return false
+	}
+	if remaining > 0 {
+		queue.AddAfter(apis.Request{
+			Namespace: job.Namespace,
+			JobName:   job.Name,
+			JobUid:    job.UID,
+			Event:     busv1alpha1.OutOfSyncEvent,
+		}, remaining)
+		return false
+	}
+
+	klog.V(3).Infof("Job <%s/%s> exceeds its active deadline of %s seconds",
+		job.Namespace, job.Name, job.Annotations[api.JobActiveDeadlineSeconds])
+	cc.recordJobEvent(job.Namespace, job.Name, batch.ExecuteAction, fmt.Sprintf(
+		"Job exceeds its active deadline of %s seconds", job.Annotations[api.JobActiveDeadlineSeconds]))
+	return true
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+func TestActiveDeadlineRemaining(t *testing.T) {
+	now := time.Now()
+	started := metav1.NewTime(now.Add(-30 * time.Minute))
+	restarted := metav1.NewTime(now.Add(-time.Minute))
+	buildJob := func(deadline string, phase batch.JobPhase, conditions ...batch.JobCondition) *batch.Job {
+		job := &batch.Job{
+			Status: batch.JobStatus{State: batch.JobState{Phase: phase}, Conditions: conditions},
+		}
+		if deadline != "" {
+			job.Annotations = map[string]string{api.JobActiveDeadlineSeconds: deadline}
+		}
+		return job
+	}
+
+	testCases := []struct {
+		name              string
+		job               *batch.Job
+		expectedRemaining time.Duration
+		expectedFound     bool
+	}{
+		{
+			name: "job without deadline",
+			job:  buildJob("", batch.Running, batch.JobCondition{Status: batch.Running, LastTransitionTime: &started}),
+		},
+		{
+			name: "job not started",
+			job:  buildJob("3600", batch.Pending, batch.JobCondition{Status: batch.Pending, LastTransitionTime: &started}),
+		},
+		{
+			name:              "running job within deadline",
+			job:               buildJob("3600", batch.Running, batch.JobCondition{Status: batch.Running, LastTransitionTime: &started}),
+			expectedRemaining: 30 * time.Minute,
+			expectedFound:     true,
+		},
+		{
+			name: "restarted job counts from the first start",
+			job: buildJob("600", batch.Running,
+				batch.JobCondition{Status: batch.Running, LastTransitionTime: &started},
+				batch.JobCondition{Status: batch.Restarting, LastTransitionTime: &restarted},
+				batch.JobCondition{Status: batch.Running, LastTransitionTime: &restarted}),
+			expectedRemaining: -20 * time.Minute,
+			expectedFound:     true,
+		},
+		{
+			name: "completed job",
+			job:  buildJob("600", batch.Completed, batch.JobCondition{Status: batch.Running, LastTransitionTime: &started}),
+		},
+	}
+
+	for _, tc := range testCases {
+		remaining, found := activeDeadlineRemaining(tc.job, now)
+		if found != tc.expectedFound || remaining != tc.expectedRemaining {
+			t.Errorf("%s: expected %v %v, got %v %v", tc.name, tc.expectedRemaining, tc.expectedFound, remaining, found)
+		}
+	}
+}
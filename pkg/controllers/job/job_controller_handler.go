@@ -37,6 +37,7 @@ import (
 	jobcache "volcano.sh/volcano/pkg/controllers/cache"
 	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/controllers/job/state"
+	"volcano.sh/volcano/pkg/scheduler/api"
 )
 
 func (cc *jobcontroller) addCommand(obj interface{}) {
@@ -73,6 +74,12 @@ func (cc *jobcontroller) addJob(obj interface{}) {
 	queue.Add(req)
 }
 
+// propagatedMetadataChanged returns whether the labels or annotations propagated to the objects of the job plugins change.
+func propagatedMetadataChanged(oldJob, newJob *batch.Job) bool {
+	return !equality.Semantic.DeepEqual(oldJob.Labels, newJob.Labels) ||
+		!equality.Semantic.DeepEqual(jobhelpers.PropagatedAnnotations(oldJob), jobhelpers.PropagatedAnnotations(newJob))
+}
+
 func (cc *jobcontroller) updateJob(oldObj, newObj interface{}) {
 	newJob, ok := newObj.(*batch.Job)
 	if !ok {
@@ -99,7 +106,11 @@ func (cc *jobcontroller) updateJob(oldObj, newObj interface{}) {
 
 	// NOTE: Since we only reconcile job based on Spec, we will ignore other attributes
 	// For Job status, it's used internally and always been updated via our controller.
-	if equality.Semantic.DeepEqual(newJob.Spec, oldJob.Spec) && newJob.Status.State.Phase == oldJob.Status.State.Phase {
+	// The suspend annotation is handled as the spec of job, and the labels and annotations
+	// propagated to the objects of the job plugins are reconciled as well.
+	oldSuspended, newSuspended := api.IsSuspended(oldJob.Annotations), api.IsSuspended(newJob.Annotations)
+	if equality.Semantic.DeepEqual(newJob.Spec, oldJob.Spec) && newJob.Status.State.Phase == oldJob.Status.State.Phase &&
+		oldSuspended == newSuspended && !propagatedMetadataChanged(oldJob, newJob) {
 		klog.V(6).Infof("Job update event is ignored since no update in 'Spec'.")
 		return
 	}
@@ -109,6 +120,9 @@ func (cc *jobcontroller) updateJob(oldObj, newObj interface{}) {
 		JobName:   newJob.Name,
 		Event:     bus.OutOfSyncEvent,
 	}
+	if oldSuspended && !newSuspended {
+		req.Action = bus.ResumeJobAction
+	}
 	key := jobhelpers.GetJobKeyByReq(&req)
 	queue := cc.getWorkerQueue(key)
 	queue.Add(req)
@@ -19,6 +19,7 @@ package job
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/client-go/informers"
 	kubeclientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
 
 	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	bus "volcano.sh/apis/pkg/apis/bus/v1alpha1"
@@ -34,6 +36,7 @@ import (
 	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	vcclientset "volcano.sh/apis/pkg/client/clientset/versioned"
 	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
+	"volcano.sh/volcano/pkg/controllers/apis"
 	"volcano.sh/volcano/pkg/controllers/framework"
 )
 
@@ -286,6 +289,34 @@ func TestUpdateJobFunc(t *testing.T) {
 	}
 }
 
+func TestPropagatedMetadataChanged(t *testing.T) {
+	oldJob := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"team": "ml"},
+			Annotations: map[string]string{"cost-center": "42", "volcano.sh/job-suspend": "false"},
+		},
+	}
+	testcases := []struct {
+		Name     string
+		update   func(job *batch.Job)
+		expected bool
+	}{
+		{Name: "label changed", update: func(job *batch.Job) { job.Labels["team"] = "infra" }, expected: true},
+		{Name: "annotation changed", update: func(job *batch.Job) { job.Annotations["cost-center"] = "43" }, expected: true},
+		{Name: "internal annotation changed", update: func(job *batch.Job) { job.Annotations["volcano.sh/job-suspend"] = "true" }},
+		{Name: "nothing changed", update: func(job *batch.Job) {}},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			newJob := oldJob.DeepCopy()
+			testcase.update(newJob)
+			if got := propagatedMetadataChanged(oldJob, newJob); got != testcase.expected {
+				t.Errorf("expected %v, got %v", testcase.expected, got)
+			}
+		})
+	}
+}
+
 func TestAddPodFunc(t *testing.T) {
 	namespace := "test"
 
@@ -572,3 +603,65 @@ func TestUpdatePodGroupFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestCoalescePodEvent(t *testing.T) {
+	testCases := []struct {
+		name             string
+		statusSyncPeriod time.Duration
+		action           bus.Action
+		delay            time.Duration
+		expectCoalesced  bool
+		expectLen        int
+	}{
+		{
+			name:             "pod events of a job are merged into a single sync",
+			statusSyncPeriod: 10 * time.Millisecond,
+			action:           bus.SyncJobAction,
+			expectCoalesced:  true,
+			expectLen:        1,
+		},
+		{
+			name:             "no coalescing without status sync period",
+			statusSyncPeriod: 0,
+			action:           bus.SyncJobAction,
+		},
+		{
+			name:             "pod events matching a policy are not coalesced",
+			statusSyncPeriod: 10 * time.Millisecond,
+			action:           bus.RestartJobAction,
+		},
+		{
+			name:             "pod events with a delayed action are not coalesced",
+			statusSyncPeriod: 10 * time.Millisecond,
+			action:           bus.SyncJobAction,
+			delay:            time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cc := &jobcontroller{statusSyncPeriod: tc.statusSyncPeriod}
+			queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any]())
+			defer queue.ShutDown()
+
+			for i := 0; i < 100; i++ {
+				req := apis.Request{
+					Namespace: "default",
+					JobName:   "job1",
+					JobUid:    "job1-uid",
+					PodName:   fmt.Sprintf("job1-worker-%d", i),
+					Event:     bus.PodRunningEvent,
+				}
+				delayAct := &delayAction{action: tc.action, delay: tc.delay}
+				if coalesced := cc.coalescePodEvent(queue, req, delayAct); coalesced != tc.expectCoalesced {
+					t.Fatalf("expected coalesced %v, got %v", tc.expectCoalesced, coalesced)
+				}
+			}
+
+			time.Sleep(10*tc.statusSyncPeriod + 50*time.Millisecond)
+			if queue.Len() != tc.expectLen {
+				t.Errorf("expected %d requests in queue, got %d", tc.expectLen, queue.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+// indexedTask tracks the indexes of an indexed task during a sync of the job.
+type indexedTask struct {
+	// completed is the completed indexes of the task, including the ones whose pods are removed.
+	completed sets.Set[int]
+	// parallelism is the max number of pods of the task running at the same time.
+	parallelism int
+	// active is the number of pods of the task which are not finished.
+	active int
+}
+
+// newIndexedTask returns the indexes of the indexed task, the recorded completed indexes beyond the replicas
+// of the task are dropped after it is scaled down.
+func newIndexedTask(job *batch.Job, ts *batch.TaskSpec, recorded sets.Set[int], pods map[string]*v1.Pod) *indexedTask {
+	parallelism, err := jobhelpers.GetTaskParallelism(ts)
+	if err != nil {
+		klog.Warningf("Run all the pods of task %s in Job <%s/%s> at the same time: %v", ts.Name, job.Namespace, job.Name, err)
+		parallelism = int(ts.Replicas)
+	}
+
+	it := &indexedTask{completed: sets.New[int](), parallelism: parallelism}
+	for i := 0; i < int(ts.Replicas); i++ {
+		if recorded.Has(i) {
+			it.completed.Insert(i)
+		}
+		pod, found := pods[jobhelpers.MakePodName(job.Name, ts.Name, i)]
+		if !found {
+			continue
+		}
+		switch pod.Status.Phase {
+		case v1.PodSucceeded:
+			it.completed.Insert(i)
+		case v1.PodFailed:
+		default:
+			it.active++
+		}
+	}
+	return it
+}
+
+// shouldCreate returns whether the pod of the index is created, the pods are created in the order of
+// their indexes as long as the parallelism of the task is not exceeded.
+func (it *indexedTask) shouldCreate(index int) bool {
+	if it.completed.Has(index) || it.active >= it.parallelism {
+		return false
+	}
+	it.active++
+	return true
+}
+
+// addUpCompletedIndex counts the completed index whose pod is removed as a succeeded pod of the task,
+// so that the task still completes when all its indexes complete.
+func addUpCompletedIndex(taskName string, taskStatusCount map[string]batch.TaskState) {
+	calMutex.Lock()
+	defer calMutex.Unlock()
+	if _, ok := taskStatusCount[taskName]; !ok {
+		taskStatusCount[taskName] = batch.TaskState{
+			Phase: make(map[v1.PodPhase]int32),
+		}
+	}
+	taskStatusCount[taskName].Phase[v1.PodSucceeded]++
+}
+
+// syncCompletedIndexes records the completed indexes of the indexed tasks into the CompletedIndexesAnnotation
+// of the job, the annotation is patched only if the completed indexes are changed.
+func (cc *jobcontroller) syncCompletedIndexes(job *batch.Job, indexedTasks map[string]*indexedTask) error {
+	completed := map[string]sets.Set[int]{}
+	for taskName, it := range indexedTasks {
+		completed[taskName] = it.completed
+	}
+	data, err := jobhelpers.FormatCompletedIndexes(completed)
+	if err != nil {
+		return err
+	}
+
+	current, found := job.Annotations[jobhelpers.CompletedIndexesAnnotation]
+	// the annotation is removed by a null value in the merge patch
+	var value interface{}
+	if data != "" {
+		if found && data == current {
+			return nil
+		}
+		value = data
+	} else if !found {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{jobhelpers.CompletedIndexesAnnotation: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := cc.vcClient.BatchV1alpha1().Jobs(job.Namespace).Patch(context.TODO(),
+		job.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch completed indexes: %v", err)
+	}
+	klog.V(3).Infof("Updated the completed indexes of Job <%s/%s> to %s", job.Namespace, job.Name, data)
+	return nil
+}
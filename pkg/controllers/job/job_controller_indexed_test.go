@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+func TestIndexedTask(t *testing.T) {
+	job := &batch.Job{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "default"}}
+	ts := &batch.TaskSpec{
+		Name:     "trial",
+		Replicas: 6,
+		Template: v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			jobhelpers.CompletionModeAnnotation: jobhelpers.IndexedCompletionMode,
+			jobhelpers.ParallelismAnnotation:    "2",
+		}}},
+	}
+	pods := map[string]*v1.Pod{
+		"job1-trial-1": buildPod("default", "job1-trial-1", v1.PodSucceeded, nil),
+		"job1-trial-2": buildPod("default", "job1-trial-2", v1.PodRunning, nil),
+		"job1-trial-3": buildPod("default", "job1-trial-3", v1.PodFailed, nil),
+	}
+
+	// index 0 completed before its pod is removed, and index 9 is beyond the replicas after a scale down
+	it := newIndexedTask(job, ts, sets.New(0, 9), pods)
+	if !it.completed.Equal(sets.New(0, 1)) {
+		t.Errorf("expected completed indexes [0 1], got %v", sets.List(it.completed))
+	}
+	if it.active != 1 {
+		t.Errorf("expected 1 active pod, got %d", it.active)
+	}
+
+	var created []int
+	for i := 0; i < int(ts.Replicas); i++ {
+		if _, found := pods[jobhelpers.MakePodName(job.Name, ts.Name, i)]; !found && it.shouldCreate(i) {
+			created = append(created, i)
+		}
+	}
+	if len(created) != 1 || created[0] != 4 {
+		t.Errorf("expected only index 4 to be created, got %v", created)
+	}
+}
+
+func TestSyncCompletedIndexes(t *testing.T) {
+	fakeController := newFakeController()
+	job := &batch.Job{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "default"}}
+	if _, err := fakeController.vcClient.BatchV1alpha1().Jobs(job.Namespace).Create(context.TODO(), job, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+
+	getAnnotation := func() (string, bool) {
+		newJob, err := fakeController.vcClient.BatchV1alpha1().Jobs(job.Namespace).Get(context.TODO(), job.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get job: %v", err)
+		}
+		job = newJob
+		value, found := newJob.Annotations[jobhelpers.CompletedIndexesAnnotation]
+		return value, found
+	}
+
+	indexedTasks := map[string]*indexedTask{
+		"trial": {completed: sets.New(0, 1, 2, 5)},
+		"eval":  {completed: sets.New[int]()},
+	}
+	if err := fakeController.syncCompletedIndexes(job, indexedTasks); err != nil {
+		t.Fatalf("failed to sync completed indexes: %v", err)
+	}
+	if value, _ := getAnnotation(); value != `{"trial":"0-2,5"}` {
+		t.Errorf("unexpected completed indexes %q", value)
+	}
+
+	// the completed indexes are removed when the job has no indexed task
+	if err := fakeController.syncCompletedIndexes(job, nil); err != nil {
+		t.Fatalf("failed to sync completed indexes: %v", err)
+	}
+	if value, found := getAnnotation(); found {
+		t.Errorf("expected completed indexes removed, got %q", value)
+	}
+}
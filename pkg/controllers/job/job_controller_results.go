@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+// addTaskResult adds the result of the pod of the task, the invalid results are ignored with an event.
+func (cc *jobcontroller) addTaskResult(job *batch.Job, results jobhelpers.TaskResults, taskName string, pod *v1.Pod) {
+	result, err := jobhelpers.GetPodTaskResult(pod)
+	if err != nil {
+		klog.Warningf("Ignore the result of pod <%s/%s>: %v", pod.Namespace, pod.Name, err)
+		cc.recorder.Event(job, v1.EventTypeWarning, InvalidTaskResultReason,
+			fmt.Sprintf("Ignore the result of pod %s: %v", pod.Name, err))
+		return
+	}
+	if result != nil {
+		results.Add(taskName, pod.Name, result)
+	}
+}
+
+// syncTaskResults aggregates the results of the pods into the TaskResultsAnnotation of the job,
+// the annotation is patched only if the results are changed.
+func (cc *jobcontroller) syncTaskResults(job *batch.Job, results jobhelpers.TaskResults) error {
+	current, found := job.Annotations[jobhelpers.TaskResultsAnnotation]
+	// the annotation is removed by a null value in the merge patch
+	var value interface{}
+	if len(results) != 0 {
+		data, err := json.Marshal(results)
+		if err != nil {
+			return err
+		}
+		if len(data) > jobhelpers.MaxTaskResultsSize {
+			klog.Warningf("Results of Job <%s/%s> of %d bytes exceed the limit of %d bytes, skip updating them",
+				job.Namespace, job.Name, len(data), jobhelpers.MaxTaskResultsSize)
+			return nil
+		}
+		if found && string(data) == current {
+			return nil
+		}
+		value = string(data)
+	} else if !found {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{jobhelpers.TaskResultsAnnotation: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := cc.vcClient.BatchV1alpha1().Jobs(job.Namespace).Patch(context.TODO(),
+		job.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	klog.V(3).Infof("Updated the task results of Job <%s/%s>", job.Namespace, job.Name)
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+func TestSyncTaskResults(t *testing.T) {
+	fakeController := newFakeController()
+	job := &batch.Job{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "default"}}
+	if _, err := fakeController.vcClient.BatchV1alpha1().Jobs(job.Namespace).Create(context.TODO(), job, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+
+	getAnnotation := func() (string, bool) {
+		newJob, err := fakeController.vcClient.BatchV1alpha1().Jobs(job.Namespace).Get(context.TODO(), job.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get job: %v", err)
+		}
+		job = newJob
+		value, found := newJob.Annotations[jobhelpers.TaskResultsAnnotation]
+		return value, found
+	}
+
+	results := jobhelpers.TaskResults{}
+	pods := []*v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "job1-train-0", Annotations: map[string]string{jobhelpers.TaskResultAnnotation: `{"loss": 0.1}`}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "job1-train-1", Annotations: map[string]string{jobhelpers.TaskResultAnnotation: "invalid"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "job1-train-2"},
+		},
+	}
+	for _, pod := range pods {
+		fakeController.addTaskResult(job, results, "train", pod)
+	}
+	if err := fakeController.syncTaskResults(job, results); err != nil {
+		t.Fatalf("failed to sync task results: %v", err)
+	}
+	if value, _ := getAnnotation(); value != `{"train":{"job1-train-0":{"loss":0.1}}}` {
+		t.Errorf("unexpected task results %q", value)
+	}
+
+	// the results are removed when there is no result of the pods
+	if err := fakeController.syncTaskResults(job, jobhelpers.TaskResults{}); err != nil {
+		t.Fatalf("failed to sync task results: %v", err)
+	}
+	if value, found := getAnnotation(); found {
+		t.Errorf("expected task results removed, got %q", value)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// shouldSuspend returns whether the suspended job is still pending or running, so that it is aborted
+// rather than synced.
+func shouldSuspend(job *batch.Job) bool {
+	if !api.IsSuspended(job.Annotations) {
+		return false
+	}
+	switch job.Status.State.Phase {
+	case "", batch.Pending, batch.Running:
+		return true
+	default:
+		return false
+	}
+}
+
+// syncPodGroupSuspend propagates the suspend annotation of the job to its podgroup, the scheduler
+// skips the podgroups suspended.
+func (cc *jobcontroller) syncPodGroupSuspend(job *batch.Job) error {
+	pg, err := cc.getPodGroupByJob(job)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	suspended := api.IsSuspended(job.Annotations)
+	if api.IsSuspended(pg.Annotations) == suspended {
+		return nil
+	}
+
+	var value interface{}
+	if suspended {
+		value = "true"
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{api.JobSuspend: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := cc.vcClient.SchedulingV1beta1().PodGroups(pg.Namespace).Patch(context.TODO(),
+		pg.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	klog.V(3).Infof("Set PodGroup <%s/%s> of Job <%s/%s> suspended to %v",
+		pg.Namespace, pg.Name, job.Namespace, job.Name, suspended)
+	return nil
+}
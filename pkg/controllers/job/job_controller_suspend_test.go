@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	bus "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/apis"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+func buildSuspendJob(suspend string, phase batch.JobPhase) *batch.Job {
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "default", UID: "uid-1", ResourceVersion: "1"},
+		Status:     batch.JobStatus{State: batch.JobState{Phase: phase}},
+	}
+	if suspend != "" {
+		job.Annotations = map[string]string{api.JobSuspend: suspend}
+	}
+	return job
+}
+
+func TestShouldSuspend(t *testing.T) {
+	testCases := []struct {
+		name     string
+		job      *batch.Job
+		expected bool
+	}{
+		{name: "new job suspended", job: buildSuspendJob("true", ""), expected: true},
+		{name: "running job suspended", job: buildSuspendJob("true", batch.Running), expected: true},
+		{name: "aborted job suspended", job: buildSuspendJob("true", batch.Aborted), expected: false},
+		{name: "running job not suspended", job: buildSuspendJob("false", batch.Running), expected: false},
+		{name: "invalid annotation", job: buildSuspendJob("yes", batch.Running), expected: false},
+	}
+
+	for _, tc := range testCases {
+		if got := shouldSuspend(tc.job); got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestSyncPodGroupSuspend(t *testing.T) {
+	fakeController := newFakeController()
+	job := buildSuspendJob("true", batch.Running)
+	pg := &scheduling.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: fakeController.generateRelatedPodGroupName(job), Namespace: job.Namespace},
+	}
+	if _, err := fakeController.vcClient.SchedulingV1beta1().PodGroups(pg.Namespace).Create(context.TODO(), pg, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create podgroup: %v", err)
+	}
+	fakeController.pgInformer.Informer().GetIndexer().Add(pg)
+
+	if err := fakeController.syncPodGroupSuspend(job); err != nil {
+		t.Fatalf("failed to sync podgroup suspend: %v", err)
+	}
+	newPG, err := fakeController.vcClient.SchedulingV1beta1().PodGroups(pg.Namespace).Get(context.TODO(), pg.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get podgroup: %v", err)
+	}
+	if !api.IsSuspended(newPG.Annotations) {
+		t.Fatalf("expected podgroup suspended, got annotations %v", newPG.Annotations)
+	}
+
+	fakeController.pgInformer.Informer().GetIndexer().Update(newPG)
+	job.Annotations[api.JobSuspend] = "false"
+	if err := fakeController.syncPodGroupSuspend(job); err != nil {
+		t.Fatalf("failed to sync podgroup suspend: %v", err)
+	}
+	newPG, err = fakeController.vcClient.SchedulingV1beta1().PodGroups(pg.Namespace).Get(context.TODO(), pg.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get podgroup: %v", err)
+	}
+	if _, found := newPG.Annotations[api.JobSuspend]; found {
+		t.Errorf("expected suspend annotation removed from podgroup, got %v", newPG.Annotations)
+	}
+}
+
+func TestUpdateJobSuspend(t *testing.T) {
+	testCases := []struct {
+		name           string
+		oldSuspend     string
+		newSuspend     string
+		expectedAction bus.Action
+	}{
+		{name: "job suspended", oldSuspend: "", newSuspend: "true", expectedAction: ""},
+		{name: "job resumed", oldSuspend: "true", newSuspend: "false", expectedAction: bus.ResumeJobAction},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeController := newFakeController()
+			oldJob := buildSuspendJob(tc.oldSuspend, batch.Running)
+			newJob := buildSuspendJob(tc.newSuspend, batch.Running)
+			newJob.ResourceVersion = "2"
+			if err := fakeController.cache.Add(oldJob); err != nil {
+				t.Fatalf("failed to add job into cache: %v", err)
+			}
+
+			fakeController.updateJob(oldJob, newJob)
+
+			key := jobhelpers.GetJobKeyByReq(&apis.Request{Namespace: newJob.Namespace, JobName: newJob.Name})
+			queue := fakeController.getWorkerQueue(key)
+			if queue.Len() != 1 {
+				t.Fatalf("expected job enqueued, got %d requests", queue.Len())
+			}
+			obj, _ := queue.Get()
+			if req := obj.(apis.Request); req.Action != tc.expectedAction {
+				t.Errorf("expected action %q, got %q", tc.expectedAction, req.Action)
+			}
+		})
+	}
+}
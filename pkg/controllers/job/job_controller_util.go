@@ -59,6 +59,12 @@ func createJobPod(job *batch.Job, template *v1.PodTemplateSpec, topologyPolicy b
 		Spec: templateCopy.Spec,
 	}
 
+	// The hostname and subdomain of the pod are kept across restarts for stable pod identities.
+	if jobhelpers.StablePodIdentity(job) {
+		pod.Spec.Hostname = pod.Name
+		pod.Spec.Subdomain = job.Name
+	}
+
 	// If no scheduler name in Pod, use scheduler name from Job.
 	if len(pod.Spec.SchedulerName) == 0 {
 		pod.Spec.SchedulerName = job.Spec.SchedulerName
@@ -98,6 +104,14 @@ func createJobPod(job *batch.Job, template *v1.PodTemplateSpec, topologyPolicy b
 		}
 	}
 
+	// Every replica mounts its own PVCs created from the volume claim templates of the task.
+	if claims, err := jobhelpers.GetVolumeClaimTemplates(template); err != nil {
+		klog.Errorf("Failed to get volume claim templates of task %s of job %s/%s: %v", template.Name, job.Namespace, job.Name, err)
+	} else {
+		jobhelpers.ApplyVolumeClaimTemplates(pod, claims)
+	}
+	delete(pod.Annotations, jobhelpers.VolumeClaimTemplatesAnnotation)
+
 	tsKey := templateCopy.Name
 	if len(tsKey) == 0 {
 		tsKey = batch.DefaultTaskSpec
@@ -110,8 +124,7 @@ func createJobPod(job *batch.Job, template *v1.PodTemplateSpec, topologyPolicy b
 	index := strconv.Itoa(ix)
 	pod.Annotations[batch.TaskIndex] = index
 	pod.Annotations[batch.TaskSpecKey] = tsKey
-	pgName := job.Name + "-" + string(job.UID)
-	pod.Annotations[schedulingv2.KubeGroupNameAnnotationKey] = pgName
+	pod.Annotations[schedulingv2.KubeGroupNameAnnotationKey] = jobhelpers.MakePodGroupName(job)
 	pod.Annotations[batch.JobNameKey] = job.Name
 	pod.Annotations[batch.QueueNameKey] = job.Spec.Queue
 	pod.Annotations[batch.JobVersion] = fmt.Sprintf("%d", job.Status.Version)
@@ -164,6 +177,16 @@ func createJobPod(job *batch.Job, template *v1.PodTemplateSpec, topologyPolicy b
 		pod.Labels[batch.JobForwardingKey] = "true"
 	}
 
+	if affinities, err := jobhelpers.GetTaskAffinities(job); err != nil {
+		klog.Warningf("Ignore the task affinities of Job <%s/%s>: %v", job.Namespace, job.Name, err)
+	} else {
+		jobhelpers.ApplyTaskAffinity(pod, job, tsKey, affinities)
+	}
+
+	if err := jobhelpers.ApplyCheckpointPath(pod, job); err != nil {
+		klog.Warningf("Ignore the checkpoint hook of Job <%s/%s>: %v", job.Namespace, job.Name, err)
+	}
+
 	return pod
 }
 
@@ -207,34 +230,12 @@ func applyPolicies(job *batch.Job, req *apis.Request) (delayAct *delayAction) {
 		// Parse task level policies
 		for _, task := range job.Spec.Tasks {
 			if task.Name == req.TaskName {
-				for _, policy := range task.Policies {
-					policyEvents := getEventlist(policy)
-
-					if len(policyEvents) > 0 && len(req.Event) > 0 {
-						if checkEventExist(policyEvents, req.Event) || checkEventExist(policyEvents, v1alpha1.AnyEvent) {
-							// Check if the event requires a timeout configuration, and whether a timeout policy is specified.
-							// If the event does not require a timeout (shouldConfigureTimeout returns false),
-							// or if a timeout policy is already set (policy.Timeout != nil),
-							// execute the corresponding delay action and set the delay time based on the policy's Timeout.Duration.
-							// If a timeout policy is specified, set the delay to the timeout duration.
-							if !shouldConfigureTimeout(req.Event) || policy.Timeout != nil {
-								delayAct.action = policy.Action
-								if policy.Timeout != nil {
-									delayAct.delay = policy.Timeout.Duration
-								}
-								return
-							}
-						}
-					}
-
-					// 0 is not an error code, is prevented in validation admission controller
-					if policy.ExitCode != nil && *policy.ExitCode == req.ExitCode {
-						delayAct.action = policy.Action
-						if policy.Timeout != nil {
-							delayAct.delay = policy.Timeout.Duration
-						}
-						return
+				if policy := matchPolicy(task.Policies, req); policy != nil {
+					delayAct.action = policy.Action
+					if policy.Timeout != nil {
+						delayAct.delay = policy.Timeout.Duration
 					}
+					return
 				}
 				break
 			}
@@ -242,32 +243,58 @@ func applyPolicies(job *batch.Job, req *apis.Request) (delayAct *delayAction) {
 	}
 
 	// Parse Job level policies
-	for _, policy := range job.Spec.Policies {
-		policyEvents := getEventlist(policy)
+	if policy := matchPolicy(job.Spec.Policies, req); policy != nil {
+		delayAct.action = policy.Action
+		if policy.Timeout != nil {
+			delayAct.delay = policy.Timeout.Duration
+		}
+	}
+
+	return
+}
+
+// matchPolicy returns the policy to apply for the request, nil if no policy matches.
+// The policies with both event and exitCode are more specific, so they are matched before the others,
+// e.g. to restart only the failed pod for a known flaky exit code but restart the job for other failures.
+func matchPolicy(policies []batch.LifecyclePolicy, req *apis.Request) *batch.LifecyclePolicy {
+	for i := range policies {
+		policy := &policies[i]
+		policyEvents := getEventlist(*policy)
+		if len(policyEvents) == 0 || policy.ExitCode == nil {
+			continue
+		}
+		if len(req.Event) > 0 && checkEventExist(policyEvents, req.Event) && *policy.ExitCode == req.ExitCode {
+			return policy
+		}
+	}
+
+	for i := range policies {
+		policy := &policies[i]
+		policyEvents := getEventlist(*policy)
+		if len(policyEvents) > 0 && policy.ExitCode != nil {
+			continue
+		}
 
 		if len(policyEvents) > 0 && len(req.Event) > 0 {
 			if checkEventExist(policyEvents, req.Event) || checkEventExist(policyEvents, v1alpha1.AnyEvent) {
-				if !(shouldConfigureTimeout(req.Event) && policy.Timeout == nil) {
-					delayAct.action = policy.Action
-					if policy.Timeout != nil {
-						delayAct.delay = policy.Timeout.Duration
-					}
-					return
+				// Check if the event requires a timeout configuration, and whether a timeout policy is specified.
+				// If the event does not require a timeout (shouldConfigureTimeout returns false),
+				// or if a timeout policy is already set (policy.Timeout != nil),
+				// execute the corresponding delay action and set the delay time based on the policy's Timeout.Duration.
+				// If a timeout policy is specified, set the delay to the timeout duration.
+				if !shouldConfigureTimeout(req.Event) || policy.Timeout != nil {
+					return policy
 				}
 			}
 		}
 
 		// 0 is not an error code, is prevented in validation admission controller
 		if policy.ExitCode != nil && *policy.ExitCode == req.ExitCode {
-			delayAct.action = policy.Action
-			if policy.Timeout != nil {
-				delayAct.delay = policy.Timeout.Duration
-			}
-			return
+			return policy
 		}
 	}
 
-	return
+	return nil
 }
 
 func shouldConfigureTimeout(event v1alpha1.Event) bool {
@@ -22,11 +22,13 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/controllers/apis"
 )
 
@@ -251,6 +253,41 @@ func TestCreateJobPod(t *testing.T) {
 	}
 }
 
+func TestCreateJobPodInheritsPriorityClass(t *testing.T) {
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "test"},
+		Spec: v1alpha1.JobSpec{
+			PriorityClassName: "high",
+			Tasks:             []v1alpha1.TaskSpec{{Name: "task1", Replicas: 1}},
+		},
+	}
+
+	testcases := []struct {
+		name             string
+		templatePriority string
+		expected         string
+	}{
+		{name: "inherit priority class of job", expected: "high"},
+		{name: "keep priority class of template", templatePriority: "low", expected: "low"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			template := &v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Name: "task1"},
+				Spec: v1.PodSpec{
+					PriorityClassName: testcase.templatePriority,
+					Containers:        []v1.Container{{Name: "c"}},
+				},
+			}
+			pod := createJobPod(job, template, "", 0, false)
+			if pod.Spec.PriorityClassName != testcase.expected {
+				t.Errorf("expected priorityClassName %q, got %q", testcase.expected, pod.Spec.PriorityClassName)
+			}
+		})
+	}
+}
+
 func TestApplyPolicies(t *testing.T) {
 	namespace := "test"
 	errorCode0 := int32(0)
@@ -745,6 +782,82 @@ func TestApplyPolicies(t *testing.T) {
 	}
 }
 
+func TestApplyPoliciesWithEventAndExitCode(t *testing.T) {
+	flakyExitCode := int32(137)
+	fatalExitCode := int32(2)
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job1",
+			Namespace: "test",
+		},
+		Spec: v1alpha1.JobSpec{
+			Tasks: []v1alpha1.TaskSpec{
+				{
+					Name:     "worker",
+					Replicas: 64,
+					Policies: []v1alpha1.LifecyclePolicy{
+						{
+							Action: busv1alpha1.RestartTaskAction,
+							Event:  busv1alpha1.PodFailedEvent,
+						},
+						{
+							Action:   busv1alpha1.RestartPodAction,
+							Event:    busv1alpha1.PodFailedEvent,
+							ExitCode: &flakyExitCode,
+						},
+					},
+				},
+			},
+			Policies: []v1alpha1.LifecyclePolicy{
+				{
+					Action: busv1alpha1.RestartJobAction,
+					Event:  busv1alpha1.PodFailedEvent,
+				},
+				{
+					Action:   busv1alpha1.AbortJobAction,
+					ExitCode: &fatalExitCode,
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		Name      string
+		Request   *apis.Request
+		ReturnVal busv1alpha1.Action
+	}{
+		{
+			Name:      "pod failed with the flaky exit code restarts only the pod",
+			Request:   &apis.Request{TaskName: "worker", Event: busv1alpha1.PodFailedEvent, ExitCode: flakyExitCode},
+			ReturnVal: busv1alpha1.RestartPodAction,
+		},
+		{
+			Name:      "pod failed with other exit code restarts the task",
+			Request:   &apis.Request{TaskName: "worker", Event: busv1alpha1.PodFailedEvent, ExitCode: 1},
+			ReturnVal: busv1alpha1.RestartTaskAction,
+		},
+		{
+			Name:      "pod of task without policies failed restarts the job",
+			Request:   &apis.Request{TaskName: "ps", Event: busv1alpha1.PodFailedEvent, ExitCode: flakyExitCode},
+			ReturnVal: busv1alpha1.RestartJobAction,
+		},
+		{
+			Name:      "exit code policy applies to requests without event",
+			Request:   &apis.Request{TaskName: "ps", ExitCode: fatalExitCode},
+			ReturnVal: busv1alpha1.AbortJobAction,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			action := applyPolicies(job, testcase.Request)
+			if action.action != testcase.ReturnVal {
+				t.Errorf("Expected action %s but got %s", testcase.ReturnVal, action.action)
+			}
+		})
+	}
+}
+
 func TestTasksPriority_Less(t *testing.T) {
 	testcases := []struct {
 		Name          string
@@ -1234,3 +1347,120 @@ func TestCalcPGMinResources(t *testing.T) {
 
 	}
 }
+
+func TestCalcPGMinResourcesWithInitContainersAndOverhead(t *testing.T) {
+	jc := newFakeController()
+	jc.rcInformer.Informer().GetIndexer().Add(&nodev1.RuntimeClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "kata"},
+		Handler:    "kata",
+		Overhead: &nodev1.Overhead{PodFixed: v1.ResourceList{
+			v1.ResourceCPU: resource.MustParse("50m"),
+		}},
+	})
+
+	cpu := func(milli int64) v1.ResourceList {
+		return v1.ResourceList{v1.ResourceCPU: *resource.NewMilliQuantity(milli, resource.DecimalSI)}
+	}
+	buildJob := func(runtimeClassName *string) *v1alpha1.Job {
+		minAvailable := int32(2)
+		return &v1alpha1.Job{
+			Spec: v1alpha1.JobSpec{
+				MinAvailable: 2,
+				Tasks: []v1alpha1.TaskSpec{{
+					Name:         "worker",
+					Replicas:     2,
+					MinAvailable: &minAvailable,
+					Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+						RuntimeClassName: runtimeClassName,
+						InitContainers: []v1.Container{
+							{Name: "init1", Resources: v1.ResourceRequirements{Requests: cpu(500)}},
+							{Name: "init2", Resources: v1.ResourceRequirements{Requests: cpu(300)}},
+						},
+						Containers: []v1.Container{
+							{Name: "main", Resources: v1.ResourceRequirements{Requests: cpu(100)}},
+							{Name: "helper", Resources: v1.ResourceRequirements{Requests: cpu(100)}},
+						},
+					}},
+				}},
+			},
+		}
+	}
+	kata, unknown := "kata", "unknown"
+
+	testcases := []struct {
+		name        string
+		job         *v1alpha1.Job
+		expectedCPU int64
+	}{
+		{
+			name:        "init containers by the max rule",
+			job:         buildJob(nil),
+			expectedCPU: 2 * 500,
+		},
+		{
+			name:        "init containers and the overhead of the runtime class",
+			job:         buildJob(&kata),
+			expectedCPU: 2 * (500 + 50),
+		},
+		{
+			name:        "unknown runtime class",
+			job:         buildJob(&unknown),
+			expectedCPU: 2 * 500,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotMin := jc.calcPGMinResources(tc.job)
+			gotCPU := (*gotMin)[v1.ResourceCPU]
+			if gotCPU.MilliValue() != tc.expectedCPU {
+				t.Errorf("expected cpu %dm, got %v", tc.expectedCPU, gotCPU.String())
+			}
+			if tc.job.Spec.Tasks[0].Template.Spec.Overhead != nil {
+				t.Errorf("expected the job unchanged, got overhead %v", tc.job.Spec.Tasks[0].Template.Spec.Overhead)
+			}
+		})
+	}
+}
+
+func TestShouldUpdateExistingPodGroupOnScale(t *testing.T) {
+	jc := newFakeController()
+	minAvailable := int32(1)
+	job := &v1alpha1.Job{
+		Spec: v1alpha1.JobSpec{
+			MinAvailable: 1,
+			Tasks: []v1alpha1.TaskSpec{{
+				Name:         "worker",
+				Replicas:     2,
+				MinAvailable: &minAvailable,
+				Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{
+					Name: "main",
+					Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+						v1.ResourceCPU: resource.MustParse("100m"),
+					}},
+				}}}},
+			}},
+		},
+	}
+	pg := &scheduling.PodGroup{}
+	if !jc.shouldUpdateExistingPodGroup(pg, job) {
+		t.Fatalf("expected the podgroup updated")
+	}
+	if jc.shouldUpdateExistingPodGroup(pg, job) {
+		t.Fatalf("expected the podgroup unchanged")
+	}
+
+	// scale up the task and its min available
+	scaled := int32(3)
+	job.Spec.MinAvailable = 3
+	job.Spec.Tasks[0].Replicas = 4
+	job.Spec.Tasks[0].MinAvailable = &scaled
+	if !jc.shouldUpdateExistingPodGroup(pg, job) {
+		t.Fatalf("expected the podgroup updated after the scale")
+	}
+	gotCPU := (*pg.Spec.MinResources)[v1.ResourceCPU]
+	if pg.Spec.MinMember != 3 || pg.Spec.MinTaskMember["worker"] != 3 || gotCPU.MilliValue() != 300 {
+		t.Errorf("expected min member 3, min task member 3 and min cpu 300m, got %d, %d and %s",
+			pg.Spec.MinMember, pg.Spec.MinTaskMember["worker"], gotCPU.String())
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/apis/helpers"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+// createVolumeClaimsOfPod creates the PVCs of the pod from the volume claim templates of its task before the pod is
+// created. The PVCs are owned by the job, so that they are kept across the restarts of the pod and garbage collected
+// with the job. The PVCs of WaitForFirstConsumer storage classes are bound by the scheduler once the gang of the pod
+// is allocated.
+func (cc *jobcontroller) createVolumeClaimsOfPod(job *batch.Job, template *v1.PodTemplateSpec, pod *v1.Pod) error {
+	claims, err := jobhelpers.GetVolumeClaimTemplates(template)
+	if err != nil {
+		return err
+	}
+	for _, claim := range claims {
+		name := jobhelpers.VolumeClaimName(claim.Name, pod.Name)
+		if exist, err := cc.checkPVCExist(job, name); err != nil {
+			return err
+		} else if exist {
+			continue
+		}
+
+		pvc := claim.DeepCopy()
+		pvc.ObjectMeta = metav1.ObjectMeta{
+			Namespace: job.Namespace,
+			Name:      name,
+			Labels:    claim.Labels,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(job, helpers.JobKind),
+			},
+		}
+		if pvc.Labels == nil {
+			pvc.Labels = map[string]string{}
+		}
+		pvc.Labels[batch.JobNameKey] = job.Name
+		pvc.Labels[batch.TaskSpecKey] = template.Name
+		pvc.Annotations = claim.Annotations
+		pvc.Status = v1.PersistentVolumeClaimStatus{}
+
+		klog.V(3).Infof("Create PVC %s of pod %s for Job <%s/%s>", name, pod.Name, job.Namespace, job.Name)
+		if _, err := cc.kubeClient.CoreV1().PersistentVolumeClaims(job.Namespace).Create(context.TODO(), pvc, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create PVC %s of pod %s, err: %v", name, pod.Name, err)
+		}
+	}
+	return nil
+}
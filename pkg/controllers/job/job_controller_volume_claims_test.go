@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+func TestCreateVolumeClaimsOfPod(t *testing.T) {
+	fakeController := newFakeController()
+	template := v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "worker",
+			Annotations: map[string]string{jobhelpers.VolumeClaimTemplatesAnnotation: `[{"metadata": {"name": "data"},
+				"spec": {"accessModes": ["ReadWriteOnce"], "resources": {"requests": {"storage": "1Gi"}}}}]`},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "main", VolumeMounts: []v1.VolumeMount{{Name: "data", MountPath: "/data"}}}}},
+	}
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "default", UID: "uid-1"},
+		Spec:       batch.JobSpec{Tasks: []batch.TaskSpec{{Name: "worker", Replicas: 1, Template: template}}},
+	}
+
+	pod := createJobPod(job, &template, "", 0, false)
+	if _, found := pod.Annotations[jobhelpers.VolumeClaimTemplatesAnnotation]; found {
+		t.Errorf("expected volume claim templates annotation removed from pod, got %v", pod.Annotations)
+	}
+	if len(pod.Spec.Volumes) != 1 || pod.Spec.Volumes[0].PersistentVolumeClaim == nil ||
+		pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName != "data-job1-worker-0" {
+		t.Fatalf("expected pod to mount PVC data-job1-worker-0, got %v", pod.Spec.Volumes)
+	}
+
+	if err := fakeController.createVolumeClaimsOfPod(job, &template, pod); err != nil {
+		t.Fatalf("failed to create volume claims: %v", err)
+	}
+	pvc, err := fakeController.kubeClient.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "data-job1-worker-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get PVC: %v", err)
+	}
+	if len(pvc.OwnerReferences) != 1 || pvc.OwnerReferences[0].UID != job.UID {
+		t.Errorf("expected PVC owned by job, got %v", pvc.OwnerReferences)
+	}
+	if pvc.Labels[batch.JobNameKey] != "job1" || pvc.Labels[batch.TaskSpecKey] != "worker" {
+		t.Errorf("expected PVC labeled with job and task, got %v", pvc.Labels)
+	}
+
+	// The PVC is kept when the pod is recreated.
+	if err := fakeController.createVolumeClaimsOfPod(job, &template, pod); err != nil {
+		t.Errorf("failed to create volume claims again: %v", err)
+	}
+}
@@ -18,12 +18,14 @@ package mpi
 
 import (
 	"flag"
+	"fmt"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	apishelpers "volcano.sh/apis/pkg/apis/helpers"
 
 	"volcano.sh/volcano/pkg/controllers/job/helpers"
 	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
@@ -40,6 +42,22 @@ const (
 	DefaultWorker = "worker"
 	// MPIHost is the environment variable key of MPI host
 	MPIHost = "MPI_HOST"
+	// MPIHostFile is the environment variable key of the hostfile path
+	MPIHostFile = "MPI_HOSTFILE"
+
+	// FlavorOpenMPI bootstraps the job with OpenMPI
+	FlavorOpenMPI = "openmpi"
+	// FlavorIntelMPI bootstraps the job with the hydra process manager of Intel MPI
+	FlavorIntelMPI = "intelmpi"
+	// FlavorMPICH bootstraps the job with the hydra process manager of MPICH
+	FlavorMPICH = "mpich"
+	// DefaultFlavor is the default MPI implementation
+	DefaultFlavor = FlavorOpenMPI
+
+	// HostFileMountPath is the path where the hostfile is mounted in master pods
+	HostFileMountPath = "/etc/mpi"
+	// HostFileKey is the key of the hostfile in the ConfigMap
+	HostFileKey = "hostfile"
 )
 
 type Plugin struct {
@@ -48,6 +66,9 @@ type Plugin struct {
 	masterName   string
 	workerName   string
 	port         int
+	flavor       string
+	slots        int
+	waitWorkers  bool
 }
 
 // New creates mpi plugin.
@@ -68,9 +89,24 @@ func (mp *Plugin) addFlags() {
 	flagSet.StringVar(&mp.masterName, "master", DefaultMaster, "name of master role task")
 	flagSet.StringVar(&mp.workerName, "worker", DefaultWorker, "name of worker role task")
 	flagSet.IntVar(&mp.port, "port", DefaultPort, "open port for containers")
+	flagSet.StringVar(&mp.flavor, "flavor", DefaultFlavor, "MPI implementation of the job, one of openmpi, intelmpi and mpich")
+	flagSet.IntVar(&mp.slots, "slots", 0, "number of slots of each worker in the hostfile, not set if zero")
+	flagSet.BoolVar(&mp.waitWorkers, "wait-workers", false, "create the master pod after all worker pods are ready")
 	if err := flagSet.Parse(mp.mpiArguments); err != nil {
 		klog.Errorf("plugin %s flagset parse failed, err: %v", mp.Name(), err)
 	}
+	if !IsSupportedFlavor(mp.flavor) {
+		klog.Errorf("plugin %s got unsupported flavor %s, fall back to %s", mp.Name(), mp.flavor, DefaultFlavor)
+	}
+}
+
+// IsSupportedFlavor returns whether the MPI implementation is supported by the plugin.
+func IsSupportedFlavor(flavor string) bool {
+	switch flavor {
+	case FlavorOpenMPI, FlavorIntelMPI, FlavorMPICH:
+		return true
+	}
+	return false
 }
 
 func (mp *Plugin) Name() string {
@@ -79,73 +115,125 @@ func (mp *Plugin) Name() string {
 
 func (mp *Plugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
 	isMaster := false
-	workerHosts := ""
-	env := v1.EnvVar{}
+	var envs []v1.EnvVar
 	if helpers.GetTaskKey(pod) == mp.masterName {
 		taskIndex := helpers.GetTaskIndexUnderJob(mp.workerName, job)
 		if taskIndex == -1 {
 			return nil
 		}
-		workerHosts = mp.generateTaskHosts(job.Spec.Tasks[taskIndex], job.Name)
-		env = v1.EnvVar{
-			Name:  MPIHost,
-			Value: workerHosts,
-		}
+		workerHosts := strings.Join(mp.generateTaskHosts(job.Spec.Tasks[taskIndex], job), ",")
+		envs = append([]v1.EnvVar{{Name: MPIHost, Value: workerHosts}}, mp.bootstrapEnvs()...)
 
 		isMaster = true
 	}
 
-	// open port for ssh and add MPI_HOST env for master task
+	// open port for ssh and add MPI_HOST and bootstrap envs for master task
 	for index, ic := range pod.Spec.InitContainers {
 		mp.openContainerPort(&ic, index, pod, true)
 		if isMaster {
-			pod.Spec.InitContainers[index].Env = append(pod.Spec.InitContainers[index].Env, env)
+			pod.Spec.InitContainers[index].Env = append(pod.Spec.InitContainers[index].Env, envs...)
 		}
 	}
 
 	for index, c := range pod.Spec.Containers {
 		mp.openContainerPort(&c, index, pod, false)
 		if isMaster {
-			pod.Spec.Containers[index].Env = append(pod.Spec.Containers[index].Env, env)
+			pod.Spec.Containers[index].Env = append(pod.Spec.Containers[index].Env, envs...)
 		}
 	}
 
+	if isMaster {
+		mp.mountHostFile(pod, job)
+	}
+
 	return nil
 }
 
-func (mp *Plugin) generateTaskHosts(task batch.TaskSpec, jobName string) string {
-	if task.Replicas == 0 {
-		return ""
+// bootstrapEnvs returns the envs pointing the launcher of the MPI implementation to the hostfile and ssh.
+func (mp *Plugin) bootstrapEnvs() []v1.EnvVar {
+	hostFile := HostFileMountPath + "/" + HostFileKey
+	envs := []v1.EnvVar{{Name: MPIHostFile, Value: hostFile}}
+	sshArgs := ""
+	if mp.port != DefaultPort {
+		sshArgs = fmt.Sprintf("-p %d", mp.port)
 	}
 
-	var builder strings.Builder
-	for i := 0; i < int(task.Replicas); i++ {
-		hostName := task.Template.Spec.Hostname
-		subdomain := task.Template.Spec.Subdomain
-
-		if hostName == "" {
-			hostName = helpers.MakePodName(jobName, task.Name, i)
+	switch mp.flavor {
+	case FlavorIntelMPI:
+		envs = append(envs,
+			v1.EnvVar{Name: "I_MPI_HYDRA_BOOTSTRAP", Value: "ssh"},
+			v1.EnvVar{Name: "I_MPI_HYDRA_HOST_FILE", Value: hostFile})
+		if sshArgs != "" {
+			envs = append(envs, v1.EnvVar{Name: "I_MPI_HYDRA_BOOTSTRAP_EXEC_EXTRA_ARGS", Value: sshArgs})
+		}
+	case FlavorMPICH:
+		envs = append(envs,
+			v1.EnvVar{Name: "HYDRA_LAUNCHER", Value: "ssh"},
+			v1.EnvVar{Name: "HYDRA_HOST_FILE", Value: hostFile})
+		if sshArgs != "" {
+			envs = append(envs, v1.EnvVar{Name: "HYDRA_LAUNCHER_EXTRA_ARGS", Value: sshArgs})
 		}
-		if subdomain == "" {
-			subdomain = jobName
+	default:
+		envs = append(envs, v1.EnvVar{Name: "OMPI_MCA_orte_default_hostfile", Value: hostFile})
+		if sshArgs != "" {
+			envs = append(envs, v1.EnvVar{Name: "OMPI_MCA_plm_rsh_args", Value: sshArgs})
 		}
+	}
+	return envs
+}
 
-		builder.WriteString(hostName)
-		builder.WriteString(".")
-		builder.WriteString(subdomain)
+// generateHostFile generates the hostfile of the worker hosts in the format of the MPI implementation.
+func (mp *Plugin) generateHostFile(job *batch.Job) string {
+	taskIndex := helpers.GetTaskIndexUnderJob(mp.workerName, job)
+	if taskIndex == -1 {
+		return ""
+	}
 
-		if task.Template.Spec.Hostname == "" && i < int(task.Replicas)-1 {
-			builder.WriteString(",")
+	var builder strings.Builder
+	for _, host := range mp.generateTaskHosts(job.Spec.Tasks[taskIndex], job) {
+		builder.WriteString(host)
+		if mp.slots > 0 {
+			switch mp.flavor {
+			case FlavorIntelMPI, FlavorMPICH:
+				builder.WriteString(fmt.Sprintf(":%d", mp.slots))
+			default:
+				builder.WriteString(fmt.Sprintf(" slots=%d", mp.slots))
+			}
 		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
 
-		// If a hostname is explicitly specified, assume only one host is needed.
-		// Break the loop early to avoid generating additional hosts.
-		if task.Template.Spec.Hostname != "" {
-			break
-		}
+func (mp *Plugin) generateTaskHosts(task batch.TaskSpec, job *batch.Job) []string {
+	return helpers.MakeTaskDomainNames(task, job)
+}
+
+func (mp *Plugin) mountHostFile(pod *v1.Pod, job *batch.Job) {
+	cmName := mp.cmName(job)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+		Name: cmName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: cmName},
+			},
+		},
+	})
+
+	vm := v1.VolumeMount{
+		MountPath: HostFileMountPath,
+		Name:      cmName,
 	}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].VolumeMounts = append(pod.Spec.Containers[i].VolumeMounts, vm)
+	}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].VolumeMounts = append(pod.Spec.InitContainers[i].VolumeMounts, vm)
+	}
+}
 
-	return builder.String()
+func (mp *Plugin) cmName(job *batch.Job) string {
+	return fmt.Sprintf("%s-%s", job.Name, mp.Name())
 }
 
 func (mp *Plugin) openContainerPort(c *v1.Container, index int, pod *v1.Pod, isInitContainer bool) {
@@ -173,6 +261,12 @@ func (mp *Plugin) OnJobAdd(job *batch.Job) error {
 	if job.Status.ControlledResources["plugin-"+mp.Name()] == mp.Name() {
 		return nil
 	}
+
+	data := map[string]string{HostFileKey: mp.generateHostFile(job)}
+	if err := helpers.CreateOrUpdateConfigMap(job, mp.clientset.KubeClients, mp.clientset.ResourceLabels, data, mp.cmName(job)); err != nil {
+		return err
+	}
+
 	job.Status.ControlledResources["plugin-"+mp.Name()] = mp.Name()
 	return nil
 }
@@ -181,12 +275,17 @@ func (mp *Plugin) OnJobDelete(job *batch.Job) error {
 	if job.Status.ControlledResources["plugin-"+mp.Name()] != mp.Name() {
 		return nil
 	}
+	if err := apishelpers.DeleteConfigmap(job, mp.clientset.KubeClients, mp.cmName(job)); err != nil {
+		return err
+	}
 	delete(job.Status.ControlledResources, "plugin-"+mp.Name())
 	return nil
 }
 
 func (mp *Plugin) OnJobUpdate(job *batch.Job) error {
-	return nil
+	// updates the hostfile when the workers are scaled
+	data := map[string]string{HostFileKey: mp.generateHostFile(job)}
+	return helpers.CreateOrUpdateConfigMap(job, mp.clientset.KubeClients, mp.clientset.ResourceLabels, data, mp.cmName(job))
 }
 
 func (mp *Plugin) GetMasterName() string {
@@ -200,3 +299,11 @@ func (mp *Plugin) GetWorkerName() string {
 func (mp *Plugin) GetMpiArguments() []string {
 	return mp.mpiArguments
 }
+
+func (mp *Plugin) GetFlavor() string {
+	return mp.flavor
+}
+
+func (mp *Plugin) WaitWorkers() bool {
+	return mp.waitWorkers
+}
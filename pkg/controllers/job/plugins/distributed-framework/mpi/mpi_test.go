@@ -17,10 +17,12 @@ limitations under the License.
 package mpi
 
 import (
+	"context"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
@@ -331,3 +333,122 @@ func checkNoMPIHostEnvVar(t *testing.T, index int, testName string, containers [
 		}
 	}
 }
+
+func buildFlavorJob(arguments []string) *v1alpha1.Job {
+	return &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "job1"},
+		Spec: v1alpha1.JobSpec{
+			Plugins: map[string][]string{MPIPluginName: arguments},
+			Tasks: []v1alpha1.TaskSpec{
+				{Name: "master", Replicas: 1},
+				{Name: "worker", Replicas: 2},
+			},
+		},
+		Status: v1alpha1.JobStatus{ControlledResources: map[string]string{}},
+	}
+}
+
+func TestMpiFlavor(t *testing.T) {
+	testcases := []struct {
+		Name             string
+		Arguments        []string
+		ExpectedEnvs     map[string]string
+		ExpectedHostFile string
+	}{
+		{
+			Name:      "openmpi by default",
+			Arguments: []string{},
+			ExpectedEnvs: map[string]string{
+				MPIHostFile:                      "/etc/mpi/hostfile",
+				"OMPI_MCA_orte_default_hostfile": "/etc/mpi/hostfile",
+			},
+			ExpectedHostFile: "job1-worker-0.job1\njob1-worker-1.job1\n",
+		},
+		{
+			Name:      "openmpi with slots and port",
+			Arguments: []string{"--flavor=openmpi", "--slots=4", "--port=5000"},
+			ExpectedEnvs: map[string]string{
+				"OMPI_MCA_orte_default_hostfile": "/etc/mpi/hostfile",
+				"OMPI_MCA_plm_rsh_args":          "-p 5000",
+			},
+			ExpectedHostFile: "job1-worker-0.job1 slots=4\njob1-worker-1.job1 slots=4\n",
+		},
+		{
+			Name:      "intel mpi",
+			Arguments: []string{"--flavor=intelmpi", "--slots=2"},
+			ExpectedEnvs: map[string]string{
+				"I_MPI_HYDRA_BOOTSTRAP": "ssh",
+				"I_MPI_HYDRA_HOST_FILE": "/etc/mpi/hostfile",
+			},
+			ExpectedHostFile: "job1-worker-0.job1:2\njob1-worker-1.job1:2\n",
+		},
+		{
+			Name:      "mpich",
+			Arguments: []string{"--flavor=mpich", "--port=5000"},
+			ExpectedEnvs: map[string]string{
+				"HYDRA_LAUNCHER":            "ssh",
+				"HYDRA_HOST_FILE":           "/etc/mpi/hostfile",
+				"HYDRA_LAUNCHER_EXTRA_ARGS": "-p 5000",
+			},
+			ExpectedHostFile: "job1-worker-0.job1\njob1-worker-1.job1\n",
+		},
+		{
+			Name:      "unsupported flavor falls back to openmpi",
+			Arguments: []string{"--flavor=unknown"},
+			ExpectedEnvs: map[string]string{
+				"OMPI_MCA_orte_default_hostfile": "/etc/mpi/hostfile",
+			},
+			ExpectedHostFile: "job1-worker-0.job1\njob1-worker-1.job1\n",
+		},
+	}
+
+	for index, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset()
+			mp := New(pluginsinterface.PluginClientset{KubeClients: fakeClient}, testcase.Arguments)
+			job := buildFlavorJob(testcase.Arguments)
+
+			master := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "job1-master-0",
+					Annotations: map[string]string{v1alpha1.TaskSpecKey: "master"},
+				},
+				Spec: v1.PodSpec{Containers: []v1.Container{{Name: "master"}}},
+			}
+			if err := mp.OnPodCreate(master, job); err != nil {
+				t.Fatalf("Case %d (%s): expect no error, but got error %v", index, testcase.Name, err)
+			}
+			envs := map[string]string{}
+			for _, env := range master.Spec.Containers[0].Env {
+				envs[env.Name] = env.Value
+			}
+			for name, value := range testcase.ExpectedEnvs {
+				if envs[name] != value {
+					t.Errorf("Case %d (%s): expect env %s=%s, got %s", index, testcase.Name, name, value, envs[name])
+				}
+			}
+			if len(master.Spec.Volumes) != 1 || len(master.Spec.Containers[0].VolumeMounts) != 1 ||
+				master.Spec.Containers[0].VolumeMounts[0].MountPath != HostFileMountPath {
+				t.Errorf("Case %d (%s): expect hostfile mounted at %s", index, testcase.Name, HostFileMountPath)
+			}
+
+			if err := mp.OnJobAdd(job); err != nil {
+				t.Fatalf("Case %d (%s): OnJobAdd failed: %v", index, testcase.Name, err)
+			}
+			cm, err := fakeClient.CoreV1().ConfigMaps("ns1").Get(context.TODO(), "job1-mpi", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Case %d (%s): hostfile ConfigMap not found: %v", index, testcase.Name, err)
+			}
+			if cm.Data[HostFileKey] != testcase.ExpectedHostFile {
+				t.Errorf("Case %d (%s): expect hostfile %q, got %q", index, testcase.Name, testcase.ExpectedHostFile, cm.Data[HostFileKey])
+			}
+
+			if err := mp.OnJobDelete(job); err != nil {
+				t.Fatalf("Case %d (%s): OnJobDelete failed: %v", index, testcase.Name, err)
+			}
+			if _, err := fakeClient.CoreV1().ConfigMaps("ns1").Get(context.TODO(), "job1-mpi", metav1.GetOptions{}); err == nil {
+				t.Errorf("Case %d (%s): expect hostfile ConfigMap to be deleted", index, testcase.Name)
+			}
+		})
+	}
+}
@@ -87,7 +87,7 @@ func (pp *pytorchPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
 	}
 
 	masterEnvVars := []v1.EnvVar{}
-	masterAddr := pp.generateMasterAddr(job.Spec.Tasks[masterIndex], job.Name)
+	masterAddr := pp.generateMasterAddr(job.Spec.Tasks[masterIndex], job)
 	masterEnvVars = append(masterEnvVars, v1.EnvVar{
 		Name:  EnvMasterAddr,
 		Value: masterAddr,
@@ -144,18 +144,8 @@ func (pp *pytorchPlugin) getTotalReplicas(job *batch.Job) int32 {
 	return jobReplicas
 }
 
-func (pp *pytorchPlugin) generateMasterAddr(task batch.TaskSpec, jobName string) string {
-	hostName := task.Template.Spec.Hostname
-	subdomain := task.Template.Spec.Subdomain
-	if len(hostName) == 0 {
-		hostName = helpers.MakePodName(jobName, task.Name, 0)
-	}
-	if len(subdomain) == 0 {
-		subdomain = jobName
-	}
-
-	host := hostName + "." + subdomain
-	return host
+func (pp *pytorchPlugin) generateMasterAddr(task batch.TaskSpec, job *batch.Job) string {
+	return helpers.MakeDomainName(task, job, 0)
 }
 
 func (pp *pytorchPlugin) openContainerPort(c *v1.Container, index int, pod *v1.Pod) {
@@ -0,0 +1,287 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pytorchelastic
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+const (
+	// PytorchElasticPluginName is the name of the plugin
+	PytorchElasticPluginName = "pytorch-elastic"
+	// DefaultPort is the default port of the c10d rendezvous endpoint
+	DefaultPort = 29400
+	// DefaultMaster is the default task name of the rendezvous host
+	DefaultMaster = "master"
+	// DefaultWorker is the default task name of worker
+	DefaultWorker = "worker"
+	// RendezvousPortName is the port name of the rendezvous endpoint
+	RendezvousPortName = "c10d"
+
+	// EnvRdzvBackend is the env name of rendezvous backend
+	EnvRdzvBackend = "PET_RDZV_BACKEND"
+	// EnvRdzvEndpoint is the env name of rendezvous endpoint
+	EnvRdzvEndpoint = "PET_RDZV_ENDPOINT"
+	// EnvRdzvID is the env name of rendezvous id
+	EnvRdzvID = "PET_RDZV_ID"
+	// EnvNnodes is the env name of the number of nodes, in format `min:max` for elastic jobs
+	EnvNnodes = "PET_NNODES"
+	// EnvNprocPerNode is the env name of the number of processes per node
+	EnvNprocPerNode = "PET_NPROC_PER_NODE"
+
+	rdzvBackendC10d = "c10d"
+)
+
+// pytorchElasticPlugin configures torchrun in the containers to join a c10d rendezvous.
+// Unlike the pytorch plugin, WORLD_SIZE and RANK are assigned by torchrun on each
+// rendezvous round, so the number of nodes is allowed to change as replicas scale.
+type pytorchElasticPlugin struct {
+	pytorchElasticArguments []string
+	clientset               pluginsinterface.PluginClientset
+	masterName              string
+	workerName              string
+	port                    int
+	maxNodes                int
+	nprocPerNode            string
+}
+
+// New creates pytorch elastic plugin.
+func New(client pluginsinterface.PluginClientset, arguments []string) pluginsinterface.PluginInterface {
+	pp := pytorchElasticPlugin{pytorchElasticArguments: arguments, clientset: client}
+	pp.addFlags()
+	return &pp
+}
+
+func (pp *pytorchElasticPlugin) addFlags() {
+	flagSet := flag.NewFlagSet(pp.Name(), flag.ContinueOnError)
+	flagSet.StringVar(&pp.masterName, "master", DefaultMaster, "name of the task hosting the rendezvous, worker task is used if not found")
+	flagSet.StringVar(&pp.workerName, "worker", DefaultWorker, "name of worker role task")
+	flagSet.IntVar(&pp.port, "port", DefaultPort, "port of the rendezvous endpoint")
+	flagSet.IntVar(&pp.maxNodes, "max-nodes", 0, "maximum number of nodes allowed to join the rendezvous, defaults to the total replicas")
+	flagSet.StringVar(&pp.nprocPerNode, "nproc-per-node", "", "number of processes per node, not set by default")
+	if err := flagSet.Parse(pp.pytorchElasticArguments); err != nil {
+		klog.Errorf("plugin %s flagset parse failed, err: %v", pp.Name(), err)
+	}
+}
+
+func (pp *pytorchElasticPlugin) Name() string {
+	return PytorchElasticPluginName
+}
+
+func (pp *pytorchElasticPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
+	taskName := jobhelpers.GetTaskKey(pod)
+	if taskName != pp.masterName && taskName != pp.workerName {
+		return nil
+	}
+
+	hostTask := pp.rendezvousHostTask(job)
+	if hostTask == "" {
+		return fmt.Errorf("job %v doesn't have task %v or %v", job.Name, pp.masterName, pp.workerName)
+	}
+
+	minNodes, maxNodes := pp.getNodesRange(job)
+	envVars := []v1.EnvVar{
+		{Name: EnvRdzvBackend, Value: rdzvBackendC10d},
+		{Name: EnvRdzvEndpoint, Value: fmt.Sprintf("%s:%d", pp.serviceName(job), pp.port)},
+		{Name: EnvRdzvID, Value: pp.rendezvousID(job)},
+		{Name: EnvNnodes, Value: formatNodesRange(minNodes, maxNodes)},
+	}
+	if pp.nprocPerNode != "" {
+		envVars = append(envVars, v1.EnvVar{Name: EnvNprocPerNode, Value: pp.nprocPerNode})
+	}
+
+	isHost := taskName == hostTask && jobhelpers.GetPodIndexUnderTask(pod) == "0"
+	for i := range pod.Spec.Containers {
+		if isHost {
+			pp.openContainerPort(&pod.Spec.Containers[i])
+		}
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, envVars...)
+	}
+
+	return nil
+}
+
+// rendezvousHostTask returns the task whose first pod hosts the c10d store.
+func (pp *pytorchElasticPlugin) rendezvousHostTask(job *batch.Job) string {
+	if jobhelpers.GetTaskIndexUnderJob(pp.masterName, job) != -1 {
+		return pp.masterName
+	}
+	if jobhelpers.GetTaskIndexUnderJob(pp.workerName, job) != -1 {
+		return pp.workerName
+	}
+	return ""
+}
+
+// getNodesRange returns the minimal and maximal number of nodes of the rendezvous, the minimal
+// number follows minAvailable of tasks, and the maximal number follows the current replicas.
+func (pp *pytorchElasticPlugin) getNodesRange(job *batch.Job) (int32, int32) {
+	var minNodes, maxNodes int32
+	for _, task := range job.Spec.Tasks {
+		if task.Name != pp.masterName && task.Name != pp.workerName {
+			continue
+		}
+		maxNodes += task.Replicas
+		if task.MinAvailable != nil {
+			minNodes += *task.MinAvailable
+		} else {
+			minNodes += task.Replicas
+		}
+	}
+
+	if minNodes > maxNodes {
+		minNodes = maxNodes
+	}
+	if int32(pp.maxNodes) > maxNodes {
+		maxNodes = int32(pp.maxNodes)
+	}
+	return minNodes, maxNodes
+}
+
+// ValidateMaxNodes checks that the max-nodes argument of the plugin, if set, is not less than the total
+// replicas of the master and worker tasks, the pods beyond it could never join the rendezvous otherwise.
+func ValidateMaxNodes(arguments []string, job *batch.Job) error {
+	pp := pytorchElasticPlugin{pytorchElasticArguments: arguments}
+	pp.addFlags()
+	if pp.maxNodes < 0 {
+		return fmt.Errorf("'max-nodes' of plugin %s must not be negative", PytorchElasticPluginName)
+	}
+	if pp.maxNodes == 0 {
+		return nil
+	}
+	var replicas int32
+	for _, task := range job.Spec.Tasks {
+		if task.Name == pp.masterName || task.Name == pp.workerName {
+			replicas += task.Replicas
+		}
+	}
+	if int32(pp.maxNodes) < replicas {
+		return fmt.Errorf("'max-nodes' %d of plugin %s must not be less than the total replicas %d of tasks %s and %s",
+			pp.maxNodes, PytorchElasticPluginName, replicas, pp.masterName, pp.workerName)
+	}
+	return nil
+}
+
+func formatNodesRange(minNodes, maxNodes int32) string {
+	if minNodes == maxNodes {
+		return strconv.Itoa(int(maxNodes))
+	}
+	return fmt.Sprintf("%d:%d", minNodes, maxNodes)
+}
+
+// rendezvousID is unique for each incarnation of the job, so that a recreated job does
+// not join the rendezvous of the previous one.
+func (pp *pytorchElasticPlugin) rendezvousID(job *batch.Job) string {
+	if job.UID != "" {
+		return string(job.UID)
+	}
+	return job.Name
+}
+
+func (pp *pytorchElasticPlugin) serviceName(job *batch.Job) string {
+	return job.Name + "-rdzv"
+}
+
+func (pp *pytorchElasticPlugin) openContainerPort(c *v1.Container) {
+	for _, p := range c.Ports {
+		if p.ContainerPort == int32(pp.port) {
+			return
+		}
+	}
+
+	c.Ports = append(c.Ports, v1.ContainerPort{
+		Name:          RendezvousPortName,
+		ContainerPort: int32(pp.port),
+	})
+}
+
+func (pp *pytorchElasticPlugin) OnJobAdd(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+pp.Name()] == pp.Name() {
+		return nil
+	}
+
+	if err := pp.createOrUpdateService(job); err != nil {
+		return err
+	}
+
+	job.Status.ControlledResources["plugin-"+pp.Name()] = pp.Name()
+	return nil
+}
+
+func (pp *pytorchElasticPlugin) OnJobDelete(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+pp.Name()] != pp.Name() {
+		return nil
+	}
+
+	if err := pp.clientset.KubeClients.CoreV1().Services(job.Namespace).Delete(context.TODO(), pp.serviceName(job), metav1.DeleteOptions{}); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to delete Service of Job %v/%v: %v", job.Namespace, pp.serviceName(job), err)
+			return err
+		}
+	}
+	delete(job.Status.ControlledResources, "plugin-"+pp.Name())
+	return nil
+}
+
+// OnJobUpdate updates the metadata of the service propagated from the job. The pods created after
+// scaling get the new nodes range, and the running agents re-rendezvous with the new nodes.
+func (pp *pytorchElasticPlugin) OnJobUpdate(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+pp.Name()] != pp.Name() {
+		return nil
+	}
+	return pp.createOrUpdateService(job)
+}
+
+// createOrUpdateService creates a headless service selecting the first pod of the
+// rendezvous host task as the rendezvous endpoint.
+func (pp *pytorchElasticPlugin) createOrUpdateService(job *batch.Job) error {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: job.Namespace, Name: pp.serviceName(job)},
+		Spec: v1.ServiceSpec{
+			ClusterIP: v1.ClusterIPNone,
+			Selector: map[string]string{
+				batch.JobNameKey:      job.Name,
+				batch.JobNamespaceKey: job.Namespace,
+				batch.TaskSpecKey:     pp.rendezvousHostTask(job),
+				batch.TaskIndex:       "0",
+			},
+			// the agents start the rendezvous before the pods become ready
+			PublishNotReadyAddresses: true,
+			Ports: []v1.ServicePort{
+				{
+					Name:       RendezvousPortName,
+					Port:       int32(pp.port),
+					TargetPort: intstr.FromInt32(int32(pp.port)),
+					Protocol:   v1.ProtocolTCP,
+				},
+			},
+		},
+	}
+	return jobhelpers.CreateOrUpdateService(job, pp.clientset.KubeClients, pp.clientset.ResourceLabels, svc)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pytorchelastic
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+func buildPod(name, task string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{v1alpha1.TaskSpecKey: task},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "trainer"}}},
+	}
+}
+
+func getEnv(container v1.Container, name string) (string, bool) {
+	for _, env := range container.Env {
+		if env.Name == name {
+			return env.Value, true
+		}
+	}
+	return "", false
+}
+
+func TestOnPodCreate(t *testing.T) {
+	testcases := []struct {
+		name         string
+		args         []string
+		tasks        []v1alpha1.TaskSpec
+		pod          *v1.Pod
+		expectedEnvs map[string]string
+		expectedPort bool
+	}{
+		{
+			name: "master pod hosts the rendezvous",
+			tasks: []v1alpha1.TaskSpec{
+				{Name: "master", Replicas: 1},
+				{Name: "worker", Replicas: 3, MinAvailable: ptr.To[int32](1)},
+			},
+			pod: buildPod("job-master-0", "master"),
+			expectedEnvs: map[string]string{
+				EnvRdzvBackend:  "c10d",
+				EnvRdzvEndpoint: "job-rdzv:29400",
+				EnvRdzvID:       "job-uid",
+				EnvNnodes:       "2:4",
+			},
+			expectedPort: true,
+		},
+		{
+			name: "worker pod joins the rendezvous",
+			args: []string{"--port=1234", "--max-nodes=8", "--nproc-per-node=4"},
+			tasks: []v1alpha1.TaskSpec{
+				{Name: "master", Replicas: 1},
+				{Name: "worker", Replicas: 3, MinAvailable: ptr.To[int32](1)},
+			},
+			pod: buildPod("job-worker-0", "worker"),
+			expectedEnvs: map[string]string{
+				EnvRdzvEndpoint: "job-rdzv:1234",
+				EnvNnodes:       "2:8",
+				EnvNprocPerNode: "4",
+			},
+		},
+		{
+			name: "first worker hosts the rendezvous without master",
+			tasks: []v1alpha1.TaskSpec{
+				{Name: "worker", Replicas: 2},
+			},
+			pod: buildPod("job-worker-0", "worker"),
+			expectedEnvs: map[string]string{
+				EnvNnodes: "2",
+			},
+			expectedPort: true,
+		},
+		{
+			name: "other tasks are not touched",
+			tasks: []v1alpha1.TaskSpec{
+				{Name: "worker", Replicas: 2},
+				{Name: "evaluator", Replicas: 1},
+			},
+			pod: buildPod("job-evaluator-0", "evaluator"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "job", UID: "job-uid"},
+				Spec:       v1alpha1.JobSpec{Tasks: tc.tasks},
+			}
+			pp := New(pluginsinterface.PluginClientset{}, tc.args)
+			if err := pp.OnPodCreate(tc.pod, job); err != nil {
+				t.Fatalf("expect no error, but got %v", err)
+			}
+
+			container := tc.pod.Spec.Containers[0]
+			if len(tc.expectedEnvs) == 0 && len(container.Env) != 0 {
+				t.Errorf("expect no env, but got %v", container.Env)
+			}
+			for name, expected := range tc.expectedEnvs {
+				if value, _ := getEnv(container, name); value != expected {
+					t.Errorf("expect env %s=%s, but got %s", name, expected, value)
+				}
+			}
+			if hasPort := len(container.Ports) > 0; hasPort != tc.expectedPort {
+				t.Errorf("expect rendezvous port opened %v, but got %v", tc.expectedPort, container.Ports)
+			}
+		})
+	}
+}
+
+func TestOnJobAddAndDelete(t *testing.T) {
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "default"},
+		Spec: v1alpha1.JobSpec{
+			Tasks: []v1alpha1.TaskSpec{{Name: "worker", Replicas: 2}},
+		},
+		Status: v1alpha1.JobStatus{ControlledResources: map[string]string{}},
+	}
+	fakeClient := fake.NewSimpleClientset()
+	pp := New(pluginsinterface.PluginClientset{KubeClients: fakeClient}, nil)
+
+	if err := pp.OnJobAdd(job); err != nil {
+		t.Fatalf("OnJobAdd failed: %v", err)
+	}
+	svc, err := fakeClient.CoreV1().Services("default").Get(context.TODO(), "job-rdzv", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Service not found: %v", err)
+	}
+	if svc.Spec.ClusterIP != v1.ClusterIPNone {
+		t.Errorf("expect headless service, but got cluster ip %s", svc.Spec.ClusterIP)
+	}
+	if svc.Spec.Selector[v1alpha1.TaskSpecKey] != "worker" || svc.Spec.Selector[v1alpha1.TaskIndex] != "0" {
+		t.Errorf("expect service selecting the first worker, but got %v", svc.Spec.Selector)
+	}
+	if job.Status.ControlledResources["plugin-"+pp.Name()] != pp.Name() {
+		t.Errorf("ControlledResources not updated: %v", job.Status.ControlledResources)
+	}
+
+	if err := pp.OnJobDelete(job); err != nil {
+		t.Fatalf("OnJobDelete failed: %v", err)
+	}
+	if _, err := fakeClient.CoreV1().Services("default").Get(context.TODO(), "job-rdzv", metav1.GetOptions{}); err == nil {
+		t.Errorf("expect service to be deleted")
+	}
+	if _, ok := job.Status.ControlledResources["plugin-"+pp.Name()]; ok {
+		t.Errorf("expect ControlledResources entry to be deleted")
+	}
+}
+
+func TestValidateMaxNodes(t *testing.T) {
+	tasks := []v1alpha1.TaskSpec{
+		{Name: "master", Replicas: 1},
+		{Name: "worker", Replicas: 3},
+		{Name: "evaluator", Replicas: 2},
+	}
+	testcases := []struct {
+		name      string
+		args      []string
+		expectErr bool
+	}{
+		{name: "max nodes not set"},
+		{name: "max nodes equal to replicas", args: []string{"--max-nodes=4"}},
+		{name: "max nodes larger than replicas", args: []string{"--max-nodes=8"}},
+		{name: "max nodes less than replicas", args: []string{"--max-nodes=3"}, expectErr: true},
+		{name: "negative max nodes", args: []string{"--max-nodes=-1"}, expectErr: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &v1alpha1.Job{Spec: v1alpha1.JobSpec{Tasks: tasks}}
+			if err := ValidateMaxNodes(tc.args, job); (err != nil) != tc.expectErr {
+				t.Errorf("expected error %v, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
@@ -28,7 +28,6 @@ import (
 	"k8s.io/klog/v2"
 
 	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
-	"volcano.sh/apis/pkg/apis/helpers"
 	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
 	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
 )
@@ -56,6 +55,11 @@ const (
 	DashboardPortName = "dashboard"
 	// ClientServerPortName is the port name for a ray client api
 	ClientServerPortName = "client-server"
+	// HeadServiceEnv is the env of the name of the head node Service, injected into all the containers of the job.
+	HeadServiceEnv = "RAY_HEAD_SERVICE"
+	// AddressEnv is the env of the address of the ray job submission server, injected into the containers of the
+	// tasks other than head and worker, e.g. a submitter task running `ray job submit`.
+	AddressEnv = "RAY_ADDRESS"
 )
 
 type rayPlugin struct {
@@ -68,6 +72,8 @@ type rayPlugin struct {
 	port                int
 	dashboardPort       int
 	clientPort          int
+	headArgs            string
+	workerArgs          string
 }
 
 // New creates ray plugin.
@@ -86,6 +92,8 @@ func (rp *rayPlugin) addFlags() {
 	flagSet.IntVar(&rp.port, "port", DefaultPort, "The port for GCS")
 	flagSet.IntVar(&rp.dashboardPort, "dashboardPort", DefaultDashboardPort, "The port for the Ray dashboard")
 	flagSet.IntVar(&rp.clientPort, "clientPort", DefaultClientPort, "The port for the Ray client server")
+	flagSet.StringVar(&rp.headArgs, "headArgs", "", "The extra arguments of `ray start` in a head task pod, e.g. --num-cpus=0")
+	flagSet.StringVar(&rp.workerArgs, "workerArgs", "", "The extra arguments of `ray start` in a worker task pod, e.g. --num-gpus=1")
 	if err := flagSet.Parse(rp.rayArguments); err != nil {
 		klog.Errorf("plugin %s flagset parse failed, err: %v", rp.Name(), err)
 	}
@@ -111,37 +119,74 @@ func (rp *rayPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
 			var headCommand []string
 			headCommand = append(headCommand, "sh")
 			headCommand = append(headCommand, "-c")
-			headCommand = append(headCommand, fmt.Sprintf("ray start --head --block --dashboard-host=0.0.0.0 --port=%v --dashboard-port=%v --ray-client-server-port=%v", rp.port, rp.dashboardPort, rp.clientPort))
+			headCommand = append(headCommand, withArgs(fmt.Sprintf("ray start --head --block --dashboard-host=0.0.0.0 --port=%v --dashboard-port=%v --ray-client-server-port=%v", rp.port, rp.dashboardPort, rp.clientPort), rp.headArgs))
 			pod.Spec.Containers[i].Command = headCommand
 		}
 
 		if taskSpec == rp.workerName && c.Name == rp.workerContainerName {
-			headAddr := rp.generateHeadAddr(job.Spec.Tasks[headIndex], job.Name)
+			headAddr := rp.generateHeadAddr(job.Spec.Tasks[headIndex], job)
 			headEndpoint := fmt.Sprintf("%v:%v", headAddr, rp.port)
 			var workerCommand []string
 			workerCommand = append(workerCommand, "sh")
 			workerCommand = append(workerCommand, "-c")
-			workerCommand = append(workerCommand, fmt.Sprintf("ray start --block --address=%v", headEndpoint))
+			workerCommand = append(workerCommand, withArgs(fmt.Sprintf("ray start --block --address=%v", headEndpoint), rp.workerArgs))
 			pod.Spec.Containers[i].Command = workerCommand
 		}
 	}
 
+	rp.mountEnvs(pod, taskSpec, job)
 	return nil
 }
 
-func (rp *rayPlugin) generateHeadAddr(task batch.TaskSpec, jobName string) string {
-	hostName := task.Template.Spec.Hostname
-	subdomain := task.Template.Spec.Subdomain
-	// If hostname does not exist, pod name[metadata.name] replace it.
-	if hostName == "" {
-		hostName = jobhelpers.MakePodName(jobName, task.Name, 0)
+// withArgs appends the extra arguments to the command.
+func withArgs(command, args string) string {
+	if args == "" {
+		return command
 	}
-	if subdomain == "" {
-		subdomain = jobName
+	return command + " " + args
+}
+
+// mountEnvs injects the name of the head node Service into all the containers of the pod, and the address of the
+// job submission server into the containers of the tasks other than head and worker, so that a submitter task can
+// run `ray job submit` against the cluster of the job. The envs set by users are kept.
+func (rp *rayPlugin) mountEnvs(pod *v1.Pod, taskSpec string, job *batch.Job) {
+	envs := []v1.EnvVar{{Name: HeadServiceEnv, Value: getHeadServiceName(job)}}
+	if taskSpec != rp.headName && taskSpec != rp.workerName {
+		envs = append(envs, v1.EnvVar{
+			Name:  AddressEnv,
+			Value: fmt.Sprintf("http://%s:%d", getHeadServiceName(job), rp.dashboardPort),
+		})
+	}
+
+	addEnvs := func(containers []v1.Container) {
+		for i := range containers {
+			for _, env := range envs {
+				if !hasEnv(containers[i].Env, env.Name) {
+					containers[i].Env = append(containers[i].Env, env)
+				}
+			}
+		}
+	}
+	addEnvs(pod.Spec.InitContainers)
+	addEnvs(pod.Spec.Containers)
+}
+
+func hasEnv(envs []v1.EnvVar, name string) bool {
+	for _, env := range envs {
+		if env.Name == name {
+			return true
+		}
 	}
+	return false
+}
 
-	host := hostName + "." + subdomain
-	return host
+// getHeadServiceName returns the name of the Service of the head node.
+func getHeadServiceName(job *batch.Job) string {
+	return job.Name + "-head-svc"
+}
+
+func (rp *rayPlugin) generateHeadAddr(task batch.TaskSpec, job *batch.Job) string {
+	return jobhelpers.MakeDomainName(task, job, 0)
 }
 
 func (rp *rayPlugin) openHeadContainerPort(c *v1.Container, index int, pod *v1.Pod) {
@@ -198,7 +243,7 @@ func (rp *rayPlugin) OnJobAdd(job *batch.Job) error {
 	}
 
 	// When the Volcano Job is created, also create a Service for the head node
-	if err := rp.createServiceIfNotExist(job); err != nil {
+	if err := rp.createOrUpdateService(job); err != nil {
 		return err
 	}
 
@@ -212,7 +257,7 @@ func (rp *rayPlugin) OnJobDelete(job *batch.Job) error {
 	}
 
 	// When OnJobDelete is called, the head node Service is deleted
-	headServiceName := job.Name + "-head-svc"
+	headServiceName := getHeadServiceName(job)
 	if err := rp.clientset.KubeClients.CoreV1().Services(job.Namespace).Delete(context.TODO(), headServiceName, metav1.DeleteOptions{}); err != nil {
 		if !apierrors.IsNotFound(err) {
 			klog.Errorf("Failed to delete Service of Job %v/%v: %v", job.Namespace, headServiceName, err)
@@ -223,62 +268,44 @@ func (rp *rayPlugin) OnJobDelete(job *batch.Job) error {
 	return nil
 }
 
+// OnJobUpdate updates the metadata of the head node Service propagated from the job.
 func (rp *rayPlugin) OnJobUpdate(job *batch.Job) error {
-	return nil
+	if job.Status.ControlledResources["plugin-"+rp.Name()] != rp.Name() {
+		return nil
+	}
+	return rp.createOrUpdateService(job)
 }
 
-func (rp *rayPlugin) createServiceIfNotExist(job *batch.Job) error {
-	// If Service does not exist, create one for Job.
-	headServiceName := job.Name + "-head-svc"
-	if _, err := rp.clientset.KubeClients.CoreV1().Services(job.Namespace).Get(context.TODO(), headServiceName, metav1.GetOptions{}); err != nil {
-		if !apierrors.IsNotFound(err) {
-			klog.V(3).Infof("Failed to get Service for Job <%s/%s>: %v",
-				job.Namespace, job.Name, err)
-			return err
-		}
-
-		svc := &v1.Service{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: job.Namespace,
-				Name:      headServiceName,
-				OwnerReferences: []metav1.OwnerReference{
-					*metav1.NewControllerRef(job, helpers.JobKind),
-				},
+func (rp *rayPlugin) createOrUpdateService(job *batch.Job) error {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: job.Namespace, Name: getHeadServiceName(job)},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{
+				batch.JobNameKey:      job.Name,
+				batch.JobNamespaceKey: job.Namespace,
+				batch.TaskSpecKey:     rp.headName,
 			},
-			Spec: v1.ServiceSpec{
-				Selector: map[string]string{
-					batch.JobNameKey:      job.Name,
-					batch.JobNamespaceKey: job.Namespace,
-					batch.TaskSpecKey:     rp.headName,
+			Ports: []v1.ServicePort{
+				{
+					Name:       GcsPortName,
+					Port:       int32(rp.port),
+					TargetPort: intstr.FromString(GcsPortName),
+					Protocol:   v1.ProtocolTCP,
 				},
-				Ports: []v1.ServicePort{
-					{
-						Name:       GcsPortName,
-						Port:       int32(rp.port),
-						TargetPort: intstr.FromString(GcsPortName),
-						Protocol:   v1.ProtocolTCP,
-					},
-					{
-						Name:       DashboardPortName,
-						Port:       int32(rp.dashboardPort),
-						TargetPort: intstr.FromString(DashboardPortName),
-						Protocol:   v1.ProtocolTCP,
-					},
-					{
-						Name:       ClientServerPortName,
-						Port:       int32(rp.clientPort),
-						TargetPort: intstr.FromString(ClientServerPortName),
-						Protocol:   v1.ProtocolTCP,
-					},
+				{
+					Name:       DashboardPortName,
+					Port:       int32(rp.dashboardPort),
+					TargetPort: intstr.FromString(DashboardPortName),
+					Protocol:   v1.ProtocolTCP,
+				},
+				{
+					Name:       ClientServerPortName,
+					Port:       int32(rp.clientPort),
+					TargetPort: intstr.FromString(ClientServerPortName),
+					Protocol:   v1.ProtocolTCP,
 				},
 			},
-		}
-
-		if _, e := rp.clientset.KubeClients.CoreV1().Services(job.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{}); e != nil {
-			klog.V(3).Infof("Failed to create Service for Job <%s/%s>: %v", job.Namespace, headServiceName, e)
-			return e
-		}
+		},
 	}
-
-	return nil
+	return jobhelpers.CreateOrUpdateService(job, rp.clientset.KubeClients, rp.clientset.ResourceLabels, svc)
 }
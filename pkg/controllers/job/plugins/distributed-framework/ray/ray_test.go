@@ -199,3 +199,77 @@ func TestRayPlugin(t *testing.T) {
 		})
 	}
 }
+
+func TestRayPluginEnvsAndArgs(t *testing.T) {
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "ray-job"},
+		Spec: v1alpha1.JobSpec{
+			Tasks: []v1alpha1.TaskSpec{
+				{Name: DefaultHead, Replicas: 1},
+				{Name: DefaultWorker, Replicas: 2},
+				{Name: "submitter", Replicas: 1},
+			},
+		},
+	}
+	buildPod := func(task, container string, envs ...v1.EnvVar) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{v1alpha1.TaskSpecKey: task}},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: container, Env: envs}}},
+		}
+	}
+	envValue := func(pod *v1.Pod, name string) (string, bool) {
+		for _, env := range pod.Spec.Containers[0].Env {
+			if env.Name == name {
+				return env.Value, true
+			}
+		}
+		return "", false
+	}
+
+	testcases := []struct {
+		name            string
+		pod             *v1.Pod
+		expectedCommand string
+		expectedAddress string
+	}{
+		{
+			name:            "head",
+			pod:             buildPod(DefaultHead, DefaultHeadContainer),
+			expectedCommand: "ray start --head --block --dashboard-host=0.0.0.0 --port=6379 --dashboard-port=8265 --ray-client-server-port=10001 --num-cpus=0",
+		},
+		{
+			name:            "worker",
+			pod:             buildPod(DefaultWorker, DefaultWorkerContainer),
+			expectedCommand: "ray start --block --address=ray-job-head-0.ray-job:6379 --num-gpus=1",
+		},
+		{
+			name:            "submitter",
+			pod:             buildPod("submitter", "submitter"),
+			expectedAddress: "http://ray-job-head-svc:8265",
+		},
+		{
+			name:            "address set by user",
+			pod:             buildPod("submitter", "submitter", v1.EnvVar{Name: AddressEnv, Value: "http://dashboard:8265"}),
+			expectedAddress: "http://dashboard:8265",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			rp := New(pluginsinterface.PluginClientset{}, []string{"--headArgs=--num-cpus=0", "--workerArgs=--num-gpus=1"})
+			if err := rp.OnPodCreate(tc.pod, job); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if service, _ := envValue(tc.pod, HeadServiceEnv); service != "ray-job-head-svc" {
+				t.Errorf("expected head service env ray-job-head-svc, got %q", service)
+			}
+			if tc.expectedCommand != "" && !slices.Equal(tc.pod.Spec.Containers[0].Command, []string{"sh", "-c", tc.expectedCommand}) {
+				t.Errorf("expected command %q, got %v", tc.expectedCommand, tc.pod.Spec.Containers[0].Command)
+			}
+			address, found := envValue(tc.pod, AddressEnv)
+			if found != (tc.expectedAddress != "") || address != tc.expectedAddress {
+				t.Errorf("expected address env %q, got %q", tc.expectedAddress, address)
+			}
+		})
+	}
+}
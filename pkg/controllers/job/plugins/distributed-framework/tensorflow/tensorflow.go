@@ -20,7 +20,9 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -47,22 +49,58 @@ type tensorflowPlugin struct {
 	chiefName     string
 	evaluatorName string
 	port          int
+	// roles maps the names of the tasks to their roles, in addition to the tasks named by the role flags.
+	roles roleMapping
 }
 
 // New creates tensorflow plugin.
 func New(client pluginsinterface.PluginClientset, arguments []string) pluginsinterface.PluginInterface {
-	tp := tensorflowPlugin{tfArguments: arguments, Clientset: client}
+	tp := tensorflowPlugin{tfArguments: arguments, Clientset: client, roles: roleMapping{}}
 	tp.addFlags()
 	return &tp
 }
 
+// roleMapping is the flag mapping the names of the tasks to their roles, e.g. --role=trainer=worker,master=chief.
+// The flag can be repeated, and several tasks can be mapped to the same role.
+type roleMapping map[string]tfTaskType
+
+func (m roleMapping) String() string {
+	items := make([]string, 0, len(m))
+	for taskName, role := range m {
+		items = append(items, fmt.Sprintf("%s=%s", taskName, role))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+func (m roleMapping) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		taskName, role, found := strings.Cut(item, "=")
+		if !found || taskName == "" {
+			return fmt.Errorf("invalid role mapping %q, expected task=role", item)
+		}
+		switch tfTaskType(role) {
+		case tfPS, tfWorker, tfChief, tfEvaluator:
+			m[taskName] = tfTaskType(role)
+		default:
+			return fmt.Errorf("unknown role %q of task %s, expected one of ps, worker, chief and evaluator", role, taskName)
+		}
+	}
+	return nil
+}
+
 func (tp *tensorflowPlugin) addFlags() {
 	flagSet := flag.NewFlagSet(tp.Name(), flag.ContinueOnError)
 	flagSet.StringVar(&tp.psName, "ps", "ps", "name of ps role task")
-	flagSet.StringVar(&tp.workerName, "worker", "worker", "name of ps role task")
+	flagSet.StringVar(&tp.workerName, "worker", "worker", "name of worker role task")
 	flagSet.StringVar(&tp.chiefName, "chief", "chief", "name of chief role task")
 	flagSet.StringVar(&tp.evaluatorName, "evaluator", "evaluator", "name of evaluator role task")
 	flagSet.IntVar(&tp.port, "port", DefaultPort, "service port")
+	flagSet.Var(tp.roles, "role", "roles of tasks as task=role separated by comma, e.g. trainer=worker,master=chief")
 	if err := flagSet.Parse(tp.tfArguments); err != nil {
 		klog.Errorf("plugin %s flagset parse failed, err: %v", tp.Name(), err)
 	}
@@ -125,46 +163,61 @@ func (tp *tensorflowPlugin) generateTFClusterSpec(pod *v1.Pod, job *batch.Job) (
 		return tfClusterSpec{}, err
 	}
 
-	// Generate tensorflow task info
+	// Generate tensorflow task info, the tasks without role keep their names as the type
+	taskName := jobhelpers.GetTaskKey(pod)
+	taskType, found := tp.getTaskType(taskName)
+	if !found {
+		taskType = tfTaskType(taskName)
+	}
 	c := tfClusterSpec{
 		Task: taskInfo{
-			Type:  tp.getTaskType(jobhelpers.GetTaskKey(pod)),
+			Type:  taskType,
 			Index: index,
 		},
 	}
 
-	// Generate tensorflow cluster info
+	// Generate tensorflow cluster info, the hosts of the tasks with the same role are listed in the order of
+	// the tasks, so the index of the pod is offset by the replicas of the tasks before its task.
+	replicas := map[tfTaskType]int{}
 	for _, ts := range job.Spec.Tasks {
+		role, found := tp.getTaskType(ts.Name)
+		if !found {
+			continue
+		}
+		if ts.Name == taskName {
+			c.Task.Index = replicas[role] + index
+		}
+		replicas[role] += int(ts.Replicas)
+
+		// The evaluator is not a part of the training cluster.
+		if role == tfEvaluator {
+			continue
+		}
 		hosts := []string{}
 		for i := 0; i < int(ts.Replicas); i++ {
 			hosts = append(hosts, fmt.Sprintf("%s:%d", jobhelpers.MakeDomainName(ts, job, i), tp.port))
 		}
-		switch ts.Name {
-		case tp.psName:
-			c.Cluster.PS = hosts
-		case tp.workerName:
-			c.Cluster.Worker = hosts
-		case tp.chiefName:
-			c.Cluster.Chief = hosts
-		case tp.evaluatorName:
-			c.Cluster.Evaluator = hosts
-		}
+		c.Cluster.addHosts(role, hosts)
 	}
 	return c, nil
 }
 
-func (tp *tensorflowPlugin) getTaskType(taskKey string) tfTaskType {
+// getTaskType returns the role of the task, the role mapping takes precedence over the role flags.
+func (tp *tensorflowPlugin) getTaskType(taskKey string) (tfTaskType, bool) {
+	if role, found := tp.roles[taskKey]; found {
+		return role, true
+	}
 	switch taskKey {
 	case tp.chiefName:
-		return tfChief
+		return tfChief, true
 	case tp.workerName:
-		return tfWorker
+		return tfWorker, true
 	case tp.psName:
-		return tfPS
+		return tfPS, true
 	case tp.evaluatorName:
-		return tfEvaluator
+		return tfEvaluator, true
 	}
-	return tfTaskType(taskKey)
+	return "", false
 }
 
 // TfClusterSpec is the spec of a tensorflow cluster
@@ -187,10 +240,20 @@ type tfClusterSpec struct {
 }
 
 type clusterInfo struct {
-	PS        []string `json:"ps,omitempty"`
-	Worker    []string `json:"worker,omitempty"`
-	Chief     []string `json:"chief,omitempty"`
-	Evaluator []string `json:"evaluator,omitempty"`
+	PS     []string `json:"ps,omitempty"`
+	Worker []string `json:"worker,omitempty"`
+	Chief  []string `json:"chief,omitempty"`
+}
+
+func (ci *clusterInfo) addHosts(role tfTaskType, hosts []string) {
+	switch role {
+	case tfPS:
+		ci.PS = append(ci.PS, hosts...)
+	case tfWorker:
+		ci.Worker = append(ci.Worker, hosts...)
+	case tfChief:
+		ci.Chief = append(ci.Chief, hosts...)
+	}
 }
 
 type tfTaskType string
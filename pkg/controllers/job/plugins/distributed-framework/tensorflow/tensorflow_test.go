@@ -17,6 +17,7 @@ limitations under the License.
 package tensorflow
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -141,3 +142,90 @@ func TestTensorflow(t *testing.T) {
 		})
 	}
 }
+
+func TestTensorflowRoles(t *testing.T) {
+	testjob := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "train-123"},
+		Spec: batch.JobSpec{
+			Tasks: []batch.TaskSpec{
+				{Name: "master", Replicas: 1},
+				{Name: "trainer", Replicas: 2},
+				{Name: "trainer-gpu", Replicas: 1},
+				{Name: "evaluator", Replicas: 1},
+				{Name: "tensorboard", Replicas: 1},
+			},
+		},
+	}
+	buildPod := func(taskName string, index int) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("train-123-%s-%d", taskName, index),
+				Annotations: map[string]string{
+					batch.TaskSpecKey: taskName,
+				},
+			},
+			Spec: v1.PodSpec{Containers: []v1.Container{{Name: "main"}}},
+		}
+	}
+	cluster := `"cluster":{"worker":["train-123-trainer-0.train-123:2222","train-123-trainer-1.train-123:2222",` +
+		`"train-123-trainer-gpu-0.train-123:2222"],"chief":["train-123-master-0.train-123:2222"]}`
+
+	testcases := []struct {
+		Name     string
+		Pod      *v1.Pod
+		Expected string
+	}{
+		{
+			Name:     "chief mapped by role",
+			Pod:      buildPod("master", 0),
+			Expected: `{` + cluster + `,"task":{"type":"chief","index":0}}`,
+		},
+		{
+			Name:     "worker of the first task",
+			Pod:      buildPod("trainer", 1),
+			Expected: `{` + cluster + `,"task":{"type":"worker","index":1}}`,
+		},
+		{
+			Name:     "worker of the second task is offset",
+			Pod:      buildPod("trainer-gpu", 0),
+			Expected: `{` + cluster + `,"task":{"type":"worker","index":2}}`,
+		},
+		{
+			Name:     "evaluator is not in the cluster",
+			Pod:      buildPod("evaluator", 0),
+			Expected: `{` + cluster + `,"task":{"type":"evaluator","index":0}}`,
+		},
+		{
+			Name:     "task without role",
+			Pod:      buildPod("tensorboard", 0),
+			Expected: `{` + cluster + `,"task":{"type":"tensorboard","index":0}}`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			tp := New(pluginsinterface.PluginClientset{}, []string{"--role=master=chief,trainer=worker", "--role=trainer-gpu=worker"})
+			if err := tp.OnPodCreate(testcase.Pod, testjob); err != nil {
+				t.Fatalf("expect no error, but got error %v", err)
+			}
+			if got := testcase.Pod.Spec.Containers[0].Env[0].Value; got != testcase.Expected {
+				t.Errorf("expected %s, got %s", testcase.Expected, got)
+			}
+		})
+	}
+}
+
+func TestRoleMapping(t *testing.T) {
+	roles := roleMapping{}
+	if err := roles.Set("master=chief, trainer=worker"); err != nil {
+		t.Fatalf("expect no error, but got error %v", err)
+	}
+	if got := roles.String(); got != "master=chief,trainer=worker" {
+		t.Errorf("expected master=chief,trainer=worker, got %s", got)
+	}
+	for _, value := range []string{"trainer", "=worker", "trainer=master"} {
+		if err := (roleMapping{}).Set(value); err == nil {
+			t.Errorf("expected error of role mapping %q", value)
+		}
+	}
+}
@@ -25,4 +25,28 @@ const (
 
 	// TaskIndex is used as key in container env
 	TaskIndex = "VC_TASK_INDEX"
+
+	// TaskReplicas is the env key of the replicas of the task of the pod
+	TaskReplicas = "VC_TASK_REPLICAS"
+	// JobName is the env key of the name of the job
+	JobName = "VC_JOB_NAME"
+	// Queue is the env key of the queue of the job
+	Queue = "VC_QUEUE"
+	// Rank is the env key of the rank of the pod in the job, the pods are ranked by task in order and then by index
+	Rank = "VC_RANK"
+	// TaskDNSNamesFmt is the env key of the service dns names of the pods of a task, separated by comma
+	TaskDNSNamesFmt = "VC_%s_DNS_NAMES"
+
+	// PlacementMountPath is the mount path of the placement files
+	PlacementMountPath = "/etc/volcano-placement"
+	// PlacementVolumeName is the name of the downward api volume of the placement files
+	PlacementVolumeName = "volcano-placement"
+	// PlacementEnvFile is the file holding the placement env in KEY=VALUE lines
+	PlacementEnvFile = "env"
+	// HostRankFile is the file mapping the ranks to the service dns names of the pods of the job, a "RANK NAME" line each
+	HostRankFile = "hostrank"
+	// PlacementEnvAnnotation is the annotation of the pod projected to the PlacementEnvFile
+	PlacementEnvAnnotation = "volcano.sh/placement-env"
+	// HostRankAnnotation is the annotation of the pod projected to the HostRankFile
+	HostRankAnnotation = "volcano.sh/host-rank"
 )
@@ -17,7 +17,13 @@ limitations under the License.
 package env
 
 import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
 
 	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
@@ -29,12 +35,17 @@ type envPlugin struct {
 	pluginArguments []string
 
 	Clientset pluginsinterface.PluginClientset
+
+	// flag parse args
+	placementFile bool
 }
 
 // New creates env plugin.
 func New(client pluginsinterface.PluginClientset, arguments []string) pluginsinterface.PluginInterface {
 	envPlugin := envPlugin{pluginArguments: arguments, Clientset: client}
 
+	envPlugin.addFlags()
+
 	return &envPlugin
 }
 
@@ -42,6 +53,16 @@ func (ep *envPlugin) Name() string {
 	return "env"
 }
 
+func (ep *envPlugin) addFlags() {
+	flagSet := flag.NewFlagSet(ep.Name(), flag.ContinueOnError)
+	flagSet.BoolVar(&ep.placementFile, "placement-file", ep.placementFile,
+		"mount the placement env and the host rank mapping of the job as files in the pods")
+
+	if err := flagSet.Parse(ep.pluginArguments); err != nil {
+		klog.Errorf("plugin %s flagset parse failed, err: %v", ep.Name(), err)
+	}
+}
+
 func (ep *envPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
 	index := jobhelpers.GetPodIndexUnderTask(pod)
 
@@ -55,9 +76,90 @@ func (ep *envPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
 		pod.Spec.InitContainers[i].Env = append(pod.Spec.InitContainers[i].Env, v1.EnvVar{Name: TaskVkIndex, Value: index}, v1.EnvVar{Name: TaskIndex, Value: index})
 	}
 
+	placementEnv, hostRanks := placement(pod, job)
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, placementEnv...)
+	}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].Env = append(pod.Spec.InitContainers[i].Env, placementEnv...)
+	}
+
+	if ep.placementFile {
+		mountPlacement(pod, placementEnv, hostRanks)
+	}
+
 	return nil
 }
 
+// placement returns the placement env of the pod in the job, and the "RANK NAME" lines mapping the ranks of the pods
+// of the job to their service dns names. The names are resolved by the headless service of the svc plugin.
+func placement(pod *v1.Pod, job *batch.Job) ([]v1.EnvVar, []string) {
+	taskName := jobhelpers.GetTaskKey(pod)
+	index, _ := strconv.Atoi(jobhelpers.GetPodIndexUnderTask(pod))
+	env := []v1.EnvVar{
+		{Name: TaskReplicas, Value: strconv.Itoa(int(jobhelpers.GetTaskReplicasUnderJob(taskName, job)))},
+		{Name: JobName, Value: job.Name},
+		{Name: Queue, Value: job.Spec.Queue},
+	}
+
+	var hostRanks []string
+	rank := 0
+	for _, ts := range job.Spec.Tasks {
+		names := make([]string, 0, ts.Replicas)
+		for i := 0; i < int(ts.Replicas); i++ {
+			if ts.Name == taskName && i == index {
+				env = append(env, v1.EnvVar{Name: Rank, Value: strconv.Itoa(rank)})
+			}
+			name := fmt.Sprintf("%s.%s.svc", jobhelpers.MakeDomainName(ts, job, i), job.Namespace)
+			names = append(names, name)
+			hostRanks = append(hostRanks, fmt.Sprintf("%d %s", rank, name))
+			rank++
+		}
+		key := strings.ToUpper(strings.Replace(ts.Name, "-", "_", -1))
+		env = append(env, v1.EnvVar{Name: fmt.Sprintf(TaskDNSNamesFmt, key), Value: strings.Join(names, ",")})
+	}
+	return env, hostRanks
+}
+
+// mountPlacement mounts the placement env and the host rank mapping as files by a downward api volume projecting the
+// annotations of the pod, so that no configmap is created for the job.
+func mountPlacement(pod *v1.Pod, placementEnv []v1.EnvVar, hostRanks []string) {
+	lines := make([]string, 0, len(placementEnv))
+	for _, env := range placementEnv {
+		lines = append(lines, env.Name+"="+env.Value)
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[PlacementEnvAnnotation] = strings.Join(lines, "\n") + "\n"
+	pod.Annotations[HostRankAnnotation] = strings.Join(hostRanks, "\n") + "\n"
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+		Name: PlacementVolumeName,
+		VolumeSource: v1.VolumeSource{
+			DownwardAPI: &v1.DownwardAPIVolumeSource{
+				Items: []v1.DownwardAPIVolumeFile{
+					{
+						Path:     PlacementEnvFile,
+						FieldRef: &v1.ObjectFieldSelector{FieldPath: fmt.Sprintf("metadata.annotations['%s']", PlacementEnvAnnotation)},
+					},
+					{
+						Path:     HostRankFile,
+						FieldRef: &v1.ObjectFieldSelector{FieldPath: fmt.Sprintf("metadata.annotations['%s']", HostRankAnnotation)},
+					},
+				},
+			},
+		},
+	})
+	mount := v1.VolumeMount{Name: PlacementVolumeName, MountPath: PlacementMountPath, ReadOnly: true}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].VolumeMounts = append(pod.Spec.Containers[i].VolumeMounts, mount)
+	}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].VolumeMounts = append(pod.Spec.InitContainers[i].VolumeMounts, mount)
+	}
+}
+
 func (ep *envPlugin) OnJobAdd(job *batch.Job) error {
 	if job.Status.ControlledResources["plugin-"+ep.Name()] == ep.Name() {
 		return nil
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package env
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+func TestOnPodCreate(t *testing.T) {
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "tf"},
+		Spec: batch.JobSpec{
+			Queue: "q1",
+			Tasks: []batch.TaskSpec{
+				{Name: "ps", Replicas: 1},
+				{Name: "worker-gpu", Replicas: 2},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		params        []string
+		expectedMount bool
+	}{
+		{
+			name: "env only",
+		},
+		{
+			name:          "with placement file",
+			params:        []string{"--placement-file"},
+			expectedMount: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "tf-worker-gpu-1",
+					Annotations: map[string]string{batch.TaskSpecKey: "worker-gpu"},
+				},
+				Spec: v1.PodSpec{Containers: []v1.Container{{Name: "main"}}},
+			}
+			plugin := New(pluginsinterface.PluginClientset{}, tc.params)
+			if err := plugin.OnPodCreate(pod, job); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			env := map[string]string{}
+			for _, e := range pod.Spec.Containers[0].Env {
+				env[e.Name] = e.Value
+			}
+			expectedEnv := map[string]string{
+				TaskIndex:                 "1",
+				TaskReplicas:              "2",
+				JobName:                   "tf",
+				Queue:                     "q1",
+				Rank:                      "2",
+				"VC_PS_DNS_NAMES":         "tf-ps-0.tf.ns1.svc",
+				"VC_WORKER_GPU_DNS_NAMES": "tf-worker-gpu-0.tf.ns1.svc,tf-worker-gpu-1.tf.ns1.svc",
+			}
+			for name, value := range expectedEnv {
+				if env[name] != value {
+					t.Errorf("expected env %s=%s, got %s", name, value, env[name])
+				}
+			}
+
+			mounted := len(pod.Spec.Containers[0].VolumeMounts) == 1 && len(pod.Spec.Volumes) == 1
+			if mounted != tc.expectedMount {
+				t.Fatalf("expected placement mounted %v, got volumes %v", tc.expectedMount, pod.Spec.Volumes)
+			}
+			if !tc.expectedMount {
+				return
+			}
+			expectedHostRanks := "0 tf-ps-0.tf.ns1.svc\n1 tf-worker-gpu-0.tf.ns1.svc\n2 tf-worker-gpu-1.tf.ns1.svc\n"
+			if hostRanks := pod.Annotations[HostRankAnnotation]; hostRanks != expectedHostRanks {
+				t.Errorf("expected host ranks %q, got %q", expectedHostRanks, hostRanks)
+			}
+			if pod.Spec.Containers[0].VolumeMounts[0].MountPath != PlacementMountPath {
+				t.Errorf("expected placement mounted at %s, got %s", PlacementMountPath, pod.Spec.Containers[0].VolumeMounts[0].MountPath)
+			}
+		})
+	}
+}
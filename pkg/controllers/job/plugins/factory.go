@@ -22,6 +22,7 @@ import (
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/hcclrank"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/mpi"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/pytorch"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/pytorchelastic"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/ray"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/tensorflow"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/env"
@@ -39,6 +40,7 @@ func init() {
 	RegisterPluginBuilder("pytorch", pytorch.New)
 	RegisterPluginBuilder("hcclrank", hcclrank.New)
 	RegisterPluginBuilder("ray", ray.New)
+	RegisterPluginBuilder(pytorchelastic.PytorchElasticPluginName, pytorchelastic.New)
 }
 
 var pluginMutex sync.Mutex
@@ -26,6 +26,8 @@ import (
 // PluginClientset clientset.
 type PluginClientset struct {
 	KubeClients kubernetes.Interface
+	// ResourceLabels are the labels added to all the objects created by the plugins, configured cluster-wide.
+	ResourceLabels map[string]string
 }
 
 // PluginInterface interface.
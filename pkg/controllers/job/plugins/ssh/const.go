@@ -34,4 +34,7 @@ const (
 
 	// SSHRelativePath ssh rel path
 	SSHRelativePath = ".ssh"
+
+	// SSHDefaultPort ssh default port
+	SSHDefaultPort = 22
 )
@@ -17,15 +17,20 @@ limitations under the License.
 package ssh
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"flag"
 	"fmt"
+	"path"
+	"strconv"
+	"strings"
 
 	"golang.org/x/crypto/ssh"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
 	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
@@ -48,6 +53,18 @@ type sshPlugin struct {
 
 	// public key string
 	sshPublicKey string
+
+	// name of the secret with the keys provided by user
+	sshSecret string
+
+	// port of sshd in the pods
+	sshPort int
+
+	// user to login the pods
+	sshUser string
+
+	// home directory of the user, the keys are stored in its .ssh directory
+	sshHomeDir string
 }
 
 // New creates ssh plugin
@@ -56,6 +73,7 @@ func New(client pluginsinterface.PluginClientset, arguments []string) pluginsint
 		pluginArguments: arguments,
 		client:          client,
 		sshKeyFilePath:  SSHAbsolutePath,
+		sshPort:         SSHDefaultPort,
 	}
 
 	p.addFlags()
@@ -68,6 +86,11 @@ func (sp *sshPlugin) Name() string {
 }
 
 func (sp *sshPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
+	// sshd based on the mounted keys is not available on windows nodes
+	if jobhelpers.IsWindowsPod(pod) {
+		klog.V(4).Infof("Skip mounting ssh keys to windows pod <%s/%s>", pod.Namespace, pod.Name)
+		return nil
+	}
 	sp.mountRsaKey(pod, job)
 
 	return nil
@@ -80,16 +103,19 @@ func (sp *sshPlugin) OnJobAdd(job *batch.Job) error {
 
 	var data map[string][]byte
 	var err error
-	if len(sp.sshPrivateKey) > 0 {
-		data, err = withUserProvidedRsaKey(job, sp.sshPrivateKey, sp.sshPublicKey)
+	if len(sp.sshSecret) > 0 {
+		data, err = sp.withUserProvidedSecret(job)
+	} else if len(sp.sshPrivateKey) > 0 {
+		data, err = withUserProvidedRsaKey(sp.sshPrivateKey, sp.sshPublicKey)
 	} else {
-		data, err = generateRsaKey(job)
+		data, err = generateRsaKey()
 	}
 	if err != nil {
 		return err
 	}
+	data[SSHConfig] = []byte(sp.generateSSHConfig(job))
 
-	if err := helpers.CreateOrUpdateSecret(job, sp.client.KubeClients, data, sp.secretName(job)); err != nil {
+	if err := jobhelpers.CreateOrUpdateSecret(job, sp.client.KubeClients, sp.client.ResourceLabels, data, sp.secretName(job)); err != nil {
 		return fmt.Errorf("create secret for job <%s/%s> with ssh plugin failed for %v",
 			job.Namespace, job.Name, err)
 	}
@@ -136,6 +162,7 @@ func (sp *sshPlugin) mountRsaKey(pod *v1.Pod, job *batch.Job) {
 	}
 
 	var mode int32 = 0600
+	nonRoot := setFSGroupForNonRoot(pod)
 	sshVolume.Secret = &v1.SecretVolumeSource{
 		SecretName: secretName,
 		Items: []v1.KeyToPath{
@@ -159,7 +186,8 @@ func (sp *sshPlugin) mountRsaKey(pod *v1.Pod, job *batch.Job) {
 		DefaultMode: &mode,
 	}
 
-	if sp.sshKeyFilePath != SSHAbsolutePath {
+	// the keys of non-root pods are readable by the fsGroup, otherwise readable by all users
+	if sp.sshKeyFilePath != SSHAbsolutePath && !nonRoot {
 		var noRootMode int32 = 0644
 		sshVolume.Secret.DefaultMode = &noRootMode
 	}
@@ -186,7 +214,7 @@ func (sp *sshPlugin) mountRsaKey(pod *v1.Pod, job *batch.Job) {
 	}
 }
 
-func generateRsaKey(job *batch.Job) (map[string][]byte, error) {
+func generateRsaKey() (map[string][]byte, error) {
 	bitSize := 2048
 
 	privateKey, err := rsa.GenerateKey(rand.Reader, bitSize)
@@ -214,21 +242,64 @@ func generateRsaKey(job *batch.Job) (map[string][]byte, error) {
 	data[SSHPrivateKey] = privateKeyBytes
 	data[SSHPublicKey] = publicKeyBytes
 	data[SSHAuthorizedKeys] = publicKeyBytes
-	data[SSHConfig] = []byte(generateSSHConfig(job))
 
 	return data, nil
 }
 
-func withUserProvidedRsaKey(job *batch.Job, sshPrivateKey string, sshPublicKey string) (map[string][]byte, error) {
+func withUserProvidedRsaKey(sshPrivateKey string, sshPublicKey string) (map[string][]byte, error) {
 	data := make(map[string][]byte)
 	data[SSHPrivateKey] = []byte(sshPrivateKey)
 	data[SSHPublicKey] = []byte(sshPublicKey)
 	data[SSHAuthorizedKeys] = []byte(sshPublicKey)
-	data[SSHConfig] = []byte(generateSSHConfig(job))
 
 	return data, nil
 }
 
+// withUserProvidedSecret copies the keys in the secret provided by user, which must contain id_rsa and
+// authorized_keys. The public key is derived from the private key if id_rsa.pub is absent.
+func (sp *sshPlugin) withUserProvidedSecret(job *batch.Job) (map[string][]byte, error) {
+	secret, err := sp.client.KubeClients.CoreV1().Secrets(job.Namespace).Get(context.TODO(), sp.sshSecret, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ssh secret <%s/%s>: %v", job.Namespace, sp.sshSecret, err)
+	}
+
+	data := make(map[string][]byte)
+	for _, key := range []string{SSHPrivateKey, SSHAuthorizedKeys} {
+		if len(secret.Data[key]) == 0 {
+			return nil, fmt.Errorf("ssh secret <%s/%s> does not contain %s", job.Namespace, sp.sshSecret, key)
+		}
+		data[key] = secret.Data[key]
+	}
+
+	data[SSHPublicKey] = secret.Data[SSHPublicKey]
+	if len(data[SSHPublicKey]) == 0 {
+		signer, err := ssh.ParsePrivateKey(data[SSHPrivateKey])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s in ssh secret <%s/%s>: %v", SSHPrivateKey, job.Namespace, sp.sshSecret, err)
+		}
+		data[SSHPublicKey] = ssh.MarshalAuthorizedKey(signer.PublicKey())
+	}
+
+	return data, nil
+}
+
+// setFSGroupForNonRoot sets the fsGroup of pods running as non-root user to make the keys readable by the user,
+// it returns whether the pod runs as non-root with a fsGroup.
+func setFSGroupForNonRoot(pod *v1.Pod) bool {
+	sc := pod.Spec.SecurityContext
+	if sc == nil || sc.RunAsUser == nil || *sc.RunAsUser == 0 {
+		return false
+	}
+	if sc.FSGroup == nil {
+		group := *sc.RunAsUser
+		if sc.RunAsGroup != nil {
+			group = *sc.RunAsGroup
+		}
+		sc.FSGroup = &group
+	}
+	return true
+}
+
 func (sp *sshPlugin) secretName(job *batch.Job) string {
 	return fmt.Sprintf("%s-%s", job.Name, sp.Name())
 }
@@ -239,31 +310,42 @@ func (sp *sshPlugin) addFlags() {
 		"ssh private and public keys, it is `/root/.ssh` by default.")
 	flagSet.StringVar(&sp.sshPrivateKey, "ssh-private-key", sp.sshPrivateKey, "The input string of the private key")
 	flagSet.StringVar(&sp.sshPublicKey, "ssh-public-key", sp.sshPublicKey, "The input string of the public key")
+	flagSet.StringVar(&sp.sshSecret, "ssh-secret", sp.sshSecret, "The name of the secret in the namespace of the job "+
+		"containing id_rsa and authorized_keys, which takes precedence over ssh-private-key and ssh-public-key.")
+	flagSet.IntVar(&sp.sshPort, "ssh-port", sp.sshPort, "The port of sshd in the pods.")
+	flagSet.StringVar(&sp.sshUser, "ssh-user", sp.sshUser, "The user to login the pods, the current user by default.")
+	flagSet.StringVar(&sp.sshHomeDir, "ssh-home-dir", sp.sshHomeDir, "The home directory of the user, the keys are "+
+		"stored in its .ssh directory unless ssh-key-file-path is specified.")
 
 	if err := flagSet.Parse(sp.pluginArguments); err != nil {
 		klog.Errorf("plugin %s flagset parse failed, err: %v", sp.Name(), err)
 	}
+
+	keyFilePathSet := false
+	flagSet.Visit(func(f *flag.Flag) {
+		if f.Name == "ssh-key-file-path" {
+			keyFilePathSet = true
+		}
+	})
+	if len(sp.sshHomeDir) > 0 && !keyFilePathSet {
+		sp.sshKeyFilePath = path.Join(sp.sshHomeDir, SSHRelativePath)
+	}
 }
 
-func generateSSHConfig(job *batch.Job) string {
+func (sp *sshPlugin) generateSSHConfig(job *batch.Job) string {
 	config := "StrictHostKeyChecking no\nUserKnownHostsFile /dev/null\n"
+	if sp.sshPort != SSHDefaultPort {
+		config += "Port " + strconv.Itoa(sp.sshPort) + "\n"
+	}
+	if len(sp.sshUser) > 0 {
+		config += "User " + sp.sshUser + "\n"
+	}
 
 	for _, ts := range job.Spec.Tasks {
-		for i := 0; i < int(ts.Replicas); i++ {
-			hostName := ts.Template.Spec.Hostname
-			subdomain := ts.Template.Spec.Subdomain
-			if len(hostName) == 0 {
-				hostName = jobhelpers.MakePodName(job.Name, ts.Name, i)
-			}
-			if len(subdomain) == 0 {
-				subdomain = job.Name
-			}
-
+		for _, domainName := range jobhelpers.MakeTaskDomainNames(ts, job) {
+			hostName := strings.SplitN(domainName, ".", 2)[0]
 			config += "Host " + hostName + "\n"
-			config += "  HostName " + hostName + "." + subdomain + "\n"
-			if len(ts.Template.Spec.Hostname) != 0 {
-				break
-			}
+			config += "  HostName " + domainName + "\n"
 		}
 	}
 
@@ -131,16 +131,16 @@ func (sp *servicePlugin) OnJobAdd(job *batch.Job) error {
 	hostFile := GenerateHosts(job)
 
 	// Create ConfigMap of hosts for Pods to mount.
-	if err := helpers.CreateOrUpdateConfigMap(job, sp.Clientset.KubeClients, hostFile, sp.cmName(job)); err != nil {
+	if err := jobhelpers.CreateOrUpdateConfigMap(job, sp.Clientset.KubeClients, sp.Clientset.ResourceLabels, hostFile, sp.cmName(job)); err != nil {
 		return err
 	}
 
-	if err := sp.createServiceIfNotExist(job); err != nil {
+	if err := sp.createOrUpdateService(job); err != nil {
 		return err
 	}
 
 	if !sp.disableNetworkPolicy {
-		if err := sp.createNetworkPolicyIfNotExist(job); err != nil {
+		if err := sp.createOrUpdateNetworkPolicy(job); err != nil {
 			return err
 		}
 	}
@@ -181,7 +181,21 @@ func (sp *servicePlugin) OnJobUpdate(job *batch.Job) error {
 	hostFile := GenerateHosts(job)
 
 	// updates ConfigMap of hosts for Pods to mount.
-	return helpers.CreateOrUpdateConfigMap(job, sp.Clientset.KubeClients, hostFile, sp.cmName(job))
+	if err := jobhelpers.CreateOrUpdateConfigMap(job, sp.Clientset.KubeClients, sp.Clientset.ResourceLabels, hostFile, sp.cmName(job)); err != nil {
+		return err
+	}
+	if job.Status.ControlledResources["plugin-"+sp.Name()] != sp.Name() {
+		return nil
+	}
+
+	// updates the metadata of the Service and NetworkPolicy propagated from the job.
+	if err := sp.createOrUpdateService(job); err != nil {
+		return err
+	}
+	if !sp.disableNetworkPolicy {
+		return sp.createOrUpdateNetworkPolicy(job)
+	}
+	return nil
 }
 
 func (sp *servicePlugin) mountConfigmap(pod *v1.Pod, job *batch.Job) {
@@ -209,90 +223,47 @@ func (sp *servicePlugin) mountConfigmap(pod *v1.Pod, job *batch.Job) {
 	}
 }
 
-func (sp *servicePlugin) createServiceIfNotExist(job *batch.Job) error {
-	// If Service does not exist, create one for Job.
-	if _, err := sp.Clientset.KubeClients.CoreV1().Services(job.Namespace).Get(context.TODO(), job.Name, metav1.GetOptions{}); err != nil {
-		if !apierrors.IsNotFound(err) {
-			klog.V(3).Infof("Failed to get Service for Job <%s/%s>: %v",
-				job.Namespace, job.Name, err)
-			return err
-		}
-
-		svc := &v1.Service{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: job.Namespace,
-				Name:      job.Name,
-				OwnerReferences: []metav1.OwnerReference{
-					*metav1.NewControllerRef(job, helpers.JobKind),
-				},
-			},
-			Spec: v1.ServiceSpec{
-				ClusterIP: "None",
-				Selector: map[string]string{
-					batch.JobNameKey:      job.Name,
-					batch.JobNamespaceKey: job.Namespace,
-				},
-				PublishNotReadyAddresses: sp.publishNotReadyAddresses,
+func (sp *servicePlugin) createOrUpdateService(job *batch.Job) error {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: job.Namespace, Name: job.Name},
+		Spec: v1.ServiceSpec{
+			ClusterIP: "None",
+			Selector: map[string]string{
+				batch.JobNameKey:      job.Name,
+				batch.JobNamespaceKey: job.Namespace,
 			},
-		}
-
-		if _, e := sp.Clientset.KubeClients.CoreV1().Services(job.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{}); e != nil {
-			klog.V(3).Infof("Failed to create Service for Job <%s/%s>: %v", job.Namespace, job.Name, e)
-			return e
-		}
-		job.Status.ControlledResources["plugin-"+sp.Name()] = sp.Name()
+			// the domain names of the pods are kept resolvable while the pods are restarted for stable pod identities
+			PublishNotReadyAddresses: sp.publishNotReadyAddresses || jobhelpers.StablePodIdentity(job),
+		},
 	}
-
-	return nil
+	return jobhelpers.CreateOrUpdateService(job, sp.Clientset.KubeClients, sp.Clientset.ResourceLabels, svc)
 }
 
 // Limit pods can be accessible only by pods belong to the job.
-func (sp *servicePlugin) createNetworkPolicyIfNotExist(job *batch.Job) error {
-	// If network policy does not exist, create one for Job.
-	if _, err := sp.Clientset.KubeClients.NetworkingV1().NetworkPolicies(job.Namespace).Get(context.TODO(), job.Name, metav1.GetOptions{}); err != nil {
-		if !apierrors.IsNotFound(err) {
-			klog.V(3).Infof("Failed to get NetworkPolicy for Job <%s/%s>: %v",
-				job.Namespace, job.Name, err)
-			return err
-		}
-
-		networkpolicy := &networkingv1.NetworkPolicy{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: job.Namespace,
-				Name:      job.Name,
-				OwnerReferences: []metav1.OwnerReference{
-					*metav1.NewControllerRef(job, helpers.JobKind),
+func (sp *servicePlugin) createOrUpdateNetworkPolicy(job *batch.Job) error {
+	networkpolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: job.Namespace, Name: job.Name},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					batch.JobNameKey:      job.Name,
+					batch.JobNamespaceKey: job.Namespace,
 				},
 			},
-			Spec: networkingv1.NetworkPolicySpec{
-				PodSelector: metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						batch.JobNameKey:      job.Name,
-						batch.JobNamespaceKey: job.Namespace,
-					},
-				},
-				Ingress: []networkingv1.NetworkPolicyIngressRule{{
-					From: []networkingv1.NetworkPolicyPeer{{
-						PodSelector: &metav1.LabelSelector{
-							MatchLabels: map[string]string{
-								batch.JobNameKey:      job.Name,
-								batch.JobNamespaceKey: job.Namespace,
-							},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							batch.JobNameKey:      job.Name,
+							batch.JobNamespaceKey: job.Namespace,
 						},
-					}},
+					},
 				}},
-				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
-			},
-		}
-
-		if _, e := sp.Clientset.KubeClients.NetworkingV1().NetworkPolicies(job.Namespace).Create(context.TODO(), networkpolicy, metav1.CreateOptions{}); e != nil {
-			klog.V(3).Infof("Failed to create Service for Job <%s/%s>: %v", job.Namespace, job.Name, e)
-			return e
-		}
-		job.Status.ControlledResources["plugin-"+sp.Name()] = sp.Name()
+			}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
 	}
-
-	return nil
+	return jobhelpers.CreateOrUpdateNetworkPolicy(job, sp.Clientset.KubeClients, sp.Clientset.ResourceLabels, networkpolicy)
 }
 
 func (sp *servicePlugin) cmName(job *batch.Job) string {
@@ -304,22 +275,7 @@ func GenerateHosts(job *batch.Job) map[string]string {
 	hostFile := make(map[string]string, len(job.Spec.Tasks))
 
 	for _, ts := range job.Spec.Tasks {
-		hosts := make([]string, 0, ts.Replicas)
-
-		for i := 0; i < int(ts.Replicas); i++ {
-			hostName := ts.Template.Spec.Hostname
-			subdomain := ts.Template.Spec.Subdomain
-			if len(hostName) == 0 {
-				hostName = jobhelpers.MakePodName(job.Name, ts.Name, i)
-			}
-			if len(subdomain) == 0 {
-				subdomain = job.Name
-			}
-			hosts = append(hosts, hostName+"."+subdomain)
-			if len(ts.Template.Spec.Hostname) != 0 {
-				break
-			}
-		}
+		hosts := jobhelpers.MakeTaskDomainNames(ts, job)
 
 		formateENVKey := strings.Replace(ts.Name, "-", "_", -1)
 		key := fmt.Sprintf(ConfigMapTaskHostFmt, formateENVKey)
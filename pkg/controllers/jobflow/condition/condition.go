@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package condition
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/lru"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	flowv1alpha1 "volcano.sh/apis/pkg/apis/flow/v1alpha1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+const (
+	// FlowConditionsAnnotation is the annotation of JobFlow holding the conditions of the flows, the value is
+	// a JSON object from the flow name to a CEL expression, e.g. {"cleanup": "jobs.train.phase == 'Failed'"}.
+	// A conditional flow is deployed after all its targets finish and the expression is evaluated to true,
+	// otherwise it is skipped.
+	FlowConditionsAnnotation = "volcano.sh/flow-conditions"
+	// OutputConfigMapLabel is the label of the ConfigMaps exposed to the conditions of a JobFlow,
+	// the value is the name of the JobFlow.
+	OutputConfigMapLabel = "volcano.sh/jobflow-output"
+
+	// Skipped is the phase recorded in the JobFlow conditions for the flows skipped.
+	Skipped v1alpha1.JobPhase = "Skipped"
+
+	jobsVariable       = "jobs"
+	configMapsVariable = "configMaps"
+
+	// costLimit bounds the cost of evaluating a condition, so that an expensive expression does not block the sync.
+	costLimit = 1000000
+	// programCacheSize is the number of the compiled conditions cached.
+	programCacheSize = 1024
+)
+
+// programs caches the compiled conditions keyed by the expression, because the conditions are evaluated on
+// every sync of the JobFlow.
+var programs = lru.New(programCacheSize)
+
+// Parse returns the conditions of the flows in the JobFlow, keyed by the flow name.
+func Parse(jobFlow *flowv1alpha1.JobFlow) (map[string]string, error) {
+	value, found := jobFlow.Annotations[FlowConditionsAnnotation]
+	if !found || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	conditions := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &conditions); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", FlowConditionsAnnotation, err)
+	}
+	return conditions, nil
+}
+
+// Compile compiles the condition, which must be a CEL expression of bool type. The variables are:
+//   - jobs: the jobs created by the JobFlow keyed by the flow name, with the fields phase, retryCount,
+//     pending, running, succeeded, failed, terminating and taskResults, which is the results of the pods
+//     keyed by the task name and then the pod name, see jobhelpers.TaskResultsAnnotation.
+//   - configMaps: the data of the ConfigMaps labeled with OutputConfigMapLabel, keyed by the ConfigMap name.
+//
+// The evaluation of the program fails if its cost exceeds costLimit.
+func Compile(expression string) (cel.Program, error) {
+	if program, found := programs.Get(expression); found {
+		return program.(cel.Program), nil
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable(jobsVariable, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(configMapsVariable, cel.MapType(cel.StringType, cel.MapType(cel.StringType, cel.StringType))),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("condition %q must be of bool type, got %v", expression, ast.OutputType())
+	}
+	program, err := env.Program(ast, cel.CostLimit(costLimit))
+	if err != nil {
+		return nil, err
+	}
+	programs.Add(expression, program)
+	return program, nil
+}
+
+// ReferConfigMaps returns whether the condition refers to the ConfigMaps, so that they are only listed if needed.
+func ReferConfigMaps(expression string) bool {
+	return strings.Contains(expression, configMapsVariable)
+}
+
+// Evaluate evaluates the condition against the jobs keyed by the flow name and the data of the ConfigMaps.
+func Evaluate(program cel.Program, jobs map[string]*v1alpha1.Job, configMaps map[string]map[string]string) (bool, error) {
+	jobVars := make(map[string]interface{}, len(jobs))
+	for flowName, job := range jobs {
+		jobVars[flowName] = jobVariable(job)
+	}
+	if configMaps == nil {
+		configMaps = map[string]map[string]string{}
+	}
+
+	result, _, err := program.Eval(map[string]interface{}{
+		jobsVariable:       jobVars,
+		configMapsVariable: configMaps,
+	})
+	if err != nil {
+		return false, err
+	}
+	value, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("condition is evaluated to %v, not bool", result.Value())
+	}
+	return value, nil
+}
+
+func jobVariable(job *v1alpha1.Job) map[string]interface{} {
+	taskResults := map[string]interface{}{}
+	if value, found := job.Annotations[jobhelpers.TaskResultsAnnotation]; found {
+		if err := json.Unmarshal([]byte(value), &taskResults); err != nil {
+			klog.Warningf("Ignore the invalid task results of Job <%s/%s>: %v", job.Namespace, job.Name, err)
+		}
+	}
+	return map[string]interface{}{
+		"taskResults": taskResults,
+		"phase":       string(job.Status.State.Phase),
+		"retryCount":  int64(job.Status.RetryCount),
+		"pending":     int64(job.Status.Pending),
+		"running":     int64(job.Status.Running),
+		"succeeded":   int64(job.Status.Succeeded),
+		"failed":      int64(job.Status.Failed),
+		"terminating": int64(job.Status.Terminating),
+	}
+}
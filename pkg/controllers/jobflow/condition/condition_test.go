@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package condition
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+func TestEvaluate(t *testing.T) {
+	jobs := map[string]*v1alpha1.Job{
+		"train": {Status: v1alpha1.JobStatus{State: v1alpha1.JobState{Phase: v1alpha1.Failed}, RetryCount: 2, Failed: 1}},
+		"eval": {
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				jobhelpers.TaskResultsAnnotation: `{"eval": {"eval-eval-0": {"accuracy": 0.92}}}`,
+			}},
+		},
+	}
+	configMaps := map[string]map[string]string{"train-output": {"result": "ok"}}
+
+	testCases := []struct {
+		name       string
+		expression string
+		expected   bool
+		expectErr  bool
+	}{
+		{name: "phase", expression: "jobs.train.phase == 'Failed'", expected: true},
+		{name: "counters", expression: "jobs['train'].retryCount > 2 || jobs.train.failed == 0", expected: false},
+		{name: "configmap", expression: "configMaps['train-output'].result == 'ok'", expected: true},
+		{name: "task results", expression: "jobs.eval.taskResults.eval['eval-eval-0'].accuracy > 0.9", expected: true},
+		{name: "no task results", expression: "size(jobs.train.taskResults) == 0", expected: true},
+		{name: "missing key", expression: "configMaps['deploy-output'].result == 'ok'", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			program, err := Compile(tc.expression)
+			if err != nil {
+				t.Fatalf("failed to compile %q: %v", tc.expression, err)
+			}
+			got, err := Evaluate(program, jobs, configMaps)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	if _, err := Compile("jobs.train.phase"); err == nil {
+		t.Errorf("expected non-bool condition rejected")
+	}
+	if _, err := Compile("unknown == 1"); err == nil {
+		t.Errorf("expected undeclared variable rejected")
+	}
+
+	expression := "jobs.train.phase == 'Failed'"
+	program, err := Compile(expression)
+	if err != nil {
+		t.Fatalf("failed to compile %q: %v", expression, err)
+	}
+	if cached, _ := Compile(expression); cached != program {
+		t.Errorf("expected compiled condition cached")
+	}
+}
+
+func TestEvaluateCostLimit(t *testing.T) {
+	items := make([]string, 100)
+	for i := range items {
+		items[i] = strconv.Itoa(i)
+	}
+	list := "[" + strings.Join(items, ",") + "]"
+	expression := fmt.Sprintf("%s.all(a, %s.all(b, %s.all(c, a + b + c >= 0)))", list, list, list)
+
+	program, err := Compile(expression)
+	if err != nil {
+		t.Fatalf("failed to compile %q: %v", expression, err)
+	}
+	if _, err := Evaluate(program, nil, nil); err == nil {
+		t.Errorf("expected evaluation exceeding the cost limit failed")
+	}
+}
@@ -32,7 +32,9 @@ import (
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	v1alpha1flow "volcano.sh/apis/pkg/apis/flow/v1alpha1"
 	"volcano.sh/apis/pkg/client/clientset/versioned/scheme"
+	"volcano.sh/volcano/pkg/controllers/jobflow/condition"
 	"volcano.sh/volcano/pkg/controllers/jobflow/state"
+	"volcano.sh/volcano/pkg/controllers/jobtemplate/parameters"
 )
 
 func (jf *jobflowcontroller) syncJobFlow(jobFlow *v1alpha1flow.JobFlow, updateStateFn state.UpdateJobFlowStatusFn) error {
@@ -50,7 +52,8 @@ func (jf *jobflowcontroller) syncJobFlow(jobFlow *v1alpha1flow.JobFlow, updateSt
 	}
 
 	// deploy job by dependence order.
-	if err := jf.deployJob(jobFlow); err != nil {
+	resolver, err := jf.deployJob(jobFlow)
+	if err != nil {
 		klog.Errorf("Failed to create jobs of JobFlow %v/%v: %v",
 			jobFlow.Namespace, jobFlow.Name, err)
 		return err
@@ -62,7 +65,13 @@ func (jf *jobflowcontroller) syncJobFlow(jobFlow *v1alpha1flow.JobFlow, updateSt
 		return err
 	}
 	jobFlow.Status = *jobFlowStatus
-	updateStateFn(&jobFlow.Status, len(jobFlow.Spec.Flows))
+	for _, flowName := range resolver.skippedFlows() {
+		jobFlow.Status.Conditions[getJobName(jobFlow.Name, flowName)] = v1alpha1flow.Condition{Phase: condition.Skipped}
+	}
+	// the failures handled by the conditional flows do not fail the JobFlow, and the skipped flows are not expected
+	stateStatus, allJobList := resolver.stateStatus(&jobFlow.Status)
+	updateStateFn(stateStatus, allJobList)
+	jobFlow.Status.State = stateStatus.State
 	_, err = jf.vcClient.FlowV1alpha1().JobFlows(jobFlow.Namespace).UpdateStatus(context.Background(), jobFlow, metav1.UpdateOptions{})
 	if err != nil {
 		klog.Errorf("Failed to update status of JobFlow %v/%v: %v",
@@ -73,54 +82,26 @@ func (jf *jobflowcontroller) syncJobFlow(jobFlow *v1alpha1flow.JobFlow, updateSt
 	return nil
 }
 
-func (jf *jobflowcontroller) deployJob(jobFlow *v1alpha1flow.JobFlow) error {
-	// load jobTemplate by flow and deploy it
-	for _, flow := range jobFlow.Spec.Flows {
-		jobName := getJobName(jobFlow.Name, flow.Name)
-		if _, err := jf.jobLister.Jobs(jobFlow.Namespace).Get(jobName); err != nil {
-			if errors.IsNotFound(err) {
-				// If it is not distributed, judge whether the dependency of the VcJob meets the requirements
-				if flow.DependsOn == nil || flow.DependsOn.Targets == nil {
-					if err := jf.createJob(jobFlow, flow); err != nil {
-						return err
-					}
-				} else {
-					// query whether the dependencies of the job have been met
-					flag, err := jf.judge(jobFlow, flow)
-					if err != nil {
-						return err
-					}
-					if flag {
-						if err := jf.createJob(jobFlow, flow); err != nil {
-							return err
-						}
-					}
-				}
-				continue
-			}
-			return err
-		}
+func (jf *jobflowcontroller) deployJob(jobFlow *v1alpha1flow.JobFlow) (*flowResolver, error) {
+	// judge whether the dependencies and the conditions of the flows are met
+	resolver, err := jf.newFlowResolver(jobFlow)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolver.resolveAll(); err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// judge query whether the dependencies of the job have been met. If it is satisfied, create the job, if not, judge the next job. Create the job if satisfied
-func (jf *jobflowcontroller) judge(jobFlow *v1alpha1flow.JobFlow, flow v1alpha1flow.Flow) (bool, error) {
-	for _, targetName := range flow.DependsOn.Targets {
-		targetJobName := getJobName(jobFlow.Name, targetName)
-		job, err := jf.jobLister.Jobs(jobFlow.Namespace).Get(targetJobName)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				klog.Info(fmt.Sprintf("No %v Job found！", targetJobName))
-				return false, nil
-			}
-			return false, err
+	// load jobTemplate by flow and deploy it
+	for _, flow := range jobFlow.Spec.Flows {
+		if resolver.decisions[flow.Name] != flowReady {
+			continue
 		}
-		if job.Status.State.Phase != v1alpha1.Completed {
-			return false, nil
+		if err := jf.createJob(jobFlow, flow); err != nil {
+			return nil, err
 		}
 	}
-	return true, nil
+	return resolver, nil
 }
 
 // createJob
@@ -257,6 +238,15 @@ func (jf *jobflowcontroller) loadJobTemplateAndSetJob(jobFlow *v1alpha1flow.JobF
 	if err != nil {
 		return err
 	}
+	// instantiate the jobTemplate with the parameters of the flow
+	flowValues, err := parameters.ParseFlowValues(jobFlow)
+	if err != nil {
+		return err
+	}
+	spec, err := parameters.Render(jobTemplate, flowValues[flowName])
+	if err != nil {
+		return err
+	}
 
 	*job = v1alpha1.Job{
 		ObjectMeta: metav1.ObjectMeta{
@@ -271,7 +261,7 @@ func (jf *jobflowcontroller) loadJobTemplateAndSetJob(jobFlow *v1alpha1flow.JobF
 				CreatedByJobFlow:     GenerateObjectString(jobFlow.Namespace, jobFlow.Name),
 			},
 		},
-		Spec:   jobTemplate.Spec,
+		Spec:   *spec,
 		Status: v1alpha1.JobStatus{},
 	}
 
@@ -35,6 +35,7 @@ import (
 	"volcano.sh/apis/pkg/client/clientset/versioned/scheme"
 	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
 	"volcano.sh/volcano/pkg/controllers/framework"
+	"volcano.sh/volcano/pkg/controllers/jobtemplate/parameters"
 )
 
 func newFakeController() *jobflowcontroller {
@@ -841,6 +842,50 @@ func TestLoadJobTemplateAndSetJobFunc(t *testing.T) {
 	}
 }
 
+func TestLoadJobTemplateWithParameters(t *testing.T) {
+	jobTemplate := &jobflowv1alpha1.JobTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "train",
+			Namespace:   "default",
+			Annotations: map[string]string{parameters.ParametersAnnotation: `{"queue": null, "minAvailable": "1"}`},
+		},
+		Spec: v1alpha1.JobSpec{Queue: "{{.params.queue}}"},
+	}
+	tests := []struct {
+		name      string
+		values    string
+		queue     string
+		expectErr bool
+	}{
+		{name: "parameters of the flow", values: `{"train": {"queue": "research"}}`, queue: "research"},
+		{name: "required parameter not set", values: `{"eval": {"queue": "research"}}`, expectErr: true},
+		{name: "invalid parameters", values: `{"train": "research"}`, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeController := newFakeController()
+			if err := fakeController.jobTemplateInformer.Informer().GetIndexer().Add(jobTemplate); err != nil {
+				t.Fatalf("failed to add jobTemplate: %v", err)
+			}
+			jobFlow := &jobflowv1alpha1.JobFlow{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "jobflow",
+					Namespace:   "default",
+					Annotations: map[string]string{parameters.FlowParametersAnnotation: tt.values},
+				},
+			}
+			job := &v1alpha1.Job{}
+			err := fakeController.loadJobTemplateAndSetJob(jobFlow, "train", getJobName("jobflow", "train"), job)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if !tt.expectErr && job.Spec.Queue != tt.queue {
+				t.Errorf("expected queue %s, got %s", tt.queue, job.Spec.Queue)
+			}
+		})
+	}
+}
+
 func TestDeployJobFunc(t *testing.T) {
 	type args struct {
 		jobFlow         *jobflowv1alpha1.JobFlow
@@ -893,7 +938,7 @@ func TestDeployJobFunc(t *testing.T) {
 				}
 			}
 
-			if got := fakeController.deployJob(tt.args.jobFlow); got != tt.want {
+			if _, got := fakeController.deployJob(tt.args.jobFlow); got != tt.want {
 				t.Error("Expected deployJob() return nil, but not nil")
 			}
 		})
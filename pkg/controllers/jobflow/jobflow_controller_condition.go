@@ -0,0 +1,253 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobflow
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	v1alpha1flow "volcano.sh/apis/pkg/apis/flow/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/jobflow/condition"
+)
+
+type flowDecision int
+
+const (
+	// flowWaiting means the dependencies of the flow are not met yet, or a target of the flow without
+	// condition is finished but not completed.
+	flowWaiting flowDecision = iota
+	// flowReady means the job of the flow is to be created.
+	flowReady
+	// flowDeployed means the job of the flow is created.
+	flowDeployed
+	// flowSkipped means the flow is never deployed, because its condition is false or a target is skipped.
+	flowSkipped
+)
+
+// flowResolver decides whether the flows of a JobFlow are deployed by their dependencies and conditions.
+type flowResolver struct {
+	jf         *jobflowcontroller
+	jobFlow    *v1alpha1flow.JobFlow
+	flows      map[string]v1alpha1flow.Flow
+	conditions map[string]string
+	// jobs are the jobs created keyed by the flow name
+	jobs       map[string]*v1alpha1.Job
+	configMaps map[string]map[string]string
+	decisions  map[string]flowDecision
+}
+
+func (jf *jobflowcontroller) newFlowResolver(jobFlow *v1alpha1flow.JobFlow) (*flowResolver, error) {
+	conditions, err := condition.Parse(jobFlow)
+	if err != nil {
+		return nil, err
+	}
+	r := &flowResolver{
+		jf:         jf,
+		jobFlow:    jobFlow,
+		flows:      make(map[string]v1alpha1flow.Flow, len(jobFlow.Spec.Flows)),
+		conditions: conditions,
+		jobs:       make(map[string]*v1alpha1.Job, len(jobFlow.Spec.Flows)),
+		decisions:  make(map[string]flowDecision, len(jobFlow.Spec.Flows)),
+	}
+	for _, flow := range jobFlow.Spec.Flows {
+		r.flows[flow.Name] = flow
+		job, err := jf.jobLister.Jobs(jobFlow.Namespace).Get(getJobName(jobFlow.Name, flow.Name))
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		r.jobs[flow.Name] = job
+	}
+	return r, nil
+}
+
+// resolve returns the decision of the flow, the decisions of its targets are resolved first.
+func (r *flowResolver) resolve(flowName string) (flowDecision, error) {
+	if decision, found := r.decisions[flowName]; found {
+		return decision, nil
+	}
+	// guard against the cycles, which are rejected by the admission webhook
+	r.decisions[flowName] = flowWaiting
+	decision, err := r.decide(flowName)
+	if err != nil {
+		delete(r.decisions, flowName)
+		return flowWaiting, err
+	}
+	r.decisions[flowName] = decision
+	return decision, nil
+}
+
+func (r *flowResolver) decide(flowName string) (flowDecision, error) {
+	if _, found := r.jobs[flowName]; found {
+		return flowDeployed, nil
+	}
+	flow, found := r.flows[flowName]
+	if !found {
+		klog.Infof("No flow %v found in JobFlow %v/%v.", flowName, r.jobFlow.Namespace, r.jobFlow.Name)
+		return flowWaiting, nil
+	}
+	expression, conditional := r.conditions[flowName]
+
+	if flow.DependsOn != nil {
+		for _, target := range flow.DependsOn.Targets {
+			decision, err := r.resolve(target)
+			if err != nil {
+				return flowWaiting, err
+			}
+			if decision == flowSkipped {
+				return flowSkipped, nil
+			}
+			if decision != flowDeployed {
+				return flowWaiting, nil
+			}
+			phase := r.jobs[target].Status.State.Phase
+			if !isJobFinished(phase) {
+				return flowWaiting, nil
+			}
+			// the flow without condition only runs after its targets complete, and keeps waiting
+			// if a target fails as before
+			if !conditional && phase != v1alpha1.Completed {
+				return flowWaiting, nil
+			}
+		}
+	}
+	if !conditional {
+		return flowReady, nil
+	}
+
+	ok, err := r.evaluate(expression)
+	if err != nil {
+		klog.Errorf("Failed to evaluate condition of flow %v in JobFlow %v/%v: %v",
+			flowName, r.jobFlow.Namespace, r.jobFlow.Name, err)
+		r.jf.recorder.Eventf(r.jobFlow, corev1.EventTypeWarning, "ConditionError",
+			"failed to evaluate condition of flow %v, skip it: %v", flowName, err)
+		return flowSkipped, nil
+	}
+	if !ok {
+		return flowSkipped, nil
+	}
+	return flowReady, nil
+}
+
+func (r *flowResolver) evaluate(expression string) (bool, error) {
+	program, err := condition.Compile(expression)
+	if err != nil {
+		return false, err
+	}
+	if r.configMaps == nil && condition.ReferConfigMaps(expression) {
+		if err := r.loadConfigMaps(); err != nil {
+			return false, err
+		}
+	}
+	return condition.Evaluate(program, r.jobs, r.configMaps)
+}
+
+func (r *flowResolver) loadConfigMaps() error {
+	selector := labels.SelectorFromSet(labels.Set{condition.OutputConfigMapLabel: r.jobFlow.Name})
+	configMaps, err := r.jf.kubeClient.CoreV1().ConfigMaps(r.jobFlow.Namespace).List(context.Background(),
+		metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	r.configMaps = make(map[string]map[string]string, len(configMaps.Items))
+	for _, configMap := range configMaps.Items {
+		r.configMaps[configMap.Name] = configMap.Data
+	}
+	return nil
+}
+
+// resolveAll resolves the decisions of all flows.
+func (r *flowResolver) resolveAll() error {
+	for _, flow := range r.jobFlow.Spec.Flows {
+		if _, err := r.resolve(flow.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skippedFlows returns the names of the flows skipped.
+func (r *flowResolver) skippedFlows() []string {
+	skipped := make([]string, 0)
+	for _, flow := range r.jobFlow.Spec.Flows {
+		if r.decisions[flow.Name] == flowSkipped {
+			skipped = append(skipped, flow.Name)
+		}
+	}
+	return skipped
+}
+
+// handledJobs returns the names of the jobs failed or terminated, which are the targets of a conditional
+// flow deployed, e.g. a cleanup flow running on failure.
+func (r *flowResolver) handledJobs() map[string]bool {
+	handled := map[string]bool{}
+	for flowName := range r.conditions {
+		flow, found := r.flows[flowName]
+		if !found || flow.DependsOn == nil {
+			continue
+		}
+		if decision := r.decisions[flowName]; decision != flowReady && decision != flowDeployed {
+			continue
+		}
+		for _, target := range flow.DependsOn.Targets {
+			if job, found := r.jobs[target]; found && job.Status.State.Phase != v1alpha1.Completed {
+				handled[job.Name] = true
+			}
+		}
+	}
+	return handled
+}
+
+// stateStatus returns the status for the state transition of the JobFlow, where the jobs handled by the
+// conditional flows are counted as completed, and the number of the jobs expected excluding the skipped flows.
+func (r *flowResolver) stateStatus(status *v1alpha1flow.JobFlowStatus) (*v1alpha1flow.JobFlowStatus, int) {
+	result := status.DeepCopy()
+	handled := r.handledJobs()
+	if len(handled) > 0 {
+		result.FailedJobs = excludeJobs(result.FailedJobs, handled, &result.CompletedJobs)
+		result.TerminatedJobs = excludeJobs(result.TerminatedJobs, handled, &result.CompletedJobs)
+	}
+	return result, len(r.jobFlow.Spec.Flows) - len(r.skippedFlows())
+}
+
+func excludeJobs(jobs []string, excluded map[string]bool, moved *[]string) []string {
+	result := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		if excluded[job] {
+			*moved = append(*moved, job)
+			continue
+		}
+		result = append(result, job)
+	}
+	return result
+}
+
+func isJobFinished(phase v1alpha1.JobPhase) bool {
+	switch phase {
+	case v1alpha1.Completed, v1alpha1.Failed, v1alpha1.Terminated, v1alpha1.Aborted:
+		return true
+	}
+	return false
+}
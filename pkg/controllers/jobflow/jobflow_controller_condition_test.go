@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobflow
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	jobflowv1alpha1 "volcano.sh/apis/pkg/apis/flow/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/jobflow/condition"
+)
+
+func TestFlowResolver(t *testing.T) {
+	flows := []jobflowv1alpha1.Flow{
+		{Name: "train"},
+		{Name: "cleanup", DependsOn: &jobflowv1alpha1.DependsOn{Targets: []string{"train"}}},
+		{Name: "deploy", DependsOn: &jobflowv1alpha1.DependsOn{Targets: []string{"train"}}},
+		{Name: "notify", DependsOn: &jobflowv1alpha1.DependsOn{Targets: []string{"deploy"}}},
+		{Name: "report", DependsOn: &jobflowv1alpha1.DependsOn{Targets: []string{"train"}}},
+	}
+	conditions := `{"cleanup": "jobs.train.phase == 'Failed'", "deploy": "configMaps['train-output'].result == 'ok'"}`
+
+	testCases := []struct {
+		name              string
+		trainPhase        v1alpha1.JobPhase
+		result            string
+		expectedDecisions map[string]flowDecision
+		expectedHandled   []string
+		expectedJobList   int
+	}{
+		{
+			name:       "targets not finished",
+			trainPhase: v1alpha1.Running,
+			result:     "ok",
+			expectedDecisions: map[string]flowDecision{
+				"train": flowDeployed, "cleanup": flowWaiting, "deploy": flowWaiting, "notify": flowWaiting,
+				"report": flowWaiting,
+			},
+			expectedJobList: 5,
+		},
+		{
+			name:       "train completed with result ok",
+			trainPhase: v1alpha1.Completed,
+			result:     "ok",
+			expectedDecisions: map[string]flowDecision{
+				"train": flowDeployed, "cleanup": flowSkipped, "deploy": flowReady, "notify": flowWaiting,
+				"report": flowReady,
+			},
+			expectedJobList: 4,
+		},
+		{
+			name:       "train completed with result not ok",
+			trainPhase: v1alpha1.Completed,
+			result:     "bad",
+			expectedDecisions: map[string]flowDecision{
+				"train": flowDeployed, "cleanup": flowSkipped, "deploy": flowSkipped, "notify": flowSkipped,
+				"report": flowReady,
+			},
+			expectedJobList: 2,
+		},
+		{
+			name:       "train failed",
+			trainPhase: v1alpha1.Failed,
+			expectedDecisions: map[string]flowDecision{
+				"train": flowDeployed, "cleanup": flowReady, "deploy": flowSkipped, "notify": flowSkipped,
+				"report": flowWaiting,
+			},
+			expectedHandled: []string{"jobflow-train"},
+			expectedJobList: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeController := newFakeController()
+			fakeController.recorder = record.NewFakeRecorder(10)
+			jobFlow := &jobflowv1alpha1.JobFlow{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "jobflow",
+					Namespace:   "default",
+					Annotations: map[string]string{condition.FlowConditionsAnnotation: conditions},
+				},
+				Spec: jobflowv1alpha1.JobFlowSpec{Flows: flows},
+			}
+			train := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: getJobName(jobFlow.Name, "train"), Namespace: "default"},
+				Status:     v1alpha1.JobStatus{State: v1alpha1.JobState{Phase: tc.trainPhase}},
+			}
+			if err := fakeController.jobInformer.Informer().GetIndexer().Add(train); err != nil {
+				t.Fatalf("failed to add job: %v", err)
+			}
+			if tc.result != "" {
+				configMap := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "train-output",
+						Namespace: "default",
+						Labels:    map[string]string{condition.OutputConfigMapLabel: jobFlow.Name},
+					},
+					Data: map[string]string{"result": tc.result},
+				}
+				if _, err := fakeController.kubeClient.CoreV1().ConfigMaps("default").Create(context.TODO(), configMap, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("failed to create configmap: %v", err)
+				}
+			}
+
+			resolver, err := fakeController.newFlowResolver(jobFlow)
+			if err != nil {
+				t.Fatalf("failed to create resolver: %v", err)
+			}
+			if err := resolver.resolveAll(); err != nil {
+				t.Fatalf("failed to resolve flows: %v", err)
+			}
+			for flowName, expected := range tc.expectedDecisions {
+				if got := resolver.decisions[flowName]; got != expected {
+					t.Errorf("expected decision of flow %s %v, got %v", flowName, expected, got)
+				}
+			}
+
+			handled := resolver.handledJobs()
+			if len(handled) != len(tc.expectedHandled) {
+				t.Errorf("expected handled jobs %v, got %v", tc.expectedHandled, handled)
+			}
+			for _, job := range tc.expectedHandled {
+				if !handled[job] {
+					t.Errorf("expected job %s handled", job)
+				}
+			}
+
+			status := &jobflowv1alpha1.JobFlowStatus{}
+			if tc.trainPhase == v1alpha1.Failed {
+				status.FailedJobs = []string{train.Name}
+			}
+			stateStatus, allJobList := resolver.stateStatus(status)
+			if allJobList != tc.expectedJobList {
+				t.Errorf("expected %d jobs, got %d", tc.expectedJobList, allJobList)
+			}
+			if len(stateStatus.FailedJobs)+len(stateStatus.CompletedJobs) != len(status.FailedJobs) ||
+				len(stateStatus.CompletedJobs) != len(tc.expectedHandled) {
+				t.Errorf("expected handled jobs counted as completed, got %v", stateStatus)
+			}
+		})
+	}
+}
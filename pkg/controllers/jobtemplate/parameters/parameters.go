@@ -0,0 +1,206 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parameters
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	flowv1alpha1 "volcano.sh/apis/pkg/apis/flow/v1alpha1"
+)
+
+const (
+	// ParametersAnnotation is the annotation of JobTemplate declaring its parameters, the value is a JSON object
+	// from the parameter name to its default value, or null for a required parameter,
+	// e.g. {"image": null, "queue": "default"}.
+	ParametersAnnotation = "volcano.sh/template-parameters"
+	// FlowParametersAnnotation is the annotation of JobFlow holding the values of the parameters of the
+	// JobTemplates of the flows, the value is a JSON object from the flow name to the values keyed by the
+	// parameter name, e.g. {"train": {"image": "trainer:v2"}}.
+	FlowParametersAnnotation = "volcano.sh/flow-parameters"
+	// JobTemplateAnnotation is the annotation of Job referring to the JobTemplate in the same namespace
+	// the spec of the job is instantiated from.
+	JobTemplateAnnotation = "volcano.sh/job-template"
+	// JobParametersAnnotation is the annotation of Job holding the values of the parameters of the JobTemplate
+	// it refers to, the value is a JSON object from the parameter name to the value.
+	JobParametersAnnotation = "volcano.sh/job-template-parameters"
+)
+
+// placeholder matches the references to the parameters in the strings of the spec, e.g. {{.params.image}}.
+var placeholder = regexp.MustCompile(`\{\{\s*\.params\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// Declared returns the parameters declared by the JobTemplate with their default values,
+// a nil value means the parameter is required.
+func Declared(jobTemplate *flowv1alpha1.JobTemplate) (map[string]*string, error) {
+	value, found := jobTemplate.Annotations[ParametersAnnotation]
+	if !found || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	declared := map[string]*string{}
+	if err := json.Unmarshal([]byte(value), &declared); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", ParametersAnnotation, err)
+	}
+	return declared, nil
+}
+
+// ParseValues parses the values of the parameters in the annotation of a Job.
+func ParseValues(annotations map[string]string) (map[string]string, error) {
+	value, found := annotations[JobParametersAnnotation]
+	if !found || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	values := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", JobParametersAnnotation, err)
+	}
+	return values, nil
+}
+
+// ParseFlowValues returns the values of the parameters of the flows in the JobFlow, keyed by the flow name.
+func ParseFlowValues(jobFlow *flowv1alpha1.JobFlow) (map[string]map[string]string, error) {
+	value, found := jobFlow.Annotations[FlowParametersAnnotation]
+	if !found || strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	values := map[string]map[string]string{}
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", FlowParametersAnnotation, err)
+	}
+	return values, nil
+}
+
+// Resolve merges the values into the defaults of the declared parameters. It fails if a value is given for an
+// undeclared parameter, or no value is given for a required parameter.
+func Resolve(declared map[string]*string, values map[string]string) (map[string]string, error) {
+	var undeclared, missing []string
+	for name := range values {
+		if _, found := declared[name]; !found {
+			undeclared = append(undeclared, name)
+		}
+	}
+	params := map[string]string{}
+	for name, defaultValue := range declared {
+		if value, found := values[name]; found {
+			params[name] = value
+		} else if defaultValue != nil {
+			params[name] = *defaultValue
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	if len(undeclared) > 0 {
+		sort.Strings(undeclared)
+		return nil, fmt.Errorf("parameters %v are not declared", undeclared)
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("required parameters %v are not set", missing)
+	}
+	return params, nil
+}
+
+// Substitute returns a copy of the spec with the references to the parameters replaced by their values.
+// The parameters can only be referred in the string fields of the spec, e.g. the image, the arguments or
+// the environment variables of the containers. It fails if any reference is left unresolved.
+func Substitute(spec *v1alpha1.JobSpec, params map[string]string) (*v1alpha1.JobSpec, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var object interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	unresolved := sets.New[string]()
+	object = substitute(object, params, unresolved)
+	if unresolved.Len() > 0 {
+		return nil, fmt.Errorf("unresolved parameters %v", sets.List(unresolved))
+	}
+
+	if data, err = json.Marshal(object); err != nil {
+		return nil, err
+	}
+	result := &v1alpha1.JobSpec{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Unresolved returns the parameters still referred in the spec, sorted by name.
+func Unresolved(spec *v1alpha1.JobSpec) ([]string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var object interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	unresolved := sets.New[string]()
+	substitute(object, nil, unresolved)
+	return sets.List(unresolved), nil
+}
+
+// Render instantiates the spec of the JobTemplate with the values of its parameters.
+func Render(jobTemplate *flowv1alpha1.JobTemplate, values map[string]string) (*v1alpha1.JobSpec, error) {
+	declared, err := Declared(jobTemplate)
+	if err != nil {
+		return nil, err
+	}
+	params, err := Resolve(declared, values)
+	if err != nil {
+		return nil, fmt.Errorf("jobTemplate %s/%s: %v", jobTemplate.Namespace, jobTemplate.Name, err)
+	}
+	spec, err := Substitute(&jobTemplate.Spec, params)
+	if err != nil {
+		return nil, fmt.Errorf("jobTemplate %s/%s: %v", jobTemplate.Namespace, jobTemplate.Name, err)
+	}
+	return spec, nil
+}
+
+// substitute replaces the references in the strings of the decoded JSON object, and collects the references
+// without value.
+func substitute(object interface{}, params map[string]string, unresolved sets.Set[string]) interface{} {
+	switch value := object.(type) {
+	case string:
+		return placeholder.ReplaceAllStringFunc(value, func(reference string) string {
+			name := placeholder.FindStringSubmatch(reference)[1]
+			if param, found := params[name]; found {
+				return param
+			}
+			unresolved.Insert(name)
+			return reference
+		})
+	case map[string]interface{}:
+		for k, v := range value {
+			value[k] = substitute(v, params, unresolved)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = substitute(v, params, unresolved)
+		}
+	}
+	return object
+}
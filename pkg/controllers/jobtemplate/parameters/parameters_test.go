@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parameters
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	flowv1alpha1 "volcano.sh/apis/pkg/apis/flow/v1alpha1"
+)
+
+func buildJobTemplate(declared string) *flowv1alpha1.JobTemplate {
+	jobTemplate := &flowv1alpha1.JobTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "train", Namespace: "default"},
+		Spec: v1alpha1.JobSpec{
+			Queue: "{{.params.queue}}",
+			Tasks: []v1alpha1.TaskSpec{{
+				Name:     "worker",
+				Replicas: 2,
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Name:  "trainer",
+					Image: "{{ .params.image }}",
+					Args:  []string{"--epochs={{.params.epochs}}", "--lr=0.1"},
+				}}}},
+			}},
+		},
+	}
+	if declared != "" {
+		jobTemplate.Annotations = map[string]string{ParametersAnnotation: declared}
+	}
+	return jobTemplate
+}
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name      string
+		declared  string
+		values    map[string]string
+		queue     string
+		image     string
+		args      []string
+		expectErr bool
+	}{
+		{
+			name:     "values and defaults",
+			declared: `{"image": null, "epochs": "10", "queue": "default"}`,
+			values:   map[string]string{"image": "trainer:v2", "queue": "research"},
+			queue:    "research",
+			image:    "trainer:v2",
+			args:     []string{"--epochs=10", "--lr=0.1"},
+		},
+		{
+			name:      "required parameter not set",
+			declared:  `{"image": null, "epochs": "10", "queue": "default"}`,
+			values:    map[string]string{"queue": "research"},
+			expectErr: true,
+		},
+		{
+			name:      "undeclared parameter",
+			declared:  `{"image": null, "epochs": "10", "queue": "default"}`,
+			values:    map[string]string{"image": "trainer:v2", "imagee": "trainer:v3"},
+			expectErr: true,
+		},
+		{
+			name:      "unresolved reference",
+			declared:  `{"image": null, "queue": "default"}`,
+			values:    map[string]string{"image": "trainer:v2"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid declaration",
+			declared:  `["image"]`,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jobTemplate := buildJobTemplate(tc.declared)
+			spec, err := Render(jobTemplate, tc.values)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+			container := spec.Tasks[0].Template.Spec.Containers[0]
+			if spec.Queue != tc.queue || container.Image != tc.image || !reflect.DeepEqual(container.Args, tc.args) {
+				t.Errorf("expected queue %s, image %s and args %v, got %s, %s and %v",
+					tc.queue, tc.image, tc.args, spec.Queue, container.Image, container.Args)
+			}
+			if spec.Tasks[0].Replicas != 2 {
+				t.Errorf("expected replicas 2, got %d", spec.Tasks[0].Replicas)
+			}
+			if jobTemplate.Spec.Queue != "{{.params.queue}}" {
+				t.Errorf("expected the spec of the template unchanged, got queue %s", jobTemplate.Spec.Queue)
+			}
+		})
+	}
+}
+
+func TestUnresolved(t *testing.T) {
+	unresolved, err := Unresolved(&buildJobTemplate("").Spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"epochs", "image", "queue"}
+	if !reflect.DeepEqual(unresolved, expected) {
+		t.Errorf("expected %v, got %v", expected, unresolved)
+	}
+
+	unresolved, err = Unresolved(&v1alpha1.JobSpec{Queue: "default"})
+	if err != nil || len(unresolved) != 0 {
+		t.Errorf("expected no unresolved parameters, got %v, %v", unresolved, err)
+	}
+}
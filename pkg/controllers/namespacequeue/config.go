@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacequeue
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const defaultWeight int32 = 1
+
+// controllerConfig is the configuration of the controller manager, only the section of this controller is parsed.
+type controllerConfig struct {
+	NamespaceQueues *BootstrapConfig `yaml:"namespaceQueues"`
+}
+
+// BootstrapConfig configures how the queues of the namespaces are bootstrapped.
+type BootstrapConfig struct {
+	// NamespaceSelector is the label selector of the namespaces to create queues for, e.g. "volcano.sh/tenant",
+	// all the namespaces are selected if it is empty.
+	NamespaceSelector string `yaml:"namespaceSelector"`
+	// TeamLabel is the label of the namespaces holding their team, the namespaces of a team share one queue. The
+	// namespaces without the label are skipped. A queue is created per namespace if it is empty.
+	TeamLabel string `yaml:"teamLabel"`
+	// QueuePrefix is prepended to the name of the namespace or the team to name the queue.
+	QueuePrefix string `yaml:"queuePrefix"`
+	// DefaultWeight is the weight of the queues not listed in Weights, 1 if not set.
+	DefaultWeight int32 `yaml:"defaultWeight"`
+	// Weights is the weight of the queues by the name of their namespace or team.
+	Weights map[string]int32 `yaml:"weights"`
+
+	selector labels.Selector
+}
+
+// parseConfig parses the section of the controller in the configuration, nil is returned if the section is absent.
+func parseConfig(data string) (*BootstrapConfig, error) {
+	conf := &controllerConfig{}
+	if err := yaml.Unmarshal([]byte(data), conf); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	if conf.NamespaceQueues == nil {
+		return nil, nil
+	}
+
+	bootstrap := conf.NamespaceQueues
+	selector, err := labels.Parse(bootstrap.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespaceSelector %q: %v", bootstrap.NamespaceSelector, err)
+	}
+	bootstrap.selector = selector
+	if bootstrap.DefaultWeight <= 0 {
+		bootstrap.DefaultWeight = defaultWeight
+	}
+	return bootstrap, nil
+}
+
+// tenant returns the namespace or the team of the namespace, which owns a queue. False is returned if the namespace
+// is not selected.
+func (c *BootstrapConfig) tenant(ns *v1.Namespace) (string, bool) {
+	if !c.selector.Matches(labels.Set(ns.Labels)) {
+		return "", false
+	}
+	if c.TeamLabel == "" {
+		return ns.Name, true
+	}
+	team := ns.Labels[c.TeamLabel]
+	return team, team != ""
+}
+
+// queueName returns the name of the queue of the tenant.
+func (c *BootstrapConfig) queueName(tenant string) string {
+	return c.QueuePrefix + tenant
+}
+
+// weight returns the weight of the queue of the tenant.
+func (c *BootstrapConfig) weight(tenant string) int32 {
+	if weight, found := c.Weights[tenant]; found && weight > 0 {
+		return weight
+	}
+	return c.DefaultWeight
+}
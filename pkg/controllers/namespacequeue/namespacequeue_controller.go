@@ -0,0 +1,323 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacequeue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	vcclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	vcinformer "volcano.sh/apis/pkg/client/informers/externalversions"
+	schedulinglister "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/framework"
+	controllerconfig "volcano.sh/volcano/pkg/controllers/hypernode/config"
+	"volcano.sh/volcano/pkg/features"
+)
+
+const (
+	// ManagedByLabel is the label of the queues created by the controller, only the weight of such queues is kept
+	// in sync with the configuration.
+	ManagedByLabel = "volcano.sh/managed-by"
+	managedByValue = "namespacequeue-controller"
+)
+
+func init() {
+	framework.RegisterController(&namespaceQueueController{})
+}
+
+// namespaceQueueController creates a queue per namespace, or per team of the namespaces, sets the queue as the
+// default queue of the namespaces by the queue name annotation, and keeps the weight of the queues in sync with
+// the configuration in the controller ConfigMap. The queues are never deleted by the controller.
+type namespaceQueueController struct {
+	kubeClient kubernetes.Interface
+	vcClient   vcclientset.Interface
+
+	informerFactory   informers.SharedInformerFactory
+	vcInformerFactory vcinformer.SharedInformerFactory
+
+	nsLister    corelisters.NamespaceLister
+	queueLister schedulinglister.QueueLister
+
+	// configMapInformer only watches the controller ConfigMap, it is not shared with the other controllers.
+	configMapInformer  cache.SharedIndexInformer
+	configMapNamespace string
+	configMapName      string
+
+	mutex  sync.RWMutex
+	config *BootstrapConfig
+
+	// namespaces that need to be synced.
+	queue   workqueue.TypedRateLimitingInterface[string]
+	workers uint32
+}
+
+func (nc *namespaceQueueController) Name() string {
+	return "namespacequeue-controller"
+}
+
+// Initialize creates an instance of namespaceQueueController.
+func (nc *namespaceQueueController) Initialize(opt *framework.ControllerOption) error {
+	nc.kubeClient = opt.KubeClient
+	nc.vcClient = opt.VolcanoClient
+	nc.workers = opt.WorkerNum
+	nc.informerFactory = opt.SharedInformerFactory
+	nc.vcInformerFactory = opt.VCSharedInformerFactory
+
+	nsInformer := nc.informerFactory.Core().V1().Namespaces()
+	nc.nsLister = nsInformer.Lister()
+	nc.queueLister = nc.vcInformerFactory.Scheduling().V1beta1().Queues().Lister()
+	nc.queue = workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+
+	nsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: nc.addNamespace,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			nc.addNamespace(newObj)
+		},
+	})
+
+	nc.configMapNamespace = os.Getenv(controllerconfig.NamespaceEnvKey)
+	if nc.configMapNamespace == "" {
+		nc.configMapNamespace = controllerconfig.DefaultNamespace
+	}
+	releaseName := os.Getenv(controllerconfig.ReleaseNameEnvKey)
+	if releaseName == "" {
+		releaseName = controllerconfig.DefaultReleaseName
+	}
+	nc.configMapName = releaseName + "-controller-configmap"
+	nc.configMapInformer = coreinformers.NewFilteredConfigMapInformer(nc.kubeClient, nc.configMapNamespace, 0,
+		cache.Indexers{}, func(options *metav1.ListOptions) {
+			options.FieldSelector = fmt.Sprintf("metadata.name=%s", nc.configMapName)
+		})
+	nc.configMapInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: nc.updateConfig,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			nc.updateConfig(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			nc.setConfig(nil)
+		},
+	})
+
+	return nil
+}
+
+// Run starts namespaceQueueController, it does nothing unless the NamespaceQueueBootstrap feature is enabled.
+func (nc *namespaceQueueController) Run(stopCh <-chan struct{}) {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.NamespaceQueueBootstrap) {
+		klog.Infof("Feature %s is disabled, namespace queue controller will not run", features.NamespaceQueueBootstrap)
+		return
+	}
+	defer nc.queue.ShutDown()
+
+	klog.Infof("Starting namespace queue controller")
+	defer klog.Infof("Shutting down namespace queue controller")
+
+	nc.informerFactory.Start(stopCh)
+	nc.vcInformerFactory.Start(stopCh)
+	go nc.configMapInformer.Run(stopCh)
+	for informerType, ok := range nc.informerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("caches failed to sync: %v", informerType)
+			return
+		}
+	}
+	for informerType, ok := range nc.vcInformerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			klog.Errorf("caches failed to sync: %v", informerType)
+			return
+		}
+	}
+	if !cache.WaitForCacheSync(stopCh, nc.configMapInformer.HasSynced) {
+		klog.Errorf("caches failed to sync: controller ConfigMap")
+		return
+	}
+
+	for i := 0; i < int(nc.workers); i++ {
+		go wait.Until(nc.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (nc *namespaceQueueController) addNamespace(obj interface{}) {
+	ns, ok := obj.(*v1.Namespace)
+	if !ok {
+		klog.Errorf("obj is not Namespace")
+		return
+	}
+	nc.queue.Add(ns.Name)
+}
+
+func (nc *namespaceQueueController) updateConfig(obj interface{}) {
+	cm, ok := obj.(*v1.ConfigMap)
+	if !ok {
+		klog.Errorf("obj is not ConfigMap")
+		return
+	}
+	conf, err := parseConfig(cm.Data[controllerconfig.DefaultConfigKey])
+	if err != nil {
+		klog.Errorf("Failed to load namespace queues config from ConfigMap <%s/%s>: %v", cm.Namespace, cm.Name, err)
+		return
+	}
+	nc.setConfig(conf)
+}
+
+// setConfig replaces the configuration and resyncs all the namespaces, as the queues or weights of any of them
+// may change.
+func (nc *namespaceQueueController) setConfig(conf *BootstrapConfig) {
+	nc.mutex.Lock()
+	nc.config = conf
+	nc.mutex.Unlock()
+
+	namespaces, err := nc.nsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list namespaces: %v", err)
+		return
+	}
+	for _, ns := range namespaces {
+		nc.queue.Add(ns.Name)
+	}
+}
+
+func (nc *namespaceQueueController) getConfig() *BootstrapConfig {
+	nc.mutex.RLock()
+	defer nc.mutex.RUnlock()
+	return nc.config
+}
+
+func (nc *namespaceQueueController) worker() {
+	for nc.processNextWorkItem() {
+	}
+}
+
+func (nc *namespaceQueueController) processNextWorkItem() bool {
+	key, quit := nc.queue.Get()
+	if quit {
+		return false
+	}
+	defer nc.queue.Done(key)
+
+	if err := nc.sync(key); err != nil {
+		klog.V(4).Infof("Error syncing the queue of namespace %s: %v", key, err)
+		nc.queue.AddRateLimited(key)
+		return true
+	}
+	nc.queue.Forget(key)
+	return true
+}
+
+// sync ensures the queue of the namespace exists with the configured weight, and is the default queue of the
+// namespace unless the namespace already has one.
+func (nc *namespaceQueueController) sync(name string) error {
+	conf := nc.getConfig()
+	if conf == nil {
+		return nil
+	}
+
+	ns, err := nc.nsLister.Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if ns.DeletionTimestamp != nil {
+		return nil
+	}
+	tenant, selected := conf.tenant(ns)
+	if !selected {
+		return nil
+	}
+
+	queueName := conf.queueName(tenant)
+	if err := nc.syncQueue(queueName, conf.weight(tenant)); err != nil {
+		return err
+	}
+	if _, found := ns.Annotations[schedulingv1beta1.QueueNameAnnotationKey]; found {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{schedulingv1beta1.QueueNameAnnotationKey: queueName},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = nc.kubeClient.CoreV1().Namespaces().Patch(context.TODO(), ns.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to set queue %s to namespace %s: %v", queueName, ns.Name, err)
+	}
+	klog.V(3).Infof("Set queue %s as the default queue of namespace %s", queueName, ns.Name)
+	return nil
+}
+
+// syncQueue creates the queue with the weight, or updates the weight of the queue created by the controller.
+func (nc *namespaceQueueController) syncQueue(name string, weight int32) error {
+	queue, err := nc.queueLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		queue = &schedulingv1beta1.Queue{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{ManagedByLabel: managedByValue},
+			},
+			Spec: schedulingv1beta1.QueueSpec{
+				Weight: weight,
+			},
+		}
+		_, err = nc.vcClient.SchedulingV1beta1().Queues().Create(context.TODO(), queue, metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create queue %s: %v", name, err)
+		}
+		klog.V(3).Infof("Created queue %s with weight %d", name, weight)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if queue.Labels[ManagedByLabel] != managedByValue || queue.Spec.Weight == weight {
+		return nil
+	}
+	queue = queue.DeepCopy()
+	queue.Spec.Weight = weight
+	if _, err := nc.vcClient.SchedulingV1beta1().Queues().Update(context.TODO(), queue, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update the weight of queue %s: %v", name, err)
+	}
+	klog.V(3).Infof("Updated the weight of queue %s to %d", name, weight)
+	return nil
+}
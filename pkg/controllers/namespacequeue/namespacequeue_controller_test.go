@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacequeue
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	kubeclient "k8s.io/client-go/kubernetes/fake"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	vcclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+	vcinformer "volcano.sh/apis/pkg/client/informers/externalversions"
+	"volcano.sh/volcano/pkg/controllers/framework"
+)
+
+const testConfig = `
+networkTopologyDiscovery: []
+namespaceQueues:
+  namespaceSelector: volcano.sh/tenant
+  teamLabel: team
+  queuePrefix: team-
+  defaultWeight: 2
+  weights:
+    ml: 4
+`
+
+func newFakeController(t *testing.T, namespaces []*v1.Namespace, queues []*schedulingv1beta1.Queue) *namespaceQueueController {
+	kubeClient := kubeclient.NewSimpleClientset()
+	vcClient := vcclient.NewSimpleClientset()
+	nc := &namespaceQueueController{}
+	opt := &framework.ControllerOption{
+		KubeClient:              kubeClient,
+		VolcanoClient:           vcClient,
+		SharedInformerFactory:   informers.NewSharedInformerFactory(kubeClient, 0),
+		VCSharedInformerFactory: vcinformer.NewSharedInformerFactory(vcClient, 0),
+		WorkerNum:               1,
+	}
+	if err := nc.Initialize(opt); err != nil {
+		t.Fatalf("failed to initialize controller: %v", err)
+	}
+	for _, ns := range namespaces {
+		if _, err := kubeClient.CoreV1().Namespaces().Create(context.TODO(), ns, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("failed to create namespace: %v", err)
+		}
+		opt.SharedInformerFactory.Core().V1().Namespaces().Informer().GetIndexer().Add(ns)
+	}
+	for _, queue := range queues {
+		if _, err := vcClient.SchedulingV1beta1().Queues().Create(context.TODO(), queue, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("failed to create queue: %v", err)
+		}
+		opt.VCSharedInformerFactory.Scheduling().V1beta1().Queues().Informer().GetIndexer().Add(queue)
+	}
+	return nc
+}
+
+func TestParseConfig(t *testing.T) {
+	conf, err := parseConfig(testConfig)
+	if err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "ml-dev",
+		Labels: map[string]string{"volcano.sh/tenant": "true", "team": "ml"},
+	}}
+	tenant, selected := conf.tenant(ns)
+	if !selected || tenant != "ml" {
+		t.Errorf("expected tenant ml selected, got %q %v", tenant, selected)
+	}
+	if name := conf.queueName(tenant); name != "team-ml" {
+		t.Errorf("expected queue team-ml, got %s", name)
+	}
+	if weight := conf.weight("ml"); weight != 4 {
+		t.Errorf("expected weight 4 of ml, got %d", weight)
+	}
+	if weight := conf.weight("infra"); weight != 2 {
+		t.Errorf("expected default weight 2, got %d", weight)
+	}
+
+	if _, selected := conf.tenant(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "no-team",
+		Labels: map[string]string{"volcano.sh/tenant": "true"},
+	}}); selected {
+		t.Errorf("expected namespace without team label not selected")
+	}
+
+	if conf, err := parseConfig("networkTopologyDiscovery: []"); err != nil || conf != nil {
+		t.Errorf("expected no config without namespaceQueues section, got %v %v", conf, err)
+	}
+	if _, err := parseConfig("namespaceQueues:\n  namespaceSelector: '=x'"); err == nil {
+		t.Errorf("expected error of invalid namespaceSelector")
+	}
+}
+
+func TestSync(t *testing.T) {
+	conf, err := parseConfig(testConfig)
+	if err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	namespaces := []*v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:   "ml-dev",
+			Labels: map[string]string{"volcano.sh/tenant": "true", "team": "ml"},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:        "infra",
+			Labels:      map[string]string{"volcano.sh/tenant": "true", "team": "infra"},
+			Annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "custom"},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+	}
+	queues := []*schedulingv1beta1.Queue{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-infra", Labels: map[string]string{ManagedByLabel: managedByValue}},
+			Spec:       schedulingv1beta1.QueueSpec{Weight: 1},
+		},
+	}
+	nc := newFakeController(t, namespaces, queues)
+	nc.setConfig(conf)
+
+	for _, ns := range namespaces {
+		if err := nc.sync(ns.Name); err != nil {
+			t.Fatalf("failed to sync namespace %s: %v", ns.Name, err)
+		}
+	}
+
+	ml, err := nc.vcClient.SchedulingV1beta1().Queues().Get(context.TODO(), "team-ml", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected queue team-ml created: %v", err)
+	}
+	if ml.Spec.Weight != 4 || ml.Labels[ManagedByLabel] != managedByValue {
+		t.Errorf("expected managed queue team-ml of weight 4, got %v", ml)
+	}
+	infra, err := nc.vcClient.SchedulingV1beta1().Queues().Get(context.TODO(), "team-infra", metav1.GetOptions{})
+	if err != nil || infra.Spec.Weight != 2 {
+		t.Errorf("expected weight of queue team-infra synced to 2, got %v %v", infra, err)
+	}
+	if _, err := nc.vcClient.SchedulingV1beta1().Queues().Get(context.TODO(), "team-kube-system", metav1.GetOptions{}); err == nil {
+		t.Errorf("expected no queue created for the namespace not selected")
+	}
+
+	for name, expected := range map[string]string{"ml-dev": "team-ml", "infra": "custom", "kube-system": ""} {
+		ns, err := nc.kubeClient.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get namespace %s: %v", name, err)
+		}
+		if queue := ns.Annotations[schedulingv1beta1.QueueNameAnnotationKey]; queue != expected {
+			t.Errorf("expected queue %q of namespace %s, got %q", expected, name, queue)
+		}
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package podgroup
 
 import (
+	"fmt"
 	"slices"
 
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -58,6 +59,8 @@ type pgcontroller struct {
 
 	// A store of pods
 	podLister corelisters.PodLister
+	// podIndexer indexes the bare pods grouped by annotation by their groups
+	podIndexer cache.Indexer
 
 	// A store of podgroups
 	pgLister schedulinglister.PodGroupLister
@@ -90,8 +93,14 @@ func (pg *pgcontroller) Initialize(opt *framework.ControllerOption) error {
 	pg.informerFactory = opt.SharedInformerFactory
 	pg.podInformer = opt.SharedInformerFactory.Core().V1().Pods()
 	pg.podLister = pg.podInformer.Lister()
+	if err := pg.podInformer.Informer().AddIndexers(cache.Indexers{groupedPodIndex: groupedPodIndexFunc}); err != nil {
+		return fmt.Errorf("failed to add group indexer of pods: %v", err)
+	}
+	pg.podIndexer = pg.podInformer.Informer().GetIndexer()
 	pg.podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: pg.addPod,
+		AddFunc:    pg.addPod,
+		UpdateFunc: pg.updatePod,
+		DeleteFunc: pg.deletePod,
 	})
 
 	factory := opt.VCSharedInformerFactory
@@ -153,6 +162,18 @@ func (pg *pgcontroller) processNextReq() bool {
 
 	defer pg.queue.Done(req)
 
+	// bare pods grouped by annotation
+	if req.groupName != "" {
+		klog.V(4).Infof("Try to create or update podgroup for group %s/%s", req.podNamespace, req.groupName)
+		if err := pg.syncGroupedPods(req.podNamespace, req.groupName); err != nil {
+			klog.Errorf("Failed to handle group <%s/%s>: %v", req.podNamespace, req.groupName, err)
+			pg.queue.AddRateLimited(req)
+			return true
+		}
+		pg.queue.Forget(req)
+		return true
+	}
+
 	pod, err := pg.podLister.Pods(req.podNamespace).Get(req.podName)
 	if err != nil {
 		klog.Errorf("Failed to get pod by <%v> from cache: %v", req, err)
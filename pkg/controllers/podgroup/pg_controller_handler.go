@@ -21,15 +21,19 @@ import (
 	"encoding/json"
 	"reflect"
 	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	quotav1 "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
 	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
@@ -41,11 +45,37 @@ import (
 
 const (
 	controllerRevisionHashLabelKey = "controller-revision-hash"
+
+	// groupNameAnnotationKey is the annotation of the bare pods to be gang scheduled together, the pods with the
+	// same value in a namespace share the PodGroup of the name.
+	groupNameAnnotationKey = "pod-group.scheduling.volcano.sh/name"
+	// groupMinMemberAnnotationKey is the annotation of the bare pods holding the minMember of their PodGroup.
+	groupMinMemberAnnotationKey = "pod-group.scheduling.volcano.sh/min-member"
+
+	// groupedPodIndex indexes the bare pods grouped by annotation by the namespace/name of their group.
+	groupedPodIndex = "pg-grouped-pods"
 )
 
 type podRequest struct {
 	podName      string
 	podNamespace string
+	// groupName is set instead of the podName to sync the PodGroup of the bare pods grouped by annotation.
+	groupName string
+}
+
+func groupedPodIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok || !isGroupedPod(pod) {
+		return nil, nil
+	}
+	return []string{pod.Namespace + "/" + pod.Annotations[groupNameAnnotationKey]}, nil
+}
+
+func newGroupRequest(pod *v1.Pod) podRequest {
+	return podRequest{
+		podNamespace: pod.Namespace,
+		groupName:    pod.Annotations[groupNameAnnotationKey],
+	}
 }
 
 type metadataForMergePatch struct {
@@ -63,6 +93,11 @@ func (pg *pgcontroller) addPod(obj interface{}) {
 		return
 	}
 
+	if isGroupedPod(pod) {
+		pg.queue.Add(newGroupRequest(pod))
+		return
+	}
+
 	req := podRequest{
 		podName:      pod.Name,
 		podNamespace: pod.Namespace,
@@ -71,6 +106,58 @@ func (pg *pgcontroller) addPod(obj interface{}) {
 	pg.queue.Add(req)
 }
 
+// updatePod syncs the groups of the bare pods grouped by annotation, which change when their pods finish, are
+// being deleted or change the group or its minMember.
+func (pg *pgcontroller) updatePod(oldObj, newObj interface{}) {
+	oldPod, ok := oldObj.(*v1.Pod)
+	if !ok {
+		klog.Errorf("Failed to convert %v to v1.Pod", oldObj)
+		return
+	}
+	newPod, ok := newObj.(*v1.Pod)
+	if !ok {
+		klog.Errorf("Failed to convert %v to v1.Pod", newObj)
+		return
+	}
+
+	if oldPod.Status.Phase == newPod.Status.Phase &&
+		oldPod.DeletionTimestamp.Equal(newPod.DeletionTimestamp) &&
+		isGroupedPod(oldPod) == isGroupedPod(newPod) &&
+		oldPod.Annotations[groupNameAnnotationKey] == newPod.Annotations[groupNameAnnotationKey] &&
+		oldPod.Annotations[groupMinMemberAnnotationKey] == newPod.Annotations[groupMinMemberAnnotationKey] {
+		return
+	}
+
+	if isGroupedPod(oldPod) && (!isGroupedPod(newPod) ||
+		oldPod.Annotations[groupNameAnnotationKey] != newPod.Annotations[groupNameAnnotationKey]) {
+		pg.queue.Add(newGroupRequest(oldPod))
+	}
+	if isGroupedPod(newPod) {
+		pg.queue.Add(newGroupRequest(newPod))
+	}
+}
+
+// deletePod syncs the group of the deleted bare pod grouped by annotation.
+func (pg *pgcontroller) deletePod(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Couldn't get object from tombstone %#v", obj)
+			return
+		}
+		pod, ok = tombstone.Obj.(*v1.Pod)
+		if !ok {
+			klog.Errorf("Tombstone contained object that is not a Pod: %#v", obj)
+			return
+		}
+	}
+
+	if isGroupedPod(pod) {
+		pg.queue.Add(newGroupRequest(pod))
+	}
+}
+
 func (pg *pgcontroller) addReplicaSet(obj interface{}) {
 	rs, ok := obj.(*appsv1.ReplicaSet)
 	if !ok {
@@ -105,6 +192,10 @@ func (pg *pgcontroller) addReplicaSet(obj interface{}) {
 				klog.V(4).Infof("Pod %s field SchedulerName is not matched", klog.KObj(&pod))
 				return
 			}
+			if isGroupedPod(&pod) {
+				klog.V(4).Infof("Pod %s is grouped by annotation %s", klog.KObj(&pod), groupNameAnnotationKey)
+				return
+			}
 			err := pg.createNormalPodPGIfNotExist(&pod)
 			if err != nil {
 				klog.Errorf("Failed to create PodGroup for pod %s: %v", klog.KObj(&pod), err)
@@ -166,6 +257,10 @@ func (pg *pgcontroller) addStatefulSet(obj interface{}) {
 				klog.V(4).Infof("Pod %s is already associated with a podgroup %s", klog.KObj(pod), pgName)
 				return
 			}
+			if isGroupedPod(pod) {
+				klog.V(4).Infof("Pod %s is grouped by annotation %s", klog.KObj(pod), groupNameAnnotationKey)
+				return
+			}
 
 			err := pg.createOrUpdateNormalPodPG(pod)
 			if err != nil {
@@ -492,6 +587,153 @@ func (pg *pgcontroller) shouldUpdateExistingPodGroup(podGroup *scheduling.PodGro
 	return isUpdated
 }
 
+// isGroupedPod checks whether the pod is a bare pod grouped by the groupNameAnnotationKey, the pods of vcjob are
+// grouped by the job controller.
+func isGroupedPod(pod *v1.Pod) bool {
+	if pod.Annotations[groupNameAnnotationKey] == "" {
+		return false
+	}
+	controllerRef := metav1.GetControllerOf(pod)
+	return controllerRef == nil || controllerRef.APIVersion != helpers.JobKind.GroupVersion().String() ||
+		controllerRef.Kind != helpers.JobKind.Kind
+}
+
+// syncGroupedPods creates or updates the PodGroup shared by the active pods of the group, with the minMember of
+// the latest pod and the minResources aggregated from the pods of the group, and adds the pods to the PodGroup.
+// The PodGroup is left to the garbage collector once no pod of the group is active.
+func (pg *pgcontroller) syncGroupedPods(namespace, pgName string) error {
+	pods, err := pg.listGroupedPods(namespace, pgName)
+	if err != nil {
+		klog.Errorf("Failed to list pods of group <%s/%s>: %v", namespace, pgName, err)
+		return err
+	}
+	if len(pods) == 0 {
+		klog.V(4).Infof("No active pod in group <%s/%s>", namespace, pgName)
+		return nil
+	}
+	pod := pods[len(pods)-1]
+	newPodGroup := pg.buildPodGroupFromGroupedPods(pod, pods, pgName)
+
+	if podGroup, err := pg.pgLister.PodGroups(pod.Namespace).Get(pgName); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to get PodGroup <%s/%s> for Pod <%s/%s>: %v",
+				pod.Namespace, pgName, pod.Namespace, pod.Name, err)
+			return err
+		}
+
+		if _, err := pg.vcClient.SchedulingV1beta1().PodGroups(pod.Namespace).Create(context.TODO(), newPodGroup, metav1.CreateOptions{}); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// The PodGroup is created for another pod of the group in the meantime, update it in the retry.
+				klog.V(4).Infof("PodGroup <%s/%s> already exists for Pod <%s/%s>",
+					pod.Namespace, pgName, pod.Namespace, pod.Name)
+			} else {
+				klog.Errorf("Failed to create PodGroup <%s/%s> for Pod <%s/%s>: %v",
+					pod.Namespace, pgName, pod.Namespace, pod.Name, err)
+			}
+			return err
+		}
+		klog.V(4).Infof("PodGroup <%s/%s> created for Pod <%s/%s>",
+			pod.Namespace, pgName, pod.Namespace, pod.Name)
+	} else if podGroup.Spec.MinMember != newPodGroup.Spec.MinMember ||
+		!equality.Semantic.DeepEqual(podGroup.Spec.MinResources, newPodGroup.Spec.MinResources) ||
+		!equality.Semantic.DeepEqual(podGroup.OwnerReferences, newPodGroup.OwnerReferences) {
+		podGroupToUpdate := podGroup.DeepCopy()
+		podGroupToUpdate.Spec.MinMember = newPodGroup.Spec.MinMember
+		podGroupToUpdate.Spec.MinResources = newPodGroup.Spec.MinResources
+		podGroupToUpdate.OwnerReferences = newPodGroup.OwnerReferences
+		if _, err := pg.vcClient.SchedulingV1beta1().PodGroups(pod.Namespace).Update(context.TODO(), podGroupToUpdate, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("Failed to update PodGroup <%s/%s>: %v", pod.Namespace, pgName, err)
+			return err
+		}
+		klog.V(4).Infof("PodGroup <%s/%s> updated for Pod <%s/%s>",
+			pod.Namespace, pgName, pod.Namespace, pod.Name)
+	}
+
+	for _, groupedPod := range pods {
+		if groupedPod.Annotations[scheduling.KubeGroupNameAnnotationKey] == pgName {
+			continue
+		}
+		if err := pg.updatePodAnnotations(groupedPod, pgName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listGroupedPods lists the active pods of the group scheduled by the schedulers of the controller from the index,
+// ordered by the creation time.
+func (pg *pgcontroller) listGroupedPods(namespace, groupName string) ([]*v1.Pod, error) {
+	objs, err := pg.podIndexer.ByIndex(groupedPodIndex, namespace+"/"+groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	var grouped []*v1.Pod
+	for _, obj := range objs {
+		pod, ok := obj.(*v1.Pod)
+		if !ok || !slices.Contains(pg.schedulerNames, pod.Spec.SchedulerName) {
+			continue
+		}
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		// the pod is already in another PodGroup
+		if name := pod.Annotations[scheduling.KubeGroupNameAnnotationKey]; name != "" && name != groupName {
+			continue
+		}
+		grouped = append(grouped, pod)
+	}
+	sort.Slice(grouped, func(i, j int) bool {
+		if !grouped[i].CreationTimestamp.Equal(&grouped[j].CreationTimestamp) {
+			return grouped[i].CreationTimestamp.Before(&grouped[j].CreationTimestamp)
+		}
+		return grouped[i].Name < grouped[j].Name
+	})
+	return grouped, nil
+}
+
+// buildPodGroupFromGroupedPods builds the PodGroup of the group from the pod, the minResources is the sum of the
+// requests of the first minMember pods of the group, and the PodGroup is owned by all the pods of the group, so
+// that it is garbage collected with the last pod.
+func (pg *pgcontroller) buildPodGroupFromGroupedPods(pod *v1.Pod, pods []*v1.Pod, pgName string) *scheduling.PodGroup {
+	obj := pg.buildPodGroupFromPod(pod, pgName)
+
+	minMember := getGroupMinMember(pod)
+	minResources := v1.ResourceList{}
+	ownerReferences := make([]metav1.OwnerReference, 0, len(pods))
+	for i, groupedPod := range pods {
+		if int32(i) < minMember {
+			minResources = quotav1.Add(minResources, util.GetPodQuotaUsage(groupedPod))
+		}
+		ownerReferences = append(ownerReferences, metav1.OwnerReference{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+			Name:       groupedPod.Name,
+			UID:        groupedPod.UID,
+		})
+	}
+
+	obj.OwnerReferences = ownerReferences
+	obj.Spec.MinMember = minMember
+	obj.Spec.MinResources = &minResources
+	return obj
+}
+
+// getGroupMinMember gets the minMember of the group from the pod, 1 if it is not set or invalid.
+func getGroupMinMember(pod *v1.Pod) int32 {
+	value, found := pod.Annotations[groupMinMemberAnnotationKey]
+	if !found {
+		return 1
+	}
+	minMember, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || minMember < 1 {
+		klog.Errorf("Invalid %s %q of Pod <%s/%s>, minMember remains as 1",
+			groupMinMemberAnnotationKey, value, pod.Namespace, pod.Name)
+		return 1
+	}
+	return int32(minMember)
+}
+
 func newPGOwnerReferences(pod *v1.Pod) []metav1.OwnerReference {
 	if len(pod.OwnerReferences) != 0 {
 		for _, ownerReference := range pod.OwnerReferences {
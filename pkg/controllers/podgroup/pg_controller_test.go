@@ -1261,3 +1261,206 @@ func TestBuildPodGroupFromPodWithNetworkTopology(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncGroupedPods(t *testing.T) {
+	namespace := "test"
+	c := newFakeController()
+
+	buildGroupedPod := func(name string, createdAt int64) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				UID:               types.UID(name),
+				CreationTimestamp: metav1.Unix(createdAt, 0),
+				Annotations: map[string]string{
+					groupNameAnnotationKey:            "group1",
+					groupMinMemberAnnotationKey:       "2",
+					scheduling.QueueNameAnnotationKey: "q1",
+				},
+			},
+			Spec: v1.PodSpec{
+				SchedulerName: "volcano",
+				Containers: []v1.Container{{
+					Name: "c1",
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+					},
+				}},
+			},
+		}
+	}
+
+	for i, pod := range []*v1.Pod{buildGroupedPod("pod1", 1), buildGroupedPod("pod2", 2), buildGroupedPod("pod3", 3)} {
+		if _, err := c.kubeClient.CoreV1().Pods(namespace).Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("Failed to create pod %s: %v", pod.Name, err)
+		}
+		if err := c.podInformer.Informer().GetIndexer().Add(pod); err != nil {
+			t.Fatalf("Failed to add pod %s to cache: %v", pod.Name, err)
+		}
+
+		if err := c.syncGroupedPods(namespace, "group1"); err != nil {
+			t.Fatalf("Failed to create or update podgroup for pod %s: %v", pod.Name, err)
+		}
+
+		pg, err := c.vcClient.SchedulingV1beta1().PodGroups(namespace).Get(context.TODO(), "group1", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Failed to get podgroup: %v", err)
+		}
+		if err := c.pgInformer.Informer().GetIndexer().Update(pg); err != nil {
+			t.Fatalf("Failed to add podgroup to cache: %v", err)
+		}
+
+		if pg.Spec.MinMember != 2 || pg.Spec.Queue != "q1" {
+			t.Errorf("expected minMember 2 and queue q1, got %d and %s", pg.Spec.MinMember, pg.Spec.Queue)
+		}
+		// the minResources is aggregated from the first minMember pods
+		expectedCPU := resource.MustParse(fmt.Sprintf("%d", min(i+1, 2)))
+		if cpu := pg.Spec.MinResources.Cpu(); cpu.Cmp(expectedCPU) != 0 {
+			t.Errorf("after pod %s, expected minResources cpu %s, got %s", pod.Name, expectedCPU.String(), cpu.String())
+		}
+		if len(pg.OwnerReferences) != i+1 {
+			t.Errorf("after pod %s, expected %d owners, got %v", pod.Name, i+1, pg.OwnerReferences)
+		}
+
+		newPod, err := c.kubeClient.CoreV1().Pods(namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Failed to get pod %s: %v", pod.Name, err)
+		}
+		if newPod.Annotations[scheduling.KubeGroupNameAnnotationKey] != "group1" {
+			t.Errorf("expected pod %s in podgroup group1, got %s", pod.Name, newPod.Annotations[scheduling.KubeGroupNameAnnotationKey])
+		}
+	}
+
+	// the finished pod leaves the group
+	finished := buildGroupedPod("pod1", 1)
+	finished.Status.Phase = v1.PodSucceeded
+	if err := c.podInformer.Informer().GetIndexer().Update(finished); err != nil {
+		t.Fatalf("Failed to update pod %s in cache: %v", finished.Name, err)
+	}
+	if err := c.syncGroupedPods(namespace, "group1"); err != nil {
+		t.Fatalf("Failed to update podgroup after pod %s finished: %v", finished.Name, err)
+	}
+	pg, err := c.vcClient.SchedulingV1beta1().PodGroups(namespace).Get(context.TODO(), "group1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get podgroup: %v", err)
+	}
+	if len(pg.OwnerReferences) != 2 || pg.OwnerReferences[0].Name != "pod2" {
+		t.Errorf("expected owners pod2 and pod3, got %v", pg.OwnerReferences)
+	}
+}
+
+func TestGroupedPodEventHandlers(t *testing.T) {
+	c := newFakeController()
+	groupedPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:        "pod1",
+		Namespace:   "test",
+		Annotations: map[string]string{groupNameAnnotationKey: "group1"},
+	}}
+	labeledPod := groupedPod.DeepCopy()
+	labeledPod.Labels = map[string]string{"a": "b"}
+	runningPod := groupedPod.DeepCopy()
+	runningPod.Status.Phase = v1.PodRunning
+	movedPod := groupedPod.DeepCopy()
+	movedPod.Annotations[groupNameAnnotationKey] = "group2"
+
+	expected := func(groups ...string) []podRequest {
+		requests := []podRequest{}
+		for _, group := range groups {
+			requests = append(requests, podRequest{podNamespace: "test", groupName: group})
+		}
+		return requests
+	}
+	testCases := []struct {
+		name     string
+		handle   func()
+		expected []podRequest
+	}{
+		{
+			name:     "add pod",
+			handle:   func() { c.addPod(groupedPod) },
+			expected: expected("group1"),
+		},
+		{
+			name:     "update unrelated to group",
+			handle:   func() { c.updatePod(groupedPod, labeledPod) },
+			expected: expected(),
+		},
+		{
+			name:     "update phase",
+			handle:   func() { c.updatePod(groupedPod, runningPod) },
+			expected: expected("group1"),
+		},
+		{
+			name:     "move to another group",
+			handle:   func() { c.updatePod(groupedPod, movedPod) },
+			expected: expected("group1", "group2"),
+		},
+		{
+			name:     "delete pod",
+			handle:   func() { c.deletePod(cache.DeletedFinalStateUnknown{Obj: groupedPod}) },
+			expected: expected("group1"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.handle()
+			requests := []podRequest{}
+			for c.queue.Len() > 0 {
+				req, _ := c.queue.Get()
+				c.queue.Done(req)
+				requests = append(requests, req)
+			}
+			assert.Equal(t, tc.expected, requests)
+		})
+	}
+}
+
+func TestIsGroupedPod(t *testing.T) {
+	isController := true
+	testCases := []struct {
+		name     string
+		pod      *v1.Pod
+		expected bool
+	}{
+		{
+			name:     "pod without group annotation",
+			pod:      &v1.Pod{},
+			expected: false,
+		},
+		{
+			name: "bare pod with group annotation",
+			pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{groupNameAnnotationKey: "group1"},
+			}},
+			expected: true,
+		},
+		{
+			name: "pod of operator with group annotation",
+			pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{groupNameAnnotationKey: "group1"},
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "kubeflow.org/v1", Kind: "MPIJob", Name: "mpi1", Controller: &isController},
+				},
+			}},
+			expected: true,
+		},
+		{
+			name: "pod of vcjob with group annotation",
+			pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{groupNameAnnotationKey: "group1"},
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: vcbatch.SchemeGroupVersion.String(), Kind: "Job", Name: "job1", Controller: &isController},
+				},
+			}},
+			expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, isGroupedPod(testCase.pod))
+		})
+	}
+}
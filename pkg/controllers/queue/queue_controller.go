@@ -28,8 +28,10 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -74,7 +76,15 @@ type queuecontroller struct {
 	cmdLister   busv1alpha1lister.CommandLister
 	cmdSynced   cache.InformerSynced
 
+	// node lister, the capability of the node pool of queues is calculated from nodes
+	nodeLister corelisters.NodeLister
+	nodeSynced cache.InformerSynced
+
+	// pod indexer by podgroup, the pods of the podgroups are deleted when the draining queue evicts them
+	podIndexer cache.Indexer
+
 	vcInformerFactory vcinformer.SharedInformerFactory
+	informerFactory   informers.SharedInformerFactory
 
 	// queues that need to be updated.
 	queue        workqueue.TypedRateLimitingInterface[*apis.Request]
@@ -140,6 +150,24 @@ func (c *queuecontroller) Initialize(opt *framework.ControllerOption) error {
 		DeleteFunc: c.deletePodGroup,
 	})
 
+	if opt.SharedInformerFactory != nil {
+		c.informerFactory = opt.SharedInformerFactory
+		nodeInformer := opt.SharedInformerFactory.Core().V1().Nodes()
+		c.nodeLister = nodeInformer.Lister()
+		c.nodeSynced = nodeInformer.Informer().HasSynced
+		nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addNode,
+			UpdateFunc: c.updateNode,
+			DeleteFunc: c.deleteNode,
+		})
+
+		podInformer := opt.SharedInformerFactory.Core().V1().Pods()
+		if err := podInformer.Informer().AddIndexers(cache.Indexers{podGroupIndex: podGroupIndexFunc}); err != nil {
+			return fmt.Errorf("failed to add podgroup indexer of pods: %v", err)
+		}
+		c.podIndexer = podInformer.Informer().GetIndexer()
+	}
+
 	if utilfeature.DefaultFeatureGate.Enabled(features.QueueCommandSync) {
 		c.cmdInformer = factory.Bus().V1alpha1().Commands()
 		c.cmdInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
@@ -162,6 +190,7 @@ func (c *queuecontroller) Initialize(opt *framework.ControllerOption) error {
 	queuestate.SyncQueue = c.syncQueue
 	queuestate.OpenQueue = c.openQueue
 	queuestate.CloseQueue = c.closeQueue
+	queuestate.DrainQueue = c.drainQueue
 
 	c.syncHandler = c.handleQueue
 	c.syncCommandHandler = c.handleCommand
@@ -181,6 +210,15 @@ func (c *queuecontroller) Run(stopCh <-chan struct{}) {
 	defer klog.Infof("Shutting down queue controller.")
 
 	c.vcInformerFactory.Start(stopCh)
+	if c.informerFactory != nil {
+		c.informerFactory.Start(stopCh)
+		for informerType, ok := range c.informerFactory.WaitForCacheSync(stopCh) {
+			if !ok {
+				klog.Errorf("caches failed to sync: %v", informerType)
+				return
+			}
+		}
+	}
 
 	for informerType, ok := range c.vcInformerFactory.WaitForCacheSync(stopCh) {
 		if !ok {
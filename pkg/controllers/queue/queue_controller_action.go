@@ -37,6 +37,7 @@ import (
 	"volcano.sh/volcano/pkg/controllers/apis"
 	"volcano.sh/volcano/pkg/controllers/metrics"
 	"volcano.sh/volcano/pkg/controllers/queue/state"
+	"volcano.sh/volcano/pkg/scheduler/api"
 )
 
 const (
@@ -55,6 +56,11 @@ func (c *queuecontroller) syncQueue(queue *schedulingv1beta1.Queue, updateStateF
 		return err
 	}
 
+	queue, err = c.syncNodePoolCapability(queue)
+	if err != nil {
+		return err
+	}
+
 	podGroups := c.getPodGroups(queue.Name)
 	queueStatus := schedulingv1beta1.QueueStatus{}
 
@@ -99,12 +105,11 @@ func (c *queuecontroller) syncQueue(queue *schedulingv1beta1.Queue, updateStateF
 	}
 
 	newQueue := queue.DeepCopy()
-	// ignore update when state does not change
-	if queueStatus.State != queue.Status.State {
-		queueStatusApply := v1beta1apply.QueueStatus().WithState(queueStatus.State)
-		queueApply := v1beta1apply.Queue(queue.Name).WithStatus(queueStatusApply)
+	// ignore update when neither state nor podgroup counts change
+	if queueStatus.State != queue.Status.State || !equalPodGroupCounts(&queueStatus, &queue.Status) {
+		queueApply := v1beta1apply.Queue(queue.Name).WithStatus(queueStatusApplyConfiguration(&queueStatus))
 		if newQueue, err = c.vcClient.SchedulingV1beta1().Queues().ApplyStatus(context.TODO(), queueApply, metav1.ApplyOptions{FieldManager: controllerName}); err != nil {
-			klog.Errorf("Update queue state from %s to %s failed for %v", queue.Status.State, queueStatus.State, err)
+			klog.Errorf("Update queue status of %s failed for %v", queue.Name, err)
 			return err
 		}
 	}
@@ -112,6 +117,25 @@ func (c *queuecontroller) syncQueue(queue *schedulingv1beta1.Queue, updateStateF
 	return c.syncHierarchicalQueue(newQueue)
 }
 
+// equalPodGroupCounts returns whether the podgroup counts of the queue status are the same.
+func equalPodGroupCounts(a, b *schedulingv1beta1.QueueStatus) bool {
+	return a.Pending == b.Pending && a.Running == b.Running && a.Unknown == b.Unknown &&
+		a.Inqueue == b.Inqueue && a.Completed == b.Completed
+}
+
+// queueStatusApplyConfiguration returns the status fields of the queue owned by the controller, the state and the
+// podgroup counts. They are always applied together, since the fields left out of an apply request are removed
+// from the fields owned by the controller.
+func queueStatusApplyConfiguration(status *schedulingv1beta1.QueueStatus) *v1beta1apply.QueueStatusApplyConfiguration {
+	return v1beta1apply.QueueStatus().
+		WithState(status.State).
+		WithPending(status.Pending).
+		WithRunning(status.Running).
+		WithUnknown(status.Unknown).
+		WithInqueue(status.Inqueue).
+		WithCompleted(status.Completed)
+}
+
 func (c *queuecontroller) openQueue(queue *schedulingv1beta1.Queue, updateStateFn state.UpdateQueueStatusFn) error {
 	klog.V(4).Infof("Begin to open queue %s.", queue.Name)
 
@@ -128,8 +152,7 @@ func (c *queuecontroller) openQueue(queue *schedulingv1beta1.Queue, updateStateF
 	}
 
 	if queue.Status.State != newQueue.Status.State {
-		queueStatusApply := v1beta1apply.QueueStatus().WithState(newQueue.Status.State)
-		queueApply := v1beta1apply.Queue(queue.Name).WithStatus(queueStatusApply)
+		queueApply := v1beta1apply.Queue(queue.Name).WithStatus(queueStatusApplyConfiguration(&newQueue.Status))
 		if _, err := c.vcClient.SchedulingV1beta1().Queues().ApplyStatus(context.TODO(), queueApply, metav1.ApplyOptions{FieldManager: controllerName}); err != nil {
 			c.recorder.Event(newQueue, v1.EventTypeWarning, string(v1alpha1.OpenQueueAction),
 				fmt.Sprintf("Update queue status from %s to %s failed for %v",
@@ -159,8 +182,7 @@ func (c *queuecontroller) closeQueue(queue *schedulingv1beta1.Queue, updateState
 	}
 
 	if queue.Status.State != newQueue.Status.State {
-		queueStatusApply := v1beta1apply.QueueStatus().WithState(newQueue.Status.State)
-		queueApply := v1beta1apply.Queue(queue.Name).WithStatus(queueStatusApply)
+		queueApply := v1beta1apply.Queue(queue.Name).WithStatus(queueStatusApplyConfiguration(&newQueue.Status))
 		if _, err := c.vcClient.SchedulingV1beta1().Queues().ApplyStatus(context.TODO(), queueApply, metav1.ApplyOptions{FieldManager: controllerName}); err != nil {
 			c.recorder.Event(newQueue, v1.EventTypeWarning, string(v1alpha1.CloseQueueAction),
 				fmt.Sprintf("Close queue failed for %v", err))
@@ -305,28 +327,50 @@ func (c *queuecontroller) updateQueueParent(queue *schedulingv1beta1.Queue) (*sc
 	return c.vcClient.SchedulingV1beta1().Queues().Patch(context.TODO(), queue.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
 }
 
+// ownedQueueAnnotations are the annotations of the queues written by the controller.
+var ownedQueueAnnotations = []string{ClosedByParentAnnotationKey, api.QueueNodePoolCapability}
+
+// queueAnnotationsApplyConfiguration returns the annotations of the queue owned by the controller with the key set to
+// the value, or left out if the value is nil. The owned annotations are always applied together, since the fields
+// left out of an apply request are removed from the fields owned by the controller.
+func queueAnnotationsApplyConfiguration(queue *schedulingv1beta1.Queue, key string, value *string) *v1beta1apply.QueueApplyConfiguration {
+	annotations := map[string]string{}
+	for _, owned := range ownedQueueAnnotations {
+		if current, found := queue.Annotations[owned]; found && owned != key {
+			annotations[owned] = current
+		}
+	}
+	if value != nil {
+		annotations[key] = *value
+	}
+	return v1beta1apply.Queue(queue.Name).WithUID(queue.UID).WithAnnotations(annotations)
+}
+
 func (c *queuecontroller) updateQueueAnnotation(queue *schedulingv1beta1.Queue, key string, value string) (*schedulingv1beta1.Queue, error) {
 	if len(queue.Annotations) > 0 && queue.Annotations[key] == value {
 		return queue, nil
 	}
 
-	var patch []patchOperation
-	if len(queue.Annotations) == 0 {
-		patch = append(patch, patchOperation{
-			Op:   "replace",
-			Path: "/metadata/annotations",
-			Value: map[string]string{
-				key: value,
-			},
-		})
-	} else {
-		patch = append(patch, patchOperation{
-			Op:    "replace",
-			Path:  fmt.Sprintf("/metadata/annotations/%s", strings.ReplaceAll(key, "/", "~1")),
-			Value: value,
-		})
+	// the annotations written by the patches of the former versions are taken over by force
+	return c.vcClient.SchedulingV1beta1().Queues().Apply(context.TODO(), queueAnnotationsApplyConfiguration(queue, key, &value),
+		metav1.ApplyOptions{FieldManager: controllerName, Force: true})
+}
+
+func (c *queuecontroller) removeQueueAnnotation(queue *schedulingv1beta1.Queue, key string) (*schedulingv1beta1.Queue, error) {
+	newQueue, err := c.vcClient.SchedulingV1beta1().Queues().Apply(context.TODO(), queueAnnotationsApplyConfiguration(queue, key, nil),
+		metav1.ApplyOptions{FieldManager: controllerName, Force: true})
+	if err != nil {
+		return nil, err
+	}
+	if _, found := newQueue.Annotations[key]; !found {
+		return newQueue, nil
 	}
 
+	// the annotation is not owned by the controller, e.g. written by a patch of the former versions
+	patch := []patchOperation{{
+		Op:   "remove",
+		Path: fmt.Sprintf("/metadata/annotations/%s", strings.ReplaceAll(key, "/", "~1")),
+	}}
 	patchBytes, err := json.Marshal(patch)
 	if err != nil {
 		return nil, err
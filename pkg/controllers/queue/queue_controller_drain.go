@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/apis"
+	"volcano.sh/volcano/pkg/controllers/queue/state"
+)
+
+// podGroupIndex indexes the pods by the namespace/name of their podgroups.
+const podGroupIndex = "queue-podgroup"
+
+func podGroupIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		return nil, nil
+	}
+	pgName := pod.Annotations[schedulingv1beta1.KubeGroupNameAnnotationKey]
+	if pgName == "" {
+		return nil, nil
+	}
+	return []string{pod.Namespace + "/" + pgName}, nil
+}
+
+// drainQueue syncs the state of the draining queue, and evicts or migrates its remaining jobs once the
+// drain deadline is passed. The queue is synced again at the deadline.
+func (c *queuecontroller) drainQueue(queue *schedulingv1beta1.Queue, updateStateFn state.UpdateQueueStatusFn) error {
+	klog.V(4).Infof("Begin to drain queue %s.", queue.Name)
+
+	if err := c.syncQueue(queue, updateStateFn); err != nil {
+		return err
+	}
+
+	deadline, found, err := drainDeadline(queue)
+	if err != nil {
+		c.recorder.Event(queue, v1.EventTypeWarning, string(state.DrainQueueAction), err.Error())
+		return nil
+	}
+	if !found {
+		return nil
+	}
+
+	if remaining := time.Until(deadline); remaining > 0 {
+		c.queue.AddAfter(&apis.Request{
+			QueueName: queue.Name,
+			Event:     busv1alpha1.OutOfSyncEvent,
+			Action:    busv1alpha1.SyncQueueAction,
+		}, remaining)
+		return nil
+	}
+
+	return c.drainPodGroups(queue)
+}
+
+// drainDeadline returns the drain deadline of the queue, and whether it is set.
+func drainDeadline(queue *schedulingv1beta1.Queue) (time.Time, bool, error) {
+	value, found := queue.Annotations[state.DrainDeadlineAnnotationKey]
+	if !found || value == "" {
+		return time.Time{}, false, nil
+	}
+	deadline, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s %q of queue %s, the remaining jobs are never evicted: %v",
+			state.DrainDeadlineAnnotationKey, value, queue.Name, err)
+	}
+	return deadline, true, nil
+}
+
+// drainPodGroups migrates the unfinished podgroups of the queue to the fallback queue if it is set,
+// otherwise the podgroups holding resources are evicted.
+func (c *queuecontroller) drainPodGroups(queue *schedulingv1beta1.Queue) error {
+	fallback := queue.Annotations[state.DrainFallbackQueueAnnotationKey]
+	if fallback != "" {
+		fallbackQueue, err := c.queueLister.Get(fallback)
+		if err != nil {
+			return fmt.Errorf("failed to get fallback queue %s of queue %s: %v", fallback, queue.Name, err)
+		}
+		if fallbackQueue.Status.State != schedulingv1beta1.QueueStateOpen {
+			c.recorder.Event(queue, v1.EventTypeWarning, string(state.DrainQueueAction),
+				fmt.Sprintf("Fallback queue %s is %s, wait for it to be opened", fallback, fallbackQueue.Status.State))
+			return fmt.Errorf("fallback queue %s of queue %s is not open", fallback, queue.Name)
+		}
+	}
+
+	for _, pgKey := range c.getPodGroups(queue.Name) {
+		// Ignore error here, it can not occur.
+		ns, name, _ := cache.SplitMetaNamespaceKey(pgKey)
+
+		pg, err := c.pgLister.PodGroups(ns).Get(name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if pg.Status.Phase == schedulingv1beta1.PodGroupCompleted {
+			continue
+		}
+
+		if fallback != "" {
+			if err := c.migratePodGroup(pg, fallback); err != nil {
+				return err
+			}
+			c.recorder.Event(queue, v1.EventTypeNormal, string(state.DrainQueueAction),
+				fmt.Sprintf("Migrate podgroup %s to queue %s after drain deadline", pgKey, fallback))
+			continue
+		}
+
+		// Pending podgroups hold no resources, they are left in the queue until they are deleted.
+		if pg.Status.Phase == schedulingv1beta1.PodGroupPending {
+			continue
+		}
+		if err := c.evictPodGroup(queue, pg); err != nil {
+			return err
+		}
+		c.recorder.Event(queue, v1.EventTypeNormal, string(state.DrainQueueAction),
+			fmt.Sprintf("Evict podgroup %s after drain deadline", pgKey))
+	}
+	return nil
+}
+
+// migratePodGroup moves the podgroup to the queue, the queue of the volcano job owning the podgroup is not changed.
+func (c *queuecontroller) migratePodGroup(pg *schedulingv1beta1.PodGroup, queue string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"queue": queue},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.vcClient.SchedulingV1beta1().PodGroups(pg.Namespace).Patch(context.TODO(), pg.Name,
+		types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to migrate podgroup %s/%s to queue %s: %v", pg.Namespace, pg.Name, queue, err)
+	}
+	return nil
+}
+
+// evictPodGroup aborts the volcano job owning the podgroup, or deletes the pods of other podgroups.
+func (c *queuecontroller) evictPodGroup(queue *schedulingv1beta1.Queue, pg *schedulingv1beta1.PodGroup) error {
+	if ref := metav1.GetControllerOf(pg); ref != nil &&
+		ref.APIVersion == batchv1alpha1.SchemeGroupVersion.String() && ref.Kind == "Job" {
+		cmd := &busv1alpha1.Command{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            fmt.Sprintf("%s-drain-%s", ref.Name, queue.Name),
+				Namespace:       pg.Namespace,
+				OwnerReferences: []metav1.OwnerReference{*ref},
+			},
+			TargetObject: ref,
+			Action:       string(busv1alpha1.AbortJobAction),
+		}
+		_, err := c.vcClient.BusV1alpha1().Commands(pg.Namespace).Create(context.TODO(), cmd, metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to abort job %s/%s: %v", pg.Namespace, ref.Name, err)
+		}
+		return nil
+	}
+
+	if c.podIndexer == nil {
+		return fmt.Errorf("failed to evict podgroup %s/%s: pods are not watched", pg.Namespace, pg.Name)
+	}
+	pods, err := c.podIndexer.ByIndex(podGroupIndex, pg.Namespace+"/"+pg.Name)
+	if err != nil {
+		return err
+	}
+	for _, obj := range pods {
+		pod, ok := obj.(*v1.Pod)
+		if !ok || pod.DeletionTimestamp != nil {
+			continue
+		}
+		err := c.kubeClient.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/apis"
+	"volcano.sh/volcano/pkg/controllers/queue/state"
+)
+
+func buildDrainQueue(name string, queueState schedulingv1beta1.QueueState, annotations map[string]string) *schedulingv1beta1.Queue {
+	return &schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations},
+		Spec:       schedulingv1beta1.QueueSpec{Parent: "root"},
+		Status:     schedulingv1beta1.QueueStatus{State: queueState},
+	}
+}
+
+func buildDrainPodGroup(name, queue string, phase schedulingv1beta1.PodGroupPhase, owner *metav1.OwnerReference) *schedulingv1beta1.PodGroup {
+	pg := &schedulingv1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name},
+		Spec:       schedulingv1beta1.PodGroupSpec{Queue: queue},
+		Status:     schedulingv1beta1.PodGroupStatus{Phase: phase},
+	}
+	if owner != nil {
+		pg.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return pg
+}
+
+func setupDrainController(t *testing.T, queues []*schedulingv1beta1.Queue, pgs []*schedulingv1beta1.PodGroup) *queuecontroller {
+	c := newFakeControllerWithNodes()
+	root := &schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: "root"},
+		Status:     schedulingv1beta1.QueueStatus{State: schedulingv1beta1.QueueStateOpen},
+	}
+	for _, queue := range append(queues, root) {
+		_, err := c.vcClient.SchedulingV1beta1().Queues().Create(context.TODO(), queue, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, c.queueInformer.Informer().GetIndexer().Add(queue))
+	}
+	for _, pg := range pgs {
+		_, err := c.vcClient.SchedulingV1beta1().PodGroups(pg.Namespace).Create(context.TODO(), pg, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, c.pgInformer.Informer().GetIndexer().Add(pg))
+		c.addPodGroup(pg)
+	}
+	return c
+}
+
+func TestDrainQueueState(t *testing.T) {
+	testCases := []struct {
+		name          string
+		queueState    schedulingv1beta1.QueueState
+		action        busv1alpha1.Action
+		pgs           []*schedulingv1beta1.PodGroup
+		expectedState schedulingv1beta1.QueueState
+	}{
+		{
+			name:          "drain open queue with running jobs",
+			queueState:    schedulingv1beta1.QueueStateOpen,
+			action:        state.DrainQueueAction,
+			pgs:           []*schedulingv1beta1.PodGroup{buildDrainPodGroup("pg1", "q1", schedulingv1beta1.PodGroupRunning, nil)},
+			expectedState: state.QueueStateDraining,
+		},
+		{
+			name:          "drain open queue without jobs",
+			queueState:    schedulingv1beta1.QueueStateOpen,
+			action:        state.DrainQueueAction,
+			expectedState: schedulingv1beta1.QueueStateClosed,
+		},
+		{
+			name:          "draining queue is closed once jobs are gone",
+			queueState:    state.QueueStateDraining,
+			action:        busv1alpha1.SyncQueueAction,
+			expectedState: schedulingv1beta1.QueueStateClosed,
+		},
+		{
+			name:          "open draining queue",
+			queueState:    state.QueueStateDraining,
+			action:        busv1alpha1.OpenQueueAction,
+			pgs:           []*schedulingv1beta1.PodGroup{buildDrainPodGroup("pg1", "q1", schedulingv1beta1.PodGroupRunning, nil)},
+			expectedState: schedulingv1beta1.QueueStateOpen,
+		},
+		{
+			name:          "close draining queue",
+			queueState:    state.QueueStateDraining,
+			action:        busv1alpha1.CloseQueueAction,
+			pgs:           []*schedulingv1beta1.PodGroup{buildDrainPodGroup("pg1", "q1", schedulingv1beta1.PodGroupRunning, nil)},
+			expectedState: schedulingv1beta1.QueueStateClosing,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := buildDrainQueue("q1", tc.queueState, nil)
+			c := setupDrainController(t, []*schedulingv1beta1.Queue{queue}, tc.pgs)
+
+			assert.NoError(t, c.handleQueue(&apis.Request{QueueName: "q1", Action: tc.action}))
+
+			item, err := c.vcClient.SchedulingV1beta1().Queues().Get(context.TODO(), "q1", metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedState, item.Status.State)
+		})
+	}
+}
+
+func TestDrainQueueBeforeDeadline(t *testing.T) {
+	deadline := time.Now().Add(time.Hour).Format(time.RFC3339)
+	queue := buildDrainQueue("q1", state.QueueStateDraining, map[string]string{state.DrainDeadlineAnnotationKey: deadline})
+	pg := buildDrainPodGroup("pg1", "q1", schedulingv1beta1.PodGroupRunning, nil)
+	c := setupDrainController(t, []*schedulingv1beta1.Queue{queue}, []*schedulingv1beta1.PodGroup{pg})
+	// drop the requests of adding podgroups
+	for c.queue.Len() > 0 {
+		req, _ := c.queue.Get()
+		c.queue.Done(req)
+	}
+
+	assert.NoError(t, c.handleQueue(&apis.Request{QueueName: "q1", Action: busv1alpha1.SyncQueueAction}))
+
+	item, err := c.vcClient.SchedulingV1beta1().PodGroups("ns1").Get(context.TODO(), "pg1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "q1", item.Spec.Queue)
+	// the queue is synced again at the deadline
+	assert.Equal(t, 0, c.queue.Len())
+}
+
+func TestDrainQueueAfterDeadline(t *testing.T) {
+	deadline := time.Now().Add(-time.Minute).Format(time.RFC3339)
+	jobRef := metav1.NewControllerRef(&batchv1alpha1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "job1"}},
+		batchv1alpha1.SchemeGroupVersion.WithKind("Job"))
+
+	t.Run("migrate to fallback queue", func(t *testing.T) {
+		queue := buildDrainQueue("q1", state.QueueStateDraining, map[string]string{
+			state.DrainDeadlineAnnotationKey:      deadline,
+			state.DrainFallbackQueueAnnotationKey: "q2",
+		})
+		fallback := buildDrainQueue("q2", schedulingv1beta1.QueueStateOpen, nil)
+		pgs := []*schedulingv1beta1.PodGroup{
+			buildDrainPodGroup("pg1", "q1", schedulingv1beta1.PodGroupRunning, jobRef),
+			buildDrainPodGroup("pg2", "q1", schedulingv1beta1.PodGroupPending, nil),
+			buildDrainPodGroup("pg3", "q1", schedulingv1beta1.PodGroupCompleted, nil),
+		}
+		c := setupDrainController(t, []*schedulingv1beta1.Queue{queue, fallback}, pgs)
+
+		assert.NoError(t, c.handleQueue(&apis.Request{QueueName: "q1", Action: busv1alpha1.SyncQueueAction}))
+
+		expectedQueues := map[string]string{"pg1": "q2", "pg2": "q2", "pg3": "q1"}
+		for name, expected := range expectedQueues {
+			item, err := c.vcClient.SchedulingV1beta1().PodGroups("ns1").Get(context.TODO(), name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, expected, item.Spec.Queue, "queue of podgroup %s", name)
+		}
+	})
+
+	t.Run("wait for fallback queue to be opened", func(t *testing.T) {
+		queue := buildDrainQueue("q1", state.QueueStateDraining, map[string]string{
+			state.DrainDeadlineAnnotationKey:      deadline,
+			state.DrainFallbackQueueAnnotationKey: "q2",
+		})
+		fallback := buildDrainQueue("q2", schedulingv1beta1.QueueStateClosed, nil)
+		pg := buildDrainPodGroup("pg1", "q1", schedulingv1beta1.PodGroupRunning, nil)
+		c := setupDrainController(t, []*schedulingv1beta1.Queue{queue, fallback}, []*schedulingv1beta1.PodGroup{pg})
+
+		assert.Error(t, c.handleQueue(&apis.Request{QueueName: "q1", Action: busv1alpha1.SyncQueueAction}))
+	})
+
+	t.Run("evict jobs", func(t *testing.T) {
+		queue := buildDrainQueue("q1", state.QueueStateDraining, map[string]string{state.DrainDeadlineAnnotationKey: deadline})
+		pgs := []*schedulingv1beta1.PodGroup{
+			buildDrainPodGroup("pg1", "q1", schedulingv1beta1.PodGroupRunning, jobRef),
+			buildDrainPodGroup("pg2", "q1", schedulingv1beta1.PodGroupRunning, nil),
+		}
+		c := setupDrainController(t, []*schedulingv1beta1.Queue{queue}, pgs)
+		pods := []*v1.Pod{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "p1", Annotations: map[string]string{schedulingv1beta1.KubeGroupNameAnnotationKey: "pg2"}}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "p2", Annotations: map[string]string{schedulingv1beta1.KubeGroupNameAnnotationKey: "other"}}},
+		}
+		for _, pod := range pods {
+			_, err := c.kubeClient.CoreV1().Pods("ns1").Create(context.TODO(), pod, metav1.CreateOptions{})
+			assert.NoError(t, err)
+			assert.NoError(t, c.podIndexer.Add(pod))
+		}
+
+		assert.NoError(t, c.handleQueue(&apis.Request{QueueName: "q1", Action: busv1alpha1.SyncQueueAction}))
+		// evicting again does not fail on the command created before
+		assert.NoError(t, c.handleQueue(&apis.Request{QueueName: "q1", Action: busv1alpha1.SyncQueueAction}))
+
+		cmd, err := c.vcClient.BusV1alpha1().Commands("ns1").Get(context.TODO(), "job1-drain-q1", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, string(busv1alpha1.AbortJobAction), cmd.Action)
+		assert.Equal(t, "job1", cmd.TargetObject.Name)
+
+		remaining, err := c.kubeClient.CoreV1().Pods("ns1").List(context.TODO(), metav1.ListOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(remaining.Items))
+		assert.Equal(t, "p2", remaining.Items[0].Name)
+	})
+}
+
+func TestUpdatePodGroupQueue(t *testing.T) {
+	c := newFakeController()
+	oldPG := buildDrainPodGroup("pg1", "q1", schedulingv1beta1.PodGroupRunning, nil)
+	c.addPodGroup(oldPG)
+
+	newPG := oldPG.DeepCopy()
+	newPG.Spec.Queue = "q2"
+	c.updatePodGroup(oldPG, newPG)
+
+	assert.Empty(t, c.getPodGroups("q1"))
+	assert.Equal(t, []string{"ns1/pg1"}, c.getPodGroups("q2"))
+}
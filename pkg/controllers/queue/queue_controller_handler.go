@@ -17,6 +17,7 @@ limitations under the License.
 package queue
 
 import (
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
@@ -68,7 +69,8 @@ func (c *queuecontroller) updateQueue(oldObj, newObj interface{}) {
 	oldQueue := oldObj.(*schedulingv1beta1.Queue)
 	newQueue := newObj.(*schedulingv1beta1.Queue)
 
-	if oldQueue.Spec.Parent != newQueue.Spec.Parent {
+	if oldQueue.Spec.Parent != newQueue.Spec.Parent ||
+		!equality.Semantic.DeepEqual(queueNodeGroups(oldQueue), queueNodeGroups(newQueue)) {
 		c.addQueue(newObj)
 	}
 }
@@ -99,8 +101,12 @@ func (c *queuecontroller) updatePodGroup(old, new interface{}) {
 	oldPG := old.(*schedulingv1beta1.PodGroup)
 	newPG := new.(*schedulingv1beta1.PodGroup)
 
-	// Note: we have no use case update PodGroup.Spec.Queue
-	// So do not consider it here.
+	// PodGroup.Spec.Queue is updated when the podgroup is migrated out of a draining queue.
+	if oldPG.Spec.Queue != newPG.Spec.Queue {
+		c.deletePodGroup(oldPG)
+		c.addPodGroup(newPG)
+		return
+	}
 	if oldPG.Status.Phase != newPG.Status.Phase {
 		c.addPodGroup(newPG)
 	}
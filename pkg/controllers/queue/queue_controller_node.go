@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/apis"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	commonutil "volcano.sh/volcano/pkg/util"
+)
+
+const (
+	// nodeGroupNameKey is the node label of node groups, which is matched by the node group affinity of queues.
+	nodeGroupNameKey = "volcano.sh/nodegroup-name"
+
+	// NodePoolCapabilityChangedReason is the reason of the event recorded when the capability of the node pool
+	// of a queue changes.
+	NodePoolCapabilityChangedReason = "NodePoolCapabilityChanged"
+)
+
+// unavailableNodeTaints are the taints added by the node lifecycle, the nodes with them do not provide capacity.
+var unavailableNodeTaints = map[string]struct{}{
+	v1.TaintNodeUnschedulable: {},
+	v1.TaintNodeNotReady:      {},
+	v1.TaintNodeUnreachable:   {},
+	v1.TaintNodeOutOfService:  {},
+}
+
+func (c *queuecontroller) addNode(obj interface{}) {
+	node := obj.(*v1.Node)
+	c.enqueueQueuesOfNodeGroups(node.Labels[nodeGroupNameKey])
+}
+
+func (c *queuecontroller) updateNode(oldObj, newObj interface{}) {
+	oldNode := oldObj.(*v1.Node)
+	newNode := newObj.(*v1.Node)
+
+	if oldNode.Labels[nodeGroupNameKey] == newNode.Labels[nodeGroupNameKey] &&
+		isNodeAvailable(oldNode) == isNodeAvailable(newNode) &&
+		apiequality.Semantic.DeepEqual(oldNode.Status.Allocatable, newNode.Status.Allocatable) {
+		return
+	}
+	c.enqueueQueuesOfNodeGroups(oldNode.Labels[nodeGroupNameKey], newNode.Labels[nodeGroupNameKey])
+}
+
+func (c *queuecontroller) deleteNode(obj interface{}) {
+	node, ok := obj.(*v1.Node)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Couldn't get object from tombstone %#v.", obj)
+			return
+		}
+		node, ok = tombstone.Obj.(*v1.Node)
+		if !ok {
+			klog.Errorf("Tombstone contained object that is not a Node: %#v.", obj)
+			return
+		}
+	}
+	c.enqueueQueuesOfNodeGroups(node.Labels[nodeGroupNameKey])
+}
+
+// enqueueQueuesOfNodeGroups syncs the queues bound to any of the node groups.
+func (c *queuecontroller) enqueueQueuesOfNodeGroups(groups ...string) {
+	changed := map[string]struct{}{}
+	for _, group := range groups {
+		if group != "" {
+			changed[group] = struct{}{}
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	queues, err := c.queueLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list queues: %v", err)
+		return
+	}
+	for _, queue := range queues {
+		for _, group := range queueNodeGroups(queue) {
+			if _, found := changed[group]; found {
+				c.enqueue(&apis.Request{
+					QueueName: queue.Name,
+					Event:     busv1alpha1.OutOfSyncEvent,
+					Action:    busv1alpha1.SyncQueueAction,
+				})
+				break
+			}
+		}
+	}
+}
+
+// queueNodeGroups returns the node groups the queue is required to run on.
+func queueNodeGroups(queue *schedulingv1beta1.Queue) []string {
+	if queue.Spec.Affinity == nil || queue.Spec.Affinity.NodeGroupAffinity == nil {
+		return nil
+	}
+	return queue.Spec.Affinity.NodeGroupAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+}
+
+// isNodeAvailable returns whether the node provides capacity to the node pool, that is the node is
+// ready and not cordoned.
+func isNodeAvailable(node *v1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	for _, taint := range node.Spec.Taints {
+		if _, found := unavailableNodeTaints[taint.Key]; found {
+			return false
+		}
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == v1.NodeReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// nodePoolCapability sums the allocatable resources of the available nodes.
+func nodePoolCapability(nodes []*v1.Node) v1.ResourceList {
+	capability := v1.ResourceList{}
+	for _, node := range nodes {
+		if !isNodeAvailable(node) {
+			continue
+		}
+		for name, quantity := range node.Status.Allocatable {
+			total := capability[name]
+			total.Add(quantity)
+			capability[name] = total
+		}
+	}
+	return capability
+}
+
+// syncNodePoolCapability publishes the capability of the node pool of the queue in its annotation, so that
+// the scheduler limits the queue by the nodes actually available rather than the static capability.
+func (c *queuecontroller) syncNodePoolCapability(queue *schedulingv1beta1.Queue) (*schedulingv1beta1.Queue, error) {
+	if c.nodeLister == nil {
+		return queue, nil
+	}
+
+	groups := queueNodeGroups(queue)
+	oldValue, published := queue.Annotations[api.QueueNodePoolCapability]
+	if len(groups) == 0 {
+		if !published {
+			return queue, nil
+		}
+		return c.removeQueueAnnotation(queue, api.QueueNodePoolCapability)
+	}
+
+	selector, err := labels.NewRequirement(nodeGroupNameKey, selection.In, groups)
+	if err != nil {
+		return queue, err
+	}
+	nodes, err := c.nodeLister.List(labels.NewSelector().Add(*selector))
+	if err != nil {
+		return queue, err
+	}
+	capability := nodePoolCapability(nodes)
+	data, err := json.Marshal(capability)
+	if err != nil {
+		return queue, err
+	}
+	newValue := string(data)
+	if published && oldValue == newValue {
+		return queue, nil
+	}
+
+	newQueue, err := c.updateQueueAnnotation(queue, api.QueueNodePoolCapability, newValue)
+	if err != nil {
+		return queue, err
+	}
+
+	sortedGroups := append([]string(nil), groups...)
+	sort.Strings(sortedGroups)
+	c.recorder.Event(newQueue, v1.EventTypeNormal, NodePoolCapabilityChangedReason,
+		fmt.Sprintf("Capability of node groups [%s] changed to %s", strings.Join(sortedGroups, ","), commonutil.FormatResourceList(capability)))
+	return newQueue, nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	kubeclient "k8s.io/client-go/kubernetes/fake"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	vcclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
+	"volcano.sh/volcano/pkg/controllers/framework"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+func newFakeControllerWithNodes() *queuecontroller {
+	vcClient := vcclient.NewSimpleClientset()
+	kubeClient := kubeclient.NewSimpleClientset()
+
+	controller := &queuecontroller{}
+	opt := framework.ControllerOption{
+		VolcanoClient:           vcClient,
+		KubeClient:              kubeClient,
+		VCSharedInformerFactory: informerfactory.NewSharedInformerFactory(vcClient, 0),
+		SharedInformerFactory:   informers.NewSharedInformerFactory(kubeClient, 0),
+	}
+	controller.Initialize(&opt)
+
+	return controller
+}
+
+func buildPoolNode(name, group string, cpu string, ready bool) *v1.Node {
+	status := v1.ConditionTrue
+	if !ready {
+		status = v1.ConditionFalse
+	}
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{nodeGroupNameKey: group}},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+			Conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func buildPoolQueue(name string, groups ...string) *schedulingv1beta1.Queue {
+	return &schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: schedulingv1beta1.QueueSpec{
+			Parent: "root",
+			Affinity: &schedulingv1beta1.Affinity{
+				NodeGroupAffinity: &schedulingv1beta1.NodeGroupAffinity{RequiredDuringSchedulingIgnoredDuringExecution: groups},
+			},
+		},
+	}
+}
+
+func TestIsNodeAvailable(t *testing.T) {
+	cordoned := buildPoolNode("cordoned", "pool", "8", true)
+	cordoned.Spec.Unschedulable = true
+	unreachable := buildPoolNode("unreachable", "pool", "8", true)
+	unreachable.Spec.Taints = []v1.Taint{{Key: v1.TaintNodeUnreachable, Effect: v1.TaintEffectNoExecute}}
+	dedicated := buildPoolNode("dedicated", "pool", "8", true)
+	dedicated.Spec.Taints = []v1.Taint{{Key: "dedicated", Value: "pool", Effect: v1.TaintEffectNoSchedule}}
+
+	testCases := []struct {
+		name     string
+		node     *v1.Node
+		expected bool
+	}{
+		{name: "ready node", node: buildPoolNode("ready", "pool", "8", true), expected: true},
+		{name: "not ready node", node: buildPoolNode("not-ready", "pool", "8", false), expected: false},
+		{name: "cordoned node", node: cordoned, expected: false},
+		{name: "unreachable node", node: unreachable, expected: false},
+		{name: "node tainted for the pool", node: dedicated, expected: true},
+	}
+
+	for _, tc := range testCases {
+		if available := isNodeAvailable(tc.node); available != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, available)
+		}
+	}
+}
+
+func TestNodeEventsEnqueueQueues(t *testing.T) {
+	c := newFakeControllerWithNodes()
+	c.queueInformer.Informer().GetIndexer().Add(buildPoolQueue("q1", "pool-a"))
+	c.queueInformer.Informer().GetIndexer().Add(buildPoolQueue("q2", "pool-b"))
+	c.queueInformer.Informer().GetIndexer().Add(&schedulingv1beta1.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q3"}})
+
+	c.addNode(buildPoolNode("n1", "pool-a", "8", true))
+	if c.queue.Len() != 1 {
+		t.Fatalf("expected 1 queue enqueued on node added, got %d", c.queue.Len())
+	}
+
+	oldNode := buildPoolNode("n1", "pool-a", "8", true)
+	newNode := oldNode.DeepCopy()
+	newNode.ResourceVersion = "2"
+	c.updateNode(oldNode, newNode)
+	if c.queue.Len() != 1 {
+		t.Errorf("expected no queue enqueued on irrelevant node update, got %d", c.queue.Len())
+	}
+
+	newNode.Labels[nodeGroupNameKey] = "pool-b"
+	c.updateNode(oldNode, newNode)
+	if enqueued := c.queue.Len() - 1; enqueued != 2 {
+		t.Errorf("expected queues of both node groups enqueued on node group change, got %d", enqueued)
+	}
+}
+
+func TestSyncNodePoolCapability(t *testing.T) {
+	c := newFakeControllerWithNodes()
+	nodeIndexer := c.informerFactory.Core().V1().Nodes().Informer().GetIndexer()
+	nodeIndexer.Add(buildPoolNode("n1", "pool-a", "8", true))
+	nodeIndexer.Add(buildPoolNode("n2", "pool-b", "16", true))
+	nodeIndexer.Add(buildPoolNode("n3", "pool-a", "32", false))
+	nodeIndexer.Add(buildPoolNode("n4", "pool-c", "64", true))
+
+	queue := buildPoolQueue("q1", "pool-a", "pool-b")
+	if _, err := c.vcClient.SchedulingV1beta1().Queues().Create(context.TODO(), queue, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create queue: %v", err)
+	}
+
+	newQueue, err := c.syncNodePoolCapability(queue)
+	if err != nil {
+		t.Fatalf("failed to sync node pool capability: %v", err)
+	}
+	var capability v1.ResourceList
+	if err := json.Unmarshal([]byte(newQueue.Annotations[api.QueueNodePoolCapability]), &capability); err != nil {
+		t.Fatalf("invalid node pool capability %q: %v", newQueue.Annotations[api.QueueNodePoolCapability], err)
+	}
+	if cpu := capability[v1.ResourceCPU]; cpu.Cmp(resource.MustParse("24")) != 0 {
+		t.Errorf("expected cpu capability 24, got %s", cpu.String())
+	}
+
+	newQueue.Spec.Affinity = nil
+	newQueue, err = c.syncNodePoolCapability(newQueue)
+	if err != nil {
+		t.Fatalf("failed to sync node pool capability: %v", err)
+	}
+	if _, found := newQueue.Annotations[api.QueueNodePoolCapability]; found {
+		t.Errorf("expected node pool capability removed, got %v", newQueue.Annotations)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"volcano.sh/volcano/pkg/controllers/apis"
 	"volcano.sh/volcano/pkg/controllers/framework"
 	"volcano.sh/volcano/pkg/controllers/queue/state"
+	"volcano.sh/volcano/pkg/scheduler/api"
 )
 
 func newFakeController() *queuecontroller {
@@ -302,6 +303,71 @@ func TestSyncQueue(t *testing.T) {
 	}
 }
 
+func TestSyncQueuePodGroupCounts(t *testing.T) {
+	c := newFakeController()
+	queue := &schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: "root"},
+		Status:     schedulingv1beta1.QueueStatus{State: schedulingv1beta1.QueueStateOpen},
+	}
+	_, err := c.vcClient.SchedulingV1beta1().Queues().Create(context.TODO(), queue, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	phases := []schedulingv1beta1.PodGroupPhase{
+		schedulingv1beta1.PodGroupPending,
+		schedulingv1beta1.PodGroupRunning,
+		schedulingv1beta1.PodGroupRunning,
+		schedulingv1beta1.PodGroupInqueue,
+	}
+	for i, phase := range phases {
+		pg := &schedulingv1beta1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pg%d", i), Namespace: "c1"},
+			Spec:       schedulingv1beta1.PodGroupSpec{Queue: "root"},
+			Status:     schedulingv1beta1.PodGroupStatus{Phase: phase},
+		}
+		assert.NoError(t, c.pgInformer.Informer().GetIndexer().Add(pg))
+		c.addPodGroup(pg)
+	}
+
+	err = c.syncQueue(queue, nil)
+	assert.NoError(t, err)
+
+	item, err := c.vcClient.SchedulingV1beta1().Queues().Get(context.TODO(), queue.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, schedulingv1beta1.QueueStateOpen, item.Status.State)
+	assert.Equal(t, int32(1), item.Status.Pending)
+	assert.Equal(t, int32(2), item.Status.Running)
+	assert.Equal(t, int32(1), item.Status.Inqueue)
+}
+
+func TestQueueAnnotations(t *testing.T) {
+	c := newFakeController()
+	queue := &schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "q1",
+			Annotations: map[string]string{"user": "value", api.QueueNodePoolCapability: `{"cpu":"8"}`},
+		},
+	}
+	_, err := c.vcClient.SchedulingV1beta1().Queues().Create(context.TODO(), queue, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	value := ClosedByParentAnnotationTrueValue
+	queueApply := queueAnnotationsApplyConfiguration(queue, ClosedByParentAnnotationKey, &value)
+	assert.Equal(t, map[string]string{
+		ClosedByParentAnnotationKey: ClosedByParentAnnotationTrueValue,
+		api.QueueNodePoolCapability: `{"cpu":"8"}`,
+	}, queueApply.Annotations)
+
+	newQueue, err := c.updateQueueAnnotation(queue, ClosedByParentAnnotationKey, ClosedByParentAnnotationTrueValue)
+	assert.NoError(t, err)
+	assert.Equal(t, ClosedByParentAnnotationTrueValue, newQueue.Annotations[ClosedByParentAnnotationKey])
+	assert.Equal(t, "value", newQueue.Annotations["user"])
+
+	newQueue, err = c.removeQueueAnnotation(newQueue, api.QueueNodePoolCapability)
+	assert.NoError(t, err)
+	assert.NotContains(t, newQueue.Annotations, api.QueueNodePoolCapability)
+	assert.Equal(t, ClosedByParentAnnotationTrueValue, newQueue.Annotations[ClosedByParentAnnotationKey])
+}
+
 func TestProcessNextWorkItem(t *testing.T) {
 	testCases := []struct {
 		Name        string
@@ -31,7 +31,7 @@ func (cs *closedState) Execute(action v1alpha1.Action) error {
 		return OpenQueue(cs.queue, func(status *v1beta1.QueueStatus, podGroupList []string) {
 			status.State = v1beta1.QueueStateOpen
 		})
-	case v1alpha1.CloseQueueAction:
+	case v1alpha1.CloseQueueAction, DrainQueueAction:
 		return SyncQueue(cs.queue, func(status *v1beta1.QueueStatus, podGroupList []string) {
 			status.State = v1beta1.QueueStateClosed
 		})
@@ -39,6 +39,8 @@ func (cs *closingState) Execute(action v1alpha1.Action) error {
 			}
 			status.State = v1beta1.QueueStateClosing
 		})
+	case DrainQueueAction:
+		return DrainQueue(cs.queue, updateDrainingStatus)
 	default:
 		return SyncQueue(cs.queue, func(status *v1beta1.QueueStatus, podGroupList []string) {
 			specState := cs.queue.Status.State
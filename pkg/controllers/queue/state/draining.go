@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"volcano.sh/apis/pkg/apis/bus/v1alpha1"
+	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+const (
+	// QueueStateDraining is the state of a queue which rejects new podgroups while the running jobs continue,
+	// the remaining jobs are evicted or migrated to the fallback queue after the drain deadline.
+	QueueStateDraining v1beta1.QueueState = "Draining"
+
+	// DrainQueueAction is the action to drain queue.
+	DrainQueueAction v1alpha1.Action = "DrainQueue"
+
+	// DrainDeadlineAnnotationKey is the annotation of queue with the RFC3339 time after which the
+	// remaining jobs of the draining queue are evicted or migrated, they are never if not set.
+	DrainDeadlineAnnotationKey = "volcano.sh/drain-deadline"
+	// DrainFallbackQueueAnnotationKey is the annotation of queue naming the queue to migrate the remaining
+	// jobs to after the drain deadline, the jobs are evicted if not set.
+	DrainFallbackQueueAnnotationKey = "volcano.sh/drain-fallback-queue"
+)
+
+type drainingState struct {
+	queue *v1beta1.Queue
+}
+
+func (ds *drainingState) Execute(action v1alpha1.Action) error {
+	switch action {
+	case v1alpha1.OpenQueueAction:
+		return OpenQueue(ds.queue, func(status *v1beta1.QueueStatus, podGroupList []string) {
+			status.State = v1beta1.QueueStateOpen
+		})
+	case v1alpha1.CloseQueueAction:
+		return CloseQueue(ds.queue, func(status *v1beta1.QueueStatus, podGroupList []string) {
+			if len(podGroupList) == 0 {
+				status.State = v1beta1.QueueStateClosed
+				return
+			}
+			status.State = v1beta1.QueueStateClosing
+		})
+	default:
+		return DrainQueue(ds.queue, updateDrainingStatus)
+	}
+}
+
+// updateDrainingStatus closes the draining queue once it has no podgroups.
+func updateDrainingStatus(status *v1beta1.QueueStatus, podGroupList []string) {
+	if len(podGroupList) == 0 {
+		status.State = v1beta1.QueueStateClosed
+		return
+	}
+	status.State = QueueStateDraining
+}
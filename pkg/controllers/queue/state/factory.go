@@ -40,6 +40,8 @@ var (
 	OpenQueue QueueActionFn
 	// CloseQueue will set state of queue to close
 	CloseQueue QueueActionFn
+	// DrainQueue will set state of queue to draining, and evict or migrate its jobs after the drain deadline
+	DrainQueue QueueActionFn
 )
 
 // NewState gets the state from queue status.
@@ -51,6 +53,8 @@ func NewState(queue *v1beta1.Queue) State {
 		return &closedState{queue: queue}
 	case v1beta1.QueueStateClosing:
 		return &closingState{queue: queue}
+	case QueueStateDraining:
+		return &drainingState{queue: queue}
 	case v1beta1.QueueStateUnknown:
 		return &unknownState{queue: queue}
 	}
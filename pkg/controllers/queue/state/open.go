@@ -39,6 +39,8 @@ func (os *openState) Execute(action v1alpha1.Action) error {
 			}
 			status.State = v1beta1.QueueStateClosing
 		})
+	case DrainQueueAction:
+		return DrainQueue(os.queue, updateDrainingStatus)
 	default:
 		return SyncQueue(os.queue, func(status *v1beta1.QueueStatus, podGroupList []string) {
 			specState := os.queue.Status.State
@@ -39,6 +39,8 @@ func (us *unknownState) Execute(action v1alpha1.Action) error {
 			}
 			status.State = v1beta1.QueueStateClosing
 		})
+	case DrainQueueAction:
+		return DrainQueue(us.queue, updateDrainingStatus)
 	default:
 		return SyncQueue(us.queue, func(status *v1beta1.QueueStatus, podGroupList []string) {
 			specState := us.queue.Status.State
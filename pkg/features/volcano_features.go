@@ -46,6 +46,25 @@ const (
 
 	// CronVolcanoJobSupport can identify and schedule volcano cronjob.
 	CronVolcanoJobSupport featuregate.Feature = "CronVolcanoJobSupport"
+
+	// ElasticJobScaling scales elastic tasks of volcano job according to queue pressure.
+	ElasticJobScaling featuregate.Feature = "ElasticJobScaling"
+
+	// PodGroupValidatingAdmissionPolicy delegates the podgroup spec checks of the validating webhook
+	// to the ValidatingAdmissionPolicy, the webhook only checks the queue state.
+	PodGroupValidatingAdmissionPolicy featuregate.Feature = "PodGroupValidatingAdmissionPolicy"
+
+	// GangAutoscalerHints annotates the pending pods of unschedulable gangs with the size and total resources of
+	// the gang, so that cluster autoscalers can scale up the nodes for the whole gang at once.
+	GangAutoscalerHints featuregate.Feature = "GangAutoscalerHints"
+
+	// GPUShareNormalization accounts the whole and the fractional gpus requested by the pods as the virtual
+	// resource volcano.sh/gpu-share, so that the fractional gpus are limited by the quota of queues.
+	GPUShareNormalization featuregate.Feature = "GPUShareNormalization"
+
+	// NamespaceQueueBootstrap creates a queue per namespace or team of namespaces, and keeps their weights in
+	// sync with the controller configuration.
+	NamespaceQueueBootstrap featuregate.Feature = "NamespaceQueueBootstrap"
 )
 
 func init() {
@@ -62,4 +81,10 @@ var defaultVolcanoFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec
 	CSIStorage:            {Default: false, PreRelease: featuregate.Alpha},
 	ResourceTopology:      {Default: true, PreRelease: featuregate.Alpha},
 	CronVolcanoJobSupport: {Default: true, PreRelease: featuregate.Alpha},
+	ElasticJobScaling:     {Default: false, PreRelease: featuregate.Alpha},
+
+	PodGroupValidatingAdmissionPolicy: {Default: false, PreRelease: featuregate.Alpha},
+	GangAutoscalerHints:               {Default: false, PreRelease: featuregate.Alpha},
+	GPUShareNormalization:             {Default: false, PreRelease: featuregate.Alpha},
+	NamespaceQueueBootstrap:           {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -21,7 +21,6 @@ import (
 
 	"k8s.io/klog/v2"
 
-	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
@@ -33,6 +32,8 @@ type Action struct {
 	session *framework.Session
 	// configured flag for error cache
 	enablePredicateErrorCache bool
+	// queueRoundRobin interleaves the queues tied by the queue order in proportion to their weights
+	queueRoundRobin bool
 
 	// hyperNodeScoresByJob stores job total score for all available hyperNodes, this is used for accumulate
 	// all nodes' scores in each available hyperNode only when job has hard network topology constrains
@@ -56,6 +57,8 @@ func (alloc *Action) Initialize() {}
 func (alloc *Action) parseArguments(ssn *framework.Session) {
 	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, alloc.Name())
 	arguments.GetBool(&alloc.enablePredicateErrorCache, conf.EnablePredicateErrCacheKey)
+	alloc.queueRoundRobin = false
+	arguments.GetBool(&alloc.queueRoundRobin, QueueRoundRobinKey)
 }
 
 func (alloc *Action) Execute(ssn *framework.Session) {
@@ -85,27 +88,28 @@ func (alloc *Action) Execute(ssn *framework.Session) {
 func (alloc *Action) pickUpQueuesAndJobs(queues *util.PriorityQueue, jobsMap map[api.QueueID]*util.PriorityQueue) {
 	ssn := alloc.session
 	for _, job := range ssn.Jobs {
+		logger := ssn.JobLogger(job)
 		// If not config enqueue action, change Pending pg into Inqueue state to avoid blocking job scheduling.
 		if job.IsPending() {
-			if conf.EnabledActionMap["enqueue"] {
-				klog.V(4).Infof("Job <%s/%s> Queue <%s> skip allocate, reason: job status is pending.",
-					job.Namespace, job.Name, job.Queue)
+			if conf.EnabledActionMap["enqueue"] && !ssn.Queues[job.Queue].ActionDisabled("enqueue") {
+				logger.V(4).Info("Skip allocate, job status is pending")
 				continue
 			} else {
-				klog.V(4).Infof("Job <%s/%s> Queue <%s> status update from pending to inqueue, reason: no enqueue action is configured.",
-					job.Namespace, job.Name, job.Queue)
-				job.PodGroup.Status.Phase = scheduling.PodGroupInqueue
+				logger.V(4).Info("Update job status from pending to inqueue, no enqueue action is configured")
+				ssn.UpdatePodGroupInqueue(job)
 			}
 		}
 
 		if vr := ssn.JobValid(job); vr != nil && !vr.Pass {
-			klog.V(4).Infof("Job <%s/%s> Queue <%s> skip allocate, reason: %v, message %v", job.Namespace, job.Name, job.Queue, vr.Reason, vr.Message)
+			logger.V(4).Info("Skip allocate, job is not valid", "reason", vr.Reason, "message", vr.Message)
 			continue
 		}
 
-		if _, found := ssn.Queues[job.Queue]; !found {
-			klog.Warningf("Skip adding Job <%s/%s> because its queue %s is not found",
-				job.Namespace, job.Name, job.Queue)
+		if queue, found := ssn.Queues[job.Queue]; !found {
+			logger.Info("Skip adding job because its queue is not found")
+			continue
+		} else if queue.ActionDisabled(alloc.Name()) {
+			logger.V(4).Info("Skip allocate, allocate is disabled for the queue")
 			continue
 		}
 
@@ -114,7 +118,7 @@ func (alloc *Action) pickUpQueuesAndJobs(queues *util.PriorityQueue, jobsMap map
 			queues.Push(ssn.Queues[job.Queue])
 		}
 
-		klog.V(4).Infof("Added Job <%s/%s> into Queue <%s>", job.Namespace, job.Name, job.Queue)
+		logger.V(4).Info("Added job into queue")
 		jobsMap[job.Queue].Push(job)
 	}
 }
@@ -127,6 +131,10 @@ func (alloc *Action) allocateResources(queues *util.PriorityQueue, jobsMap map[a
 	pendingTasks := map[api.JobID]*util.PriorityQueue{}
 
 	allNodes := ssn.NodeList
+	var roundRobin *queueRoundRobin
+	if alloc.queueRoundRobin {
+		roundRobin = newQueueRoundRobin(ssn.CompareQueueOrder)
+	}
 
 	// To pick <namespace, queue> tuple for job, we choose to pick namespace firstly.
 	// Because we believe that number of queues would less than namespaces in most case.
@@ -136,7 +144,12 @@ func (alloc *Action) allocateResources(queues *util.PriorityQueue, jobsMap map[a
 			break
 		}
 
-		queue := queues.Pop().(*api.QueueInfo)
+		var queue *api.QueueInfo
+		if roundRobin != nil {
+			queue = roundRobin.pop(queues)
+		} else {
+			queue = queues.Pop().(*api.QueueInfo)
+		}
 
 		if ssn.Overused(queue) {
 			klog.V(3).Infof("Queue <%s> is overused, ignore it.", queue.Name)
@@ -179,8 +192,7 @@ func (alloc *Action) allocateResources(queues *util.PriorityQueue, jobsMap map[a
 			continue
 		}
 
-		klog.V(3).Infof("Try to allocate resource to %d tasks of Job <%v/%v>",
-			tasks.Len(), job.Namespace, job.Name)
+		ssn.JobLogger(job).V(3).Info("Try to allocate resource to tasks of job", "tasks", tasks.Len())
 
 		hardMode, highestAllowedTier := job.IsHardTopologyMode()
 		var stmt *framework.Statement
@@ -362,21 +374,22 @@ func (alloc *Action) allocateResourcesForTasks(tasks *util.PriorityQueue, job *a
 
 	for !tasks.Empty() {
 		task := tasks.Pop().(*api.TaskInfo)
+		logger := ssn.TaskLogger(task)
 		if !ssn.Allocatable(queue, task) {
-			klog.V(3).Infof("Queue <%s> is overused when considering task <%s>, ignore it.", queue.Name, task.Name)
+			logger.V(3).Info("Queue is overused when considering task, ignore it")
 			continue
 		}
 
 		// check if the task with its spec has already predicates failed
 		if job.TaskHasFitErrors(task) {
-			klog.V(5).Infof("Task %s with role spec %s has already predicated failed, skip", task.Name, task.TaskRole)
+			logger.V(5).Info("Task with role spec has already predicated failed, skip", "role", task.TaskRole)
 			continue
 		}
 
-		klog.V(3).Infof("There are <%d> nodes for Job <%v/%v>", len(ssn.Nodes), job.Namespace, job.Name)
+		logger.V(3).Info("Try to allocate task", "nodes", len(ssn.Nodes))
 
 		if err := ssn.PrePredicateFn(task); err != nil {
-			klog.V(3).Infof("PrePredicate for task %s/%s failed for: %v", task.Namespace, task.Name, err)
+			logger.V(3).Info("PrePredicate for task failed", "err", err)
 			fitErrors := api.NewFitErrors()
 			for _, ni := range allNodes {
 				fitErrors.SetNodeError(ni.Name, err)
@@ -543,14 +556,13 @@ func (alloc *Action) prioritizeNodes(ssn *framework.Session, task *api.TaskInfo,
 
 func (alloc *Action) allocateResourcesForTask(stmt *framework.Statement, task *api.TaskInfo, node *api.NodeInfo, job *api.JobInfo) (err error) {
 	// Allocate idle resource to the task.
+	logger := alloc.session.TaskLogger(task)
 	if task.InitResreq.LessEqual(node.Idle, api.Zero) {
-		klog.V(3).Infof("Binding Task <%v/%v> to node <%v>", task.Namespace, task.Name, node.Name)
+		logger.V(3).Info("Binding task to node", "node", node.Name)
 		if err = stmt.Allocate(task, node); err != nil {
-			klog.Errorf("Failed to bind Task %v on %v in Session %v, err: %v",
-				task.UID, node.Name, alloc.session.UID, err)
+			logger.Error(err, "Failed to bind task on node", "node", node.Name)
 			if rollbackErr := stmt.UnAllocate(task); rollbackErr != nil {
-				klog.Errorf("Failed to unallocate Task %v on %v in Session %v for %v.",
-					task.UID, node.Name, alloc.session.UID, rollbackErr)
+				logger.Error(rollbackErr, "Failed to unallocate task on node", "node", node.Name)
 			}
 		} else {
 			metrics.UpdateE2eSchedulingDurationByJob(job.Name, string(job.Queue), job.Namespace, metrics.Duration(job.CreationTimestamp.Time))
@@ -559,16 +571,14 @@ func (alloc *Action) allocateResourcesForTask(stmt *framework.Statement, task *a
 		return
 	}
 
-	klog.V(3).Infof("Predicates failed in allocate for task <%s/%s> on node <%s> with limited resources",
-		task.Namespace, task.Name, node.Name)
+	logger.V(3).Info("Predicates failed in allocate for task on node with limited resources", "node", node.Name)
 
 	// Allocate releasing resource to the task if any.
 	if task.InitResreq.LessEqual(node.FutureIdle(), api.Zero) {
-		klog.V(3).Infof("Pipelining Task <%v/%v> to node <%v> for <%v> on <%v>",
-			task.Namespace, task.Name, node.Name, task.InitResreq, node.Releasing)
+		logger.V(3).Info("Pipelining task to node", "node", node.Name,
+			"request", task.InitResreq, "releasing", node.Releasing)
 		if err = stmt.Pipeline(task, node.Name, false); err != nil {
-			klog.Errorf("Failed to pipeline Task %v on %v in Session %v for %v.",
-				task.UID, node.Name, alloc.session.UID, err)
+			logger.Error(err, "Failed to pipeline task on node", "node", node.Name)
 		} else {
 			metrics.UpdateE2eSchedulingDurationByJob(job.Name, string(job.Queue), job.Namespace, metrics.Duration(job.CreationTimestamp.Time))
 			metrics.UpdateE2eSchedulingLastTimeByJob(job.Name, string(job.Queue), job.Namespace, time.Now())
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocate
+
+import (
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+// QueueRoundRobinKey is the argument of the allocate action to interleave the queues tied by the queue order of the
+// plugins, e.g. at the same share level, in proportion to their weights. The tied queues are ordered by their
+// creation time otherwise, so the oldest queue is drained before the others.
+const QueueRoundRobinKey = "allocate.queueRoundRobin"
+
+// queueRoundRobin picks the queues tied by the queue order in the smooth weighted round-robin way: every time the
+// tied queues are picked from, each of them gains its weight as credit, and the queue of the most credit is picked
+// and pays the total weights of the tied queues.
+type queueRoundRobin struct {
+	compareFn func(l, r interface{}) int
+	credits   map[api.QueueID]int64
+}
+
+func newQueueRoundRobin(compareFn func(l, r interface{}) int) *queueRoundRobin {
+	return &queueRoundRobin{
+		compareFn: compareFn,
+		credits:   map[api.QueueID]int64{},
+	}
+}
+
+// pop pops the queue to allocate resources to next from the queues, the other queues tied with it are kept.
+func (rr *queueRoundRobin) pop(queues *util.PriorityQueue) *api.QueueInfo {
+	first := queues.Pop().(*api.QueueInfo)
+	tied := []*api.QueueInfo{first}
+	for !queues.Empty() {
+		next := queues.Pop().(*api.QueueInfo)
+		if rr.compareFn(first, next) != 0 {
+			queues.Push(next)
+			break
+		}
+		tied = append(tied, next)
+	}
+	if len(tied) == 1 {
+		return first
+	}
+
+	var total int64
+	picked := first
+	for _, queue := range tied {
+		weight := queueWeight(queue)
+		total += weight
+		rr.credits[queue.UID] += weight
+		if rr.credits[queue.UID] > rr.credits[picked.UID] {
+			picked = queue
+		}
+	}
+	rr.credits[picked.UID] -= total
+	for _, queue := range tied {
+		if queue != picked {
+			queues.Push(queue)
+		}
+	}
+	return picked
+}
+
+func queueWeight(queue *api.QueueInfo) int64 {
+	if queue.Weight <= 0 {
+		return 1
+	}
+	return int64(queue.Weight)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package allocate
+
+import (
+	"testing"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestQueueRoundRobin(t *testing.T) {
+	q1 := &api.QueueInfo{UID: "q1", Name: "q1", Weight: 1}
+	q2 := &api.QueueInfo{UID: "q2", Name: "q2", Weight: 3}
+	q3 := &api.QueueInfo{UID: "q3", Name: "q3", Weight: 1}
+
+	// q3 is always ordered after the tied q1 and q2
+	level := map[api.QueueID]int{"q1": 0, "q2": 0, "q3": 1}
+	compareFn := func(l, r interface{}) int {
+		return level[l.(*api.QueueInfo).UID] - level[r.(*api.QueueInfo).UID]
+	}
+	lessFn := func(l, r interface{}) bool {
+		if c := compareFn(l, r); c != 0 {
+			return c < 0
+		}
+		// ordered by name like the creation time, q1 is always first if not interleaved
+		return l.(*api.QueueInfo).Name < r.(*api.QueueInfo).Name
+	}
+
+	queues := util.NewPriorityQueue(lessFn)
+	for _, queue := range []*api.QueueInfo{q1, q2, q3} {
+		queues.Push(queue)
+	}
+
+	rr := newQueueRoundRobin(compareFn)
+	picks := map[api.QueueID]int{}
+	var sequence []api.QueueID
+	for i := 0; i < 8; i++ {
+		queue := rr.pop(queues)
+		picks[queue.UID]++
+		sequence = append(sequence, queue.UID)
+		queues.Push(queue)
+	}
+
+	if picks["q1"] != 2 || picks["q2"] != 6 || picks["q3"] != 0 {
+		t.Errorf("expected q1 and q2 picked 2 and 6 times in proportion to their weights, got %v", picks)
+	}
+	// the smooth round-robin never picks the light queue twice in a row
+	for i := 1; i < len(sequence); i++ {
+		if sequence[i] == "q1" && sequence[i-1] == "q1" {
+			t.Errorf("expected q1 interleaved with q2, got %v", sequence)
+		}
+	}
+	if queues.Len() != 3 {
+		t.Errorf("expected all the queues kept, got %d", queues.Len())
+	}
+}
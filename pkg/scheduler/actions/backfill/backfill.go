@@ -130,6 +130,10 @@ func (backfill *Action) pickUpPendingTasks(ssn *framework.Session) []*api.TaskIn
 		if !found {
 			continue
 		}
+		if queue.ActionDisabled(backfill.Name()) {
+			klog.V(4).Infof("Job <%s/%s> Queue <%s> skip backfill, reason: backfill is disabled for the queue", job.Namespace, job.Name, job.Queue)
+			continue
+		}
 
 		for _, task := range job.TaskStatusIndex[api.Pending] {
 			if !task.BestEffort {
@@ -58,6 +58,8 @@ func TestPickUpPendingTasks(t *testing.T) {
 	}
 
 	priority4, priority3, priority2, priority1 := int32(4), int32(3), int32(2), int32(1)
+	backfillDisabledQueue := util.BuildQueue("q2", 1, nil)
+	backfillDisabledQueue.Annotations = map[string]string{api.QueueDisabledActions: "backfill"}
 
 	testCases := []struct {
 		name            string
@@ -125,6 +127,24 @@ func TestPickUpPendingTasks(t *testing.T) {
 				"pg1-besteffort-task-1",
 			},
 		},
+		{
+			name: "backfill disabled for queue",
+			pendingPods: []*v1.Pod{
+				util.BuildPodWithPriority("default", "pg1-besteffort-task-1", "", v1.PodPending, nil, "pg1", make(map[string]string), make(map[string]string), &priority1),
+				util.BuildPodWithPriority("default", "pg2-besteffort-task-1", "", v1.PodPending, nil, "pg2", make(map[string]string), make(map[string]string), &priority1),
+			},
+			queues: []*schedulingv1beta1.Queue{
+				util.BuildQueue("q1", 1, nil),
+				backfillDisabledQueue,
+			},
+			podGroups: []*schedulingv1beta1.PodGroup{
+				util.BuildPodGroup("pg1", "default", "q1", 1, map[string]int32{"": 1}, schedulingv1beta1.PodGroupInqueue),
+				util.BuildPodGroup("pg2", "default", "q2", 1, map[string]int32{"": 1}, schedulingv1beta1.PodGroupInqueue),
+			},
+			expectedResult: []string{
+				"pg1-besteffort-task-1",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -17,19 +17,29 @@ limitations under the License.
 package enqueue
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/helpers"
 	"volcano.sh/volcano/pkg/scheduler/framework"
+	pluginutil "volcano.sh/volcano/pkg/scheduler/plugins/util"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
-type Action struct{}
+type Action struct {
+	// order is the order of the pending jobs in a queue
+	order string
+	// agingPeriod is the pending time boosting the priority of a job by 1 in the priority-aging order
+	agingPeriod time.Duration
+}
 
 func New() *Action {
 	return &Action{}
@@ -45,9 +55,13 @@ func (enqueue *Action) Execute(ssn *framework.Session) {
 	klog.V(5).Infof("Enter Enqueue ...")
 	defer klog.V(5).Infof("Leaving Enqueue ...")
 
+	enqueue.parseArguments(ssn)
+
 	queues := util.NewPriorityQueue(ssn.QueueOrderFn)
 	queueSet := sets.NewString()
-	jobsMap := map[api.QueueID]*util.PriorityQueue{}
+	jobsMap := map[api.QueueID]jobQueue{}
+	// used is the resources used by the jobs of the queues whose capability is enforced at enqueue
+	used := map[api.QueueID]*api.Resource{}
 
 	for _, job := range ssn.Jobs {
 		if job.ScheduleStartTimestamp.IsZero() {
@@ -67,9 +81,28 @@ func (enqueue *Action) Execute(ssn *framework.Session) {
 			queues.Push(queue)
 		}
 
+		if queue := ssn.Queues[job.Queue]; queue.HardCapability() && len(queue.Queue.Spec.Capability) != 0 {
+			if _, found := used[job.Queue]; !found {
+				used[job.Queue] = api.EmptyResource()
+			}
+			if !job.IsPending() {
+				used[job.Queue].Add(jobUsed(job))
+			}
+		}
+
 		if job.IsPending() {
+			if gates := api.GetSchedulingGates(job.PodGroup.Annotations); len(gates) != 0 {
+				enqueue.gate(ssn, job, gates)
+				continue
+			}
+			// the job is moved to inqueue by the allocate action
+			if ssn.Queues[job.Queue].ActionDisabled(enqueue.Name()) {
+				klog.V(4).Infof("Job <%s/%s> Queue <%s> skip enqueue, reason: enqueue is disabled for the queue",
+					job.Namespace, job.Name, job.Queue)
+				continue
+			}
 			if _, found := jobsMap[job.Queue]; !found {
-				jobsMap[job.Queue] = util.NewPriorityQueue(ssn.JobOrderFn)
+				jobsMap[job.Queue] = enqueue.newJobQueue(ssn)
 			}
 			klog.V(5).Infof("Added Job <%s/%s> into Queue <%s>", job.Namespace, job.Name, job.Queue)
 			jobsMap[job.Queue].Push(job)
@@ -92,10 +125,20 @@ func (enqueue *Action) Execute(ssn *framework.Session) {
 		}
 		job := jobs.Pop().(*api.JobInfo)
 
+		if queueUsed, found := used[queue.UID]; found {
+			if !enqueue.withinCapability(ssn, queue, job, queueUsed) {
+				queues.Push(queue)
+				continue
+			}
+		}
+
 		if job.PodGroup.Spec.MinResources == nil || ssn.JobEnqueueable(job) {
 			ssn.JobEnqueued(job)
-			job.PodGroup.Status.Phase = scheduling.PodGroupInqueue
 			ssn.Jobs[job.UID] = job
+			ssn.UpdatePodGroupInqueue(job)
+			if queueUsed, found := used[queue.UID]; found {
+				queueUsed.Add(jobUsed(job))
+			}
 		}
 
 		// Added Queue back until no job in Queue.
@@ -103,4 +146,56 @@ func (enqueue *Action) Execute(ssn *framework.Session) {
 	}
 }
 
+// withinCapability checks whether the minResources of the job fit in the remaining capability of the queue,
+// the job is marked unschedulable with the insufficient resources otherwise.
+func (enqueue *Action) withinCapability(ssn *framework.Session, queue *api.QueueInfo, job *api.JobInfo, used *api.Resource) bool {
+	minReq := job.GetMinResources()
+	capability := api.NewResource(queue.Queue.Spec.Capability)
+	fit, resourceNames := minReq.Clone().Add(used).LessEqualWithDimensionAndResourcesName(capability, minReq)
+	if fit {
+		return true
+	}
+
+	msg := pluginutil.FormatResourceNames(fmt.Sprintf("queue <%s> quota exceeded", queue.Name), "insufficient", resourceNames)
+	klog.V(3).Infof("Refuse to enqueue job <%s/%s>: %s", job.Namespace, job.Name, msg)
+	job.JobFitErrors = msg
+	cond := &scheduling.PodGroupCondition{
+		Type:               scheduling.PodGroupUnschedulableType,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		TransitionID:       string(ssn.UID),
+		Reason:             api.QueueQuotaExceededReason,
+		Message:            msg,
+	}
+	if err := ssn.UpdatePodGroupCondition(job, cond); err != nil {
+		klog.Errorf("Failed to update job <%s/%s> condition: %v", job.Namespace, job.Name, err)
+	}
+	ssn.RecordPodGroupEvent(job.PodGroup, v1.EventTypeWarning, api.QueueQuotaExceededReason, msg)
+	return false
+}
+
+// gate keeps the job with scheduling gates pending, and marks it unschedulable with the gates to be removed.
+func (enqueue *Action) gate(ssn *framework.Session, job *api.JobInfo, gates []string) {
+	msg := fmt.Sprintf("podgroup is waiting for scheduling gates to be removed: %s", strings.Join(gates, ", "))
+	klog.V(3).Infof("Skip enqueueing job <%s/%s>: %s", job.Namespace, job.Name, msg)
+	job.JobFitErrors = msg
+	cond := &scheduling.PodGroupCondition{
+		Type:               scheduling.PodGroupUnschedulableType,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		TransitionID:       string(ssn.UID),
+		Reason:             api.SchedulingGatedReason,
+		Message:            msg,
+	}
+	if err := ssn.UpdatePodGroupCondition(job, cond); err != nil {
+		klog.Errorf("Failed to update job <%s/%s> condition: %v", job.Namespace, job.Name, err)
+	}
+}
+
+// jobUsed returns the resources used by the job in the capability of its queue, which is the larger one of the
+// allocated resources and the minResources of the job.
+func jobUsed(job *api.JobInfo) *api.Resource {
+	return helpers.Max(job.Allocated, job.GetMinResources())
+}
+
 func (enqueue *Action) UnInitialize() {}
@@ -17,9 +17,13 @@ limitations under the License.
 package enqueue
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"volcano.sh/apis/pkg/apis/scheduling"
 	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
@@ -165,3 +169,247 @@ func TestEnqueue(t *testing.T) {
 		})
 	}
 }
+
+func TestEnqueueHardCapability(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{
+		gang.PluginName: gang.New,
+	}
+	hard := map[string]string{api.QueueHardCapability: "true"}
+	tests := []struct {
+		uthelper.TestCommonStruct
+		expectedReason map[api.JobID]string
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "podgroup exceeding the remaining hard capability is refused",
+				PodGroups: []*schedulingv1.PodGroup{
+					util.BuildPodGroupWithMinResources("pg1", "c1", "c1", 1, nil, api.BuildResourceList("3", "2G"), schedulingv1.PodGroupRunning),
+					util.BuildPodGroupWithMinResources("pg2", "c1", "c1", 1, nil, api.BuildResourceList("2", "1G"), schedulingv1.PodGroupPending),
+					util.BuildPodGroupWithMinResources("pg3", "c1", "c1", 1, nil, api.BuildResourceList("1", "1G"), schedulingv1.PodGroupPending),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("3", "2G"), "pg1", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("2", "1G"), "pg2", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "p3", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg3", make(map[string]string), make(map[string]string)),
+				},
+				Queues: []*schedulingv1.Queue{
+					util.BuildQueueWithAnnos("c1", 1, api.BuildResourceList("4", "4G"), hard),
+				},
+				ExpectStatus: map[api.JobID]scheduling.PodGroupPhase{
+					"c1/pg1": scheduling.PodGroupRunning,
+					"c1/pg2": scheduling.PodGroupPending,
+					"c1/pg3": scheduling.PodGroupInqueue,
+				},
+			},
+			expectedReason: map[api.JobID]string{
+				"c1/pg2": api.QueueQuotaExceededReason,
+				"c1/pg3": "",
+			},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "podgroups enqueued in the session count in the hard capability",
+				PodGroups: []*schedulingv1.PodGroup{
+					util.BuildPodGroupWithMinResources("pg1", "c1", "c1", 1, nil, api.BuildResourceList("3", "1G"), schedulingv1.PodGroupPending),
+					util.BuildPodGroupWithMinResources("pg2", "c1", "c1", 1, nil, api.BuildResourceList("3", "1G"), schedulingv1.PodGroupPending),
+				},
+				Queues: []*schedulingv1.Queue{
+					util.BuildQueueWithAnnos("c1", 1, api.BuildResourceList("4", "4G"), hard),
+				},
+				ExpectStatus: map[api.JobID]scheduling.PodGroupPhase{
+					"c1/pg1": scheduling.PodGroupInqueue,
+					"c1/pg2": scheduling.PodGroupPending,
+				},
+			},
+			expectedReason: map[api.JobID]string{
+				"c1/pg2": api.QueueQuotaExceededReason,
+			},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "capability is not enforced at enqueue without the annotation",
+				PodGroups: []*schedulingv1.PodGroup{
+					util.BuildPodGroupWithMinResources("pg1", "c1", "c1", 1, nil, api.BuildResourceList("8", "8G"), schedulingv1.PodGroupPending),
+				},
+				Queues: []*schedulingv1.Queue{
+					util.BuildQueue("c1", 1, api.BuildResourceList("4", "4G")),
+				},
+				ExpectStatus: map[api.JobID]scheduling.PodGroupPhase{
+					"c1/pg1": scheduling.PodGroupInqueue,
+				},
+			},
+			expectedReason: map[api.JobID]string{
+				"c1/pg1": "",
+			},
+		},
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:            gang.PluginName,
+					EnabledJobOrder: &trueValue,
+				},
+			},
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Plugins = plugins
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run([]framework.Action{New()})
+			for jobID, expected := range test.expectedReason {
+				reason := ""
+				for _, c := range ssn.Jobs[jobID].PodGroup.Status.Conditions {
+					if c.Type == scheduling.PodGroupUnschedulableType {
+						reason = c.Reason
+					}
+				}
+				if reason != expected {
+					t.Errorf("expected job %s unschedulable reason %q, got %q", jobID, expected, reason)
+				}
+			}
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestEnqueueSchedulingGates(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{
+		gang.PluginName: gang.New,
+	}
+	test := uthelper.TestCommonStruct{
+		Name: "podgroup with scheduling gates is not enqueued",
+		PodGroups: []*schedulingv1.PodGroup{
+			util.BuildPodGroupWithAnno("pg1", "c1", "c1", 1, nil, schedulingv1.PodGroupPending,
+				map[string]string{api.PodGroupSchedulingGates: "budget.example.com/approval, quota.example.com/check"}),
+			util.BuildPodGroupWithAnno("pg2", "c1", "c1", 1, nil, schedulingv1.PodGroupPending,
+				map[string]string{api.PodGroupSchedulingGates: ""}),
+			util.BuildPodGroupWithAnno("pg3", "c1", "c1", 1, nil, schedulingv1.PodGroupInqueue,
+				map[string]string{api.PodGroupSchedulingGates: "budget.example.com/approval"}),
+		},
+		Pods: []*v1.Pod{
+			util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)),
+		},
+		Queues: []*schedulingv1.Queue{
+			util.BuildQueue("c1", 1, api.BuildResourceList("4", "4G")),
+		},
+		ExpectStatus: map[api.JobID]scheduling.PodGroupPhase{
+			"c1/pg1": scheduling.PodGroupPending,
+			"c1/pg2": scheduling.PodGroupInqueue,
+			// the gates are checked only before the podgroup is enqueued
+			"c1/pg3": scheduling.PodGroupInqueue,
+		},
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:            gang.PluginName,
+					EnabledJobOrder: &trueValue,
+				},
+			},
+		},
+	}
+	test.Plugins = plugins
+	ssn := test.RegisterSession(tiers, nil)
+	test.Run([]framework.Action{New()})
+	if err := test.CheckPGStatus(0); err != nil {
+		t.Fatal(err)
+	}
+	// the reason is kept by gang when the session is closed
+	job := ssn.Jobs["c1/pg1"]
+	enqueued := ssn.Jobs["c1/pg2"]
+	test.Close()
+
+	// the admission is recorded in the Inqueue condition for the timeline of vcctl
+	for _, expected := range []struct {
+		job      *api.JobInfo
+		admitted bool
+	}{{job, false}, {enqueued, true}} {
+		admitted := false
+		for _, c := range expected.job.PodGroup.Status.Conditions {
+			if c.Type == api.PodGroupInqueueType && c.Status == v1.ConditionTrue && !c.LastTransitionTime.IsZero() {
+				admitted = true
+			}
+		}
+		if admitted != expected.admitted {
+			t.Errorf("expected job %s admitted %v, got %v", expected.job.UID, expected.admitted, admitted)
+		}
+	}
+
+	var cond *scheduling.PodGroupCondition
+	for i, c := range job.PodGroup.Status.Conditions {
+		if c.Type == scheduling.PodGroupUnschedulableType {
+			cond = &job.PodGroup.Status.Conditions[i]
+		}
+	}
+	if cond == nil || cond.Reason != api.SchedulingGatedReason {
+		t.Fatalf("expected unschedulable condition with reason %s, got %v", api.SchedulingGatedReason, cond)
+	}
+	if !strings.Contains(cond.Message, "budget.example.com/approval, quota.example.com/check") {
+		t.Errorf("expected the gates in the condition message, got %q", cond.Message)
+	}
+}
+
+func TestJobQueueOrder(t *testing.T) {
+	now := time.Now()
+	buildJob := func(uid, namespace string, priority int32, pending time.Duration) *api.JobInfo {
+		return &api.JobInfo{
+			UID:               api.JobID(uid),
+			Namespace:         namespace,
+			Priority:          priority,
+			CreationTimestamp: metav1.NewTime(now.Add(-pending)),
+		}
+	}
+	jobs := []*api.JobInfo{
+		buildJob("high-new", "ns1", 10, time.Minute),
+		buildJob("low-old", "ns1", 1, 2*time.Hour),
+		buildJob("mid", "ns2", 5, 30*time.Minute),
+		buildJob("low-new", "ns1", 1, 10*time.Minute),
+	}
+
+	tests := []struct {
+		name     string
+		queue    jobQueue
+		expected []api.JobID
+	}{
+		{
+			name:     "fifo",
+			queue:    (&Action{order: OrderFIFO}).newJobQueue(nil),
+			expected: []api.JobID{"low-old", "mid", "low-new", "high-new"},
+		},
+		{
+			// low-old is boosted to 1+12, mid to 5+3, low-new to 1+1
+			name:     "priority aging",
+			queue:    (&Action{order: OrderPriorityAging, agingPeriod: 10 * time.Minute}).newJobQueue(nil),
+			expected: []api.JobID{"low-old", "high-new", "mid", "low-new"},
+		},
+		{
+			name:     "namespace fair",
+			queue:    newNamespaceFairQueue(fifoLess),
+			expected: []api.JobID{"low-old", "mid", "low-new", "high-new"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, job := range jobs {
+				test.queue.Push(job)
+			}
+			var popped []api.JobID
+			for !test.queue.Empty() {
+				popped = append(popped, test.queue.Pop().(*api.JobInfo).UID)
+			}
+			if !reflect.DeepEqual(popped, test.expected) {
+				t.Errorf("expected jobs popped in order %v, got %v", test.expected, popped)
+			}
+		})
+	}
+}
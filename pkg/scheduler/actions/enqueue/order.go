@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enqueue
+
+import (
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+const (
+	// OrderKey is the argument of the enqueue action to select the order of the pending jobs in a queue.
+	OrderKey = "enqueue.order"
+	// AgingPeriodKey is the argument of the enqueue action for the pending time boosting the priority of a job by 1
+	// in the priority-aging order.
+	AgingPeriodKey = "enqueue.agingPeriod"
+
+	// OrderJobOrderFn orders the jobs by the jobOrderFn of the plugins, it is the default order.
+	OrderJobOrderFn = ""
+	// OrderFIFO orders the jobs by their creation time strictly.
+	OrderFIFO = "fifo"
+	// OrderPriorityAging orders the jobs by their priority boosted by their pending time, so that the old jobs of low
+	// priority are not starved by the jobs of high priority submitted continuously.
+	OrderPriorityAging = "priority-aging"
+	// OrderNamespaceFair interleaves the jobs of the namespaces in a queue, the jobs of a namespace are ordered by
+	// the jobOrderFn of the plugins.
+	OrderNamespaceFair = "namespace-fair"
+
+	defaultAgingPeriod = 5 * time.Minute
+)
+
+// jobQueue is the queue of the pending jobs of a queue to be enqueued.
+type jobQueue interface {
+	Push(it interface{})
+	Pop() interface{}
+	Empty() bool
+}
+
+func (enqueue *Action) parseArguments(ssn *framework.Session) {
+	enqueue.order = OrderJobOrderFn
+	enqueue.agingPeriod = defaultAgingPeriod
+
+	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, enqueue.Name())
+	arguments.GetString(&enqueue.order, OrderKey)
+	switch enqueue.order {
+	case OrderJobOrderFn, OrderFIFO, OrderPriorityAging, OrderNamespaceFair:
+	default:
+		klog.Warningf("Unknown %s %q, the jobs are ordered by jobOrderFn", OrderKey, enqueue.order)
+		enqueue.order = OrderJobOrderFn
+	}
+
+	var agingPeriod string
+	arguments.GetString(&agingPeriod, AgingPeriodKey)
+	if agingPeriod != "" {
+		period, err := time.ParseDuration(agingPeriod)
+		if err != nil || period <= 0 {
+			klog.Warningf("Invalid %s %q, use the default %v", AgingPeriodKey, agingPeriod, defaultAgingPeriod)
+		} else {
+			enqueue.agingPeriod = period
+		}
+	}
+}
+
+// newJobQueue returns the queue of the pending jobs of a queue in the configured order.
+func (enqueue *Action) newJobQueue(ssn *framework.Session) jobQueue {
+	switch enqueue.order {
+	case OrderFIFO:
+		return util.NewPriorityQueue(fifoLess)
+	case OrderPriorityAging:
+		now := time.Now()
+		return util.NewPriorityQueue(func(l, r interface{}) bool {
+			lv, rv := l.(*api.JobInfo), r.(*api.JobInfo)
+			lp, rp := agedPriority(lv, now, enqueue.agingPeriod), agedPriority(rv, now, enqueue.agingPeriod)
+			if lp != rp {
+				return lp > rp
+			}
+			return fifoLess(l, r)
+		})
+	case OrderNamespaceFair:
+		return newNamespaceFairQueue(ssn.JobOrderFn)
+	default:
+		return util.NewPriorityQueue(ssn.JobOrderFn)
+	}
+}
+
+// fifoLess orders the jobs by their creation time, and by their uid for the jobs created at the same time.
+func fifoLess(l, r interface{}) bool {
+	lv, rv := l.(*api.JobInfo), r.(*api.JobInfo)
+	if !lv.CreationTimestamp.Equal(&rv.CreationTimestamp) {
+		return lv.CreationTimestamp.Before(&rv.CreationTimestamp)
+	}
+	return lv.UID < rv.UID
+}
+
+// agedPriority returns the priority of the job boosted by 1 for every aging period it has been pending.
+func agedPriority(job *api.JobInfo, now time.Time, period time.Duration) int64 {
+	priority := int64(job.Priority)
+	if !job.CreationTimestamp.IsZero() && now.After(job.CreationTimestamp.Time) {
+		priority += int64(now.Sub(job.CreationTimestamp.Time) / period)
+	}
+	return priority
+}
+
+// namespaceFairQueue pops the jobs of the namespaces in turn, in the order the namespaces are first pushed.
+type namespaceFairQueue struct {
+	lessFn     api.LessFn
+	namespaces []string
+	jobs       map[string]*util.PriorityQueue
+	next       int
+}
+
+func newNamespaceFairQueue(lessFn api.LessFn) *namespaceFairQueue {
+	return &namespaceFairQueue{
+		lessFn: lessFn,
+		jobs:   map[string]*util.PriorityQueue{},
+	}
+}
+
+func (q *namespaceFairQueue) Push(it interface{}) {
+	job := it.(*api.JobInfo)
+	jobs, found := q.jobs[job.Namespace]
+	if !found {
+		jobs = util.NewPriorityQueue(q.lessFn)
+		q.jobs[job.Namespace] = jobs
+		q.namespaces = append(q.namespaces, job.Namespace)
+	}
+	jobs.Push(job)
+}
+
+func (q *namespaceFairQueue) Pop() interface{} {
+	for range q.namespaces {
+		namespace := q.namespaces[q.next]
+		q.next = (q.next + 1) % len(q.namespaces)
+		if jobs := q.jobs[namespace]; !jobs.Empty() {
+			return jobs.Pop()
+		}
+	}
+	return nil
+}
+
+func (q *namespaceFairQueue) Empty() bool {
+	for _, jobs := range q.jobs {
+		if !jobs.Empty() {
+			return false
+		}
+	}
+	return true
+}
@@ -6,6 +6,7 @@ Modifications made by Volcano authors:
 - Added topology-aware preemption
 - Enhanced with predicate error caching and BestEffort constraints
 - Added victim selection algorithms with scoring and ordering
+- Added gang preemption planning across nodes
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -56,6 +57,8 @@ const (
 	MinCandidateNodesPercentageKey = "minCandidateNodesPercentage"
 	MinCandidateNodesAbsoluteKey   = "minCandidateNodesAbsolute"
 	MaxCandidateNodesAbsoluteKey   = "maxCandidateNodesAbsolute"
+
+	EnableGangPreemptionPlanningKey = "enableGangPreemptionPlanning"
 )
 
 type Action struct {
@@ -69,6 +72,8 @@ type Action struct {
 	minCandidateNodesPercentage   int
 	minCandidateNodesAbsolute     int
 	maxCandidateNodesAbsolute     int
+
+	enableGangPreemptionPlanning bool
 }
 
 func New() *Action {
@@ -79,6 +84,7 @@ func New() *Action {
 		minCandidateNodesPercentage:   10,
 		minCandidateNodesAbsolute:     1,
 		maxCandidateNodesAbsolute:     100,
+		enableGangPreemptionPlanning:  false,
 	}
 }
 
@@ -96,6 +102,7 @@ func (pmpt *Action) parseArguments(ssn *framework.Session) {
 	arguments.GetInt(&pmpt.minCandidateNodesPercentage, MinCandidateNodesPercentageKey)
 	arguments.GetInt(&pmpt.minCandidateNodesAbsolute, MinCandidateNodesAbsoluteKey)
 	arguments.GetInt(&pmpt.maxCandidateNodesAbsolute, MaxCandidateNodesAbsoluteKey)
+	arguments.GetBool(&pmpt.enableGangPreemptionPlanning, EnableGangPreemptionPlanningKey)
 	pmpt.ssn = ssn
 }
 
@@ -123,6 +130,9 @@ func (pmpt *Action) Execute(ssn *framework.Session) {
 
 		if queue, found := ssn.Queues[job.Queue]; !found {
 			continue
+		} else if queue.ActionDisabled(pmpt.Name()) {
+			klog.V(4).Infof("Job <%s/%s> Queue <%s> skip preemption, reason: preempt is disabled for the queue", job.Namespace, job.Name, job.Queue)
+			continue
 		} else if _, existed := queues[queue.UID]; !existed {
 			klog.V(3).Infof("Added Queue <%s> for Job <%s/%s>",
 				queue.Name, job.Namespace, job.Name)
@@ -163,42 +173,31 @@ func (pmpt *Action) Execute(ssn *framework.Session) {
 			stmt := framework.NewStatement(ssn)
 			var assigned bool
 			var err error
-			for {
-				// If job is not request more resource, then stop preempting.
-				if !ssn.JobStarving(preemptorJob) {
-					break
+			if pmpt.enableGangPreemptionPlanning {
+				assigned, err = pmpt.gangPreempt(ssn, stmt, preemptorJob, preemptorTasks[preemptorJob.UID], ph)
+				if err != nil {
+					klog.V(3).Infof("Job <%s/%s> failed to plan gang preemption, err: %s", preemptorJob.Namespace, preemptorJob.Name, err)
 				}
+			} else {
+				for {
+					// If job is not request more resource, then stop preempting.
+					if !ssn.JobStarving(preemptorJob) {
+						break
+					}
 
-				// If not preemptor tasks, next job.
-				if preemptorTasks[preemptorJob.UID].Empty() {
-					klog.V(3).Infof("No preemptor task in job <%s/%s>.",
-						preemptorJob.Namespace, preemptorJob.Name)
-					break
-				}
+					// If not preemptor tasks, next job.
+					if preemptorTasks[preemptorJob.UID].Empty() {
+						klog.V(3).Infof("No preemptor task in job <%s/%s>.",
+							preemptorJob.Namespace, preemptorJob.Name)
+						break
+					}
 
-				preemptor := preemptorTasks[preemptorJob.UID].Pop().(*api.TaskInfo)
+					preemptor := preemptorTasks[preemptorJob.UID].Pop().(*api.TaskInfo)
 
-				assigned, err = pmpt.preempt(ssn, stmt, preemptor, func(task *api.TaskInfo) bool {
-					// Ignore non running task.
-					if !api.PreemptableStatus(task.Status) {
-						return false
-					}
-					// BestEffort pod is not supported to preempt unBestEffort pod.
-					if preemptor.BestEffort && !task.BestEffort {
-						return false
-					}
-					if !task.Preemptable {
-						return false
-					}
-					job, found := ssn.Jobs[task.Job]
-					if !found {
-						return false
+					assigned, err = pmpt.preempt(ssn, stmt, preemptor, jobPreemptionFilter(ssn, preemptorJob, preemptor), ph)
+					if err != nil {
+						klog.V(3).Infof("Preemptor <%s/%s> failed to preempt Task , err: %s", preemptor.Namespace, preemptor.Name, err)
 					}
-					// Preempt other jobs within queue
-					return job.Queue == preemptorJob.Queue && preemptor.Job != task.Job
-				}, ph)
-				if err != nil {
-					klog.V(3).Infof("Preemptor <%s/%s> failed to preempt Task , err: %s", preemptor.Namespace, preemptor.Name, err)
 				}
 			}
 
@@ -397,6 +396,160 @@ func (pmpt *Action) normalPreempt(
 	return assigned, nil
 }
 
+// jobPreemptionFilter filters the tasks of the other jobs within the queue the preemptor can preempt.
+func jobPreemptionFilter(ssn *framework.Session, preemptorJob *api.JobInfo, preemptor *api.TaskInfo) func(*api.TaskInfo) bool {
+	return func(task *api.TaskInfo) bool {
+		// Ignore non running task.
+		if !api.PreemptableStatus(task.Status) {
+			return false
+		}
+		// BestEffort pod is not supported to preempt unBestEffort pod.
+		if preemptor.BestEffort && !task.BestEffort {
+			return false
+		}
+		if !task.Preemptable {
+			return false
+		}
+		job, found := ssn.Jobs[task.Job]
+		if !found {
+			return false
+		}
+		// Preempt other jobs within queue
+		return job.Queue == preemptorJob.Queue && preemptor.Job != task.Job
+	}
+}
+
+// gangPreempt plans the preemption for the pending tasks of the job as a whole instead of per task: every task is
+// placed on the node needing the fewest victims, counting the victims already evicted for the former tasks, so that
+// the gang is placed with a minimal victim set across the nodes. The statement is discarded by the caller if the job
+// is still not pipelined, so no victim is evicted for a gang which can not start.
+func (pmpt *Action) gangPreempt(
+	ssn *framework.Session,
+	stmt *framework.Statement,
+	preemptorJob *api.JobInfo,
+	preemptorTasks *util.PriorityQueue,
+	predicateHelper util.PredicateHelper,
+) (bool, error) {
+	currentQueue := ssn.Queues[preemptorJob.Queue]
+
+	assigned := false
+	var errs []error
+	for ssn.JobStarving(preemptorJob) && !preemptorTasks.Empty() {
+		preemptor := preemptorTasks.Pop().(*api.TaskInfo)
+
+		if err := pmpt.taskEligibleToPreempt(preemptor); err != nil {
+			errs = append(errs, fmt.Errorf("task %s/%s: %v", preemptor.Namespace, preemptor.Name, err))
+			continue
+		}
+		if err := ssn.PrePredicateFn(preemptor); err != nil {
+			errs = append(errs, fmt.Errorf("PrePredicate for task %s/%s failed for: %v", preemptor.Namespace, preemptor.Name, err))
+			continue
+		}
+
+		allNodes := ssn.FilterOutUnschedulableAndUnresolvableNodesForTask(preemptor)
+		predicateNodes, _ := predicateHelper.PredicateNodes(preemptor, allNodes, ssn.PredicateForPreemptAction, pmpt.enablePredicateErrorCache)
+		nodeScores := util.PrioritizeNodes(preemptor, predicateNodes, ssn.BatchNodeOrderFn, ssn.NodeOrderMapFn, ssn.NodeOrderReduceFn)
+
+		filter := jobPreemptionFilter(ssn, preemptorJob, preemptor)
+		node, victims := planVictims(ssn, currentQueue, preemptor, filter, util.SortNodes(nodeScores))
+		if node == nil {
+			errs = append(errs, fmt.Errorf("no node for task %s/%s even if preempting", preemptor.Namespace, preemptor.Name))
+			continue
+		}
+		metrics.UpdatePreemptionVictimsCount(len(victims))
+
+		for _, victim := range victims {
+			klog.V(3).Infof("Try to preempt Task <%s/%s> for Task <%s/%s>",
+				victim.Namespace, victim.Name, preemptor.Namespace, preemptor.Name)
+			if err := stmt.Evict(victim, "preempt"); err != nil {
+				klog.Errorf("Failed to preempt Task <%s/%s> for Task <%s/%s>: %v",
+					victim.Namespace, victim.Name, preemptor.Namespace, preemptor.Name, err)
+			}
+		}
+		metrics.RegisterPreemptionAttempts()
+
+		if err := stmt.Pipeline(preemptor, node.Name, len(victims) > 0); err != nil {
+			klog.Errorf("Failed to pipeline Task <%s/%s> on Node <%s>",
+				preemptor.Namespace, preemptor.Name, node.Name)
+			if rollbackErr := stmt.UnPipeline(preemptor); rollbackErr != nil {
+				klog.Errorf("Failed to unpipeline Task %v on %v in Session %v for %v.",
+					preemptor.UID, node.Name, ssn.UID, rollbackErr)
+			}
+		}
+
+		// Ignore pipeline error, will be corrected in next scheduling loop.
+		assigned = true
+	}
+
+	return assigned, utilerrors.NewAggregate(errs)
+}
+
+// planVictims returns the node needing the fewest victims to place the preemptor and the victims on it, the node
+// ordered first wins a tie. It returns nil if the preemptor can not be placed on any node.
+func planVictims(
+	ssn *framework.Session,
+	queue *api.QueueInfo,
+	preemptor *api.TaskInfo,
+	filter func(*api.TaskInfo) bool,
+	nodes []*api.NodeInfo,
+) (*api.NodeInfo, []*api.TaskInfo) {
+	var bestNode *api.NodeInfo
+	var bestVictims []*api.TaskInfo
+	for _, node := range nodes {
+		victims, ok := selectVictims(ssn, queue, preemptor, filter, node)
+		if !ok {
+			continue
+		}
+		if bestNode == nil || len(victims) < len(bestVictims) {
+			bestNode, bestVictims = node, victims
+		}
+		if len(bestVictims) == 0 {
+			break
+		}
+	}
+	return bestNode, bestVictims
+}
+
+// selectVictims selects the victims on the node to place the preemptor, lowest priority first, as normalPreempt does.
+// The victims are evicted in a trial statement which is discarded, so that the queue is allocatable for the preemptor
+// with the victims released. It returns false if the preemptor can not be placed on the node.
+func selectVictims(
+	ssn *framework.Session,
+	queue *api.QueueInfo,
+	preemptor *api.TaskInfo,
+	filter func(*api.TaskInfo) bool,
+	node *api.NodeInfo,
+) ([]*api.TaskInfo, bool) {
+	var preemptees []*api.TaskInfo
+	for _, task := range node.Tasks {
+		if filter(task) {
+			preemptees = append(preemptees, task.Clone())
+		}
+	}
+	victims := ssn.Preemptable(preemptor, preemptees)
+	if err := util.ValidateVictims(preemptor, node, victims); err != nil {
+		klog.V(4).Infof("No validated victims on Node <%s>: %v", node.Name, err)
+		return nil, false
+	}
+
+	trial := framework.NewStatement(ssn)
+	defer trial.Discard()
+
+	var selected []*api.TaskInfo
+	victimsQueue := ssn.BuildVictimsPriorityQueue(victims, preemptor)
+	for !ssn.Allocatable(queue, preemptor) || !preemptor.InitResreq.LessEqual(node.FutureIdle(), api.Zero) {
+		if victimsQueue.Empty() {
+			return nil, false
+		}
+		victim := victimsQueue.Pop().(*api.TaskInfo)
+		if err := trial.Evict(victim, "preempt"); err != nil {
+			continue
+		}
+		selected = append(selected, victim)
+	}
+	return selected, true
+}
+
 func (pmpt *Action) taskEligibleToPreempt(preemptor *api.TaskInfo) error {
 	if preemptor.Pod.Spec.PreemptionPolicy != nil && *preemptor.Pod.Spec.PreemptionPolicy == v1.PreemptNever {
 		return fmt.Errorf("not eligible to preempt other tasks due to preemptionPolicy is Never")
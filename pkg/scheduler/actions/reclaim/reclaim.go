@@ -23,6 +23,8 @@ limitations under the License.
 package reclaim
 
 import (
+	"sort"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
@@ -69,6 +71,9 @@ func (ra *Action) Execute(ssn *framework.Session) {
 		if queue, found := ssn.Queues[job.Queue]; !found {
 			klog.Errorf("Failed to find Queue <%s> for Job <%s/%s>", job.Queue, job.Namespace, job.Name)
 			continue
+		} else if queue.ActionDisabled(ra.Name()) {
+			klog.V(4).Infof("Job <%s/%s> Queue <%s> skip reclaim, reason: reclaim is disabled for the queue", job.Namespace, job.Name, job.Queue)
+			continue
 		} else if _, existed := queueMap[queue.UID]; !existed {
 			klog.V(4).Infof("Added Queue <%s> for Job <%s/%s>", queue.Name, job.Namespace, job.Name)
 			queueMap[queue.UID] = queue
@@ -194,8 +199,12 @@ func (ra *Action) Execute(ssn *framework.Session) {
 			reclaimed := api.EmptyResource()
 
 			// Reclaim victims for tasks.
-			for !victimsQueue.Empty() {
-				reclaimee := victimsQueue.Pop().(*api.TaskInfo)
+			for _, reclaimee := range drainOrder(victimsQueue, ssn.TaskCompareFns) {
+				if j, found := ssn.Jobs[reclaimee.Job]; found {
+					if q, found := ssn.Queues[j.Queue]; found {
+						reclaimee.EvictionGracePeriodSeconds = q.EvictionGracePeriodSeconds()
+					}
+				}
 				klog.Errorf("Try to reclaim Task <%s/%s> for Tasks <%s/%s>",
 					reclaimee.Namespace, reclaimee.Name, task.Namespace, task.Name)
 				if err := ssn.Evict(reclaimee, "reclaim"); err != nil {
@@ -213,6 +222,8 @@ func (ra *Action) Execute(ssn *framework.Session) {
 			klog.V(3).Infof("Reclaimed <%v> for task <%s/%s> requested <%v>.",
 				reclaimed, task.Namespace, task.Name, task.InitResreq)
 
+			// The task is pipelined rather than allocated, so that it is bound only after the victims are
+			// terminated and their resources are released from the node, rather than counted twice.
 			if task.InitResreq.LessEqual(reclaimed, api.Zero) {
 				if err := ssn.Pipeline(task, n.Name); err != nil {
 					klog.Errorf("Failed to pipeline Task <%s/%s> on Node <%s>",
@@ -235,3 +246,32 @@ func (ra *Action) Execute(ssn *framework.Session) {
 
 func (ra *Action) UnInitialize() {
 }
+
+// drainOrder returns the victims in the order of eviction. The jobs are in the order of the victims queue,
+// and the victims of the same job are evicted from the last in task order. The victims ranked equally by
+// the task order functions are evicted in reverse-create order, so that a gang is drained from its latest
+// members, which are usually the workers rather than the masters.
+func drainOrder(victimsQueue *util.PriorityQueue, taskCompareFn api.CompareFn) []*api.TaskInfo {
+	var jobs []api.JobID
+	jobVictims := map[api.JobID][]*api.TaskInfo{}
+	for !victimsQueue.Empty() {
+		victim := victimsQueue.Pop().(*api.TaskInfo)
+		if _, found := jobVictims[victim.Job]; !found {
+			jobs = append(jobs, victim.Job)
+		}
+		jobVictims[victim.Job] = append(jobVictims[victim.Job], victim)
+	}
+
+	victims := make([]*api.TaskInfo, 0, len(jobVictims))
+	for _, job := range jobs {
+		tasks := jobVictims[job]
+		sort.SliceStable(tasks, func(i, j int) bool {
+			if res := taskCompareFn(tasks[i], tasks[j]); res != 0 {
+				return res > 0
+			}
+			return tasks[j].Pod.CreationTimestamp.Before(&tasks[i].Pod.CreationTimestamp)
+		})
+		victims = append(victims, tasks...)
+	}
+	return victims
+}
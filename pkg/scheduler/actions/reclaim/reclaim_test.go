@@ -22,9 +22,11 @@ package reclaim
 
 import (
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/scheduler/api"
@@ -265,3 +267,50 @@ func TestReclaim(t *testing.T) {
 		})
 	}
 }
+
+func TestDrainOrder(t *testing.T) {
+	now := time.Now()
+	buildVictim := func(name string, job api.JobID, priority int32, created time.Time) *api.TaskInfo {
+		return &api.TaskInfo{
+			Name:     name,
+			Job:      job,
+			Priority: priority,
+			Pod:      &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)}},
+		}
+	}
+
+	// the task order prefers the tasks of higher priority, so the victims of lower priority are evicted first
+	taskCompareFn := func(l, r interface{}) int {
+		lv, rv := l.(*api.TaskInfo).Priority, r.(*api.TaskInfo).Priority
+		if lv == rv {
+			return 0
+		}
+		if lv > rv {
+			return -1
+		}
+		return 1
+	}
+	// the victims queue orders the jobs by name
+	victimsQueue := util.NewPriorityQueue(func(l, r interface{}) bool {
+		return l.(*api.TaskInfo).Job < r.(*api.TaskInfo).Job
+	})
+	victimsQueue.Push(buildVictim("j1-master", "j1", 1, now))
+	victimsQueue.Push(buildVictim("j1-worker-0", "j1", 1, now.Add(time.Minute)))
+	victimsQueue.Push(buildVictim("j1-worker-1", "j1", 1, now.Add(2*time.Minute)))
+	victimsQueue.Push(buildVictim("j2-master", "j2", 2, now.Add(2*time.Minute)))
+	victimsQueue.Push(buildVictim("j2-worker-0", "j2", 1, now))
+	victimsQueue.Push(buildVictim("j2-worker-1", "j2", 1, now.Add(time.Minute)))
+
+	// the victims of j2 ranked equally by the task order are evicted in reverse-create order,
+	// and the master of higher priority is evicted last even though it is created latest
+	expected := []string{"j1-worker-1", "j1-worker-0", "j1-master", "j2-worker-1", "j2-worker-0", "j2-master"}
+	victims := drainOrder(victimsQueue, taskCompareFn)
+	if len(victims) != len(expected) {
+		t.Fatalf("expected %d victims, got %d", len(expected), len(victims))
+	}
+	for i, victim := range victims {
+		if victim.Name != expected[i] {
+			t.Errorf("expected victim %d to be %s, got %s", i, expected[i], victim.Name)
+		}
+	}
+}
@@ -29,6 +29,14 @@ const (
 	VolcanoVGPURegister = "volcano.sh/node-vgpu-register"
 	// VolcanoVGPUHandshake for vgpu
 	VolcanoVGPUHandshake = "volcano.sh/node-vgpu-handshake"
+	// VolcanoVGPUMigGeometry is the mig geometries the GPUs are partitioned into, reported by device-plugin to scheduler
+	VolcanoVGPUMigGeometry = "volcano.sh/node-vgpu-mig-geometry"
+	// VolcanoVGPUMigReconfig is the mig geometries the GPUs are requested to be repartitioned into, from scheduler
+	// to device-plugin
+	VolcanoVGPUMigReconfig = "volcano.sh/node-vgpu-mig-reconfig"
+	// VolcanoVGPUMigSlicePrefix is the prefix of the resources of mig slices accounted to queues, e.g.
+	// volcano.sh/vgpu-mig-1g.10gb
+	VolcanoVGPUMigSlicePrefix = "volcano.sh/vgpu-mig-"
 )
 
 // MigTemplate is the template for a certain mig instance
@@ -110,6 +118,9 @@ type NvidiaConfig struct {
 	DisableCoreLimit bool `yaml:"disableCoreLimit"`
 	// MigGeometriesList is the mig-template geometries
 	MigGeometriesList []AllowedMigGeometries `yaml:"knownMigGeometries"`
+	// DynamicMigReconfig is whether an idle GPU can be repartitioned into another geometry than the one reported by
+	// vgpu-device-plugin
+	DynamicMigReconfig bool `yaml:"dynamicMigReconfig"`
 	// GPUMemoryFactor is the multiplier to every unit of device memory
 	GPUMemoryFactor uint `yaml:"gpuMemoryFactor"`
 }
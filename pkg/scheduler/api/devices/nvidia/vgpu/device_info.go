@@ -23,6 +23,7 @@ import (
 
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
@@ -64,6 +65,9 @@ type GPUDevice struct {
 	MigTemplate []deviceconfig.Geometry
 	/// MigUsage for this GPU
 	MigUsage deviceconfig.MigInUse
+	// MigConfigured is the index of the geometry group this GPU is partitioned into reported by the device plugin,
+	// -1 if not reported
+	MigConfigured int
 }
 
 type GPUDevices struct {
@@ -72,10 +76,14 @@ type GPUDevices struct {
 	Mode string
 	// We cache score in filter step according to schedulePolicy, to avoid recalculating in score
 	Score float64
+	// SchedulePolicy is cached in filter step, so that devices are chosen in the same way in allocate step
+	SchedulePolicy string
 
 	Device map[int]*GPUDevice
 	// Sharing sharing handler
 	Sharing SharingFactory
+	// MigReconfig is the geometry groups the GPUs are requested to be repartitioned into, by GPU UUID
+	MigReconfig map[string]string
 }
 
 // NewGPUDevice creates a device
@@ -87,6 +95,8 @@ func NewGPUDevice(id int, mem uint) *GPUDevice {
 		UsedNum:  0,
 		UsedMem:  0,
 		UsedCore: 0,
+		// no geometry group is reported yet
+		MigConfigured: -1,
 	}
 }
 
@@ -108,6 +118,10 @@ func NewGPUDevices(name string, node *v1.Node) *GPUDevices {
 	}
 	sharingHandler, _ := GetSharingHandler(sharingMode)
 	klog.V(3).Infoln("GPU sharing mode: ", sharingMode)
+	if sharingMode == vGPUControllerMIG {
+		setMigGeometries(nodedevices, decodeMigGeometries(node.Annotations[deviceconfig.VolcanoVGPUMigGeometry]))
+		nodedevices.MigReconfig = decodeMigGeometries(node.Annotations[deviceconfig.VolcanoVGPUMigReconfig])
+	}
 	for _, val := range nodedevices.Device {
 		klog.V(3).InfoS("Nvidia Device registered name", "name", nodedevices.Name, "val", *val)
 		ResetDeviceMetrics(val.UUID, node.Name, float64(val.Memory))
@@ -165,6 +179,10 @@ func (gs *GPUDevices) AddQueueResource(pod *v1.Pod) map[string]float64 {
 				if strings.Contains(deviceused.UUID, gsdevice.UUID) {
 					res[getConfig().ResourceMemoryName] += float64(deviceused.Usedmem * 1000)
 					res[getConfig().ResourceCoreName] += float64(deviceused.Usedcores * 1000)
+					// the mig slices are accounted by profile, so that the usage of the queue is tracked in slices
+					if profile, ok := migProfile(gsdevice, deviceused.UUID); ok && gs.Mode == vGPUControllerMIG {
+						res[migSliceResourceName(profile)] += 1000
+					}
 				}
 			}
 		}
@@ -253,6 +271,7 @@ func (gs *GPUDevices) FilterNode(pod *v1.Pod, schedulePolicy string) (int, strin
 			return devices.Unschedulable, "hami-vgpuDeviceSharing error", err
 		}
 		gs.Score = score
+		gs.SchedulePolicy = schedulePolicy
 		klog.V(4).Infoln("hami-vgpu DeviceSharing successfully filters pods")
 	}
 	return devices.Success, "", nil
@@ -261,7 +280,7 @@ func (gs *GPUDevices) FilterNode(pod *v1.Pod, schedulePolicy string) (int, strin
 func (gs *GPUDevices) Allocate(kubeClient kubernetes.Interface, pod *v1.Pod) error {
 	if VGPUEnable {
 		klog.V(4).Infoln("hami-vgpu DeviceSharing:Into AllocateToPod", pod.Name)
-		fit, device, _, err := checkNodeGPUSharingPredicateAndScore(pod, gs, false, "")
+		fit, device, _, err := checkNodeGPUSharingPredicateAndScore(pod, gs, false, gs.SchedulePolicy)
 		if err != nil || !fit {
 			klog.ErrorS(err, "Failed to allocate vgpu task", "pod", pod.Name)
 			return err
@@ -274,6 +293,17 @@ func (gs *GPUDevices) Allocate(kubeClient kubernetes.Interface, pod *v1.Pod) err
 			}
 		}
 
+		if gs.Mode == vGPUControllerMIG && getConfig().DynamicMigReconfig {
+			if requests := migReconfigRequests(gs, device); requests != nil {
+				err = patchNodeAnnotations(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: gs.Name}},
+					map[string]string{deviceconfig.VolcanoVGPUMigReconfig: encodeMigGeometries(requests)})
+				if err != nil {
+					return errors.Errorf("failed to request mig reconfiguration of node %s for %s: %v", gs.Name, pod.Name, err)
+				}
+				gs.MigReconfig = requests
+			}
+		}
+
 		annotations := make(map[string]string)
 		annotations[AssignedNodeAnnotations] = gs.Name
 		annotations[AssignedTimeAnnotations] = strconv.FormatInt(time.Now().Unix(), 10)
@@ -28,7 +28,7 @@ func init() {
 	RegisterFactory(vGPUControllerHAMICore, HAMICoreFactory{})
 }
 
-func (f HAMICoreFactory) TryAddPod(gd *GPUDevice, mem uint, core uint) (bool, string) {
+func (f HAMICoreFactory) TryAddPod(gd *GPUDevice, mem uint, core uint, profile string) (bool, string) {
 	gd.UsedNum++
 	gd.UsedMem += mem
 	gd.UsedCore += core
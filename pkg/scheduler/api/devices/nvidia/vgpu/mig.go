@@ -33,8 +33,8 @@ func init() {
 	RegisterFactory(vGPUControllerMIG, MIGFactory{})
 }
 
-func (f MIGFactory) TryAddPod(gd *GPUDevice, mem uint, core uint) (bool, string) {
-	found, dev, usedMem := findMatch(gd.UUID, mem, gd.MigUsage, gd.MigTemplate)
+func (f MIGFactory) TryAddPod(gd *GPUDevice, mem uint, core uint, profile string) (bool, string) {
+	found, dev, usedMem := findMatch(gd.UUID, mem, profile, gd.MigUsage, gd.MigTemplate)
 	if !found {
 		return false, ""
 	}
@@ -96,17 +96,18 @@ func (f MIGFactory) SubPod(gd *GPUDevice, mem uint, core uint, podUID string, de
 	return nil
 }
 
-// Try to find a match
+// Try to find a match, of the profile if not empty
 func findMatch(
 	uuid string,
 	requestMem uint,
+	profile string,
 	usage config.MigInUse,
 	allowedGeometries []config.Geometry,
 ) (bool, string, uint) {
 	// If a group is already in use
 	if usage.Index >= 0 {
 		group := allowedGeometries[usage.Index]
-		fitted, position, realMem := pickFromGroup(group, usage.UsageList, requestMem)
+		fitted, position, realMem := pickFromGroup(group, usage.UsageList, requestMem, profile)
 		if fitted {
 			MIGID := encodeMIGID(uuid, group.Group, position)
 			return true, MIGID, realMem
@@ -117,7 +118,7 @@ func findMatch(
 
 	// No group in use yet, try groups in order
 	for _, group := range allowedGeometries {
-		fitted, position, realMem := pickFromGroup(group, nil, requestMem)
+		fitted, position, realMem := pickFromGroup(group, nil, requestMem, profile)
 		if fitted {
 			MIGID := encodeMIGID(uuid, group.Group, position)
 			return true, MIGID, realMem
@@ -146,8 +147,10 @@ For example: the group define like this:
 
 The position of "1g.10gb" in group2 is 3 + 1 - 1. "3" is the resource count before
 "1g.10gb", the "1" is in-resource index.
+
+If the profile is not empty, only the instances of the profile are picked whatever their memory.
 */
-func pickFromGroup(group config.Geometry, usage config.MIGS, requestMemory uint) (bool, int, uint) {
+func pickFromGroup(group config.Geometry, usage config.MIGS, requestMemory uint, profile string) (bool, int, uint) {
 	type MigTemplateWithIndex struct {
 		Index    int
 		Instance config.MigTemplate
@@ -164,27 +167,46 @@ func pickFromGroup(group config.Geometry, usage config.MIGS, requestMemory uint)
 		return instances[i].Instance.Memory < instances[j].Instance.Memory
 	})
 
+	// The instances in use out of the group means the GPU is partitioned into another geometry
+	for _, usedInst := range usage {
+		if !hasInstance(group, usedInst.Name) {
+			klog.V(4).Infoln("mig instance in use is not in group: ", usedInst.Name, group.Group)
+			return false, -1, 0
+		}
+	}
+
 	for _, inst := range instances {
-		if len(usage) == 0 && inst.Instance.Memory >= requestMemory {
-			position := getPosition(group, inst.Instance.Count, []int{}, inst.Index)
-			klog.V(4).Infoln("pick mig group with no used group: ", inst.Index, inst.Instance.Memory, group.Group)
-			return true, position, inst.Instance.Memory
+		if profile != "" && inst.Instance.Name != profile {
+			continue
+		}
+		if profile == "" && inst.Instance.Memory < requestMemory {
+			continue
 		}
+		usedIndex := []int{}
 		for _, usedInst := range usage {
 			if usedInst.Name == inst.Instance.Name {
-				available := inst.Instance.Count - len(usedInst.UsedIndex)
-				if available > 0 && inst.Instance.Memory >= requestMemory {
-					position := getPosition(group, inst.Instance.Count, usedInst.UsedIndex, inst.Index)
-					klog.V(4).Infoln("pick mig group with used group: ", usedInst.Name, inst.Instance.Name, position)
-					return true, position, inst.Instance.Memory
-				}
+				usedIndex = usedInst.UsedIndex
 			}
 		}
+		if inst.Instance.Count-len(usedIndex) > 0 {
+			position := getPosition(group, inst.Instance.Count, usedIndex, inst.Index)
+			klog.V(4).Infoln("pick mig group: ", inst.Instance.Name, group.Group, position)
+			return true, position, inst.Instance.Memory
+		}
 	}
 	klog.V(2).Infoln("pick mig group but no suitalbe")
 	return false, -1, 0
 }
 
+func hasInstance(group config.Geometry, name string) bool {
+	for _, inst := range group.Instances {
+		if inst.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func getPosition(group config.Geometry, count int, usedIndex []int, index int) int {
 	position := 0
 	for i := 0; i < index; i++ {
@@ -290,6 +312,9 @@ func subMigUsed(gd *GPUDevice, groupName string, position int) uint {
 				if len(gd.MigUsage.UsageList[i].UsedIndex) == 0 {
 					gd.MigUsage.UsageList = append(gd.MigUsage.UsageList[:i], gd.MigUsage.UsageList[i+1:]...)
 				}
+				if len(gd.MigUsage.UsageList) == 0 {
+					gd.MigUsage.Index = gd.idleMigIndex()
+				}
 				return mem
 			}
 			return 0
@@ -298,6 +323,137 @@ func subMigUsed(gd *GPUDevice, groupName string, position int) uint {
 	return 0
 }
 
+// idleMigIndex returns the geometry group the GPU is restricted to when none of its mig instances is used, -1 for
+// any group. The GPU is restricted to the geometry reported by the device plugin, unless it can be repartitioned.
+func (gd *GPUDevice) idleMigIndex() int {
+	if getConfig().DynamicMigReconfig {
+		return -1
+	}
+	return gd.MigConfigured
+}
+
+// migProfile returns the profile of the mig instance of the device id, e.g. 1g.10gb of GPU-xxx[group2-3].
+func migProfile(gd *GPUDevice, devID string) (string, bool) {
+	groupName, position, err := decodeMIGID(devID)
+	if err != nil {
+		return "", false
+	}
+	for _, group := range gd.MigTemplate {
+		if group.Group != groupName {
+			continue
+		}
+		instanceIndex, _ := findPosition(group, position)
+		if instanceIndex < 0 {
+			return "", false
+		}
+		return group.Instances[instanceIndex].Name, true
+	}
+	return "", false
+}
+
+// migProfileMemory returns the memory of the mig instances of the profile, if any geometry of the GPU has them.
+func migProfileMemory(gd *GPUDevice, profile string) (uint, bool) {
+	for _, group := range gd.MigTemplate {
+		for _, inst := range group.Instances {
+			if inst.Name == profile {
+				return inst.Memory, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// migSliceResourceName returns the resource the mig slices of the profile are accounted to queues in.
+func migSliceResourceName(profile string) string {
+	// profiles like 1g.10gb+me are not valid resource names
+	return config.VolcanoVGPUMigSlicePrefix + strings.ReplaceAll(profile, "+", "-")
+}
+
+/*
+The mig geometries are exchanged with the device plugin by the node annotations in the form of
+"GPU-uuid1,group1:GPU-uuid2,group2":
+  - the device plugin reports the groups the GPUs are partitioned into by volcano.sh/node-vgpu-mig-geometry;
+  - if dynamicMigReconfig is enabled, the scheduler may allocate the instances of another group on an idle GPU, and
+    requests the device plugin to repartition it by volcano.sh/node-vgpu-mig-reconfig. The device plugin repartitions
+    the GPU before it allocates the instances to the containers, then reports the new geometry and drops the request.
+*/
+func decodeMigGeometries(str string) map[string]string {
+	geometries := map[string]string{}
+	for _, item := range strings.Split(str, ":") {
+		parts := strings.Split(item, ",")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+		geometries[parts[0]] = parts[1]
+	}
+	return geometries
+}
+
+func encodeMigGeometries(geometries map[string]string) string {
+	uuids := make([]string, 0, len(geometries))
+	for uuid := range geometries {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+	items := make([]string, 0, len(uuids))
+	for _, uuid := range uuids {
+		items = append(items, uuid+","+geometries[uuid])
+	}
+	return strings.Join(items, ":")
+}
+
+// setMigGeometries sets the geometry groups the GPUs are partitioned into as reported by the device plugin.
+func setMigGeometries(gs *GPUDevices, geometries map[string]string) {
+	for _, gd := range gs.Device {
+		groupName, ok := geometries[gd.UUID]
+		if !ok {
+			continue
+		}
+		for index, group := range gd.MigTemplate {
+			if group.Group == groupName {
+				gd.MigConfigured = index
+				gd.MigUsage.Index = gd.idleMigIndex()
+				break
+			}
+		}
+	}
+}
+
+// migReconfigRequests returns the requests for the GPUs to be repartitioned into the groups of the instances
+// allocated, merged with the requests pending, or nil if all the GPUs are already partitioned so.
+func migReconfigRequests(gs *GPUDevices, pd []ContainerDevices) map[string]string {
+	requests := map[string]string{}
+	for uuid, group := range gs.MigReconfig {
+		requests[uuid] = group
+	}
+	changed := false
+	for _, cd := range pd {
+		for _, dev := range cd {
+			groupName, _, err := decodeMIGID(dev.UUID)
+			if err != nil {
+				continue
+			}
+			for _, gd := range gs.Device {
+				if !strings.HasPrefix(dev.UUID, gd.UUID+"[") || gd.MigConfigured < 0 {
+					continue
+				}
+				current := gd.MigTemplate[gd.MigConfigured].Group
+				if pending, ok := requests[gd.UUID]; ok {
+					current = pending
+				}
+				if current != groupName {
+					requests[gd.UUID] = groupName
+					changed = true
+				}
+			}
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return requests
+}
+
 // Insert a value in order
 func insert(list []int, value int) []int {
 	klog.V(4).Infoln("insert mig used list before: ", list, value)
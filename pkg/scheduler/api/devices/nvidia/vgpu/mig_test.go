@@ -19,6 +19,10 @@ package vgpu
 import (
 	"testing"
 
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"volcano.sh/volcano/pkg/scheduler/api/devices/config"
 )
 
@@ -27,6 +31,7 @@ func TestFindMatch(t *testing.T) {
 		name              string
 		uuid              string
 		requestMem        uint
+		profile           string
 		usage             config.MigInUse
 		allowedGeometries []config.Geometry
 		wantFit           bool
@@ -233,11 +238,91 @@ func TestFindMatch(t *testing.T) {
 			wantUUID: "gpu-0005[default-0]",
 			wantMem:  10,
 		},
+		{
+			name:       "Unused instance of the group in use",
+			uuid:       "gpu-0006",
+			requestMem: 5,
+			usage: config.MigInUse{
+				Index: 0,
+				UsageList: config.MIGS{
+					{Name: "2g.20gb", Memory: 20, InUse: true, UsedIndex: []int{0}},
+				},
+			},
+			allowedGeometries: []config.Geometry{
+				{
+					Group: "group2",
+					Instances: []config.MigTemplate{
+						{Name: "2g.20gb", Memory: 20, Count: 3},
+						{Name: "1g.10gb", Memory: 10, Count: 1},
+					},
+				},
+			},
+			wantFit:  true,
+			wantUUID: "gpu-0006[group2-3]",
+			wantMem:  10,
+		},
+		{
+			name:       "Profile picked whatever the memory requested",
+			uuid:       "gpu-0007",
+			requestMem: 5,
+			profile:    "2g.20gb",
+			usage: config.MigInUse{
+				Index:     -1,
+				UsageList: config.MIGS{},
+			},
+			allowedGeometries: []config.Geometry{
+				{
+					Group: "group1",
+					Instances: []config.MigTemplate{
+						{Name: "1g.10gb", Memory: 10, Count: 7},
+					},
+				},
+				{
+					Group: "group2",
+					Instances: []config.MigTemplate{
+						{Name: "2g.20gb", Memory: 20, Count: 3},
+						{Name: "1g.10gb", Memory: 10, Count: 1},
+					},
+				},
+			},
+			wantFit:  true,
+			wantUUID: "gpu-0007[group2-0]",
+			wantMem:  20,
+		},
+		{
+			name:       "Profile not in the group in use",
+			uuid:       "gpu-0008",
+			requestMem: 5,
+			profile:    "2g.20gb",
+			usage: config.MigInUse{
+				Index: 0,
+				UsageList: config.MIGS{
+					{Name: "1g.10gb", Memory: 10, InUse: true, UsedIndex: []int{0}},
+				},
+			},
+			allowedGeometries: []config.Geometry{
+				{
+					Group: "group1",
+					Instances: []config.MigTemplate{
+						{Name: "1g.10gb", Memory: 10, Count: 7},
+					},
+				},
+				{
+					Group: "group2",
+					Instances: []config.MigTemplate{
+						{Name: "2g.20gb", Memory: 20, Count: 3},
+					},
+				},
+			},
+			wantFit:  false,
+			wantUUID: "",
+			wantMem:  0,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			gotFit, gotUUID, gotMem := findMatch(tc.uuid, tc.requestMem, tc.usage, tc.allowedGeometries)
+			gotFit, gotUUID, gotMem := findMatch(tc.uuid, tc.requestMem, tc.profile, tc.usage, tc.allowedGeometries)
 
 			if gotFit != tc.wantFit {
 				t.Errorf("findMatch() gotFit = %v, want %v", gotFit, tc.wantFit)
@@ -391,3 +476,108 @@ func TestAddAndSubMigUsed(t *testing.T) {
 		})
 	}
 }
+
+func buildMigDevices() *GPUDevices {
+	geometries := []config.Geometry{
+		{
+			Group: "group1",
+			Instances: []config.MigTemplate{
+				{Name: "1g.10gb", Memory: 10240, Count: 7},
+			},
+		},
+		{
+			Group: "group2",
+			Instances: []config.MigTemplate{
+				{Name: "2g.20gb", Memory: 20480, Count: 3},
+				{Name: "1g.10gb", Memory: 10240, Count: 1},
+			},
+		},
+	}
+	return &GPUDevices{
+		Name: "node1",
+		Mode: vGPUControllerMIG,
+		Device: map[int]*GPUDevice{
+			0: {ID: 0, UUID: "GPU-0", Type: "NVIDIA-A100-PCIE-80GB", Number: 10, Memory: 81920, PodMap: map[string]*GPUUsage{},
+				MigTemplate: geometries, MigUsage: config.MigInUse{Index: -1}, MigConfigured: -1},
+			1: {ID: 1, UUID: "GPU-1", Type: "NVIDIA-A100-PCIE-80GB", Number: 10, Memory: 81920, PodMap: map[string]*GPUUsage{},
+				MigTemplate: geometries, MigUsage: config.MigInUse{Index: -1}, MigConfigured: -1},
+		},
+		Sharing: sharingRegistry[vGPUControllerMIG],
+	}
+}
+
+func TestMigGeometries(t *testing.T) {
+	geometries := decodeMigGeometries("GPU-1,group2:GPU-0,group1:invalid")
+	if len(geometries) != 2 || geometries["GPU-0"] != "group1" || geometries["GPU-1"] != "group2" {
+		t.Fatalf("unexpected geometries decoded: %v", geometries)
+	}
+	if encoded := encodeMigGeometries(geometries); encoded != "GPU-0,group1:GPU-1,group2" {
+		t.Errorf("unexpected geometries encoded: %s", encoded)
+	}
+
+	gs := buildMigDevices()
+	setMigGeometries(gs, map[string]string{"GPU-0": "group1", "GPU-1": "unknown"})
+	if gs.Device[0].MigConfigured != 0 || gs.Device[0].MigUsage.Index != 0 {
+		t.Errorf("expected GPU-0 restricted to group1, got %d, %d", gs.Device[0].MigConfigured, gs.Device[0].MigUsage.Index)
+	}
+	if gs.Device[1].MigConfigured != -1 || gs.Device[1].MigUsage.Index != -1 {
+		t.Errorf("expected GPU-1 not restricted, got %d, %d", gs.Device[1].MigConfigured, gs.Device[1].MigUsage.Index)
+	}
+
+	// the GPU partitioned into group1 is restricted to it again once idle
+	addMigUsed(gs.Device[0], "group1", 0)
+	subMigUsed(gs.Device[0], "group1", 0)
+	if gs.Device[0].MigUsage.Index != 0 {
+		t.Errorf("expected idle GPU-0 restricted to group1, got %d", gs.Device[0].MigUsage.Index)
+	}
+
+	requests := migReconfigRequests(gs, []ContainerDevices{{{UUID: "GPU-0[group1-1]"}, {UUID: "GPU-1[group2-0]"}}})
+	if requests != nil {
+		t.Errorf("expected no reconfiguration of the GPUs partitioned as allocated, got %v", requests)
+	}
+	requests = migReconfigRequests(gs, []ContainerDevices{{{UUID: "GPU-0[group2-0]"}}})
+	if len(requests) != 1 || requests["GPU-0"] != "group2" {
+		t.Errorf("expected reconfiguration of GPU-0 into group2, got %v", requests)
+	}
+	gs.MigReconfig = requests
+	if requests = migReconfigRequests(gs, []ContainerDevices{{{UUID: "GPU-0[group2-1]"}}}); requests != nil {
+		t.Errorf("expected no more reconfiguration of GPU-0 requested, got %v", requests)
+	}
+}
+
+func TestMigProfileRequest(t *testing.T) {
+	buildPod := func(annotations map[string]string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod", UID: "pod-uid", Annotations: annotations},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{config.VolcanoVGPUNumber: resource.MustParse("1")},
+					},
+				}},
+			},
+		}
+	}
+
+	gs := buildMigDevices()
+	pod := buildPod(map[string]string{MigProfileAnnotation: "2g.20gb"})
+	fit, devs, _, err := checkNodeGPUSharingPredicateAndScore(pod, gs, false, "")
+	if err != nil || !fit {
+		t.Fatalf("expected pod to fit, got fit %v, err %v", fit, err)
+	}
+	if len(devs) != 1 || len(devs[0]) != 1 || devs[0][0].UUID != "GPU-1[group2-0]" || devs[0][0].Usedmem != 20480 {
+		t.Fatalf("expected a 2g.20gb slice allocated, got %v", devs)
+	}
+
+	// the slices allocated are accounted to the queue by profile
+	pod.Annotations[AssignedIDsAnnotations] = encodePodDevices(devs)
+	res := gs.AddQueueResource(pod)
+	if res[config.VolcanoVGPUMigSlicePrefix+"2g.20gb"] != 1000 {
+		t.Errorf("expected a 2g.20gb slice accounted to the queue, got %v", res)
+	}
+
+	gs.Mode = vGPUControllerHAMICore
+	if fit, _, _, _ := checkNodeGPUSharingPredicateAndScore(pod, gs, true, ""); fit {
+		t.Errorf("expected pod requiring mig profile not to fit in hami-core mode")
+	}
+}
@@ -17,8 +17,8 @@ limitations under the License.
 package vgpu
 
 type SharingFactory interface {
-	// TryAddPod try to add pod, not add the pod to the device pod map
-	TryAddPod(gd *GPUDevice, mem uint, core uint) (bool, string)
+	// TryAddPod try to add pod, not add the pod to the device pod map, the mig profile is only honored in mig mode
+	TryAddPod(gd *GPUDevice, mem uint, core uint, profile string) (bool, string)
 	// AddPod truely add pod, add it to the device pod map
 	AddPod(gd *GPUDevice, mem uint, core uint, podUID string, devID string) error
 	// SubPod substract the pod and remove it from the device pod map
@@ -39,6 +39,9 @@ const (
 	// UnhealthyGPUIDs list of unhealthy gpu ids
 	UnhealthyGPUIDs = "volcano.sh/gpu-unhealthy-ids"
 
+	// MigProfileAnnotation is the mig profile the mig slices allocated to the pod must be of, e.g. 1g.10gb
+	MigProfileAnnotation = "volcano.sh/vgpu-mig-profile"
+
 	// binpack means the lower device memory remained after this allocation, the better
 	binpackPolicy = "binpack"
 	// spread means better put this task into an idle GPU card than a shared GPU card
@@ -67,6 +70,8 @@ type ContainerDeviceRequest struct {
 	Memreq           uint
 	MemPercentagereq int32
 	Coresreq         uint
+	// MigProfile is the mig profile requested, any profile fitting Memreq if empty
+	MigProfile string
 }
 
 type ContainerDevice struct {
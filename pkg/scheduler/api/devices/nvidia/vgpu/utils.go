@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -84,6 +86,7 @@ func decodeNodeDevices(name, str string) (*GPUDevices, string) {
 				MigTemplate: []config.Geometry{},
 				MigUsage: config.MigInUse{
 					Index: -1},
+				MigConfigured: -1,
 			}
 			sharingMode = getSharingMode(items[5])
 			if sharingMode == vGPUControllerMIG {
@@ -232,6 +235,7 @@ func resourcereqs(pod *v1.Pod) []ContainerDeviceRequest {
 				Memreq:           memnum,
 				MemPercentagereq: int32(mempnum),
 				Coresreq:         corenum,
+				MigProfile:       pod.Annotations[MigProfileAnnotation],
 			})
 		}
 	}
@@ -311,6 +315,7 @@ func getGPUDeviceSnapShot(snap *GPUDevices) *GPUDevices {
 				MigUsage:    val.MigUsage,
 			}
 			ret.Device[index].MigUsage = deepCopyMigInUse(val.MigUsage)
+			ret.Device[index].MigConfigured = val.MigConfigured
 			klog.V(4).Infoln("getGPUDeviceSnapShot:", ret.Device[index].UsedMem, val.UsedMem, ret.Device[index].MigUsage, val.MigUsage)
 		}
 	}
@@ -361,6 +366,11 @@ func checkNodeGPUSharingPredicateAndScore(pod *v1.Pod, gssnap *GPUDevices, repli
 		return false, []ContainerDevices{}, 0, fmt.Errorf("pod required sharing mode %s is not the same as the node mode %s", podSharingMode, gssnap.Mode)
 	}
 
+	// the mig profile can only be allocated in mig mode
+	if profile, ok := pod.Annotations[MigProfileAnnotation]; ok && gssnap.Mode != vGPUControllerMIG {
+		return false, []ContainerDevices{}, 0, fmt.Errorf("pod required mig profile %s but the node mode is %s", profile, gssnap.Mode)
+	}
+
 	ctrReq := resourcereqs(pod)
 	if len(ctrReq) == 0 {
 		return true, []ContainerDevices{}, 0, nil
@@ -380,13 +390,20 @@ func checkNodeGPUSharingPredicateAndScore(pod *v1.Pod, gssnap *GPUDevices, repli
 		}
 		klog.V(3).InfoS("Allocating device for container", "request", val)
 
-		for i := len(gs.Device) - 1; i >= 0; i-- {
+		for _, i := range deviceOrder(gs, schedulePolicy) {
 			klog.V(3).InfoS("Scoring pod request", "memReq", val.Memreq, "memPercentageReq", val.MemPercentagereq, "coresReq", val.Coresreq, "Nums", val.Nums, "Index", i, "ID", gs.Device[i].ID)
 			klog.V(3).InfoS("Current Device", "Index", i, "TotalMemory", gs.Device[i].Memory, "UsedMemory", gs.Device[i].UsedMem, "UsedCores", gs.Device[i].UsedCore, "replicate", replicate)
 			if gs.Device[i].Number <= uint(gs.Device[i].UsedNum) {
 				continue
 			}
-			if val.MemPercentagereq != 101 && val.Memreq == 0 {
+			if val.MigProfile != "" {
+				// the memory of the slice of the profile is allocated whatever the memory requested
+				memory, ok := migProfileMemory(gs.Device[i], val.MigProfile)
+				if !ok {
+					continue
+				}
+				val.Memreq = memory
+			} else if val.MemPercentagereq != 101 && val.Memreq == 0 {
 				val.Memreq = gs.Device[i].Memory * uint(val.MemPercentagereq/100)
 			}
 			if int(gs.Device[i].Memory)-int(gs.Device[i].UsedMem) < int(val.Memreq) {
@@ -407,7 +424,7 @@ func checkNodeGPUSharingPredicateAndScore(pod *v1.Pod, gssnap *GPUDevices, repli
 				klog.Errorln("failed checktype", gs.Device[i].Type, val.Type)
 				continue
 			}
-			fit, uuid := gs.Sharing.TryAddPod(gs.Device[i], uint(val.Memreq), uint(val.Coresreq))
+			fit, uuid := gs.Sharing.TryAddPod(gs.Device[i], uint(val.Memreq), uint(val.Coresreq), val.MigProfile)
 			if !fit {
 				klog.V(3).Info(gs.Device[i].ID, "not fit")
 				continue
@@ -437,6 +454,31 @@ func checkNodeGPUSharingPredicateAndScore(pod *v1.Pod, gssnap *GPUDevices, repli
 	return true, ctrdevs, score, nil
 }
 
+// deviceOrder returns the indexes of devices in the order to try for allocation. For binpack, devices
+// with less free memory are tried first, so that shared workloads are packed onto as few physical GPUs
+// as possible and idle GPUs are left for exclusive workloads. Otherwise, devices are tried from the
+// highest index.
+func deviceOrder(gs *GPUDevices, schedulePolicy string) []int {
+	order := make([]int, 0, len(gs.Device))
+	for i := len(gs.Device) - 1; i >= 0; i-- {
+		order = append(order, i)
+	}
+	if schedulePolicy != binpackPolicy {
+		return order
+	}
+
+	freeMemory := func(i int) int {
+		if gs.Device[i] == nil {
+			return math.MaxInt
+		}
+		return int(gs.Device[i].Memory) - int(gs.Device[i].UsedMem)
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return freeMemory(order[a]) < freeMemory(order[b])
+	})
+	return order
+}
+
 func GPUScore(schedulePolicy string, device *GPUDevice) float64 {
 	var score float64
 	switch schedulePolicy {
@@ -16,7 +16,15 @@ limitations under the License.
 
 package vgpu
 
-import "testing"
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api/devices/config"
+)
 
 func TestCheckGPUtype(t *testing.T) {
 	tests := []struct {
@@ -111,3 +119,70 @@ func TestCheckGPUtype(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckNodeGPUSharingPredicateAndScoreBinpack(t *testing.T) {
+	buildDevices := func() *GPUDevices {
+		return &GPUDevices{
+			Name: "node1",
+			Device: map[int]*GPUDevice{
+				0: {ID: 0, UUID: "GPU-0", Type: NvidiaGPUDevice, Number: 10, Memory: 16000, UsedNum: 1, UsedMem: 12000, UsedCore: 10},
+				1: {ID: 1, UUID: "GPU-1", Type: NvidiaGPUDevice, Number: 10, Memory: 16000, UsedNum: 1, UsedMem: 4000, UsedCore: 10},
+				2: {ID: 2, UUID: "GPU-2", Type: NvidiaGPUDevice, Number: 10, Memory: 16000},
+			},
+			Sharing: sharingRegistry[vGPUControllerHAMICore],
+		}
+	}
+	buildPod := func(memory string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod", Annotations: map[string]string{}},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{
+							config.VolcanoVGPUNumber: resource.MustParse("1"),
+							config.VolcanoVGPUMemory: resource.MustParse(memory),
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		policy       string
+		memory       string
+		expectedUUID string
+	}{
+		{
+			name:         "binpack picks the fitting GPU with least free memory",
+			policy:       binpackPolicy,
+			memory:       "4000",
+			expectedUUID: "GPU-0",
+		},
+		{
+			name:         "binpack skips the GPU without enough free memory",
+			policy:       binpackPolicy,
+			memory:       "8000",
+			expectedUUID: "GPU-1",
+		},
+		{
+			name:         "other policies try GPUs from the highest index",
+			policy:       spreadPolicy,
+			memory:       "4000",
+			expectedUUID: "GPU-2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fit, devs, _, err := checkNodeGPUSharingPredicateAndScore(buildPod(tc.memory), buildDevices(), true, tc.policy)
+			if err != nil || !fit {
+				t.Fatalf("expected pod to fit, got fit %v, err %v", fit, err)
+			}
+			if len(devs) != 1 || len(devs[0]) != 1 || devs[0][0].UUID != tc.expectedUUID {
+				t.Errorf("expected device %s, got %v", tc.expectedUUID, devs)
+			}
+		})
+	}
+}
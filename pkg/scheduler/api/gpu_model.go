@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// GPUModelLabel is the label key of the nodes holding the model of their gpus, e.g. a100. The gpus of the nodes
+	// are also accounted as the virtual resource of the model, e.g. nvidia.com/gpu-a100, so that the quota of queues
+	// can be granted per gpu model. The pods select the model by the node selector of the same key.
+	GPUModelLabel = "volcano.sh/gpu-model"
+	// GPUModelResourcePrefix is the prefix of the virtual resources of the gpu models.
+	GPUModelResourcePrefix = GPUResourceName + "-"
+)
+
+// GPUModelResourceName returns the virtual resource name of the gpu model.
+func GPUModelResourceName(model string) v1.ResourceName {
+	return v1.ResourceName(GPUModelResourcePrefix + model)
+}
+
+// GetGPUModelOfResource returns the gpu model of the virtual resource, false if it is not a gpu model resource.
+func GetGPUModelOfResource(name v1.ResourceName) (string, bool) {
+	if !strings.HasPrefix(string(name), GPUModelResourcePrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(string(name), GPUModelResourcePrefix), true
+}
+
+// addGPUModelRequest accounts the gpus requested by the pod selecting a gpu model as the virtual resource of the
+// model too, the gpus requested by the pods not selecting a model are only accounted as nvidia.com/gpu.
+func addGPUModelRequest(pod *v1.Pod, req *Resource) {
+	model := pod.Spec.NodeSelector[GPUModelLabel]
+	if model == "" || req.ScalarResources[GPUResourceName] <= 0 {
+		return
+	}
+	req.AddScalar(GPUModelResourceName(model), req.ScalarResources[GPUResourceName])
+}
+
+// gpuModelResource returns the gpus of the node as the virtual resource of its gpu model, empty if the node has
+// no gpu model label.
+func gpuModelResource(node *v1.Node, list v1.ResourceList) *Resource {
+	res := EmptyResource()
+	if node == nil || node.Labels[GPUModelLabel] == "" {
+		return res
+	}
+	if quantity, found := list[GPUResourceName]; found && !quantity.IsZero() {
+		res.AddScalar(GPUModelResourceName(node.Labels[GPUModelLabel]), float64(quantity.MilliValue()))
+	}
+	return res
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGPUModelAccounting(t *testing.T) {
+	a100 := GPUModelResourceName("a100")
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "n1", Labels: map[string]string{GPUModelLabel: "a100"}},
+		Status: v1.NodeStatus{
+			Capacity:    v1.ResourceList{GPUResourceName: resource.MustParse("8")},
+			Allocatable: v1.ResourceList{GPUResourceName: resource.MustParse("8")},
+		},
+	}
+	nodeInfo := NewNodeInfo(node)
+	if got := nodeInfo.Allocatable.ScalarResources[a100]; got != 8000 {
+		t.Errorf("expected 8 gpus of model a100 allocatable, got %v", got)
+	}
+
+	buildPod := func(name string, nodeSelector map[string]string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name},
+			Spec: v1.PodSpec{
+				NodeSelector: nodeSelector,
+				Containers: []v1.Container{{Name: "main", Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{GPUResourceName: resource.MustParse("2")},
+				}}},
+			},
+		}
+	}
+	modelTask := NewTaskInfo(buildPod("p1", map[string]string{GPUModelLabel: "a100"}))
+	if got := modelTask.Resreq.ScalarResources[a100]; got != 2000 {
+		t.Errorf("expected 2 gpus of model a100 requested, got %v", got)
+	}
+	anyTask := NewTaskInfo(buildPod("p2", nil))
+	if _, found := anyTask.Resreq.ScalarResources[a100]; found {
+		t.Errorf("expected no gpu model requested by the pod not selecting a model, got %v", anyTask.Resreq)
+	}
+
+	if model, isModel := GetGPUModelOfResource(a100); !isModel || model != "a100" {
+		t.Errorf("expected gpu model a100 of %s, got %q", a100, model)
+	}
+	if _, isModel := GetGPUModelOfResource(GPUResourceName); isModel {
+		t.Errorf("expected %s not a gpu model resource", GPUResourceName)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/features"
+)
+
+const (
+	// GPUShareResourceName is the virtual resource of the shares of the gpus, one gpu is one share. The fractional
+	// gpus requested by the pods and the whole gpus requested as nvidia.com/gpu are both accounted as the shares,
+	// so that they are allocated, preempted, reclaimed and limited by the quota of queues like the other resources.
+	GPUShareResourceName v1.ResourceName = "volcano.sh/gpu-share"
+	// GPUCorePercentageAnnotation is the annotation of the pods holding the percentage of the cores of a gpu they
+	// request, e.g. 50.
+	GPUCorePercentageAnnotation = "volcano.sh/gpu-core-percentage"
+	// GPUMemoryPercentageAnnotation is the annotation of the pods holding the percentage of the memory of a gpu they
+	// request, e.g. 25.
+	GPUMemoryPercentageAnnotation = "volcano.sh/gpu-memory-percentage"
+)
+
+// gpuShareEnabled returns whether the gpus are accounted as the virtual gpu share resource.
+func gpuShareEnabled() bool {
+	return utilfeature.DefaultFeatureGate.Enabled(features.GPUShareNormalization)
+}
+
+// GetPodGPUFraction returns the fraction of a gpu requested by the annotations of the pod, which is the larger of
+// the percentages of the cores and the memory, as the pod holds the gpu until both are released. Zero is returned
+// if the pod requests no fractional gpu.
+func GetPodGPUFraction(pod *v1.Pod) float64 {
+	var fraction float64
+	for _, key := range []string{GPUCorePercentageAnnotation, GPUMemoryPercentageAnnotation} {
+		value, found := pod.Annotations[key]
+		if !found {
+			continue
+		}
+		percentage, err := strconv.ParseFloat(value, 64)
+		if err != nil || percentage <= 0 || percentage > 100 {
+			klog.Warningf("Ignore invalid annotation %s %q of pod <%s/%s>, it must be a percentage in (0, 100]",
+				key, value, pod.Namespace, pod.Name)
+			continue
+		}
+		if percentage/100 > fraction {
+			fraction = percentage / 100
+		}
+	}
+	return fraction
+}
+
+// addGPUShareRequest accounts the whole gpus and the fractional gpu requested by the pod as the gpu shares.
+func addGPUShareRequest(pod *v1.Pod, req *Resource) {
+	if !gpuShareEnabled() {
+		return
+	}
+	shares := req.ScalarResources[GPUResourceName] + GetPodGPUFraction(pod)*1000
+	if shares > 0 {
+		req.AddScalar(GPUShareResourceName, shares)
+	}
+}
+
+// gpuShareResource returns the gpus of the node as the gpu shares, empty if the node has no gpus.
+func gpuShareResource(list v1.ResourceList) *Resource {
+	res := EmptyResource()
+	if !gpuShareEnabled() {
+		return res
+	}
+	if quantity, found := list[GPUResourceName]; found && !quantity.IsZero() {
+		res.AddScalar(GPUShareResourceName, float64(quantity.MilliValue()))
+	}
+	return res
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	"volcano.sh/volcano/pkg/features"
+)
+
+func TestGPUShareAccounting(t *testing.T) {
+	featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.GPUShareNormalization, true)
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "n1"},
+		Status: v1.NodeStatus{
+			Capacity:    v1.ResourceList{GPUResourceName: resource.MustParse("2")},
+			Allocatable: v1.ResourceList{GPUResourceName: resource.MustParse("2")},
+		},
+	}
+	nodeInfo := NewNodeInfo(node)
+	if got := nodeInfo.Allocatable.ScalarResources[GPUShareResourceName]; got != 2000 {
+		t.Errorf("expected 2 gpu shares allocatable, got %v", got)
+	}
+
+	buildPod := func(name string, annotations map[string]string, gpus string) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name, Annotations: annotations},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "main"}}},
+		}
+		if gpus != "" {
+			pod.Spec.Containers[0].Resources.Requests = v1.ResourceList{GPUResourceName: resource.MustParse(gpus)}
+		}
+		return pod
+	}
+
+	testCases := []struct {
+		name     string
+		pod      *v1.Pod
+		expected float64
+	}{
+		{
+			name:     "whole gpus",
+			pod:      buildPod("p1", nil, "1"),
+			expected: 1000,
+		},
+		{
+			name: "larger of core and memory percentages",
+			pod: buildPod("p2", map[string]string{
+				GPUCorePercentageAnnotation:   "30",
+				GPUMemoryPercentageAnnotation: "50",
+			}, ""),
+			expected: 500,
+		},
+		{
+			name:     "invalid percentage ignored",
+			pod:      buildPod("p3", map[string]string{GPUCorePercentageAnnotation: "150"}, ""),
+			expected: 0,
+		},
+		{
+			name:     "no gpu",
+			pod:      buildPod("p4", nil, ""),
+			expected: 0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			task := NewTaskInfo(tc.pod)
+			if got := task.Resreq.ScalarResources[GPUShareResourceName]; got != tc.expected {
+				t.Errorf("expected %v gpu shares requested, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGPUShareDisabled(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns1",
+			Name:        "p1",
+			Annotations: map[string]string{GPUCorePercentageAnnotation: "50"},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "main"}}},
+	}
+	if _, found := NewTaskInfo(pod).Resreq.ScalarResources[GPUShareResourceName]; found {
+		t.Errorf("expected no gpu shares accounted if %s is disabled", features.GPUShareNormalization)
+	}
+}
@@ -22,6 +22,8 @@ package api
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	clientcache "k8s.io/client-go/tools/cache"
@@ -136,3 +138,53 @@ func MergeErrors(errs ...error) error {
 func JobTerminated(job *JobInfo) bool {
 	return job.PodGroup == nil && len(job.Tasks) == 0
 }
+
+// GetTaskArchitectures returns the cpu architectures set by the TaskArchitectures annotation,
+// in order of preference, nil if the annotation is not set.
+func GetTaskArchitectures(annotations map[string]string) []string {
+	value, found := annotations[TaskArchitectures]
+	if !found {
+		return nil
+	}
+
+	var archs []string
+	for _, arch := range strings.Split(value, ",") {
+		if arch = strings.TrimSpace(arch); arch != "" {
+			archs = append(archs, arch)
+		}
+	}
+	return archs
+}
+
+// GetSchedulingGates returns the gates set by the PodGroupSchedulingGates annotation, nil if there is no gate.
+func GetSchedulingGates(annotations map[string]string) []string {
+	var gates []string
+	for _, gate := range strings.Split(annotations[PodGroupSchedulingGates], ",") {
+		if gate = strings.TrimSpace(gate); gate != "" {
+			gates = append(gates, gate)
+		}
+	}
+	return gates
+}
+
+// IsSuspended returns whether the JobSuspend annotation is "true".
+func IsSuspended(annotations map[string]string) bool {
+	suspended, err := strconv.ParseBool(annotations[JobSuspend])
+	return err == nil && suspended
+}
+
+// GetJobActiveDeadlineSeconds returns the JobActiveDeadlineSeconds annotation, nil if it is not set.
+func GetJobActiveDeadlineSeconds(annotations map[string]string) (*int64, error) {
+	value, found := annotations[JobActiveDeadlineSeconds]
+	if !found {
+		return nil, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s is invalid number: %v", JobActiveDeadlineSeconds, err)
+	}
+	if seconds <= 0 {
+		return nil, fmt.Errorf("%s must be larger than 0", JobActiveDeadlineSeconds)
+	}
+	return &seconds, nil
+}
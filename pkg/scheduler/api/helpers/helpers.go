@@ -76,6 +76,47 @@ func Max(l, r *api.Resource) *api.Resource {
 	return res
 }
 
+// BorrowingCapability returns the max resources a queue can allocate with the borrowing limit, which is its
+// deserved resources plus the limit. The cpu and memory of the limit set to infinity are kept infinity, and the
+// scalar resources not in the limit are left unset, which are treated as infinity.
+func BorrowingCapability(deserved, limit *api.Resource) *api.Resource {
+	res := &api.Resource{MilliCPU: math.MaxFloat64, Memory: math.MaxFloat64}
+	if limit.MilliCPU != math.MaxFloat64 {
+		res.MilliCPU = deserved.MilliCPU + limit.MilliCPU
+	}
+	if limit.Memory != math.MaxFloat64 {
+		res.Memory = deserved.Memory + limit.Memory
+	}
+	for name, quant := range limit.ScalarResources {
+		if res.ScalarResources == nil {
+			res.ScalarResources = map[v1.ResourceName]float64{}
+		}
+		res.ScalarResources[name] = deserved.Get(name) + quant
+	}
+	return res
+}
+
+// LendingReserved returns the resources reserved for a queue with the lending limit, which are its deserved
+// resources beyond the limit, and at least its guarantee. The resources not limited are reserved up to the guarantee.
+func LendingReserved(deserved, guarantee, limit *api.Resource) *api.Resource {
+	res := guarantee.Clone()
+	if limit.MilliCPU != math.MaxFloat64 {
+		res.MilliCPU = math.Max(res.MilliCPU, deserved.MilliCPU-limit.MilliCPU)
+	}
+	if limit.Memory != math.MaxFloat64 {
+		res.Memory = math.Max(res.Memory, deserved.Memory-limit.Memory)
+	}
+	for name, quant := range limit.ScalarResources {
+		if reserved := deserved.Get(name) - quant; reserved > res.Get(name) {
+			if res.ScalarResources == nil {
+				res.ScalarResources = map[v1.ResourceName]float64{}
+			}
+			res.ScalarResources[name] = reserved
+		}
+	}
+	return res
+}
+
 // Share is used to determine the share
 func Share(l, r float64) float64 {
 	var share float64
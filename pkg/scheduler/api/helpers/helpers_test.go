@@ -17,6 +17,7 @@ limitations under the License.
 package helpers
 
 import (
+	"math"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
@@ -56,3 +57,25 @@ func TestMax(t *testing.T) {
 		t.Errorf("expected: %#v, got: %#v", expected, re)
 	}
 }
+
+func TestBorrowingCapability(t *testing.T) {
+	deserved := &api.Resource{MilliCPU: 2000, Memory: 1024, ScalarResources: map[v1.ResourceName]float64{"nvidia.com/gpu": 2000}}
+	limit := &api.Resource{MilliCPU: 1000, Memory: math.MaxFloat64, ScalarResources: map[v1.ResourceName]float64{"nvidia.com/gpu": 1000}}
+	expected := &api.Resource{MilliCPU: 3000, Memory: math.MaxFloat64, ScalarResources: map[v1.ResourceName]float64{"nvidia.com/gpu": 3000}}
+
+	if re := BorrowingCapability(deserved, limit); !equality.Semantic.DeepEqual(expected, re) {
+		t.Errorf("expected: %#v, got: %#v", expected, re)
+	}
+}
+
+func TestLendingReserved(t *testing.T) {
+	deserved := &api.Resource{MilliCPU: 4000, Memory: 4096, ScalarResources: map[v1.ResourceName]float64{"nvidia.com/gpu": 4000}}
+	guarantee := &api.Resource{MilliCPU: 1000, Memory: 1024}
+	limit := &api.Resource{MilliCPU: 1000, Memory: math.MaxFloat64, ScalarResources: map[v1.ResourceName]float64{"nvidia.com/gpu": 8000}}
+	// the cpu beyond the limit is reserved, the memory is not limited and the gpu limit is more than deserved
+	expected := &api.Resource{MilliCPU: 3000, Memory: 1024}
+
+	if re := LendingReserved(deserved, guarantee, limit); !equality.Semantic.DeepEqual(expected, re) {
+		t.Errorf("expected: %#v, got: %#v", expected, re)
+	}
+}
@@ -147,6 +147,9 @@ type TaskInfo struct {
 	CustomBindErrHandler func() error `json:"-"`
 	// CustomBindErrHandlerSucceeded indicates whether CustomBindErrHandler is executed successfully.
 	CustomBindErrHandlerSucceeded bool
+
+	// EvictionGracePeriodSeconds overrides the terminationGracePeriodSeconds of the pod when the task is evicted.
+	EvictionGracePeriodSeconds *int64
 }
 
 func getJobID(pod *v1.Pod) JobID {
@@ -179,6 +182,8 @@ const TaskPriorityAnnotation = "volcano.sh/task-priority"
 // NewTaskInfo creates new taskInfo object for a Pod
 func NewTaskInfo(pod *v1.Pod) *TaskInfo {
 	initResReq := GetPodResourceRequest(pod)
+	addGPUModelRequest(pod, initResReq)
+	addGPUShareRequest(pod, initResReq)
 	resReq := initResReq
 	bestEffort := initResReq.IsEmpty()
 	preemptable := GetPodPreemptable(pod)
@@ -293,6 +298,7 @@ func (ti *TaskInfo) Clone() *TaskInfo {
 		RevocableZone:               ti.RevocableZone,
 		NumaInfo:                    ti.NumaInfo.Clone(),
 		SchGated:                    ti.SchGated,
+		EvictionGracePeriodSeconds:  ti.EvictionGracePeriodSeconds,
 		TransactionContext: TransactionContext{
 			NodeName: ti.NodeName,
 			Status:   ti.Status,
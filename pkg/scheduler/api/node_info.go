@@ -164,9 +164,9 @@ func NewNodeInfo(node *v1.Node) *NodeInfo {
 	if node != nil {
 		nodeInfo.Name = node.Name
 		nodeInfo.Node = node
-		nodeInfo.Idle = NewResource(node.Status.Allocatable).Add(nodeInfo.OversubscriptionResource)
-		nodeInfo.Allocatable = NewResource(node.Status.Allocatable).Add(nodeInfo.OversubscriptionResource)
-		nodeInfo.Capacity = NewResource(node.Status.Capacity).Add(nodeInfo.OversubscriptionResource)
+		nodeInfo.Idle = NewResource(node.Status.Allocatable).Add(nodeInfo.OversubscriptionResource).Add(gpuModelResource(node, node.Status.Allocatable)).Add(gpuShareResource(node.Status.Allocatable))
+		nodeInfo.Allocatable = NewResource(node.Status.Allocatable).Add(nodeInfo.OversubscriptionResource).Add(gpuModelResource(node, node.Status.Allocatable)).Add(gpuShareResource(node.Status.Allocatable))
+		nodeInfo.Capacity = NewResource(node.Status.Capacity).Add(nodeInfo.OversubscriptionResource).Add(gpuModelResource(node, node.Status.Capacity)).Add(gpuShareResource(node.Status.Capacity))
 	}
 	nodeInfo.setNodeOthersResource(node)
 	nodeInfo.setNodeState(node)
@@ -364,11 +364,11 @@ func (ni *NodeInfo) setNode(node *v1.Node) {
 	ni.setRevocableZone(node)
 	ni.setNodeOthersResource(node)
 
-	ni.Allocatable = NewResource(node.Status.Allocatable).Add(ni.OversubscriptionResource)
-	ni.Capacity = NewResource(node.Status.Capacity).Add(ni.OversubscriptionResource)
+	ni.Allocatable = NewResource(node.Status.Allocatable).Add(ni.OversubscriptionResource).Add(gpuModelResource(node, node.Status.Allocatable)).Add(gpuShareResource(node.Status.Allocatable))
+	ni.Capacity = NewResource(node.Status.Capacity).Add(ni.OversubscriptionResource).Add(gpuModelResource(node, node.Status.Capacity)).Add(gpuShareResource(node.Status.Capacity))
 	ni.Releasing = EmptyResource()
 	ni.Pipelined = EmptyResource()
-	ni.Idle = NewResource(node.Status.Allocatable).Add(ni.OversubscriptionResource)
+	ni.Idle = NewResource(node.Status.Allocatable).Add(ni.OversubscriptionResource).Add(gpuModelResource(node, node.Status.Allocatable)).Add(gpuShareResource(node.Status.Allocatable))
 	ni.Used = EmptyResource()
 
 	for _, ti := range ni.Tasks {
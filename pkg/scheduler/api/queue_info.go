@@ -23,7 +23,14 @@ limitations under the License.
 package api
 
 import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
 
 	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
@@ -32,6 +39,10 @@ import (
 // QueueID is UID type, serves as unique ID for each queue
 type QueueID types.UID
 
+// QueueStateDraining is the state of the queue which rejects new jobs while the jobs admitted before continue,
+// keep it in line with the state of the queue controller.
+const QueueStateDraining scheduling.QueueState = "Draining"
+
 // QueueInfo will have all details about queue
 type QueueInfo struct {
 	UID  QueueID
@@ -52,13 +63,22 @@ type QueueInfo struct {
 
 // NewQueueInfo creates new queueInfo object
 func NewQueueInfo(queue *scheduling.Queue) *QueueInfo {
+	hierarchy := queue.Annotations[v1beta1.KubeHierarchyAnnotationKey]
+	weights := queue.Annotations[v1beta1.KubeHierarchyWeightAnnotationKey]
+	// the queue admitted without the validation of the webhook may miss the weights of the nodes in the hierarchy,
+	// it is put under the root instead of crashing the hdrf plugin.
+	if strings.Count(hierarchy, "/") != strings.Count(weights, "/") {
+		klog.Warningf("Ignore hierarchy %q of queue <%s> which does not match the weights %q", hierarchy, queue.Name, weights)
+		hierarchy, weights = "", ""
+	}
+
 	return &QueueInfo{
 		UID:  QueueID(queue.Name),
 		Name: queue.Name,
 
 		Weight:    queue.Spec.Weight,
-		Hierarchy: queue.Annotations[v1beta1.KubeHierarchyAnnotationKey],
-		Weights:   queue.Annotations[v1beta1.KubeHierarchyWeightAnnotationKey],
+		Hierarchy: hierarchy,
+		Weights:   weights,
 
 		Queue: queue,
 	}
@@ -76,6 +96,171 @@ func (q *QueueInfo) Clone() *QueueInfo {
 	}
 }
 
+// NodePoolCapability returns the total allocatable resources of the node pool the queue is bound to,
+// nil if it is not published for the queue.
+func (q *QueueInfo) NodePoolCapability() *Resource {
+	if q.Queue == nil {
+		return nil
+	}
+	value, found := q.Queue.Annotations[QueueNodePoolCapability]
+	if !found {
+		return nil
+	}
+
+	var capability v1.ResourceList
+	if err := json.Unmarshal([]byte(value), &capability); err != nil {
+		klog.Warningf("Invalid node pool capability of queue <%s>: %v", q.Name, err)
+		return nil
+	}
+	return NewResource(capability)
+}
+
+// PendingResources returns the total requests of the pending tasks in the queue recorded by the scheduler,
+// nil if it is not recorded.
+func (q *QueueInfo) PendingResources() v1.ResourceList {
+	return q.recordedResources(QueuePendingResources)
+}
+
+// PipelinedResources returns the total requests of the pipelined tasks in the queue recorded by the scheduler,
+// nil if it is not recorded.
+func (q *QueueInfo) PipelinedResources() v1.ResourceList {
+	return q.recordedResources(QueuePipelinedResources)
+}
+
+func (q *QueueInfo) recordedResources(key string) v1.ResourceList {
+	if q.Queue == nil {
+		return nil
+	}
+	value, found := q.Queue.Annotations[key]
+	if !found {
+		return nil
+	}
+
+	var resources v1.ResourceList
+	if err := json.Unmarshal([]byte(value), &resources); err != nil {
+		klog.Warningf("Invalid %s of queue <%s>: %v", key, q.Name, err)
+		return nil
+	}
+	return resources
+}
+
+// BorrowingLimit returns the max resources the queue can allocate beyond its deserved resources,
+// nil if it is not limited.
+func (q *QueueInfo) BorrowingLimit() *Resource {
+	return q.resourceLimit(QueueBorrowingLimit)
+}
+
+// LendingLimit returns the max resources of the idle deserved resources of the queue other queues
+// can borrow, nil if it is not limited.
+func (q *QueueInfo) LendingLimit() *Resource {
+	return q.resourceLimit(QueueLendingLimit)
+}
+
+// resourceLimit returns the limit in the annotation of the queue, the cpu and memory not in the limit
+// are set to infinity like the capability, and the scalar resources not in the limit are left unset.
+func (q *QueueInfo) resourceLimit(key string) *Resource {
+	if q.Queue == nil {
+		return nil
+	}
+	value, found := q.Queue.Annotations[key]
+	if !found {
+		return nil
+	}
+
+	var limit v1.ResourceList
+	if err := json.Unmarshal([]byte(value), &limit); err != nil {
+		klog.Warningf("Invalid %s of queue <%s>: %v", key, q.Name, err)
+		return nil
+	}
+	r := NewResource(limit)
+	if _, found := limit[v1.ResourceCPU]; !found {
+		r.MilliCPU = math.MaxFloat64
+	}
+	if _, found := limit[v1.ResourceMemory]; !found {
+		r.Memory = math.MaxFloat64
+	}
+	return r
+}
+
+// EvictionGracePeriodSeconds returns the grace period overriding the one of the pods of the queue when they
+// are reclaimed, nil if not overridden.
+func (q *QueueInfo) EvictionGracePeriodSeconds() *int64 {
+	if q.Queue == nil {
+		return nil
+	}
+	value, found := q.Queue.Annotations[QueueEvictionGracePeriod]
+	if !found {
+		return nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		klog.Warningf("Invalid eviction grace period <%s> of queue <%s>", value, q.Name)
+		return nil
+	}
+	return &seconds
+}
+
+// Allocatable returns whether the tasks of the queue can be allocated, the queue is open, or draining so that the
+// pending, restarted and elastic tasks of the jobs admitted before continue.
+func (q *QueueInfo) Allocatable() bool {
+	state := q.Queue.Status.State
+	return state == scheduling.QueueStateOpen || state == QueueStateDraining
+}
+
+// HardCapability returns whether the capability of the queue is enforced at enqueue.
+func (q *QueueInfo) HardCapability() bool {
+	if q.Queue == nil {
+		return false
+	}
+	value, found := q.Queue.Annotations[QueueHardCapability]
+	if !found {
+		return false
+	}
+
+	hard, err := strconv.ParseBool(value)
+	if err != nil {
+		klog.Warningf("Invalid hard capability <%s> of queue <%s>", value, q.Name)
+		return false
+	}
+	return hard
+}
+
+// AllowOversubscription returns whether the jobs of the queue are allowed to request oversubscription resources.
+func (q *QueueInfo) AllowOversubscription() bool {
+	if q.Queue == nil {
+		return false
+	}
+	value, found := q.Queue.Annotations[QueueOversubscription]
+	if !found {
+		return false
+	}
+
+	allowed, err := strconv.ParseBool(value)
+	if err != nil {
+		klog.Warningf("Invalid oversubscription <%s> of queue <%s>", value, q.Name)
+		return false
+	}
+	return allowed
+}
+
+// ActionDisabled returns whether the action is disabled for the jobs of the queue by the disabled actions annotation.
+func (q *QueueInfo) ActionDisabled(action string) bool {
+	if q == nil || q.Queue == nil {
+		return false
+	}
+	value, found := q.Queue.Annotations[QueueDisabledActions]
+	if !found {
+		return false
+	}
+	for _, name := range strings.Split(value, ",") {
+		if strings.TrimSpace(name) == action {
+			return true
+		}
+	}
+	return false
+}
+
 // Reclaimable return whether queue is reclaimable
 func (q *QueueInfo) Reclaimable() bool {
 	if q == nil {
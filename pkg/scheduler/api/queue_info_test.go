@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"math"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+)
+
+func TestQueueEvictionGracePeriodSeconds(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    *int64
+	}{
+		{name: "not overridden"},
+		{name: "overridden", annotations: map[string]string{QueueEvictionGracePeriod: "30"}, expected: ptrInt64(30)},
+		{name: "zero", annotations: map[string]string{QueueEvictionGracePeriod: "0"}, expected: ptrInt64(0)},
+		{name: "invalid", annotations: map[string]string{QueueEvictionGracePeriod: "30s"}},
+		{name: "negative", annotations: map[string]string{QueueEvictionGracePeriod: "-1"}},
+	}
+
+	for _, tc := range testCases {
+		queue := &QueueInfo{Name: "q1", Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q1", Annotations: tc.annotations}}}
+		got := queue.EvictionGracePeriodSeconds()
+		if (got == nil) != (tc.expected == nil) || (got != nil && *got != *tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestQueueAllocatable(t *testing.T) {
+	testCases := map[scheduling.QueueState]bool{
+		scheduling.QueueStateOpen:    true,
+		QueueStateDraining:           true,
+		scheduling.QueueStateClosing: false,
+		scheduling.QueueStateClosed:  false,
+	}
+	for state, expected := range testCases {
+		queue := &QueueInfo{Name: "q1", Queue: &scheduling.Queue{Status: scheduling.QueueStatus{State: state}}}
+		if got := queue.Allocatable(); got != expected {
+			t.Errorf("%s: expected %v, got %v", state, expected, got)
+		}
+	}
+}
+
+func TestQueueHardCapability(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{name: "not set"},
+		{name: "enabled", annotations: map[string]string{QueueHardCapability: "true"}, expected: true},
+		{name: "disabled", annotations: map[string]string{QueueHardCapability: "false"}},
+		{name: "invalid", annotations: map[string]string{QueueHardCapability: "yes"}},
+	}
+
+	for _, tc := range testCases {
+		queue := &QueueInfo{Name: "q1", Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q1", Annotations: tc.annotations}}}
+		if got := queue.HardCapability(); got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestQueueAllowOversubscription(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{name: "not set"},
+		{name: "allowed", annotations: map[string]string{QueueOversubscription: "true"}, expected: true},
+		{name: "not allowed", annotations: map[string]string{QueueOversubscription: "false"}},
+		{name: "invalid", annotations: map[string]string{QueueOversubscription: "yes"}},
+	}
+
+	for _, tc := range testCases {
+		queue := &QueueInfo{Name: "q1", Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q1", Annotations: tc.annotations}}}
+		if got := queue.AllowOversubscription(); got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestQueueActionDisabled(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{name: "not set"},
+		{name: "disabled", annotations: map[string]string{QueueDisabledActions: "reclaim, preempt"}, expected: true},
+		{name: "other actions disabled", annotations: map[string]string{QueueDisabledActions: "backfill,reclaim"}},
+	}
+
+	for _, tc := range testCases {
+		queue := &QueueInfo{Name: "q1", Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q1", Annotations: tc.annotations}}}
+		if got := queue.ActionDisabled("preempt"); got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestQueuePendingResources(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    v1.ResourceList
+	}{
+		{name: "not recorded"},
+		{
+			name:        "recorded",
+			annotations: map[string]string{QueuePendingResources: `{"cpu": "8", "memory": "16Gi"}`},
+			expected:    BuildResourceList("8", "16Gi"),
+		},
+		{name: "invalid", annotations: map[string]string{QueuePendingResources: "cpu=8"}},
+	}
+
+	for _, tc := range testCases {
+		queue := &QueueInfo{Name: "q1", Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q1", Annotations: tc.annotations}}}
+		got := queue.PendingResources()
+		if (got == nil) != (tc.expected == nil) || !NewResource(got).Equal(NewResource(tc.expected), Zero) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestQueuePipelinedResources(t *testing.T) {
+	queue := &QueueInfo{Name: "q1", Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q1",
+		Annotations: map[string]string{QueuePipelinedResources: `{"cpu": "4", "memory": "8Gi"}`}}}}
+	got := queue.PipelinedResources()
+	if expected := BuildResourceList("4", "8Gi"); !NewResource(got).Equal(NewResource(expected), Zero) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := queue.PendingResources(); got != nil {
+		t.Errorf("expected pending resources not recorded, got %v", got)
+	}
+}
+
+func TestQueueBorrowingLimit(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    *Resource
+	}{
+		{name: "not limited"},
+		{
+			name:        "limited gpu",
+			annotations: map[string]string{QueueBorrowingLimit: `{"nvidia.com/gpu": "4"}`},
+			expected: &Resource{MilliCPU: math.MaxFloat64, Memory: math.MaxFloat64,
+				ScalarResources: map[v1.ResourceName]float64{"nvidia.com/gpu": 4000}},
+		},
+		{
+			name:        "limited cpu",
+			annotations: map[string]string{QueueBorrowingLimit: `{"cpu": "2"}`},
+			expected:    &Resource{MilliCPU: 2000, Memory: math.MaxFloat64},
+		},
+		{name: "invalid", annotations: map[string]string{QueueBorrowingLimit: "cpu=2"}},
+	}
+
+	for _, tc := range testCases {
+		queue := &QueueInfo{Name: "q1", Queue: &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q1", Annotations: tc.annotations}}}
+		got := queue.BorrowingLimit()
+		if (got == nil) != (tc.expected == nil) || (got != nil && !got.Equal(tc.expected, Zero)) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func ptrInt64(value int64) *int64 {
+	return &value
+}
@@ -47,4 +47,107 @@ const (
 	// to which the job is allocated. This typically represents the lowest common ancestor
 	// HyperNode in the scheduling hierarchy.
 	JobAllocatedHyperNode = "volcano.sh/job-allocated-hypernode"
+
+	// TaskArchitectures is the annotation key of the cpu architectures the task can run on,
+	// in order of preference, e.g. "arm64,amd64".
+	TaskArchitectures = "volcano.sh/task-arch"
+
+	// QueueNodePoolCapability is the annotation key of the queue recording the total allocatable resources
+	// of the available nodes in the node groups the queue is bound to, which is maintained by the queue controller.
+	QueueNodePoolCapability = "volcano.sh/node-pool-capability"
+
+	// JobSuspend is the annotation key of the job suspending it when "true", the pods of the suspended job are
+	// deleted and not created, and the podgroup is skipped by the scheduler. The job resumes when it is "false" or removed.
+	JobSuspend = "volcano.sh/job-suspend"
+
+	// JobActiveDeadlineSeconds is the annotation key of the job limiting its runtime in seconds from the time it
+	// first started running, including the time of retries. The job is terminated by the job controller when exceeded.
+	JobActiveDeadlineSeconds = "volcano.sh/job-active-deadline-seconds"
+
+	// QueueEvictionGracePeriod is the annotation key of the queue overriding the terminationGracePeriodSeconds
+	// of its pods evicted by reclaim, in seconds.
+	QueueEvictionGracePeriod = "volcano.sh/eviction-grace-period-seconds"
+
+	// QueueHardCapability is the annotation key of the queue enforcing its capability at enqueue when "true", the
+	// podgroups whose minResources exceed the remaining capability of the queue are refused by the enqueue action.
+	QueueHardCapability = "volcano.sh/hard-capability"
+
+	// QueueBorrowingLimit is the annotation key of the queue holding the max resources in json it can allocate
+	// beyond its deserved resources, e.g. {"cpu": "8", "nvidia.com/gpu": "4"}. The resources not in the limit are
+	// not limited.
+	QueueBorrowingLimit = "volcano.sh/borrowing-limit"
+	// QueueLendingLimit is the annotation key of the queue holding the max resources in json of its idle deserved
+	// resources other queues can borrow, the rest of its deserved resources are reserved for it like its guarantee.
+	// The resources not in the limit are not limited.
+	QueueLendingLimit = "volcano.sh/lending-limit"
+
+	// QueueQuotaExceededReason is the reason of the unschedulable condition of the podgroups refused by the
+	// enqueue action for the hard capability of the queue.
+	QueueQuotaExceededReason = "QueueQuotaExceeded"
+	// PodGroupInqueueType is the type of the condition of the podgroups recording when they were moved into Inqueue,
+	// i.e. admitted by their queues, e.g. for the scheduling timeline of jobs printed by vcctl. It is named after the phase.
+	PodGroupInqueueType = "Inqueue"
+
+	// PodGroupSchedulingGates is the annotation key of the podgroup listing the gates, separated by comma, which must
+	// be removed by external controllers, e.g. budget or approval systems, before the podgroup is enqueued.
+	PodGroupSchedulingGates = "volcano.sh/scheduling-gates"
+
+	// QueueOversubscription is the annotation key of the queue allowing its jobs to request the oversubscription
+	// resources reported by the volcano agent when "true", e.g. kubernetes.io/batch-cpu, which is usually set on the
+	// queues of best-effort offline jobs.
+	QueueOversubscription = "volcano.sh/oversubscription"
+
+	// GangMinMember is the annotation key of the pending pods of an unschedulable gang recording the minMember
+	// of the gang, it is set with GangSize and GangTotalResources when GangAutoscalerHints is enabled.
+	GangMinMember = "volcano.sh/gang-min-member"
+	// GangSize is the annotation key of the pending pods of an unschedulable gang recording the number of pods of the gang.
+	GangSize = "volcano.sh/gang-size"
+	// GangTotalResources is the annotation key of the pending pods of an unschedulable gang recording the total requests
+	// of the pending pods of the gang, e.g. "cpu=32,memory=128Gi,nvidia.com/gpu=8,pods=4", so that autoscalers can scale up
+	// the nodes for the whole gang at once.
+	GangTotalResources = "volcano.sh/gang-total-resources"
+
+	// SchedulingGatedReason is the reason of the unschedulable condition of the podgroups with scheduling gates.
+	SchedulingGatedReason = "SchedulingGated"
+	// GangBackoffReason is the reason of the unschedulable condition of the podgroups backed off by the gang plugin,
+	// after their tasks were partially allocated and released again in several sessions.
+	GangBackoffReason = "GangBackoff"
+
+	// PodGroupSchedulerShard is the annotation key of the podgroup assigning it to the scheduler shard of the name, the
+	// podgroups without it belong to the DefaultSchedulerShard. It is copied from the annotations of the Volcano job.
+	PodGroupSchedulerShard = "volcano.sh/scheduler-shard"
+	// DefaultSchedulerShard is the scheduler shard of the podgroups without the PodGroupSchedulerShard annotation.
+	DefaultSchedulerShard = "default"
+	// QueueShardAllocatedPrefix is the prefix of the annotation keys of the queue recording the allocated resources
+	// of the queue in each scheduler shard in json, e.g. allocated.shard.volcano.sh/zone-a: {"cpu":"8","memory":"16Gi"}.
+	// The resources allocated by the other shards are deducted from the quota of the queue in a shard.
+	QueueShardAllocatedPrefix = "allocated.shard.volcano.sh/"
+	// QueuePendingResources is the annotation key of the queue recording the total requests of the pending tasks in
+	// the queue and its child queues in json, e.g. {"cpu":"8","memory":"16Gi"}. It is updated by the scheduler every
+	// session together with the allocated resources in the status of the queue.
+	QueuePendingResources = "volcano.sh/queue-pending-resources"
+	// QueuePipelinedResources is the annotation key of the queue recording the total requests of the tasks in the
+	// queue and its child queues pipelined to nodes, i.e. waiting for the resources of the victims to be released,
+	// in json. These resources look idle on the nodes but are already promised to the pipelined tasks.
+	QueuePipelinedResources = "volcano.sh/queue-pipelined-resources"
+
+	// QueueDisabledActions is the annotation key of the queue listing the actions, separated by comma, which do not
+	// schedule the jobs of the queue, e.g. "preempt,reclaim" for a production queue whose jobs must not evict others.
+	// The jobs of the queue are scheduled by the other actions in the scheduler configuration.
+	QueueDisabledActions = "volcano.sh/disabled-actions"
+
+	// QueueJobOrderPolicy is the annotation key of the queue selecting the order of its jobs, which takes precedence
+	// over the job order of the plugins: "fifo", "namespace-fair" or "user-fair", see the queue-job-order plugin.
+	QueueJobOrderPolicy = "volcano.sh/job-order-policy"
+	// QueueJobOrderUserLabel is the annotation key of the queue giving the label of the jobs identifying their users
+	// for the "user-fair" job order policy, e.g. "volcano.sh/user".
+	QueueJobOrderUserLabel = "volcano.sh/job-order-user-label"
+
+	// PodGroupPipelinedType is the type of the podgroup condition telling whether tasks of the podgroup are pipelined
+	// to nodes, waiting for the resources of the victims to be released.
+	PodGroupPipelinedType = "Pipelined"
+	// WaitingForVictimsReason is the reason of the pipelined condition of the podgroups with pipelined tasks.
+	WaitingForVictimsReason = "WaitingForVictims"
+	// NoPipelinedTasksReason is the reason of the pipelined condition of the podgroups without pipelined tasks.
+	NoPipelinedTasksReason = "NoPipelinedTasks"
 )
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the scheduling decisions of the scheduler, e.g. which node a pod is bound to, the scores
+// of the node by plugins and the victims evicted for it, for the post-hoc audit of the fairness of the allocation.
+package audit
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// DecisionType is the type of a scheduling decision.
+type DecisionType string
+
+const (
+	// DecisionBind is the decision binding the pod to the node.
+	DecisionBind DecisionType = "Bind"
+	// DecisionPipeline is the decision reserving the node for the pod, which is bound when the resources
+	// released by the victims are available.
+	DecisionPipeline DecisionType = "Pipeline"
+	// DecisionEvict is the decision evicting the pod from the node.
+	DecisionEvict DecisionType = "Evict"
+
+	// SinkKey is the key of the audit configuration selecting the sink of the decisions, the decisions are not
+	// recorded if it is not set.
+	SinkKey = "sink"
+)
+
+// Decision is a scheduling decision about a pod.
+type Decision struct {
+	Time time.Time `json:"time"`
+	// Session is the uid of the scheduling session making the decision.
+	Session string `json:"session"`
+	// Action is the action making the decision, e.g. allocate or preempt.
+	Action    string       `json:"action"`
+	Type      DecisionType `json:"type"`
+	Namespace string       `json:"namespace"`
+	Pod       string       `json:"pod"`
+	Job       string       `json:"job"`
+	Queue     string       `json:"queue"`
+	Node      string       `json:"node"`
+	// Scores are the weighted scores of the node for the pod by the node order plugins, keyed by the plugin name.
+	Scores map[string]float64 `json:"scores,omitempty"`
+	// Victims are the pods evicted on the node in the same statement, as namespace/name.
+	Victims []string `json:"victims,omitempty"`
+	// Reason is the reason of the eviction.
+	Reason string `json:"reason,omitempty"`
+
+	// Object is the pod the decision is about, on which the events of the decision are recorded.
+	Object runtime.Object `json:"-"`
+}
+
+// Sink records the scheduling decisions.
+type Sink interface {
+	// Record records the decision, it must not block the scheduling.
+	Record(decision *Decision)
+	// Close flushes the decisions and releases the resources of the sink.
+	Close()
+}
+
+// SinkBuilder builds a sink with the audit configuration, the recorder is used by the sinks recording events.
+type SinkBuilder func(conf map[string]string, recorder record.EventRecorder) (Sink, error)
+
+var (
+	sinkMutex    sync.RWMutex
+	sinkBuilders = map[string]SinkBuilder{}
+)
+
+// RegisterSink registers the builder of a sink by name.
+func RegisterSink(name string, builder SinkBuilder) {
+	sinkMutex.Lock()
+	defer sinkMutex.Unlock()
+
+	sinkBuilders[name] = builder
+}
+
+// NewSink builds the sink selected by the audit configuration, nil is returned if no sink is selected.
+func NewSink(conf map[string]string, recorder record.EventRecorder) (Sink, error) {
+	name := conf[SinkKey]
+	if name == "" {
+		return nil, nil
+	}
+
+	sinkMutex.RLock()
+	builder, found := sinkBuilders[name]
+	sinkMutex.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("audit sink %q is not registered, registered sinks: %v", name, registeredSinks())
+	}
+	return builder(conf, recorder)
+}
+
+func registeredSinks() []string {
+	sinkMutex.RLock()
+	defer sinkMutex.RUnlock()
+
+	names := make([]string, 0, len(sinkBuilders))
+	for name := range sinkBuilders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func buildDecision() *Decision {
+	return &Decision{
+		Time:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Session:   "s1",
+		Action:    "preempt",
+		Type:      DecisionPipeline,
+		Namespace: "ns1",
+		Pod:       "p1",
+		Job:       "ns1/j1",
+		Queue:     "q1",
+		Node:      "n1",
+		Scores:    map[string]float64{"binpack": 10, "nodeorder": 20.5},
+		Victims:   []string{"ns2/p2", "ns2/p3"},
+		Object:    &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "p1"}},
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	tests := []struct {
+		name      string
+		conf      map[string]string
+		recorder  record.EventRecorder
+		expectNil bool
+		expectErr bool
+	}{
+		{
+			name:      "disabled",
+			conf:      nil,
+			expectNil: true,
+		},
+		{
+			name: "stdout",
+			conf: map[string]string{SinkKey: StdoutSink},
+		},
+		{
+			name:      "unknown sink",
+			conf:      map[string]string{SinkKey: "kafka"},
+			expectErr: true,
+		},
+		{
+			name:      "http without url",
+			conf:      map[string]string{SinkKey: HTTPSink},
+			expectErr: true,
+		},
+		{
+			name:      "http with invalid timeout",
+			conf:      map[string]string{SinkKey: HTTPSink, URLKey: "http://audit", TimeoutKey: "soon"},
+			expectErr: true,
+		},
+		{
+			name:      "events without recorder",
+			conf:      map[string]string{SinkKey: EventsSink},
+			expectErr: true,
+		},
+		{
+			name:     "events",
+			conf:     map[string]string{SinkKey: EventsSink},
+			recorder: record.NewFakeRecorder(1),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sink, err := NewSink(test.conf, test.recorder)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("expected error %v, got %v", test.expectErr, err)
+			}
+			if !test.expectErr && (sink == nil) != test.expectNil {
+				t.Errorf("expected nil sink %v, got %v", test.expectNil, sink)
+			}
+			if sink != nil {
+				sink.Close()
+			}
+		})
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := newWriterSink(&buf)
+	sink.Record(buildDecision())
+
+	expected := `{"time":"2025-01-01T00:00:00Z","session":"s1","action":"preempt","type":"Pipeline","namespace":"ns1",` +
+		`"pod":"p1","job":"ns1/j1","queue":"q1","node":"n1","scores":{"binpack":10,"nodeorder":20.5},` +
+		`"victims":["ns2/p2","ns2/p3"]}` + "\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	var mutex sync.Mutex
+	var received []*Decision
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		decision := &Decision{}
+		if err := json.Unmarshal(data, decision); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mutex.Lock()
+		received = append(received, decision)
+		mutex.Unlock()
+	}))
+	defer server.Close()
+
+	sink, err := NewSink(map[string]string{SinkKey: HTTPSink, URLKey: server.URL, TimeoutKey: "1s"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink.Record(buildDecision())
+	sink.Close()
+	// the decisions recorded after the sink is closed are dropped
+	sink.Record(buildDecision())
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(received) != 1 {
+		t.Fatalf("expected 1 decision posted, got %d", len(received))
+	}
+	expected := buildDecision()
+	expected.Object = nil
+	if !reflect.DeepEqual(received[0], expected) {
+		t.Errorf("expected %+v, got %+v", expected, received[0])
+	}
+}
+
+func TestEventSink(t *testing.T) {
+	recorder := record.NewFakeRecorder(2)
+	sink := &eventSink{recorder: recorder}
+	sink.Record(buildDecision())
+
+	evict := buildDecision()
+	evict.Type = DecisionEvict
+	evict.Victims = nil
+	evict.Reason = "preempt"
+	sink.Record(evict)
+
+	expected := []string{
+		"Normal SchedulingDecision Pipeline to node n1 by action preempt, victims: ns2/p2,ns2/p3",
+		"Normal SchedulingDecision Evict from node n1 by action preempt: preempt",
+	}
+	for _, e := range expected {
+		got := <-recorder.Events
+		if !strings.HasPrefix(got, e) {
+			t.Errorf("expected event %q, got %q", e, got)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// StdoutSink writes the decisions to the stdout as json lines.
+	StdoutSink = "stdout"
+	// HTTPSink posts the decisions as json to the url of the audit configuration.
+	HTTPSink = "http"
+	// EventsSink records the decisions as the events of the pods, with the decision in json in the
+	// DecisionAnnotation of the event.
+	EventsSink = "events"
+
+	// URLKey is the key of the audit configuration holding the url the http sink posts the decisions to.
+	URLKey = "url"
+	// TimeoutKey is the key of the audit configuration holding the timeout of posting a decision, e.g. 5s.
+	TimeoutKey = "timeout"
+
+	// DecisionAnnotation is the annotation key of the events holding the decision in json.
+	DecisionAnnotation = "volcano.sh/scheduling-decision"
+	// DecisionReason is the reason of the events of the decisions.
+	DecisionReason = "SchedulingDecision"
+
+	defaultTimeout   = 5 * time.Second
+	defaultQueueSize = 4096
+)
+
+func init() {
+	RegisterSink(StdoutSink, func(conf map[string]string, recorder record.EventRecorder) (Sink, error) {
+		return newWriterSink(os.Stdout), nil
+	})
+	RegisterSink(HTTPSink, newHTTPSink)
+	RegisterSink(EventsSink, func(conf map[string]string, recorder record.EventRecorder) (Sink, error) {
+		if recorder == nil {
+			return nil, fmt.Errorf("no event recorder for audit sink %s", EventsSink)
+		}
+		return &eventSink{recorder: recorder}, nil
+	})
+}
+
+// writerSink writes the decisions to the writer as json lines.
+type writerSink struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+func newWriterSink(writer io.Writer) *writerSink {
+	return &writerSink{writer: writer}
+}
+
+func (s *writerSink) Record(decision *Decision) {
+	data, err := json.Marshal(decision)
+	if err != nil {
+		klog.Errorf("Failed to marshal scheduling decision of pod <%s/%s>: %v", decision.Namespace, decision.Pod, err)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, err := s.writer.Write(append(data, '\n')); err != nil {
+		klog.Errorf("Failed to write scheduling decision of pod <%s/%s>: %v", decision.Namespace, decision.Pod, err)
+	}
+}
+
+func (s *writerSink) Close() {}
+
+// httpSink posts the decisions in the background, so that the scheduling is not blocked by the receiver.
+// The decisions are posted in order, and dropped when the queue is full or the sink is closed.
+type httpSink struct {
+	url       string
+	client    *http.Client
+	decisions chan *Decision
+	done      chan struct{}
+
+	// mutex protects closed, the sessions may still record decisions when the sink is replaced and closed.
+	mutex  sync.RWMutex
+	closed bool
+}
+
+func newHTTPSink(conf map[string]string, _ record.EventRecorder) (Sink, error) {
+	url := strings.TrimSpace(conf[URLKey])
+	if url == "" {
+		return nil, fmt.Errorf("%s of audit sink %s is not set", URLKey, HTTPSink)
+	}
+	timeout := defaultTimeout
+	if value, found := conf[TimeoutKey]; found {
+		var err error
+		if timeout, err = time.ParseDuration(value); err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid %s %q of audit sink %s", TimeoutKey, value, HTTPSink)
+		}
+	}
+
+	s := &httpSink{
+		url:       url,
+		client:    &http.Client{Timeout: timeout},
+		decisions: make(chan *Decision, defaultQueueSize),
+		done:      make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+func (s *httpSink) Record(decision *Decision) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.decisions <- decision:
+	default:
+		klog.Warningf("Audit queue is full, drop the scheduling decision of pod <%s/%s>", decision.Namespace, decision.Pod)
+	}
+}
+
+// Close posts the queued decisions and stops the sink.
+func (s *httpSink) Close() {
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return
+	}
+	s.closed = true
+	close(s.decisions)
+	s.mutex.Unlock()
+	<-s.done
+}
+
+func (s *httpSink) run() {
+	defer close(s.done)
+	for decision := range s.decisions {
+		if err := s.post(decision); err != nil {
+			klog.Errorf("Failed to post scheduling decision of pod <%s/%s>: %v", decision.Namespace, decision.Pod, err)
+		}
+	}
+}
+
+func (s *httpSink) post(decision *Decision) error {
+	data, err := json.Marshal(decision)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// eventSink records the decisions as the events of the pods.
+type eventSink struct {
+	recorder record.EventRecorder
+}
+
+func (s *eventSink) Record(decision *Decision) {
+	if decision.Object == nil {
+		return
+	}
+	data, err := json.Marshal(decision)
+	if err != nil {
+		klog.Errorf("Failed to marshal scheduling decision of pod <%s/%s>: %v", decision.Namespace, decision.Pod, err)
+		return
+	}
+	s.recorder.AnnotatedEventf(decision.Object, map[string]string{DecisionAnnotation: string(data)},
+		v1.EventTypeNormal, DecisionReason, "%s", decisionMessage(decision))
+}
+
+func (s *eventSink) Close() {}
+
+// decisionMessage describes the decision in the message of its event.
+func decisionMessage(decision *Decision) string {
+	var message string
+	switch decision.Type {
+	case DecisionEvict:
+		message = fmt.Sprintf("Evict from node %s by action %s: %s", decision.Node, decision.Action, decision.Reason)
+	default:
+		message = fmt.Sprintf("%s to node %s by action %s", decision.Type, decision.Node, decision.Action)
+	}
+	if len(decision.Victims) > 0 {
+		message += fmt.Sprintf(", victims: %s", strings.Join(decision.Victims, ","))
+	}
+	return message
+}
@@ -68,10 +68,12 @@ import (
 	topologyinformerv1alpha1 "volcano.sh/apis/pkg/client/informers/externalversions/topology/v1alpha1"
 
 	"volcano.sh/volcano/cmd/scheduler/app/options"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/features"
 	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
 	"volcano.sh/volcano/pkg/scheduler/metrics/source"
+	"volcano.sh/volcano/pkg/scheduler/placement"
 	commonutil "volcano.sh/volcano/pkg/util"
 )
 
@@ -94,7 +96,7 @@ func init() {
 }
 
 // New returns a Cache implementation.
-func New(config *rest.Config, schedulerNames []string, defaultQueue string, nodeSelectors []string, nodeWorkers uint32, ignoredProvisioners []string, resyncPeriod time.Duration) Cache {
+func New(config *rest.Config, schedulerNames []string, defaultQueue string, nodeSelectors []string, nodeWorkers uint32, ignoredProvisioners []string, resyncPeriod time.Duration) (Cache, error) {
 	return newSchedulerCache(config, schedulerNames, defaultQueue, nodeSelectors, nodeWorkers, ignoredProvisioners, resyncPeriod)
 }
 
@@ -105,6 +107,7 @@ type SchedulerCache struct {
 	kubeClient   kubernetes.Interface
 	restConfig   *rest.Config
 	vcClient     vcclient.Interface
+	stopCh       <-chan struct{}
 	defaultQueue string
 	// schedulerName is the name for volcano scheduler
 	schedulerNames     []string
@@ -177,6 +180,11 @@ type SchedulerCache struct {
 
 	// sharedDRAManager is used in DRA plugin, contains resourceClaimTracker, resourceSliceLister and deviceClassLister
 	sharedDRAManager k8sframework.SharedDRAManager
+
+	// placementRecorder persists the placement records of tasks, it is nil if no placement store is configured.
+	placementRecorder *placement.Recorder
+	// shardName is the name of the scheduler shard the scheduler owns, all the podgroups are scheduled if it is empty.
+	shardName string
 }
 
 type multiSchedulerInfo struct {
@@ -243,7 +251,7 @@ type defaultEvictor struct {
 }
 
 // Evict will send delete pod request to api server
-func (de *defaultEvictor) Evict(p *v1.Pod, reason string) error {
+func (de *defaultEvictor) Evict(p *v1.Pod, reason string, gracePeriodSeconds *int64) error {
 	klog.V(3).Infof("Evicting pod %v/%v, because of %v", p.Namespace, p.Name, reason)
 
 	evictMsg := fmt.Sprintf("Pod is evicted, because of %v", reason)
@@ -280,7 +288,7 @@ func (de *defaultEvictor) Evict(p *v1.Pod, reason string) error {
 		klog.Errorf("Failed to update pod <%v/%v> status: %v", pod.Namespace, pod.Name, err)
 		return err
 	}
-	if err := de.kubeclient.CoreV1().Pods(p.Namespace).Delete(context.TODO(), p.Name, metav1.DeleteOptions{}); err != nil {
+	if err := de.kubeclient.CoreV1().Pods(p.Namespace).Delete(context.TODO(), p.Name, metav1.DeleteOptions{GracePeriodSeconds: gracePeriodSeconds}); err != nil {
 		klog.Errorf("Failed to evict pod <%v/%v>: %#v", p.Namespace, p.Name, err)
 		return err
 	}
@@ -336,7 +344,8 @@ func (su *defaultStatusUpdater) UpdatePodGroup(pg *schedulingapi.PodGroup) (*sch
 		return nil, err
 	}
 
-	updated, err := su.vcclient.SchedulingV1beta1().PodGroups(podgroup.Namespace).Update(context.TODO(), podgroup, metav1.UpdateOptions{})
+	updated, err := su.vcclient.SchedulingV1beta1().PodGroups(podgroup.Namespace).Apply(context.TODO(),
+		podGroupApplyConfiguration(podgroup), metav1.ApplyOptions{FieldManager: FieldManager, Force: true})
 	if err != nil {
 		klog.Errorf("Error while updating PodGroup with error: %v", err)
 		return nil, err
@@ -359,11 +368,21 @@ func (su *defaultStatusUpdater) UpdateQueueStatus(queue *schedulingapi.QueueInfo
 		return err
 	}
 
-	_, err := su.vcclient.SchedulingV1beta1().Queues().UpdateStatus(context.TODO(), newQueue, metav1.UpdateOptions{})
+	_, err := su.vcclient.SchedulingV1beta1().Queues().ApplyStatus(context.TODO(),
+		QueueStatusApplyConfiguration(newQueue), metav1.ApplyOptions{FieldManager: FieldManager, Force: true})
 	if err != nil {
 		klog.Errorf("error occurred in updating Queue <%s>: %s", newQueue.Name, err.Error())
 		return err
 	}
+
+	if queueApply := queueRecordedResourcesApplyConfiguration(newQueue); queueApply != nil {
+		_, err = su.vcclient.SchedulingV1beta1().Queues().Apply(context.TODO(), queueApply,
+			metav1.ApplyOptions{FieldManager: FieldManager, Force: true})
+		if err != nil {
+			klog.Errorf("error occurred in updating pending and pipelined resources of Queue <%s>: %s", newQueue.Name, err.Error())
+			return err
+		}
+	}
 	return nil
 }
 
@@ -492,7 +511,7 @@ func newDefaultAndRootQueue(vcClient vcclient.Interface, defaultQueue string) {
 	}
 }
 
-func newSchedulerCache(config *rest.Config, schedulerNames []string, defaultQueue string, nodeSelectors []string, nodeWorkers uint32, ignoredProvisioners []string, resyncPeriod time.Duration) *SchedulerCache {
+func newSchedulerCache(config *rest.Config, schedulerNames []string, defaultQueue string, nodeSelectors []string, nodeWorkers uint32, ignoredProvisioners []string, resyncPeriod time.Duration) (*SchedulerCache, error) {
 	kubeClient, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		panic(fmt.Sprintf("failed init kubeClient, with err: %v", err))
@@ -549,6 +568,16 @@ func newSchedulerCache(config *rest.Config, schedulerNames []string, defaultQueu
 	if len(nodeSelectors) > 0 {
 		sc.updateNodeSelectors(nodeSelectors)
 	}
+	if options.ServerOpts != nil && options.ServerOpts.PlacementStore != "" {
+		store, err := placement.NewStore(options.ServerOpts.PlacementStore, options.ServerOpts.PlacementStoreDataSource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init placement store %s: %v", options.ServerOpts.PlacementStore, err)
+		}
+		sc.placementRecorder = placement.NewRecorder(store)
+	}
+	if options.ServerOpts != nil {
+		sc.shardName = options.ServerOpts.SchedulerShardName
+	}
 	// Prepare event clients.
 	broadcaster := record.NewBroadcaster()
 	broadcaster.StartRecordingToSink(&corev1.EventSinkImpl{Interface: eventClient.CoreV1().Events("")})
@@ -582,7 +611,7 @@ func newSchedulerCache(config *rest.Config, schedulerNames []string, defaultQueu
 	// add all events handlers
 	sc.addEventHandler()
 	sc.HyperNodesInfo = schedulingapi.NewHyperNodesInfo(sc.nodeInformer.Lister())
-	return sc
+	return sc, nil
 }
 
 func (sc *SchedulerCache) addEventHandler() {
@@ -731,7 +760,7 @@ func (sc *SchedulerCache) addEventHandler() {
 					return false
 				}
 
-				return responsibleForPodGroup(pg, sc.schedulerPodName, sc.c)
+				return responsibleForPodGroup(pg, sc.schedulerPodName, sc.c) && responsibleForShard(pg, sc.shardName)
 			},
 			Handler: cache.ResourceEventHandlerFuncs{
 				AddFunc:    sc.AddPodGroupV1beta1,
@@ -790,6 +819,7 @@ func (sc *SchedulerCache) addEventHandler() {
 
 // Run  starts the schedulerCache
 func (sc *SchedulerCache) Run(stopCh <-chan struct{}) {
+	sc.stopCh = stopCh
 	sc.informerFactory.Start(stopCh)
 	sc.vcInformerFactory.Start(stopCh)
 	sc.WaitForCacheSync(stopCh)
@@ -808,6 +838,10 @@ func (sc *SchedulerCache) Run(stopCh <-chan struct{}) {
 
 	go wait.Until(sc.processBindTask, time.Millisecond*20, stopCh)
 
+	if sc.placementRecorder != nil {
+		go sc.placementRecorder.Run(stopCh)
+	}
+
 	// Get metrics data
 	klog.V(3).Infof("Start metrics collection, metricsConf is %v", sc.metricsConf)
 	interval, err := time.ParseDuration(sc.metricsConf["interval"])
@@ -878,9 +912,21 @@ func (sc *SchedulerCache) Evict(taskInfo *schedulingapi.TaskInfo, reason string)
 	}
 
 	p := task.Pod
+	// the grace period is set on the task of session by the action evicting it
+	gracePeriodSeconds := taskInfo.EvictionGracePeriodSeconds
+
+	var hook *jobhelpers.CheckpointHook
+	if job.PodGroup != nil {
+		if hook, err = jobhelpers.GetCheckpointHook(job.PodGroup.Annotations); err != nil {
+			klog.Warningf("Ignore the checkpoint hook of Job <%s/%s>: %v", job.Namespace, job.Name, err)
+		}
+	}
 
 	go func() {
-		err := sc.Evictor.Evict(p, reason)
+		if hook != nil {
+			sc.checkpoint(p, hook)
+		}
+		err := sc.Evictor.Evict(p, reason, gracePeriodSeconds)
 		if err != nil {
 			sc.resyncTask(task)
 		}
@@ -918,6 +964,7 @@ func (sc *SchedulerCache) Bind(ctx context.Context, bindContexts []*BindContext,
 	for _, bindContext := range bindContexts {
 		if reason, ok := errMsg[bindContext.TaskInfo.UID]; !ok {
 			sc.Recorder.Eventf(bindContext.TaskInfo.Pod, v1.EventTypeNormal, "Scheduled", "Successfully assigned %v/%v to %v", bindContext.TaskInfo.Namespace, bindContext.TaskInfo.Name, bindContext.TaskInfo.NodeName)
+			sc.recordPlacementStart(bindContext.TaskInfo)
 		} else {
 			unschedulableMsg := fmt.Sprintf("failed to bind to node %s: %s", bindContext.TaskInfo.NodeName, reason)
 			if err := sc.taskUnschedulable(bindContext.TaskInfo, schedulingapi.PodReasonSchedulerError, unschedulableMsg, ""); err != nil {
@@ -960,6 +1007,11 @@ func (sc *SchedulerCache) ClientConfig() *rest.Config {
 	return sc.restConfig
 }
 
+// StopCh returns the stop channel the cache runs with
+func (sc *SchedulerCache) StopCh() <-chan struct{} {
+	return sc.stopCh
+}
+
 // SharedInformerFactory returns the scheduler SharedInformerFactory
 func (sc *SchedulerCache) SharedInformerFactory() informers.SharedInformerFactory {
 	return sc.informerFactory
@@ -1399,7 +1451,7 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 	sc.HyperNodesInfo.Unlock()
 
 	for _, value := range sc.Queues {
-		snapshot.Queues[value.UID] = value.Clone()
+		snapshot.Queues[value.UID] = sc.cloneQueue(value)
 	}
 
 	var cloneJobLock sync.Mutex
@@ -1446,6 +1498,12 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 			continue
 		}
 
+		if schedulingapi.IsSuspended(value.PodGroup.Annotations) {
+			klog.V(4).Infof("The Job <%v:%s/%s> is suspended, ignore it.",
+				value.UID, value.Namespace, value.Name)
+			continue
+		}
+
 		wg.Add(1)
 		go cloneJob(value)
 	}
@@ -1522,6 +1580,9 @@ func (sc *SchedulerCache) RecordJobStatusEvent(job *schedulingapi.JobInfo, updat
 			fitErrStr)
 		// TODO: should we skip pod unschedulable event if pod group is unschedulable due to gates to avoid printing too many messages?
 		sc.recordPodGroupEvent(job.PodGroup, v1.EventTypeWarning, string(scheduling.PodGroupUnschedulableType), msg)
+		if utilfeature.DefaultFeatureGate.Enabled(features.GangAutoscalerHints) {
+			sc.recordGangHints(job)
+		}
 	} else if updatePG {
 		sc.recordPodGroupEvent(job.PodGroup, v1.EventTypeNormal, string(scheduling.PodGroupScheduled), string(scheduling.PodGroupReady))
 	}
@@ -1585,6 +1646,9 @@ func (sc *SchedulerCache) updateJobAnnotations(job *schedulingapi.JobInfo) {
 
 // UpdateQueueStatus update the status of queue.
 func (sc *SchedulerCache) UpdateQueueStatus(queue *schedulingapi.QueueInfo) error {
+	if sc.shardName != "" {
+		return sc.updateShardQueueStatus(queue)
+	}
 	return sc.StatusUpdater.UpdateQueueStatus(queue)
 }
 
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kubescheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+// checkpoint invokes the checkpoint hook on the pod before it is evicted, and records the completion of the
+// checkpoint on the job of the pod. The pod is evicted anyway if the hook fails or times out.
+func (sc *SchedulerCache) checkpoint(pod *v1.Pod, hook *jobhelpers.CheckpointHook) {
+	ctx, cancel := context.WithTimeout(context.Background(), hook.Timeout())
+	defer cancel()
+
+	var err error
+	if hook.Exec != nil {
+		err = sc.execCheckpointHook(ctx, pod, hook.Exec)
+	} else {
+		err = httpCheckpointHook(ctx, pod, hook.HTTPGet)
+	}
+	if err != nil {
+		klog.Warningf("Failed to checkpoint pod <%s/%s> before evicting it: %v", pod.Namespace, pod.Name, err)
+		sc.Recorder.Eventf(pod, v1.EventTypeWarning, "CheckpointFailed", "Failed to checkpoint before eviction: %v", err)
+		return
+	}
+	klog.V(3).Infof("Checkpointed pod <%s/%s> before evicting it", pod.Namespace, pod.Name)
+	sc.Recorder.Eventf(pod, v1.EventTypeNormal, "Checkpointed", "Checkpointed before eviction")
+
+	jobName := pod.Annotations[batch.JobNameKey]
+	if jobName == "" {
+		return
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`,
+		jobhelpers.CheckpointCompletedAnnotation, time.Now().UTC().Format(time.RFC3339))
+	if _, err := sc.vcClient.BatchV1alpha1().Jobs(pod.Namespace).Patch(context.TODO(), jobName,
+		types.MergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+		klog.Errorf("Failed to record the checkpoint of Job <%s/%s>: %v", pod.Namespace, jobName, err)
+	}
+}
+
+// execCheckpointHook executes the command of the hook in the first container of the pod.
+func (sc *SchedulerCache) execCheckpointHook(ctx context.Context, pod *v1.Pod, action *v1.ExecAction) error {
+	if sc.restConfig == nil {
+		return fmt.Errorf("no client config to exec in pod")
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return fmt.Errorf("pod has no container")
+	}
+	req := sc.kubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: pod.Spec.Containers[0].Name,
+			Command:   action.Command,
+			Stdout:    true,
+			Stderr:    true,
+		}, kubescheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(sc.restConfig, http.MethodPost, req.URL())
+	if err != nil {
+		return err
+	}
+	var stdout, stderr bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr}); err != nil {
+		return fmt.Errorf("%v, stderr: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// httpCheckpointHook sends the http request of the hook to the pod, the hook succeeds on a 2xx or 3xx response.
+func httpCheckpointHook(ctx context.Context, pod *v1.Pod, action *v1.HTTPGetAction) error {
+	port, err := resolveContainerPort(action.Port, pod)
+	if err != nil {
+		return err
+	}
+	host := action.Host
+	if host == "" {
+		host = pod.Status.PodIP
+	}
+	if host == "" {
+		return fmt.Errorf("pod has no ip")
+	}
+	scheme := strings.ToLower(string(action.Scheme))
+	if scheme == "" {
+		scheme = "http"
+	}
+	url := fmt.Sprintf("%s://%s/%s", scheme, net.JoinHostPort(host, strconv.Itoa(port)), strings.TrimPrefix(action.Path, "/"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	for _, header := range action.HTTPHeaders {
+		req.Header.Add(header.Name, header.Value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("http GET %s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+// resolveContainerPort returns the number of the port, which is looked up in the containers of the pod by name.
+func resolveContainerPort(port intstr.IntOrString, pod *v1.Pod) (int, error) {
+	if port.Type == intstr.Int {
+		if port.IntValue() <= 0 {
+			return 0, fmt.Errorf("invalid port %d", port.IntValue())
+		}
+		return port.IntValue(), nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port.StrVal {
+				return int(containerPort.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("port %q is not found in the containers", port.StrVal)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestHTTPCheckpointHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/checkpoint" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, _ := strconv.Atoi(portStr)
+
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{Containers: []v1.Container{{
+			Name:  "main",
+			Ports: []v1.ContainerPort{{Name: "ckpt", ContainerPort: int32(port)}},
+		}}},
+		Status: v1.PodStatus{PodIP: host},
+	}
+
+	testCases := []struct {
+		name      string
+		action    *v1.HTTPGetAction
+		expectErr bool
+	}{
+		{
+			name:   "port by number",
+			action: &v1.HTTPGetAction{Path: "/checkpoint", Port: intstr.FromInt32(int32(port))},
+		},
+		{
+			name:   "port by name",
+			action: &v1.HTTPGetAction{Path: "checkpoint", Port: intstr.FromString("ckpt")},
+		},
+		{
+			name:      "port not found",
+			action:    &v1.HTTPGetAction{Path: "/checkpoint", Port: intstr.FromString("http")},
+			expectErr: true,
+		},
+		{
+			name:      "error response",
+			action:    &v1.HTTPGetAction{Path: "/unknown", Port: intstr.FromInt32(int32(port))},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := httpCheckpointHook(context.Background(), pod, tc.action)
+			if (err != nil) != tc.expectErr {
+				t.Errorf("expected error %v, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
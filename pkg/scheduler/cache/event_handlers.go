@@ -419,6 +419,9 @@ func (sc *SchedulerCache) UpdatePod(oldObj, newObj interface{}) {
 		klog.Errorf("Failed to update pod %v in cache: %v", oldPod.Name, err)
 		return
 	}
+	if !isPodFinished(oldPod) && isPodFinished(newPod) {
+		sc.recordPlacementStop(newPod)
+	}
 
 	klog.V(4).Infof("Updated pod <%s/%v> in cache.", oldPod.Namespace, oldPod.Name)
 }
@@ -444,6 +447,7 @@ func (sc *SchedulerCache) DeletePod(obj interface{}) {
 	sc.Mutex.Lock()
 	defer sc.Mutex.Unlock()
 
+	sc.recordPlacementStop(pod)
 	err := sc.deletePod(pod)
 	if err != nil {
 		klog.Errorf("Failed to delete pod %v from cache: %v", pod.Name, err)
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+	commonutil "volcano.sh/volcano/pkg/util"
+)
+
+// gangHints returns the annotations describing the demand of the gang to autoscalers.
+func gangHints(job *schedulingapi.JobInfo) map[string]string {
+	total := schedulingapi.EmptyResource()
+	for _, task := range job.TaskStatusIndex[schedulingapi.Pending] {
+		total.Add(task.InitResreq)
+	}
+	return map[string]string{
+		schedulingapi.GangMinMember:      strconv.Itoa(int(job.MinAvailable)),
+		schedulingapi.GangSize:           strconv.Itoa(len(job.Tasks)),
+		schedulingapi.GangTotalResources: commonutil.FormatResourceList(util.ConvertRes2ResList(total)),
+	}
+}
+
+// recordGangHints annotates the pending pods of the unschedulable gang with the demand of the whole gang,
+// so that autoscalers scale up enough nodes at once instead of one node at a time. The pods already
+// annotated with the same hints are not patched.
+func (sc *SchedulerCache) recordGangHints(job *schedulingapi.JobInfo) {
+	hints := gangHints(job)
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": hints},
+	})
+	if err != nil {
+		klog.Errorf("Failed to marshal gang hints of job <%s/%s>: %v", job.Namespace, job.Name, err)
+		return
+	}
+
+	for _, task := range job.TaskStatusIndex[schedulingapi.Pending] {
+		// The scheduling gated pods are not considered by autoscalers.
+		if task.Pod == nil || task.SchGated || hasAnnotations(task.Pod, hints) {
+			continue
+		}
+		if _, err := sc.kubeClient.CoreV1().Pods(task.Namespace).Patch(context.TODO(), task.Name,
+			types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			klog.ErrorS(err, "Failed to annotate gang hints", "task", klog.KRef(task.Namespace, task.Name))
+		}
+	}
+}
+
+func hasAnnotations(pod *v1.Pod, annotations map[string]string) bool {
+	for key, value := range annotations {
+		if current, found := pod.Annotations[key]; !found || current != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+func TestRecordGangHints(t *testing.T) {
+	owner := buildOwnerReference("j1")
+	running := buildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("2", "4Gi"),
+		[]metav1.OwnerReference{owner}, make(map[string]string))
+	pending1 := buildPod("c1", "p2", "", v1.PodPending, api.BuildResourceListWithGPU("4", "8Gi", "2"),
+		[]metav1.OwnerReference{owner}, make(map[string]string))
+	pending2 := buildPod("c1", "p3", "", v1.PodPending, api.BuildResourceListWithGPU("4", "8Gi", "2"),
+		[]metav1.OwnerReference{owner}, make(map[string]string))
+
+	job := api.NewJobInfo("j1", api.NewTaskInfo(running), api.NewTaskInfo(pending1), api.NewTaskInfo(pending2))
+	job.MinAvailable = 3
+
+	sc := &SchedulerCache{kubeClient: fake.NewSimpleClientset(running, pending1, pending2)}
+	sc.recordGangHints(job)
+
+	expected := map[string]string{
+		api.GangMinMember:      "3",
+		api.GangSize:           "3",
+		api.GangTotalResources: "cpu=8,memory=16Gi,nvidia.com/gpu=4,pods=2",
+	}
+	for _, pod := range []*v1.Pod{pending1, pending2} {
+		got, err := sc.kubeClient.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got.Annotations, expected) {
+			t.Errorf("pod %s: expected annotations %v, got %v", pod.Name, expected, got.Annotations)
+		}
+	}
+
+	got, err := sc.kubeClient.CoreV1().Pods(running.Namespace).Get(context.TODO(), running.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Annotations) != 0 {
+		t.Errorf("expected running pod not to be annotated, got %v", got.Annotations)
+	}
+}
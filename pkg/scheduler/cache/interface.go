@@ -76,6 +76,9 @@ type Cache interface {
 	// ClientConfig returns the rest config
 	ClientConfig() *rest.Config
 
+	// StopCh returns the stop channel the cache runs with, the informers started by plugins stop with it
+	StopCh() <-chan struct{}
+
 	UpdateSchedulerNumaInfo(sets map[string]api.ResNumaSets) error
 
 	// SharedInformerFactory return scheduler SharedInformerFactory
@@ -101,7 +104,8 @@ type Binder interface {
 
 // Evictor interface for evict pods
 type Evictor interface {
-	Evict(pod *v1.Pod, reason string) error
+	// Evict deletes the pod with the grace period, the terminationGracePeriodSeconds of the pod is used if it is nil.
+	Evict(pod *v1.Pod, reason string, gracePeriodSeconds *int64) error
 }
 
 // StatusUpdater updates pod with given PodCondition
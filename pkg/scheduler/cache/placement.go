@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/gpushare"
+	"volcano.sh/volcano/pkg/scheduler/placement"
+)
+
+// recordPlacementStart records the placement of the task bound successfully.
+func (sc *SchedulerCache) recordPlacementStart(task *schedulingapi.TaskInfo) {
+	if sc.placementRecorder == nil {
+		return
+	}
+
+	var queue string
+	sc.Mutex.Lock()
+	if job, found := sc.Jobs[task.Job]; found {
+		queue = string(job.Queue)
+	}
+	sc.Mutex.Unlock()
+
+	record := &placement.Record{
+		TaskUID:   string(task.UID),
+		Namespace: task.Namespace,
+		Name:      task.Name,
+		Job:       string(task.Job),
+		Queue:     queue,
+		Node:      task.NodeName,
+		Start:     time.Now(),
+	}
+	if task.Pod != nil {
+		record.GPUs = gpushare.GetGPUIndex(task.Pod)
+	}
+	sc.placementRecorder.RecordStart(record)
+}
+
+// recordPlacementStop records the stop of the placement of the pod, which is finished or deleted.
+func (sc *SchedulerCache) recordPlacementStop(pod *v1.Pod) {
+	if sc.placementRecorder == nil || pod.Spec.NodeName == "" {
+		return
+	}
+	sc.placementRecorder.RecordStop(string(pod.UID), time.Now())
+}
+
+func isPodFinished(pod *v1.Pod) bool {
+	return pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed
+}
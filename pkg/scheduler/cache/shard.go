@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// rootQueueName is the name of the root queue, whose resources are managed by the session.
+const rootQueueName = "root"
+
+// responsibleForShard returns whether the podgroup is assigned to the scheduler shard, all the podgroups
+// are assigned to the scheduler if it does not own a shard.
+func responsibleForShard(pg *scheduling.PodGroup, shardName string) bool {
+	if shardName == "" {
+		return true
+	}
+	shard := pg.Annotations[schedulingapi.PodGroupSchedulerShard]
+	if shard == "" {
+		shard = schedulingapi.DefaultSchedulerShard
+	}
+	return shard == shardName
+}
+
+// shardAllocatedKey returns the annotation key of the queue recording its allocated resources in the shard.
+func shardAllocatedKey(shardName string) string {
+	return schedulingapi.QueueShardAllocatedPrefix + shardName
+}
+
+// shardAllocated returns the allocated resources of the queue recorded by the shards, keyed by the shard name.
+// The invalid records are ignored.
+func shardAllocated(queue *schedulingapi.QueueInfo) map[string]v1.ResourceList {
+	allocated := map[string]v1.ResourceList{}
+	for key, value := range queue.Queue.Annotations {
+		shardName, found := strings.CutPrefix(key, schedulingapi.QueueShardAllocatedPrefix)
+		if !found || shardName == "" {
+			continue
+		}
+		var rl v1.ResourceList
+		if err := json.Unmarshal([]byte(value), &rl); err != nil {
+			klog.Warningf("Invalid allocated resources of queue <%s> in shard %s: %v", queue.Name, shardName, err)
+			continue
+		}
+		allocated[shardName] = rl
+	}
+	return allocated
+}
+
+// othersAllocated returns the total allocated resources of the queue in the shards other than the shard.
+func othersAllocated(queue *schedulingapi.QueueInfo, shardName string) v1.ResourceList {
+	total := v1.ResourceList{}
+	for shard, rl := range shardAllocated(queue) {
+		if shard == shardName {
+			continue
+		}
+		addResourceList(total, rl)
+	}
+	return total
+}
+
+// addResourceList adds the quantities of rl to total.
+func addResourceList(total, rl v1.ResourceList) {
+	for name, quantity := range rl {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
+
+// deductResourceList deducts the quantities of used from the quantities set in rl, the result is at least zero.
+// The resources not set in rl are not limited, so they are left unset.
+func deductResourceList(rl, used v1.ResourceList) {
+	for name, quantity := range rl {
+		u, found := used[name]
+		if !found {
+			continue
+		}
+		left := quantity.DeepCopy()
+		left.Sub(u)
+		if left.Sign() < 0 {
+			left = *resource.NewQuantity(0, quantity.Format)
+		}
+		rl[name] = left
+	}
+}
+
+// cloneQueue clones the queue for the snapshot. If the scheduler owns a shard, the quota of the queue is
+// shared with the other shards, so the resources allocated by the other shards are deducted from the
+// capability, deserved and guarantee of the queue, and the allocated resources of the queue in the status
+// are the ones allocated in the shard.
+func (sc *SchedulerCache) cloneQueue(queue *schedulingapi.QueueInfo) *schedulingapi.QueueInfo {
+	clone := queue.Clone()
+	if sc.shardName == "" || queue.Name == rootQueueName || queue.Queue == nil {
+		return clone
+	}
+
+	clone.Queue = queue.Queue.DeepCopy()
+	others := othersAllocated(queue, sc.shardName)
+	deductResourceList(clone.Queue.Spec.Capability, others)
+	deductResourceList(clone.Queue.Spec.Deserved, others)
+	deductResourceList(clone.Queue.Spec.Guarantee.Resource, others)
+	clone.Queue.Status.Allocated = shardAllocated(queue)[sc.shardName]
+	return clone
+}
+
+// updateShardQueueStatus records the allocated resources of the queue in the shard into the annotation of
+// the queue if they are changed, and updates the allocated resources in the status of the queue to the total
+// of all shards.
+func (sc *SchedulerCache) updateShardQueueStatus(queue *schedulingapi.QueueInfo) error {
+	allocated := queue.Queue.Status.Allocated
+	if allocated == nil {
+		allocated = v1.ResourceList{}
+	}
+	recorded, found := shardAllocated(queue)[sc.shardName]
+	if !found || !equality.Semantic.DeepEqual(recorded, allocated) {
+		if err := sc.patchShardAllocated(queue.Name, allocated); err != nil {
+			return err
+		}
+	}
+
+	total := othersAllocated(queue, sc.shardName)
+	addResourceList(total, allocated)
+	clone := queue.Clone()
+	clone.Queue = queue.Queue.DeepCopy()
+	clone.Queue.Status.Allocated = total
+	return sc.StatusUpdater.UpdateQueueStatus(clone)
+}
+
+// patchShardAllocated records the allocated resources of the queue in the shard into the annotation of the queue.
+func (sc *SchedulerCache) patchShardAllocated(queueName string, allocated v1.ResourceList) error {
+	data, err := json.Marshal(allocated)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{shardAllocatedKey(sc.shardName): string(data)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := sc.vcClient.SchedulingV1beta1().Queues().Patch(context.TODO(), queueName,
+		types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch allocated resources of queue <%s> in shard %s: %v", queueName, sc.shardName, err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	fakevcClient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+type queueStatusRecorder struct {
+	util.FakeStatusUpdater
+	queues []*api.QueueInfo
+}
+
+func (r *queueStatusRecorder) UpdateQueueStatus(queue *api.QueueInfo) error {
+	r.queues = append(r.queues, queue)
+	return nil
+}
+
+func TestResponsibleForShard(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		shardName   string
+		expected    bool
+	}{
+		{
+			name:      "not sharded",
+			shardName: "",
+			expected:  true,
+		},
+		{
+			name:      "default shard",
+			shardName: api.DefaultSchedulerShard,
+			expected:  true,
+		},
+		{
+			name:        "same shard",
+			annotations: map[string]string{api.PodGroupSchedulerShard: "gpu"},
+			shardName:   "gpu",
+			expected:    true,
+		},
+		{
+			name:        "other shard",
+			annotations: map[string]string{api.PodGroupSchedulerShard: "gpu"},
+			shardName:   api.DefaultSchedulerShard,
+			expected:    false,
+		},
+		{
+			name:      "podgroup of default shard",
+			shardName: "gpu",
+			expected:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pg := &schedulingv1beta1.PodGroup{ObjectMeta: metav1.ObjectMeta{Name: "pg", Annotations: test.annotations}}
+			if got := responsibleForShard(pg, test.shardName); got != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func buildShardQueue(annotations map[string]string) *api.QueueInfo {
+	return api.NewQueueInfo(&scheduling.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: "q1", Annotations: annotations},
+		Spec: scheduling.QueueSpec{
+			Weight:     1,
+			Capability: api.BuildResourceList("10", "20Gi"),
+			Deserved:   api.BuildResourceList("6", "12Gi"),
+			Guarantee:  scheduling.Guarantee{Resource: api.BuildResourceList("2", "")},
+		},
+	})
+}
+
+func TestCloneQueueOfShard(t *testing.T) {
+	queue := buildShardQueue(map[string]string{
+		api.QueueShardAllocatedPrefix + "a": `{"cpu":"2","memory":"4Gi"}`,
+		api.QueueShardAllocatedPrefix + "b": `{"cpu":"3","memory":"8Gi"}`,
+		api.QueueShardAllocatedPrefix + "c": `invalid`,
+	})
+
+	sc := &SchedulerCache{}
+	if got := sc.cloneQueue(queue); got.Queue != queue.Queue {
+		t.Errorf("expected the queue not copied when the scheduler is not sharded")
+	}
+
+	sc.shardName = "a"
+	got := sc.cloneQueue(queue)
+	expected := map[string]v1.ResourceList{
+		"capability": api.BuildResourceList("7", "12Gi"),
+		"deserved":   api.BuildResourceList("3", "4Gi"),
+		"guarantee":  api.BuildResourceList("0", ""),
+		"allocated":  api.BuildResourceList("2", "4Gi"),
+	}
+	actual := map[string]v1.ResourceList{
+		"capability": got.Queue.Spec.Capability,
+		"deserved":   got.Queue.Spec.Deserved,
+		"guarantee":  got.Queue.Spec.Guarantee.Resource,
+		"allocated":  got.Queue.Status.Allocated,
+	}
+	for name, rl := range expected {
+		if !equality.Semantic.DeepEqual(rl, actual[name]) {
+			t.Errorf("%s: expected %v, got %v", name, rl, actual[name])
+		}
+	}
+	if !equality.Semantic.DeepEqual(queue.Queue.Spec.Capability, api.BuildResourceList("10", "20Gi")) {
+		t.Errorf("expected the queue in the cache not changed, got capability %v", queue.Queue.Spec.Capability)
+	}
+}
+
+func TestUpdateShardQueueStatus(t *testing.T) {
+	queue := buildShardQueue(map[string]string{
+		api.QueueShardAllocatedPrefix + "a": `{"cpu":"2","memory":"4Gi"}`,
+		api.QueueShardAllocatedPrefix + "b": `{"cpu":"3","memory":"8Gi"}`,
+	})
+	queue.Queue.Status.Allocated = api.BuildResourceList("4", "8Gi")
+
+	recorder := &queueStatusRecorder{}
+	sc := &SchedulerCache{
+		vcClient: fakevcClient.NewSimpleClientset(&schedulingv1beta1.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: "q1", Annotations: queue.Queue.Annotations},
+		}),
+		StatusUpdater: recorder,
+		shardName:     "a",
+	}
+	if err := sc.UpdateQueueStatus(queue); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := sc.vcClient.SchedulingV1beta1().Queues().Get(context.TODO(), "q1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := updated.Annotations[api.QueueShardAllocatedPrefix+"a"]; got != `{"cpu":"4","memory":"8Gi"}` {
+		t.Errorf("expected the allocated resources of the shard recorded, got %s", got)
+	}
+	if got := updated.Annotations[api.QueueShardAllocatedPrefix+"b"]; got != `{"cpu":"3","memory":"8Gi"}` {
+		t.Errorf("expected the allocated resources of the other shard kept, got %s", got)
+	}
+
+	if len(recorder.queues) != 1 {
+		t.Fatalf("expected the queue status updated once, got %d", len(recorder.queues))
+	}
+	if expected := api.BuildResourceList("7", "16Gi"); !equality.Semantic.DeepEqual(recorder.queues[0].Queue.Status.Allocated, expected) {
+		t.Errorf("expected the allocated resources of all shards %v, got %v", expected, recorder.queues[0].Queue.Status.Allocated)
+	}
+	// the annotation is not patched again if the allocated resources of the shard are not changed
+	queue.Queue.Annotations = updated.Annotations
+	client := sc.vcClient.(*fakevcClient.Clientset)
+	client.ClearActions()
+	if err := sc.UpdateQueueStatus(queue); err != nil {
+		t.Fatal(err)
+	}
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "patch" {
+			t.Errorf("expected the queue not patched, got %v", action)
+		}
+	}
+	if len(recorder.queues) != 2 {
+		t.Errorf("expected the queue status updated again, got %d", len(recorder.queues))
+	}
+}
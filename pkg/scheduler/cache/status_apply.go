@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	vcv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	v1beta1apply "volcano.sh/apis/pkg/client/applyconfiguration/scheduling/v1beta1"
+
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// FieldManager is the field manager of the scheduler in server-side apply requests, the
+// scheduler owns the status of podgroups and the allocated resources of queues.
+const FieldManager = "volcano-scheduler"
+
+// podGroupApplyConfiguration returns the fields of the podgroup owned by the scheduler. The uid
+// is set so that the podgroup is not re-created by the apply request if it has been deleted.
+func podGroupApplyConfiguration(pg *vcv1beta1.PodGroup) *v1beta1apply.PodGroupApplyConfiguration {
+	status := v1beta1apply.PodGroupStatus().
+		WithPhase(pg.Status.Phase).
+		WithRunning(pg.Status.Running).
+		WithSucceeded(pg.Status.Succeeded).
+		WithFailed(pg.Status.Failed)
+	for _, cond := range pg.Status.Conditions {
+		status.WithConditions(v1beta1apply.PodGroupCondition().
+			WithType(cond.Type).
+			WithStatus(cond.Status).
+			WithTransitionID(cond.TransitionID).
+			WithLastTransitionTime(cond.LastTransitionTime).
+			WithReason(cond.Reason).
+			WithMessage(cond.Message))
+	}
+
+	pgApply := v1beta1apply.PodGroup(pg.Name, pg.Namespace).WithUID(pg.UID).WithStatus(status)
+	if hyperNode, found := pg.Annotations[schedulingapi.JobAllocatedHyperNode]; found {
+		pgApply.WithAnnotations(map[string]string{schedulingapi.JobAllocatedHyperNode: hyperNode})
+	}
+	return pgApply
+}
+
+// QueueStatusApplyConfiguration returns the status fields of the queue owned by the scheduler.
+func QueueStatusApplyConfiguration(queue *vcv1beta1.Queue) *v1beta1apply.QueueApplyConfiguration {
+	return v1beta1apply.Queue(queue.Name).WithStatus(v1beta1apply.QueueStatus().WithAllocated(queue.Status.Allocated))
+}
+
+// queueRecordedResourcesApplyConfiguration returns the annotations of the queue recording the pending and pipelined
+// resources owned by the scheduler, nil if neither is set. The uid is set so that the queue is not re-created by the
+// apply request if it has been deleted.
+func queueRecordedResourcesApplyConfiguration(queue *vcv1beta1.Queue) *v1beta1apply.QueueApplyConfiguration {
+	annotations := map[string]string{}
+	for _, key := range []string{schedulingapi.QueuePendingResources, schedulingapi.QueuePipelinedResources} {
+		if value, found := queue.Annotations[key]; found {
+			annotations[key] = value
+		}
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	return v1beta1apply.Queue(queue.Name).WithUID(queue.UID).WithAnnotations(annotations)
+}
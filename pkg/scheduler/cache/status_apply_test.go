@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vcv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+func TestPodGroupApplyConfiguration(t *testing.T) {
+	pg := &vcv1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pg1",
+			Namespace: "ns1",
+			UID:       "uid1",
+			Annotations: map[string]string{
+				schedulingapi.JobAllocatedHyperNode: "hypernode0",
+				"owned-by-others":                   "value",
+			},
+		},
+		Spec: vcv1beta1.PodGroupSpec{MinMember: 3},
+		Status: vcv1beta1.PodGroupStatus{
+			Phase: vcv1beta1.PodGroupRunning,
+			Conditions: []vcv1beta1.PodGroupCondition{
+				{Type: vcv1beta1.PodGroupScheduled, Status: v1.ConditionTrue},
+			},
+		},
+	}
+
+	pgApply := podGroupApplyConfiguration(pg)
+	if *pgApply.UID != "uid1" {
+		t.Errorf("expected uid to be set as precondition, got %v", *pgApply.UID)
+	}
+	if pgApply.Spec != nil {
+		t.Errorf("expected spec not to be applied by scheduler, got %v", pgApply.Spec)
+	}
+	if len(pgApply.Annotations) != 1 || pgApply.Annotations[schedulingapi.JobAllocatedHyperNode] != "hypernode0" {
+		t.Errorf("expected only the allocated hypernode annotation, got %v", pgApply.Annotations)
+	}
+	if *pgApply.Status.Phase != vcv1beta1.PodGroupRunning || *pgApply.Status.Running != 0 {
+		t.Errorf("unexpected status %v/%v", *pgApply.Status.Phase, *pgApply.Status.Running)
+	}
+	if len(pgApply.Status.Conditions) != 1 || *pgApply.Status.Conditions[0].Type != vcv1beta1.PodGroupScheduled {
+		t.Errorf("expected one scheduled condition, got %v", pgApply.Status.Conditions)
+	}
+}
+
+func TestQueueRecordedResourcesApplyConfiguration(t *testing.T) {
+	queue := &vcv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "q1",
+			UID:  "uid1",
+			Annotations: map[string]string{
+				schedulingapi.QueuePendingResources:   `{"cpu":"8"}`,
+				schedulingapi.QueuePipelinedResources: `{"cpu":"2"}`,
+				"owned-by-others":                     "value",
+			},
+		},
+		Spec: vcv1beta1.QueueSpec{Weight: 1},
+	}
+
+	queueApply := queueRecordedResourcesApplyConfiguration(queue)
+	if *queueApply.UID != "uid1" {
+		t.Errorf("expected uid to be set as precondition, got %v", *queueApply.UID)
+	}
+	if queueApply.Spec != nil || queueApply.Status != nil {
+		t.Errorf("expected spec and status not to be applied, got %v/%v", queueApply.Spec, queueApply.Status)
+	}
+	if len(queueApply.Annotations) != 2 || queueApply.Annotations[schedulingapi.QueuePendingResources] != `{"cpu":"8"}` ||
+		queueApply.Annotations[schedulingapi.QueuePipelinedResources] != `{"cpu":"2"}` {
+		t.Errorf("expected only the pending and pipelined resources annotations, got %v", queueApply.Annotations)
+	}
+
+	delete(queue.Annotations, schedulingapi.QueuePendingResources)
+	delete(queue.Annotations, schedulingapi.QueuePipelinedResources)
+	if queueApply := queueRecordedResourcesApplyConfiguration(queue); queueApply != nil {
+		t.Errorf("expected nothing applied without pending or pipelined resources, got %v", queueApply)
+	}
+}
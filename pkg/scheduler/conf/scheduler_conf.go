@@ -33,6 +33,8 @@ type SchedulerConfiguration struct {
 	// Configurations is configuration for actions
 	Configurations       []Configuration   `yaml:"configurations"`
 	MetricsConfiguration map[string]string `yaml:"metrics"`
+	// AuditConfiguration configures the sink recording the scheduling decisions, e.g. sink: stdout
+	AuditConfiguration map[string]string `yaml:"audit"`
 }
 
 // Tier defines plugin tier
@@ -94,6 +96,12 @@ type PluginOption struct {
 	EnabledAllocatable *bool `yaml:"enabledAllocatable"`
 	// EnabledHyperNodeOrder defines whether hyperNode is enabled
 	EnabledHyperNodeOrder *bool `yaml:"enabledHyperNodeOrder"`
+	// Weight defines the weight of the node order scores of the plugin, 1 if not set
+	Weight *int `yaml:"weight"`
+	// ActionWeights overrides the weight of the node order scores of the plugin in the given actions
+	ActionWeights map[string]int `yaml:"actionWeights"`
+	// ScoreNormalizer defines the normalizer applied to the node order scores of the plugin before weighted
+	ScoreNormalizer string `yaml:"scoreNormalizer"`
 	// Arguments defines the different arguments that can be given to different plugins
 	Arguments map[string]interface{} `yaml:"arguments"`
 }
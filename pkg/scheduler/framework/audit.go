@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/audit"
+)
+
+// SetAuditSink sets the sink recording the scheduling decisions of the session.
+func (ssn *Session) SetAuditSink(sink audit.Sink) {
+	ssn.auditSink = sink
+}
+
+// recordNodeScores records the scores of the node for the task by the node order plugins when the task is
+// allocated or pipelined to it, before the task is added to the node. The batch node order plugins are not
+// included, since their scores are relative to all the candidate nodes.
+func (ssn *Session) recordNodeScores(task *api.TaskInfo, node *api.NodeInfo) {
+	if ssn.auditSink == nil || node == nil {
+		return
+	}
+
+	scores := map[string]float64{}
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if !isEnabled(plugin.EnabledNodeOrder) {
+				continue
+			}
+			if pfn, found := ssn.nodeOrderFns[plugin.Name]; found {
+				score, err := pfn(task, node)
+				if err != nil {
+					klog.V(4).Infof("Failed to score node <%s> for task <%s/%s> by plugin %s for audit: %v",
+						node.Name, task.Namespace, task.Name, plugin.Name, err)
+					continue
+				}
+				scores[plugin.Name] += score * ssn.scoreWeight(plugin)
+			}
+			if pfn, found := ssn.nodeMapFns[plugin.Name]; found {
+				score, err := pfn(task, node)
+				if err != nil {
+					klog.V(4).Infof("Failed to score node <%s> for task <%s/%s> by plugin %s for audit: %v",
+						node.Name, task.Namespace, task.Name, plugin.Name, err)
+					continue
+				}
+				scores[plugin.Name] += score * ssn.scoreWeight(plugin)
+			}
+		}
+	}
+
+	if ssn.auditScores == nil {
+		ssn.auditScores = map[api.TaskID]map[string]float64{}
+	}
+	ssn.auditScores[task.UID] = scores
+}
+
+// recordDecision records the decision about the task to the audit sink of the session.
+func (ssn *Session) recordDecision(decisionType audit.DecisionType, task *api.TaskInfo, reason string, victims []string) {
+	if ssn.auditSink == nil {
+		return
+	}
+
+	decision := &audit.Decision{
+		Time:      time.Now(),
+		Session:   string(ssn.UID),
+		Action:    ssn.currentAction,
+		Type:      decisionType,
+		Namespace: task.Namespace,
+		Pod:       task.Name,
+		Job:       string(task.Job),
+		Node:      task.NodeName,
+		Victims:   victims,
+		Reason:    reason,
+	}
+	if job, found := ssn.Jobs[task.Job]; found {
+		decision.Queue = string(job.Queue)
+	}
+	if decisionType != audit.DecisionEvict {
+		decision.Scores = ssn.auditScores[task.UID]
+	}
+	if task.Pod != nil {
+		decision.Object = task.Pod
+	}
+	ssn.auditSink.Record(decision)
+}
+
+// evictedVictims returns the tasks evicted by the operations, as namespace/name keyed by their nodes.
+func evictedVictims(operations []operation) map[string][]string {
+	victims := map[string][]string{}
+	for _, op := range operations {
+		if op.name != Evict {
+			continue
+		}
+		victims[op.task.NodeName] = append(victims[op.task.NodeName], op.task.Namespace+"/"+op.task.Name)
+	}
+	return victims
+}
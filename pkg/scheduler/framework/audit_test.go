@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/audit"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+)
+
+type fakeAuditSink struct {
+	decisions []*audit.Decision
+}
+
+func (s *fakeAuditSink) Record(decision *audit.Decision) {
+	s.decisions = append(s.decisions, decision)
+}
+
+func (s *fakeAuditSink) Close() {}
+
+func TestRecordDecision(t *testing.T) {
+	sink := &fakeAuditSink{}
+	ssn := &Session{
+		UID:           "s1",
+		currentAction: "preempt",
+		Jobs:          map[api.JobID]*api.JobInfo{"ns1/j1": {UID: "ns1/j1", Queue: "q1"}},
+		Tiers: []conf.Tier{{Plugins: []conf.PluginOption{
+			{Name: "binpack", EnabledNodeOrder: ptr.To(true), Weight: ptr.To(2)},
+			{Name: "nodeorder", EnabledNodeOrder: ptr.To(true)},
+			{Name: "disabled", EnabledNodeOrder: ptr.To(false)},
+		}}},
+		nodeOrderFns: map[string]api.NodeOrderFn{
+			"binpack":  func(*api.TaskInfo, *api.NodeInfo) (float64, error) { return 5, nil },
+			"disabled": func(*api.TaskInfo, *api.NodeInfo) (float64, error) { return 100, nil },
+		},
+		nodeMapFns: map[string]api.NodeMapFn{
+			"nodeorder": func(*api.TaskInfo, *api.NodeInfo) (float64, error) { return 30, nil },
+		},
+	}
+
+	preemptor := &api.TaskInfo{UID: "t1", Job: "ns1/j1", Namespace: "ns1", Name: "p1", TransactionContext: api.TransactionContext{NodeName: "n1"}}
+	operations := []operation{
+		{name: Evict, task: &api.TaskInfo{UID: "t2", Namespace: "ns2", Name: "p2", TransactionContext: api.TransactionContext{NodeName: "n1"}}, reason: "preempt"},
+		{name: Evict, task: &api.TaskInfo{UID: "t3", Namespace: "ns2", Name: "p3", TransactionContext: api.TransactionContext{NodeName: "n2"}}, reason: "preempt"},
+		{name: Pipeline, task: preemptor},
+	}
+
+	// no decisions are recorded without the audit sink
+	ssn.recordNodeScores(preemptor, &api.NodeInfo{Name: "n1"})
+	ssn.recordDecision(audit.DecisionPipeline, preemptor, "", nil)
+	if ssn.auditScores != nil {
+		t.Errorf("expected no scores recorded without the audit sink, got %v", ssn.auditScores)
+	}
+
+	ssn.SetAuditSink(sink)
+	ssn.recordNodeScores(preemptor, &api.NodeInfo{Name: "n1"})
+	victims := evictedVictims(operations)
+	ssn.recordDecision(audit.DecisionPipeline, preemptor, "", victims[preemptor.NodeName])
+
+	if len(sink.decisions) != 1 {
+		t.Fatalf("expected 1 decision recorded, got %d", len(sink.decisions))
+	}
+	got := sink.decisions[0]
+	expected := &audit.Decision{
+		Time:      got.Time,
+		Session:   "s1",
+		Action:    "preempt",
+		Type:      audit.DecisionPipeline,
+		Namespace: "ns1",
+		Pod:       "p1",
+		Job:       "ns1/j1",
+		Queue:     "q1",
+		Node:      "n1",
+		Scores:    map[string]float64{"binpack": 10, "nodeorder": 30},
+		Victims:   []string{"ns2/p2"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
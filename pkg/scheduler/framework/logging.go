@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// Logger returns the logger of the session, the messages logged by it carry the session field, so that the
+// messages of a scheduling cycle can be filtered out of the busy logs.
+func (ssn *Session) Logger() klog.Logger {
+	return klog.LoggerWithValues(klog.Background(), "session", ssn.UID)
+}
+
+// JobLogger returns the logger of the job in the session, the messages logged by it carry the session, job,
+// podgroup and queue fields, e.g. grep 'job="ns1/job1"' to follow a job through the sessions.
+func (ssn *Session) JobLogger(job *api.JobInfo) klog.Logger {
+	return klog.LoggerWithValues(ssn.Logger(), JobLogValues(job)...)
+}
+
+// TaskLogger returns the logger of the task in the session, the messages logged by it carry the fields of the
+// job of the task and the pod field.
+func (ssn *Session) TaskLogger(task *api.TaskInfo) klog.Logger {
+	logger := ssn.Logger()
+	if job, found := ssn.Jobs[task.Job]; found {
+		logger = klog.LoggerWithValues(logger, JobLogValues(job)...)
+	}
+	return klog.LoggerWithValues(logger, "pod", klog.KRef(task.Namespace, task.Name))
+}
+
+// JobLogValues returns the key/value pairs of the job, podgroup and queue fields of the job. The job field is the
+// owner of the podgroup, e.g. the vcjob or the deployment, or the podgroup itself if it is not owned.
+func JobLogValues(job *api.JobInfo) []interface{} {
+	owner := job.Name
+	if job.PodGroup != nil {
+		if ref := metav1.GetControllerOf(&job.PodGroup.PodGroup); ref != nil {
+			owner = ref.Name
+		}
+	}
+	return []interface{}{
+		"job", klog.KRef(job.Namespace, owner),
+		"podgroup", klog.KRef(job.Namespace, job.Name),
+		"queue", job.Queue,
+	}
+}
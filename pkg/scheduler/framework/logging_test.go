@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+func TestJobLogValues(t *testing.T) {
+	buildJob := func(owners []metav1.OwnerReference) *api.JobInfo {
+		job := api.NewJobInfo("ns1/job1-uid")
+		job.SetPodGroup(&api.PodGroup{
+			PodGroup: scheduling.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "job1-uid", OwnerReferences: owners},
+				Spec:       scheduling.PodGroupSpec{Queue: "q1"},
+			},
+		})
+		return job
+	}
+	controller := true
+
+	testCases := []struct {
+		name     string
+		job      *api.JobInfo
+		expected []interface{}
+	}{
+		{
+			name: "podgroup owned by vcjob",
+			job:  buildJob([]metav1.OwnerReference{{Kind: "Job", Name: "job1", Controller: &controller}}),
+			expected: []interface{}{
+				"job", klog.KRef("ns1", "job1"),
+				"podgroup", klog.KRef("ns1", "job1-uid"),
+				"queue", api.QueueID("q1"),
+			},
+		},
+		{
+			name: "podgroup not owned",
+			job:  buildJob(nil),
+			expected: []interface{}{
+				"job", klog.KRef("ns1", "job1-uid"),
+				"podgroup", klog.KRef("ns1", "job1-uid"),
+				"queue", api.QueueID("q1"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := JobLogValues(tc.job); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+	commonutil "volcano.sh/volcano/pkg/util"
+)
+
+// PipelinedTask is a task pipelined to a node in the last session, waiting for the resources of the victims
+// on the node to be released.
+type PipelinedTask struct {
+	Namespace string          `json:"namespace"`
+	Name      string          `json:"name"`
+	Job       string          `json:"job"`
+	Queue     string          `json:"queue"`
+	Node      string          `json:"node"`
+	Resources v1.ResourceList `json:"resources"`
+}
+
+// PipelinedSnapshot is the pipelined tasks of the last session, and their total requests by node and queue.
+// The resources look idle on the nodes but are promised to the pipelined tasks.
+type PipelinedSnapshot struct {
+	Session   string                     `json:"session"`
+	Timestamp time.Time                  `json:"timestamp"`
+	Tasks     []PipelinedTask            `json:"tasks"`
+	Nodes     map[string]v1.ResourceList `json:"nodes"`
+	Queues    map[string]v1.ResourceList `json:"queues"`
+}
+
+var (
+	pipelinedMutex    sync.RWMutex
+	pipelinedSnapshot = &PipelinedSnapshot{}
+)
+
+// recordPipelined records the pipelined tasks of the session for the debug endpoint, and sets the pipelined
+// condition of the podgroups, which is updated together with the status of the podgroups.
+func recordPipelined(ssn *Session) {
+	snapshot := &PipelinedSnapshot{
+		Session:   string(ssn.UID),
+		Timestamp: time.Now(),
+		Tasks:     []PipelinedTask{},
+	}
+	nodes := map[string]*api.Resource{}
+	queues := map[string]*api.Resource{}
+	for _, job := range ssn.Jobs {
+		tasks := job.TaskStatusIndex[api.Pipelined]
+		updatePipelinedCondition(ssn, job, tasks)
+
+		queueName := string(job.Queue)
+		if queue, found := ssn.Queues[job.Queue]; found {
+			queueName = queue.Name
+		}
+		for _, task := range tasks {
+			snapshot.Tasks = append(snapshot.Tasks, PipelinedTask{
+				Namespace: task.Namespace,
+				Name:      task.Name,
+				Job:       job.Name,
+				Queue:     queueName,
+				Node:      task.NodeName,
+				Resources: util.ConvertRes2ResList(task.Resreq),
+			})
+			if _, found := nodes[task.NodeName]; !found {
+				nodes[task.NodeName] = api.EmptyResource()
+			}
+			nodes[task.NodeName].Add(task.Resreq)
+			if _, found := queues[queueName]; !found {
+				queues[queueName] = api.EmptyResource()
+			}
+			queues[queueName].Add(task.Resreq)
+		}
+	}
+	sort.Slice(snapshot.Tasks, func(i, j int) bool {
+		if snapshot.Tasks[i].Namespace != snapshot.Tasks[j].Namespace {
+			return snapshot.Tasks[i].Namespace < snapshot.Tasks[j].Namespace
+		}
+		return snapshot.Tasks[i].Name < snapshot.Tasks[j].Name
+	})
+	snapshot.Nodes = toResourceLists(nodes)
+	snapshot.Queues = toResourceLists(queues)
+
+	pipelinedMutex.Lock()
+	pipelinedSnapshot = snapshot
+	pipelinedMutex.Unlock()
+}
+
+func toResourceLists(resources map[string]*api.Resource) map[string]v1.ResourceList {
+	lists := make(map[string]v1.ResourceList, len(resources))
+	for name, res := range resources {
+		lists[name] = util.ConvertRes2ResList(res)
+	}
+	return lists
+}
+
+// updatePipelinedCondition tells why the podgroup is waiting although the nodes look idle. The condition is only
+// turned false, instead of added, for the podgroups without pipelined tasks, so that most podgroups never have it.
+func updatePipelinedCondition(ssn *Session, job *api.JobInfo, tasks map[api.TaskID]*api.TaskInfo) {
+	if job.PodGroup == nil {
+		return
+	}
+	cond := &scheduling.PodGroupCondition{
+		Type:               api.PodGroupPipelinedType,
+		Status:             v1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		TransitionID:       string(ssn.UID),
+		Reason:             api.NoPipelinedTasksReason,
+	}
+	if len(tasks) == 0 {
+		found := false
+		for _, c := range job.PodGroup.Status.Conditions {
+			if c.Type == api.PodGroupPipelinedType && c.Status == v1.ConditionTrue {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return
+		}
+	} else {
+		nodeSet := map[string]struct{}{}
+		total := api.EmptyResource()
+		for _, task := range tasks {
+			nodeSet[task.NodeName] = struct{}{}
+			total.Add(task.Resreq)
+		}
+		nodes := make([]string, 0, len(nodeSet))
+		for node := range nodeSet {
+			nodes = append(nodes, node)
+		}
+		sort.Strings(nodes)
+		cond.Status = v1.ConditionTrue
+		cond.Reason = api.WaitingForVictimsReason
+		cond.Message = fmt.Sprintf("%d tasks pipelined to nodes %s, waiting for the release of %s by the victims",
+			len(tasks), strings.Join(nodes, ","), commonutil.FormatResourceList(util.ConvertRes2ResList(total)))
+	}
+
+	if err := ssn.UpdatePodGroupCondition(job, cond); err != nil {
+		klog.Errorf("Failed to update pipelined condition of job <%s/%s>: %v", job.Namespace, job.Name, err)
+	}
+}
+
+// GetPipelinedSnapshot returns the pipelined tasks of the last session.
+func GetPipelinedSnapshot() *PipelinedSnapshot {
+	pipelinedMutex.RLock()
+	defer pipelinedMutex.RUnlock()
+	return pipelinedSnapshot
+}
+
+// PipelinedHandler serves the pipelined tasks of the last session in json, the tasks can be filtered by the
+// queue and node query parameters.
+func PipelinedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		snapshot := GetPipelinedSnapshot()
+		queue, node := r.URL.Query().Get("queue"), r.URL.Query().Get("node")
+		if queue != "" || node != "" {
+			filtered := *snapshot
+			filtered.Tasks = []PipelinedTask{}
+			for _, task := range snapshot.Tasks {
+				if (queue == "" || task.Queue == queue) && (node == "" || task.Node == node) {
+					filtered.Tasks = append(filtered.Tasks, task)
+				}
+			}
+			snapshot = &filtered
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+			klog.Errorf("Failed to encode pipelined tasks: %v", err)
+		}
+	})
+}
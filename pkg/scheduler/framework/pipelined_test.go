@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func buildPipelinedJob(uid api.JobID, namespace, name, queue string, conditions []scheduling.PodGroupCondition, pipelinedNodes ...string) *api.JobInfo {
+	var tasks []*api.TaskInfo
+	for i, node := range pipelinedNodes {
+		pod := util.BuildPod(namespace, name+"-"+string(rune('a'+i)), "", v1.PodPending, api.BuildResourceList("2", "4Gi"), name, nil, nil)
+		task := api.NewTaskInfo(pod)
+		task.Status = api.Pipelined
+		task.NodeName = node
+		tasks = append(tasks, task)
+	}
+	job := api.NewJobInfo(uid, tasks...)
+	job.Namespace, job.Name, job.Queue = namespace, name, api.QueueID(queue)
+	job.PodGroup = &api.PodGroup{PodGroup: scheduling.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status:     scheduling.PodGroupStatus{Conditions: conditions},
+	}}
+	return job
+}
+
+func getCondition(job *api.JobInfo) *scheduling.PodGroupCondition {
+	for i, c := range job.PodGroup.Status.Conditions {
+		if c.Type == api.PodGroupPipelinedType {
+			return &job.PodGroup.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func TestRecordPipelined(t *testing.T) {
+	wasPipelined := []scheduling.PodGroupCondition{{Type: api.PodGroupPipelinedType, Status: v1.ConditionTrue}}
+	pipelined := buildPipelinedJob("ns1/j1", "ns1", "j1", "q1", nil, "n1", "n2")
+	released := buildPipelinedJob("ns1/j2", "ns1", "j2", "q1", wasPipelined)
+	other := buildPipelinedJob("ns2/j3", "ns2", "j3", "q2", nil)
+	ssn := &Session{
+		UID: "s1",
+		Jobs: map[api.JobID]*api.JobInfo{
+			pipelined.UID: pipelined,
+			released.UID:  released,
+			other.UID:     other,
+		},
+		Queues: map[api.QueueID]*api.QueueInfo{
+			"q1": {UID: "q1", Name: "q1"},
+			"q2": {UID: "q2", Name: "q2"},
+		},
+	}
+
+	recordPipelined(ssn)
+
+	if cond := getCondition(pipelined); cond == nil || cond.Status != v1.ConditionTrue || cond.Reason != api.WaitingForVictimsReason {
+		t.Errorf("expected the pipelined condition true, got %v", cond)
+	}
+	if cond := getCondition(released); cond == nil || cond.Status != v1.ConditionFalse || cond.Reason != api.NoPipelinedTasksReason {
+		t.Errorf("expected the pipelined condition turned false, got %v", cond)
+	}
+	if cond := getCondition(other); cond != nil {
+		t.Errorf("expected no pipelined condition added, got %v", cond)
+	}
+
+	snapshot := GetPipelinedSnapshot()
+	if snapshot.Session != "s1" || len(snapshot.Tasks) != 2 || len(snapshot.Nodes) != 2 {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+	if cpu := snapshot.Queues["q1"][v1.ResourceCPU]; cpu.Value() != 4 {
+		t.Errorf("expected 4 cpus pipelined in queue q1, got %v", cpu.String())
+	}
+
+	recorder := httptest.NewRecorder()
+	PipelinedHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/pipelined?node=n2", nil))
+	var served PipelinedSnapshot
+	if err := json.Unmarshal(recorder.Body.Bytes(), &served); err != nil {
+		t.Fatalf("failed to decode the response: %v", err)
+	}
+	if len(served.Tasks) != 1 || served.Tasks[0].Node != "n2" || served.Tasks[0].Job != "j1" {
+		t.Errorf("expected the task pipelined to n2, got %+v", served.Tasks)
+	}
+}
@@ -121,3 +121,25 @@ func GetAction(name string) (Action, bool) {
 	act, found := actionMap[name]
 	return act, found
 }
+
+// Score normalizer management
+var scoreNormalizerMap = map[string]ScoreNormalizer{
+	MinMaxScoreNormalizer: normalizeMinMax,
+}
+
+// RegisterScoreNormalizer register score normalizer
+func RegisterScoreNormalizer(name string, normalizer ScoreNormalizer) {
+	pluginMutex.Lock()
+	defer pluginMutex.Unlock()
+
+	scoreNormalizerMap[name] = normalizer
+}
+
+// GetScoreNormalizer get the score normalizer by name
+func GetScoreNormalizer(name string) (ScoreNormalizer, bool) {
+	pluginMutex.RLock()
+	defer pluginMutex.RUnlock()
+
+	normalizer, found := scoreNormalizerMap[name]
+	return normalizer, found
+}
@@ -24,6 +24,7 @@ package framework
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"sync"
@@ -46,6 +47,7 @@ import (
 	vcv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	vcclient "volcano.sh/apis/pkg/client/clientset/versioned"
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/audit"
 	"volcano.sh/volcano/pkg/scheduler/cache"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
@@ -61,6 +63,7 @@ type Session struct {
 	recorder        record.EventRecorder
 	cache           cache.Cache
 	restConfig      *rest.Config
+	stopCh          <-chan struct{}
 	informerFactory informers.SharedInformerFactory
 
 	TotalResource  *api.Resource
@@ -96,6 +99,14 @@ type Session struct {
 	RealNodesList             map[string][]*api.NodeInfo
 	HyperNodesReadyToSchedule bool
 
+	// currentAction is the name of the action being executed
+	currentAction string
+
+	// auditSink records the scheduling decisions of the session, they are not recorded if it is nil.
+	auditSink audit.Sink
+	// auditScores are the scores of the nodes the tasks are allocated or pipelined to by plugins, keyed by the task.
+	auditScores map[api.TaskID]map[string]float64
+
 	plugins             map[string]Plugin
 	eventHandlers       []*EventHandler
 	jobOrderFns         map[string]api.CompareFn
@@ -146,6 +157,7 @@ func openSession(cache cache.Cache) *Session {
 		kubeClient:      cache.Client(),
 		vcClient:        cache.VCClient(),
 		restConfig:      cache.ClientConfig(),
+		stopCh:          cache.StopCh(),
 		recorder:        cache.EventRecorder(),
 		cache:           cache,
 		informerFactory: cache.SharedInformerFactory(),
@@ -222,8 +234,7 @@ func openSession(cache cache.Cache) *Session {
 		ssn.TotalResource.Add(n.Allocatable)
 	}
 
-	klog.V(3).Infof("Open Session %v with <%d> Job and <%d> Queues",
-		ssn.UID, len(ssn.Jobs), len(ssn.Queues))
+	ssn.Logger().V(3).Info("Open session", "jobs", len(ssn.Jobs), "queues", len(ssn.Queues))
 
 	return ssn
 }
@@ -257,42 +268,40 @@ func addNodeSharableDeviceUsage(ssn *Session, task *api.TaskInfo) {
 	}
 }
 
-// updateQueueStatus updates allocated field in queue status on session close.
+// updateQueueStatus updates allocated field in queue status and the pending and pipelined resources of queue on
+// session close.
 func updateQueueStatus(ssn *Session) {
 	rootQueue := api.QueueID("root")
-	// calculate allocated resources on each queue
+	// calculate allocated, pending and pipelined resources on each queue
 	var allocatedResources = make(map[api.QueueID]*api.Resource, len(ssn.Queues))
+	var pendingResources = make(map[api.QueueID]*api.Resource, len(ssn.Queues))
+	var pipelinedResources = make(map[api.QueueID]*api.Resource, len(ssn.Queues))
 	for queueID := range ssn.Queues {
 		allocatedResources[queueID] = &api.Resource{}
+		pendingResources[queueID] = &api.Resource{}
+		pipelinedResources[queueID] = &api.Resource{}
 	}
 	for _, job := range ssn.Jobs {
 		for status, tasks := range job.TaskStatusIndex {
 			if api.AllocatedStatus(status) {
 				for _, task := range tasks {
 					addNodeSharableDeviceUsage(ssn, task)
-					allocatedResources[job.Queue].Add(task.Resreq)
-					// recursively updates the allocated resources of parent queues
-					queue := ssn.Queues[job.Queue].Queue
-					// compatibility unit testing
-					for ssn.Queues[rootQueue] != nil {
-						parent := string(rootQueue)
-						if queue.Spec.Parent != "" {
-							parent = queue.Spec.Parent
-						}
-						allocatedResources[api.QueueID(parent)].Add(task.Resreq)
-
-						if parent == string(rootQueue) {
-							break
-						}
-						queue = ssn.Queues[api.QueueID(queue.Spec.Parent)].Queue
-					}
+					addQueueResources(ssn, allocatedResources, job.Queue, task.Resreq)
+				}
+			} else if status == api.Pending {
+				for _, task := range tasks {
+					addQueueResources(ssn, pendingResources, job.Queue, task.Resreq)
+				}
+			} else if status == api.Pipelined {
+				for _, task := range tasks {
+					addQueueResources(ssn, pipelinedResources, job.Queue, task.Resreq)
 				}
 			}
 		}
 	}
 
 	// update queue status
-	for queueID := range ssn.Queues {
+	for queueID, queue := range ssn.Queues {
 		// convert api.Resource to v1.ResourceList
 		var queueStatus = util.ConvertRes2ResList(allocatedResources[queueID]).DeepCopy()
 		if queueID == rootQueue {
@@ -300,17 +309,61 @@ func updateQueueStatus(ssn *Session) {
 			continue
 		}
 
-		if equality.Semantic.DeepEqual(ssn.Queues[queueID].Queue.Status.Allocated, queueStatus) {
+		pending := util.ConvertRes2ResList(pendingResources[queueID]).DeepCopy()
+		pipelined := util.ConvertRes2ResList(pipelinedResources[queueID]).DeepCopy()
+		allocatedEqual := equality.Semantic.DeepEqual(queue.Queue.Status.Allocated, queueStatus)
+		pendingEqual := equality.Semantic.DeepEqual(queue.PendingResources(), pending)
+		pipelinedEqual := equality.Semantic.DeepEqual(queue.PipelinedResources(), pipelined)
+		if allocatedEqual && pendingEqual && pipelinedEqual {
 			klog.V(5).Infof("Queue <%s> allocated resource keeps equal, no need to update queue status <%v>.",
-				queueID, ssn.Queues[queueID].Queue.Status.Allocated)
+				queueID, queue.Queue.Status.Allocated)
 			continue
 		}
 
-		ssn.Queues[queueID].Queue.Status.Allocated = queueStatus
+		queue.Queue.Status.Allocated = queueStatus
+		if !pendingEqual || !pipelinedEqual {
+			pendingData, err := json.Marshal(pending)
+			if err != nil {
+				klog.Errorf("failed to marshal pending resources of queue <%s>: %v", queue.Name, err)
+				continue
+			}
+			pipelinedData, err := json.Marshal(pipelined)
+			if err != nil {
+				klog.Errorf("failed to marshal pipelined resources of queue <%s>: %v", queue.Name, err)
+				continue
+			}
+			annotations := make(map[string]string, len(queue.Queue.Annotations)+2)
+			for k, v := range queue.Queue.Annotations {
+				annotations[k] = v
+			}
+			annotations[api.QueuePendingResources] = string(pendingData)
+			annotations[api.QueuePipelinedResources] = string(pipelinedData)
+			queue.Queue.Annotations = annotations
+		}
+
+		if err := ssn.cache.UpdateQueueStatus(queue); err != nil {
+			klog.Errorf("failed to update queue <%s> status: %s", queue.Name, err.Error())
+		}
+	}
+}
+
+// addQueueResources adds the resources to the queue and recursively to its parent queues.
+func addQueueResources(ssn *Session, resources map[api.QueueID]*api.Resource, queueID api.QueueID, res *api.Resource) {
+	rootQueue := api.QueueID("root")
+	resources[queueID].Add(res)
+	queue := ssn.Queues[queueID].Queue
+	// compatibility unit testing
+	for ssn.Queues[rootQueue] != nil {
+		parent := string(rootQueue)
+		if queue.Spec.Parent != "" {
+			parent = queue.Spec.Parent
+		}
+		resources[api.QueueID(parent)].Add(res)
 
-		if err := ssn.cache.UpdateQueueStatus(ssn.Queues[queueID]); err != nil {
-			klog.Errorf("failed to update queue <%s> status: %s", ssn.Queues[queueID].Name, err.Error())
+		if parent == string(rootQueue) {
+			break
 		}
+		queue = ssn.Queues[api.QueueID(queue.Spec.Parent)].Queue
 	}
 }
 
@@ -347,7 +400,8 @@ func updateRootQueueResources(ssn *Session, allocated v1.ResourceList) {
 
 	if !equality.Semantic.DeepEqual(queue.Status.Allocated, allocated) {
 		queue.Status.Allocated = allocated
-		_, err = ssn.VCClient().SchedulingV1beta1().Queues().UpdateStatus(context.TODO(), queue, metav1.UpdateOptions{})
+		_, err = ssn.VCClient().SchedulingV1beta1().Queues().ApplyStatus(context.TODO(),
+			cache.QueueStatusApplyConfiguration(queue), metav1.ApplyOptions{FieldManager: cache.FieldManager, Force: true})
 		if err != nil {
 			klog.Errorf("failed to update root queue status: %s", err.Error())
 			return
@@ -356,10 +410,13 @@ func updateRootQueueResources(ssn *Session, allocated v1.ResourceList) {
 }
 
 func closeSession(ssn *Session) {
+	recordPipelined(ssn)
+
 	ju := NewJobUpdater(ssn)
 	ju.UpdateAll()
 
 	updateQueueStatus(ssn)
+	updateQueueUsageMetrics(ssn)
 
 	ssn.Jobs = nil
 	ssn.Nodes = nil
@@ -372,7 +429,7 @@ func closeSession(ssn *Session) {
 	ssn.NodeList = nil
 	ssn.TotalResource = nil
 
-	klog.V(3).Infof("Close Session %v", ssn.UID)
+	ssn.Logger().V(3).Info("Close session")
 }
 
 func getPodGroupPhase(jobInfo *api.JobInfo, unschedulable bool) scheduling.PodGroupPhase {
@@ -513,29 +570,26 @@ func (ssn *Session) Pipeline(task *api.TaskInfo, hostname string) error {
 	job, found := ssn.Jobs[task.Job]
 	if found {
 		if err := job.UpdateTaskStatus(task, api.Pipelined); err != nil {
-			klog.Errorf("Failed to update task <%v/%v> status to %v when pipeline in Session <%v>: %v",
-				task.Namespace, task.Name, api.Pipelined, ssn.UID, err)
+			ssn.TaskLogger(task).Error(err, "Failed to update task status when pipeline", "status", api.Pipelined)
 			return err
 		}
 	} else {
-		klog.Errorf("Failed to find Job <%s> in Session <%s> index when pipeline.",
-			task.Job, ssn.UID)
+		ssn.Logger().Error(nil, "Failed to find job in session index when pipeline", "job", task.Job)
 		return fmt.Errorf("failed to find job %s when pipeline", task.Job)
 	}
 
 	task.NodeName = hostname
 
 	if node, found := ssn.Nodes[hostname]; found {
+		ssn.recordNodeScores(task, node)
 		if err := node.AddTask(task); err != nil {
-			klog.Errorf("Failed to add task <%v/%v> to node <%v> when pipeline in Session <%v>: %v",
-				task.Namespace, task.Name, hostname, ssn.UID, err)
+			ssn.TaskLogger(task).Error(err, "Failed to add task to node when pipeline", "node", hostname)
 			return err
 		}
-		klog.V(3).Infof("After pipelined Task <%v/%v> to Node <%v>: idle <%v>, used <%v>, releasing <%v>",
-			task.Namespace, task.Name, node.Name, node.Idle, node.Used, node.Releasing)
+		ssn.TaskLogger(task).V(3).Info("Pipelined task to node", "node", node.Name,
+			"idle", node.Idle, "used", node.Used, "releasing", node.Releasing)
 	} else {
-		klog.Errorf("Failed to find Node <%s> in Session <%s> index when pipeline.",
-			hostname, ssn.UID)
+		ssn.TaskLogger(task).Error(nil, "Failed to find node in session index when pipeline", "node", hostname)
 		return fmt.Errorf("failed to find node %s", hostname)
 	}
 
@@ -546,6 +600,7 @@ func (ssn *Session) Pipeline(task *api.TaskInfo, hostname string) error {
 			})
 		}
 	}
+	ssn.recordDecision(audit.DecisionPipeline, task, "", nil)
 
 	return nil
 }
@@ -559,29 +614,26 @@ func (ssn *Session) Allocate(task *api.TaskInfo, nodeInfo *api.NodeInfo) (err er
 	job, found := ssn.Jobs[task.Job]
 	if found {
 		if err := job.UpdateTaskStatus(task, api.Allocated); err != nil {
-			klog.Errorf("Failed to update task <%v/%v> status to %v when binding in Session <%v>: %v",
-				task.Namespace, task.Name, api.Allocated, ssn.UID, err)
+			ssn.TaskLogger(task).Error(err, "Failed to update task status when binding", "status", api.Allocated)
 			return err
 		}
 	} else {
-		klog.Errorf("Failed to find Job <%s> in Session <%s> index when binding.",
-			task.Job, ssn.UID)
+		ssn.Logger().Error(nil, "Failed to find job in session index when binding", "job", task.Job)
 		return fmt.Errorf("failed to find job %s", task.Job)
 	}
 
 	task.NodeName = hostname
 
 	if node, found := ssn.Nodes[hostname]; found {
+		ssn.recordNodeScores(task, node)
 		if err := node.AddTask(task); err != nil {
-			klog.Errorf("Failed to add task <%v/%v> to node <%v>  when binding in Session <%v>: %v",
-				task.Namespace, task.Name, hostname, ssn.UID, err)
+			ssn.TaskLogger(task).Error(err, "Failed to add task to node when binding", "node", hostname)
 			return err
 		}
-		klog.V(3).Infof("After allocated Task <%v/%v> to Node <%v>: idle <%v>, used <%v>, releasing <%v>",
-			task.Namespace, task.Name, node.Name, node.Idle, node.Used, node.Releasing)
+		ssn.TaskLogger(task).V(3).Info("Allocated task to node", "node", node.Name,
+			"idle", node.Idle, "used", node.Used, "releasing", node.Releasing)
 	} else {
-		klog.Errorf("Failed to find Node <%s> in Session <%s> index when binding.",
-			hostname, ssn.UID)
+		ssn.TaskLogger(task).Error(nil, "Failed to find node in session index when binding", "node", hostname)
 		return fmt.Errorf("failed to find node %s", hostname)
 	}
 
@@ -597,8 +649,7 @@ func (ssn *Session) Allocate(task *api.TaskInfo, nodeInfo *api.NodeInfo) (err er
 	if ssn.JobReady(job) {
 		for _, task := range job.TaskStatusIndex[api.Allocated] {
 			if err := ssn.dispatch(task); err != nil {
-				klog.Errorf("Failed to dispatch task <%v/%v>: %v",
-					task.Namespace, task.Name, err)
+				ssn.TaskLogger(task).Error(err, "Failed to dispatch task")
 				return err
 			}
 		}
@@ -616,15 +667,14 @@ func (ssn *Session) dispatch(task *api.TaskInfo) error {
 	// Update status in session
 	if job, found := ssn.Jobs[task.Job]; found {
 		if err := job.UpdateTaskStatus(task, api.Binding); err != nil {
-			klog.Errorf("Failed to update task <%v/%v> status to %v when binding in Session <%v>: %v",
-				task.Namespace, task.Name, api.Binding, ssn.UID, err)
+			ssn.TaskLogger(task).Error(err, "Failed to update task status when binding", "status", api.Binding)
 			return err
 		}
 	} else {
-		klog.Errorf("Failed to find Job <%s> in Session <%s> index when binding.",
-			task.Job, ssn.UID)
+		ssn.Logger().Error(nil, "Failed to find job in session index when binding", "job", task.Job)
 		return fmt.Errorf("failed to find job %s", task.Job)
 	}
+	ssn.recordDecision(audit.DecisionBind, task, "", nil)
 
 	metrics.UpdateTaskScheduleDuration(metrics.Duration(task.Pod.CreationTimestamp.Time))
 	return nil
@@ -691,21 +741,18 @@ func (ssn *Session) Evict(reclaimee *api.TaskInfo, reason string) error {
 	job, found := ssn.Jobs[reclaimee.Job]
 	if found {
 		if err := job.UpdateTaskStatus(reclaimee, api.Releasing); err != nil {
-			klog.Errorf("Failed to update task <%v/%v> status to %v when evicting in Session <%v>: %v",
-				reclaimee.Namespace, reclaimee.Name, api.Releasing, ssn.UID, err)
+			ssn.TaskLogger(reclaimee).Error(err, "Failed to update task status when evicting", "status", api.Releasing)
 			return err
 		}
 	} else {
-		klog.Errorf("Failed to find Job <%s> in Session <%s> index when evicting.",
-			reclaimee.Job, ssn.UID)
+		ssn.Logger().Error(nil, "Failed to find job in session index when evicting", "job", reclaimee.Job)
 		return fmt.Errorf("failed to find job %s", reclaimee.Job)
 	}
 
 	// Update task in node.
 	if node, found := ssn.Nodes[reclaimee.NodeName]; found {
 		if err := node.UpdateTask(reclaimee); err != nil {
-			klog.Errorf("Failed to update task <%v/%v> in Session <%v>: %v",
-				reclaimee.Namespace, reclaimee.Name, ssn.UID, err)
+			ssn.TaskLogger(reclaimee).Error(err, "Failed to update task on node when evicting", "node", reclaimee.NodeName)
 			return err
 		}
 	}
@@ -717,6 +764,7 @@ func (ssn *Session) Evict(reclaimee *api.TaskInfo, reason string) error {
 			})
 		}
 	}
+	ssn.recordDecision(audit.DecisionEvict, reclaimee, reason, nil)
 
 	return nil
 }
@@ -751,6 +799,23 @@ func (ssn *Session) UpdatePodGroupCondition(jobInfo *api.JobInfo, cond *scheduli
 	return nil
 }
 
+// UpdatePodGroupInqueue moves the podgroup of the job into Inqueue, and records the admission in its Inqueue
+// condition. The condition is only written back when it changes, so it keeps the time of the first admission.
+func (ssn *Session) UpdatePodGroupInqueue(jobInfo *api.JobInfo) {
+	jobInfo.PodGroup.Status.Phase = scheduling.PodGroupInqueue
+	cond := &scheduling.PodGroupCondition{
+		Type:               api.PodGroupInqueueType,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		TransitionID:       string(ssn.UID),
+		Reason:             "Enqueued",
+		Message:            fmt.Sprintf("podgroup is admitted by queue <%s>", jobInfo.Queue),
+	}
+	if err := ssn.UpdatePodGroupCondition(jobInfo, cond); err != nil {
+		klog.Errorf("Failed to update job <%s/%s> condition: %v", jobInfo.Namespace, jobInfo.Name, err)
+	}
+}
+
 // AddEventHandler add event handlers
 func (ssn *Session) AddEventHandler(eh *EventHandler) {
 	ssn.eventHandlers = append(ssn.eventHandlers, eh)
@@ -776,11 +841,27 @@ func (ssn *Session) ClientConfig() *rest.Config {
 	return ssn.restConfig
 }
 
+// StopCh returns the stop channel of the scheduler, the informers started by plugins stop with it
+func (ssn *Session) StopCh() <-chan struct{} {
+	return ssn.stopCh
+}
+
 // InformerFactory returns the scheduler ShareInformerFactory
 func (ssn *Session) InformerFactory() informers.SharedInformerFactory {
 	return ssn.informerFactory
 }
 
+// SetCurrentAction sets the name of the action being executed, the node order scores of plugins
+// are weighted by the weights configured for the action.
+func (ssn *Session) SetCurrentAction(name string) {
+	ssn.currentAction = name
+}
+
+// CurrentAction returns the name of the action being executed
+func (ssn *Session) CurrentAction() string {
+	return ssn.currentAction
+}
+
 // RecordPodGroupEvent records podGroup events
 func (ssn *Session) RecordPodGroupEvent(podGroup *api.PodGroup, eventType, reason, msg string) {
 	if podGroup == nil {
@@ -23,11 +23,13 @@ package framework
 import (
 	"context"
 
+	"k8s.io/klog/v2"
 	k8sframework "k8s.io/kubernetes/pkg/scheduler/framework"
 
 	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
@@ -599,6 +601,22 @@ func (ssn *Session) ClusterOrderFn(l, r interface{}) bool {
 
 // QueueOrderFn invoke queueorder function of the plugins
 func (ssn *Session) QueueOrderFn(l, r interface{}) bool {
+	if j := ssn.CompareQueueOrder(l, r); j != 0 {
+		return j < 0
+	}
+
+	// If no queue order funcs, order queue by CreationTimestamp first, then by UID.
+	lv := l.(*api.QueueInfo)
+	rv := r.(*api.QueueInfo)
+	if lv.Queue.CreationTimestamp.Equal(&rv.Queue.CreationTimestamp) {
+		return lv.UID < rv.UID
+	}
+	return lv.Queue.CreationTimestamp.Before(&rv.Queue.CreationTimestamp)
+}
+
+// CompareQueueOrder invoke queueorder function of the plugins, it returns 0 if the queues are tied by all the
+// plugins, e.g. at the same share level, rather than ordering them by their creation time as QueueOrderFn does.
+func (ssn *Session) CompareQueueOrder(l, r interface{}) int {
 	for _, tier := range ssn.Tiers {
 		for _, plugin := range tier.Plugins {
 			if !isEnabled(plugin.EnabledQueueOrder) {
@@ -609,18 +627,11 @@ func (ssn *Session) QueueOrderFn(l, r interface{}) bool {
 				continue
 			}
 			if j := qof(l, r); j != 0 {
-				return j < 0
+				return j
 			}
 		}
 	}
-
-	// If no queue order funcs, order queue by CreationTimestamp first, then by UID.
-	lv := l.(*api.QueueInfo)
-	rv := r.(*api.QueueInfo)
-	if lv.Queue.CreationTimestamp.Equal(&rv.Queue.CreationTimestamp) {
-		return lv.UID < rv.UID
-	}
-	return lv.Queue.CreationTimestamp.Before(&rv.Queue.CreationTimestamp)
+	return 0
 }
 
 // VictimQueueOrderFn invoke victimqueueorder function of the plugins
@@ -828,7 +839,7 @@ func (ssn *Session) NodeOrderFn(task *api.TaskInfo, node *api.NodeInfo) (float64
 			if err != nil {
 				return 0, err
 			}
-			priorityScore += score
+			priorityScore += score * ssn.scoreWeight(plugin)
 		}
 	}
 	return priorityScore, nil
@@ -850,8 +861,10 @@ func (ssn *Session) BatchNodeOrderFn(task *api.TaskInfo, nodes []*api.NodeInfo)
 			if err != nil {
 				return nil, err
 			}
+			ssn.normalizeScores(plugin, score)
+			weight := ssn.scoreWeight(plugin)
 			for nodeName, score := range score {
-				priorityScore[nodeName] += score
+				priorityScore[nodeName] += score * weight
 			}
 		}
 	}
@@ -862,6 +875,30 @@ func isEnabled(enabled *bool) bool {
 	return enabled != nil && *enabled
 }
 
+// scoreWeight returns the weight of the node order scores of the plugin in the current action.
+func (ssn *Session) scoreWeight(plugin conf.PluginOption) float64 {
+	if weight, found := plugin.ActionWeights[ssn.currentAction]; found {
+		return float64(weight)
+	}
+	if plugin.Weight != nil {
+		return float64(*plugin.Weight)
+	}
+	return 1
+}
+
+// normalizeScores normalizes the node order scores of the plugin in place with its score normalizer.
+func (ssn *Session) normalizeScores(plugin conf.PluginOption, scores map[string]float64) {
+	if plugin.ScoreNormalizer == "" {
+		return
+	}
+	normalizer, found := GetScoreNormalizer(plugin.ScoreNormalizer)
+	if !found {
+		klog.Errorf("Failed to find score normalizer %s of plugin %s", plugin.ScoreNormalizer, plugin.Name)
+		return
+	}
+	normalizer(scores)
+}
+
 // NodeOrderMapFn invoke node order function of the plugins
 func (ssn *Session) NodeOrderMapFn(task *api.TaskInfo, node *api.NodeInfo) (map[string]float64, float64, error) {
 	nodeScoreMap := map[string]float64{}
@@ -876,7 +913,7 @@ func (ssn *Session) NodeOrderMapFn(task *api.TaskInfo, node *api.NodeInfo) (map[
 				if err != nil {
 					return nodeScoreMap, priorityScore, err
 				}
-				priorityScore += score
+				priorityScore += score * ssn.scoreWeight(plugin)
 			}
 			if pfn, found := ssn.nodeMapFns[plugin.Name]; found {
 				score, err := pfn(task, node)
@@ -928,8 +965,14 @@ func (ssn *Session) NodeOrderReduceFn(task *api.TaskInfo, pluginNodeScoreMap map
 			if err := pfn(task, pluginNodeScoreMap[plugin.Name]); err != nil {
 				return nodeScoreMap, err
 			}
+			scores := make(map[string]float64, len(pluginNodeScoreMap[plugin.Name]))
 			for _, hp := range pluginNodeScoreMap[plugin.Name] {
-				nodeScoreMap[hp.Name] += float64(hp.Score)
+				scores[hp.Name] += float64(hp.Score)
+			}
+			ssn.normalizeScores(plugin, scores)
+			weight := ssn.scoreWeight(plugin)
+			for name, score := range scores {
+				nodeScoreMap[name] += score * weight
 			}
 		}
 	}
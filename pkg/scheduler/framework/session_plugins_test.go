@@ -22,10 +22,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 
 	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
@@ -136,3 +138,85 @@ func TestFilterOutPreemptMayNotHelpNodes(t *testing.T) {
 		})
 	}
 }
+
+func TestWeightedNodeOrderFn(t *testing.T) {
+	nodes := []*api.NodeInfo{{Name: "n1"}, {Name: "n2"}, {Name: "n3"}}
+	binpackScores := map[string]float64{"n1": 10, "n2": 20, "n3": 30}
+	spreadScores := map[string]float64{"n1": 3, "n2": 2, "n3": 1}
+
+	tests := []struct {
+		name     string
+		action   string
+		binpack  conf.PluginOption
+		expected map[string]float64
+	}{
+		{
+			name:     "weight is 1 if not set",
+			action:   "allocate",
+			binpack:  conf.PluginOption{Name: "binpack"},
+			expected: map[string]float64{"n1": 13, "n2": 22, "n3": 31},
+		},
+		{
+			name:     "weight of plugin",
+			action:   "allocate",
+			binpack:  conf.PluginOption{Name: "binpack", Weight: ptr.To(2)},
+			expected: map[string]float64{"n1": 23, "n2": 42, "n3": 61},
+		},
+		{
+			name:     "weight of action overrides weight of plugin",
+			action:   "backfill",
+			binpack:  conf.PluginOption{Name: "binpack", Weight: ptr.To(2), ActionWeights: map[string]int{"backfill": 0}},
+			expected: map[string]float64{"n1": 3, "n2": 2, "n3": 1},
+		},
+		{
+			name:     "normalized before weighted",
+			action:   "allocate",
+			binpack:  conf.PluginOption{Name: "binpack", Weight: ptr.To(2), ScoreNormalizer: MinMaxScoreNormalizer},
+			expected: map[string]float64{"n1": 3, "n2": 102, "n3": 201},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.binpack.EnabledNodeOrder = ptr.To(true)
+			spread := conf.PluginOption{Name: "spread", EnabledNodeOrder: ptr.To(true)}
+			ssn := &Session{
+				Tiers: []conf.Tier{{Plugins: []conf.PluginOption{tt.binpack, spread}}},
+				batchNodeOrderFns: map[string]api.BatchNodeOrderFn{
+					"binpack": func(*api.TaskInfo, []*api.NodeInfo) (map[string]float64, error) {
+						scores := map[string]float64{}
+						for name, score := range binpackScores {
+							scores[name] = score
+						}
+						return scores, nil
+					},
+				},
+				nodeOrderFns: map[string]api.NodeOrderFn{
+					"spread": func(_ *api.TaskInfo, node *api.NodeInfo) (float64, error) {
+						return spreadScores[node.Name], nil
+					},
+				},
+			}
+			ssn.SetCurrentAction(tt.action)
+
+			scores, err := ssn.BatchNodeOrderFn(&api.TaskInfo{}, nodes)
+			assert.NoError(t, err)
+			for _, node := range nodes {
+				score, err := ssn.NodeOrderFn(&api.TaskInfo{}, node)
+				assert.NoError(t, err)
+				scores[node.Name] += score
+			}
+			assert.Equal(t, tt.expected, scores)
+		})
+	}
+}
+
+func TestNormalizeMinMax(t *testing.T) {
+	scores := map[string]float64{"n1": -5, "n2": 0, "n3": 15}
+	normalizeMinMax(scores)
+	assert.Equal(t, map[string]float64{"n1": 0, "n2": 25, "n3": 100}, scores)
+
+	equal := map[string]float64{"n1": 7, "n2": 7}
+	normalizeMinMax(equal)
+	assert.Equal(t, map[string]float64{"n1": 0, "n2": 0}, equal)
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/audit"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
 )
 
@@ -174,6 +175,7 @@ func (s *Statement) Pipeline(task *api.TaskInfo, hostname string, evictionOccurr
 	task.EvictionOccurred = evictionOccurred
 
 	if node, found := s.ssn.Nodes[hostname]; found {
+		s.ssn.recordNodeScores(task, node)
 		if err := node.AddTask(task); err != nil {
 			klog.Errorf("Failed to add task <%v/%v> to node <%v> when pipeline in Session <%v>: %v",
 				task.Namespace, task.Name, hostname, s.ssn.UID, err)
@@ -282,6 +284,7 @@ func (s *Statement) Allocate(task *api.TaskInfo, nodeInfo *api.NodeInfo) (err er
 
 	task.NodeName = hostname
 	if node, found := s.ssn.Nodes[hostname]; found {
+		s.ssn.recordNodeScores(task, node)
 		if err := node.AddTask(task); err != nil {
 			klog.Errorf("Failed to add task <%v/%v> to node <%v> when allocating in Session <%v>: %v",
 				task.Namespace, task.Name, hostname, s.ssn.UID, err)
@@ -417,6 +420,7 @@ func (s *Statement) Discard() {
 // Commit operation for evict and pipeline
 func (s *Statement) Commit() {
 	klog.V(3).Info("Committing operations ...")
+	victims := evictedVictims(s.operations)
 	for _, op := range s.operations {
 		op.task.ClearLastTxContext()
 		switch op.name {
@@ -424,9 +428,12 @@ func (s *Statement) Commit() {
 			err := s.evict(op.task, op.reason)
 			if err != nil {
 				klog.Errorf("Failed to evict task: %s", err.Error())
+			} else {
+				s.ssn.recordDecision(audit.DecisionEvict, op.task, op.reason, nil)
 			}
 		case Pipeline:
 			s.pipeline(op.task)
+			s.ssn.recordDecision(audit.DecisionPipeline, op.task, "", victims[op.task.NodeName])
 		case Allocate:
 			err := s.allocate(op.task)
 			if err != nil {
@@ -434,6 +441,8 @@ func (s *Statement) Commit() {
 					klog.Errorf("Failed to unallocate task <%v/%v>: %v.", op.task.Namespace, op.task.Name, e)
 				}
 				klog.Errorf("Failed to allocate task <%v/%v>: %v.", op.task.Namespace, op.task.Name, err)
+			} else {
+				s.ssn.recordDecision(audit.DecisionBind, op.task, "", victims[op.task.NodeName])
 			}
 		}
 	}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+// UserLabelKey is the label (or annotation) on the podgroup which identifies the user
+// a job is charged to, it is exported as the user label of the queue usage metrics.
+const UserLabelKey = "volcano.sh/user"
+
+// getJobUser returns the user the job is charged to, labels take precedence over annotations.
+func getJobUser(job *api.JobInfo) string {
+	if job.PodGroup == nil {
+		return ""
+	}
+	if user, found := job.PodGroup.Labels[UserLabelKey]; found {
+		return user
+	}
+	return job.PodGroup.Annotations[UserLabelKey]
+}
+
+// buildQueueUsage aggregates the allocated and requested resources of jobs by queue, namespace and user.
+func buildQueueUsage(jobs map[api.JobID]*api.JobInfo) map[metrics.UsageKey]*metrics.Usage {
+	type usageSum struct {
+		allocated *api.Resource
+		request   *api.Resource
+	}
+	sums := make(map[metrics.UsageKey]*usageSum)
+	for _, job := range jobs {
+		key := metrics.UsageKey{Queue: string(job.Queue), Namespace: job.Namespace, User: getJobUser(job)}
+		sum, found := sums[key]
+		if !found {
+			sum = &usageSum{allocated: api.EmptyResource(), request: api.EmptyResource()}
+			sums[key] = sum
+		}
+		sum.allocated.Add(job.Allocated)
+		sum.request.Add(job.TotalRequest)
+	}
+
+	usages := make(map[metrics.UsageKey]*metrics.Usage, len(sums))
+	for key, sum := range sums {
+		allocated, request := sum.allocated, sum.request
+		usages[key] = &metrics.Usage{
+			AllocatedMilliCPU:        allocated.MilliCPU,
+			AllocatedMemory:          allocated.Memory,
+			AllocatedScalarResources: allocated.ScalarResources,
+			RequestMilliCPU:          request.MilliCPU,
+			RequestMemory:            request.Memory,
+			RequestScalarResources:   request.ScalarResources,
+		}
+	}
+	return usages
+}
+
+// updateQueueUsageMetrics exports the queue usage broken down by namespace and user, together
+// with the queue hierarchy, so that chargeback does not need to aggregate pods by itself.
+func updateQueueUsageMetrics(ssn *Session) {
+	metrics.UpdateQueueUsage(buildQueueUsage(ssn.Jobs))
+
+	parents := make(map[string]string, len(ssn.Queues))
+	for _, queue := range ssn.Queues {
+		if queue.Queue == nil || queue.Queue.Spec.Parent == "" {
+			continue
+		}
+		parents[queue.Name] = queue.Queue.Spec.Parent
+	}
+	metrics.UpdateQueueParents(parents)
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package framework
 
 import (
+	"math"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
@@ -262,3 +264,29 @@ func (nl *NodeLister) List() ([]*v1.Node, error) {
 	}
 	return nodes, nil
 }
+
+// MinMaxScoreNormalizer is the name of the normalizer scaling the scores into [0, MaxNodeScore].
+const MinMaxScoreNormalizer = "minmax"
+
+// ScoreNormalizer normalizes the node scores of a plugin in place, the key of scores is the node name.
+type ScoreNormalizer func(scores map[string]float64)
+
+// normalizeMinMax scales the scores linearly, the lowest score becomes 0 and the highest one becomes MaxNodeScore.
+// All scores become 0 if they are equal.
+func normalizeMinMax(scores map[string]float64) {
+	if len(scores) == 0 {
+		return
+	}
+	lowest, highest := math.MaxFloat64, -math.MaxFloat64
+	for _, score := range scores {
+		lowest = math.Min(lowest, score)
+		highest = math.Max(highest, score)
+	}
+	for name, score := range scores {
+		if highest == lowest {
+			scores[name] = 0
+			continue
+		}
+		scores[name] = (score - lowest) * float64(k8sframework.MaxNodeScore) / (highest - lowest)
+	}
+}
@@ -37,6 +37,14 @@ var (
 			Help:      "Number of retry counts for one job",
 		}, []string{"job_id"},
 	)
+
+	gangThrashCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "gang_thrash_counts",
+			Help:      "Number of sessions in which the tasks of one gang were partially allocated and released",
+		}, []string{"job_id"},
+	)
 )
 
 // UpdateJobShare records share for one job
@@ -49,6 +57,11 @@ func RegisterJobRetries(jobID string) {
 	jobRetryCount.WithLabelValues(jobID).Inc()
 }
 
+// RegisterGangThrash total number of sessions in which the gang was partially allocated and released.
+func RegisterGangThrash(jobID string) {
+	gangThrashCount.WithLabelValues(jobID).Inc()
+}
+
 // DeleteJobMetrics delete all metrics related to the job
 func DeleteJobMetrics(jobName, queue, namespace string) {
 	e2eJobSchedulingDuration.DeleteLabelValues(jobName, queue, namespace)
@@ -57,4 +70,5 @@ func DeleteJobMetrics(jobName, queue, namespace string) {
 	unscheduleTaskCount.DeleteLabelValues(jobName)
 	jobShare.DeleteLabelValues(namespace, jobName)
 	jobRetryCount.DeleteLabelValues(jobName)
+	gangThrashCount.DeleteLabelValues(jobName)
 }
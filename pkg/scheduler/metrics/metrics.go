@@ -133,6 +133,14 @@ var (
 		},
 	)
 
+	placementRecordsDropped = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "placement_records_dropped_total",
+			Help:      "Total placement records dropped because the queue of the placement store is full",
+		},
+	)
+
 	unscheduleTaskCount = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: VolcanoSubSystemName,
@@ -214,6 +222,11 @@ func RegisterPreemptionAttempts() {
 	preemptionAttempts.Inc()
 }
 
+// RegisterPlacementRecordDropped records the placement record dropped by the placement recorder
+func RegisterPlacementRecordDropped() {
+	placementRecordsDropped.Inc()
+}
+
 // UpdateUnscheduleTaskCount records total number of unscheduleable tasks
 func UpdateUnscheduleTaskCount(jobID string, taskCount int) {
 	unscheduleTaskCount.WithLabelValues(jobID).Set(float64(taskCount))
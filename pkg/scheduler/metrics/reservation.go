@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto" // auto-registry collectors in default registry
+	v1 "k8s.io/api/core/v1"
+)
+
+var (
+	reservationUnusedMilliCPU = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "reservation_unused_milli_cpu",
+			Help:      "Reserved but unused CPU count for one reservation",
+		}, []string{"reservation_name", "queue_name"},
+	)
+
+	reservationUnusedMemory = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "reservation_unused_memory_bytes",
+			Help:      "Reserved but unused memory for one reservation",
+		}, []string{"reservation_name", "queue_name"},
+	)
+
+	reservationUnusedScalarResource = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "reservation_unused_scalar_resources",
+			Help:      "Reserved but unused scalar resources for one reservation",
+		}, []string{"reservation_name", "queue_name", "resource"},
+	)
+)
+
+// UpdateReservationUnused records reserved but unused resources for one reservation
+func UpdateReservationUnused(reservationName, queueName string, milliCPU, memory float64, scalarResources map[v1.ResourceName]float64) {
+	reservationUnusedMilliCPU.WithLabelValues(reservationName, queueName).Set(milliCPU)
+	reservationUnusedMemory.WithLabelValues(reservationName, queueName).Set(memory)
+	for resource, quant := range scalarResources {
+		reservationUnusedScalarResource.WithLabelValues(reservationName, queueName, string(resource)).Set(quant)
+	}
+}
+
+// DeleteReservationMetrics delete all metrics related to the reservation
+func DeleteReservationMetrics(reservationName string) {
+	partialLabelMap := map[string]string{"reservation_name": reservationName}
+	reservationUnusedMilliCPU.DeletePartialMatch(partialLabelMap)
+	reservationUnusedMemory.DeletePartialMatch(partialLabelMap)
+	reservationUnusedScalarResource.DeletePartialMatch(partialLabelMap)
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto" // auto-registry collectors in default registry
+)
+
+var (
+	slaViolatedJobs = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "sla_violated_jobs",
+			Help:      "Number of pending jobs waiting longer than their sla waiting time in one queue",
+		}, []string{"queue_name"},
+	)
+)
+
+// UpdateSLAViolatedJobs records the number of pending jobs violating sla in one queue
+func UpdateSLAViolatedJobs(queueName string, count int) {
+	slaViolatedJobs.WithLabelValues(queueName).Set(float64(count))
+}
@@ -30,6 +30,7 @@ const (
 	Metrics_Type_Prometheus_Adaptor = "prometheus_adaptor"
 	Metrics_Tpye_Prometheus         = "prometheus"
 	Metrics_Type_Elasticsearch      = "elasticsearch"
+	Metrics_Type_Metrics_Server     = "metrics_server"
 )
 
 type NodeMetrics struct {
@@ -51,9 +52,11 @@ func NewMetricsClient(restConfig *rest.Config, metricsConf map[string]string) (M
 		return NewPrometheusMetricsClient(metricsConf)
 	} else if metricsType == Metrics_Type_Prometheus_Adaptor {
 		return NewCustomMetricsClient(restConfig)
+	} else if metricsType == Metrics_Type_Metrics_Server {
+		return NewMetricsServerClient(restConfig)
 	} else {
 		return nil, fmt.Errorf("data cannot be collected from the %s monitoring system. "+
-			"The supported monitoring systems are %s, %s, and %s",
-			metricsType, Metrics_Type_Elasticsearch, Metrics_Tpye_Prometheus, Metrics_Type_Prometheus_Adaptor)
+			"The supported monitoring systems are %s, %s, %s, and %s",
+			metricsType, Metrics_Type_Elasticsearch, Metrics_Tpye_Prometheus, Metrics_Type_Prometheus_Adaptor, Metrics_Type_Metrics_Server)
 	}
 }
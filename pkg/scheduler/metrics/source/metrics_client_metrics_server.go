@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// MetricsServerClient gets the usage of the nodes from the metrics.k8s.io api served by metrics-server. The usage is
+// averaged over the window of metrics-server, which is much shorter than the period of the other metrics systems.
+type MetricsServerClient struct {
+	kubeClient    kubernetes.Interface
+	metricsClient metricsclient.Interface
+}
+
+var metricsServerClient *MetricsServerClient
+
+func NewMetricsServerClient(cfg *rest.Config) (*MetricsServerClient, error) {
+	if metricsServerClient != nil {
+		return metricsServerClient, nil
+	}
+
+	klog.V(3).Infof("Create metrics server api client")
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	metricsClient, err := metricsclient.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	metricsServerClient = &MetricsServerClient{
+		kubeClient:    kubeClient,
+		metricsClient: metricsClient,
+	}
+	return metricsServerClient, nil
+}
+
+func (ms *MetricsServerClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	klog.V(5).Infof("Get node metrics from metrics server")
+
+	metricsList, err := ms.metricsClient.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		klog.Errorf("Failed to list node metrics from metrics server, error is: %v.", err)
+		return err
+	}
+	nodeList, err := ms.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+	if err != nil {
+		klog.Errorf("Failed to list nodes, error is: %v.", err)
+		return err
+	}
+
+	setNodesUsage(nodeMetricsMap, metricsList.Items, nodeList.Items)
+	return nil
+}
+
+// setNodesUsage sets the usage of the nodes as the percentage of the used resources to the allocatable resources.
+func setNodesUsage(nodeMetricsMap map[string]*NodeMetrics, metrics []metricsv1beta1.NodeMetrics, nodes []v1.Node) {
+	allocatable := make(map[string]v1.ResourceList, len(nodes))
+	for _, node := range nodes {
+		allocatable[node.Name] = node.Status.Allocatable
+	}
+
+	for _, metric := range metrics {
+		nodeName := metric.Name
+		if _, ok := nodeMetricsMap[nodeName]; !ok {
+			klog.Warningf("The node %s information is obtained through the metrics server, but the volcano cache does not contain the node information.", nodeName)
+			continue
+		}
+		nodeAllocatable, ok := allocatable[nodeName]
+		if !ok {
+			continue
+		}
+		nodeMetricsMap[nodeName].MetricsTime = metric.Timestamp.Time
+		nodeMetricsMap[nodeName].CPU = usagePercentage(metric.Usage, nodeAllocatable, v1.ResourceCPU)
+		nodeMetricsMap[nodeName].Memory = usagePercentage(metric.Usage, nodeAllocatable, v1.ResourceMemory)
+		klog.V(5).Infof("The updated usage information of node %s is %v.", nodeName, nodeMetricsMap[nodeName])
+	}
+}
+
+func usagePercentage(usage, allocatable v1.ResourceList, name v1.ResourceName) float64 {
+	total := allocatable[name]
+	if total.IsZero() {
+		return 0
+	}
+	used := usage[name]
+	return float64(used.MilliValue()) / float64(total.MilliValue()) * 100
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+func TestSetNodesUsage(t *testing.T) {
+	now := metav1.NewTime(time.Now())
+	nodes := []v1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "n1"},
+			Status: v1.NodeStatus{Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+			}},
+		},
+	}
+	metrics := []metricsv1beta1.NodeMetrics{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "n1"},
+			Timestamp:  now,
+			Usage: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("1"),
+				v1.ResourceMemory: resource.MustParse("6Gi"),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unknown"},
+			Timestamp:  now,
+		},
+	}
+	nodeMetricsMap := map[string]*NodeMetrics{"n1": {}}
+
+	setNodesUsage(nodeMetricsMap, metrics, nodes)
+
+	if len(nodeMetricsMap) != 1 {
+		t.Fatalf("expected only the nodes in the cache, got %v", nodeMetricsMap)
+	}
+	n1 := nodeMetricsMap["n1"]
+	if n1.CPU != 25 || n1.Memory != 75 || !n1.MetricsTime.Equal(now.Time) {
+		t.Errorf("expected usage of n1 to be cpu 25%% and memory 75%% at %v, got %+v", now.Time, n1)
+	}
+}
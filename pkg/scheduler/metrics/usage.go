@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto" // auto-registry collectors in default registry
+	v1 "k8s.io/api/core/v1"
+)
+
+var usageLabels = []string{"queue_name", "namespace_name", "user"}
+
+var (
+	usageAllocatedMilliCPU = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_usage_allocated_milli_cpu",
+			Help:      "Allocated CPU count for one queue, namespace and user",
+		}, usageLabels,
+	)
+
+	usageAllocatedMemory = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_usage_allocated_memory_bytes",
+			Help:      "Allocated memory for one queue, namespace and user",
+		}, usageLabels,
+	)
+
+	usageAllocatedScalarResource = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_usage_allocated_scalar_resources",
+			Help:      "Allocated scalar resources for one queue, namespace and user",
+		}, append(usageLabels, "resource"),
+	)
+
+	usageRequestMilliCPU = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_usage_request_milli_cpu",
+			Help:      "Request CPU count for one queue, namespace and user",
+		}, usageLabels,
+	)
+
+	usageRequestMemory = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_usage_request_memory_bytes",
+			Help:      "Request memory for one queue, namespace and user",
+		}, usageLabels,
+	)
+
+	usageRequestScalarResource = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_usage_request_scalar_resources",
+			Help:      "Request scalar resources for one queue, namespace and user",
+		}, append(usageLabels, "resource"),
+	)
+
+	queueParent = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_parent",
+			Help:      "Parent of one queue in the queue hierarchy, the value is always 1",
+		}, []string{"queue_name", "parent_queue"},
+	)
+
+	// usage breakdowns exported in the last update, used to clean up stale series
+	knownUsageKeys     = make(map[UsageKey]struct{})
+	knownQueueParents  = make(map[string]string)
+	knownUsageKeysLock sync.Mutex
+)
+
+// UsageKey identifies the consumer of queue resources.
+type UsageKey struct {
+	Queue     string
+	Namespace string
+	User      string
+}
+
+func (k UsageKey) labels() prometheus.Labels {
+	return prometheus.Labels{"queue_name": k.Queue, "namespace_name": k.Namespace, "user": k.User}
+}
+
+// Usage is the allocated and requested resources of one consumer.
+type Usage struct {
+	AllocatedMilliCPU        float64
+	AllocatedMemory          float64
+	AllocatedScalarResources map[v1.ResourceName]float64
+	RequestMilliCPU          float64
+	RequestMemory            float64
+	RequestScalarResources   map[v1.ResourceName]float64
+}
+
+// UpdateQueueUsage records the resource usage broken down by queue, namespace and user,
+// series of consumers which are not present anymore are deleted.
+func UpdateQueueUsage(usages map[UsageKey]*Usage) {
+	knownUsageKeysLock.Lock()
+	defer knownUsageKeysLock.Unlock()
+
+	for key := range knownUsageKeys {
+		if _, found := usages[key]; !found {
+			deleteUsageMetrics(key)
+		}
+	}
+
+	current := make(map[UsageKey]struct{}, len(usages))
+	for key, usage := range usages {
+		current[key] = struct{}{}
+		usageAllocatedMilliCPU.WithLabelValues(key.Queue, key.Namespace, key.User).Set(usage.AllocatedMilliCPU)
+		usageAllocatedMemory.WithLabelValues(key.Queue, key.Namespace, key.User).Set(usage.AllocatedMemory)
+		usageRequestMilliCPU.WithLabelValues(key.Queue, key.Namespace, key.User).Set(usage.RequestMilliCPU)
+		usageRequestMemory.WithLabelValues(key.Queue, key.Namespace, key.User).Set(usage.RequestMemory)
+		// scalar resources may come and go, always rebuild them
+		usageAllocatedScalarResource.DeletePartialMatch(key.labels())
+		usageRequestScalarResource.DeletePartialMatch(key.labels())
+		for name, value := range usage.AllocatedScalarResources {
+			usageAllocatedScalarResource.WithLabelValues(key.Queue, key.Namespace, key.User, string(name)).Set(value)
+		}
+		for name, value := range usage.RequestScalarResources {
+			usageRequestScalarResource.WithLabelValues(key.Queue, key.Namespace, key.User, string(name)).Set(value)
+		}
+	}
+	knownUsageKeys = current
+}
+
+// UpdateQueueParents records the parent of each queue, so that the usage of sub-queues
+// can be aggregated to their parent queues in dashboards.
+func UpdateQueueParents(parents map[string]string) {
+	knownUsageKeysLock.Lock()
+	defer knownUsageKeysLock.Unlock()
+
+	for queue, parent := range knownQueueParents {
+		if parents[queue] != parent {
+			queueParent.DeleteLabelValues(queue, parent)
+		}
+	}
+	for queue, parent := range parents {
+		queueParent.WithLabelValues(queue, parent).Set(1)
+	}
+	knownQueueParents = parents
+}
+
+func deleteUsageMetrics(key UsageKey) {
+	usageAllocatedMilliCPU.DeleteLabelValues(key.Queue, key.Namespace, key.User)
+	usageAllocatedMemory.DeleteLabelValues(key.Queue, key.Namespace, key.User)
+	usageRequestMilliCPU.DeleteLabelValues(key.Queue, key.Namespace, key.User)
+	usageRequestMemory.DeleteLabelValues(key.Queue, key.Namespace, key.User)
+	usageAllocatedScalarResource.DeletePartialMatch(key.labels())
+	usageRequestScalarResource.DeletePartialMatch(key.labels())
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestUpdateQueueUsage(t *testing.T) {
+	alice := UsageKey{Queue: "q1", Namespace: "ns1", User: "alice"}
+	bob := UsageKey{Queue: "q1", Namespace: "ns2", User: "bob"}
+
+	UpdateQueueUsage(map[UsageKey]*Usage{
+		alice: {
+			AllocatedMilliCPU:        1000,
+			AllocatedMemory:          1024,
+			AllocatedScalarResources: map[v1.ResourceName]float64{"nvidia.com/gpu": 1000},
+			RequestMilliCPU:          2000,
+			RequestMemory:            2048,
+		},
+		bob: {AllocatedMilliCPU: 500, RequestMilliCPU: 500},
+	})
+
+	if got := testutil.ToFloat64(usageAllocatedMilliCPU.WithLabelValues("q1", "ns1", "alice")); got != 1000 {
+		t.Errorf("expected allocated cpu of alice to be 1000, got %v", got)
+	}
+	if got := testutil.ToFloat64(usageRequestMemory.WithLabelValues("q1", "ns1", "alice")); got != 2048 {
+		t.Errorf("expected request memory of alice to be 2048, got %v", got)
+	}
+	if got := testutil.ToFloat64(usageAllocatedScalarResource.WithLabelValues("q1", "ns1", "alice", "nvidia.com/gpu")); got != 1000 {
+		t.Errorf("expected allocated gpu of alice to be 1000, got %v", got)
+	}
+	if got := testutil.ToFloat64(usageAllocatedMilliCPU.WithLabelValues("q1", "ns2", "bob")); got != 500 {
+		t.Errorf("expected allocated cpu of bob to be 500, got %v", got)
+	}
+
+	// alice has no job anymore, the series of alice should be removed
+	UpdateQueueUsage(map[UsageKey]*Usage{
+		bob: {AllocatedMilliCPU: 800, RequestMilliCPU: 800},
+	})
+	if count := testutil.CollectAndCount(usageAllocatedMilliCPU); count != 1 {
+		t.Errorf("expected 1 allocated cpu series, got %d", count)
+	}
+	if count := testutil.CollectAndCount(usageAllocatedScalarResource); count != 0 {
+		t.Errorf("expected no allocated scalar resources series, got %d", count)
+	}
+	if got := testutil.ToFloat64(usageAllocatedMilliCPU.WithLabelValues("q1", "ns2", "bob")); got != 800 {
+		t.Errorf("expected allocated cpu of bob to be 800, got %v", got)
+	}
+}
+
+func TestUpdateQueueParents(t *testing.T) {
+	UpdateQueueParents(map[string]string{"child": "parent1"})
+	UpdateQueueParents(map[string]string{"child": "parent2"})
+
+	if count := testutil.CollectAndCount(queueParent); count != 1 {
+		t.Errorf("expected 1 queue parent series, got %d", count)
+	}
+	if got := testutil.ToFloat64(queueParent.WithLabelValues("child", "parent2")); got != 1 {
+		t.Errorf("expected parent of child to be parent2, got %v", got)
+	}
+}
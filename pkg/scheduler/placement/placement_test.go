@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeStore struct {
+	sync.Mutex
+	records map[string]*Record
+	stops   map[string]time.Time
+	closed  chan struct{}
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{records: map[string]*Record{}, stops: map[string]time.Time{}, closed: make(chan struct{})}
+}
+
+func (s *fakeStore) RecordStart(_ context.Context, record *Record) error {
+	s.Lock()
+	defer s.Unlock()
+	s.records[record.TaskUID] = record
+	return nil
+}
+
+func (s *fakeStore) RecordStop(_ context.Context, taskUID string, stop time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+	if _, found := s.stops[taskUID]; !found {
+		s.stops[taskUID] = stop
+	}
+	return nil
+}
+
+func (s *fakeStore) Close() error {
+	close(s.closed)
+	return nil
+}
+
+func TestNewStore(t *testing.T) {
+	store := newFakeStore()
+	RegisterStore("fake", func(string) (Store, error) { return store, nil })
+
+	if got, err := NewStore("fake", ""); err != nil || got != store {
+		t.Errorf("expected fake store, got %v, %v", got, err)
+	}
+	if _, err := NewStore("unknown", ""); err == nil {
+		t.Errorf("expected error of unregistered store")
+	}
+	// the driver is not linked
+	RegisterSQLStore("unlinked", Dialect{Driver: "unlinked", TimeType: "TIMESTAMP"})
+	if _, err := NewStore("unlinked", "placements.db"); err == nil {
+		t.Errorf("expected error of unknown sql driver")
+	}
+}
+
+// fakeDriver records the statements executed, it is registered as the drivers of the dialects.
+type fakeDriver struct {
+	sync.Mutex
+	statements []string
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{driver: d}, nil }
+
+func (d *fakeDriver) executed() []string {
+	d.Lock()
+	defer d.Unlock()
+	return append([]string(nil), d.statements...)
+}
+
+type fakeConn struct{ driver *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{driver: c.driver, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type fakeStmt struct {
+	driver *fakeDriver
+	query  string
+}
+
+func (s *fakeStmt) Close() error { return nil }
+
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	s.driver.Lock()
+	defer s.driver.Unlock()
+	s.driver.statements = append(s.driver.statements, s.query)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("queries are not supported")
+}
+
+func TestSQLStores(t *testing.T) {
+	tests := []struct {
+		name        string
+		dialect     Dialect
+		timeType    string
+		placeholder string
+	}{
+		{name: "sqlite", dialect: SQLiteDialect, timeType: "TIMESTAMP", placeholder: "?"},
+		{name: "postgres", dialect: PostgresDialect, timeType: "TIMESTAMPTZ", placeholder: "$1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := &fakeDriver{}
+			sql.Register(test.dialect.Driver, fake)
+			RegisterSQLStore(test.name, test.dialect)
+
+			store, err := NewStore(test.name, "placements")
+			if err != nil {
+				t.Fatalf("failed to open store through the registry: %v", err)
+			}
+			defer store.Close()
+
+			ctx := context.Background()
+			if err := store.RecordStart(ctx, &Record{TaskUID: "uid", Node: "n1", GPUs: []int{0}, Start: time.Now()}); err != nil {
+				t.Errorf("failed to record start: %v", err)
+			}
+			if err := store.RecordStop(ctx, "uid", time.Now()); err != nil {
+				t.Errorf("failed to record stop: %v", err)
+			}
+
+			statements := fake.executed()
+			if len(statements) != 3 {
+				t.Fatalf("expected create, insert and update statements, got %v", statements)
+			}
+			if !strings.Contains(statements[0], "start_time "+test.timeType+" NOT NULL") {
+				t.Errorf("expected time type %s, got %q", test.timeType, statements[0])
+			}
+			if !strings.Contains(statements[1], "VALUES ("+test.placeholder) {
+				t.Errorf("expected placeholder %s, got %q", test.placeholder, statements[1])
+			}
+		})
+	}
+}
+
+func TestRebind(t *testing.T) {
+	statement := "UPDATE t SET a = ? WHERE b = ?"
+	if got := (&sqlStore{dialect: SQLiteDialect}).rebind(statement); got != statement {
+		t.Errorf("expected sqlite statement not changed, got %q", got)
+	}
+	if got := (&sqlStore{dialect: PostgresDialect}).rebind(statement); got != "UPDATE t SET a = $1 WHERE b = $2" {
+		t.Errorf("expected numbered placeholders of postgres, got %q", got)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	store := newFakeStore()
+	recorder := NewRecorder(store)
+	stopCh := make(chan struct{})
+	go recorder.Run(stopCh)
+
+	start := time.Now()
+	recorder.RecordStart(&Record{TaskUID: "uid-1", Namespace: "ns", Name: "job-worker-0", Job: "ns/job", Queue: "q1", Node: "n1", GPUs: []int{0, 1}, Start: start})
+	recorder.RecordStop("uid-1", start.Add(time.Hour))
+	recorder.RecordStop("uid-1", start.Add(2*time.Hour))
+
+	err := wait(func() bool {
+		store.Lock()
+		defer store.Unlock()
+		return store.records["uid-1"] != nil && !store.stops["uid-1"].IsZero()
+	})
+	if err != nil {
+		t.Fatalf("records are not written")
+	}
+	if stop := store.stops["uid-1"]; !stop.Equal(start.Add(time.Hour)) {
+		t.Errorf("expected first stop kept, got %v", stop)
+	}
+
+	close(stopCh)
+	select {
+	case <-store.closed:
+	case <-time.After(time.Second):
+		t.Errorf("expected store closed after stop")
+	}
+}
+
+func wait(condition func() bool) error {
+	for i := 0; i < 100; i++ {
+		if condition() {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return context.DeadlineExceeded
+}
+
+func TestFormatGPUs(t *testing.T) {
+	if got := formatGPUs([]int{0, 3}); got != "0,3" {
+		t.Errorf("expected 0,3, got %q", got)
+	}
+	if got := formatGPUs(nil); got != "" {
+		t.Errorf("expected empty, got %q", got)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+const (
+	defaultQueueSize = 4096
+	writeTimeout     = 10 * time.Second
+)
+
+type event struct {
+	start   *Record
+	taskUID string
+	stop    time.Time
+}
+
+// Recorder writes records to the store in the background, so that the scheduling is not blocked by the store.
+// The events are written in order, and dropped when the queue is full, the drops are counted by the
+// placement_records_dropped_total metric.
+type Recorder struct {
+	store  Store
+	events chan event
+}
+
+// NewRecorder creates a recorder writing to the store.
+func NewRecorder(store Store) *Recorder {
+	return &Recorder{
+		store:  store,
+		events: make(chan event, defaultQueueSize),
+	}
+}
+
+// RecordStart records the placement of a task.
+func (r *Recorder) RecordStart(record *Record) {
+	r.push(event{start: record})
+}
+
+// RecordStop records the stop of the placement of a task.
+func (r *Recorder) RecordStop(taskUID string, stop time.Time) {
+	r.push(event{taskUID: taskUID, stop: stop})
+}
+
+func (r *Recorder) push(e event) {
+	select {
+	case r.events <- e:
+	default:
+		metrics.RegisterPlacementRecordDropped()
+		klog.Warningf("Placement record queue is full, drop the record of task <%s>", e.uid())
+	}
+}
+
+// Run writes the records until the stop channel is closed, then closes the store.
+func (r *Recorder) Run(stopCh <-chan struct{}) {
+	defer func() {
+		if err := r.store.Close(); err != nil {
+			klog.Errorf("Failed to close placement store: %v", err)
+		}
+	}()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case e := <-r.events:
+			r.write(e)
+		}
+	}
+}
+
+func (r *Recorder) write(e event) {
+	ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+	defer cancel()
+
+	var err error
+	if e.start != nil {
+		err = r.store.RecordStart(ctx, e.start)
+	} else {
+		err = r.store.RecordStop(ctx, e.taskUID, e.stop)
+	}
+	if err != nil {
+		klog.Errorf("Failed to persist placement record of task <%s>: %v", e.uid(), err)
+	}
+}
+
+func (e event) uid() string {
+	if e.start != nil {
+		return e.start.TaskUID
+	}
+	return e.taskUID
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect is the sql flavor of a database.
+type Dialect struct {
+	// Driver is the name the database/sql driver is registered with
+	Driver string
+	// TimeType is the column type of the start and stop time
+	TimeType string
+	// Numbered is whether the placeholders are $1, $2... rather than ?
+	Numbered bool
+}
+
+var (
+	// SQLiteDialect is the dialect of sqlite with the driver registered as sqlite3, e.g. github.com/mattn/go-sqlite3.
+	SQLiteDialect = Dialect{Driver: "sqlite3", TimeType: "TIMESTAMP"}
+	// PostgresDialect is the dialect of postgres with the driver registered as postgres, e.g. github.com/lib/pq.
+	PostgresDialect = Dialect{Driver: "postgres", TimeType: "TIMESTAMPTZ", Numbered: true}
+)
+
+// RegisterSQLStore registers the store persisting records in the database of the dialect by name. The driver of the
+// dialect must be imported along with the registration, see package stores for the stores linked into the scheduler.
+func RegisterSQLStore(name string, d Dialect) {
+	RegisterStore(name, func(dataSource string) (Store, error) {
+		return newSQLStore(d, dataSource)
+	})
+}
+
+// sqlStore persists records in the scheduler_placements table.
+type sqlStore struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+func newSQLStore(d Dialect, dataSource string) (*sqlStore, error) {
+	db, err := sql.Open(d.Driver, dataSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database with driver %q, the driver must be linked into the scheduler: %v", d.Driver, err)
+	}
+
+	s := &sqlStore{db: db, dialect: d}
+	if err := s.createTable(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *sqlStore) createTable(ctx context.Context) error {
+	statement := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS scheduler_placements (
+	task_uid VARCHAR(64) PRIMARY KEY,
+	namespace VARCHAR(253) NOT NULL,
+	name VARCHAR(253) NOT NULL,
+	job VARCHAR(512) NOT NULL,
+	queue VARCHAR(253) NOT NULL,
+	node VARCHAR(253) NOT NULL,
+	gpus VARCHAR(256) NOT NULL,
+	start_time %[1]s NOT NULL,
+	stop_time %[1]s
+)`, s.dialect.TimeType)
+	if _, err := s.db.ExecContext(ctx, statement); err != nil {
+		return fmt.Errorf("failed to create table scheduler_placements: %v", err)
+	}
+	return nil
+}
+
+// RecordStart inserts the record, the record of a task rebound is replaced.
+func (s *sqlStore) RecordStart(ctx context.Context, record *Record) error {
+	statement := s.rebind(`INSERT INTO scheduler_placements (task_uid, namespace, name, job, queue, node, gpus, start_time, stop_time)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, NULL)
+ON CONFLICT (task_uid) DO UPDATE SET node = excluded.node, gpus = excluded.gpus, start_time = excluded.start_time, stop_time = NULL`)
+	_, err := s.db.ExecContext(ctx, statement, record.TaskUID, record.Namespace, record.Name, record.Job,
+		record.Queue, record.Node, formatGPUs(record.GPUs), record.Start.UTC())
+	return err
+}
+
+// RecordStop sets the stop time of the record if it is not stopped yet.
+func (s *sqlStore) RecordStop(ctx context.Context, taskUID string, stop time.Time) error {
+	statement := s.rebind(`UPDATE scheduler_placements SET stop_time = ? WHERE task_uid = ? AND stop_time IS NULL`)
+	_, err := s.db.ExecContext(ctx, statement, stop.UTC(), taskUID)
+	return err
+}
+
+// Close closes the database.
+func (s *sqlStore) Close() error {
+	return s.db.Close()
+}
+
+// rebind replaces the ? placeholders of the statement with the numbered placeholders of the dialect.
+func (s *sqlStore) rebind(statement string) string {
+	if !s.dialect.Numbered {
+		return statement
+	}
+
+	var builder strings.Builder
+	index := 0
+	for _, c := range statement {
+		if c != '?' {
+			builder.WriteRune(c)
+			continue
+		}
+		index++
+		builder.WriteString("$" + strconv.Itoa(index))
+	}
+	return builder.String()
+}
+
+func formatGPUs(gpus []int) string {
+	ids := make([]string, 0, len(gpus))
+	for _, gpu := range gpus {
+		ids = append(ids, strconv.Itoa(gpu))
+	}
+	return strings.Join(ids, ",")
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package placement persists the placement decisions of the scheduler, so that external systems such as
+// chargeback pipelines can query the authoritative allocation history of tasks.
+package placement
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Record is the placement of a task on a node, from the task being bound to it being stopped.
+type Record struct {
+	TaskUID   string
+	Namespace string
+	Name      string
+	Job       string
+	Queue     string
+	Node      string
+	// GPUs are the indexes of the gpus allocated to the task on the node
+	GPUs  []int
+	Start time.Time
+}
+
+// Store persists placement records.
+type Store interface {
+	// RecordStart persists the placement of a task when it is bound.
+	RecordStart(ctx context.Context, record *Record) error
+	// RecordStop sets the stop time of the placement of a task when it finishes or is deleted.
+	RecordStop(ctx context.Context, taskUID string, stop time.Time) error
+	// Close releases the resources of the store.
+	Close() error
+}
+
+// StoreBuilder builds a store connected to the data source.
+type StoreBuilder func(dataSource string) (Store, error)
+
+var (
+	storeMutex    sync.RWMutex
+	storeBuilders = map[string]StoreBuilder{}
+)
+
+// RegisterStore registers the builder of a store by name.
+func RegisterStore(name string, builder StoreBuilder) {
+	storeMutex.Lock()
+	defer storeMutex.Unlock()
+
+	storeBuilders[name] = builder
+}
+
+// NewStore builds the store registered with the name.
+func NewStore(name, dataSource string) (Store, error) {
+	storeMutex.RLock()
+	builder, found := storeBuilders[name]
+	storeMutex.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("placement store %q is not registered, registered stores: %v", name, registeredStores())
+	}
+	return builder(dataSource)
+}
+
+func registeredStores() []string {
+	storeMutex.RLock()
+	defer storeMutex.RUnlock()
+
+	names := make([]string, 0, len(storeBuilders))
+	for name := range storeBuilders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stores registers the placement stores linked into the scheduler together with their database drivers.
+//
+// The postgres store is always linked. The sqlite store depends on the cgo driver github.com/mattn/go-sqlite3, so it
+// is only linked when the scheduler is built with CGO_ENABLED=1 and the sqlite build tag, e.g.
+//
+//	CGO_ENABLED=1 go build -tags sqlite ./cmd/scheduler
+package stores
+
+import (
+	// the driver registered as postgres
+	_ "github.com/lib/pq"
+
+	"volcano.sh/volcano/pkg/scheduler/placement"
+)
+
+func init() {
+	placement.RegisterSQLStore("postgres", placement.PostgresDialect)
+}
@@ -1,5 +1,8 @@
+//go:build sqlite
+// +build sqlite
+
 /*
-Copyright 2019 The Volcano Authors.
+Copyright 2025 The Volcano Authors.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -14,4 +17,15 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package router
+package stores
+
+import (
+	// the driver registered as sqlite3, it requires cgo
+	_ "github.com/mattn/go-sqlite3"
+
+	"volcano.sh/volcano/pkg/scheduler/placement"
+)
+
+func init() {
+	placement.RegisterSQLStore("sqlite", placement.SQLiteDialect)
+}
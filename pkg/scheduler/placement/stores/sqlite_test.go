@@ -0,0 +1,51 @@
+//go:build sqlite
+// +build sqlite
+
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stores
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"volcano.sh/volcano/pkg/scheduler/placement"
+)
+
+func TestSQLiteStore(t *testing.T) {
+	store, err := placement.NewStore("sqlite", filepath.Join(t.TempDir(), "placements.db"))
+	if err != nil {
+		t.Fatalf("failed to open sqlite store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	start := time.Now()
+	record := &placement.Record{TaskUID: "uid", Namespace: "ns", Name: "pod", Job: "ns/job", Queue: "default", Node: "n1", GPUs: []int{0, 1}, Start: start}
+	if err := store.RecordStart(ctx, record); err != nil {
+		t.Errorf("failed to record start: %v", err)
+	}
+	// the record of a task rebound is replaced
+	if err := store.RecordStart(ctx, record); err != nil {
+		t.Errorf("failed to record start of rebound task: %v", err)
+	}
+	if err := store.RecordStop(ctx, "uid", start.Add(time.Minute)); err != nil {
+		t.Errorf("failed to record stop: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stores
+
+import (
+	"strings"
+	"testing"
+
+	"volcano.sh/volcano/pkg/scheduler/placement"
+)
+
+func TestPostgresStore(t *testing.T) {
+	// no postgres server listens on the socket, the store is opened through the registry but fails to create the table
+	_, err := placement.NewStore("postgres", "host=/nonexistent port=5432 user=volcano sslmode=disable connect_timeout=1")
+	if err == nil {
+		t.Fatalf("expected error of connecting to postgres")
+	}
+	if strings.Contains(err.Error(), "is not registered") {
+		t.Errorf("expected postgres store registered, got %v", err)
+	}
+}
@@ -72,6 +72,11 @@ type queueAttr struct {
 	// realCapability represents the resource limit of the queue, LessEqual capability
 	realCapability *api.Resource
 	guarantee      *api.Resource
+	// borrowingLimit is the max resources the queue can allocate beyond its deserved resources, nil if not limited
+	borrowingLimit *api.Resource
+	// lendingLimit is the max resources of the idle deserved resources of the queue other queues can borrow,
+	// nil if not limited
+	lendingLimit *api.Resource
 }
 
 // New return capacityPlugin action
@@ -140,8 +145,8 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 
 		queue := obj.(*api.QueueInfo)
 		task := candidate.(*api.TaskInfo)
-		if queue.Queue.Status.State != scheduling.QueueStateOpen {
-			klog.V(3).Infof("Queue <%s> current state: %s, is not open state, can not reclaim for <%s>.", queue.Name, queue.Queue.Status.State, task.Name)
+		if !queue.Allocatable() {
+			klog.V(3).Infof("Queue <%s> current state: %s, is not allocatable, can not reclaim for <%s>.", queue.Name, queue.Queue.Status.State, task.Name)
 			return false
 		}
 		attr := cp.queueOpts[queue.UID]
@@ -161,7 +166,7 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 	})
 
 	ssn.AddAllocatableFn(cp.Name(), func(queue *api.QueueInfo, candidate *api.TaskInfo) bool {
-		if queue.Queue.Status.State != scheduling.QueueStateOpen {
+		if !queue.Allocatable() {
 			klog.V(3).Infof("Queue <%s> current state: %s, cannot allocate task <%s>.", queue.Name, queue.Queue.Status.State, candidate.Name)
 			return false
 		}
@@ -363,11 +368,7 @@ func (cp *capacityPlugin) OnSessionClose(ssn *framework.Session) {
 
 func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
 	for _, queue := range ssn.Queues {
-		if len(queue.Queue.Spec.Guarantee.Resource) == 0 {
-			continue
-		}
-		guarantee := api.NewResource(queue.Queue.Spec.Guarantee.Resource)
-		cp.totalGuarantee.Add(guarantee)
+		cp.totalGuarantee.Add(reservedOfQueue(queue))
 	}
 	klog.V(4).Infof("The total guarantee resource is <%v>", cp.totalGuarantee)
 	// Build attributes for Queues.
@@ -385,6 +386,8 @@ func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
 				elastic:   api.EmptyResource(),
 				inqueue:   api.EmptyResource(),
 				guarantee: api.EmptyResource(),
+
+				borrowingLimit: queue.BorrowingLimit(),
 			}
 			if len(queue.Queue.Spec.Capability) != 0 {
 				attr.capability = api.NewResource(queue.Queue.Spec.Capability)
@@ -398,7 +401,11 @@ func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
 			if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
 				attr.guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
 			}
-			realCapability := api.ExceededPart(cp.totalResource, cp.totalGuarantee).Add(attr.guarantee)
+			realCapability := api.ExceededPart(cp.totalResource, cp.totalGuarantee).Add(reservedOfQueue(queue))
+			// the queue bound to a node pool can not use more than the nodes in the pool
+			if nodePoolCapability := queue.NodePoolCapability(); nodePoolCapability != nil {
+				realCapability.MinDimensionResource(nodePoolCapability, api.Zero)
+			}
 			if attr.capability == nil {
 				attr.capability = api.EmptyResource()
 				attr.realCapability = realCapability
@@ -450,6 +457,7 @@ func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
 		}
 
 		attr.deserved = helpers.Max(attr.deserved, attr.guarantee)
+		attr.limitBorrowing()
 		cp.updateShare(attr)
 		klog.V(4).Infof("The attributes of queue <%s> in capacity: deserved <%v>, realCapability <%v>, allocate <%v>, request <%v>, elastic <%v>, share <%0.2f>",
 			attr.name, attr.deserved, attr.realCapability, attr.allocated, attr.request, attr.elastic, attr.share)
@@ -478,11 +486,10 @@ func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
 		metrics.UpdateQueueDeserved(queueInfo.Name, deservedCPU, deservedMem, scalarResources)
 		metrics.UpdateQueueAllocated(queueInfo.Name, 0, 0, map[v1.ResourceName]float64{})
 		metrics.UpdateQueueRequest(queueInfo.Name, 0, 0, map[v1.ResourceName]float64{})
-		guarantee := api.EmptyResource()
-		if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
-			guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
+		realCapacity := api.ExceededPart(cp.totalResource, cp.totalGuarantee).Add(reservedOfQueue(queue))
+		if limit := queue.BorrowingLimit(); limit != nil {
+			realCapacity.MinDimensionResource(helpers.BorrowingCapability(api.NewResource(queue.Queue.Spec.Deserved), limit), api.Infinity)
 		}
-		realCapacity := api.ExceededPart(cp.totalResource, cp.totalGuarantee).Add(guarantee)
 		if len(queue.Queue.Spec.Capability) > 0 {
 			capacity := api.NewResource(queue.Queue.Spec.Capability)
 			realCapacity.MinDimensionResource(capacity, api.Infinity)
@@ -710,6 +717,8 @@ func (cp *capacityPlugin) newQueueAttr(queue *api.QueueInfo) *queueAttr {
 		guarantee:      api.EmptyResource(),
 		capability:     api.EmptyResource(),
 		realCapability: api.EmptyResource(),
+		borrowingLimit: queue.BorrowingLimit(),
+		lendingLimit:   queue.LendingLimit(),
 	}
 	if len(queue.Queue.Spec.Capability) != 0 {
 		attr.capability = api.NewResource(queue.Queue.Spec.Capability)
@@ -753,9 +762,11 @@ func (cp *capacityPlugin) updateAncestors(queue *api.QueueInfo, ssn *framework.S
 func (cp *capacityPlugin) checkHierarchicalQueue(attr *queueAttr) error {
 	totalGuarantee := api.EmptyResource()
 	totalDeserved := api.EmptyResource()
+	totalReserved := api.EmptyResource()
 	for _, childAttr := range attr.children {
 		totalDeserved.Add(childAttr.deserved)
 		totalGuarantee.Add(childAttr.guarantee)
+		totalReserved.Add(childAttr.reserved())
 		// if the user does not set CPU or memory in capability, we set the value to be the same as parent(we do not consider the situation where the user sets CPU or memory<=0)
 		if childAttr.capability.MilliCPU <= 0 {
 			childAttr.capability.MilliCPU = attr.capability.MilliCPU
@@ -795,7 +806,7 @@ func (cp *capacityPlugin) checkHierarchicalQueue(attr *queueAttr) error {
 	}
 
 	for _, childAttr := range attr.children {
-		realCapability := api.ExceededPart(attr.realCapability, totalGuarantee).Add(childAttr.guarantee)
+		realCapability := api.ExceededPart(attr.realCapability, totalReserved).Add(childAttr.reserved())
 		if childAttr.capability == nil {
 			childAttr.capability = api.EmptyResource()
 			childAttr.realCapability = realCapability
@@ -803,6 +814,7 @@ func (cp *capacityPlugin) checkHierarchicalQueue(attr *queueAttr) error {
 			realCapability.MinDimensionResource(childAttr.capability, api.Infinity)
 			childAttr.realCapability = realCapability
 		}
+		childAttr.limitBorrowing()
 	}
 
 	// Check if the parent queue's deserved resources are less than the total deserved resources of child queues
@@ -952,6 +964,8 @@ func (qa *queueAttr) Clone() *queueAttr {
 		capability:     qa.capability.Clone(),
 		realCapability: qa.realCapability.Clone(),
 		guarantee:      qa.guarantee.Clone(),
+		borrowingLimit: qa.borrowingLimit,
+		lendingLimit:   qa.lendingLimit,
 		children:       make(map[api.QueueID]*queueAttr),
 	}
 
@@ -967,6 +981,36 @@ func (qa *queueAttr) Clone() *queueAttr {
 	return cloned
 }
 
+// reserved returns the resources reserved for the queue, which are not lent to other queues.
+func (qa *queueAttr) reserved() *api.Resource {
+	if qa.lendingLimit == nil {
+		return qa.guarantee
+	}
+	return helpers.LendingReserved(qa.deserved, qa.guarantee, qa.lendingLimit)
+}
+
+// limitBorrowing limits the real capability of the queue to its deserved resources plus its borrowing limit.
+func (qa *queueAttr) limitBorrowing() {
+	if qa.borrowingLimit == nil || qa.realCapability == nil {
+		return
+	}
+	qa.realCapability.MinDimensionResource(helpers.BorrowingCapability(qa.deserved, qa.borrowingLimit), api.Infinity)
+}
+
+// reservedOfQueue returns the resources reserved for the queue, which are its guarantee, and its deserved
+// resources beyond its lending limit.
+func reservedOfQueue(queue *api.QueueInfo) *api.Resource {
+	guarantee := api.EmptyResource()
+	if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
+		guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
+	}
+	limit := queue.LendingLimit()
+	if limit == nil {
+		return guarantee
+	}
+	return helpers.LendingReserved(api.NewResource(queue.Queue.Spec.Deserved), guarantee, limit)
+}
+
 func (s *capacityState) Clone() k8sframework.StateData {
 	if s == nil {
 		return nil
@@ -355,6 +355,86 @@ func TestEnqueueAndAllocatable(t *testing.T) {
 	}
 }
 
+func TestBorrowingAndLendingLimits(t *testing.T) {
+	// nodes
+	n1 := util.BuildNode("n1", api.BuildResourceList("3", "3Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil)
+	n2 := util.BuildNode("n2", api.BuildResourceList("3", "3Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil)
+
+	// pods
+	buildPods := func() []*corev1.Pod {
+		var pods []*corev1.Pod
+		for _, name := range []string{"p1", "p2", "p3", "p4"} {
+			pods = append(pods, util.BuildPod("ns1", name, "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+		}
+		return pods
+	}
+
+	// queues
+	borrowingQueue := util.BuildQueueWithResourcesQuantity("q1", api.BuildResourceList("2", "2Gi"), nil)
+	borrowingQueue.Annotations = map[string]string{api.QueueBorrowingLimit: `{"cpu": "1"}`}
+	lendingQueue := util.BuildQueueWithResourcesQuantity("q2", api.BuildResourceList("4", "4Gi"), nil)
+	lendingQueue.Annotations = map[string]string{api.QueueLendingLimit: `{"cpu": "1"}`}
+	borrowerQueue := util.BuildQueueWithResourcesQuantity("q1", api.BuildResourceList("2", "2Gi"), nil)
+
+	plugins := map[string]framework.PluginBuilder{PluginName: New}
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               PluginName,
+					EnabledAllocatable: &trueValue,
+				},
+			},
+		},
+	}
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name:             "case0: queue can not allocate more than deserved plus borrowing limit",
+			Plugins:          plugins,
+			Pods:             buildPods(),
+			Nodes:            []*corev1.Node{n1, n2},
+			PodGroups:        []*schedulingv1beta1.PodGroup{util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)},
+			Queues:           []*schedulingv1beta1.Queue{borrowingQueue},
+			ExpectBindsNum:   3,
+			MinimalBindCheck: true,
+		},
+		{
+			Name:             "case1: queue can not borrow the idle deserved of other queue beyond its lending limit",
+			Plugins:          plugins,
+			Pods:             buildPods(),
+			Nodes:            []*corev1.Node{n1, n2},
+			PodGroups:        []*schedulingv1beta1.PodGroup{util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)},
+			Queues:           []*schedulingv1beta1.Queue{borrowerQueue, lendingQueue},
+			ExpectBindsNum:   3,
+			MinimalBindCheck: true,
+		},
+		{
+			Name:             "case2: queue can borrow all the idle resources without limits",
+			Plugins:          plugins,
+			Pods:             buildPods(),
+			Nodes:            []*corev1.Node{n1, n2},
+			PodGroups:        []*schedulingv1beta1.PodGroup{util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)},
+			Queues:           []*schedulingv1beta1.Queue{borrowerQueue},
+			ExpectBindsNum:   4,
+			MinimalBindCheck: true,
+		},
+	}
+	actions := []framework.Action{allocate.New()}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run(actions)
+
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
 func Test_capacityPlugin_OnSessionOpenWithHierarchy(t *testing.T) {
 	plugins := map[string]framework.PluginBuilder{PluginName: New, predicates.PluginName: predicates.New, gang.PluginName: gang.New}
 	trueValue := true
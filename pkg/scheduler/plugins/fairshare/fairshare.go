@@ -0,0 +1,255 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/helpers"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "fairshare"
+	// HalfLife is the time after which the recorded usage counts half, e.g. 24h.
+	HalfLife = "fairshare.halfLife"
+	// EnableQueueOrder is whether the queues are ordered by their fair-share factor as well.
+	EnableQueueOrder = "fairshare.enableQueueOrder"
+	// ConfigMapNamespace is the namespace of the ConfigMap persisting the usage history.
+	ConfigMapNamespace = "fairshare.configMapNamespace"
+	// ConfigMapName is the name of the ConfigMap persisting the usage history.
+	ConfigMapName = "fairshare.configMapName"
+	// PersistInterval is the minimum interval between two writes of the usage history, e.g. 1m.
+	PersistInterval = "fairshare.persistInterval"
+
+	defaultHalfLife           = 24 * time.Hour
+	defaultConfigMapNamespace = "volcano-system"
+	defaultConfigMapName      = "volcano-fairshare-usage"
+	defaultPersistInterval    = time.Minute
+)
+
+type fairSharePlugin struct {
+	// Arguments given for fairshare plugin
+	pluginArguments    framework.Arguments
+	halfLife           time.Duration
+	enableQueueOrder   bool
+	configMapNamespace string
+	configMapName      string
+	persistInterval    time.Duration
+
+	// queueFactors and userFactors are the fair-share factors of the queues and users in this session
+	queueFactors map[api.QueueID]float64
+	userFactors  map[string]float64
+}
+
+// New function returns fairshare plugin object.
+func New(arguments framework.Arguments) framework.Plugin {
+	fp := &fairSharePlugin{
+		pluginArguments:    arguments,
+		halfLife:           defaultHalfLife,
+		enableQueueOrder:   true,
+		configMapNamespace: defaultConfigMapNamespace,
+		configMapName:      defaultConfigMapName,
+		persistInterval:    defaultPersistInterval,
+		queueFactors:       map[api.QueueID]float64{},
+		userFactors:        map[string]float64{},
+	}
+	fp.parseArguments()
+	return fp
+}
+
+func (fp *fairSharePlugin) Name() string {
+	return PluginName
+}
+
+func (fp *fairSharePlugin) parseArguments() {
+	fp.pluginArguments.GetBool(&fp.enableQueueOrder, EnableQueueOrder)
+	fp.pluginArguments.GetString(&fp.configMapNamespace, ConfigMapNamespace)
+	fp.pluginArguments.GetString(&fp.configMapName, ConfigMapName)
+	fp.halfLife = fp.parseDuration(HalfLife, defaultHalfLife)
+	fp.persistInterval = fp.parseDuration(PersistInterval, defaultPersistInterval)
+}
+
+func (fp *fairSharePlugin) parseDuration(key string, defaultValue time.Duration) time.Duration {
+	value := ""
+	fp.pluginArguments.GetString(&value, key)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		klog.Warningf("Invalid %s setting: %s in fairshare plugin, use %s.", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// currentShares returns the dominant shares of the resources allocated to the queues and users now,
+// the user of a job is its namespace.
+func currentShares(ssn *framework.Session) (map[string]float64, map[string]float64) {
+	queueAllocated := map[string]*api.Resource{}
+	userAllocated := map[string]*api.Resource{}
+	for _, job := range ssn.Jobs {
+		if job.Allocated == nil || job.Allocated.IsEmpty() {
+			continue
+		}
+		queue, found := ssn.Queues[job.Queue]
+		if !found {
+			continue
+		}
+		if _, found := queueAllocated[queue.Name]; !found {
+			queueAllocated[queue.Name] = api.EmptyResource()
+		}
+		queueAllocated[queue.Name].Add(job.Allocated)
+		if _, found := userAllocated[job.Namespace]; !found {
+			userAllocated[job.Namespace] = api.EmptyResource()
+		}
+		userAllocated[job.Namespace].Add(job.Allocated)
+	}
+
+	queueShares := map[string]float64{}
+	for name, allocated := range queueAllocated {
+		queueShares[name] = dominantShare(allocated, ssn.TotalResource)
+	}
+	userShares := map[string]float64{}
+	for name, allocated := range userAllocated {
+		userShares[name] = dominantShare(allocated, ssn.TotalResource)
+	}
+	return queueShares, userShares
+}
+
+func dominantShare(allocated, total *api.Resource) float64 {
+	res := float64(0)
+	for _, rn := range total.ResourceNames() {
+		if share := helpers.Share(allocated.Get(rn), total.Get(rn)); share > res {
+			res = share
+		}
+	}
+	return res
+}
+
+// calculateFactors calculates the fair-share factors with the usage history, the target share of a queue is
+// its weight among the queues, and the users share the cluster equally.
+func (fp *fairSharePlugin) calculateFactors(ssn *framework.Session, record *usageRecord) {
+	totalWeight := float64(0)
+	for _, queue := range ssn.Queues {
+		totalWeight += float64(queue.Weight)
+	}
+	for _, queue := range ssn.Queues {
+		targetShare := float64(0)
+		if totalWeight > 0 {
+			targetShare = float64(queue.Weight) / totalWeight
+		}
+		fp.queueFactors[queue.UID] = factor(record.Queues, queue.Name, targetShare)
+	}
+
+	users := map[string]struct{}{}
+	for user := range record.Users {
+		users[user] = struct{}{}
+	}
+	for _, job := range ssn.Jobs {
+		users[job.Namespace] = struct{}{}
+	}
+	for user := range users {
+		fp.userFactors[user] = factor(record.Users, user, 1/float64(len(users)))
+	}
+}
+
+/*
+User should enable fairshare plugin via the scheduler configuration:
+actions: "enqueue, allocate, backfill"
+tiers:
+- plugins:
+  - name: fairshare
+    arguments:
+    fairshare.halfLife: 24h
+    fairshare.enableQueueOrder: true
+    fairshare.configMapNamespace: volcano-system
+    fairshare.configMapName: volcano-fairshare-usage
+    fairshare.persistInterval: 1m
+
+The usage of the queues and users (namespaces) decays by half every half-life, and the jobs of the users
+with less recent usage, relative to their share, are ordered in front of the others.
+*/
+func (fp *fairSharePlugin) OnSessionOpen(ssn *framework.Session) {
+	klog.V(4).Infof("Enter fairshare plugin ...")
+	defer klog.V(4).Infof("Leaving fairshare plugin.")
+
+	history.Lock()
+	if !history.loaded && ssn.KubeClient() != nil {
+		if err := history.load(ssn.KubeClient(), fp.configMapNamespace, fp.configMapName); err != nil {
+			klog.Errorf("Failed to load usage history from ConfigMap <%s/%s> in fairshare plugin, err: %v.",
+				fp.configMapNamespace, fp.configMapName, err)
+		}
+	}
+	queueShares, userShares := currentShares(ssn)
+	history.update(time.Now(), fp.halfLife, queueShares, userShares)
+	fp.calculateFactors(ssn, &history.usageRecord)
+	history.Unlock()
+
+	for user, f := range fp.userFactors {
+		klog.V(5).Infof("Fair-share factor of user <%s> is %f.", user, f)
+	}
+
+	jobOrderFn := func(l, r interface{}) int {
+		lv := l.(*api.JobInfo)
+		rv := r.(*api.JobInfo)
+		return compareFactors(fp.userFactors[lv.Namespace], fp.userFactors[rv.Namespace])
+	}
+	ssn.AddJobOrderFn(fp.Name(), jobOrderFn)
+
+	if fp.enableQueueOrder {
+		queueOrderFn := func(l, r interface{}) int {
+			lv := l.(*api.QueueInfo)
+			rv := r.(*api.QueueInfo)
+			return compareFactors(fp.queueFactors[lv.UID], fp.queueFactors[rv.UID])
+		}
+		ssn.AddQueueOrderFn(fp.Name(), queueOrderFn)
+	}
+}
+
+// compareFactors puts the one with the higher fair-share factor, i.e. the less recent usage, in front.
+func compareFactors(l, r float64) int {
+	if l > r {
+		return -1
+	}
+	if l < r {
+		return 1
+	}
+	return 0
+}
+
+func (fp *fairSharePlugin) OnSessionClose(ssn *framework.Session) {
+	history.Lock()
+	defer history.Unlock()
+
+	if !history.loaded || ssn.KubeClient() == nil {
+		return
+	}
+	now := time.Now()
+	if now.Sub(history.lastSaved) < fp.persistInterval {
+		return
+	}
+	if err := history.save(ssn.KubeClient(), fp.configMapNamespace, fp.configMapName, now); err != nil {
+		klog.Errorf("Failed to save usage history to ConfigMap <%s/%s> in fairshare plugin, err: %v.",
+			fp.configMapNamespace, fp.configMapName, err)
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// usageDataKey is the key of the usage history in the data of the ConfigMap.
+	usageDataKey = "usage"
+	// maxAccrualPeriod limits the period the current allocation is accrued for at once, so that the
+	// allocation seen after the scheduler was down is not charged for the whole downtime.
+	maxAccrualPeriod = 5 * time.Minute
+	// minUsage is the usage below which an entry is dropped from the history.
+	minUsage = 1e-6
+)
+
+// usageRecord is the decayed usage of the queues and users, the usage is accumulated in dominant share seconds,
+// e.g. a queue holding half of the cluster for one minute accrues 30.
+type usageRecord struct {
+	LastUpdate time.Time          `json:"lastUpdate"`
+	Queues     map[string]float64 `json:"queues"`
+	Users      map[string]float64 `json:"users"`
+}
+
+// usageHistory is the usage record kept across the sessions and persisted in a ConfigMap
+// across the restarts of the scheduler.
+type usageHistory struct {
+	sync.Mutex
+	usageRecord
+	// loaded is whether the record has been loaded from the ConfigMap, it is not persisted before it is loaded
+	// so that the history of the previous run is not overwritten.
+	loaded    bool
+	lastSaved time.Time
+}
+
+// history is shared by the sessions, as the plugin is built again for every session.
+var history = newUsageHistory()
+
+func newUsageHistory() *usageHistory {
+	return &usageHistory{
+		usageRecord: usageRecord{
+			Queues: map[string]float64{},
+			Users:  map[string]float64{},
+		},
+	}
+}
+
+// update decays the usage by the time elapsed since the last update and accrues the current shares of
+// the queues and users for that time.
+func (h *usageHistory) update(now time.Time, halfLife time.Duration, queueShares, userShares map[string]float64) {
+	if h.LastUpdate.IsZero() {
+		h.LastUpdate = now
+		return
+	}
+	if !now.After(h.LastUpdate) {
+		return
+	}
+	elapsed := now.Sub(h.LastUpdate)
+	decay := math.Pow(0.5, float64(elapsed)/float64(halfLife))
+	accrual := math.Min(elapsed.Seconds(), maxAccrualPeriod.Seconds())
+
+	accrue(h.Queues, decay, accrual, queueShares)
+	accrue(h.Users, decay, accrual, userShares)
+	h.LastUpdate = now
+}
+
+func accrue(usage map[string]float64, decay, seconds float64, shares map[string]float64) {
+	for name := range usage {
+		usage[name] *= decay
+	}
+	for name, share := range shares {
+		usage[name] += share * seconds
+	}
+	for name, value := range usage {
+		if value < minUsage {
+			delete(usage, name)
+		}
+	}
+}
+
+// factor returns the fair-share factor 2^(-U/S) of classic HPC fair-share, where U is the usage normalized by
+// the total usage and S is the target share, so 1 means no usage, 0.5 means the usage matches the target share,
+// and it approaches 0 the more the usage exceeds the target share.
+func factor(usage map[string]float64, name string, targetShare float64) float64 {
+	total := float64(0)
+	for _, value := range usage {
+		total += value
+	}
+	if total == 0 || usage[name] == 0 {
+		return 1
+	}
+	if targetShare <= 0 {
+		return 0
+	}
+	return math.Pow(2, -(usage[name]/total)/targetShare)
+}
+
+// load reads the usage record from the ConfigMap, a missing ConfigMap means no usage history.
+func (h *usageHistory) load(client kubernetes.Interface, namespace, name string) error {
+	cm, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			h.loaded = true
+			return nil
+		}
+		return err
+	}
+	record := usageRecord{}
+	if data, found := cm.Data[usageDataKey]; found {
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			return err
+		}
+	}
+	if record.Queues == nil {
+		record.Queues = map[string]float64{}
+	}
+	if record.Users == nil {
+		record.Users = map[string]float64{}
+	}
+	h.usageRecord = record
+	h.loaded = true
+	return nil
+}
+
+// save writes the usage record to the ConfigMap, the ConfigMap is created if missing.
+func (h *usageHistory) save(client kubernetes.Interface, namespace, name string, now time.Time) error {
+	data, err := json.Marshal(h.usageRecord)
+	if err != nil {
+		return err
+	}
+	cm, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data:       map[string]string{usageDataKey: string(data)},
+		}
+		if _, err := client.CoreV1().ConfigMaps(namespace).Create(context.TODO(), cm, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	} else {
+		cm = cm.DeepCopy()
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[usageDataKey] = string(data)
+		if _, err := client.CoreV1().ConfigMaps(namespace).Update(context.TODO(), cm, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	h.lastSaved = now
+	return nil
+}
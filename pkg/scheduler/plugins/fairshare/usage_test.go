@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestUsageUpdate(t *testing.T) {
+	h := newUsageHistory()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	h.update(start, time.Hour, map[string]float64{"q1": 0.5}, nil)
+	if len(h.Queues) != 0 {
+		t.Fatalf("expected no usage accrued on the first update, got %v", h.Queues)
+	}
+
+	h.update(start.Add(time.Minute), time.Hour, map[string]float64{"q1": 0.5}, map[string]float64{"ns1": 0.5})
+	if h.Queues["q1"] != 30 || h.Users["ns1"] != 30 {
+		t.Fatalf("expected usage 30 after one minute, got %v and %v", h.Queues, h.Users)
+	}
+
+	// the usage halves after a half-life, the allocation is accrued for maxAccrualPeriod at most
+	h.update(start.Add(time.Minute+time.Hour), time.Hour, map[string]float64{"q2": 1}, nil)
+	if math.Abs(h.Queues["q1"]-15) > 1e-9 {
+		t.Errorf("expected usage 15 of q1 after one half-life, got %f", h.Queues["q1"])
+	}
+	if h.Queues["q2"] != maxAccrualPeriod.Seconds() {
+		t.Errorf("expected usage %f of q2, got %f", maxAccrualPeriod.Seconds(), h.Queues["q2"])
+	}
+}
+
+func TestFactor(t *testing.T) {
+	usage := map[string]float64{"q1": 75, "q2": 25}
+	testCases := []struct {
+		name        string
+		queue       string
+		targetShare float64
+		expected    float64
+	}{
+		{name: "no usage", queue: "q3", targetShare: 0.5, expected: 1},
+		{name: "usage matches share", queue: "q2", targetShare: 0.25, expected: 0.5},
+		{name: "usage exceeds share", queue: "q1", targetShare: 0.25, expected: 0.125},
+		{name: "no share", queue: "q1", targetShare: 0, expected: 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if f := factor(usage, tc.queue, tc.targetShare); math.Abs(f-tc.expected) > 1e-9 {
+				t.Errorf("expected factor %f, got %f", tc.expected, f)
+			}
+		})
+	}
+}
+
+func TestUsagePersistence(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	h := newUsageHistory()
+	if err := h.load(client, defaultConfigMapNamespace, defaultConfigMapName); err != nil || !h.loaded {
+		t.Fatalf("expected empty history loaded without ConfigMap, got %v", err)
+	}
+	h.LastUpdate = now
+	h.Queues["q1"] = 10
+	h.Users["ns1"] = 20
+	if err := h.save(client, defaultConfigMapNamespace, defaultConfigMapName, now); err != nil {
+		t.Fatalf("failed to create the ConfigMap: %v", err)
+	}
+	h.Queues["q1"] = 15
+	if err := h.save(client, defaultConfigMapNamespace, defaultConfigMapName, now); err != nil {
+		t.Fatalf("failed to update the ConfigMap: %v", err)
+	}
+
+	restored := newUsageHistory()
+	if err := restored.load(client, defaultConfigMapNamespace, defaultConfigMapName); err != nil {
+		t.Fatalf("failed to load the ConfigMap: %v", err)
+	}
+	if !restored.LastUpdate.Equal(now) || restored.Queues["q1"] != 15 || restored.Users["ns1"] != 20 {
+		t.Errorf("expected usage restored, got %+v", restored.usageRecord)
+	}
+}
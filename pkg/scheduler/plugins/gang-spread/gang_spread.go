@@ -0,0 +1,254 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gangspread
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "gang-spread"
+
+	// TopologyKeyAnnotationKey is the node label key which defines the failure domain, e.g. topology.kubernetes.io/zone.
+	TopologyKeyAnnotationKey = "volcano.sh/gang-spread-topology-key"
+	// MaxPerDomainAnnotationKey is the maximal number of pods of the gang in one failure domain.
+	MaxPerDomainAnnotationKey = "volcano.sh/gang-spread-max-per-domain"
+	// SameDomainAnnotationKey requires all pods of the gang to be placed in the same failure domain if set to "true".
+	SameDomainAnnotationKey = "volcano.sh/gang-spread-same-domain"
+)
+
+// User should specify the constraint in the annotations of the job or podgroup, e.g.
+//
+//	metadata:
+//	  annotations:
+//	    volcano.sh/gang-spread-topology-key: topology.kubernetes.io/zone
+//	    volcano.sh/gang-spread-max-per-domain: "8"
+//
+// and enable the plugin in the scheduler configuration:
+//
+//	tiers:
+//	- plugins:
+//	  - name: predicates
+//	  - name: gang-spread
+//
+// Different from the native topologySpreadConstraints, which are evaluated pod by pod against
+// the pods already running, the constraint is evaluated collectively for all pods of the gang,
+// including the pods allocated in the current session.
+
+type spreadConstraint struct {
+	topologyKey  string
+	maxPerDomain int
+	sameDomain   bool
+}
+
+type gangSpreadPlugin struct {
+	// Arguments given for the plugin
+	pluginArguments framework.Arguments
+	constraints     map[api.JobID]*spreadConstraint
+	// counts is the number of pods of each job placed in each domain, it is computed once per
+	// job when the session is opened and kept up to date by the allocate and deallocate events,
+	// so that the predicates running in parallel only read it.
+	counts map[api.JobID]map[string]int
+}
+
+// New return gang-spread plugin
+func New(arguments framework.Arguments) framework.Plugin {
+	return &gangSpreadPlugin{pluginArguments: arguments}
+}
+
+func (gp *gangSpreadPlugin) Name() string {
+	return PluginName
+}
+
+// parseConstraint returns nil if there is no spread constraint in the annotations.
+func parseConstraint(annotations map[string]string) (*spreadConstraint, error) {
+	topologyKey := annotations[TopologyKeyAnnotationKey]
+	if topologyKey == "" {
+		return nil, nil
+	}
+
+	c := &spreadConstraint{topologyKey: topologyKey}
+	if value, found := annotations[MaxPerDomainAnnotationKey]; found {
+		maxPerDomain, err := strconv.Atoi(value)
+		if err != nil || maxPerDomain <= 0 {
+			return nil, fmt.Errorf("invalid %s %q, must be a positive integer", MaxPerDomainAnnotationKey, value)
+		}
+		c.maxPerDomain = maxPerDomain
+	}
+	if value, found := annotations[SameDomainAnnotationKey]; found {
+		sameDomain, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %v", SameDomainAnnotationKey, value, err)
+		}
+		c.sameDomain = sameDomain
+	}
+	if c.maxPerDomain == 0 && !c.sameDomain {
+		return nil, fmt.Errorf("%s is set but neither %s nor %s is specified",
+			TopologyKeyAnnotationKey, MaxPerDomainAnnotationKey, SameDomainAnnotationKey)
+	}
+	return c, nil
+}
+
+// check checks whether one more pod of the gang can be placed in the domain,
+// counts is the number of pods of the gang already placed in each domain.
+func (c *spreadConstraint) check(counts map[string]int, domain string) error {
+	if c.sameDomain {
+		for d, count := range counts {
+			if d != domain && count > 0 {
+				return fmt.Errorf("gang must be placed in the same %s %s", c.topologyKey, d)
+			}
+		}
+	}
+	if c.maxPerDomain > 0 && counts[domain] >= c.maxPerDomain {
+		return fmt.Errorf("gang already has %d pods in %s %s", counts[domain], c.topologyKey, domain)
+	}
+	return nil
+}
+
+// placed returns whether the task is placed on a node, including the tasks
+// allocated or pipelined in the current session.
+func placed(task *api.TaskInfo) bool {
+	return task.NodeName != "" && (api.AllocatedStatus(task.Status) || task.Status == api.Pipelined)
+}
+
+// domainOf returns the domain of the node, the bool result is false if the node is not labeled.
+func domainOf(ssn *framework.Session, nodeName, topologyKey string) (string, bool) {
+	node, found := ssn.Nodes[nodeName]
+	if !found || node.Node == nil {
+		return "", false
+	}
+	domain, found := node.Node.Labels[topologyKey]
+	return domain, found
+}
+
+// domainCounts counts the pods of the job which are placed on nodes.
+func domainCounts(ssn *framework.Session, job *api.JobInfo, topologyKey string) map[string]int {
+	counts := map[string]int{}
+	for _, task := range job.Tasks {
+		if !placed(task) {
+			continue
+		}
+		if domain, found := domainOf(ssn, task.NodeName, topologyKey); found {
+			counts[domain]++
+		}
+	}
+	return counts
+}
+
+// countsWithout returns the counts excluding the task itself if it is already placed,
+// e.g. when a running task is evaluated for preemption.
+func countsWithout(ssn *framework.Session, counts map[string]int, task *api.TaskInfo, topologyKey string) map[string]int {
+	if !placed(task) {
+		return counts
+	}
+	domain, found := domainOf(ssn, task.NodeName, topologyKey)
+	if !found || counts[domain] == 0 {
+		return counts
+	}
+	excluded := make(map[string]int, len(counts))
+	for d, count := range counts {
+		excluded[d] = count
+	}
+	excluded[domain]--
+	return excluded
+}
+
+func (gp *gangSpreadPlugin) OnSessionOpen(ssn *framework.Session) {
+	gp.constraints = map[api.JobID]*spreadConstraint{}
+	gp.counts = map[api.JobID]map[string]int{}
+	for _, job := range ssn.Jobs {
+		if job.PodGroup == nil {
+			continue
+		}
+		c, err := parseConstraint(job.PodGroup.Annotations)
+		if err != nil {
+			klog.Warningf("Ignore gang spread constraint of job <%s/%s>: %v", job.Namespace, job.Name, err)
+			continue
+		}
+		if c != nil {
+			gp.constraints[job.UID] = c
+			gp.counts[job.UID] = domainCounts(ssn, job, c.topologyKey)
+		}
+	}
+
+	predicateFn := func(task *api.TaskInfo, node *api.NodeInfo) error {
+		c, found := gp.constraints[task.Job]
+		if !found {
+			return nil
+		}
+		jobCounts, found := gp.counts[task.Job]
+		if !found {
+			return nil
+		}
+
+		domain, found := node.Node.Labels[c.topologyKey]
+		if !found {
+			return newFitErr(task, node, fmt.Sprintf("node does not have label %s", c.topologyKey))
+		}
+
+		counts := countsWithout(ssn, jobCounts, task, c.topologyKey)
+		if err := c.check(counts, domain); err != nil {
+			klog.V(4).Infof("Task <%s/%s> can not be placed on node <%s>: %v", task.Namespace, task.Name, node.Name, err)
+			return newFitErr(task, node, err.Error())
+		}
+		return nil
+	}
+	ssn.AddPredicateFn(gp.Name(), predicateFn)
+
+	// update the domain counts computed when the session is opened
+	updateCounts := func(task *api.TaskInfo, delta int) {
+		c, found := gp.constraints[task.Job]
+		if !found {
+			return
+		}
+		counts, found := gp.counts[task.Job]
+		if !found {
+			return
+		}
+		if domain, found := domainOf(ssn, task.NodeName, c.topologyKey); found {
+			counts[domain] += delta
+		}
+	}
+	ssn.AddEventHandler(&framework.EventHandler{
+		AllocateFunc: func(event *framework.Event) {
+			updateCounts(event.Task, 1)
+		},
+		DeallocateFunc: func(event *framework.Event) {
+			updateCounts(event.Task, -1)
+		},
+	})
+}
+
+func (gp *gangSpreadPlugin) OnSessionClose(ssn *framework.Session) {
+	gp.constraints = nil
+	gp.counts = nil
+}
+
+func newFitErr(task *api.TaskInfo, node *api.NodeInfo, reason string) error {
+	status := &api.Status{
+		Code:   api.Unschedulable,
+		Reason: reason,
+	}
+	return api.NewFitErrWithStatus(task, node, status)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gangspread
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+func TestParseConstraint(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *spreadConstraint
+		expectedErr bool
+	}{
+		{
+			name: "no constraint",
+		},
+		{
+			name: "max per domain",
+			annotations: map[string]string{
+				TopologyKeyAnnotationKey:  "zone",
+				MaxPerDomainAnnotationKey: "8",
+			},
+			expected: &spreadConstraint{topologyKey: "zone", maxPerDomain: 8},
+		},
+		{
+			name: "same domain",
+			annotations: map[string]string{
+				TopologyKeyAnnotationKey: "zone",
+				SameDomainAnnotationKey:  "true",
+			},
+			expected: &spreadConstraint{topologyKey: "zone", sameDomain: true},
+		},
+		{
+			name: "invalid max per domain",
+			annotations: map[string]string{
+				TopologyKeyAnnotationKey:  "zone",
+				MaxPerDomainAnnotationKey: "0",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "topology key without rule",
+			annotations: map[string]string{
+				TopologyKeyAnnotationKey: "zone",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := parseConstraint(tt.annotations)
+			if (err != nil) != tt.expectedErr {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if !reflect.DeepEqual(c, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, c)
+			}
+		})
+	}
+}
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraint  *spreadConstraint
+		counts      map[string]int
+		domain      string
+		expectedErr bool
+	}{
+		{
+			name:       "domain has room",
+			constraint: &spreadConstraint{topologyKey: "zone", maxPerDomain: 2},
+			counts:     map[string]int{"z1": 1},
+			domain:     "z1",
+		},
+		{
+			name:        "domain is full",
+			constraint:  &spreadConstraint{topologyKey: "zone", maxPerDomain: 2},
+			counts:      map[string]int{"z1": 2},
+			domain:      "z1",
+			expectedErr: true,
+		},
+		{
+			name:       "first pod of same domain gang",
+			constraint: &spreadConstraint{topologyKey: "zone", sameDomain: true},
+			counts:     map[string]int{},
+			domain:     "z2",
+		},
+		{
+			name:        "same domain gang placed in another domain",
+			constraint:  &spreadConstraint{topologyKey: "zone", sameDomain: true},
+			counts:      map[string]int{"z1": 3},
+			domain:      "z2",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.constraint.check(tt.counts, tt.domain)
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestCountsWithout(t *testing.T) {
+	ssn := &framework.Session{
+		Nodes: map[string]*api.NodeInfo{
+			"n1": {Name: "n1", Node: &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1", Labels: map[string]string{"zone": "z1"}}}},
+		},
+	}
+	counts := map[string]int{"z1": 2}
+
+	tests := []struct {
+		name     string
+		task     *api.TaskInfo
+		expected map[string]int
+	}{
+		{
+			name:     "pending task is not counted",
+			task:     &api.TaskInfo{TransactionContext: api.TransactionContext{Status: api.Pending}},
+			expected: map[string]int{"z1": 2},
+		},
+		{
+			name:     "running task is excluded from its domain",
+			task:     &api.TaskInfo{TransactionContext: api.TransactionContext{Status: api.Running, NodeName: "n1"}},
+			expected: map[string]int{"z1": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countsWithout(ssn, counts, tt.task, "zone"); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+			if counts["z1"] != 2 {
+				t.Errorf("counts of the job must not be changed, got %v", counts)
+			}
+		})
+	}
+}
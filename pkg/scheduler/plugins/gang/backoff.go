@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gang
+
+import (
+	"sync"
+	"time"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// thrashRecord is the thrashing of a gang, i.e. the sessions in which its tasks were partially allocated and
+// released as the gang was not ready.
+type thrashRecord struct {
+	// count is the number of the sessions the gang thrashed in since it was ready or backed off
+	count int
+	// backoffUntil is the time until which the gang is not scheduled
+	backoffUntil time.Time
+}
+
+// thrashHistory is the thrashing of the gangs kept across the sessions.
+type thrashHistory struct {
+	sync.Mutex
+	jobs map[api.JobID]*thrashRecord
+}
+
+// thrashes is shared by the sessions, as the plugin is built again for every session.
+var thrashes = newThrashHistory()
+
+func newThrashHistory() *thrashHistory {
+	return &thrashHistory{jobs: map[api.JobID]*thrashRecord{}}
+}
+
+// backoffUntil returns the time until which the job is backed off, zero if it is not backed off.
+func (h *thrashHistory) backoffUntil(job api.JobID, now time.Time) time.Time {
+	h.Lock()
+	defer h.Unlock()
+	if record, found := h.jobs[job]; found && now.Before(record.backoffUntil) {
+		return record.backoffUntil
+	}
+	return time.Time{}
+}
+
+// thrash records that the job thrashed in a session, and backs it off for the backoff duration once it thrashed
+// in threshold sessions. It returns the number of the sessions the job thrashed in and whether it is backed off.
+func (h *thrashHistory) thrash(job api.JobID, now time.Time, threshold int, backoff time.Duration) (int, bool) {
+	h.Lock()
+	defer h.Unlock()
+	record, found := h.jobs[job]
+	if !found {
+		record = &thrashRecord{}
+		h.jobs[job] = record
+	}
+	record.count++
+	count := record.count
+	if count < threshold {
+		return count, false
+	}
+	record.count = 0
+	record.backoffUntil = now.Add(backoff)
+	return count, true
+}
+
+// reset forgets the thrashing of the job once it is ready.
+func (h *thrashHistory) reset(job api.JobID) {
+	h.Lock()
+	defer h.Unlock()
+	delete(h.jobs, job)
+}
+
+// cleanup forgets the thrashing of the jobs which are gone and not backed off any more.
+func (h *thrashHistory) cleanup(jobs map[api.JobID]*api.JobInfo, now time.Time) {
+	h.Lock()
+	defer h.Unlock()
+	for job, record := range h.jobs {
+		if _, found := jobs[job]; !found && !now.Before(record.backoffUntil) {
+			delete(h.jobs, job)
+		}
+	}
+}
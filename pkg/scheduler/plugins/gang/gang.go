@@ -24,6 +24,8 @@ package gang
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,23 +33,52 @@ import (
 
 	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
 )
 
-// PluginName indicates name of volcano scheduler plugin.
-const PluginName = "gang"
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "gang"
+	// EvictExcessByIndex makes the pods of a job beyond its minAvailable, e.g. after the minAvailable is lowered,
+	// evicted by preempt and reclaim from the highest index, so that the pods of the lowest indexes are kept.
+	EvictExcessByIndex = "gang.evictExcessByIndex"
+	// ThrashThreshold is the number of the sessions in which the tasks of a gang are partially allocated and released
+	// as the gang is not ready, after which the gang is backed off. The gangs are not backed off if it is not positive.
+	ThrashThreshold = "gang.thrashThreshold"
+	// ThrashBackoff is the duration a thrashing gang is backed off for, e.g. 5m.
+	ThrashBackoff = "gang.thrashBackoff"
+
+	defaultThrashBackoff = 5 * time.Minute
+)
 
 type gangPlugin struct {
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
+
+	evictExcessByIndex bool
+	thrashThreshold    int
+	thrashBackoff      time.Duration
 }
 
 // New return gang plugin
 func New(arguments framework.Arguments) framework.Plugin {
-	return &gangPlugin{pluginArguments: arguments}
+	gp := &gangPlugin{pluginArguments: arguments, thrashBackoff: defaultThrashBackoff}
+	arguments.GetBool(&gp.evictExcessByIndex, EvictExcessByIndex)
+	arguments.GetInt(&gp.thrashThreshold, ThrashThreshold)
+	var backoff string
+	arguments.GetString(&backoff, ThrashBackoff)
+	if backoff != "" {
+		if d, err := time.ParseDuration(backoff); err != nil || d <= 0 {
+			klog.Errorf("Invalid %s %q, use the default %v", ThrashBackoff, backoff, defaultThrashBackoff)
+		} else {
+			gp.thrashBackoff = d
+		}
+	}
+	return gp
 }
 
 func (gp *gangPlugin) Name() string {
@@ -64,6 +95,16 @@ func (gp *gangPlugin) OnSessionOpen(ssn *framework.Session) {
 			}
 		}
 
+		if gp.thrashThreshold > 0 {
+			if until := thrashes.backoffUntil(job.UID, time.Now()); !until.IsZero() {
+				return &api.ValidateResult{
+					Pass:    false,
+					Reason:  api.GangBackoffReason,
+					Message: fmt.Sprintf("gang is backed off until %s for thrashing", until.Format(time.RFC3339)),
+				}
+			}
+		}
+
 		if valid := job.CheckTaskValid(); !valid {
 			return &api.ValidateResult{
 				Pass:    false,
@@ -90,6 +131,9 @@ func (gp *gangPlugin) OnSessionOpen(ssn *framework.Session) {
 		var victims []*api.TaskInfo
 		jobOccupiedMap := map[api.JobID]int32{}
 
+		if gp.evictExcessByIndex {
+			preemptees = orderByIndexDesc(preemptees)
+		}
 		for _, preemptee := range preemptees {
 			job := ssn.Jobs[preemptee.Job]
 			if _, found := jobOccupiedMap[job.UID]; !found {
@@ -166,6 +210,7 @@ func (gp *gangPlugin) OnSessionOpen(ssn *framework.Session) {
 }
 
 func (gp *gangPlugin) OnSessionClose(ssn *framework.Session) {
+	now := time.Now()
 	var unreadyTaskCount int32
 	var unScheduleJobCount int
 	for _, job := range ssn.Jobs {
@@ -196,12 +241,20 @@ func (gp *gangPlugin) OnSessionClose(ssn *framework.Session) {
 			// TODO: If the Job is gang-unschedulable due to scheduling gates
 			// we need a new message and reason to tell users
 			// More detail in design doc pod-scheduling-readiness.md
+			reason := v1beta1.NotEnoughResourcesReason
+			if enqueueReason := notEnqueuedReason(ssn, job); enqueueReason != "" {
+				reason = enqueueReason
+			}
+			if backoffMsg := gp.detectThrash(job, now); backoffMsg != "" {
+				reason = api.GangBackoffReason
+				msg = fmt.Sprintf("%v/%v tasks in gang unschedulable: %s", unreadyTaskCount, len(job.Tasks), backoffMsg)
+			}
 			jc := &scheduling.PodGroupCondition{
 				Type:               scheduling.PodGroupUnschedulableType,
 				Status:             v1.ConditionTrue,
 				LastTransitionTime: metav1.Now(),
 				TransitionID:       string(ssn.UID),
-				Reason:             v1beta1.NotEnoughResourcesReason,
+				Reason:             reason,
 				Message:            msg,
 			}
 
@@ -223,10 +276,102 @@ func (gp *gangPlugin) OnSessionClose(ssn *framework.Session) {
 				klog.Errorf("Failed to update job <%s/%s> condition: %v",
 					job.Namespace, job.Name, err)
 			}
+			resolveUnschedulableCondition(ssn, job)
+			thrashes.reset(job.UID)
 		}
 		metrics.UpdateUnscheduleTaskCount(job.Name, int(unreadyTaskCount))
 		unreadyTaskCount = 0
 	}
 
 	metrics.UpdateUnscheduleJobCount(unScheduleJobCount)
+	thrashes.cleanup(ssn.Jobs, now)
+}
+
+// detectThrash detects that the tasks of the unready job were partially allocated and released in the session,
+// and returns the message of the back-off if the job is backed off.
+func (gp *gangPlugin) detectThrash(job *api.JobInfo, now time.Time) string {
+	if until := thrashes.backoffUntil(job.UID, now); !until.IsZero() {
+		return fmt.Sprintf("gang is backed off until %s for thrashing", until.Format(time.RFC3339))
+	}
+
+	var released int
+	for _, task := range job.TaskStatusIndex[api.Pending] {
+		if task.LastTransaction != nil && api.AllocatedStatus(task.LastTransaction.Status) {
+			released++
+		}
+	}
+	if released == 0 {
+		return ""
+	}
+
+	metrics.RegisterGangThrash(job.Name)
+	if gp.thrashThreshold <= 0 {
+		return ""
+	}
+	count, backedOff := thrashes.thrash(job.UID, now, gp.thrashThreshold, gp.thrashBackoff)
+	klog.V(3).Infof("Gang <%s/%s> thrashed in %d sessions, %d tasks were allocated and released",
+		job.Namespace, job.Name, count, released)
+	if !backedOff {
+		return ""
+	}
+	return fmt.Sprintf("%d tasks were allocated and released in %d sessions, gang is backed off for %v",
+		released, count, gp.thrashBackoff)
+}
+
+// resolveUnschedulableCondition turns the unschedulable condition of the ready job left by the former sessions to
+// false, e.g. the job becomes ready once its minAvailable is lowered, so that it is not reported unschedulable any more.
+func resolveUnschedulableCondition(ssn *framework.Session, job *api.JobInfo) {
+	for _, c := range job.PodGroup.Status.Conditions {
+		if c.Type != scheduling.PodGroupUnschedulableType || c.Status != v1.ConditionTrue {
+			continue
+		}
+		jc := &scheduling.PodGroupCondition{
+			Type:               scheduling.PodGroupUnschedulableType,
+			Status:             v1.ConditionFalse,
+			LastTransitionTime: metav1.Now(),
+			TransitionID:       string(ssn.UID),
+			Reason:             "tasks in gang are ready to be scheduled",
+		}
+		if err := ssn.UpdatePodGroupCondition(job, jc); err != nil {
+			klog.Errorf("Failed to update job <%s/%s> condition: %v", job.Namespace, job.Name, err)
+		}
+		return
+	}
+}
+
+// orderByIndexDesc orders the preemptees of each job from the highest pod index, the jobs are kept in the order
+// they are first seen.
+func orderByIndexDesc(preemptees []*api.TaskInfo) []*api.TaskInfo {
+	var jobs []api.JobID
+	byJob := map[api.JobID][]*api.TaskInfo{}
+	for _, preemptee := range preemptees {
+		if _, found := byJob[preemptee.Job]; !found {
+			jobs = append(jobs, preemptee.Job)
+		}
+		byJob[preemptee.Job] = append(byJob[preemptee.Job], preemptee)
+	}
+
+	ordered := make([]*api.TaskInfo, 0, len(preemptees))
+	for _, job := range jobs {
+		tasks := byJob[job]
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return helpers.CompareTask(tasks[j], tasks[i])
+		})
+		ordered = append(ordered, tasks...)
+	}
+	return ordered
+}
+
+// notEnqueuedReason returns the reason the job is not enqueued by the enqueue action in the session, e.g. the hard
+// capability of its queue or its scheduling gates, the reason is kept to tell users what the job is waiting for.
+func notEnqueuedReason(ssn *framework.Session, job *api.JobInfo) string {
+	for _, c := range job.PodGroup.Status.Conditions {
+		if c.Type != scheduling.PodGroupUnschedulableType || c.TransitionID != string(ssn.UID) {
+			continue
+		}
+		if c.Reason == api.QueueQuotaExceededReason || c.Reason == api.SchedulingGatedReason {
+			return c.Reason
+		}
+	}
+	return ""
 }
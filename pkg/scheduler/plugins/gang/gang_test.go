@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gang
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+func buildIndexedTask(job api.JobID, name string) *api.TaskInfo {
+	return &api.TaskInfo{
+		UID:    api.TaskID(name),
+		Job:    job,
+		Name:   name,
+		Pod:    &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}},
+		Resreq: api.EmptyResource(),
+	}
+}
+
+func TestOrderByIndexDesc(t *testing.T) {
+	preemptees := []*api.TaskInfo{
+		buildIndexedTask("j1", "j1-worker-0"),
+		buildIndexedTask("j2", "j2-worker-1"),
+		buildIndexedTask("j1", "j1-worker-2"),
+		buildIndexedTask("j2", "j2-worker-3"),
+		buildIndexedTask("j1", "j1-worker-1"),
+	}
+
+	var names []string
+	for _, task := range orderByIndexDesc(preemptees) {
+		names = append(names, task.Name)
+	}
+	expected := "j1-worker-2,j1-worker-1,j1-worker-0,j2-worker-3,j2-worker-1"
+	if got := strings.Join(names, ","); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestResolveUnschedulableCondition(t *testing.T) {
+	job := api.NewJobInfo("ns1/j1")
+	job.PodGroup = &api.PodGroup{PodGroup: scheduling.PodGroup{Status: scheduling.PodGroupStatus{
+		Conditions: []scheduling.PodGroupCondition{{
+			Type:         scheduling.PodGroupUnschedulableType,
+			Status:       v1.ConditionTrue,
+			TransitionID: "former-session",
+		}},
+	}}}
+	ssn := &framework.Session{UID: "s1", Jobs: map[api.JobID]*api.JobInfo{job.UID: job}}
+
+	resolveUnschedulableCondition(ssn, job)
+	cond := job.PodGroup.Status.Conditions[0]
+	if cond.Status != v1.ConditionFalse || cond.TransitionID != "s1" {
+		t.Errorf("expected the unschedulable condition turned false in session s1, got %+v", cond)
+	}
+}
+
+func TestDetectThrash(t *testing.T) {
+	thrashes = newThrashHistory()
+	gp := &gangPlugin{thrashThreshold: 2, thrashBackoff: time.Minute}
+
+	released := buildIndexedTask("ns1/job1", "job1-worker-0")
+	released.Status = api.Pending
+	released.LastTransaction = &api.TransactionContext{Status: api.Allocated}
+	pending := buildIndexedTask("ns1/job1", "job1-worker-1")
+	pending.Status = api.Pending
+	job := api.NewJobInfo("ns1/job1", released, pending)
+	job.Namespace, job.Name = "ns1", "job1"
+
+	now := time.Now()
+	if msg := gp.detectThrash(job, now); msg != "" {
+		t.Fatalf("expected gang not backed off after thrashing once, got %q", msg)
+	}
+	if msg := gp.detectThrash(job, now); !strings.Contains(msg, "backed off for 1m0s") {
+		t.Fatalf("expected gang backed off after thrashing twice, got %q", msg)
+	}
+	if until := thrashes.backoffUntil(job.UID, now); !until.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected gang backed off until %v, got %v", now.Add(time.Minute), until)
+	}
+	if until := thrashes.backoffUntil(job.UID, now.Add(2*time.Minute)); !until.IsZero() {
+		t.Errorf("expected the back-off expired, got %v", until)
+	}
+
+	// the job gone is forgotten once its back-off expires
+	thrashes.cleanup(map[api.JobID]*api.JobInfo{}, now)
+	if _, found := thrashes.jobs[job.UID]; !found {
+		t.Errorf("expected the gang kept while it is backed off")
+	}
+	thrashes.cleanup(map[api.JobID]*api.JobInfo{}, now.Add(2*time.Minute))
+	if _, found := thrashes.jobs[job.UID]; found {
+		t.Errorf("expected the gang forgotten after its back-off expired")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeorder
+
+import (
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	k8sframework "k8s.io/kubernetes/pkg/scheduler/framework"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+// scoreCache caches the scoring work shared by the tasks of the same pod template in a session, since the
+// replicas of a task share the images and the affinity of the template. The results depending on the pods
+// placed in the session, e.g. the inter pod affinity scores, are not cached.
+type scoreCache struct {
+	sync.RWMutex
+	imageLocality map[string]map[string]int64         // key_1: pod template uid key_2: node name
+	nodeAffinity  map[string]*k8sframework.CycleState // key: pod template uid
+	nodeInfos     map[string]*k8sframework.NodeInfo   // key: node name
+}
+
+func newScoreCache() *scoreCache {
+	return &scoreCache{
+		imageLocality: make(map[string]map[string]int64),
+		nodeAffinity:  make(map[string]*k8sframework.CycleState),
+		nodeInfos:     make(map[string]*k8sframework.NodeInfo),
+	}
+}
+
+// getPodTemplateUID returns the uid of the pod template of the pod, empty for the pods not created from a template.
+func getPodTemplateUID(pod *v1.Pod) string {
+	return pod.Annotations[batch.PodTemplateKey]
+}
+
+// imageLocalityScore returns the image locality score of the pod on the node. The images of the nodes do not change
+// in a session, so score is only called for the first task of the pod template on the node.
+func (sc *scoreCache) imageLocalityScore(pod *v1.Pod, nodeName string, score func() (int64, *k8sframework.Status)) (int64, *k8sframework.Status) {
+	uid := getPodTemplateUID(pod)
+	if uid == "" {
+		return score()
+	}
+
+	sc.RLock()
+	result, found := sc.imageLocality[uid][nodeName]
+	sc.RUnlock()
+	if found {
+		return result, nil
+	}
+
+	result, status := score()
+	if !status.IsSuccess() {
+		return result, status
+	}
+	sc.Lock()
+	defer sc.Unlock()
+	if _, found := sc.imageLocality[uid]; !found {
+		sc.imageLocality[uid] = make(map[string]int64)
+	}
+	sc.imageLocality[uid][nodeName] = result
+	return result, nil
+}
+
+// nodeAffinityState returns the cycle state holding the preferred node affinity terms of the pod parsed by
+// preScore, so that the terms are parsed once per pod template instead of once per task and node. It returns
+// nil if the pod is not created from a template or the terms fail to parse.
+func (sc *scoreCache) nodeAffinityState(pod *v1.Pod, preScore func(state *k8sframework.CycleState) *k8sframework.Status) *k8sframework.CycleState {
+	uid := getPodTemplateUID(pod)
+	if uid == "" {
+		return nil
+	}
+
+	sc.RLock()
+	state, found := sc.nodeAffinity[uid]
+	sc.RUnlock()
+	if found {
+		return state
+	}
+
+	state = k8sframework.NewCycleState()
+	if status := preScore(state); !status.IsSuccess() && !status.IsSkip() {
+		return nil
+	}
+	sc.Lock()
+	defer sc.Unlock()
+	sc.nodeAffinity[uid] = state
+	return state
+}
+
+// nodeInfo returns the node info of the node for the batch scoring, which only holds the node.
+func (sc *scoreCache) nodeInfo(node *v1.Node) *k8sframework.NodeInfo {
+	sc.RLock()
+	nodeInfo, found := sc.nodeInfos[node.Name]
+	sc.RUnlock()
+	if found {
+		return nodeInfo
+	}
+
+	nodeInfo = &k8sframework.NodeInfo{}
+	nodeInfo.SetNode(node)
+	sc.Lock()
+	defer sc.Unlock()
+	sc.nodeInfos[node.Name] = nodeInfo
+	return nodeInfo
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeorder
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sframework "k8s.io/kubernetes/pkg/scheduler/framework"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+func buildTemplatePod(name, templateUID string) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{}}}
+	if templateUID != "" {
+		pod.Annotations[batch.PodTemplateKey] = templateUID
+	}
+	return pod
+}
+
+func TestImageLocalityScoreCache(t *testing.T) {
+	sc := newScoreCache()
+	calls := 0
+	score := func() (int64, *k8sframework.Status) {
+		calls++
+		return 40, nil
+	}
+
+	for _, pod := range []*v1.Pod{buildTemplatePod("worker-0", "worker"), buildTemplatePod("worker-1", "worker")} {
+		for _, nodeName := range []string{"n1", "n2"} {
+			if result, status := sc.imageLocalityScore(pod, nodeName, score); result != 40 || !status.IsSuccess() {
+				t.Fatalf("expected score 40, got %d, %v", result, status.AsError())
+			}
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected the score computed once per node for the pod template, got %d calls", calls)
+	}
+
+	calls = 0
+	for i := 0; i < 2; i++ {
+		sc.imageLocalityScore(buildTemplatePod("bare", ""), "n1", score)
+	}
+	if calls != 2 {
+		t.Errorf("expected the score of the pod without template not cached, got %d calls", calls)
+	}
+
+	calls = 0
+	failed := func() (int64, *k8sframework.Status) {
+		calls++
+		return 0, k8sframework.NewStatus(k8sframework.Error, "failed")
+	}
+	for i := 0; i < 2; i++ {
+		if _, status := sc.imageLocalityScore(buildTemplatePod("ps-0", "ps"), "n1", failed); status.IsSuccess() {
+			t.Errorf("expected the error of the score returned")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected the failed score not cached, got %d calls", calls)
+	}
+}
+
+func TestNodeAffinityStateCache(t *testing.T) {
+	sc := newScoreCache()
+	calls := 0
+	preScore := func(state *k8sframework.CycleState) *k8sframework.Status {
+		calls++
+		return nil
+	}
+
+	first := sc.nodeAffinityState(buildTemplatePod("worker-0", "worker"), preScore)
+	second := sc.nodeAffinityState(buildTemplatePod("worker-1", "worker"), preScore)
+	if first == nil || first != second || calls != 1 {
+		t.Errorf("expected the state shared by the pod template and pre scored once, got %d calls", calls)
+	}
+
+	if state := sc.nodeAffinityState(buildTemplatePod("bare", ""), preScore); state != nil || calls != 1 {
+		t.Errorf("expected no state for the pod without template, got %v and %d calls", state, calls)
+	}
+
+	failed := func(state *k8sframework.CycleState) *k8sframework.Status {
+		return k8sframework.NewStatus(k8sframework.Error, "failed")
+	}
+	if state := sc.nodeAffinityState(buildTemplatePod("ps-0", "ps"), failed); state != nil {
+		t.Errorf("expected no state when the pre score fails")
+	}
+
+	skipped := func(state *k8sframework.CycleState) *k8sframework.Status {
+		return k8sframework.NewStatus(k8sframework.Skip)
+	}
+	if state := sc.nodeAffinityState(buildTemplatePod("chief-0", "chief"), skipped); state == nil {
+		t.Errorf("expected the state cached when the pre score is skipped")
+	}
+}
+
+func TestNodeInfoCache(t *testing.T) {
+	sc := newScoreCache()
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}}
+	nodeInfo := sc.nodeInfo(node)
+	if nodeInfo.Node() != node {
+		t.Fatalf("expected the node info holding the node")
+	}
+	if sc.nodeInfo(node) != nodeInfo {
+		t.Errorf("expected the node info reused in the session")
+	}
+}
@@ -63,6 +63,8 @@ const (
 	ImageLocalityWeight = "imagelocality.weight"
 	// PodTopologySpreadWeight is the key for providing Pod Topology Spread Priority Weight in YAML
 	PodTopologySpreadWeight = "podtopologyspread.weight"
+	// ArchAffinityWeight is the key for providing Architecture Affinity Priority Weight in YAML
+	ArchAffinityWeight = "archaffinity.weight"
 )
 
 type nodeOrderPlugin struct {
@@ -88,12 +90,13 @@ type priorityWeight struct {
 	taintTolerationWeight   int
 	imageLocalityWeight     int
 	podTopologySpreadWeight int
+	archAffinityWeight      int
 }
 
 // calculateWeight from the provided arguments.
 //
 // Currently only supported priorities are nodeaffinity, podaffinity, leastrequested,
-// mostrequested, balancedresouce, imagelocality, tainttoleration.
+// mostrequested, balancedresouce, imagelocality, tainttoleration, podtopologyspread, archaffinity.
 //
 // User should specify priority weights in the config in this format:
 //
@@ -117,6 +120,7 @@ type priorityWeight struct {
 //	      tainttoleration.weight: 3
 //	      imagelocality.weight: 1
 //	      podtopologyspread.weight: 2
+//	      archaffinity.weight: 1
 func calculateWeight(args framework.Arguments) priorityWeight {
 	// Initial values for weights.
 	// By default, for backward compatibility and for reasonable scores,
@@ -130,6 +134,7 @@ func calculateWeight(args framework.Arguments) priorityWeight {
 		taintTolerationWeight:   3,
 		imageLocalityWeight:     1,
 		podTopologySpreadWeight: 2, // be consistent with kubernetes default setting.
+		archAffinityWeight:      1,
 	}
 
 	// Checks whether nodeaffinity.weight is provided or not, if given, modifies the value in weight struct.
@@ -156,6 +161,9 @@ func calculateWeight(args framework.Arguments) priorityWeight {
 	// Checks whether podtopologyspread.weight is provided or not, if given, modifies the value in weight struct.
 	args.GetInt(&weight.podTopologySpreadWeight, PodTopologySpreadWeight)
 
+	// Checks whether archaffinity.weight is provided or not, if given, modifies the value in weight struct.
+	args.GetInt(&weight.archAffinityWeight, ArchAffinityWeight)
+
 	return weight
 }
 
@@ -213,13 +221,18 @@ func (pp *nodeOrderPlugin) OnSessionOpen(ssn *framework.Session) {
 	p, _ = imagelocality.New(context.TODO(), nil, handle)
 	imageLocality := p.(*imagelocality.ImageLocality)
 
+	// the replicas of a task share the pod template, cache the scoring work of the template in the session
+	sCache := newScoreCache()
+
 	nodeOrderFn := func(task *api.TaskInfo, node *api.NodeInfo) (float64, error) {
 		var nodeScore = 0.0
 
 		state := k8sframework.NewCycleState()
 		if weight.imageLocalityWeight != 0 {
 			nodeInfo := ssn.NodeMap[node.Name]
-			score, status := imageLocality.Score(context.TODO(), state, task.Pod, nodeInfo)
+			score, status := sCache.imageLocalityScore(task.Pod, node.Name, func() (int64, *k8sframework.Status) {
+				return imageLocality.Score(context.TODO(), state, task.Pod, nodeInfo)
+			})
 			if !status.IsSuccess() {
 				klog.Warningf("Node: %s, Image Locality Priority Failed because of Error: %v", node.Name, status.AsError())
 				return 0, status.AsError()
@@ -275,7 +288,13 @@ func (pp *nodeOrderPlugin) OnSessionOpen(ssn *framework.Session) {
 		// NodeAffinity
 		if weight.nodeAffinityWeight != 0 {
 			nodeInfo := ssn.NodeMap[node.Name]
-			score, status := nodeAffinity.Score(context.TODO(), state, task.Pod, nodeInfo)
+			affinityState := sCache.nodeAffinityState(task.Pod, func(affinityState *k8sframework.CycleState) *k8sframework.Status {
+				return nodeAffinity.PreScore(context.TODO(), affinityState, task.Pod, []*k8sframework.NodeInfo{nodeInfo})
+			})
+			if affinityState == nil {
+				affinityState = state
+			}
+			score, status := nodeAffinity.Score(context.TODO(), affinityState, task.Pod, nodeInfo)
 			if !status.IsSuccess() {
 				klog.Warningf("Node: %s, Calculate Node Affinity Priority Failed because of Error: %v", node.Name, status.AsError())
 				return 0, status.AsError()
@@ -287,6 +306,13 @@ func (pp *nodeOrderPlugin) OnSessionOpen(ssn *framework.Session) {
 			klog.V(5).Infof("Node: %s, task<%s/%s> Node Affinity weight %d, score: %f", node.Name, task.Namespace, task.Name, weight.nodeAffinityWeight, float64(score)*float64(weight.nodeAffinityWeight))
 		}
 
+		// ArchAffinity
+		if weight.archAffinityWeight != 0 {
+			score := archAffinityScore(task, node)
+			nodeScore += score * float64(weight.archAffinityWeight)
+			klog.V(5).Infof("Node: %s, task<%s/%s> Arch Affinity weight %d, score: %f", node.Name, task.Namespace, task.Name, weight.archAffinityWeight, score*float64(weight.archAffinityWeight))
+		}
+
 		klog.V(4).Infof("Nodeorder Total Score for task<%s/%s> on node %s is: %f", task.Namespace, task.Name, node.Name, nodeScore)
 		return nodeScore, nil
 	}
@@ -311,9 +337,7 @@ func (pp *nodeOrderPlugin) OnSessionOpen(ssn *framework.Session) {
 		nodeInfos := make([]*k8sframework.NodeInfo, 0, len(nodeInfo))
 		nodes := make([]*v1.Node, 0, len(nodeInfo))
 		for _, node := range nodeInfo {
-			newNodeInfo := &k8sframework.NodeInfo{}
-			newNodeInfo.SetNode(node.Node)
-			nodeInfos = append(nodeInfos, newNodeInfo)
+			nodeInfos = append(nodeInfos, sCache.nodeInfo(node.Node))
 			nodes = append(nodes, node.Node)
 		}
 		nodeScores := make(map[string]float64, len(nodes))
@@ -343,6 +367,23 @@ func (pp *nodeOrderPlugin) OnSessionOpen(ssn *framework.Session) {
 	ssn.AddBatchNodeOrderFn(pp.Name(), batchNodeOrderFn)
 }
 
+// archAffinityScore scores the node by the preference of its architecture in the architectures of the task,
+// the most preferred architecture gets the max node score, so that the spare capacity of the preferred
+// architecture is used first.
+func archAffinityScore(task *api.TaskInfo, node *api.NodeInfo) float64 {
+	if task.Pod == nil || node.Node == nil {
+		return 0
+	}
+	archs := api.GetTaskArchitectures(task.Pod.Annotations)
+	nodeArch := node.Node.Labels[v1.LabelArchStable]
+	for i, arch := range archs {
+		if arch == nodeArch {
+			return float64(k8sframework.MaxNodeScore) * float64(len(archs)-i) / float64(len(archs))
+		}
+	}
+	return 0
+}
+
 func interPodAffinityScore(
 	interPodAffinity *interpodaffinity.InterPodAffinity,
 	state *k8sframework.CycleState,
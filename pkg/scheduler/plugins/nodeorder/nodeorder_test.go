@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8smetrics "k8s.io/kubernetes/pkg/scheduler/metrics"
 
 	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
@@ -139,3 +140,55 @@ func TestNodeOrderPlugin(t *testing.T) {
 		})
 	}
 }
+
+func TestArchAffinityScore(t *testing.T) {
+	buildNode := func(arch string) *api.NodeInfo {
+		return &api.NodeInfo{Node: &v1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   arch,
+			Labels: map[string]string{v1.LabelArchStable: arch},
+		}}}
+	}
+	buildTask := func(annotations map[string]string) *api.TaskInfo {
+		return &api.TaskInfo{Pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}}
+	}
+
+	tests := []struct {
+		name     string
+		task     *api.TaskInfo
+		node     *api.NodeInfo
+		expected float64
+	}{
+		{
+			name:     "no architecture preference",
+			task:     buildTask(nil),
+			node:     buildNode("arm64"),
+			expected: 0,
+		},
+		{
+			name:     "most preferred architecture",
+			task:     buildTask(map[string]string{api.TaskArchitectures: "arm64,amd64"}),
+			node:     buildNode("arm64"),
+			expected: 100,
+		},
+		{
+			name:     "less preferred architecture",
+			task:     buildTask(map[string]string{api.TaskArchitectures: "arm64,amd64"}),
+			node:     buildNode("amd64"),
+			expected: 50,
+		},
+		{
+			name:     "architecture not listed",
+			task:     buildTask(map[string]string{api.TaskArchitectures: "arm64,amd64"}),
+			node:     buildNode("s390x"),
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if score := archAffinityScore(test.task, test.node); score != test.expected {
+				t.Errorf("expected score %v, got %v", test.expected, score)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oversubscription
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "oversubscription"
+
+	// ResourcesKey is the argument of the oversubscription resources reported by the volcano agent, separated by comma.
+	ResourcesKey = "oversubscription.resources"
+
+	// defaultResources is the default extended resources reported by the volcano agent, see pkg/agent/apis/types.go.
+	defaultResources = "kubernetes.io/batch-cpu,kubernetes.io/batch-memory"
+)
+
+// The volcano agent reports the resources reclaimable from the low utilization of online workloads as extended
+// resources on the nodes with the oversubscription label, and evicts the offline pods when the real usage rises.
+// The plugin keeps these resources for the queues allowed to use them, e.g.
+//
+//	apiVersion: scheduling.volcano.sh/v1beta1
+//	kind: Queue
+//	metadata:
+//	  name: offline
+//	  annotations:
+//	    volcano.sh/oversubscription: "true"
+//
+// and the plugin is enabled with the resources reported by the agent:
+//
+//	tiers:
+//	- plugins:
+//	  - name: predicates
+//	  - name: oversubscription
+//	    arguments:
+//	      oversubscription.resources: kubernetes.io/batch-cpu,kubernetes.io/batch-memory
+//
+// The tasks requesting the resources in other queues are unschedulable.
+
+type oversubscriptionPlugin struct {
+	// Arguments given for the plugin
+	pluginArguments framework.Arguments
+	resources       []v1.ResourceName
+}
+
+// New return oversubscription plugin
+func New(arguments framework.Arguments) framework.Plugin {
+	resources := defaultResources
+	arguments.GetString(&resources, ResourcesKey)
+	return &oversubscriptionPlugin{
+		pluginArguments: arguments,
+		resources:       parseResources(resources),
+	}
+}
+
+func parseResources(value string) []v1.ResourceName {
+	var resources []v1.ResourceName
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			resources = append(resources, v1.ResourceName(name))
+		}
+	}
+	return resources
+}
+
+func (op *oversubscriptionPlugin) Name() string {
+	return PluginName
+}
+
+// requested returns the oversubscription resources requested by the task.
+func (op *oversubscriptionPlugin) requested(task *api.TaskInfo) []string {
+	var names []string
+	for _, name := range op.resources {
+		if task.Resreq.Get(name) > 0 {
+			names = append(names, string(name))
+		}
+	}
+	return names
+}
+
+func (op *oversubscriptionPlugin) OnSessionOpen(ssn *framework.Session) {
+	predicateFn := func(task *api.TaskInfo, node *api.NodeInfo) error {
+		names := op.requested(task)
+		if len(names) == 0 {
+			return nil
+		}
+		job, found := ssn.Jobs[task.Job]
+		if !found {
+			return nil
+		}
+		if queue, found := ssn.Queues[job.Queue]; found && queue.AllowOversubscription() {
+			return nil
+		}
+
+		klog.V(4).Infof("Task <%s/%s> of queue <%s> is not allowed to request oversubscription resources %v",
+			task.Namespace, task.Name, job.Queue, names)
+		return api.NewFitErrWithStatus(task, node, &api.Status{
+			Code:   api.UnschedulableAndUnresolvable,
+			Reason: fmt.Sprintf("queue %s is not allowed to use oversubscription resources %s", job.Queue, strings.Join(names, ",")),
+		})
+	}
+	ssn.AddPredicateFn(op.Name(), predicateFn)
+}
+
+func (op *oversubscriptionPlugin) OnSessionClose(ssn *framework.Session) {}
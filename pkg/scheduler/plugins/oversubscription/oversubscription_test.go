@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oversubscription
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestOversubscription(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{PluginName: New}
+	batch := api.BuildResourceList("0", "0", []api.ScalarResource{
+		{Name: "kubernetes.io/batch-cpu", Value: "1000"},
+		{Name: "kubernetes.io/batch-memory", Value: "1Gi"},
+	}...)
+	allocatable := api.BuildResourceList("4", "4Gi", []api.ScalarResource{
+		{Name: "kubernetes.io/batch-cpu", Value: "2000"},
+		{Name: "kubernetes.io/batch-memory", Value: "2Gi"},
+		{Name: "pods", Value: "10"},
+	}...)
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		arguments      framework.Arguments
+		expectedStatus map[string]int
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "only the queues allowed to use oversubscription resources request them",
+				PodGroups: []*schedulingv1.PodGroup{
+					util.BuildPodGroup("pg1", "c1", "offline", 1, nil, schedulingv1.PodGroupInqueue),
+					util.BuildPodGroup("pg2", "c1", "online", 1, nil, schedulingv1.PodGroupInqueue),
+					util.BuildPodGroup("pg3", "c1", "online", 1, nil, schedulingv1.PodGroupInqueue),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "p1", "", v1.PodPending, batch, "pg1", nil, nil),
+					util.BuildPod("c1", "p2", "", v1.PodPending, batch, "pg2", nil, nil),
+					util.BuildPod("c1", "p3", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg3", nil, nil),
+				},
+				Queues: []*schedulingv1.Queue{
+					util.BuildQueueWithAnnos("offline", 1, nil, map[string]string{api.QueueOversubscription: "true"}),
+					util.BuildQueue("online", 1, nil),
+				},
+				Nodes: []*v1.Node{util.BuildNode("n1", allocatable, nil)},
+			},
+			arguments: framework.Arguments{},
+			expectedStatus: map[string]int{
+				"p1": api.Success,
+				"p2": api.UnschedulableAndUnresolvable,
+				"p3": api.Success,
+			},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "resources not configured are not restricted",
+				PodGroups: []*schedulingv1.PodGroup{
+					util.BuildPodGroup("pg1", "c1", "online", 1, nil, schedulingv1.PodGroupInqueue),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "p1", "", v1.PodPending, batch, "pg1", nil, nil),
+				},
+				Queues: []*schedulingv1.Queue{
+					util.BuildQueue("online", 1, nil),
+				},
+				Nodes: []*v1.Node{util.BuildNode("n1", allocatable, nil)},
+			},
+			arguments: framework.Arguments{ResourcesKey: "example.com/batch-cpu"},
+			expectedStatus: map[string]int{
+				"p1": api.Success,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Plugins = plugins
+			trueValue := true
+			tiers := []conf.Tier{
+				{
+					Plugins: []conf.PluginOption{
+						{
+							Name:             PluginName,
+							EnabledPredicate: &trueValue,
+							Arguments:        test.arguments,
+						},
+					},
+				},
+			}
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+
+			for _, job := range ssn.Jobs {
+				for _, task := range job.Tasks {
+					code := api.Success
+					if err := ssn.PredicateFn(task, ssn.Nodes["n1"]); err != nil {
+						code = err.(*api.FitError).Status[0].Code
+					}
+					if expected := test.expectedStatus[task.Name]; expected != code {
+						t.Errorf("task %s expected status code %v, got %v", task.Name, expected, code)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicates
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// nodeOSMismatch is the reason of node os predicate failure.
+const nodeOSMismatch = "node(s) didn't match pod os"
+
+// checkNodeOS checks that the operating system declared by spec.os of the pod matches the
+// kubernetes.io/os label of the node. Kube-scheduler does not enforce spec.os, so a windows pod
+// without node selector would otherwise be bound to a linux node and rejected by kubelet.
+func checkNodeOS(pod *v1.Pod, node *v1.Node) *api.Status {
+	if pod.Spec.OS == nil || pod.Spec.OS.Name == "" || node == nil {
+		return &api.Status{Code: api.Success}
+	}
+
+	nodeOS, found := node.Labels[v1.LabelOSStable]
+	if !found {
+		// nodes are labeled by kubelet, skip the nodes registered without the label
+		return &api.Status{Code: api.Success}
+	}
+	if nodeOS != string(pod.Spec.OS.Name) {
+		return &api.Status{
+			Code:   api.UnschedulableAndUnresolvable,
+			Reason: fmt.Sprintf("%s: pod requires %s, node is %s", nodeOSMismatch, pod.Spec.OS.Name, nodeOS),
+			Plugin: PluginName,
+		}
+	}
+	return &api.Status{Code: api.Success}
+}
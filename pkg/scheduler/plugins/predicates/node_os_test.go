@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicates
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+func TestCheckNodeOS(t *testing.T) {
+	buildNode := func(os string) *v1.Node {
+		node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1", Labels: map[string]string{}}}
+		if os != "" {
+			node.Labels[v1.LabelOSStable] = os
+		}
+		return node
+	}
+	buildPod := func(os v1.OSName) *v1.Pod {
+		pod := &v1.Pod{}
+		if os != "" {
+			pod.Spec.OS = &v1.PodOS{Name: os}
+		}
+		return pod
+	}
+
+	testCases := []struct {
+		name     string
+		pod      *v1.Pod
+		node     *v1.Node
+		expected int
+	}{
+		{
+			name:     "pod without os",
+			pod:      buildPod(""),
+			node:     buildNode("windows"),
+			expected: api.Success,
+		},
+		{
+			name:     "windows pod on windows node",
+			pod:      buildPod(v1.Windows),
+			node:     buildNode("windows"),
+			expected: api.Success,
+		},
+		{
+			name:     "windows pod on linux node",
+			pod:      buildPod(v1.Windows),
+			node:     buildNode("linux"),
+			expected: api.UnschedulableAndUnresolvable,
+		},
+		{
+			name:     "node without os label",
+			pod:      buildPod(v1.Linux),
+			node:     buildNode(""),
+			expected: api.Success,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if status := checkNodeOS(tc.pod, tc.node); status.Code != tc.expected {
+				t.Errorf("expected code %d, got %d: %s", tc.expected, status.Code, status.Reason)
+			}
+		})
+	}
+}
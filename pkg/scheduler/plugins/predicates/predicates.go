@@ -86,6 +86,9 @@ const (
 	// DynamicResourceAllocationEnable is the key for enabling Dynamic Resource Allocation Predicates in scheduler configmap
 	DynamicResourceAllocationEnable = "predicate.DynamicResourceAllocationEnable"
 
+	// NodeOSEnable is the key for enabling Node OS Predicates in scheduler configmap
+	NodeOSEnable = "predicate.NodeOSEnable"
+
 	// CachePredicate control cache predicate feature
 	CachePredicate = "predicate.CacheEnable"
 )
@@ -124,6 +127,7 @@ type predicateEnable struct {
 	cacheEnable                     bool
 	volumeBindingEnable             bool
 	dynamicResourceAllocationEnable bool
+	nodeOSEnable                    bool
 }
 
 // bind context extension information of predicates
@@ -153,6 +157,7 @@ func enablePredicate(args framework.Arguments) predicateEnable {
 	         predicate.NodeVolumeLimitsEnable: true
 	         predicate.VolumeZoneEnable: true
 	         predicate.PodTopologySpreadEnable: true
+	         predicate.NodeOSEnable: true
 	         predicate.GPUSharingEnable: true
 	         predicate.GPUNumberEnable: true
 	         predicate.CacheEnable: true
@@ -171,6 +176,7 @@ func enablePredicate(args framework.Arguments) predicateEnable {
 		cacheEnable:                     false,
 		volumeBindingEnable:             true,
 		dynamicResourceAllocationEnable: false,
+		nodeOSEnable:                    true,
 	}
 
 	// Checks whether predicate enable args is provided or not.
@@ -184,6 +190,7 @@ func enablePredicate(args framework.Arguments) predicateEnable {
 	args.GetBool(&predicate.podTopologySpreadEnable, PodTopologySpreadEnable)
 	args.GetBool(&predicate.volumeBindingEnable, VolumeBindingEnable)
 	args.GetBool(&predicate.dynamicResourceAllocationEnable, DynamicResourceAllocationEnable)
+	args.GetBool(&predicate.nodeOSEnable, NodeOSEnable)
 	args.GetBool(&predicate.cacheEnable, CachePredicate)
 
 	return predicate
@@ -480,6 +487,17 @@ func (pp *predicatesPlugin) OnSessionOpen(ssn *framework.Session) {
 				}
 			}
 
+			// Check the os declared by pod spec
+			if predicate.nodeOSEnable {
+				nodeOSStatus := checkNodeOS(task.Pod, nodeInfo.Node())
+				if nodeOSStatus.Code != api.Success {
+					predicateStatus = append(predicateStatus, nodeOSStatus)
+					if util.ShouldAbort(nodeOSStatus) {
+						return predicateStatus, false, fmt.Errorf("plugin %s predicates failed %s", PluginName, nodeOSStatus.Reason)
+					}
+				}
+			}
+
 			// PodToleratesNodeTaints: TaintToleration
 			if predicate.taintTolerationEnable {
 				status := tolerationFilter.Filter(context.TODO(), state, task.Pod, nodeInfo)
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priority
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/util"
+)
+
+const (
+	// JobPriorityClassAnnotationKey is the annotation of jobs or podgroups naming the JobPriorityClass of the job.
+	JobPriorityClassAnnotationKey = "volcano.sh/job-priority-class"
+
+	// PreemptLowerPriority permits the jobs to preempt the jobs of lower priority in the same queue.
+	PreemptLowerPriority = "PreemptLowerPriority"
+	// PreemptNever forbids the jobs to preempt any job.
+	PreemptNever = "Never"
+)
+
+// JobPriorityClassGroupVersionResource is the resource of the JobPriorityClass CRD,
+// see config/crd/volcano/bases/scheduling.volcano.sh_jobpriorityclasses.yaml.
+var JobPriorityClassGroupVersionResource = schema.GroupVersionResource{
+	Group:    "scheduling.volcano.sh",
+	Version:  "v1beta1",
+	Resource: "jobpriorityclasses",
+}
+
+// JobPriorityClass is the priority of the jobs within their queue. Unlike PriorityClass, it is not
+// propagated to the pods, so the batch priority does not leak into the eviction of kubelet.
+type JobPriorityClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Value is the priority of the jobs of the class, the higher the value, the higher the priority.
+	Value int32 `json:"value"`
+	// PreemptionPolicy is whether the jobs of the class preempt the jobs of lower priority in the same queue,
+	// PreemptLowerPriority if empty.
+	PreemptionPolicy string `json:"preemptionPolicy,omitempty"`
+	// Description describes when the class should be used.
+	Description string `json:"description,omitempty"`
+}
+
+// jobPriority is the priority of a job given by its JobPriorityClass.
+type jobPriority struct {
+	value   int32
+	preempt bool
+}
+
+// jobPriorities returns the priorities of the jobs naming an existing JobPriorityClass, the jobs
+// without a JobPriorityClass are not in the result.
+func jobPriorities(classes []*JobPriorityClass, jobs map[api.JobID]*api.JobInfo) map[api.JobID]jobPriority {
+	byName := make(map[string]*JobPriorityClass, len(classes))
+	for _, class := range classes {
+		byName[class.Name] = class
+	}
+
+	priorities := map[api.JobID]jobPriority{}
+	for _, job := range jobs {
+		if job.PodGroup == nil {
+			continue
+		}
+		name := job.PodGroup.Annotations[JobPriorityClassAnnotationKey]
+		if name == "" {
+			continue
+		}
+		class, found := byName[name]
+		if !found {
+			klog.V(3).Infof("JobPriorityClass <%s> of job <%s/%s> is not found", name, job.Namespace, job.Name)
+			continue
+		}
+		priorities[job.UID] = jobPriority{
+			value:   class.Value,
+			preempt: class.PreemptionPolicy != PreemptNever,
+		}
+	}
+	return priorities
+}
+
+func jobPriorityClassFromUnstructured(obj interface{}) (*JobPriorityClass, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T", obj)
+	}
+	class := &JobPriorityClass{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), class); err != nil {
+		return nil, fmt.Errorf("failed to convert job priority class <%s>: %v", u.GetName(), err)
+	}
+	return class, nil
+}
+
+// jobPriorityClassInformer watches the job priority classes, it is started on first use.
+var jobPriorityClassInformer = util.NewDynamicInformer(JobPriorityClassGroupVersionResource)
+
+// listJobPriorityClasses lists the job priority classes in the informer cache, nothing is listed until the cache is synced.
+func listJobPriorityClasses(config *rest.Config, stopCh <-chan struct{}) ([]*JobPriorityClass, error) {
+	objs, err := jobPriorityClassInformer.List(config, stopCh)
+	if err != nil {
+		return nil, err
+	}
+	classes := make([]*JobPriorityClass, 0, len(objs))
+	for _, obj := range objs {
+		class, err := jobPriorityClassFromUnstructured(obj)
+		if err != nil {
+			klog.Warningf("Ignore job priority class: %v", err)
+			continue
+		}
+		classes = append(classes, class)
+	}
+	return classes, nil
+}
@@ -29,17 +29,49 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
 )
 
-// PluginName indicates name of volcano scheduler plugin.
-const PluginName = "priority"
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "priority"
+
+	// EnableJobPriorityClassKey is the argument to order and preempt the jobs within a queue by their JobPriorityClass.
+	EnableJobPriorityClassKey = "priority.enableJobPriorityClass"
+)
+
+// User should create a JobPriorityClass, e.g.
+//
+//	apiVersion: scheduling.volcano.sh/v1beta1
+//	kind: JobPriorityClass
+//	metadata:
+//	  name: urgent
+//	value: 1000
+//	preemptionPolicy: PreemptLowerPriority
+//
+// annotate the jobs with `volcano.sh/job-priority-class: urgent`, and enable the argument:
+//
+//	tiers:
+//	- plugins:
+//	  - name: priority
+//	    arguments:
+//	      priority.enableJobPriorityClass: true
+//
+// The jobs of a queue are ordered by the values of their JobPriorityClass before the priority of their
+// PriorityClass, the jobs without a JobPriorityClass have the value 0. A job of a JobPriorityClass only
+// preempts the jobs of lower value in the same queue, and never if the preemption policy is Never.
 
 type priorityPlugin struct {
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
+
+	enableJobPriorityClass bool
+	// jobPriorities is the priorities of the jobs given by their JobPriorityClass
+	jobPriorities map[api.JobID]jobPriority
 }
 
 // New return priority plugin
 func New(arguments framework.Arguments) framework.Plugin {
-	return &priorityPlugin{pluginArguments: arguments}
+	pp := &priorityPlugin{pluginArguments: arguments}
+	arguments.GetBool(&pp.enableJobPriorityClass, EnableJobPriorityClassKey)
+	return pp
 }
 
 func (pp *priorityPlugin) Name() string {
@@ -47,6 +79,14 @@ func (pp *priorityPlugin) Name() string {
 }
 
 func (pp *priorityPlugin) OnSessionOpen(ssn *framework.Session) {
+	if pp.enableJobPriorityClass {
+		classes, err := listJobPriorityClasses(ssn.ClientConfig(), ssn.StopCh())
+		if err != nil {
+			klog.Errorf("Failed to list job priority classes: %v", err)
+		}
+		pp.jobPriorities = jobPriorities(classes, ssn.Jobs)
+	}
+
 	taskOrderFn := func(l interface{}, r interface{}) int {
 		lv := l.(*api.TaskInfo)
 		rv := r.(*api.TaskInfo)
@@ -75,6 +115,12 @@ func (pp *priorityPlugin) OnSessionOpen(ssn *framework.Session) {
 		klog.V(4).Infof("Priority JobOrderFn: <%v/%v> priority: %d, <%v/%v> priority: %d",
 			lv.Namespace, lv.Name, lv.Priority, rv.Namespace, rv.Name, rv.Priority)
 
+		if lv.Queue == rv.Queue {
+			if result := pp.compareJobPriorityClass(lv, rv); result != 0 {
+				return result
+			}
+		}
+
 		if lv.Priority > rv.Priority {
 			return -1
 		}
@@ -95,7 +141,11 @@ func (pp *priorityPlugin) OnSessionOpen(ssn *framework.Session) {
 		for _, preemptee := range preemptees {
 			preempteeJob := ssn.Jobs[preemptee.Job]
 			if preempteeJob.UID != preemptorJob.UID {
-				if preempteeJob.Priority >= preemptorJob.Priority { // Preemption between Jobs within Queue
+				if pp.hasJobPriorityClass(preemptorJob) || pp.hasJobPriorityClass(preempteeJob) {
+					if pp.canPreemptByJobPriorityClass(preemptorJob, preempteeJob) {
+						victims = append(victims, preemptee)
+					}
+				} else if preempteeJob.Priority >= preemptorJob.Priority { // Preemption between Jobs within Queue
 					klog.V(4).Infof("Can not preempt task <%v/%v>"+
 						"because preemptee job has greater or equal job priority (%d) than preemptor (%d)",
 						preemptee.Namespace, preemptee.Name, preempteeJob.Priority, preemptorJob.Priority)
@@ -125,4 +175,49 @@ func (pp *priorityPlugin) OnSessionOpen(ssn *framework.Session) {
 	ssn.AddJobStarvingFns(pp.Name(), jobStarvingFn)
 }
 
-func (pp *priorityPlugin) OnSessionClose(ssn *framework.Session) {}
+func (pp *priorityPlugin) OnSessionClose(ssn *framework.Session) {
+	pp.jobPriorities = nil
+}
+
+func (pp *priorityPlugin) hasJobPriorityClass(job *api.JobInfo) bool {
+	_, found := pp.jobPriorities[job.UID]
+	return found
+}
+
+// compareJobPriorityClass compares the jobs by the values of their JobPriorityClass, the jobs
+// without a JobPriorityClass have the value 0. It returns 0 if neither job has a JobPriorityClass.
+func (pp *priorityPlugin) compareJobPriorityClass(l, r *api.JobInfo) int {
+	if !pp.hasJobPriorityClass(l) && !pp.hasJobPriorityClass(r) {
+		return 0
+	}
+	lp, rp := pp.jobPriorities[l.UID], pp.jobPriorities[r.UID]
+	if lp.value > rp.value {
+		return -1
+	}
+	if lp.value < rp.value {
+		return 1
+	}
+	return 0
+}
+
+// canPreemptByJobPriorityClass returns whether the preemptor job preempts the preemptee job by their
+// JobPriorityClass, the preemption is limited to the jobs of lower value in the same queue.
+func (pp *priorityPlugin) canPreemptByJobPriorityClass(preemptor, preemptee *api.JobInfo) bool {
+	if preemptor.Queue != preemptee.Queue {
+		klog.V(4).Infof("Can not preempt job <%v/%v> because it is not in the queue <%v> of preemptor by job priority class",
+			preemptee.Namespace, preemptee.Name, preemptor.Queue)
+		return false
+	}
+	preemptorPriority, preempteePriority := pp.jobPriorities[preemptor.UID], pp.jobPriorities[preemptee.UID]
+	if pp.hasJobPriorityClass(preemptor) && !preemptorPriority.preempt {
+		klog.V(4).Infof("Job <%v/%v> can not preempt because the preemption policy of its job priority class is %s",
+			preemptor.Namespace, preemptor.Name, PreemptNever)
+		return false
+	}
+	if preempteePriority.value >= preemptorPriority.value {
+		klog.V(4).Infof("Can not preempt job <%v/%v> because it has greater or equal job priority class value (%d) than preemptor (%d)",
+			preemptee.Namespace, preemptee.Name, preempteePriority.value, preemptorPriority.value)
+		return false
+	}
+	return true
+}
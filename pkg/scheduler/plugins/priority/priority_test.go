@@ -21,7 +21,9 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"volcano.sh/apis/pkg/apis/scheduling"
 	vcapisv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
@@ -151,3 +153,74 @@ func TestPreempt(t *testing.T) {
 		})
 	}
 }
+
+func TestJobPriorityClass(t *testing.T) {
+	buildJob := func(name, queue, class string) *api.JobInfo {
+		job := api.NewJobInfo(api.JobID("ns1/" + name))
+		job.SetPodGroup(&api.PodGroup{
+			PodGroup: scheduling.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns1",
+					Name:        name,
+					Annotations: map[string]string{JobPriorityClassAnnotationKey: class},
+				},
+				Spec: scheduling.PodGroupSpec{Queue: queue},
+			},
+		})
+		return job
+	}
+	classes := []*JobPriorityClass{
+		{ObjectMeta: metav1.ObjectMeta{Name: "urgent"}, Value: 1000},
+		{ObjectMeta: metav1.ObjectMeta{Name: "batch"}, Value: 100},
+		{ObjectMeta: metav1.ObjectMeta{Name: "polite"}, Value: 2000, PreemptionPolicy: PreemptNever},
+	}
+	urgent := buildJob("urgent", "q1", "urgent")
+	batch := buildJob("batch", "q1", "batch")
+	polite := buildJob("polite", "q1", "polite")
+	plain := buildJob("plain", "q1", "")
+	unknown := buildJob("unknown", "q1", "unknown")
+	other := buildJob("other", "q2", "batch")
+	jobs := map[api.JobID]*api.JobInfo{}
+	for _, job := range []*api.JobInfo{urgent, batch, polite, plain, unknown, other} {
+		jobs[job.UID] = job
+	}
+
+	pp := &priorityPlugin{jobPriorities: jobPriorities(classes, jobs)}
+	if len(pp.jobPriorities) != 4 {
+		t.Fatalf("expected the priorities of 4 jobs, got %v", pp.jobPriorities)
+	}
+
+	orders := []struct {
+		l, r     *api.JobInfo
+		expected int
+	}{
+		{l: urgent, r: batch, expected: -1},
+		{l: batch, r: polite, expected: 1},
+		{l: plain, r: batch, expected: 1},
+		{l: plain, r: unknown, expected: 0},
+		{l: batch, r: other, expected: 0},
+	}
+	for _, order := range orders {
+		if got := pp.compareJobPriorityClass(order.l, order.r); got != order.expected {
+			t.Errorf("expected %d comparing %s to %s, got %d", order.expected, order.l.Name, order.r.Name, got)
+		}
+	}
+
+	preemptions := []struct {
+		preemptor, preemptee *api.JobInfo
+		expected             bool
+	}{
+		{preemptor: urgent, preemptee: batch, expected: true},
+		{preemptor: urgent, preemptee: plain, expected: true},
+		{preemptor: batch, preemptee: urgent, expected: false},
+		{preemptor: polite, preemptee: batch, expected: false},
+		{preemptor: urgent, preemptee: other, expected: false},
+		{preemptor: plain, preemptee: batch, expected: false},
+	}
+	for _, preemption := range preemptions {
+		if got := pp.canPreemptByJobPriorityClass(preemption.preemptor, preemption.preemptee); got != preemption.expected {
+			t.Errorf("expected %v preempting %s by %s, got %v",
+				preemption.expected, preemption.preemptee.Name, preemption.preemptor.Name, got)
+		}
+	}
+}
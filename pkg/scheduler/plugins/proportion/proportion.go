@@ -26,6 +26,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -44,12 +45,23 @@ import (
 const (
 	PluginName         = "proportion"
 	proportionStateKey = "proportionState"
+
+	// ShareCPU is the key of the weight of cpu in the share of queues.
+	ShareCPU = "proportion.cpu"
+	// ShareMemory is the key of the weight of memory in the share of queues.
+	ShareMemory = "proportion.memory"
+	// ShareResources is the key of the scalar resources weighted in the share of queues.
+	ShareResources = "proportion.resources"
+	// ShareResourcesPrefix is the key prefix of the weight of a scalar resource in the share of queues.
+	ShareResourcesPrefix = ShareResources + "."
 )
 
 type proportionPlugin struct {
 	totalResource  *api.Resource
 	totalGuarantee *api.Resource
 	queueOpts      map[api.QueueID]*queueAttr
+	// shareWeights is the weight of each resource in the dominant share of queues, 1 if absent
+	shareWeights map[v1.ResourceName]float64
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
 }
@@ -79,10 +91,50 @@ func New(arguments framework.Arguments) framework.Plugin {
 		totalResource:   api.EmptyResource(),
 		totalGuarantee:  api.EmptyResource(),
 		queueOpts:       map[api.QueueID]*queueAttr{},
+		shareWeights:    parseShareWeights(arguments),
 		pluginArguments: arguments,
 	}
 }
 
+// parseShareWeights parses the weights of resources in the dominant share of queues, e.g.
+//
+//   - name: proportion
+//     arguments:
+//     proportion.cpu: 1
+//     proportion.memory: 1
+//     proportion.resources: nvidia.com/gpu
+//     proportion.resources.nvidia.com/gpu: 0.5
+//
+// A resource weighted 0 is not taken into account, and negative weights are reset to 1.
+func parseShareWeights(args framework.Arguments) map[v1.ResourceName]float64 {
+	weights := map[v1.ResourceName]float64{}
+
+	cpu, memory := 1.0, 1.0
+	args.GetFloat64(&cpu, ShareCPU)
+	args.GetFloat64(&memory, ShareMemory)
+	weights[v1.ResourceCPU] = cpu
+	weights[v1.ResourceMemory] = memory
+
+	resources, _ := args[ShareResources].(string)
+	for _, resource := range strings.Split(resources, ",") {
+		resource = strings.TrimSpace(resource)
+		if resource == "" {
+			continue
+		}
+		weight := 1.0
+		args.GetFloat64(&weight, ShareResourcesPrefix+resource)
+		weights[v1.ResourceName(resource)] = weight
+	}
+
+	for name, weight := range weights {
+		if weight < 0 {
+			klog.Warningf("Invalid weight %v of resource <%s> in proportion share, use 1 instead", weight, name)
+			weights[name] = 1
+		}
+	}
+	return weights
+}
+
 func (pp *proportionPlugin) Name() string {
 	return PluginName
 }
@@ -130,6 +182,10 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 				attr.guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
 			}
 			realCapability := api.ExceededPart(pp.totalResource, pp.totalGuarantee).Add(attr.guarantee)
+			// the queue bound to a node pool can not use more than the nodes in the pool
+			if nodePoolCapability := queue.NodePoolCapability(); nodePoolCapability != nil {
+				realCapability.MinDimensionResource(nodePoolCapability, api.Zero)
+			}
 			if attr.capability == nil {
 				attr.capability = api.EmptyResource()
 				attr.realCapability = realCapability
@@ -186,7 +242,9 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 		metrics.UpdateQueueRequest(queueInfo.Name, 0, 0, map[v1.ResourceName]float64{})
 	}
 
-	remaining := pp.totalResource.Clone()
+	// the idle resources reserved for the queues by their lending limits are not deserved by other queues
+	borrowingCapabilities, reservedIdle := pp.elasticQuota(ssn)
+	remaining := api.ExceededPart(pp.totalResource, reservedIdle)
 	meet := map[api.QueueID]struct{}{}
 	for {
 		totalWeight := int32(0)
@@ -222,6 +280,9 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 			if attr.realCapability != nil {
 				attr.deserved.MinDimensionResource(attr.realCapability, api.Infinity)
 			}
+			if borrowingCapability, found := borrowingCapabilities[attr.queueID]; found {
+				attr.deserved.MinDimensionResource(borrowingCapability, api.Infinity)
+			}
 			attr.deserved.MinDimensionResource(attr.request, api.Zero)
 
 			attr.deserved = helpers.Max(attr.deserved, attr.guarantee)
@@ -312,8 +373,8 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 	})
 
 	queueAllocatable := func(queue *api.QueueInfo, candidate *api.TaskInfo) bool {
-		if queue.Queue.Status.State != scheduling.QueueStateOpen {
-			klog.V(3).Infof("Queue <%s> current state: %s, is not in open state, can not allocate task <%s>.", queue.Name, queue.Queue.Status.State, candidate.Name)
+		if !queue.Allocatable() {
+			klog.V(3).Infof("Queue <%s> current state: %s, is not allocatable, can not allocate task <%s>.", queue.Name, queue.Queue.Status.State, candidate.Name)
 			return false
 		}
 
@@ -426,7 +487,7 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 			return fmt.Errorf("queue %s not found", job.Queue)
 		}
 		attr.allocated.Add(taskToAdd.Resreq)
-		updateQueueAttrShare(attr)
+		updateQueueAttrShare(attr, pp.shareWeights)
 		return nil
 	})
 
@@ -442,7 +503,7 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 			return fmt.Errorf("queue %s not found", job.Queue)
 		}
 		attr.allocated.Sub(taskToRemove.Resreq)
-		updateQueueAttrShare(attr)
+		updateQueueAttrShare(attr, pp.shareWeights)
 		return nil
 	})
 
@@ -480,7 +541,7 @@ func (pp *proportionPlugin) OnSessionClose(ssn *framework.Session) {
 }
 
 func (pp *proportionPlugin) updateShare(attr *queueAttr) {
-	updateQueueAttrShare(attr)
+	updateQueueAttrShare(attr, pp.shareWeights)
 	metrics.UpdateQueueShare(attr.name, attr.share)
 }
 
@@ -488,6 +549,36 @@ type proportionState struct {
 	queueAttrs map[api.QueueID]*queueAttr
 }
 
+// elasticQuota returns the max deserved resources of the queues with borrowing limits, which are their fair shares
+// plus the limits, and the idle resources reserved for the queues with lending limits, which are their fair shares
+// beyond the limits and not requested by them. The fair share of a queue is its weighted part of the total resources.
+func (pp *proportionPlugin) elasticQuota(ssn *framework.Session) (map[api.QueueID]*api.Resource, *api.Resource) {
+	borrowingCapabilities := map[api.QueueID]*api.Resource{}
+	reservedIdle := api.EmptyResource()
+
+	totalWeight := int32(0)
+	for _, attr := range pp.queueOpts {
+		totalWeight += attr.weight
+	}
+	if totalWeight == 0 {
+		return borrowingCapabilities, reservedIdle
+	}
+
+	for _, attr := range pp.queueOpts {
+		queue := ssn.Queues[attr.queueID]
+		fairShare := pp.totalResource.Clone().Multi(float64(attr.weight) / float64(totalWeight))
+		if limit := queue.BorrowingLimit(); limit != nil {
+			borrowingCapabilities[attr.queueID] = helpers.BorrowingCapability(fairShare, limit)
+		}
+		if limit := queue.LendingLimit(); limit != nil {
+			reserved := helpers.LendingReserved(fairShare, api.EmptyResource(), limit)
+			reservedIdle.Add(api.ExceededPart(reserved, attr.request))
+		}
+	}
+	klog.V(4).Infof("The idle resources reserved by the lending limits of queues are <%v>", reservedIdle)
+	return borrowingCapabilities, reservedIdle
+}
+
 func (qa *queueAttr) Clone() *queueAttr {
 	if qa == nil {
 		return nil
@@ -542,12 +633,18 @@ func getProportionState(cycleState *k8sframework.CycleState) (*proportionState,
 	return s, nil
 }
 
-func updateQueueAttrShare(attr *queueAttr) {
+// updateQueueAttrShare updates the share of the queue to its weighted dominant share, i.e. the max of the
+// allocated/deserved ratio of each resource multiplied by the weight of the resource.
+func updateQueueAttrShare(attr *queueAttr, weights map[v1.ResourceName]float64) {
 	res := float64(0)
 
 	// TODO(k82cn): how to handle fragment issues?
 	for _, rn := range attr.deserved.ResourceNames() {
-		share := helpers.Share(attr.allocated.Get(rn), attr.deserved.Get(rn))
+		weight, found := weights[rn]
+		if !found {
+			weight = 1
+		}
+		share := weight * helpers.Share(attr.allocated.Get(rn), attr.deserved.Get(rn))
 		if share > res {
 			res = share
 		}
@@ -18,6 +18,7 @@ package proportion
 
 import (
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"strconv"
@@ -28,6 +29,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	apiv1 "k8s.io/api/core/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
@@ -507,3 +509,106 @@ func TestAllocate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseShareWeights(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments framework.Arguments
+		expected  map[apiv1.ResourceName]float64
+	}{
+		{
+			name:      "default weights",
+			arguments: framework.Arguments{},
+			expected: map[apiv1.ResourceName]float64{
+				apiv1.ResourceCPU:    1,
+				apiv1.ResourceMemory: 1,
+			},
+		},
+		{
+			name: "weighted cpu, memory and gpu",
+			arguments: framework.Arguments{
+				ShareCPU:                                2,
+				ShareMemory:                             0.5,
+				ShareResources:                          "nvidia.com/gpu, example.com/foo",
+				ShareResourcesPrefix + "nvidia.com/gpu": 0.25,
+			},
+			expected: map[apiv1.ResourceName]float64{
+				apiv1.ResourceCPU:    2,
+				apiv1.ResourceMemory: 0.5,
+				"nvidia.com/gpu":     0.25,
+				"example.com/foo":    1,
+			},
+		},
+		{
+			name: "negative weights are reset",
+			arguments: framework.Arguments{
+				ShareCPU:                                -1,
+				ShareResources:                          "nvidia.com/gpu",
+				ShareResourcesPrefix + "nvidia.com/gpu": 0,
+			},
+			expected: map[apiv1.ResourceName]float64{
+				apiv1.ResourceCPU:    1,
+				apiv1.ResourceMemory: 1,
+				"nvidia.com/gpu":     0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := parseShareWeights(test.arguments); !equality.Semantic.DeepEqual(got, test.expected) {
+				t.Errorf("expected weights %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestUpdateQueueAttrShare(t *testing.T) {
+	deserved := api.NewResource(apiv1.ResourceList{
+		apiv1.ResourceCPU:    resource.MustParse("10"),
+		apiv1.ResourceMemory: resource.MustParse("10Gi"),
+		"nvidia.com/gpu":     resource.MustParse("8"),
+	})
+	// the queue uses little cpu and memory but most of the deserved gpu
+	allocated := api.NewResource(apiv1.ResourceList{
+		apiv1.ResourceCPU:    resource.MustParse("2"),
+		apiv1.ResourceMemory: resource.MustParse("2Gi"),
+		"nvidia.com/gpu":     resource.MustParse("6"),
+	})
+
+	tests := []struct {
+		name     string
+		weights  map[apiv1.ResourceName]float64
+		expected float64
+	}{
+		{
+			name:     "unweighted dominant share",
+			expected: 0.75,
+		},
+		{
+			name:     "gpu weighted up",
+			weights:  map[apiv1.ResourceName]float64{"nvidia.com/gpu": 2},
+			expected: 1.5,
+		},
+		{
+			name:     "gpu weighted down",
+			weights:  map[apiv1.ResourceName]float64{"nvidia.com/gpu": 0.2},
+			expected: 0.2,
+		},
+		{
+			name:     "gpu ignored",
+			weights:  map[apiv1.ResourceName]float64{apiv1.ResourceCPU: 1, "nvidia.com/gpu": 0},
+			expected: 0.2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attr := &queueAttr{deserved: deserved, allocated: allocated}
+			updateQueueAttrShare(attr, test.weights)
+			if math.Abs(attr.share-test.expected) > 1e-9 {
+				t.Errorf("expected share %v, got %v", test.expected, attr.share)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queuejoborder
+
+import (
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "queue-job-order"
+
+	// DefaultPolicyKey is the argument of the job order policy of the queues without the policy annotation.
+	DefaultPolicyKey = "queue-job-order.defaultPolicy"
+	// DefaultUserLabelKey is the argument of the label of the jobs identifying their users, for the queues of the
+	// user-fair policy without the user label annotation.
+	DefaultUserLabelKey = "queue-job-order.defaultUserLabel"
+
+	// PolicyNone leaves the order of the jobs to the other plugins.
+	PolicyNone = ""
+	// PolicyFIFO orders the jobs by their creation time strictly.
+	PolicyFIFO = "fifo"
+	// PolicyNamespaceFair orders first the jobs of the namespace with the fewest tasks allocated in the queue.
+	PolicyNamespaceFair = "namespace-fair"
+	// PolicyUserFair orders first the jobs of the user with the fewest tasks allocated in the queue, the user of a
+	// job is the value of its user label.
+	PolicyUserFair = "user-fair"
+)
+
+// User should annotate the queue with the policy, e.g.
+//
+//	apiVersion: scheduling.volcano.sh/v1beta1
+//	kind: Queue
+//	metadata:
+//	  name: shared
+//	  annotations:
+//	    volcano.sh/job-order-policy: user-fair
+//	    volcano.sh/job-order-user-label: volcano.sh/user
+//
+// and enable the plugin in the first tier of the scheduler configuration:
+//
+//	tiers:
+//	- plugins:
+//	  - name: queue-job-order
+//	  - name: priority
+//	  - name: gang
+//
+// The jobs of a queue of a fair policy are ordered by the number of tasks allocated to their namespace or user in
+// the queue, including the tasks allocated in the current session, so that the jobs of a namespace or user
+// submitting thousands of jobs are interleaved with the jobs of the others instead of taking all the turns of the
+// queue. The jobs of the same namespace or user are ordered by the other plugins.
+
+type queueJobOrderPlugin struct {
+	// Arguments given for the plugin
+	pluginArguments framework.Arguments
+
+	defaultPolicy    string
+	defaultUserLabel string
+	// allocated is the number of the tasks allocated to the groups, i.e. the namespaces or users, of the queues
+	allocated map[api.QueueID]map[string]int
+}
+
+// New return queue-job-order plugin
+func New(arguments framework.Arguments) framework.Plugin {
+	pp := &queueJobOrderPlugin{pluginArguments: arguments}
+	arguments.GetString(&pp.defaultPolicy, DefaultPolicyKey)
+	arguments.GetString(&pp.defaultUserLabel, DefaultUserLabelKey)
+	if !validPolicy(pp.defaultPolicy) {
+		klog.Warningf("Unknown %s %q, the jobs are ordered by the other plugins", DefaultPolicyKey, pp.defaultPolicy)
+		pp.defaultPolicy = PolicyNone
+	}
+	return pp
+}
+
+func (pp *queueJobOrderPlugin) Name() string {
+	return PluginName
+}
+
+func validPolicy(policy string) bool {
+	switch policy {
+	case PolicyNone, PolicyFIFO, PolicyNamespaceFair, PolicyUserFair:
+		return true
+	default:
+		return false
+	}
+}
+
+// policy returns the job order policy of the queue and the label of the jobs identifying their users.
+func (pp *queueJobOrderPlugin) policy(queue *api.QueueInfo) (string, string) {
+	if queue == nil || queue.Queue == nil {
+		return pp.defaultPolicy, pp.defaultUserLabel
+	}
+	policy, found := queue.Queue.Annotations[api.QueueJobOrderPolicy]
+	if !found {
+		policy = pp.defaultPolicy
+	} else if !validPolicy(policy) {
+		klog.V(4).Infof("Unknown job order policy %q of queue <%s>, use the default", policy, queue.Name)
+		policy = pp.defaultPolicy
+	}
+	userLabel, found := queue.Queue.Annotations[api.QueueJobOrderUserLabel]
+	if !found {
+		userLabel = pp.defaultUserLabel
+	}
+	return policy, userLabel
+}
+
+// group returns the namespace or the user of the job the fairness is kept among.
+func group(job *api.JobInfo, policy, userLabel string) string {
+	if policy == PolicyNamespaceFair {
+		return job.Namespace
+	}
+	if job.PodGroup == nil || userLabel == "" {
+		return ""
+	}
+	return job.PodGroup.Labels[userLabel]
+}
+
+func (pp *queueJobOrderPlugin) addAllocated(ssn *framework.Session, job *api.JobInfo, count int) {
+	policy, userLabel := pp.policy(ssn.Queues[job.Queue])
+	if policy != PolicyNamespaceFair && policy != PolicyUserFair {
+		return
+	}
+	groups, found := pp.allocated[job.Queue]
+	if !found {
+		groups = map[string]int{}
+		pp.allocated[job.Queue] = groups
+	}
+	groups[group(job, policy, userLabel)] += count
+}
+
+func (pp *queueJobOrderPlugin) OnSessionOpen(ssn *framework.Session) {
+	pp.allocated = map[api.QueueID]map[string]int{}
+	for _, job := range ssn.Jobs {
+		count := 0
+		for status, tasks := range job.TaskStatusIndex {
+			if api.AllocatedStatus(status) {
+				count += len(tasks)
+			}
+		}
+		if count > 0 {
+			pp.addAllocated(ssn, job, count)
+		}
+	}
+
+	jobOrderFn := func(l, r interface{}) int {
+		lv := l.(*api.JobInfo)
+		rv := r.(*api.JobInfo)
+		if lv.Queue != rv.Queue {
+			return 0
+		}
+
+		policy, userLabel := pp.policy(ssn.Queues[lv.Queue])
+		switch policy {
+		case PolicyFIFO:
+			if !lv.CreationTimestamp.Equal(&rv.CreationTimestamp) {
+				if lv.CreationTimestamp.Before(&rv.CreationTimestamp) {
+					return -1
+				}
+				return 1
+			}
+			if lv.UID < rv.UID {
+				return -1
+			} else if lv.UID > rv.UID {
+				return 1
+			}
+		case PolicyNamespaceFair, PolicyUserFair:
+			groups := pp.allocated[lv.Queue]
+			lc, rc := groups[group(lv, policy, userLabel)], groups[group(rv, policy, userLabel)]
+			klog.V(5).Infof("QueueJobOrder JobOrder: <%v/%v> group allocated %d, <%v/%v> group allocated %d",
+				lv.Namespace, lv.Name, lc, rv.Namespace, rv.Name, rc)
+			if lc < rc {
+				return -1
+			} else if lc > rc {
+				return 1
+			}
+		}
+		return 0
+	}
+	ssn.AddJobOrderFn(pp.Name(), jobOrderFn)
+
+	ssn.AddEventHandler(&framework.EventHandler{
+		AllocateFunc: func(event *framework.Event) {
+			if job, found := ssn.Jobs[event.Task.Job]; found {
+				pp.addAllocated(ssn, job, 1)
+			}
+		},
+		DeallocateFunc: func(event *framework.Event) {
+			if job, found := ssn.Jobs[event.Task.Job]; found {
+				pp.addAllocated(ssn, job, -1)
+			}
+		},
+	})
+}
+
+func (pp *queueJobOrderPlugin) OnSessionClose(ssn *framework.Session) {
+	pp.allocated = nil
+}
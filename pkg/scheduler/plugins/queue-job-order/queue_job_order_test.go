@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queuejoborder
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vcapisv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func init() {
+	options.Default()
+}
+
+func TestQueueJobOrder(t *testing.T) {
+	trueValue := true
+	plugins := map[string]framework.PluginBuilder{PluginName: New}
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{Name: PluginName, EnabledJobOrder: &trueValue}}}}
+
+	buildQueue := func(annotations map[string]string) *vcapisv1.Queue {
+		queue := util.BuildQueue("q1", 1, nil)
+		queue.Annotations = annotations
+		return queue
+	}
+	buildPodGroup := func(name, ns string, created time.Time, labels map[string]string) *vcapisv1.PodGroup {
+		pg := util.BuildPodGroup(name, ns, "q1", 1, nil, vcapisv1.PodGroupInqueue)
+		pg.CreationTimestamp = metav1.NewTime(created)
+		pg.Labels = labels
+		return pg
+	}
+	now := time.Now()
+
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name:    "namespace-fair orders first the namespace with fewer tasks allocated",
+			Plugins: plugins,
+			PodGroups: []*vcapisv1.PodGroup{
+				buildPodGroup("pg1", "ns1", now.Add(-3*time.Minute), nil),
+				buildPodGroup("pg2", "ns1", now.Add(-2*time.Minute), nil),
+				buildPodGroup("pg3", "ns2", now.Add(-1*time.Minute), nil),
+			},
+			Pods: []*v1.Pod{
+				util.BuildPod("ns1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", nil, nil),
+				util.BuildPod("ns1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", nil, nil),
+				util.BuildPod("ns2", "p3", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg3", nil, nil),
+			},
+			Nodes: []*v1.Node{
+				util.BuildNode("n1", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			},
+			Queues:         []*vcapisv1.Queue{buildQueue(map[string]string{api.QueueJobOrderPolicy: PolicyNamespaceFair})},
+			ExpectBindMap:  map[string]string{"ns2/p3": "n1"},
+			ExpectBindsNum: 1,
+		},
+		{
+			Name:    "user-fair orders first the user with fewer tasks allocated",
+			Plugins: plugins,
+			PodGroups: []*vcapisv1.PodGroup{
+				buildPodGroup("pg1", "ns1", now.Add(-3*time.Minute), map[string]string{"user": "alice"}),
+				buildPodGroup("pg2", "ns1", now.Add(-2*time.Minute), map[string]string{"user": "alice"}),
+				buildPodGroup("pg3", "ns1", now.Add(-1*time.Minute), map[string]string{"user": "bob"}),
+			},
+			Pods: []*v1.Pod{
+				util.BuildPod("ns1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", nil, nil),
+				util.BuildPod("ns1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", nil, nil),
+				util.BuildPod("ns1", "p3", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg3", nil, nil),
+			},
+			Nodes: []*v1.Node{
+				util.BuildNode("n1", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			},
+			Queues: []*vcapisv1.Queue{buildQueue(map[string]string{
+				api.QueueJobOrderPolicy:    PolicyUserFair,
+				api.QueueJobOrderUserLabel: "user",
+			})},
+			ExpectBindMap:  map[string]string{"ns1/p3": "n1"},
+			ExpectBindsNum: 1,
+		},
+		{
+			Name:    "fifo orders the jobs by creation time",
+			Plugins: plugins,
+			PodGroups: []*vcapisv1.PodGroup{
+				buildPodGroup("pg1", "ns1", now.Add(-3*time.Minute), nil),
+				buildPodGroup("pg2", "ns1", now.Add(-2*time.Minute), nil),
+				buildPodGroup("pg3", "ns2", now.Add(-1*time.Minute), nil),
+			},
+			Pods: []*v1.Pod{
+				util.BuildPod("ns1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", nil, nil),
+				util.BuildPod("ns1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", nil, nil),
+				util.BuildPod("ns2", "p3", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg3", nil, nil),
+			},
+			Nodes: []*v1.Node{
+				util.BuildNode("n1", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			},
+			Queues:         []*vcapisv1.Queue{buildQueue(map[string]string{api.QueueJobOrderPolicy: PolicyFIFO})},
+			ExpectBindMap:  map[string]string{"ns1/p2": "n1"},
+			ExpectBindsNum: 1,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run([]framework.Action{allocate.New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,420 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sort"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/klog/v2"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/features"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const (
+	// DefragmentationStrategy is the name of the strategy consolidating the free resources for pending gangs
+	DefragmentationStrategy = "defragmentation"
+	// DefaultMaxEvictions is the default number of tasks evicted by the defragmentation strategy in a run
+	DefaultMaxEvictions = 5
+)
+
+// DefaultDefragmentationConf defines the default configuration for defragmentation strategy
+var DefaultDefragmentationConf = map[string]interface{}{
+	"maxEvictions": DefaultMaxEvictions,
+}
+
+// DefragmentationConf is the configuration of the defragmentation strategy
+type DefragmentationConf struct {
+	// MaxEvictions limits the number of tasks evicted in a run
+	MaxEvictions int
+}
+
+// NewDefragmentationConf returns the pointer of DefragmentationConf object with default value
+func NewDefragmentationConf() *DefragmentationConf {
+	return &DefragmentationConf{
+		MaxEvictions: DefaultMaxEvictions,
+	}
+}
+
+// parse converts the config map to struct object
+func (dc *DefragmentationConf) parse(configs map[string]interface{}) {
+	value, ok := configs["maxEvictions"]
+	if !ok {
+		return
+	}
+	maxEvictions, ok := value.(int)
+	if !ok || maxEvictions < 0 {
+		klog.Warningf("Invalid maxEvictions %v of defragmentation strategy, use %d.", value, dc.MaxEvictions)
+		return
+	}
+	dc.MaxEvictions = maxEvictions
+}
+
+// victimsFnForDefragmentation selects the running tasks to evict so that the pending tasks of starving gangs fit
+// onto nodes. A gang is only helped if all its missing tasks fit, and the victims fit onto other nodes, so the
+// free resources are consolidated rather than reclaimed. The victims are restarted by the retry of their jobs.
+var victimsFnForDefragmentation = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	config := NewDefragmentationConf()
+	if params, ok := RegisteredStrategyConfigs[DefragmentationStrategy].(map[string]interface{}); ok {
+		config.parse(params)
+	}
+	if config.MaxEvictions == 0 {
+		return nil
+	}
+
+	d := newDefragmenter(tasks, config.MaxEvictions)
+	for _, job := range starvingJobs() {
+		if len(d.victims) >= d.maxEvictions {
+			break
+		}
+		d.consolidate(job)
+	}
+	return d.victims
+}
+
+// starvingJobs returns the enqueued jobs which can not run because of missing tasks, in job order.
+func starvingJobs() []*api.JobInfo {
+	var jobs []*api.JobInfo
+	for _, job := range Session.Jobs {
+		if job.IsPending() || !job.HasPendingTasks() || job.IsReady() {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return Session.JobOrderFn(jobs[i], jobs[j])
+	})
+	return jobs
+}
+
+// defragmenter simulates the evictions and placements of a run.
+type defragmenter struct {
+	// idle is the simulated future idle resource of each node
+	idle map[string]*api.Resource
+	// nodeNames is the sorted names of the nodes
+	nodeNames []string
+	// candidates is the running tasks on each node which may be evicted, in eviction order
+	candidates map[string][]*api.TaskInfo
+	evicted    map[api.TaskID]bool
+	victims    []*api.TaskInfo
+
+	maxEvictions int
+	pdbs         *pdbBudget
+}
+
+func newDefragmenter(tasks []*api.TaskInfo, maxEvictions int) *defragmenter {
+	d := &defragmenter{
+		idle:         map[string]*api.Resource{},
+		candidates:   map[string][]*api.TaskInfo{},
+		evicted:      map[api.TaskID]bool{},
+		maxEvictions: maxEvictions,
+		pdbs:         newPDBBudget(),
+	}
+	for name, node := range Session.Nodes {
+		if node.Node == nil || node.Node.Spec.Unschedulable {
+			continue
+		}
+		d.idle[name] = node.FutureIdle()
+		d.nodeNames = append(d.nodeNames, name)
+	}
+	sort.Strings(d.nodeNames)
+	for _, task := range tasks {
+		if task.Status == api.Running && task.NodeName != "" {
+			d.candidates[task.NodeName] = append(d.candidates[task.NodeName], task)
+		}
+	}
+	for _, candidates := range d.candidates {
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].Priority != candidates[j].Priority {
+				return candidates[i].Priority < candidates[j].Priority
+			}
+			return candidates[i].Name < candidates[j].Name
+		})
+	}
+	return d
+}
+
+// plan records the simulated evictions and placements on top of the defragmenter, it is committed
+// once all the missing tasks of a job fit.
+type plan struct {
+	d *defragmenter
+	// idle overrides the idle resource of the nodes changed by the plan
+	idle    map[string]*api.Resource
+	victims map[api.TaskID]*api.TaskInfo
+	// allowed is the disruptions allowed by each pdb after the evictions of the plan
+	allowed []int32
+}
+
+func (d *defragmenter) newPlan() *plan {
+	return &plan{
+		d:       d,
+		idle:    map[string]*api.Resource{},
+		victims: map[api.TaskID]*api.TaskInfo{},
+		allowed: append([]int32(nil), d.pdbs.allowed...),
+	}
+}
+
+func (p *plan) fork() *plan {
+	forked := &plan{
+		d:       p.d,
+		idle:    make(map[string]*api.Resource, len(p.idle)),
+		victims: make(map[api.TaskID]*api.TaskInfo, len(p.victims)),
+		allowed: append([]int32(nil), p.allowed...),
+	}
+	for node, idle := range p.idle {
+		forked.idle[node] = idle.Clone()
+	}
+	for uid, victim := range p.victims {
+		forked.victims[uid] = victim
+	}
+	return forked
+}
+
+func (p *plan) available(node string) *api.Resource {
+	if idle, ok := p.idle[node]; ok {
+		return idle
+	}
+	return p.d.idle[node]
+}
+
+func (p *plan) setAvailable(node string, idle *api.Resource) {
+	p.idle[node] = idle
+}
+
+// placeOn places the task onto the first node it fits except the excluded node, the node must pass the predicates
+// of the task.
+func (p *plan) placeOn(task *api.TaskInfo, resource *api.Resource, excluded string) bool {
+	for _, node := range p.d.nodeNames {
+		if node == excluded || !resource.LessEqual(p.available(node), api.Zero) {
+			continue
+		}
+		if err := Session.PredicateFn(task, Session.Nodes[node]); err != nil {
+			klog.V(5).Infof("Predicates failed for task <%s/%s> on node %s: %v", task.Namespace, task.Name, node, err)
+			continue
+		}
+		p.setAvailable(node, p.available(node).Clone().Sub(resource))
+		return true
+	}
+	return false
+}
+
+// evictable checks whether the running task may be evicted for the job: it must be preemptable, and
+// have lower priority than the job unless it is marked preemptable explicitly, and its eviction must
+// not violate any pdb.
+func (p *plan) evictable(task *api.TaskInfo, job *api.JobInfo) bool {
+	if p.d.evicted[task.UID] || p.victims[task.UID] != nil || task.Job == job.UID || !task.Preemptable {
+		return false
+	}
+	victimJob, found := Session.Jobs[task.Job]
+	if !found || (victimJob.Priority >= job.Priority && !isMarkedPreemptable(task.Pod)) {
+		return false
+	}
+	for _, index := range p.d.pdbs.matches(task) {
+		if p.allowed[index] <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isMarkedPreemptable checks whether the pod is marked preemptable by annotation or label,
+// pods are preemptable by default but only the marked ones are restarted for jobs of the same priority.
+func isMarkedPreemptable(pod *v1.Pod) bool {
+	if pod == nil {
+		return false
+	}
+	value, found := pod.Annotations[schedulingv1beta1.PodPreemptable]
+	if !found {
+		value, found = pod.Labels[schedulingv1beta1.PodPreemptable]
+	}
+	preemptable, err := strconv.ParseBool(value)
+	return found && err == nil && preemptable
+}
+
+func (p *plan) evict(task *api.TaskInfo) {
+	p.victims[task.UID] = task
+	for _, index := range p.d.pdbs.matches(task) {
+		p.allowed[index]--
+	}
+}
+
+// freeFor evicts the tasks on the node until the task fits, and moves the victims onto other nodes. The node is
+// skipped if the task fails the predicates on it for reasons which the evictions can not resolve.
+func (p *plan) freeFor(node string, task *api.TaskInfo, job *api.JobInfo) bool {
+	resource := task.InitResreq
+	if !resource.LessEqual(Session.Nodes[node].Allocatable, api.Zero) {
+		return false
+	}
+	if err := Session.PredicateForPreemptAction(task, Session.Nodes[node]); err != nil {
+		klog.V(5).Infof("Predicates failed for task <%s/%s> on node %s: %v", task.Namespace, task.Name, node, err)
+		return false
+	}
+	available := p.available(node).Clone()
+	var victims []*api.TaskInfo
+	for _, candidate := range p.d.candidates[node] {
+		if resource.LessEqual(available, api.Zero) {
+			break
+		}
+		if !p.evictable(candidate, job) {
+			continue
+		}
+		victims = append(victims, candidate)
+		p.evict(candidate)
+		available.Add(candidate.Resreq)
+	}
+	if !resource.LessEqual(available, api.Zero) {
+		return false
+	}
+	p.setAvailable(node, available.Sub(resource))
+
+	for _, victim := range victims {
+		if !p.placeOn(victim, victim.Resreq, node) {
+			klog.V(4).Infof("Task <%s/%s> can not be moved from node %s.", victim.Namespace, victim.Name, node)
+			return false
+		}
+	}
+	return true
+}
+
+// place simulates the placement of the task, with the fewest evictions if it does not fit without evictions.
+func (p *plan) place(task *api.TaskInfo, job *api.JobInfo) (*plan, bool) {
+	if p.placeOn(task, task.InitResreq, "") {
+		return p, true
+	}
+
+	var best *plan
+	for _, node := range p.d.nodeNames {
+		forked := p.fork()
+		if !forked.freeFor(node, task, job) {
+			continue
+		}
+		if best == nil || len(forked.victims) < len(best.victims) {
+			best = forked
+		}
+	}
+	return best, best != nil
+}
+
+// consolidate evicts tasks so that the missing tasks of the job fit, nothing is evicted if they do not fit.
+func (d *defragmenter) consolidate(job *api.JobInfo) {
+	missing := int(job.MinAvailable - job.ReadyTaskNum() - job.WaitingTaskNum())
+	if missing <= 0 {
+		return
+	}
+	var pending []*api.TaskInfo
+	for _, task := range job.TaskStatusIndex[api.Pending] {
+		pending = append(pending, task)
+	}
+	if len(pending) < missing {
+		return
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return Session.TaskOrderFn(pending[i], pending[j])
+	})
+
+	p := d.newPlan()
+	for _, task := range pending[:missing] {
+		var ok bool
+		if p, ok = p.place(task, job); !ok {
+			klog.V(4).Infof("Failed to consolidate resources for task <%s/%s>, no task is evicted for job <%s>.",
+				task.Namespace, task.Name, job.UID)
+			return
+		}
+	}
+	if len(p.victims) == 0 || len(d.victims)+len(p.victims) > d.maxEvictions {
+		return
+	}
+
+	for node, idle := range p.idle {
+		d.idle[node] = idle
+	}
+	d.pdbs.allowed = p.allowed
+	victims := make([]*api.TaskInfo, 0, len(p.victims))
+	for _, victim := range p.victims {
+		victims = append(victims, victim)
+	}
+	sort.Slice(victims, func(i, j int) bool {
+		return victims[i].Name < victims[j].Name
+	})
+	for _, victim := range victims {
+		d.evicted[victim.UID] = true
+		klog.V(3).Infof("Task <%s/%s> is evicted to consolidate resources for job <%s>.", victim.Namespace, victim.Name, job.UID)
+	}
+	d.victims = append(d.victims, victims...)
+}
+
+// pdbBudget tracks the disruptions allowed by the pdbs, it is empty unless PodDisruptionBudgetsSupport is enabled.
+type pdbBudget struct {
+	pdbs      []*policyv1.PodDisruptionBudget
+	selectors []labels.Selector
+	allowed   []int32
+}
+
+func newPDBBudget() *pdbBudget {
+	budget := &pdbBudget{}
+	if !utilfeature.DefaultFeatureGate.Enabled(features.PodDisruptionBudgetsSupport) || Session.InformerFactory() == nil {
+		return budget
+	}
+	pdbs, err := Session.InformerFactory().Policy().V1().PodDisruptionBudgets().Lister().List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list pdbs: %v", err)
+		return budget
+	}
+	return buildPDBBudget(pdbs)
+}
+
+func buildPDBBudget(pdbs []*policyv1.PodDisruptionBudget) *pdbBudget {
+	budget := &pdbBudget{}
+	for _, pdb := range pdbs {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		// A PDB with a nil or empty selector matches nothing.
+		if err != nil || selector.Empty() {
+			continue
+		}
+		budget.pdbs = append(budget.pdbs, pdb)
+		budget.selectors = append(budget.selectors, selector)
+		budget.allowed = append(budget.allowed, pdb.Status.DisruptionsAllowed)
+	}
+	return budget
+}
+
+// matches returns the indexes of the pdbs whose budget is consumed by evicting the task.
+func (b *pdbBudget) matches(task *api.TaskInfo) []int {
+	var indexes []int
+	pod := task.Pod
+	if pod == nil || len(pod.Labels) == 0 {
+		return nil
+	}
+	for i, pdb := range b.pdbs {
+		if pdb.Namespace != pod.Namespace || !b.selectors[i].Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		// Existing in DisruptedPods means it has been processed in API server.
+		if _, exist := pdb.Status.DisruptedPods[pod.Name]; exist {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
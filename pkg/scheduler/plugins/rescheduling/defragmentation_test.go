@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/actions/shuffle"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestDefragmentation(t *testing.T) {
+	priorityClasses := []*schedulingv1.PriorityClass{
+		util.BuildPriorityClass("high-priority", 100000),
+		util.BuildPriorityClass("low-priority", 10),
+	}
+	nodes := []*v1.Node{
+		util.BuildNode("n1", api.BuildResourceList("4", "4G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+		util.BuildNode("n2", api.BuildResourceList("4", "4G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+	}
+	queues := []*schedulingv1beta1.Queue{util.BuildQueue("q1", 1, nil)}
+	preemptable := map[string]string{schedulingv1beta1.PodPreemptable: "true"}
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		maxEvictions int
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "move low priority task to fit pending gang",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "running1", "n1", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "running2", "n2", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "pending1", "", v1.PodPending, api.BuildResourceList("4", "4G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/running1"},
+			},
+			maxEvictions: 5,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "move restartable task of same priority",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, "high-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "running1", "n1", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "running2", "n2", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "pending1", "", v1.PodPending, api.BuildResourceList("4", "4G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/running2"},
+			},
+			maxEvictions: 5,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "do not evict task of same priority",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, "high-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "running1", "n1", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "running2", "n2", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "pending1", "", v1.PodPending, api.BuildResourceList("4", "4G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				ExpectEvictNum: 0,
+			},
+			maxEvictions: 5,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "do not evict task which can not be moved",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "running1", "n1", v1.PodRunning, api.BuildResourceList("3", "3G"), "pg1", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "running2", "n2", v1.PodRunning, api.BuildResourceList("3", "3G"), "pg1", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "pending1", "", v1.PodPending, api.BuildResourceList("4", "4G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				ExpectEvictNum: 0,
+			},
+			maxEvictions: 5,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "do not evict if the whole gang can not fit",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "running1", "n1", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "running2", "n2", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "pending1", "", v1.PodPending, api.BuildResourceList("4", "4G"), "pg2", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "pending2", "", v1.PodPending, api.BuildResourceList("4", "4G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				ExpectEvictNum: 0,
+			},
+			maxEvictions: 5,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "evictions are limited",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "running1", "n1", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "running2", "n2", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", make(map[string]string), make(map[string]string)),
+					util.BuildPod("c1", "pending1", "", v1.PodPending, api.BuildResourceList("4", "4G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				ExpectEvictNum: 0,
+			},
+			maxEvictions: 0,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Plugins = map[string]framework.PluginBuilder{PluginName: New}
+			test.Nodes = nodes
+			test.Queues = queues
+			test.PriClass = priorityClasses
+			trueValue := true
+			tiers := []conf.Tier{
+				{
+					Plugins: []conf.PluginOption{
+						{
+							Name:          PluginName,
+							EnabledVictim: &trueValue,
+							Arguments: map[string]interface{}{
+								"interval": "1ns",
+								"strategies": []interface{}{
+									map[string]interface{}{
+										"name":   DefragmentationStrategy,
+										"params": map[string]interface{}{"maxEvictions": test.maxEvictions},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run([]framework.Action{shuffle.New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/helpers"
+)
+
+// hold is the resources held for an active reservation in the session.
+type hold struct {
+	reservation *Reservation
+	reserved    *api.Resource
+	// used is the resources allocated to the jobs of the reservation
+	used *api.Resource
+	// nodes is the resources held on each node, which are not allocatable to other jobs
+	nodes map[string]*api.Resource
+	// nodeNames is the nodes holding resources, in the order of placement
+	nodeNames []string
+}
+
+// unused returns the reserved resources which are not used by the jobs of the reservation.
+func (h *hold) unused() *api.Resource {
+	return subtract(h.reserved, h.used)
+}
+
+// holder places the reserved-but-unused resources of the active reservations onto the idle resources of nodes.
+type holder struct {
+	holds map[string]*hold
+	// consumed is the held resources consumed on each node by the tasks allocated in the session
+	consumed map[api.TaskID]map[string]*api.Resource
+}
+
+func newHolder(reservations []*Reservation, jobs map[api.JobID]*api.JobInfo, nodes []*api.NodeInfo,
+	now time.Time, leadTime time.Duration) *holder {
+	h := &holder{
+		holds:    map[string]*hold{},
+		consumed: map[api.TaskID]map[string]*api.Resource{},
+	}
+
+	for _, r := range reservations {
+		if !r.active(now, leadTime) {
+			continue
+		}
+		h.holds[r.key()] = &hold{
+			reservation: r,
+			reserved:    api.NewResource(r.Spec.Resources),
+			used:        api.EmptyResource(),
+			nodes:       map[string]*api.Resource{},
+		}
+	}
+	if len(h.holds) == 0 {
+		return h
+	}
+
+	for _, job := range jobs {
+		if hd := h.match(job); hd != nil && job.Allocated != nil {
+			hd.used.Add(job.Allocated)
+		}
+	}
+
+	sortedNodes := make([]*api.NodeInfo, len(nodes))
+	copy(sortedNodes, nodes)
+	sort.Slice(sortedNodes, func(i, j int) bool { return sortedNodes[i].Name < sortedNodes[j].Name })
+
+	keys := make([]string, 0, len(h.holds))
+	for key := range h.holds {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		hd := h.holds[key]
+		remaining := hd.unused()
+		selector := labels.SelectorFromSet(hd.reservation.Spec.NodeSelector)
+		for _, node := range sortedNodes {
+			if remaining.IsEmpty() {
+				break
+			}
+			if node.Node == nil || !node.Ready() || !selector.Matches(labels.Set(node.Node.Labels)) {
+				continue
+			}
+			free := subtract(node.FutureIdle(), h.heldOn(node.Name, ""))
+			portion := helpers.Min(remaining, free)
+			if portion.IsEmpty() {
+				continue
+			}
+			hd.nodes[node.Name] = portion
+			hd.nodeNames = append(hd.nodeNames, node.Name)
+			remaining.SubWithoutAssert(portion)
+		}
+		if !remaining.IsEmpty() {
+			klog.V(3).Infof("Reservation <%s> holds <%v> less than unused <%v>, wait for resources to be released",
+				key, remaining, hd.unused())
+		}
+	}
+	return h
+}
+
+// match returns the hold of the reservation the job belongs to, the job must be in the queue of the reservation.
+func (h *holder) match(job *api.JobInfo) *hold {
+	if job == nil || job.PodGroup == nil {
+		return nil
+	}
+	name := job.PodGroup.Annotations[AnnotationKey]
+	if name == "" {
+		return nil
+	}
+	hd, found := h.holds[job.Namespace+"/"+name]
+	if !found {
+		return nil
+	}
+	if string(job.Queue) != hd.reservation.Spec.Queue {
+		klog.V(4).Infof("Job <%s/%s> in queue <%s> can not use reservation <%s> of queue <%s>",
+			job.Namespace, job.Name, job.Queue, hd.reservation.key(), hd.reservation.Spec.Queue)
+		return nil
+	}
+	return hd
+}
+
+// heldOn returns the resources held on the node by the reservations except the given one.
+func (h *holder) heldOn(nodeName, except string) *api.Resource {
+	held := api.EmptyResource()
+	for key, hd := range h.holds {
+		if key == except {
+			continue
+		}
+		if res, found := hd.nodes[nodeName]; found {
+			held.Add(res)
+		}
+	}
+	return held
+}
+
+// allocate consumes the held resources of the reservation by the task, on the node of the task first.
+func (h *holder) allocate(hd *hold, task *api.TaskInfo) {
+	hd.used.Add(task.Resreq)
+
+	request := task.Resreq.Clone()
+	consumed := map[string]*api.Resource{}
+	for _, nodeName := range append([]string{task.NodeName}, hd.nodeNames...) {
+		held, found := hd.nodes[nodeName]
+		if !found || request.IsEmpty() {
+			continue
+		}
+		portion := helpers.Min(request, held)
+		if portion.IsEmpty() {
+			continue
+		}
+		held.SubWithoutAssert(portion)
+		request.SubWithoutAssert(portion)
+		if c, found := consumed[nodeName]; found {
+			c.Add(portion)
+		} else {
+			consumed[nodeName] = portion
+		}
+	}
+	h.consumed[task.UID] = consumed
+}
+
+// deallocate returns the held resources consumed by the task.
+func (h *holder) deallocate(hd *hold, task *api.TaskInfo) {
+	hd.used.SubWithoutAssert(task.Resreq)
+	for nodeName, portion := range h.consumed[task.UID] {
+		hd.nodes[nodeName].Add(portion)
+	}
+	delete(h.consumed, task.UID)
+}
+
+// subtract returns l - r, the dimensions less than zero are set to zero.
+func subtract(l, r *api.Resource) *api.Resource {
+	res := api.EmptyResource()
+	res.MilliCPU = math.Max(l.MilliCPU-r.MilliCPU, 0)
+	res.Memory = math.Max(l.Memory-r.Memory, 0)
+	for name, quant := range l.ScalarResources {
+		if res.ScalarResources == nil {
+			res.ScalarResources = map[v1.ResourceName]float64{}
+		}
+		res.ScalarResources[name] = math.Max(quant-r.ScalarResources[name], 0)
+	}
+	return res
+}
+
+// scalarResources returns the scalar resources of the resource except the ignored ones, e.g. pods.
+func scalarResources(r *api.Resource) map[v1.ResourceName]float64 {
+	scalars := map[v1.ResourceName]float64{}
+	for name, quant := range r.ScalarResources {
+		if api.IsIgnoredScalarResource(name) {
+			continue
+		}
+		scalars[name] = quant
+	}
+	return scalars
+}
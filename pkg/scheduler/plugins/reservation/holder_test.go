@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+var now = time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+
+func buildReservation(name, queue string, resources v1.ResourceList, selector map[string]string, start, end time.Time) *Reservation {
+	endTime := metav1.NewTime(end)
+	return &Reservation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: name},
+		Spec: ReservationSpec{
+			Queue:        queue,
+			Resources:    resources,
+			NodeSelector: selector,
+			StartTime:    metav1.NewTime(start),
+			EndTime:      &endTime,
+		},
+	}
+}
+
+func buildJob(name, queue, reservation string, tasks ...*api.TaskInfo) *api.JobInfo {
+	job := api.NewJobInfo(api.JobID("ns1/"+name), tasks...)
+	job.SetPodGroup(&api.PodGroup{
+		PodGroup: scheduling.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "ns1",
+				Name:        name,
+				Annotations: map[string]string{AnnotationKey: reservation},
+			},
+			Spec: scheduling.PodGroupSpec{Queue: queue},
+		},
+	})
+	return job
+}
+
+func buildNodes(tasks ...*api.TaskInfo) []*api.NodeInfo {
+	allocatable := api.BuildResourceList("4", "4Gi", []api.ScalarResource{{Name: "nvidia.com/gpu", Value: "4"}}...)
+	nodes := []*api.NodeInfo{
+		api.NewNodeInfo(util.BuildNode("n1", allocatable, map[string]string{"pool": "a"})),
+		api.NewNodeInfo(util.BuildNode("n2", allocatable, map[string]string{"pool": "b"})),
+		api.NewNodeInfo(util.BuildNode("n3", allocatable, map[string]string{"pool": "a"})),
+	}
+	for _, task := range tasks {
+		for _, node := range nodes {
+			if node.Name == task.NodeName {
+				if err := node.AddTask(task); err != nil {
+					panic(err)
+				}
+			}
+		}
+	}
+	return nodes
+}
+
+func TestReservationActive(t *testing.T) {
+	r := buildReservation("r1", "q1", nil, nil, now, now.Add(time.Hour))
+	tests := []struct {
+		name     string
+		now      time.Time
+		leadTime time.Duration
+		expected bool
+	}{
+		{name: "before start", now: now.Add(-time.Minute), expected: false},
+		{name: "in lead time", now: now.Add(-time.Minute), leadTime: 10 * time.Minute, expected: true},
+		{name: "in window", now: now.Add(30 * time.Minute), expected: true},
+		{name: "after end", now: now.Add(time.Hour), expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := r.active(test.now, test.leadTime); got != test.expected {
+				t.Errorf("expected active %v, got %v", test.expected, got)
+			}
+		})
+	}
+
+	r.Spec.EndTime = nil
+	if !r.active(now.Add(24*time.Hour), 0) {
+		t.Errorf("expected reservation without end time to be active")
+	}
+}
+
+func TestFromUnstructured(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "scheduling.volcano.sh/v1beta1",
+		"kind":       "Reservation",
+		"metadata":   map[string]interface{}{"namespace": "ns1", "name": "r1"},
+		"spec": map[string]interface{}{
+			"queue":        "q1",
+			"resources":    map[string]interface{}{"cpu": "2", "nvidia.com/gpu": "1"},
+			"nodeSelector": map[string]interface{}{"pool": "a"},
+			"startTime":    "2025-06-01T08:00:00Z",
+		},
+	}}
+
+	r, err := fromUnstructured(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.key() != "ns1/r1" || r.Spec.Queue != "q1" || r.Spec.NodeSelector["pool"] != "a" || r.Spec.EndTime != nil {
+		t.Errorf("unexpected reservation %+v", r)
+	}
+	if !r.Spec.StartTime.Time.Equal(now) {
+		t.Errorf("expected start time %v, got %v", now, r.Spec.StartTime)
+	}
+	if cpu := r.Spec.Resources[v1.ResourceCPU]; cpu.String() != "2" {
+		t.Errorf("expected cpu 2, got %v", cpu.String())
+	}
+}
+
+func TestNewHolder(t *testing.T) {
+	running := api.NewTaskInfo(util.BuildPod("ns1", "p1", "n1", v1.PodRunning, api.BuildResourceList("2", "1Gi"), "j1", nil, nil))
+	other := api.NewTaskInfo(util.BuildPod("ns1", "p2", "n2", v1.PodRunning, api.BuildResourceList("2", "1Gi"), "j2", nil, nil))
+	jobs := map[api.JobID]*api.JobInfo{
+		"ns1/j1": buildJob("j1", "q1", "r1", running),
+		// the job is not in the queue of the reservation
+		"ns1/j2": buildJob("j2", "q2", "r1", other),
+	}
+	nodes := buildNodes(running, other)
+
+	reserved := api.BuildResourceList("6", "4Gi", []api.ScalarResource{{Name: "nvidia.com/gpu", Value: "4"}}...)
+	reservations := []*Reservation{
+		buildReservation("r1", "q1", reserved, map[string]string{"pool": "a"}, now.Add(-time.Hour), now.Add(time.Hour)),
+		buildReservation("r2", "q1", reserved, nil, now.Add(time.Hour), now.Add(2*time.Hour)),
+	}
+
+	h := newHolder(reservations, jobs, nodes, now, 0)
+	if len(h.holds) != 1 {
+		t.Fatalf("expected 1 active reservation, got %d", len(h.holds))
+	}
+	hd := h.holds["ns1/r1"]
+	if hd == nil {
+		t.Fatalf("expected reservation ns1/r1 to be active")
+	}
+
+	expectedUnused := api.NewResource(api.BuildResourceList("4", "3Gi", []api.ScalarResource{{Name: "nvidia.com/gpu", Value: "4"}}...))
+	if !equality.Semantic.DeepEqual(hd.unused(), expectedUnused) {
+		t.Errorf("expected unused %v, got %v", expectedUnused, hd.unused())
+	}
+
+	// n1 has 2 cpu idle, the rest is held on n3, n2 is not selected
+	expectedNodes := map[string]*api.Resource{
+		"n1": api.NewResource(api.BuildResourceList("2", "3Gi", []api.ScalarResource{{Name: "nvidia.com/gpu", Value: "4"}}...)),
+		"n3": api.NewResource(api.BuildResourceList("2", "0", []api.ScalarResource{{Name: "nvidia.com/gpu", Value: "0"}}...)),
+	}
+	for name, expected := range expectedNodes {
+		if !hd.nodes[name].Equal(expected, api.Zero) {
+			t.Errorf("expected %v held on node %s, got %v", expected, name, hd.nodes[name])
+		}
+	}
+	if _, found := hd.nodes["n2"]; found {
+		t.Errorf("expected nothing held on node n2, got %v", hd.nodes["n2"])
+	}
+
+	if h.match(jobs["ns1/j1"]) != hd {
+		t.Errorf("expected job j1 to match reservation r1")
+	}
+	if h.match(jobs["ns1/j2"]) != nil {
+		t.Errorf("expected job j2 in another queue not to match reservation r1")
+	}
+	if held := h.heldOn("n1", "ns1/r1"); !held.IsEmpty() {
+		t.Errorf("expected nothing held for the jobs of r1, got %v", held)
+	}
+}
+
+func TestHolderAllocate(t *testing.T) {
+	jobs := map[api.JobID]*api.JobInfo{"ns1/j1": buildJob("j1", "q1", "r1")}
+	reserved := api.BuildResourceList("6", "0")
+	reservations := []*Reservation{
+		buildReservation("r1", "q1", reserved, map[string]string{"pool": "a"}, now, now.Add(time.Hour)),
+	}
+	h := newHolder(reservations, jobs, buildNodes(), now, 0)
+	hd := h.holds["ns1/r1"]
+
+	task := api.NewTaskInfo(util.BuildPod("ns1", "p1", "n3", v1.PodPending, api.BuildResourceList("3", "0"), "j1", nil, nil))
+	task.NodeName = "n3"
+	h.allocate(hd, task)
+
+	// 2 cpu are consumed on n3, and the other 1 on n1
+	if cpu := hd.nodes["n3"].MilliCPU; cpu != 0 {
+		t.Errorf("expected 0 cpu held on node n3, got %v", cpu)
+	}
+	if cpu := hd.nodes["n1"].MilliCPU; cpu != 3000 {
+		t.Errorf("expected 3000 cpu held on node n1, got %v", cpu)
+	}
+	if cpu := hd.unused().MilliCPU; cpu != 3000 {
+		t.Errorf("expected 3000 cpu unused, got %v", cpu)
+	}
+
+	h.deallocate(hd, task)
+	if cpu := hd.nodes["n3"].MilliCPU; cpu != 2000 {
+		t.Errorf("expected 2000 cpu held on node n3, got %v", cpu)
+	}
+	if cpu := hd.nodes["n1"].MilliCPU; cpu != 4000 {
+		t.Errorf("expected 4000 cpu held on node n1, got %v", cpu)
+	}
+	if cpu := hd.unused().MilliCPU; cpu != 6000 {
+		t.Errorf("expected 6000 cpu unused, got %v", cpu)
+	}
+}
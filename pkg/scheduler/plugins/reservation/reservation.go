@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "reservation"
+
+	// AnnotationKey is the annotation of jobs or podgroups naming the reservation in the same namespace to use.
+	AnnotationKey = "volcano.sh/reservation"
+
+	// LeadTimeKey is the argument of the duration to hold the resources before the start of reservations.
+	LeadTimeKey = "reservation.leadTime"
+)
+
+// User should create a Reservation in the namespace of the jobs, e.g.
+//
+//	apiVersion: scheduling.volcano.sh/v1beta1
+//	kind: Reservation
+//	metadata:
+//	  name: training
+//	spec:
+//	  queue: research
+//	  resources:
+//	    cpu: "64"
+//	    nvidia.com/gpu: "16"
+//	  startTime: "2025-06-01T08:00:00Z"
+//	  endTime: "2025-06-01T20:00:00Z"
+//
+// annotate the jobs of the queue with `volcano.sh/reservation: training`, and enable the plugin:
+//
+//	tiers:
+//	- plugins:
+//	  - name: predicates
+//	  - name: reservation
+//	    arguments:
+//	      reservation.leadTime: 30m
+//
+// In the time window, the reserved-but-unused resources are held on the idle resources of the selected
+// nodes, and the other jobs can not be allocated onto the held resources. Running workloads are not
+// evicted for reservations, the resources are held as they are released.
+
+// reportedReservations is the reservations whose metrics are reported, to delete the metrics when they end.
+var reportedReservations = sets.New[string]()
+
+type reservationPlugin struct {
+	// Arguments given for the plugin
+	pluginArguments framework.Arguments
+	leadTime        time.Duration
+	holder          *holder
+}
+
+// New return reservation plugin
+func New(arguments framework.Arguments) framework.Plugin {
+	rp := &reservationPlugin{pluginArguments: arguments}
+	if value, found := arguments[LeadTimeKey]; found {
+		leadTime, err := time.ParseDuration(fmt.Sprint(value))
+		if err != nil || leadTime < 0 {
+			klog.Warningf("Invalid %s %v, hold resources from the start of reservations", LeadTimeKey, value)
+		} else {
+			rp.leadTime = leadTime
+		}
+	}
+	return rp
+}
+
+func (rp *reservationPlugin) Name() string {
+	return PluginName
+}
+
+func (rp *reservationPlugin) OnSessionOpen(ssn *framework.Session) {
+	reservations, err := listReservations(ssn.ClientConfig(), ssn.StopCh())
+	if err != nil {
+		klog.Errorf("Failed to list reservations: %v", err)
+		return
+	}
+	rp.holder = newHolder(reservations, ssn.Jobs, ssn.NodeList, time.Now(), rp.leadTime)
+	if len(rp.holder.holds) == 0 {
+		return
+	}
+
+	predicateFn := func(task *api.TaskInfo, node *api.NodeInfo) error {
+		job, found := ssn.Jobs[task.Job]
+		if !found {
+			return nil
+		}
+		except := ""
+		if hd := rp.holder.match(job); hd != nil {
+			except = hd.reservation.key()
+		}
+		held := rp.holder.heldOn(node.Name, except)
+		if held.IsEmpty() {
+			return nil
+		}
+		if !task.Resreq.LessEqual(subtract(node.FutureIdle(), held), api.Zero) {
+			klog.V(4).Infof("Task <%s/%s> can not be allocated onto <%v> held by reservations on node <%s>",
+				task.Namespace, task.Name, held, node.Name)
+			return api.NewFitErrWithStatus(task, node, &api.Status{
+				Code:   api.Unschedulable,
+				Reason: "node resources are held by reservations",
+			})
+		}
+		return nil
+	}
+	ssn.AddPredicateFn(rp.Name(), predicateFn)
+
+	ssn.AddEventHandler(&framework.EventHandler{
+		AllocateFunc: func(event *framework.Event) {
+			if hd := rp.holder.match(ssn.Jobs[event.Task.Job]); hd != nil {
+				rp.holder.allocate(hd, event.Task)
+			}
+		},
+		DeallocateFunc: func(event *framework.Event) {
+			if hd := rp.holder.match(ssn.Jobs[event.Task.Job]); hd != nil {
+				rp.holder.deallocate(hd, event.Task)
+			}
+		},
+	})
+}
+
+func (rp *reservationPlugin) OnSessionClose(ssn *framework.Session) {
+	active := sets.New[string]()
+	if rp.holder != nil {
+		for key, hd := range rp.holder.holds {
+			unused := hd.unused()
+			metrics.UpdateReservationUnused(key, hd.reservation.Spec.Queue, unused.MilliCPU, unused.Memory, scalarResources(unused))
+			active.Insert(key)
+		}
+	}
+	for key := range reportedReservations.Difference(active) {
+		metrics.DeleteReservationMetrics(key)
+	}
+	reportedReservations = active
+	rp.holder = nil
+}
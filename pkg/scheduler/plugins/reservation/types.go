@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/plugins/util"
+)
+
+// GroupVersionResource is the resource of the Reservation CRD, see config/crd/volcano/bases/scheduling.volcano.sh_reservations.yaml.
+var GroupVersionResource = schema.GroupVersionResource{
+	Group:    "scheduling.volcano.sh",
+	Version:  "v1beta1",
+	Resource: "reservations",
+}
+
+// Reservation holds resources for the jobs of a queue in a time window, so that
+// jobs planned to start at a fixed time find the capacity idle.
+type Reservation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ReservationSpec `json:"spec"`
+}
+
+// ReservationSpec is the specification of a Reservation.
+type ReservationSpec struct {
+	// Queue is the queue owning the reservation, only the jobs of the queue can use the reserved resources.
+	Queue string `json:"queue"`
+	// Resources is the total amount of resources to reserve.
+	Resources v1.ResourceList `json:"resources"`
+	// NodeSelector selects the nodes to reserve resources on, all nodes if empty.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// StartTime is the time from which the resources are reserved.
+	StartTime metav1.Time `json:"startTime"`
+	// EndTime is the time after which the resources are released, never if not set.
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// active returns whether the reservation holds resources at the time, the resources are
+// held leadTime in advance so that the running workloads are drained before the start.
+func (r *Reservation) active(now time.Time, leadTime time.Duration) bool {
+	if now.Before(r.Spec.StartTime.Add(-leadTime)) {
+		return false
+	}
+	return r.Spec.EndTime == nil || now.Before(r.Spec.EndTime.Time)
+}
+
+// key returns the namespaced name of the reservation.
+func (r *Reservation) key() string {
+	return r.Namespace + "/" + r.Name
+}
+
+func fromUnstructured(obj interface{}) (*Reservation, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T", obj)
+	}
+	r := &Reservation{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), r); err != nil {
+		return nil, fmt.Errorf("failed to convert reservation <%s/%s>: %v", u.GetNamespace(), u.GetName(), err)
+	}
+	return r, nil
+}
+
+// reservationInformer watches the reservations, it is started on first use.
+var reservationInformer = util.NewDynamicInformer(GroupVersionResource)
+
+// listReservations lists the reservations in the informer cache, nothing is listed until the cache is synced.
+func listReservations(config *rest.Config, stopCh <-chan struct{}) ([]*Reservation, error) {
+	objs, err := reservationInformer.List(config, stopCh)
+	if err != nil {
+		return nil, err
+	}
+	reservations := make([]*Reservation, 0, len(objs))
+	for _, obj := range objs {
+		r, err := fromUnstructured(obj)
+		if err != nil {
+			klog.Warningf("Ignore reservation: %v", err)
+			continue
+		}
+		reservations = append(reservations, r)
+	}
+	return reservations, nil
+}
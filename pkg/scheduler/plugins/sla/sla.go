@@ -17,12 +17,14 @@ limitations under the License.
 package sla
 
 import (
+	"fmt"
 	"time"
 
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
 )
 
@@ -33,19 +35,29 @@ const (
 	// when job waits longer than waiting time, it should be enqueue at once, and cluster should reserve resources for it
 	// Valid time units are “ns”, “us” (or “µs”), “ms”, “s”, “m”, “h”
 	JobWaitingTime = "sla-waiting-time"
+	// EscalationSteps is the number of steps in which the order of a job is raised while it waits,
+	// a job goes up one step every time it waits another 1/EscalationSteps of its waiting time
+	EscalationSteps = "sla-escalation-steps"
+
+	defaultEscalationSteps = 1
 )
 
 type slaPlugin struct {
 	// Arguments given for sla plugin
 	pluginArguments framework.Arguments
 	jobWaitingTime  *time.Duration
+	// queueWaitingTime is the job waiting time set by queue annotations
+	queueWaitingTime map[api.QueueID]*time.Duration
+	escalationSteps  int
 }
 
 // New function returns sla plugin object
 func New(arguments framework.Arguments) framework.Plugin {
 	return &slaPlugin{
-		pluginArguments: arguments,
-		jobWaitingTime:  nil,
+		pluginArguments:  arguments,
+		jobWaitingTime:   nil,
+		queueWaitingTime: map[api.QueueID]*time.Duration{},
+		escalationSteps:  defaultEscalationSteps,
 	}
 }
 
@@ -53,15 +65,93 @@ func (sp *slaPlugin) Name() string {
 	return PluginName
 }
 
-// readJobWaitingTime read job waiting time from jobInfo or sla plugin arguments
+// readJobWaitingTime read job waiting time from jobInfo, queue annotations or sla plugin arguments,
+// the job setting overrides the queue setting which overrides the global setting
 // Valid time units are “ns”, “us” (or “µs”), “ms”, “s”, “m”, “h”
-func (sp *slaPlugin) readJobWaitingTime(jwt *time.Duration) *time.Duration {
+func (sp *slaPlugin) readJobWaitingTime(job *api.JobInfo) *time.Duration {
 	// read individual jobInfo waiting time from jobInfos
-	if jwt == nil {
-		// if no individual settings, read global jobInfo waiting time from sla plugin arguments
-		return sp.jobWaitingTime
+	if job.WaitingTime != nil {
+		return job.WaitingTime
+	}
+	// read waiting time of the queue of the job from queue annotations
+	if jwt, found := sp.queueWaitingTime[job.Queue]; found {
+		return jwt
+	}
+	// if no individual settings, read global jobInfo waiting time from sla plugin arguments
+	return sp.jobWaitingTime
+}
+
+// escalationLevel returns how many steps the job has been escalated, from 0 when the job is just created
+// to escalationSteps when the job waits longer than its waiting time.
+func (sp *slaPlugin) escalationLevel(job *api.JobInfo, jwt time.Duration) int {
+	waited := time.Since(job.CreationTimestamp.Time)
+	if waited >= jwt {
+		return sp.escalationSteps
+	}
+	if waited <= 0 {
+		return 0
+	}
+	return int(float64(waited) / float64(jwt) * float64(sp.escalationSteps))
+}
+
+// parseWaitingTime parses a waiting time, nil is returned if the waiting time is not valid.
+func parseWaitingTime(waitTime string) (*time.Duration, error) {
+	jwt, err := time.ParseDuration(waitTime)
+	if err != nil {
+		return nil, err
+	}
+	if jwt <= 0 {
+		return nil, fmt.Errorf("invalid waiting time %s", jwt.String())
+	}
+	return &jwt, nil
+}
+
+// readQueueWaitingTime reads job waiting time of the queues from queue annotations.
+func (sp *slaPlugin) readQueueWaitingTime(ssn *framework.Session) {
+	for _, queue := range ssn.Queues {
+		if queue.Queue == nil {
+			continue
+		}
+		waitTime, found := queue.Queue.Annotations[JobWaitingTime]
+		if !found {
+			continue
+		}
+		jwt, err := parseWaitingTime(waitTime)
+		if err != nil {
+			klog.Errorf("Error occurs in parsing job waiting time of queue <%s> in sla plugin, err: %s.", queue.Name, err.Error())
+			continue
+		}
+		sp.queueWaitingTime[queue.UID] = jwt
+		klog.V(4).Infof("Job waiting time of queue <%s> is %s.", queue.Name, jwt.String())
+	}
+}
+
+// readEscalationSteps reads the escalation steps from sla plugin arguments.
+func (sp *slaPlugin) readEscalationSteps() {
+	sp.pluginArguments.GetInt(&sp.escalationSteps, EscalationSteps)
+	if sp.escalationSteps <= 0 {
+		klog.Warningf("Invalid escalation steps setting: %d in sla plugin, use %d.", sp.escalationSteps, defaultEscalationSteps)
+		sp.escalationSteps = defaultEscalationSteps
+	}
+}
+
+// recordViolations records the pending jobs waiting longer than their waiting time of each queue.
+func (sp *slaPlugin) recordViolations(ssn *framework.Session) {
+	violations := map[api.QueueID]int{}
+	for _, job := range ssn.Jobs {
+		if !job.IsPending() {
+			continue
+		}
+		jwt := sp.readJobWaitingTime(job)
+		if jwt == nil || time.Since(job.CreationTimestamp.Time) < *jwt {
+			continue
+		}
+		klog.V(3).Infof("Job <%s/%s> has been pending longer than its sla waiting time %s.", job.Namespace, job.Name, jwt.String())
+		violations[job.Queue]++
+	}
+	for _, queue := range ssn.Queues {
+		metrics.UpdateSLAViolatedJobs(queue.Name, violations[queue.UID])
 	}
-	return jwt
 }
 
 /*
@@ -73,6 +163,14 @@ tiers:
     arguments:
     sla-waiting-time: 1h2m3s4ms5µs6ns
 
+User can also give job waiting time settings for the jobs of one queue via queue annotations:
+apiVersion: scheduling.volcano.sh/v1beta1
+kind: Queue
+metadata:
+
+	annotations:
+	  sla-waiting-time: 30m
+
 Meanwhile, user can give individual job waiting time settings for one job via job annotations:
 apiVersion: batch.volcano.sh/v1alpha1
 kind: Job
@@ -80,6 +178,9 @@ metadata:
 
 	annotations:
 	  sla-waiting-time: 1h2m3s4ms5us6ns
+
+The order of a waiting job is raised in sla-escalation-steps steps before its waiting time is over,
+instead of only once when the waiting time is over.
 */
 func (sp *slaPlugin) OnSessionOpen(ssn *framework.Session) {
 	klog.V(4).Infof("Enter sla plugin ...")
@@ -104,13 +205,16 @@ func (sp *slaPlugin) OnSessionOpen(ssn *framework.Session) {
 			klog.V(4).Infof("Global job waiting time is %s.", sp.jobWaitingTime.String())
 		}
 	}
+	sp.readQueueWaitingTime(ssn)
+	sp.readEscalationSteps()
+	sp.recordViolations(ssn)
 
 	jobOrderFn := func(l, r interface{}) int {
 		lv := l.(*api.JobInfo)
 		rv := r.(*api.JobInfo)
 
-		var lJobWaitingTime = sp.readJobWaitingTime(lv.WaitingTime)
-		var rJobWaitingTime = sp.readJobWaitingTime(rv.WaitingTime)
+		var lJobWaitingTime = sp.readJobWaitingTime(lv)
+		var rJobWaitingTime = sp.readJobWaitingTime(rv)
 
 		if lJobWaitingTime == nil {
 			if rJobWaitingTime == nil {
@@ -122,6 +226,15 @@ func (sp *slaPlugin) OnSessionOpen(ssn *framework.Session) {
 			return -1
 		}
 
+		// the job escalated more steps is in front of the other
+		lLevel := sp.escalationLevel(lv, *lJobWaitingTime)
+		rLevel := sp.escalationLevel(rv, *rJobWaitingTime)
+		if lLevel > rLevel {
+			return -1
+		} else if lLevel < rLevel {
+			return 1
+		}
+
 		lCreationTimestamp := lv.CreationTimestamp
 		rCreationTimestamp := rv.CreationTimestamp
 		if lCreationTimestamp.Add(*lJobWaitingTime).Before(rCreationTimestamp.Add(*rJobWaitingTime)) {
@@ -135,7 +248,7 @@ func (sp *slaPlugin) OnSessionOpen(ssn *framework.Session) {
 
 	permitableFn := func(obj interface{}) int {
 		jobInfo := obj.(*api.JobInfo)
-		var jwt = sp.readJobWaitingTime(jobInfo.WaitingTime)
+		var jwt = sp.readJobWaitingTime(jobInfo)
 
 		if jwt == nil {
 			return util.Abstain
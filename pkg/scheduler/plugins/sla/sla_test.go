@@ -24,10 +24,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
 func TestSlaPlugin(t *testing.T) {
@@ -146,3 +148,96 @@ func TestSlaPlugin(t *testing.T) {
 	}
 
 }
+
+func buildSlaJob(name string, queue api.QueueID, waited time.Duration, jwt *time.Duration) *api.JobInfo {
+	return &api.JobInfo{
+		Name:              name,
+		Namespace:         "default",
+		Queue:             queue,
+		CreationTimestamp: metav1.NewTime(time.Now().Add(-waited)),
+		WaitingTime:       jwt,
+	}
+}
+
+func TestSlaQueueWaitingTimeAndEscalation(t *testing.T) {
+	var (
+		halfHour = 30 * time.Minute
+		hour     = time.Hour
+		tenHours = 10 * time.Hour
+	)
+	queue := util.BuildQueue("q1", 1, nil)
+	queue.Annotations = map[string]string{JobWaitingTime: "1m"}
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		arguments     framework.Arguments
+		left, right   *api.JobInfo
+		expectedOrder bool
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:    "queue waiting time overrides global waiting time",
+				Plugins: map[string]framework.PluginBuilder{PluginName: New},
+				Queues:  []*schedulingv1beta1.Queue{queue},
+			},
+			arguments:     map[string]interface{}{JobWaitingTime: "1h"},
+			left:          buildSlaJob("job1", "q1", 2*time.Minute, nil),
+			right:         buildSlaJob("job2", "default", 2*time.Minute, &halfHour),
+			expectedOrder: true,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:    "job waiting time overrides queue waiting time",
+				Plugins: map[string]framework.PluginBuilder{PluginName: New},
+				Queues:  []*schedulingv1beta1.Queue{queue},
+			},
+			arguments:     map[string]interface{}{JobWaitingTime: "1h"},
+			left:          buildSlaJob("job1", "q1", 2*time.Minute, &hour),
+			right:         buildSlaJob("job2", "default", 2*time.Minute, &halfHour),
+			expectedOrder: false,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:    "job with earlier deadline first without escalation",
+				Plugins: map[string]framework.PluginBuilder{PluginName: New},
+			},
+			arguments:     map[string]interface{}{},
+			left:          buildSlaJob("job1", "default", 9*time.Hour, &tenHours),
+			right:         buildSlaJob("job2", "default", 20*time.Minute, &hour),
+			expectedOrder: false,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:    "job escalated more steps first",
+				Plugins: map[string]framework.PluginBuilder{PluginName: New},
+			},
+			arguments:     map[string]interface{}{EscalationSteps: 4},
+			left:          buildSlaJob("job1", "default", 9*time.Hour, &tenHours),
+			right:         buildSlaJob("job2", "default", 20*time.Minute, &hour),
+			expectedOrder: true,
+		},
+	}
+
+	for _, test := range tests {
+		trueValue := true
+		t.Run(test.Name, func(t *testing.T) {
+			tiers := []conf.Tier{
+				{
+					Plugins: []conf.PluginOption{
+						{
+							Name:            PluginName,
+							EnabledJobOrder: &trueValue,
+							Arguments:       test.arguments,
+						},
+					},
+				},
+			}
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+			isOrder := ssn.JobOrderFn(test.left, test.right)
+			if test.expectedOrder != isOrder {
+				t.Errorf("case: %s error,  expect %v, but get %v", test.Name, test.expectedOrder, isOrder)
+			}
+		})
+	}
+}
@@ -45,6 +45,7 @@ const (
        enablePredicate: false  # If the value is false, new pod scheduling is not disabled when the node load reaches the threshold. If the value is true or left blank, new pod scheduling is disabled.
        arguments:
          usage.weight: 5
+         usage.metricsActiveTime: 5m # The metrics older than it are ignored, and the node passes the filter with score 0.
          cpu.weight: 1
          memory.weight: 1
          thresholds:
@@ -63,24 +64,38 @@ type usagePlugin struct {
 	cpuThresholds   float64
 	memThresholds   float64
 	period          string
+	// metricsActiveTime is the time the metrics of a node are used for, the node is neither filtered nor scored by
+	// the usage if its metrics are not updated in time, e.g. when the metrics system is unavailable.
+	metricsActiveTime time.Duration
 }
 
 // New function returns usagePlugin object
 func New(args framework.Arguments) framework.Plugin {
 	var plugin = &usagePlugin{
-		pluginArguments: args,
-		usageWeight:     5,
-		cpuWeight:       1,
-		memoryWeight:    1,
-		usageType:       AVG,
-		cpuThresholds:   80,
-		memThresholds:   80,
-		period:          source.NODE_METRICS_PERIOD,
+		pluginArguments:   args,
+		usageWeight:       5,
+		cpuWeight:         1,
+		memoryWeight:      1,
+		usageType:         AVG,
+		cpuThresholds:     80,
+		memThresholds:     80,
+		period:            source.NODE_METRICS_PERIOD,
+		metricsActiveTime: MetricsActiveTime,
 	}
 	args.GetInt(&plugin.usageWeight, "usage.weight")
 	args.GetInt(&plugin.cpuWeight, "cpu.weight")
 	args.GetInt(&plugin.memoryWeight, "memory.weight")
 
+	var metricsActiveTime string
+	args.GetString(&metricsActiveTime, "usage.metricsActiveTime")
+	if metricsActiveTime != "" {
+		if d, err := time.ParseDuration(metricsActiveTime); err != nil || d <= 0 {
+			klog.Errorf("Invalid usage.metricsActiveTime %q, use the default %v", metricsActiveTime, MetricsActiveTime)
+		} else {
+			plugin.metricsActiveTime = d
+		}
+	}
+
 	argsValue, ok := plugin.pluginArguments[thresholdSection]
 	if !ok {
 		klog.Errorf("Failed to obtain thresholds information, usage plugin arguments is %v", plugin.pluginArguments)
@@ -128,9 +143,9 @@ func (up *usagePlugin) OnSessionOpen(ssn *framework.Session) {
 		usageStatus := &api.Status{Plugin: PluginName}
 
 		now := time.Now()
-		if up.period == "" || now.Sub(node.ResourceUsage.MetricsTime) > MetricsActiveTime {
+		if up.period == "" || now.Sub(node.ResourceUsage.MetricsTime) > up.metricsActiveTime {
 			klog.V(4).Infof("The period(%s) is empty or the usage metrics data is not updated for more than %v minutes, "+
-				"Usage plugin filter for task %s/%s on node %s pass, metrics time is %v. ", up.period, MetricsActiveTime, task.Namespace, task.Name, node.Name, node.ResourceUsage.MetricsTime)
+				"Usage plugin filter for task %s/%s on node %s pass, metrics time is %v. ", up.period, up.metricsActiveTime, task.Namespace, task.Name, node.Name, node.ResourceUsage.MetricsTime)
 
 			return nil
 		}
@@ -158,9 +173,9 @@ func (up *usagePlugin) OnSessionOpen(ssn *framework.Session) {
 	nodeOrderFn := func(task *api.TaskInfo, node *api.NodeInfo) (float64, error) {
 		score := 0.0
 		now := time.Now()
-		if up.period == "" || now.Sub(node.ResourceUsage.MetricsTime) > MetricsActiveTime {
+		if up.period == "" || now.Sub(node.ResourceUsage.MetricsTime) > up.metricsActiveTime {
 			klog.V(4).Infof("The period(%s) is empty or the usage metrics data is not updated for more than %v minutes, "+
-				"Usage plugin score for task %s/%s on node %s is 0, metrics time is %v. ", up.period, MetricsActiveTime, task.Namespace, task.Name, node.Name, node.ResourceUsage.MetricsTime)
+				"Usage plugin score for task %s/%s on node %s is 0, metrics time is %v. ", up.period, up.metricsActiveTime, task.Namespace, task.Name, node.Name, node.ResourceUsage.MetricsTime)
 			return 0, nil
 		}
 
@@ -213,6 +213,35 @@ func TestUsage_predicateFn(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "The node cannot be scheduled, because of the metrics are used for 10 minutes.",
+				PodGroups: []*schedulingv1.PodGroup{pg1},
+				Queues:    []*schedulingv1.Queue{queue1},
+				Pods:      []*v1.Pod{p1, p2},
+				Nodes:     []*v1.Node{n4},
+			},
+			nodesUsageMap: nodesUsage,
+			arguments: framework.Arguments{
+				"usage.weight":            5,
+				"usage.metricsActiveTime": "10m",
+				"cpu.weight":              1,
+				"memory.weight":           1,
+				"thresholds": map[interface{}]interface{}{
+					"cpu": 80,
+					"mem": 80,
+				},
+			},
+			expected: predicateResult{
+				predicateStatus: []*api.Status{
+					{
+						Code:   api.UnschedulableAndUnresolvable,
+						Reason: NodeUsageCPUExtend,
+					},
+				},
+				err: fmt.Errorf("plugin %s predicates failed, because of %s", PluginName, NodeUsageCPUExtend),
+			},
+		},
 		{
 			TestCommonStruct: uthelper.TestCommonStruct{
 				Name:      "The node can be scheduled, because of the metric time is in the initial state, and the usage function is invalid.",
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// DynamicInformer watches a resource without typed client, e.g. the CRDs read by plugins. The informer is started
+// on first use with the stop channel of the scheduler. It is built again on next use if it failed to be built,
+// e.g. the client config was not available.
+type DynamicInformer struct {
+	resource schema.GroupVersionResource
+
+	mutex    sync.Mutex
+	informer informers.GenericInformer
+}
+
+// NewDynamicInformer returns the informer of the resource, it is not started until first use.
+func NewDynamicInformer(resource schema.GroupVersionResource) *DynamicInformer {
+	return &DynamicInformer{resource: resource}
+}
+
+// Informer returns the informer of the resource, it is started with the stop channel if it is not started yet.
+func (d *DynamicInformer) Informer(config *rest.Config, stopCh <-chan struct{}) (informers.GenericInformer, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.informer != nil {
+		return d.informer, nil
+	}
+	if config == nil {
+		return nil, fmt.Errorf("no client config to watch %s", d.resource.Resource)
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client for %s: %v", d.resource.Resource, err)
+	}
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(client, 0)
+	informer := factory.ForResource(d.resource)
+	factory.Start(stopCh)
+	d.informer = informer
+	return informer, nil
+}
+
+// List lists the objects in the informer cache, nothing is listed until the cache is synced.
+func (d *DynamicInformer) List(config *rest.Config, stopCh <-chan struct{}) ([]runtime.Object, error) {
+	informer, err := d.Informer(config, stopCh)
+	if err != nil {
+		return nil, err
+	}
+	if !informer.Informer().HasSynced() {
+		klog.V(3).Infof("Informer of %s is not synced, is the CRD installed?", d.resource.Resource)
+		return nil, nil
+	}
+	return informer.Lister().List(labels.Everything())
+}
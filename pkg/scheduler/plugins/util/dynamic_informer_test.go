@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+func TestDynamicInformer(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	d := NewDynamicInformer(schema.GroupVersionResource{Group: "scheduling.volcano.sh", Version: "v1beta1", Resource: "reservations"})
+	if _, err := d.Informer(nil, stopCh); err == nil {
+		t.Fatalf("expected error without client config")
+	}
+
+	// the informer is built again once the client config is available
+	config := &rest.Config{Host: "http://127.0.0.1:0"}
+	informer, err := d.Informer(config, stopCh)
+	if err != nil || informer == nil {
+		t.Fatalf("expected informer built, got %v, %v", informer, err)
+	}
+	if got, _ := d.Informer(config, stopCh); got != informer {
+		t.Errorf("expected the informer started once")
+	}
+
+	objs, err := d.List(config, stopCh)
+	if err != nil || len(objs) != 0 {
+		t.Errorf("expected nothing listed before the informer is synced, got %v, %v", objs, err)
+	}
+}
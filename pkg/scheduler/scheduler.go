@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
@@ -36,6 +37,7 @@ import (
 
 	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/filewatcher"
+	"volcano.sh/volcano/pkg/scheduler/audit"
 	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
@@ -57,6 +59,8 @@ type Scheduler struct {
 	plugins        []conf.Tier
 	configurations []conf.Configuration
 	metricsConf    map[string]string
+	auditConf      map[string]string
+	auditSink      audit.Sink
 	dumper         schedcache.Dumper
 }
 
@@ -72,7 +76,10 @@ func NewScheduler(config *rest.Config, opt *options.ServerOption) (*Scheduler, e
 		}
 	}
 
-	cache := schedcache.New(config, opt.SchedulerNames, opt.DefaultQueue, opt.NodeSelector, opt.NodeWorkerThreads, opt.IgnoredCSIProvisioners, opt.ResyncPeriod)
+	cache, err := schedcache.New(config, opt.SchedulerNames, opt.DefaultQueue, opt.NodeSelector, opt.NodeWorkerThreads, opt.IgnoredCSIProvisioners, opt.ResyncPeriod)
+	if err != nil {
+		return nil, err
+	}
 	scheduler := &Scheduler{
 		schedulerConf:  opt.SchedulerConf,
 		fileWatcher:    watcher,
@@ -111,6 +118,7 @@ func (pc *Scheduler) runOnce() {
 	actions := pc.actions
 	plugins := pc.plugins
 	configurations := pc.configurations
+	auditSink := pc.auditSink
 	pc.mutex.Unlock()
 
 	// Load ConfigMap to check which action is enabled.
@@ -120,6 +128,7 @@ func (pc *Scheduler) runOnce() {
 	}
 
 	ssn := framework.OpenSession(pc.cache, plugins, configurations)
+	ssn.SetAuditSink(auditSink)
 	defer func() {
 		framework.CloseSession(ssn)
 		metrics.UpdateE2eDuration(metrics.Duration(scheduleStartTime))
@@ -127,6 +136,7 @@ func (pc *Scheduler) runOnce() {
 
 	for _, action := range actions {
 		actionStartTime := time.Now()
+		ssn.SetCurrentAction(action.Name())
 		action.Execute(ssn)
 		metrics.UpdateActionDuration(action.Name(), metrics.Duration(actionStartTime))
 	}
@@ -141,7 +151,7 @@ func (pc *Scheduler) loadSchedulerConf() {
 
 	var err error
 	pc.once.Do(func() {
-		pc.actions, pc.plugins, pc.configurations, pc.metricsConf, err = UnmarshalSchedulerConf(DefaultSchedulerConf)
+		pc.actions, pc.plugins, pc.configurations, pc.metricsConf, _, err = UnmarshalSchedulerConf(DefaultSchedulerConf)
 		if err != nil {
 			klog.Errorf("unmarshal Scheduler config %s failed: %v", DefaultSchedulerConf, err)
 			panic("invalid default configuration")
@@ -159,7 +169,7 @@ func (pc *Scheduler) loadSchedulerConf() {
 		config = strings.TrimSpace(string(confData))
 	}
 
-	actions, plugins, configurations, metricsConf, err := UnmarshalSchedulerConf(config)
+	actions, plugins, configurations, metricsConf, auditConf, err := UnmarshalSchedulerConf(config)
 	if err != nil {
 		klog.Errorf("Scheduler config %s is invalid: %v", config, err)
 		return
@@ -170,9 +180,29 @@ func (pc *Scheduler) loadSchedulerConf() {
 	pc.plugins = plugins
 	pc.configurations = configurations
 	pc.metricsConf = metricsConf
+	pc.updateAuditSink(auditConf)
 	pc.mutex.Unlock()
 }
 
+// updateAuditSink rebuilds the audit sink if the audit configuration is changed, the previous sink is kept
+// if the new one can not be built.
+func (pc *Scheduler) updateAuditSink(auditConf map[string]string) {
+	if reflect.DeepEqual(pc.auditConf, auditConf) {
+		return
+	}
+	sink, err := audit.NewSink(auditConf, pc.cache.EventRecorder())
+	if err != nil {
+		klog.Errorf("Audit config %v is invalid, using previous configuration: %v", auditConf, err)
+		return
+	}
+	if pc.auditSink != nil {
+		pc.auditSink.Close()
+	}
+	pc.auditConf = auditConf
+	pc.auditSink = sink
+	klog.V(2).Infof("Scheduling decisions are recorded with audit config %v", auditConf)
+}
+
 func (pc *Scheduler) getSchedulerConf() (actions []string, plugins []string) {
 	for _, action := range pc.actions {
 		actions = append(actions, action.Name())
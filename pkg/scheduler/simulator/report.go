@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Report is the performance of the scheduler replaying the trace.
+type Report struct {
+	Cycles int `json:"cycles"`
+	// PodsScheduled is the number of the pods bound
+	PodsScheduled int `json:"podsScheduled"`
+	// PodsPending is the number of the pods still pending when the replay stops
+	PodsPending int `json:"podsPending"`
+	// SchedulingTime is the total latency of the cycles, i.e. the time spent by the scheduler
+	SchedulingTime time.Duration `json:"schedulingTime"`
+	// Throughput is the pods scheduled per second of the scheduling time
+	Throughput      float64       `json:"throughput"`
+	P50CycleLatency time.Duration `json:"p50CycleLatency"`
+	P99CycleLatency time.Duration `json:"p99CycleLatency"`
+	MaxCycleLatency time.Duration `json:"maxCycleLatency"`
+}
+
+func (r *Report) String() string {
+	return fmt.Sprintf("cycles: %d, pods scheduled: %d, pods pending: %d, scheduling time: %v, "+
+		"throughput: %.1f pods/s, cycle latency p50: %v, p99: %v, max: %v",
+		r.Cycles, r.PodsScheduled, r.PodsPending, r.SchedulingTime, r.Throughput,
+		r.P50CycleLatency, r.P99CycleLatency, r.MaxCycleLatency)
+}
+
+// Thresholds are the bounds of the performance the replay must meet.
+type Thresholds struct {
+	// MinThroughput is the minimal pods scheduled per second, not checked if zero
+	MinThroughput float64
+	// MaxP99CycleLatency is the maximal p99 latency of the cycles, not checked if zero
+	MaxP99CycleLatency time.Duration
+	// MaxPodsPending is the maximal number of the pods pending when the replay stops, negative to skip the check
+	MaxPodsPending int
+	// Baseline is the report the throughput and the p99 latency are compared with, not compared if nil
+	Baseline *Report
+	// Tolerance is the relative regression allowed against the baseline, e.g. 0.2 for 20%
+	Tolerance float64
+}
+
+// Check returns the error listing the thresholds the report violates.
+func (t Thresholds) Check(r *Report) error {
+	var violations []string
+	if t.MinThroughput > 0 && r.Throughput < t.MinThroughput {
+		violations = append(violations, fmt.Sprintf("throughput %.1f pods/s is below %.1f pods/s", r.Throughput, t.MinThroughput))
+	}
+	if t.MaxP99CycleLatency > 0 && r.P99CycleLatency > t.MaxP99CycleLatency {
+		violations = append(violations, fmt.Sprintf("p99 cycle latency %v is above %v", r.P99CycleLatency, t.MaxP99CycleLatency))
+	}
+	if t.MaxPodsPending >= 0 && r.PodsPending > t.MaxPodsPending {
+		violations = append(violations, fmt.Sprintf("%d pods pending are more than %d", r.PodsPending, t.MaxPodsPending))
+	}
+	if t.Baseline != nil {
+		if minThroughput := t.Baseline.Throughput * (1 - t.Tolerance); r.Throughput < minThroughput {
+			violations = append(violations, fmt.Sprintf("throughput %.1f pods/s is below %.1f pods/s, %.0f%% under the baseline %.1f pods/s",
+				r.Throughput, minThroughput, t.Tolerance*100, t.Baseline.Throughput))
+		}
+		if maxLatency := time.Duration(float64(t.Baseline.P99CycleLatency) * (1 + t.Tolerance)); r.P99CycleLatency > maxLatency {
+			violations = append(violations, fmt.Sprintf("p99 cycle latency %v is above %v, %.0f%% over the baseline %v",
+				r.P99CycleLatency, maxLatency, t.Tolerance*100, t.Baseline.P99CycleLatency))
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("performance regression: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// LoadReport loads the report in json, e.g. the baseline written by `--output json`.
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("failed to decode report %s: %v", path, err)
+	}
+	return report, nil
+}
+
+// recorder records the latencies of the cycles.
+type recorder struct {
+	latencies []time.Duration
+	scheduled int
+}
+
+func (r *recorder) cycle(latency time.Duration, bound int) {
+	r.latencies = append(r.latencies, latency)
+	r.scheduled += bound
+}
+
+func (r *recorder) report(pending int) *Report {
+	report := &Report{
+		Cycles:        len(r.latencies),
+		PodsScheduled: r.scheduled,
+		PodsPending:   pending,
+	}
+	if len(r.latencies) == 0 {
+		return report
+	}
+
+	sorted := make([]time.Duration, len(r.latencies))
+	copy(sorted, r.latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, latency := range sorted {
+		report.SchedulingTime += latency
+	}
+	if report.SchedulingTime > 0 {
+		report.Throughput = float64(r.scheduled) / report.SchedulingTime.Seconds()
+	}
+	report.P50CycleLatency = percentile(sorted, 0.50)
+	report.P99CycleLatency = percentile(sorted, 0.99)
+	report.MaxCycleLatency = sorted[len(sorted)-1]
+	return report
+}
+
+// percentile returns the nearest-rank percentile of the sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(float64(len(sorted))*p)) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler"
+	_ "volcano.sh/volcano/pkg/scheduler/actions"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+	_ "volcano.sh/volcano/pkg/scheduler/plugins"
+)
+
+const (
+	schedulerName = "volcano"
+	defaultQueue  = "default"
+	// bindTimeout is the time to wait for the binds of a cycle, which are executed asynchronously by the cache
+	bindTimeout = 10 * time.Second
+)
+
+// Options are the options of the replay.
+type Options struct {
+	// SchedulerConf is the configuration of the scheduler, the default configuration if empty
+	SchedulerConf string
+	// Period is the simulated schedule period, the jobs arriving in a period are added before the next cycle
+	Period time.Duration
+	// MaxCycles stops the replay if the trace is not completed in time
+	MaxCycles int
+}
+
+// Simulator replays the trace of the jobs against the scheduler in-process. The pods are bound and evicted by the
+// fake clients, the bound pods run until their jobs complete, and the evicted pods are deleted without being
+// recreated.
+type Simulator struct {
+	options  Options
+	snapshot *Snapshot
+	jobs     []*job
+
+	actions        []framework.Action
+	tiers          []conf.Tier
+	configurations []conf.Configuration
+
+	cache   *cache.SchedulerCache
+	binder  *binder
+	evictor *evictor
+	stop    chan struct{}
+
+	// pods are the latest pods added to the cache by their keys
+	pods map[string]*v1.Pod
+	// running are the jobs with the time they complete at
+	running map[*job]time.Duration
+	// jobOfPod are the jobs of the trace by the keys of their pods
+	jobOfPod map[string]*job
+}
+
+// New builds the simulator of the trace against the snapshot, the options of the scheduler must be registered, e.g.
+// by options.Default().
+func New(opts Options, snapshot *Snapshot, trace *Trace) (*Simulator, error) {
+	if options.ServerOpts == nil {
+		return nil, fmt.Errorf("scheduler options are not registered")
+	}
+	schedulerConf := opts.SchedulerConf
+	if schedulerConf == "" {
+		schedulerConf = scheduler.DefaultSchedulerConf
+	}
+	actions, tiers, configurations, _, _, err := scheduler.UnmarshalSchedulerConf(schedulerConf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scheduler configuration: %v", err)
+	}
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("no action in scheduler configuration")
+	}
+	if opts.Period <= 0 {
+		return nil, fmt.Errorf("period must be positive")
+	}
+
+	return &Simulator{
+		options:        opts,
+		snapshot:       snapshot,
+		jobs:           trace.jobs(),
+		actions:        actions,
+		tiers:          tiers,
+		configurations: configurations,
+		pods:           map[string]*v1.Pod{},
+		running:        map[*job]time.Duration{},
+		jobOfPod:       map[string]*job{},
+	}, nil
+}
+
+// Run replays the trace until all the jobs arrived and the scheduling makes no more progress, and reports the
+// performance of the scheduler.
+func (s *Simulator) Run() (*Report, error) {
+	metrics.InitKubeSchedulerRelatedMetrics()
+	s.startCache()
+	defer close(s.stop)
+
+	conf.EnabledActionMap = make(map[string]bool, len(s.actions))
+	for _, action := range s.actions {
+		conf.EnabledActionMap[action.Name()] = true
+	}
+
+	recorder := &recorder{}
+	next := 0
+	for cycle := 0; s.options.MaxCycles <= 0 || cycle < s.options.MaxCycles; cycle++ {
+		now := time.Duration(cycle) * s.options.Period
+		s.completeJobs(now)
+		for ; next < len(s.jobs) && s.jobs[next].arrival.Duration <= now; next++ {
+			s.addJob(s.jobs[next])
+		}
+
+		start := time.Now()
+		ssn := framework.OpenSession(s.cache, s.tiers, s.configurations)
+		for _, action := range s.actions {
+			ssn.SetCurrentAction(action.Name())
+			action.Execute(ssn)
+		}
+		framework.CloseSession(ssn)
+		latency := time.Since(start)
+
+		bound, err := s.applyBinds(now)
+		if err != nil {
+			return nil, err
+		}
+		s.applyEvictions()
+		recorder.cycle(latency, bound)
+		klog.V(3).Infof("Cycle %d at %v: %d pods bound in %v", cycle, now, bound, latency)
+
+		if next == len(s.jobs) && bound == 0 && len(s.running) == 0 {
+			break
+		}
+	}
+
+	return recorder.report(s.pendingPods()), nil
+}
+
+func (s *Simulator) startCache() {
+	s.binder = &binder{binds: map[string]string{}}
+	s.evictor = &evictor{}
+	s.stop = make(chan struct{})
+	s.cache = cache.NewCustomMockSchedulerCache(schedulerName, s.binder, s.evictor, &statusUpdater{simulator: s}, nil, &record.FakeRecorder{})
+	s.cache.Run(s.stop)
+
+	hasDefaultQueue := false
+	for _, queue := range s.snapshot.Queues {
+		hasDefaultQueue = hasDefaultQueue || queue.Name == defaultQueue
+		s.cache.AddQueueV1beta1(queue)
+	}
+	if !hasDefaultQueue {
+		s.cache.AddQueueV1beta1(&schedulingv1beta1.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: defaultQueue, UID: types.UID(defaultQueue)},
+			Spec:       schedulingv1beta1.QueueSpec{Weight: 1},
+			Status:     schedulingv1beta1.QueueStatus{State: schedulingv1beta1.QueueStateOpen},
+		})
+	}
+	for _, node := range s.snapshot.Nodes {
+		if err := s.cache.AddOrUpdateNode(node); err != nil {
+			klog.Errorf("Failed to add node %s of snapshot: %v", node.Name, err)
+		}
+	}
+	for _, podGroup := range s.snapshot.PodGroups {
+		s.cache.AddPodGroupV1beta1(podGroup)
+	}
+	for _, pod := range s.snapshot.Pods {
+		s.addPod(pod)
+	}
+}
+
+func (s *Simulator) addJob(j *job) {
+	s.cache.AddPodGroupV1beta1(j.podGroup.DeepCopy())
+	for _, pod := range j.pods {
+		s.jobOfPod[podKey(pod)] = j
+		s.addPod(pod.DeepCopy())
+	}
+}
+
+func (s *Simulator) addPod(pod *v1.Pod) {
+	s.pods[podKey(pod)] = pod
+	s.cache.AddPod(pod)
+}
+
+// applyBinds waits for the binds of the cycle, and runs the pods bound like the kubelet.
+func (s *Simulator) applyBinds(now time.Duration) (int, error) {
+	expected := s.countBindingTasks()
+	deadline := time.Now().Add(bindTimeout)
+	for s.binder.length() < expected {
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("timeout waiting for %d binds, got %d", expected, s.binder.length())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	binds := s.binder.drain()
+	for key, nodeName := range binds {
+		oldPod, found := s.pods[key]
+		if !found {
+			continue
+		}
+		newPod := oldPod.DeepCopy()
+		newPod.Spec.NodeName = nodeName
+		newPod.Status.Phase = v1.PodRunning
+		s.pods[key] = newPod
+		s.cache.UpdatePod(oldPod, newPod)
+
+		if j, found := s.jobOfPod[key]; found && j.duration.Duration > 0 {
+			if _, started := s.running[j]; !started {
+				s.running[j] = now + j.duration.Duration
+			}
+		}
+	}
+	return len(binds), nil
+}
+
+// applyEvictions deletes the pods evicted in the cycle.
+func (s *Simulator) applyEvictions() {
+	for _, key := range s.evictor.drain() {
+		if pod, found := s.pods[key]; found {
+			delete(s.pods, key)
+			s.cache.DeletePod(pod)
+		}
+	}
+}
+
+// completeJobs deletes the pods and the podgroups of the jobs completed by now.
+func (s *Simulator) completeJobs(now time.Duration) {
+	for j, completion := range s.running {
+		if completion > now {
+			continue
+		}
+		for _, pod := range j.pods {
+			key := podKey(pod)
+			if current, found := s.pods[key]; found {
+				delete(s.pods, key)
+				s.cache.DeletePod(current)
+			}
+		}
+		s.cache.DeletePodGroupV1beta1(j.podGroup)
+		delete(s.running, j)
+	}
+}
+
+func (s *Simulator) countBindingTasks() int {
+	s.cache.Mutex.Lock()
+	defer s.cache.Mutex.Unlock()
+	count := 0
+	for _, job := range s.cache.Jobs {
+		count += len(job.TaskStatusIndex[api.Binding])
+	}
+	return count
+}
+
+func (s *Simulator) pendingPods() int {
+	s.cache.Mutex.Lock()
+	defer s.cache.Mutex.Unlock()
+	count := 0
+	for _, job := range s.cache.Jobs {
+		count += len(job.TaskStatusIndex[api.Pending]) + len(job.TaskStatusIndex[api.Pipelined])
+	}
+	return count
+}
+
+// setPodGroupStatus writes the status of the podgroup back to the cache like the informer.
+func (s *Simulator) setPodGroupStatus(pg *api.PodGroup) {
+	s.cache.Mutex.Lock()
+	defer s.cache.Mutex.Unlock()
+	if job, found := s.cache.Jobs[api.JobID(fmt.Sprintf("%s/%s", pg.Namespace, pg.Name))]; found && job.PodGroup != nil {
+		job.PodGroup.Status = *pg.Status.DeepCopy()
+	}
+}
+
+func podKey(pod *v1.Pod) string {
+	return fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+}
+
+// binder records the binds of the pods to be run by the simulator.
+type binder struct {
+	sync.Mutex
+	binds map[string]string
+}
+
+func (b *binder) Bind(_ kubernetes.Interface, tasks []*api.TaskInfo) map[api.TaskID]string {
+	b.Lock()
+	defer b.Unlock()
+	for _, task := range tasks {
+		b.binds[fmt.Sprintf("%s/%s", task.Namespace, task.Name)] = task.NodeName
+	}
+	return nil
+}
+
+func (b *binder) length() int {
+	b.Lock()
+	defer b.Unlock()
+	return len(b.binds)
+}
+
+func (b *binder) drain() map[string]string {
+	b.Lock()
+	defer b.Unlock()
+	binds := b.binds
+	b.binds = map[string]string{}
+	return binds
+}
+
+// evictor records the evictions of the pods to be deleted by the simulator.
+type evictor struct {
+	sync.Mutex
+	evicts []string
+}
+
+func (e *evictor) Evict(pod *v1.Pod, _ string, _ *int64) error {
+	e.Lock()
+	defer e.Unlock()
+	e.evicts = append(e.evicts, podKey(pod))
+	return nil
+}
+
+func (e *evictor) drain() []string {
+	e.Lock()
+	defer e.Unlock()
+	evicts := e.evicts
+	e.evicts = nil
+	return evicts
+}
+
+// statusUpdater writes the status of the podgroups back to the cache, the status of the pods and queues is dropped.
+type statusUpdater struct {
+	simulator *Simulator
+}
+
+func (su *statusUpdater) UpdatePodStatus(pod *v1.Pod) (*v1.Pod, error) {
+	return pod, nil
+}
+
+func (su *statusUpdater) UpdatePodGroup(pg *api.PodGroup) (*api.PodGroup, error) {
+	su.simulator.setPodGroupStatus(pg)
+	return pg, nil
+}
+
+func (su *statusUpdater) UpdateQueueStatus(queue *api.QueueInfo) error {
+	return nil
+}
+
+var _ cache.Binder = &binder{}
+var _ cache.Evictor = &evictor{}
+var _ cache.StatusUpdater = &statusUpdater{}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+)
+
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
+const testSnapshot = `
+apiVersion: v1
+kind: List
+items:
+- {apiVersion: v1, kind: Node, metadata: {name: n1}, status: {allocatable: {cpu: "4", memory: 8Gi, pods: "110"}, capacity: {cpu: "4", memory: 8Gi, pods: "110"}}}
+- {apiVersion: v1, kind: Node, metadata: {name: n2}, status: {allocatable: {cpu: "4", memory: 8Gi, pods: "110"}, capacity: {cpu: "4", memory: 8Gi, pods: "110"}}}
+- {apiVersion: v1, kind: ConfigMap, metadata: {name: ignored}}
+`
+
+const testTrace = `
+jobs:
+- namespace: ns1
+  name: first
+  count: 2
+  replicas: 4
+  resources: {cpu: "1", memory: 1Gi}
+  duration: 2s
+- arrival: 1s
+  namespace: ns2
+  name: second
+  replicas: 8
+  resources: {cpu: "1", memory: 1Gi}
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSimulator(t *testing.T) {
+	snapshot, err := LoadSnapshot(writeFile(t, "snapshot.yaml", testSnapshot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot.Nodes) != 2 {
+		t.Fatalf("expected 2 nodes in snapshot, got %d", len(snapshot.Nodes))
+	}
+	trace, err := LoadTrace(writeFile(t, "trace.yaml", testTrace))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	simulator, err := New(Options{Period: time.Second, MaxCycles: 20}, snapshot, trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := simulator.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the jobs of ns1 take the whole cluster until they complete, then the job of ns2 is scheduled
+	if report.PodsScheduled != 16 || report.PodsPending != 0 {
+		t.Errorf("expected 16 pods scheduled and none pending, got %v", report)
+	}
+	if report.Cycles < 3 {
+		t.Errorf("expected the job of ns2 scheduled after the jobs of ns1 complete, got %v", report)
+	}
+	if err := (Thresholds{MaxPodsPending: 0}).Check(report); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLoadTraceInvalid(t *testing.T) {
+	if _, err := LoadTrace(writeFile(t, "trace.yaml", "jobs:\n- namespace: ns1\n  name: job1\n")); err == nil {
+		t.Errorf("expected error loading the job without replicas")
+	}
+	if _, err := LoadTrace(writeFile(t, "trace.yaml", "jobs:\n- namespace: ns1\n  name: job1\n  replicas: 1\n  unknown: 1\n")); err == nil {
+		t.Errorf("expected error loading the job with unknown field")
+	}
+}
+
+func TestLoadReport(t *testing.T) {
+	report, err := LoadReport(writeFile(t, "baseline.json", `{"throughput": 120.5, "p99CycleLatency": 25000000}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Throughput != 120.5 || report.P99CycleLatency != 25*time.Millisecond {
+		t.Errorf("unexpected baseline %v", report)
+	}
+	if _, err := LoadReport(writeFile(t, "baseline.json", "throughput")); err == nil {
+		t.Errorf("expected error loading invalid baseline")
+	}
+}
+
+func TestReport(t *testing.T) {
+	r := &recorder{}
+	for i := 1; i <= 100; i++ {
+		r.cycle(time.Duration(i)*time.Millisecond, 1)
+	}
+	report := r.report(3)
+	if report.PodsScheduled != 100 || report.PodsPending != 3 {
+		t.Errorf("unexpected pods of report %v", report)
+	}
+	if report.P50CycleLatency != 50*time.Millisecond || report.P99CycleLatency != 99*time.Millisecond ||
+		report.MaxCycleLatency != 100*time.Millisecond {
+		t.Errorf("unexpected latencies of report %v", report)
+	}
+	if report.SchedulingTime != 5050*time.Millisecond {
+		t.Errorf("expected scheduling time 5.05s, got %v", report.SchedulingTime)
+	}
+
+	testCases := []struct {
+		name       string
+		thresholds Thresholds
+		expectErr  bool
+	}{
+		{name: "no thresholds", thresholds: Thresholds{MaxPodsPending: -1}},
+		{name: "met", thresholds: Thresholds{MinThroughput: 10, MaxP99CycleLatency: 100 * time.Millisecond, MaxPodsPending: 3}},
+		{name: "throughput", thresholds: Thresholds{MinThroughput: 100, MaxPodsPending: -1}, expectErr: true},
+		{name: "p99 latency", thresholds: Thresholds{MaxP99CycleLatency: 50 * time.Millisecond, MaxPodsPending: -1}, expectErr: true},
+		{name: "pending", thresholds: Thresholds{MaxPodsPending: 0}, expectErr: true},
+		{name: "within baseline", thresholds: Thresholds{MaxPodsPending: -1, Tolerance: 0.2,
+			Baseline: &Report{Throughput: report.Throughput * 1.1, P99CycleLatency: 90 * time.Millisecond}}},
+		{name: "throughput under baseline", thresholds: Thresholds{MaxPodsPending: -1, Tolerance: 0.2,
+			Baseline: &Report{Throughput: report.Throughput * 1.5, P99CycleLatency: 99 * time.Millisecond}}, expectErr: true},
+		{name: "p99 latency over baseline", thresholds: Thresholds{MaxPodsPending: -1, Tolerance: 0.2,
+			Baseline: &Report{Throughput: report.Throughput, P99CycleLatency: 80 * time.Millisecond}}, expectErr: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := testCase.thresholds.Check(report); (err != nil) != testCase.expectErr {
+				t.Errorf("expected error %v, got %v", testCase.expectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+// Snapshot is the state of the cluster the trace is replayed against, it is recorded by e.g.
+// `kubectl get nodes,queues,podgroups,pods -A -o yaml`.
+type Snapshot struct {
+	Nodes     []*v1.Node
+	Queues    []*schedulingv1beta1.Queue
+	PodGroups []*schedulingv1beta1.PodGroup
+	Pods      []*v1.Pod
+}
+
+// Trace is the jobs arriving at the cluster.
+type Trace struct {
+	Jobs []TraceJob `json:"jobs"`
+}
+
+// TraceJob is a job of the trace, or Count identical jobs arriving at the same time.
+type TraceJob struct {
+	// Arrival is the offset of the arrival of the job from the start of the replay
+	Arrival metav1.Duration `json:"arrival,omitempty"`
+	// Count is the number of the identical jobs, whose names are suffixed by their indexes, 1 by default
+	Count     int    `json:"count,omitempty"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Queue is the queue of the job, the default queue if empty
+	Queue             string `json:"queue,omitempty"`
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// Replicas is the number of the pods of the job
+	Replicas int32 `json:"replicas"`
+	// MinAvailable is the minMember of the podgroup of the job, Replicas by default
+	MinAvailable int32 `json:"minAvailable,omitempty"`
+	// Resources is the requests of every pod of the job
+	Resources v1.ResourceList `json:"resources,omitempty"`
+	// Duration is how long the job runs once its first pod is bound, it never completes if zero
+	Duration metav1.Duration `json:"duration,omitempty"`
+}
+
+// job is a job of the trace to be replayed.
+type job struct {
+	arrival  metav1.Duration
+	duration metav1.Duration
+	podGroup *schedulingv1beta1.PodGroup
+	pods     []*v1.Pod
+}
+
+// LoadSnapshot loads the snapshot from the List of the objects in yaml or json, the objects of the other kinds are
+// ignored.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	list := struct {
+		Items []json.RawMessage `json:"items"`
+	}{}
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %s: %v", path, err)
+	}
+
+	snapshot := &Snapshot{}
+	for _, item := range list.Items {
+		meta := metav1.TypeMeta{}
+		if err := json.Unmarshal(item, &meta); err != nil {
+			return nil, fmt.Errorf("failed to decode object of snapshot %s: %v", path, err)
+		}
+		var object interface{}
+		switch meta.Kind {
+		case "Node":
+			node := &v1.Node{}
+			snapshot.Nodes = append(snapshot.Nodes, node)
+			object = node
+		case "Queue":
+			queue := &schedulingv1beta1.Queue{}
+			snapshot.Queues = append(snapshot.Queues, queue)
+			object = queue
+		case "PodGroup":
+			podGroup := &schedulingv1beta1.PodGroup{}
+			snapshot.PodGroups = append(snapshot.PodGroups, podGroup)
+			object = podGroup
+		case "Pod":
+			pod := &v1.Pod{}
+			snapshot.Pods = append(snapshot.Pods, pod)
+			object = pod
+		default:
+			continue
+		}
+		if err := json.Unmarshal(item, object); err != nil {
+			return nil, fmt.Errorf("failed to decode %s of snapshot %s: %v", meta.Kind, path, err)
+		}
+	}
+	for _, pod := range snapshot.Pods {
+		if pod.UID == "" {
+			pod.UID = types.UID(pod.Namespace + "-" + pod.Name)
+		}
+	}
+	return snapshot, nil
+}
+
+// LoadTrace loads the trace in yaml or json.
+func LoadTrace(path string) (*Trace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	trace := &Trace{}
+	if err := yaml.UnmarshalStrict(data, trace); err != nil {
+		return nil, fmt.Errorf("failed to decode trace %s: %v", path, err)
+	}
+	for i, traceJob := range trace.Jobs {
+		if traceJob.Namespace == "" || traceJob.Name == "" || traceJob.Replicas <= 0 {
+			return nil, fmt.Errorf("job %d of trace %s must have namespace, name and positive replicas", i, path)
+		}
+	}
+	return trace, nil
+}
+
+// jobs expands the jobs of the trace in the order of their arrival.
+func (t *Trace) jobs() []*job {
+	var jobs []*job
+	for _, traceJob := range t.Jobs {
+		count := traceJob.Count
+		if count <= 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			name := traceJob.Name
+			if traceJob.Count > 1 {
+				name = fmt.Sprintf("%s-%d", traceJob.Name, i)
+			}
+			jobs = append(jobs, traceJob.build(name))
+		}
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].arrival.Duration < jobs[j].arrival.Duration
+	})
+	return jobs
+}
+
+func (t *TraceJob) build(name string) *job {
+	minAvailable := t.MinAvailable
+	if minAvailable <= 0 {
+		minAvailable = t.Replicas
+	}
+	queue := t.Queue
+	if queue == "" {
+		queue = defaultQueue
+	}
+	podGroup := &schedulingv1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: t.Namespace,
+			Name:      name,
+			UID:       types.UID(t.Namespace + "-" + name),
+		},
+		Spec: schedulingv1beta1.PodGroupSpec{
+			Queue:             queue,
+			MinMember:         minAvailable,
+			PriorityClassName: t.PriorityClassName,
+		},
+		Status: schedulingv1beta1.PodGroupStatus{
+			Phase: schedulingv1beta1.PodGroupPending,
+		},
+	}
+
+	j := &job{arrival: t.Arrival, duration: t.Duration, podGroup: podGroup}
+	for i := int32(0); i < t.Replicas; i++ {
+		podName := fmt.Sprintf("%s-%d", name, i)
+		j.pods = append(j.pods, &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   t.Namespace,
+				Name:        podName,
+				UID:         types.UID(t.Namespace + "-" + podName),
+				Annotations: map[string]string{schedulingv1beta1.KubeGroupNameAnnotationKey: name},
+			},
+			Spec: v1.PodSpec{
+				PriorityClassName: t.PriorityClassName,
+				Containers: []v1.Container{{
+					Name:      "main",
+					Resources: v1.ResourceRequirements{Requests: t.Resources.DeepCopy()},
+				}},
+			},
+			Status: v1.PodStatus{Phase: v1.PodPending},
+		})
+	}
+	return j
+}
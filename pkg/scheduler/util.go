@@ -51,13 +51,13 @@ tiers:
   - name: nodeorder
 `
 
-func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []conf.Configuration, map[string]string, error) {
+func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []conf.Configuration, map[string]string, map[string]string, error) {
 	var actions []framework.Action
 
 	schedulerConf := &conf.SchedulerConfiguration{}
 
 	if err := yaml.Unmarshal([]byte(confStr), schedulerConf); err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, nil, nil, err
 	}
 	// Set default settings for each plugin if not set
 	for i, tier := range schedulerConf.Tiers {
@@ -75,9 +75,12 @@ func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []
 				proportion = true
 			}
 			plugins.ApplyPluginConfDefaults(&schedulerConf.Tiers[i].Plugins[j])
+			if err := validatePluginScoring(&tier.Plugins[j]); err != nil {
+				return nil, nil, nil, nil, nil, err
+			}
 		}
 		if hdrf && proportion {
-			return nil, nil, nil, nil, fmt.Errorf("proportion and drf with hierarchy enabled conflicts")
+			return nil, nil, nil, nil, nil, fmt.Errorf("proportion and drf with hierarchy enabled conflicts")
 		}
 	}
 
@@ -90,7 +93,28 @@ func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []
 		}
 	}
 
-	return actions, schedulerConf.Tiers, schedulerConf.Configurations, schedulerConf.MetricsConfiguration, nil
+	return actions, schedulerConf.Tiers, schedulerConf.Configurations, schedulerConf.MetricsConfiguration, schedulerConf.AuditConfiguration, nil
+}
+
+// validatePluginScoring validates the weights and the score normalizer of the plugin.
+func validatePluginScoring(option *conf.PluginOption) error {
+	if option.Weight != nil && *option.Weight < 0 {
+		return fmt.Errorf("weight of plugin %s must be >= 0, got %d", option.Name, *option.Weight)
+	}
+	for actionName, weight := range option.ActionWeights {
+		if _, found := framework.GetAction(actionName); !found {
+			return fmt.Errorf("action %s in actionWeights of plugin %s is not found", actionName, option.Name)
+		}
+		if weight < 0 {
+			return fmt.Errorf("weight of plugin %s in action %s must be >= 0, got %d", option.Name, actionName, weight)
+		}
+	}
+	if option.ScoreNormalizer != "" {
+		if _, found := framework.GetScoreNormalizer(option.ScoreNormalizer); !found {
+			return fmt.Errorf("scoreNormalizer %s of plugin %s is not found", option.ScoreNormalizer, option.Name)
+		}
+	}
+	return nil
 }
 
 func runSchedulerSocket() {
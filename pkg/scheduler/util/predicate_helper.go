@@ -25,9 +25,13 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
+	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/scheduler/api"
 )
 
+// defaultPredicateWorkers is the number of workers checking nodes in parallel if it is not configured.
+const defaultPredicateWorkers = 16
+
 type PredicateHelper interface {
 	PredicateNodes(task *api.TaskInfo, nodes []*api.NodeInfo, fn api.PredicateFn, enableErrorCache bool) ([]*api.NodeInfo, *api.FitErrors)
 }
@@ -113,8 +117,8 @@ func (ph *predicateHelper) PredicateNodes(task *api.TaskInfo, nodes []*api.NodeI
 		}
 	}
 
-	//workqueue.ParallelizeUntil(context.TODO(), 16, len(nodes), checkNode)
-	workqueue.ParallelizeUntil(ctx, 16, allNodes, checkNode)
+	workqueue.ParallelizeUntil(ctx, predicateWorkers(), allNodes, checkNode)
+	cancel()
 
 	//processedNodes := int(numFoundNodes) + len(filteredNodesStatuses) + len(failedPredicateMap)
 	lastProcessedNodeIndex = (lastProcessedNodeIndex + int(processedNodes)) % allNodes
@@ -122,6 +126,14 @@ func (ph *predicateHelper) PredicateNodes(task *api.TaskInfo, nodes []*api.NodeI
 	return predicateNodes, fe
 }
 
+// predicateWorkers returns the number of workers checking the nodes of a task in parallel.
+func predicateWorkers() int {
+	if opts := options.ServerOpts; opts != nil && opts.PredicateWorkers > 0 {
+		return int(opts.PredicateWorkers)
+	}
+	return defaultPredicateWorkers
+}
+
 func taskGroupID(task *api.TaskInfo) string {
 	return fmt.Sprintf("%s/%s", task.Job, task.TaskRole)
 }
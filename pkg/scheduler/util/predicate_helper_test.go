@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+func buildPredicateNodes(num int) []*api.NodeInfo {
+	nodes := make([]*api.NodeInfo, 0, num)
+	for i := 0; i < num; i++ {
+		node := BuildNode(fmt.Sprintf("n%d", i), api.BuildResourceList("8", "16Gi"), map[string]string{"index": strconv.Itoa(i)})
+		nodes = append(nodes, api.NewNodeInfo(node))
+	}
+	return nodes
+}
+
+func buildPredicateTask() *api.TaskInfo {
+	return api.NewTaskInfo(BuildPod("ns1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+}
+
+// oddNodesFit fits the task onto the nodes of odd index only.
+func oddNodesFit(task *api.TaskInfo, node *api.NodeInfo) error {
+	index, _ := strconv.Atoi(node.Node.Labels["index"])
+	if index%2 == 0 {
+		return api.NewFitErrWithStatus(task, node, &api.Status{Code: api.Unschedulable, Reason: "even node"})
+	}
+	return nil
+}
+
+func TestPredicateNodes(t *testing.T) {
+	tests := []struct {
+		name             string
+		numNodes         int
+		predicateWorkers int32
+		wantNumNodes     int
+	}{
+		{
+			name:         "all feasible nodes are found in small cluster",
+			numNodes:     50,
+			wantNumNodes: 25,
+		},
+		{
+			name:             "stop once enough feasible nodes are found",
+			numNodes:         5000,
+			predicateWorkers: 4,
+			wantNumNodes:     500,
+		},
+		{
+			name:             "one worker",
+			numNodes:         5000,
+			predicateWorkers: 1,
+			wantNumNodes:     500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options.ServerOpts = &options.ServerOption{
+				MinPercentageOfNodesToFind: 5,
+				MinNodesToFind:             100,
+				PercentageOfNodesToFind:    10,
+				PredicateWorkers:           tt.predicateWorkers,
+			}
+			nodes := buildPredicateNodes(tt.numNodes)
+
+			var checked int32
+			fn := func(task *api.TaskInfo, node *api.NodeInfo) error {
+				atomic.AddInt32(&checked, 1)
+				return oddNodesFit(task, node)
+			}
+			found, fitErrors := NewPredicateHelper().PredicateNodes(buildPredicateTask(), nodes, fn, false)
+			if len(found) != tt.wantNumNodes {
+				t.Errorf("expected %d feasible nodes, got %d", tt.wantNumNodes, len(found))
+			}
+			for _, node := range found {
+				if index, _ := strconv.Atoi(node.Node.Labels["index"]); index%2 == 0 {
+					t.Errorf("unexpected infeasible node %s", node.Name)
+				}
+			}
+			if int(checked) == tt.numNodes && tt.wantNumNodes < tt.numNodes/2 {
+				t.Errorf("expected the search to stop early, all %d nodes are checked", checked)
+			}
+			if fitErrors == nil {
+				t.Errorf("expected fit errors of the infeasible nodes")
+			}
+		})
+	}
+}
+
+func TestPredicateWorkers(t *testing.T) {
+	options.ServerOpts = nil
+	if workers := predicateWorkers(); workers != defaultPredicateWorkers {
+		t.Errorf("expected %d workers if not configured, got %d", defaultPredicateWorkers, workers)
+	}
+	options.ServerOpts = &options.ServerOption{PredicateWorkers: 32}
+	if workers := predicateWorkers(); workers != 32 {
+		t.Errorf("expected 32 workers, got %d", workers)
+	}
+}
+
+func BenchmarkPredicateNodes(b *testing.B) {
+	nodes := buildPredicateNodes(5000)
+	task := buildPredicateTask()
+	for _, workers := range []int32{1, 4, 16, 64} {
+		for _, percentage := range []int32{0, 100} {
+			b.Run(fmt.Sprintf("workers=%d/percentage=%d", workers, percentage), func(b *testing.B) {
+				options.ServerOpts = &options.ServerOption{
+					MinPercentageOfNodesToFind: 5,
+					MinNodesToFind:             100,
+					PercentageOfNodesToFind:    percentage,
+					PredicateWorkers:           workers,
+				}
+				ph := NewPredicateHelper()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					ph.PredicateNodes(task, nodes, oddNodesFit, false)
+				}
+			})
+		}
+	}
+}
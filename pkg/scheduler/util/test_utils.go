@@ -530,7 +530,7 @@ func (fe *FakeEvictor) Length() int {
 }
 
 // Evict is used by fake evictor to evict pods
-func (fe *FakeEvictor) Evict(p *v1.Pod, reason string) error {
+func (fe *FakeEvictor) Evict(p *v1.Pod, reason string, gracePeriodSeconds *int64) error {
 	fe.Lock()
 	defer fe.Unlock()
 
@@ -212,7 +212,7 @@ tiers:
 
 	var expectedConfigurations []conf.Configuration
 
-	_, tiers, configurations, _, err := UnmarshalSchedulerConf(configuration)
+	_, tiers, configurations, _, _, err := UnmarshalSchedulerConf(configuration)
 	if err != nil {
 		t.Errorf("Failed to load Scheduler configuration: %v", err)
 	}
@@ -225,3 +225,87 @@ tiers:
 			expectedConfigurations, configurations)
 	}
 }
+
+func TestLoadSchedulerConfWithScoring(t *testing.T) {
+	tests := []struct {
+		name          string
+		configuration string
+		expectErr     bool
+	}{
+		{
+			name: "weights of plugin and action",
+			configuration: `
+actions: "allocate, backfill"
+tiers:
+- plugins:
+  - name: nodeorder
+    weight: 2
+    actionWeights:
+      backfill: 0
+    scoreNormalizer: minmax
+`,
+		},
+		{
+			name: "negative weight",
+			configuration: `
+actions: "allocate, backfill"
+tiers:
+- plugins:
+  - name: nodeorder
+    weight: -1
+`,
+			expectErr: true,
+		},
+		{
+			name: "negative weight of action",
+			configuration: `
+actions: "allocate, backfill"
+tiers:
+- plugins:
+  - name: nodeorder
+    actionWeights:
+      allocate: -1
+`,
+			expectErr: true,
+		},
+		{
+			name: "unknown action",
+			configuration: `
+actions: "allocate, backfill"
+tiers:
+- plugins:
+  - name: nodeorder
+    actionWeights:
+      unknown: 1
+`,
+			expectErr: true,
+		},
+		{
+			name: "unknown score normalizer",
+			configuration: `
+actions: "allocate, backfill"
+tiers:
+- plugins:
+  - name: nodeorder
+    scoreNormalizer: unknown
+`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, tiers, _, _, _, err := UnmarshalSchedulerConf(tt.configuration)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if tt.expectErr {
+				return
+			}
+			plugin := tiers[0].Plugins[0]
+			if plugin.Weight == nil || *plugin.Weight != 2 || plugin.ActionWeights["backfill"] != 0 || plugin.ScoreNormalizer != "minmax" {
+				t.Errorf("unexpected scoring options of plugin: %+v", plugin)
+			}
+		})
+	}
+}
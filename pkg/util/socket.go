@@ -18,6 +18,7 @@ package util
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"html"
 	"net"
@@ -40,9 +41,15 @@ const (
 	SocketSuffix     = "-klog.sock"
 	SocketDirEnvName = "DEBUG_SOCKET_DIR"
 	// The HTTP request patterns
-	setLogLevelPath  = "/setlevel"
-	getLogLevelPath  = "/getlevel"
-	exampleSocketCli = "\"Failed to change klog log level, because got wrong value from level argument\\n\"+\n\t\t\t\t\"example: curl --unix-socket /tmp/klog-socks/componentName-klog.sock \\\"http://localhost/setlevel?level=8&duration=60s\\\"\\n\"+\n\t\t\t\t\"level=8 means changing klog log level to 8\\n\"+\n\t\t\t\t\"duration=60s means maintaining level=8 for 60 seconds[60m -> 60 minutes; 60h -> 60 hours]\""
+	setLogLevelPath   = "/setlevel"
+	getLogLevelPath   = "/getlevel"
+	setVModulePath    = "/setvmodule"
+	getVModulePath    = "/getvmodule"
+	exampleSocketCli  = "\"Failed to change klog log level, because got wrong value from level argument\\n\"+\n\t\t\t\t\"example: curl --unix-socket /tmp/klog-socks/componentName-klog.sock \\\"http://localhost/setlevel?level=8&duration=60s\\\"\\n\"+\n\t\t\t\t\"level=8 means changing klog log level to 8\\n\"+\n\t\t\t\t\"duration=60s means maintaining level=8 for 60 seconds[60m -> 60 minutes; 60h -> 60 hours]\""
+	exampleVModuleCli = "Failed to change klog vmodule, because got wrong value from duration argument\n" +
+		"example: curl --unix-socket /tmp/klog-socks/componentName-klog.sock \"http://localhost/setvmodule?vmodule=allocate=5,gang*=4&duration=60s\"\n" +
+		"vmodule=allocate=5,gang*=4 means changing the log level of allocate.go to 5 and of the files matching gang* to 4\n" +
+		"duration=60s means maintaining the vmodule for 60 seconds[60m -> 60 minutes; 60h -> 60 hours]\n"
 )
 
 var (
@@ -57,8 +64,52 @@ var (
 	startupLogLevel string
 	// mutex is used to avoid data race about prevCtx, prevCtxCancelFunc and currentLogLevel
 	mutex sync.RWMutex
+
+	// klogFlags shares the flags of klog, to change the vmodule of klog at runtime.
+	klogFlags = newKlogFlags()
+	// startupVModule stores start-up vmodule
+	startupVModule string
+	// cancelVModuleReset cancels the timer recovering the start-up vmodule
+	cancelVModuleReset context.CancelFunc
+	// vmoduleMutex is used to avoid data race about cancelVModuleReset
+	vmoduleMutex sync.Mutex
 )
 
+func newKlogFlags() *flag.FlagSet {
+	fs := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(fs)
+	return fs
+}
+
+// modifyVModule changes the vmodule of klog, i.e. the log levels per file, and recovers the start-up vmodule
+// after the duration.
+func modifyVModule(vmodule string, duration time.Duration) error {
+	vmoduleMutex.Lock()
+	defer vmoduleMutex.Unlock()
+
+	if err := klogFlags.Set("vmodule", vmodule); err != nil {
+		return err
+	}
+	if cancelVModuleReset != nil {
+		cancelVModuleReset()
+	}
+	var ctx context.Context
+	ctx, cancelVModuleReset = context.WithCancel(context.Background())
+	go func() {
+		defer runtime.HandleCrash()
+		select {
+		case <-time.After(duration):
+			if err := klogFlags.Set("vmodule", startupVModule); err != nil {
+				klog.Error(err)
+				return
+			}
+			klog.InfoS("Klog recover to start-up vmodule successfully", "startupVModule", startupVModule)
+		case <-ctx.Done():
+		}
+	}()
+	return nil
+}
+
 // responseOk returns a statusOK response to client
 func responseOk(w *http.ResponseWriter, okMsg string) {
 	(*w).Header().Set("Content-Type", "text/plain; charset=utf-8")
@@ -161,6 +212,27 @@ func installKlogLogLevelHandler(mux *http.ServeMux, startup string) {
 		responseOk(&w, fmt.Sprintf("Current klog log level: %s\n", currentLogLevel))
 		mutex.RUnlock()
 	}))
+
+	// Register the HTTP request patterns that can set/get the log levels per file, e.g. to debug an action or a plugin
+	startupVModule = klogFlags.Lookup("vmodule").Value.String()
+	mux.Handle(setVModulePath, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		values := r.URL.Query()
+		vmodule := values.Get("vmodule")
+		duration, err := time.ParseDuration(values.Get("duration"))
+		if err != nil || duration.Milliseconds() <= 0 {
+			responseError(&w, exampleVModuleCli, http.StatusBadRequest)
+			return
+		}
+		if err := modifyVModule(vmodule, duration); err != nil {
+			responseError(&w, fmt.Sprintf("Failed to change klog vmodule. Error: %v\n", html.EscapeString(err.Error())), http.StatusBadRequest)
+			return
+		}
+		responseOk(&w, fmt.Sprintf("Change klog vmodule to %s successfully and for %v\n", html.EscapeString(vmodule), duration))
+	}))
+
+	mux.Handle(getVModulePath, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		responseOk(&w, fmt.Sprintf("Current klog vmodule: %s\n", klogFlags.Lookup("vmodule").Value.String()))
+	}))
 }
 
 // listenUnix does net.Listen for a unix socket
@@ -17,15 +17,21 @@ limitations under the License.
 package validate
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	whv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
 	flowv1alpha1 "volcano.sh/apis/pkg/apis/flow/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/jobflow/condition"
+	"volcano.sh/volcano/pkg/controllers/jobtemplate/parameters"
 	"volcano.sh/volcano/pkg/webhooks/router"
 	"volcano.sh/volcano/pkg/webhooks/schema"
 	"volcano.sh/volcano/pkg/webhooks/util"
@@ -80,6 +86,12 @@ func AdmitJobFlows(ar admissionv1.AdmissionReview) *admissionv1.AdmissionRespons
 	switch ar.Request.Operation {
 	case admissionv1.Create, admissionv1.Update:
 		msg = validateJobFlowDAG(jobFlow, &reviewResponse)
+		if reviewResponse.Allowed {
+			msg = validateJobFlowConditions(jobFlow, &reviewResponse)
+		}
+		if reviewResponse.Allowed {
+			msg = validateJobFlowParameters(jobFlow, &reviewResponse)
+		}
 	default:
 		err := OperationNotCreateOrUpdate
 		return util.ToAdmissionResponse(err)
@@ -120,3 +132,81 @@ func validateJobFlowDAG(jobflow *flowv1alpha1.JobFlow, reviewResponse *admission
 	}
 	return msg
 }
+
+// validateJobFlowConditions checks that the conditions are on the flows with targets and compile.
+func validateJobFlowConditions(jobflow *flowv1alpha1.JobFlow, reviewResponse *admissionv1.AdmissionResponse) string {
+	conditions, err := condition.Parse(jobflow)
+	if err != nil {
+		reviewResponse.Allowed = false
+		return err.Error()
+	}
+
+	flows := make(map[string]flowv1alpha1.Flow, len(jobflow.Spec.Flows))
+	for _, flow := range jobflow.Spec.Flows {
+		flows[flow.Name] = flow
+	}
+	var msg string
+	for flowName, expression := range conditions {
+		flow, found := flows[flowName]
+		if !found {
+			msg += fmt.Sprintf(" condition of flow %s: %s;", flowName, VertexNotDefinedError.Error())
+			continue
+		}
+		if flow.DependsOn == nil || len(flow.DependsOn.Targets) == 0 {
+			msg += fmt.Sprintf(" condition of flow %s: flow must depend on targets;", flowName)
+			continue
+		}
+		if _, err := condition.Compile(expression); err != nil {
+			msg += fmt.Sprintf(" condition of flow %s: %v;", flowName, err)
+		}
+	}
+	if msg != "" {
+		reviewResponse.Allowed = false
+	}
+	return msg
+}
+
+// validateJobFlowParameters checks that the parameters are on the flows, and the JobTemplates of the flows
+// can be instantiated with them. The JobTemplates not created yet are checked when the jobs are created.
+func validateJobFlowParameters(jobflow *flowv1alpha1.JobFlow, reviewResponse *admissionv1.AdmissionResponse) string {
+	values, err := parameters.ParseFlowValues(jobflow)
+	if err != nil {
+		reviewResponse.Allowed = false
+		return err.Error()
+	}
+
+	flows := make(map[string]bool, len(jobflow.Spec.Flows))
+	for _, flow := range jobflow.Spec.Flows {
+		flows[flow.Name] = true
+	}
+	var undefined []string
+	for flowName := range values {
+		if !flows[flowName] {
+			undefined = append(undefined, flowName)
+		}
+	}
+	sort.Strings(undefined)
+	var msg string
+	for _, flowName := range undefined {
+		msg += fmt.Sprintf(" parameters of flow %s: %s;", flowName, VertexNotDefinedError.Error())
+	}
+
+	if config.VolcanoClient != nil {
+		for _, flow := range jobflow.Spec.Flows {
+			jobTemplate, err := config.VolcanoClient.FlowV1alpha1().JobTemplates(jobflow.Namespace).Get(context.TODO(), flow.Name, metav1.GetOptions{})
+			if err != nil {
+				if !apierrors.IsNotFound(err) {
+					msg += fmt.Sprintf(" failed to get JobTemplate of flow %s: %v;", flow.Name, err)
+				}
+				continue
+			}
+			if _, err := parameters.Render(jobTemplate, values[flow.Name]); err != nil {
+				msg += fmt.Sprintf(" parameters of flow %s: %v;", flow.Name, err)
+			}
+		}
+	}
+	if msg != "" {
+		reviewResponse.Allowed = false
+	}
+	return msg
+}
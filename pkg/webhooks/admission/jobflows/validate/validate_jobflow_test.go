@@ -22,12 +22,16 @@ import (
 	"testing"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	flowv1alpha1 "volcano.sh/apis/pkg/apis/flow/v1alpha1"
 	schedulingv1beta2 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	fakeclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
 	informers "volcano.sh/apis/pkg/client/informers/externalversions"
+	"volcano.sh/volcano/pkg/controllers/jobflow/condition"
+	"volcano.sh/volcano/pkg/controllers/jobtemplate/parameters"
 )
 
 func TestValidateJobFlowCreate(t *testing.T) {
@@ -313,3 +317,136 @@ func TestValidateJobFlowCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateJobFlowConditions(t *testing.T) {
+	flows := []flowv1alpha1.Flow{
+		{Name: "train"},
+		{Name: "cleanup", DependsOn: &flowv1alpha1.DependsOn{Targets: []string{"train"}}},
+	}
+	testCases := []struct {
+		name       string
+		conditions string
+		allowed    bool
+	}{
+		{
+			name:    "no conditions",
+			allowed: true,
+		},
+		{
+			name:       "valid condition",
+			conditions: `{"cleanup": "jobs.train.phase == 'Failed'"}`,
+			allowed:    true,
+		},
+		{
+			name:       "invalid annotation",
+			conditions: `cleanup`,
+		},
+		{
+			name:       "flow not defined",
+			conditions: `{"deploy": "true"}`,
+		},
+		{
+			name:       "flow without targets",
+			conditions: `{"train": "true"}`,
+		},
+		{
+			name:       "condition not bool",
+			conditions: `{"cleanup": "jobs.train.phase"}`,
+		},
+		{
+			name:       "syntax error",
+			conditions: `{"cleanup": "jobs.train.phase =="}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jobFlow := &flowv1alpha1.JobFlow{
+				ObjectMeta: metav1.ObjectMeta{Name: "jobflow", Namespace: "test", Annotations: map[string]string{}},
+				Spec:       flowv1alpha1.JobFlowSpec{Flows: flows},
+			}
+			if tc.conditions != "" {
+				jobFlow.Annotations[condition.FlowConditionsAnnotation] = tc.conditions
+			}
+			reviewResponse := admissionv1.AdmissionResponse{Allowed: true}
+			msg := validateJobFlowConditions(jobFlow, &reviewResponse)
+			if reviewResponse.Allowed != tc.allowed {
+				t.Errorf("expected allowed %v, got %v: %s", tc.allowed, reviewResponse.Allowed, msg)
+			}
+		})
+	}
+}
+
+func TestValidateJobFlowParameters(t *testing.T) {
+	flows := []flowv1alpha1.Flow{
+		{Name: "train"},
+		{Name: "deploy", DependsOn: &flowv1alpha1.DependsOn{Targets: []string{"train"}}},
+	}
+	jobTemplate := &flowv1alpha1.JobTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "train",
+			Namespace:   "test",
+			Annotations: map[string]string{parameters.ParametersAnnotation: `{"image": null, "queue": "default"}`},
+		},
+		Spec: batchv1alpha1.JobSpec{Queue: "{{.params.queue}}", Tasks: []batchv1alpha1.TaskSpec{{
+			Name: "worker",
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "trainer",
+				Image: "{{.params.image}}",
+			}}}},
+		}}},
+	}
+	config.VolcanoClient = fakeclient.NewSimpleClientset(jobTemplate)
+	defer func() {
+		config.VolcanoClient = nil
+	}()
+
+	testCases := []struct {
+		name       string
+		parameters string
+		allowed    bool
+	}{
+		{
+			name:       "valid parameters",
+			parameters: `{"train": {"image": "trainer:v2"}}`,
+			allowed:    true,
+		},
+		{
+			name:       "parameters of the flow without JobTemplate",
+			parameters: `{"train": {"image": "trainer:v2"}, "deploy": {"replicas": "2"}}`,
+			allowed:    true,
+		},
+		{
+			name: "required parameter not set",
+		},
+		{
+			name:       "undeclared parameter",
+			parameters: `{"train": {"image": "trainer:v2", "tag": "v2"}}`,
+		},
+		{
+			name:       "flow not defined",
+			parameters: `{"train": {"image": "trainer:v2"}, "eval": {}}`,
+		},
+		{
+			name:       "invalid annotation",
+			parameters: `{"train": "trainer:v2"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jobFlow := &flowv1alpha1.JobFlow{
+				ObjectMeta: metav1.ObjectMeta{Name: "jobflow", Namespace: "test", Annotations: map[string]string{}},
+				Spec:       flowv1alpha1.JobFlowSpec{Flows: flows},
+			}
+			if tc.parameters != "" {
+				jobFlow.Annotations[parameters.FlowParametersAnnotation] = tc.parameters
+			}
+			reviewResponse := admissionv1.AdmissionResponse{Allowed: true}
+			msg := validateJobFlowParameters(jobFlow, &reviewResponse)
+			if reviewResponse.Allowed != tc.allowed {
+				t.Errorf("expected allowed %v, got %v: %s", tc.allowed, reviewResponse.Allowed, msg)
+			}
+		})
+	}
+}
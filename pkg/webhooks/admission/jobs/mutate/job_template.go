@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/jobtemplate/parameters"
+)
+
+// resolveJobTemplate instantiates the spec of the job from the JobTemplate it refers to, with the values of
+// the parameters in the annotations of the job. It returns true if the spec is instantiated.
+func resolveJobTemplate(job *v1alpha1.Job) (bool, error) {
+	name, found := job.Annotations[parameters.JobTemplateAnnotation]
+	if !found {
+		return false, nil
+	}
+	if len(job.Spec.Tasks) > 0 {
+		return false, fmt.Errorf("job referring to JobTemplate %s must not define tasks", name)
+	}
+	values, err := parameters.ParseValues(job.Annotations)
+	if err != nil {
+		return false, err
+	}
+	if config.VolcanoClient == nil {
+		return false, fmt.Errorf("volcano client is not initialized")
+	}
+	jobTemplate, err := config.VolcanoClient.FlowV1alpha1().JobTemplates(job.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	spec, err := parameters.Render(jobTemplate, values)
+	if err != nil {
+		return false, err
+	}
+	job.Spec = *spec
+	return true, nil
+}
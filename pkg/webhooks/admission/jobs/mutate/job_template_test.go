@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	flowv1alpha1 "volcano.sh/apis/pkg/apis/flow/v1alpha1"
+	fakeclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+	"volcano.sh/volcano/pkg/controllers/jobtemplate/parameters"
+)
+
+func TestResolveJobTemplate(t *testing.T) {
+	jobTemplate := &flowv1alpha1.JobTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "train",
+			Namespace:   "test",
+			Annotations: map[string]string{parameters.ParametersAnnotation: `{"image": null}`},
+		},
+		Spec: v1alpha1.JobSpec{
+			MinAvailable: 1,
+			Tasks: []v1alpha1.TaskSpec{{
+				Name:     "worker",
+				Replicas: 1,
+				Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{
+					Name:  "trainer",
+					Image: "{{.params.image}}",
+				}}}},
+			}},
+		},
+	}
+	client := fakeclient.NewSimpleClientset()
+	if _, err := client.FlowV1alpha1().JobTemplates("test").Create(context.TODO(), jobTemplate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create job template: %v", err)
+	}
+	config.VolcanoClient = client
+	defer func() {
+		config.VolcanoClient = nil
+	}()
+
+	testCases := []struct {
+		name          string
+		annotations   map[string]string
+		tasks         []v1alpha1.TaskSpec
+		expectErr     bool
+		expectedImage string
+	}{
+		{
+			name: "spec instantiated",
+			annotations: map[string]string{
+				parameters.JobTemplateAnnotation:   "train",
+				parameters.JobParametersAnnotation: `{"image": "trainer:v2"}`,
+			},
+			expectedImage: "trainer:v2",
+		},
+		{
+			name:        "no job template",
+			annotations: map[string]string{},
+		},
+		{
+			name:        "required parameter not set",
+			annotations: map[string]string{parameters.JobTemplateAnnotation: "train"},
+			expectErr:   true,
+		},
+		{
+			name: "job template not found",
+			annotations: map[string]string{
+				parameters.JobTemplateAnnotation:   "eval",
+				parameters.JobParametersAnnotation: `{"image": "trainer:v2"}`,
+			},
+			expectErr: true,
+		},
+		{
+			name: "tasks defined",
+			annotations: map[string]string{
+				parameters.JobTemplateAnnotation:   "train",
+				parameters.JobParametersAnnotation: `{"image": "trainer:v2"}`,
+			},
+			tasks:     []v1alpha1.TaskSpec{{Name: "worker", Replicas: 1}},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "test", Annotations: tc.annotations},
+				Spec:       v1alpha1.JobSpec{Tasks: tc.tasks},
+			}
+			resolved, err := resolveJobTemplate(job)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+			if resolved != (tc.expectedImage != "") {
+				t.Fatalf("expected resolved %v, got %v", tc.expectedImage != "", resolved)
+			}
+			if resolved {
+				if image := job.Spec.Tasks[0].Template.Spec.Containers[0].Image; image != tc.expectedImage {
+					t.Errorf("expected image %s, got %s", tc.expectedImage, image)
+				}
+				if job.Spec.MinAvailable != 1 {
+					t.Errorf("expected minAvailable 1, got %d", job.Spec.MinAvailable)
+				}
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	whv1 "k8s.io/api/admissionregistration/v1"
@@ -27,11 +28,15 @@ import (
 	"k8s.io/klog/v2"
 
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/mpi"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/pytorch"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/ray"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/tensorflow"
+	"volcano.sh/volcano/pkg/scheduler/api"
 	commonutil "volcano.sh/volcano/pkg/util"
+	mpiwebhook "volcano.sh/volcano/pkg/webhooks/admission/jobs/plugins/mpi"
 	"volcano.sh/volcano/pkg/webhooks/router"
 	"volcano.sh/volcano/pkg/webhooks/schema"
 	"volcano.sh/volcano/pkg/webhooks/util"
@@ -93,7 +98,10 @@ func Jobs(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 	var patchBytes []byte
 	switch ar.Request.Operation {
 	case admissionv1.Create:
-		patchBytes, _ = createPatch(job)
+		patchBytes, err = createPatch(job)
+		if err != nil {
+			return util.ToAdmissionResponse(err)
+		}
 	default:
 		err = fmt.Errorf("expect operation to be 'CREATE' ")
 		return util.ToAdmissionResponse(err)
@@ -114,6 +122,14 @@ func Jobs(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 
 func createPatch(job *v1alpha1.Job) ([]byte, error) {
 	var patch []patchOperation
+	// the spec instantiated from the JobTemplate must be patched first, the defaults below are patched onto it
+	jobTemplateResolved, err := resolveJobTemplate(job)
+	if err != nil {
+		return nil, err
+	}
+	if jobTemplateResolved {
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec", Value: job.Spec})
+	}
 	pathQueue := patchDefaultQueue(job)
 	if pathQueue != nil {
 		patch = append(patch, *pathQueue)
@@ -126,10 +142,25 @@ func createPatch(job *v1alpha1.Job) ([]byte, error) {
 	if pathMaxRetry != nil {
 		patch = append(patch, *pathMaxRetry)
 	}
+	pathActiveDeadline := patchDefaultActiveDeadlineSeconds(job)
+	if pathActiveDeadline != nil {
+		patch = append(patch, *pathActiveDeadline)
+	}
+	templateResolved, err := resolveTemplateRefs(job)
+	if err != nil {
+		return nil, err
+	}
 	pathSpec := mutateSpec(job.Spec.Tasks, "/spec/tasks", job)
+	if pathSpec == nil && templateResolved {
+		pathSpec = &patchOperation{Op: "replace", Path: "/spec/tasks", Value: job.Spec.Tasks}
+	}
 	if pathSpec != nil {
 		patch = append(patch, *pathSpec)
 	}
+	pathPriorityClassName := patchDefaultPriorityClassName(job)
+	if pathPriorityClassName != nil {
+		patch = append(patch, *pathPriorityClassName)
+	}
 	pathMinAvailable := patchDefaultMinAvailable(job)
 	if pathMinAvailable != nil {
 		patch = append(patch, *pathMinAvailable)
@@ -142,14 +173,81 @@ func createPatch(job *v1alpha1.Job) ([]byte, error) {
 	return json.Marshal(patch)
 }
 
+// DefaultJob returns a copy of the job with the defaults of the webhook applied, e.g. to validate the job offline.
+// The defaults read from the cluster, e.g. the default queue of the namespace, are skipped without the clients.
+func DefaultJob(job *v1alpha1.Job) (*v1alpha1.Job, error) {
+	patchBytes, err := createPatch(job.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+	var patch []patchOperation
+	if err := json.Unmarshal(patchBytes, &patch); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for _, operation := range patch {
+		if err := applyPatchOperation(object, operation); err != nil {
+			return nil, err
+		}
+	}
+	if data, err = json.Marshal(object); err != nil {
+		return nil, err
+	}
+	defaulted := &v1alpha1.Job{}
+	if err := json.Unmarshal(data, defaulted); err != nil {
+		return nil, err
+	}
+	return defaulted, nil
+}
+
+// applyPatchOperation applies the add or replace operation of createPatch, whose paths only refer to the fields
+// of objects, to the decoded job.
+func applyPatchOperation(object map[string]interface{}, operation patchOperation) error {
+	if operation.Op != "add" && operation.Op != "replace" {
+		return fmt.Errorf("unsupported operation %q of path %s", operation.Op, operation.Path)
+	}
+	keys := strings.Split(strings.TrimPrefix(operation.Path, "/"), "/")
+	for _, key := range keys[:len(keys)-1] {
+		key = strings.ReplaceAll(strings.ReplaceAll(key, "~1", "/"), "~0", "~")
+		child, ok := object[key].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			object[key] = child
+		}
+		object = child
+	}
+	last := strings.ReplaceAll(strings.ReplaceAll(keys[len(keys)-1], "~1", "/"), "~0", "~")
+	object[last] = operation.Value
+	return nil
+}
+
 func patchDefaultQueue(job *v1alpha1.Job) *patchOperation {
 	//Add default queue if not specified.
 	if job.Spec.Queue == "" {
-		return &patchOperation{Op: "add", Path: "/spec/queue", Value: DefaultQueue}
+		// the defaulted queue is used by the following mutations
+		job.Spec.Queue = getDefaultQueue(job.Namespace)
+		return &patchOperation{Op: "add", Path: "/spec/queue", Value: job.Spec.Queue}
 	}
 	return nil
 }
 
+// getDefaultQueue returns the default queue of the namespace, DefaultQueue is returned
+// if the namespace has no default queue.
+func getDefaultQueue(namespace string) string {
+	if queue := util.GetDefaultQueueOfNamespace(config.NamespaceLister, namespace, config.ConfigData); queue != "" {
+		return queue
+	}
+	return DefaultQueue
+}
+
 func patchDefaultScheduler(job *v1alpha1.Job) *patchOperation {
 	// Add default scheduler name if not specified.
 	if job.Spec.SchedulerName == "" {
@@ -169,8 +267,20 @@ func patchDefaultMaxRetry(job *v1alpha1.Job) *patchOperation {
 func patchDefaultMinAvailable(job *v1alpha1.Job) *patchOperation {
 	// Add default minAvailable if minAvailable is zero.
 	if job.Spec.MinAvailable == 0 {
+		// The mpi master waiting for workers is created after the workers are ready,
+		// it must not be counted, otherwise the workers are never scheduled by gang.
+		waitingMaster := ""
+		if arguments, ok := job.Spec.Plugins[mpi.MPIPluginName]; ok {
+			if mp := mpi.NewInstance(arguments); mp.WaitWorkers() {
+				waitingMaster = mp.GetMasterName()
+			}
+		}
+
 		var jobMinAvailable int32
 		for _, task := range job.Spec.Tasks {
+			if task.Name == waitingMaster {
+				continue
+			}
 			if task.MinAvailable != nil {
 				jobMinAvailable += *task.MinAvailable
 			} else {
@@ -184,11 +294,9 @@ func patchDefaultMinAvailable(job *v1alpha1.Job) *patchOperation {
 }
 
 func mutateSpec(tasks []v1alpha1.TaskSpec, basePath string, job *v1alpha1.Job) *patchOperation {
-	// TODO: Enable this configuration when dependOn supports coexistence with the gang plugin
-	// if _, ok := job.Spec.Plugins[mpi.MpiPluginName]; ok {
-	// 	mpi.AddDependsOn(job)
-	// }
-	patched := false
+	patched := mpiwebhook.AddDependsOn(job)
+	defaultPriorityClassName := getQueueDefaultPriorityClassName(job)
+	defaultTopologyPolicy := getDefaultTopologyPolicy(job)
 	for index := range tasks {
 		// add default task name
 		taskName := tasks[index].Name
@@ -212,6 +320,24 @@ func mutateSpec(tasks []v1alpha1.TaskSpec, basePath string, job *v1alpha1.Job) *
 			patched = true
 			tasks[index].MaxRetry = defaultMaxRetry
 		}
+
+		if defaultPriorityClassName != "" && tasks[index].Template.Spec.PriorityClassName == "" {
+			patched = true
+			tasks[index].Template.Spec.PriorityClassName = defaultPriorityClassName
+		}
+
+		if defaultTopologyPolicy != "" && tasks[index].TopologyPolicy == "" && requestsIntegerCPUs(&tasks[index].Template.Spec) {
+			patched = true
+			tasks[index].TopologyPolicy = defaultTopologyPolicy
+		}
+
+		if mutateTaskOS(&tasks[index].Template.Spec) {
+			patched = true
+		}
+
+		if mutateTaskArch(&tasks[index].Template) {
+			patched = true
+		}
 	}
 	if !patched {
 		return nil
@@ -223,6 +349,228 @@ func mutateSpec(tasks []v1alpha1.TaskSpec, basePath string, job *v1alpha1.Job) *
 	}
 }
 
+// mutateTaskOS keeps spec.os and the kubernetes.io/os node selector of the task consistent, so that
+// the scheduler places the pods on nodes of the required os, and sets the default runtime class of
+// windows tasks. It returns true if the spec is changed.
+func mutateTaskOS(spec *v1.PodSpec) bool {
+	os := jobhelpers.GetPodOS(spec)
+	if os == "" {
+		return false
+	}
+
+	patched := false
+	if spec.OS == nil && (os == string(v1.Linux) || os == string(v1.Windows)) {
+		patched = true
+		spec.OS = &v1.PodOS{Name: v1.OSName(os)}
+	}
+	if _, found := spec.NodeSelector[v1.LabelOSStable]; !found {
+		patched = true
+		if spec.NodeSelector == nil {
+			spec.NodeSelector = map[string]string{}
+		}
+		spec.NodeSelector[v1.LabelOSStable] = os
+	}
+
+	if os == string(v1.Windows) && spec.RuntimeClassName == nil {
+		if runtimeClassName := getWindowsRuntimeClassName(); runtimeClassName != "" {
+			patched = true
+			spec.RuntimeClassName = &runtimeClassName
+		}
+	}
+	return patched
+}
+
+// mutateTaskArch converts the architectures of the task annotation into a required node affinity,
+// the requirement is added to every node selector term as the terms are ORed.
+func mutateTaskArch(template *v1.PodTemplateSpec) bool {
+	archs := api.GetTaskArchitectures(template.Annotations)
+	if len(archs) == 0 {
+		return false
+	}
+
+	requirement := v1.NodeSelectorRequirement{
+		Key:      v1.LabelArchStable,
+		Operator: v1.NodeSelectorOpIn,
+		Values:   archs,
+	}
+
+	spec := &template.Spec
+	if spec.Affinity == nil {
+		spec.Affinity = &v1.Affinity{}
+	}
+	if spec.Affinity.NodeAffinity == nil {
+		spec.Affinity.NodeAffinity = &v1.NodeAffinity{}
+	}
+	nodeAffinity := spec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &v1.NodeSelector{}
+	}
+	selector := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []v1.NodeSelectorTerm{{
+			MatchExpressions: []v1.NodeSelectorRequirement{requirement},
+		}}
+		return true
+	}
+
+	patched := false
+	for i := range selector.NodeSelectorTerms {
+		term := &selector.NodeSelectorTerms[i]
+		if hasArchRequirement(term) {
+			continue
+		}
+		patched = true
+		term.MatchExpressions = append(term.MatchExpressions, requirement)
+	}
+	return patched
+}
+
+func hasArchRequirement(term *v1.NodeSelectorTerm) bool {
+	for _, expr := range term.MatchExpressions {
+		if expr.Key == v1.LabelArchStable {
+			return true
+		}
+	}
+	return false
+}
+
+func getWindowsRuntimeClassName() string {
+	if config.ConfigData == nil {
+		return ""
+	}
+	config.ConfigData.Lock()
+	defer config.ConfigData.Unlock()
+	return config.ConfigData.WindowsRuntimeClassName
+}
+
+// getJobQueue returns the queue the job is submitted to, nil if it is not found.
+func getJobQueue(job *v1alpha1.Job) *schedulingv1beta1.Queue {
+	if config.QueueLister == nil {
+		return nil
+	}
+	queueName := job.Spec.Queue
+	if queueName == "" {
+		queueName = DefaultQueue
+	}
+	queue, err := config.QueueLister.Get(queueName)
+	if err != nil {
+		klog.V(4).Infof("Failed to get queue %s of job %s/%s: %v", queueName, job.Namespace, job.Name, err)
+		return nil
+	}
+	return queue
+}
+
+// patchDefaultActiveDeadlineSeconds sets the active deadline of the job to the default of its queue,
+// which is enforced from the start time of the job by the job controller.
+func patchDefaultActiveDeadlineSeconds(job *v1alpha1.Job) *patchOperation {
+	if _, found := job.Annotations[api.JobActiveDeadlineSeconds]; found {
+		return nil
+	}
+	defaultSeconds := getQueueDefaultActiveDeadlineSeconds(job)
+	if defaultSeconds == nil {
+		return nil
+	}
+
+	value := strconv.FormatInt(*defaultSeconds, 10)
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{api.JobActiveDeadlineSeconds: value}
+		return &patchOperation{Op: "add", Path: "/metadata/annotations", Value: job.Annotations}
+	}
+	job.Annotations[api.JobActiveDeadlineSeconds] = value
+	return &patchOperation{
+		Op:    "add",
+		Path:  "/metadata/annotations/" + strings.ReplaceAll(api.JobActiveDeadlineSeconds, "/", "~1"),
+		Value: value,
+	}
+}
+
+// getQueueDefaultActiveDeadlineSeconds returns the default active deadline of the queue the job
+// is submitted to, nil if the queue does not set it.
+func getQueueDefaultActiveDeadlineSeconds(job *v1alpha1.Job) *int64 {
+	queue := getJobQueue(job)
+	if queue == nil {
+		return nil
+	}
+	defaultSeconds, _, err := util.GetQueueActiveDeadlineSeconds(queue)
+	if err != nil {
+		klog.Warningf("Ignore invalid activeDeadlineSeconds settings of queue %s: %v", queue.Name, err)
+		return nil
+	}
+	return defaultSeconds
+}
+
+// getQueueDefaultPriorityClassName returns the default priorityClassName of the queue the job is submitted to,
+// empty if the queue does not set it or the job sets its own priorityClassName, which the pods inherit.
+func getQueueDefaultPriorityClassName(job *v1alpha1.Job) string {
+	if job.Spec.PriorityClassName != "" {
+		return ""
+	}
+	queue := getJobQueue(job)
+	if queue == nil {
+		return ""
+	}
+	name, err := util.GetQueueDefaultPriorityClassName(queue)
+	if err != nil {
+		klog.Warningf("Ignore invalid default priorityClassName of queue %s: %v", queue.Name, err)
+		return ""
+	}
+	return name
+}
+
+// getDefaultTopologyPolicy returns the default numa topologyPolicy of the tasks of the job, the default of
+// the queue takes precedence over the one in the admission configuration.
+func getDefaultTopologyPolicy(job *v1alpha1.Job) v1alpha1.NumaPolicy {
+	if queue := getJobQueue(job); queue != nil {
+		policy, err := util.GetQueueDefaultTopologyPolicy(queue)
+		if err != nil {
+			klog.Warningf("Ignore invalid default topologyPolicy of queue %s: %v", queue.Name, err)
+		} else if policy != "" {
+			return policy
+		}
+	}
+
+	if config.ConfigData == nil {
+		return ""
+	}
+	config.ConfigData.Lock()
+	value := config.ConfigData.DefaultTopologyPolicy
+	config.ConfigData.Unlock()
+	if value == "" {
+		return ""
+	}
+	policy, err := util.ParseTopologyPolicy(value)
+	if err != nil {
+		klog.Warningf("Ignore invalid defaultTopologyPolicy of admission configuration: %v", err)
+		return ""
+	}
+	return policy
+}
+
+// requestsIntegerCPUs returns whether all the containers of the task request integer cpus, the requests
+// default to the limits. The default topologyPolicy is only set to such tasks, as the others are rejected
+// with a topologyPolicy.
+func requestsIntegerCPUs(spec *v1.PodSpec) bool {
+	for _, container := range append(append([]v1.Container{}, spec.Containers...), spec.InitContainers...) {
+		cpu, found := container.Resources.Requests[v1.ResourceCPU]
+		if !found && len(container.Resources.Requests) == 0 {
+			cpu = container.Resources.Limits[v1.ResourceCPU]
+		}
+		if cpu.Value() == 0 || cpu.Value()*1000 != cpu.MilliValue() {
+			return false
+		}
+	}
+	return true
+}
+
+func patchDefaultPriorityClassName(job *v1alpha1.Job) *patchOperation {
+	// Add the default priorityClassName of the queue if not specified, after the task templates are mutated.
+	if name := getQueueDefaultPriorityClassName(job); name != "" {
+		job.Spec.PriorityClassName = name
+		return &patchOperation{Op: "add", Path: "/spec/priorityClassName", Value: name}
+	}
+	return nil
+}
+
 func patchDefaultPlugins(job *v1alpha1.Job) *patchOperation {
 	if job.Spec.Plugins == nil {
 		return nil
@@ -20,9 +20,18 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
 
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulinglister "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	webhookconfig "volcano.sh/volcano/pkg/webhooks/config"
+	"volcano.sh/volcano/pkg/webhooks/util"
 )
 
 func TestCreatePatchExecution(t *testing.T) {
@@ -145,3 +154,510 @@ func TestCreatePatchExecution(t *testing.T) {
 	}
 
 }
+
+func TestPatchDefaultActiveDeadlineSeconds(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	_ = indexer.Add(&schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "limited",
+			Annotations: map[string]string{util.DefaultActiveDeadlineSecondsAnnotationKey: "3600"},
+		},
+	})
+	_ = indexer.Add(&schedulingv1beta1.Queue{ObjectMeta: metav1.ObjectMeta{Name: DefaultQueue}})
+	config.QueueLister = schedulinglister.NewQueueLister(indexer)
+	defer func() { config.QueueLister = nil }()
+
+	testCases := []struct {
+		name        string
+		queue       string
+		annotations map[string]string
+		expectPath  string
+		expected    string
+	}{
+		{
+			name:       "inherit default of queue",
+			queue:      "limited",
+			expectPath: "/metadata/annotations",
+			expected:   "3600",
+		},
+		{
+			name:        "inherit default of queue with other annotations",
+			queue:       "limited",
+			annotations: map[string]string{"a": "b"},
+			expectPath:  "/metadata/annotations/volcano.sh~1job-active-deadline-seconds",
+			expected:    "3600",
+		},
+		{
+			name:        "keep deadline set by job",
+			queue:       "limited",
+			annotations: map[string]string{api.JobActiveDeadlineSeconds: "60"},
+			expected:    "60",
+		},
+		{
+			name:  "queue without default",
+			queue: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec:       v1alpha1.JobSpec{Queue: tc.queue},
+			}
+			patch := patchDefaultActiveDeadlineSeconds(job)
+			if patch == nil && tc.expectPath != "" || patch != nil && patch.Path != tc.expectPath {
+				t.Errorf("expected patch path %q, got %v", tc.expectPath, patch)
+			}
+			if actual := job.Annotations[api.JobActiveDeadlineSeconds]; actual != tc.expected {
+				t.Errorf("expected active deadline %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMutateDefaultPriorityClassName(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	_ = indexer.Add(&schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "gold",
+			Annotations: map[string]string{util.DefaultPriorityClassNameAnnotationKey: "gold-priority"},
+		},
+	})
+	_ = indexer.Add(&schedulingv1beta1.Queue{ObjectMeta: metav1.ObjectMeta{Name: DefaultQueue}})
+	config.QueueLister = schedulinglister.NewQueueLister(indexer)
+	defer func() { config.QueueLister = nil }()
+
+	testCases := []struct {
+		name             string
+		queue            string
+		jobPriority      string
+		templatePriority string
+		expectedJob      string
+		expectedTemplate string
+	}{
+		{
+			name:             "inherit default of queue",
+			queue:            "gold",
+			expectedJob:      "gold-priority",
+			expectedTemplate: "gold-priority",
+		},
+		{
+			name:             "keep priority of template",
+			queue:            "gold",
+			templatePriority: "high",
+			expectedJob:      "gold-priority",
+			expectedTemplate: "high",
+		},
+		{
+			name:        "keep priority of job inherited by pods",
+			queue:       "gold",
+			jobPriority: "high",
+			expectedJob: "high",
+		},
+		{
+			name:  "queue without default",
+			queue: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				Spec: v1alpha1.JobSpec{
+					Queue:             tc.queue,
+					PriorityClassName: tc.jobPriority,
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{Spec: v1.PodSpec{PriorityClassName: tc.templatePriority}},
+						},
+					},
+				},
+			}
+			mutateSpec(job.Spec.Tasks, "/spec/tasks", job)
+			patchDefaultPriorityClassName(job)
+			if job.Spec.PriorityClassName != tc.expectedJob {
+				t.Errorf("expected priorityClassName of job %q, got %q", tc.expectedJob, job.Spec.PriorityClassName)
+			}
+			if actual := job.Spec.Tasks[0].Template.Spec.PriorityClassName; actual != tc.expectedTemplate {
+				t.Errorf("expected priorityClassName of template %q, got %q", tc.expectedTemplate, actual)
+			}
+		})
+	}
+}
+
+func TestMutateDefaultTopologyPolicy(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	_ = indexer.Add(&schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "numa",
+			Annotations: map[string]string{util.DefaultTopologyPolicyAnnotationKey: string(v1alpha1.SingleNumaNode)},
+		},
+	})
+	_ = indexer.Add(&schedulingv1beta1.Queue{ObjectMeta: metav1.ObjectMeta{Name: DefaultQueue}})
+	config.QueueLister = schedulinglister.NewQueueLister(indexer)
+	config.ConfigData = &webhookconfig.AdmissionConfiguration{DefaultTopologyPolicy: string(v1alpha1.BestEffort)}
+	defer func() {
+		config.QueueLister = nil
+		config.ConfigData = nil
+	}()
+
+	testCases := []struct {
+		name           string
+		queue          string
+		policy         v1alpha1.NumaPolicy
+		cpu            string
+		expectedPolicy v1alpha1.NumaPolicy
+	}{
+		{
+			name:           "inherit default of queue",
+			queue:          "numa",
+			cpu:            "2",
+			expectedPolicy: v1alpha1.SingleNumaNode,
+		},
+		{
+			name:           "inherit default of cluster",
+			cpu:            "2",
+			expectedPolicy: v1alpha1.BestEffort,
+		},
+		{
+			name:           "keep policy of task",
+			queue:          "numa",
+			policy:         v1alpha1.None,
+			cpu:            "2",
+			expectedPolicy: v1alpha1.None,
+		},
+		{
+			name:  "skip task requesting fractional cpus",
+			queue: "numa",
+			cpu:   "500m",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				Spec: v1alpha1.JobSpec{
+					Queue: tc.queue,
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:           "task",
+							Replicas:       1,
+							TopologyPolicy: tc.policy,
+							Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{
+								Name: "main",
+								Resources: v1.ResourceRequirements{
+									Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse(tc.cpu)},
+								},
+							}}}},
+						},
+					},
+				},
+			}
+			mutateSpec(job.Spec.Tasks, "/spec/tasks", job)
+			if actual := job.Spec.Tasks[0].TopologyPolicy; actual != tc.expectedPolicy {
+				t.Errorf("expected topologyPolicy %q, got %q", tc.expectedPolicy, actual)
+			}
+		})
+	}
+}
+
+func TestMutateMPIWaitWorkers(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		arguments            []string
+		minAvailable         int32
+		expectedDependsOn    bool
+		expectedMinAvailable int32
+	}{
+		{
+			name:                 "master counted if not waiting for workers",
+			arguments:            []string{"--master=mpimaster", "--worker=mpiworker"},
+			expectedMinAvailable: 3,
+		},
+		{
+			name:                 "master waits for workers and is not counted",
+			arguments:            []string{"--master=mpimaster", "--worker=mpiworker", "--wait-workers"},
+			expectedDependsOn:    true,
+			expectedMinAvailable: 2,
+		},
+		{
+			name:              "minAvailable set by job is kept",
+			arguments:         []string{"--master=mpimaster", "--worker=mpiworker", "--wait-workers"},
+			minAvailable:      1,
+			expectedDependsOn: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: tc.minAvailable,
+					Plugins:      map[string][]string{"mpi": tc.arguments},
+					Tasks: []v1alpha1.TaskSpec{
+						{Name: "mpimaster", Replicas: 1},
+						{Name: "mpiworker", Replicas: 2},
+					},
+				},
+			}
+			mutateSpec(job.Spec.Tasks, "/spec/tasks", job)
+			if dependsOn := job.Spec.Tasks[0].DependsOn != nil; dependsOn != tc.expectedDependsOn {
+				t.Errorf("expected master dependsOn %v, got %v", tc.expectedDependsOn, dependsOn)
+			}
+
+			patch := patchDefaultMinAvailable(job)
+			if tc.minAvailable != 0 {
+				if patch != nil {
+					t.Errorf("expected no patch of minAvailable, got %v", patch.Value)
+				}
+				return
+			}
+			if patch == nil || patch.Value != tc.expectedMinAvailable {
+				t.Errorf("expected minAvailable %d, got %v", tc.expectedMinAvailable, patch)
+			}
+		})
+	}
+}
+
+func TestPatchDefaultQueue(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	namespaces := []*v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:   "labeled",
+			Labels: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "label-queue"},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+	}
+	for _, ns := range namespaces {
+		if err := indexer.Add(ns); err != nil {
+			t.Fatalf("Failed to add test namespace: %v", err)
+		}
+	}
+	config.NamespaceLister = corelisters.NewNamespaceLister(indexer)
+	config.ConfigData = &webhookconfig.AdmissionConfiguration{
+		NamespaceQueues: webhookconfig.CompileNamespaceQueues(
+			[]webhookconfig.NamespaceQueueConfig{{Namespace: "team-.*", Queue: "team-queue"}}),
+	}
+	defer func() {
+		config.NamespaceLister = nil
+		config.ConfigData = nil
+	}()
+
+	testCases := []struct {
+		name      string
+		namespace string
+		queue     string
+		expected  string
+	}{
+		{
+			name:      "queue from namespace label",
+			namespace: "labeled",
+			expected:  "label-queue",
+		},
+		{
+			name:      "queue from admission configuration",
+			namespace: "team-a",
+			expected:  "team-queue",
+		},
+		{
+			name:      "fall back to default queue",
+			namespace: "other",
+			expected:  DefaultQueue,
+		},
+		{
+			name:      "queue specified by job",
+			namespace: "labeled",
+			queue:     "job-queue",
+			expected:  "job-queue",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tc.namespace},
+				Spec:       v1alpha1.JobSpec{Queue: tc.queue},
+			}
+			patch := patchDefaultQueue(job)
+			if (patch == nil) != (tc.queue != "") {
+				t.Fatalf("unexpected patch %v", patch)
+			}
+			if job.Spec.Queue != tc.expected {
+				t.Errorf("expected queue %s, got %s", tc.expected, job.Spec.Queue)
+			}
+		})
+	}
+}
+
+func TestMutateTaskOS(t *testing.T) {
+	config.ConfigData = &webhookconfig.AdmissionConfiguration{WindowsRuntimeClassName: "windows-2022"}
+	defer func() { config.ConfigData = nil }()
+
+	testCases := []struct {
+		name                 string
+		spec                 v1.PodSpec
+		expectedPatched      bool
+		expectedOS           v1.OSName
+		expectedNodeSelector string
+		expectedRuntimeClass string
+	}{
+		{
+			name: "no os required",
+			spec: v1.PodSpec{},
+		},
+		{
+			name:                 "node selector from spec.os",
+			spec:                 v1.PodSpec{OS: &v1.PodOS{Name: v1.Linux}},
+			expectedPatched:      true,
+			expectedOS:           v1.Linux,
+			expectedNodeSelector: "linux",
+		},
+		{
+			name:                 "spec.os and runtime class from windows node selector",
+			spec:                 v1.PodSpec{NodeSelector: map[string]string{v1.LabelOSStable: "windows"}},
+			expectedPatched:      true,
+			expectedOS:           v1.Windows,
+			expectedNodeSelector: "windows",
+			expectedRuntimeClass: "windows-2022",
+		},
+		{
+			name: "runtime class set by task is kept",
+			spec: v1.PodSpec{
+				OS:               &v1.PodOS{Name: v1.Windows},
+				NodeSelector:     map[string]string{v1.LabelOSStable: "windows"},
+				RuntimeClassName: ptr.To("windows-2019"),
+			},
+			expectedOS:           v1.Windows,
+			expectedNodeSelector: "windows",
+			expectedRuntimeClass: "windows-2019",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := tc.spec
+			if patched := mutateTaskOS(&spec); patched != tc.expectedPatched {
+				t.Errorf("expected patched %v, got %v", tc.expectedPatched, patched)
+			}
+			var os v1.OSName
+			if spec.OS != nil {
+				os = spec.OS.Name
+			}
+			if os != tc.expectedOS {
+				t.Errorf("expected os %q, got %q", tc.expectedOS, os)
+			}
+			if spec.NodeSelector[v1.LabelOSStable] != tc.expectedNodeSelector {
+				t.Errorf("expected node selector %q, got %q", tc.expectedNodeSelector, spec.NodeSelector[v1.LabelOSStable])
+			}
+			if ptr.Deref(spec.RuntimeClassName, "") != tc.expectedRuntimeClass {
+				t.Errorf("expected runtime class %q, got %q", tc.expectedRuntimeClass, ptr.Deref(spec.RuntimeClassName, ""))
+			}
+		})
+	}
+}
+
+func TestMutateTaskArch(t *testing.T) {
+	archAnnotation := map[string]string{"volcano.sh/task-arch": "arm64, amd64"}
+	zoneTerm := v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+		{Key: v1.LabelTopologyZone, Operator: v1.NodeSelectorOpIn, Values: []string{"zone-a"}},
+	}}
+	archTerm := v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+		{Key: v1.LabelArchStable, Operator: v1.NodeSelectorOpIn, Values: []string{"amd64"}},
+	}}
+	withTerms := func(terms ...v1.NodeSelectorTerm) *v1.Affinity {
+		return &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{NodeSelectorTerms: terms},
+		}}
+	}
+
+	testCases := []struct {
+		name            string
+		annotations     map[string]string
+		affinity        *v1.Affinity
+		expectedPatched bool
+		expectedTerms   int
+	}{
+		{
+			name: "no architecture required",
+		},
+		{
+			name:            "node affinity added",
+			annotations:     archAnnotation,
+			expectedPatched: true,
+			expectedTerms:   1,
+		},
+		{
+			name:            "requirement merged into existing terms",
+			annotations:     archAnnotation,
+			affinity:        withTerms(zoneTerm, zoneTerm),
+			expectedPatched: true,
+			expectedTerms:   2,
+		},
+		{
+			name:          "architecture required by task is kept",
+			annotations:   archAnnotation,
+			affinity:      withTerms(archTerm),
+			expectedTerms: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			template := v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec:       v1.PodSpec{Affinity: tc.affinity.DeepCopy()},
+			}
+			if patched := mutateTaskArch(&template); patched != tc.expectedPatched {
+				t.Errorf("expected patched %v, got %v", tc.expectedPatched, patched)
+			}
+			if tc.expectedTerms == 0 {
+				if template.Spec.Affinity != nil {
+					t.Errorf("expected no affinity, got %v", template.Spec.Affinity)
+				}
+				return
+			}
+
+			terms := template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+			if len(terms) != tc.expectedTerms {
+				t.Fatalf("expected %d terms, got %v", tc.expectedTerms, terms)
+			}
+			for _, term := range terms {
+				if !hasArchRequirement(&term) {
+					t.Errorf("expected architecture requirement in term %v", term)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultJob(t *testing.T) {
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "test"},
+		Spec: v1alpha1.JobSpec{
+			Tasks: []v1alpha1.TaskSpec{{Replicas: 1}, {Replicas: 2}},
+		},
+	}
+
+	defaulted, err := DefaultJob(job)
+	if err != nil {
+		t.Fatalf("Failed to apply the defaults of job: %v", err)
+	}
+	if defaulted.Spec.Queue != DefaultQueue {
+		t.Errorf("expected queue %s, got %s", DefaultQueue, defaulted.Spec.Queue)
+	}
+	if defaulted.Spec.MinAvailable != 3 {
+		t.Errorf("expected minAvailable 3, got %d", defaulted.Spec.MinAvailable)
+	}
+	for i, name := range []string{"default0", "default1"} {
+		if defaulted.Spec.Tasks[i].Name != name {
+			t.Errorf("expected name of task %d %q, got %q", i, name, defaulted.Spec.Tasks[i].Name)
+		}
+	}
+	if job.Spec.Queue != "" || job.Spec.Tasks[0].Name != "" {
+		t.Errorf("expected the job to be unchanged, got %v", job.Spec)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+// resolveTemplateRefs inlines the PodTemplates referred by the task templates, the fields set in the task
+// template override the ones of the PodTemplate. It returns true if any task template is resolved.
+func resolveTemplateRefs(job *v1alpha1.Job) (bool, error) {
+	resolved := false
+	for index := range job.Spec.Tasks {
+		task := &job.Spec.Tasks[index]
+		ref, found := task.Template.Annotations[jobhelpers.TemplateRefAnnotation]
+		if !found {
+			continue
+		}
+		// the template is already resolved, e.g. the job is copied from an existing one
+		if _, found := task.Template.Annotations[jobhelpers.TemplateHashAnnotation]; found {
+			continue
+		}
+
+		template, err := resolveTemplateRef(job.Namespace, ref, &task.Template)
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve template of task %q: %v", task.Name, err)
+		}
+		task.Template = *template
+		resolved = true
+	}
+	return resolved, nil
+}
+
+func resolveTemplateRef(namespace, ref string, template *v1.PodTemplateSpec) (*v1.PodTemplateSpec, error) {
+	name, pinnedHash, err := jobhelpers.ParseTemplateRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	if config.KubeClient == nil {
+		return nil, fmt.Errorf("kube client is not initialized")
+	}
+	podTemplate, err := config.KubeClient.CoreV1().PodTemplates(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := jobhelpers.PodTemplateHash(&podTemplate.Template)
+	if err != nil {
+		return nil, err
+	}
+	if pinnedHash != "" && pinnedHash != hash {
+		return nil, fmt.Errorf("PodTemplate %s/%s is changed, pinned hash %s, current hash %s", namespace, name, pinnedHash, hash)
+	}
+
+	result, err := mergeTemplate(&podTemplate.Template, template)
+	if err != nil {
+		return nil, err
+	}
+	if result.Annotations == nil {
+		result.Annotations = map[string]string{}
+	}
+	result.Annotations[jobhelpers.TemplateHashAnnotation] = hash
+	return result, nil
+}
+
+// mergeTemplate applies the task template to the base template by strategic merge, so that the containers
+// of the same name are merged and the fields not set in the task template are kept.
+func mergeTemplate(base, template *v1.PodTemplateSpec) (*v1.PodTemplateSpec, error) {
+	baseJSON, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	templateJSON, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+	// the fields not set in the task template are marshaled as null, which deletes them in a strategic merge patch
+	var patch map[string]interface{}
+	if err := json.Unmarshal(templateJSON, &patch); err != nil {
+		return nil, err
+	}
+	pruneNulls(patch)
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	mergedJSON, err := strategicpatch.StrategicMergePatch(baseJSON, patchJSON, v1.PodTemplateSpec{})
+	if err != nil {
+		return nil, err
+	}
+	merged := &v1.PodTemplateSpec{}
+	if err := json.Unmarshal(mergedJSON, merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+func pruneNulls(object map[string]interface{}) {
+	for key, value := range object {
+		switch v := value.(type) {
+		case nil:
+			delete(object, key)
+		case map[string]interface{}:
+			pruneNulls(v)
+			if len(v) == 0 {
+				delete(object, key)
+			}
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					pruneNulls(m)
+				}
+			}
+		}
+	}
+}
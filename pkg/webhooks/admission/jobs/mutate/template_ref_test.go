@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+)
+
+func TestResolveTemplateRefs(t *testing.T) {
+	podTemplate := &v1.PodTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "trainer", Namespace: "test"},
+		Template: v1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "trainer"}},
+			Spec: v1.PodSpec{
+				RestartPolicy: v1.RestartPolicyOnFailure,
+				Containers: []v1.Container{{
+					Name:      "trainer",
+					Image:     "trainer:v1",
+					Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}},
+				}},
+			},
+		},
+	}
+	hash, err := jobhelpers.PodTemplateHash(&podTemplate.Template)
+	if err != nil {
+		t.Fatalf("failed to hash template: %v", err)
+	}
+
+	client := fake.NewSimpleClientset()
+	if _, err := client.CoreV1().PodTemplates("test").Create(context.TODO(), podTemplate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create pod template: %v", err)
+	}
+	config.KubeClient = client
+	defer func() {
+		config.KubeClient = nil
+	}()
+
+	testCases := []struct {
+		name          string
+		template      v1.PodTemplateSpec
+		expectErr     bool
+		expectedImage string
+		expectedCPU   string
+	}{
+		{
+			name:          "template inlined",
+			template:      v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{jobhelpers.TemplateRefAnnotation: "trainer"}}},
+			expectedImage: "trainer:v1",
+			expectedCPU:   "1",
+		},
+		{
+			name: "task template overrides the pod template",
+			template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{jobhelpers.TemplateRefAnnotation: "trainer@" + hash}},
+				Spec: v1.PodSpec{Containers: []v1.Container{{
+					Name:      "trainer",
+					Resources: v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")}},
+				}}},
+			},
+			expectedImage: "trainer:v1",
+			expectedCPU:   "4",
+		},
+		{
+			name:      "pinned hash mismatched",
+			template:  v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{jobhelpers.TemplateRefAnnotation: "trainer@sha256:0000"}}},
+			expectErr: true,
+		},
+		{
+			name:      "pod template not found",
+			template:  v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{jobhelpers.TemplateRefAnnotation: "missing"}}},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "test"},
+				Spec:       v1alpha1.JobSpec{Tasks: []v1alpha1.TaskSpec{{Name: "worker", Replicas: 2, Template: tc.template}}},
+			}
+			resolved, err := resolveTemplateRefs(job)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+			if !resolved {
+				t.Fatalf("expected template resolved")
+			}
+
+			template := job.Spec.Tasks[0].Template
+			if template.Annotations[jobhelpers.TemplateHashAnnotation] != hash {
+				t.Errorf("expected template hash %s, got %v", hash, template.Annotations)
+			}
+			if template.Labels["app"] != "trainer" || template.Spec.RestartPolicy != v1.RestartPolicyOnFailure {
+				t.Errorf("expected fields of pod template kept, got %v %v", template.Labels, template.Spec.RestartPolicy)
+			}
+			if len(template.Spec.Containers) != 1 || template.Spec.Containers[0].Image != tc.expectedImage {
+				t.Fatalf("expected container of image %s, got %v", tc.expectedImage, template.Spec.Containers)
+			}
+			if cpu := template.Spec.Containers[0].Resources.Requests[v1.ResourceCPU]; cpu.String() != tc.expectedCPU {
+				t.Errorf("expected cpu request %s, got %s", tc.expectedCPU, cpu.String())
+			}
+		})
+	}
+}
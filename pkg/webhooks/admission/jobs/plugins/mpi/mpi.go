@@ -24,16 +24,38 @@ import (
 	controllerMpi "volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/mpi"
 )
 
-func AddDependsOn(job *v1alpha1.Job) {
-	mp := controllerMpi.NewInstance(job.Spec.Plugins[controllerMpi.MPIPluginName])
+// AddDependsOn makes the master task depend on the worker task if the mpi plugin waits for workers,
+// so the master pod is created once the worker pods are ready. It returns true if the job is changed.
+func AddDependsOn(job *v1alpha1.Job) bool {
+	arguments, found := job.Spec.Plugins[controllerMpi.MPIPluginName]
+	if !found {
+		return false
+	}
+	mp := controllerMpi.NewInstance(arguments)
+	if !mp.WaitWorkers() {
+		return false
+	}
 	masterIndex := helpers.GetTaskIndexUnderJob(mp.GetMasterName(), job)
 	if masterIndex == -1 {
 		klog.Errorln("Failed to find master task")
-		return
+		return false
+	}
+	workerIndex := helpers.GetTaskIndexUnderJob(mp.GetWorkerName(), job)
+	if workerIndex == -1 {
+		klog.Errorln("Failed to find worker task")
+		return false
+	}
+	if job.Spec.Tasks[masterIndex].DependsOn != nil {
+		return false
+	}
+
+	job.Spec.Tasks[masterIndex].DependsOn = &v1alpha1.DependsOn{
+		Name: []string{mp.GetWorkerName()},
 	}
-	if job.Spec.Tasks[masterIndex].DependsOn == nil {
-		job.Spec.Tasks[masterIndex].DependsOn = &v1alpha1.DependsOn{
-			Name: []string{mp.GetWorkerName()},
-		}
+	// The worker task is ready only if minAvailable of its pods are ready.
+	if job.Spec.Tasks[workerIndex].MinAvailable == nil {
+		replicas := job.Spec.Tasks[workerIndex].Replicas
+		job.Spec.Tasks[workerIndex].MinAvailable = &replicas
 	}
+	return true
 }
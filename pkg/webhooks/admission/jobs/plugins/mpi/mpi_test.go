@@ -30,7 +30,7 @@ func TestMpi(t *testing.T) {
 	workerName := "fakeWorker"
 	masterName := "fakeMaster"
 	plugins := make(map[string][]string)
-	plugins[controllerMpi.MPIPluginName] = []string{"--master=" + masterName, "--worker=" + workerName}
+	plugins[controllerMpi.MPIPluginName] = []string{"--master=" + masterName, "--worker=" + workerName, "--wait-workers"}
 	testcases := []struct {
 		Name string
 		Job  *v1alpha1.Job
@@ -60,7 +60,9 @@ func TestMpi(t *testing.T) {
 
 	for index, testcase := range testcases {
 		t.Run(testcase.Name, func(t *testing.T) {
-			AddDependsOn(testcase.Job)
+			if !AddDependsOn(testcase.Job) {
+				t.Errorf("Case %d (%s): expect job to be changed", index, testcase.Name)
+			}
 			if testcase.Job.Spec.Tasks[0].DependsOn == nil || len(testcase.Job.Spec.Tasks[0].DependsOn.Name) == 0 {
 				t.Errorf("Case %d (%s): no dependencies added ", index, testcase.Name)
 				return
@@ -68,6 +70,28 @@ func TestMpi(t *testing.T) {
 			if testcase.Job.Spec.Tasks[0].DependsOn.Name[0] != workerName {
 				t.Errorf("Case %d (%s): Dependency add error expect %s, but got %s", index, testcase.Name, workerName, testcase.Job.Spec.Tasks[0].DependsOn.Name[0])
 			}
+			if minAvailable := testcase.Job.Spec.Tasks[1].MinAvailable; minAvailable == nil || *minAvailable != 2 {
+				t.Errorf("Case %d (%s): expect minAvailable of worker to be defaulted to replicas", index, testcase.Name)
+			}
+			// the dependency is added only once
+			if AddDependsOn(testcase.Job) {
+				t.Errorf("Case %d (%s): expect job not to be changed again", index, testcase.Name)
+			}
 		})
 	}
 }
+
+func TestMpiNotWaitWorkers(t *testing.T) {
+	job := &v1alpha1.Job{
+		Spec: v1alpha1.JobSpec{
+			Plugins: map[string][]string{controllerMpi.MPIPluginName: {}},
+			Tasks: []v1alpha1.TaskSpec{
+				{Name: controllerMpi.DefaultMaster, Replicas: 1},
+				{Name: controllerMpi.DefaultWorker, Replicas: 2},
+			},
+		},
+	}
+	if AddDependsOn(job) || job.Spec.Tasks[0].DependsOn != nil {
+		t.Errorf("expect no dependsOn added if the plugin does not wait for workers")
+	}
+}
@@ -24,8 +24,9 @@ import (
 	whv1 "k8s.io/api/admissionregistration/v1"
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
@@ -39,6 +40,9 @@ import (
 	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/controllers/job/plugins"
 	controllerMpi "volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/mpi"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/pytorchelastic"
+	"volcano.sh/volcano/pkg/controllers/jobtemplate/parameters"
+	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/webhooks/router"
 	"volcano.sh/volcano/pkg/webhooks/schema"
 	"volcano.sh/volcano/pkg/webhooks/util"
@@ -117,27 +121,50 @@ func AdmitJobs(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 }
 
 func validateJobCreate(job *v1alpha1.Job, reviewResponse *admissionv1.AdmissionResponse) string {
+	msg := validateJob(job, func(job *v1alpha1.Job) string {
+		msg, warnings := validateJobQueue(job)
+		reviewResponse.Warnings = append(reviewResponse.Warnings, warnings...)
+		return msg
+	})
+	msg += validateJobPolicies(job)
+	msg += validateJobPodSecurity(job)
+	for index, task := range job.Spec.Tasks {
+		if warning := validateTaskServiceAccount(task, job, index); warning != "" {
+			reviewResponse.Warnings = append(reviewResponse.Warnings, warning)
+		}
+	}
+	if msg != "" {
+		reviewResponse.Allowed = false
+	}
+
+	return msg
+}
+
+// ValidateJob validates the job spec without accessing the cluster, the queue of the job
+// is not checked. It shares the validation logic of the admission webhook so that job
+// manifests can be validated offline, e.g. by vcctl. An empty message means the job is valid.
+func ValidateJob(job *v1alpha1.Job) string {
+	return validateJob(job, nil)
+}
+
+func validateJob(job *v1alpha1.Job, validateQueue func(job *v1alpha1.Job) string) string {
 	var msg string
 	taskNames := map[string]string{}
 	var totalReplicas int32
 
 	if job.Spec.MinAvailable < 0 {
-		reviewResponse.Allowed = false
 		return "job 'minAvailable' must be >= 0."
 	}
 
 	if job.Spec.MaxRetry < 0 {
-		reviewResponse.Allowed = false
 		return "'maxRetry' cannot be less than zero."
 	}
 
 	if job.Spec.TTLSecondsAfterFinished != nil && *job.Spec.TTLSecondsAfterFinished < 0 {
-		reviewResponse.Allowed = false
 		return "'ttlSecondsAfterFinished' cannot be less than zero."
 	}
 
 	if len(job.Spec.Tasks) == 0 {
-		reviewResponse.Allowed = false
 		return "No task specified in job spec"
 	}
 
@@ -146,13 +173,23 @@ func validateJobCreate(job *v1alpha1.Job, reviewResponse *admissionv1.AdmissionR
 		masterIndex := jobhelpers.GetTaskIndexUnderJob(mp.GetMasterName(), job)
 		workerIndex := jobhelpers.GetTaskIndexUnderJob(mp.GetWorkerName(), job)
 		if masterIndex == -1 {
-			reviewResponse.Allowed = false
 			return "The specified mpi master task was not found"
 		}
 		if workerIndex == -1 {
-			reviewResponse.Allowed = false
 			return "The specified mpi worker task was not found"
 		}
+		if !controllerMpi.IsSupportedFlavor(mp.GetFlavor()) {
+			return fmt.Sprintf("The specified mpi flavor %s is not supported", mp.GetFlavor())
+		}
+		if mp.WaitWorkers() && job.Spec.MinAvailable > totalReplicasExcept(job, mp.GetMasterName()) {
+			return "job 'minAvailable' must not count the mpi master task when waiting for workers"
+		}
+	}
+
+	if arguments, ok := job.Spec.Plugins[pytorchelastic.PytorchElasticPluginName]; ok {
+		if err := pytorchelastic.ValidateMaxNodes(arguments, job); err != nil {
+			return err.Error()
+		}
 	}
 
 	hasDependenciesBetweenTasks := false
@@ -189,22 +226,28 @@ func validateJobCreate(job *v1alpha1.Job, reviewResponse *admissionv1.AdmissionR
 			taskNames[task.Name] = task.Name
 		}
 
-		if err := validatePolicies(task.Policies, field.NewPath("spec.tasks.policies")); err != nil {
+		if err := validatePolicies(task.Policies, field.NewPath("spec").Child("tasks").Index(index).Child("policies"), true); err != nil {
 			msg += err.Error() + fmt.Sprintf(" valid events are %v, valid actions are %v;",
 				getValidEvents(), getValidActions())
 		}
 		podName := jobhelpers.MakePodName(job.Name, task.Name, index)
 		msg += validateK8sPodNameLength(podName)
 		msg += validateTaskTemplate(task, job, index)
+		msg += validateTaskCompletionMode(task, index)
 	}
 
 	msg += validateJobName(job)
+	if priorityMsg := validatePriorityClassName("spec.priorityClassName", job.Spec.PriorityClassName); priorityMsg != "" {
+		msg += " " + priorityMsg
+	}
 
 	if totalReplicas < job.Spec.MinAvailable {
 		msg += " job 'minAvailable' should not be greater than total replicas in tasks;"
+	} else if parallelReplicas(job) < job.Spec.MinAvailable {
+		msg += " job 'minAvailable' should not be greater than the replicas running in parallel in tasks;"
 	}
 
-	if err := validatePolicies(job.Spec.Policies, field.NewPath("spec.policies")); err != nil {
+	if err := validatePolicies(job.Spec.Policies, field.NewPath("spec").Child("policies"), false); err != nil {
 		msg = msg + err.Error() + fmt.Sprintf(" valid events are %v, valid actions are %v;",
 			getValidEvents(), getValidActions())
 	}
@@ -218,37 +261,29 @@ func validateJobCreate(job *v1alpha1.Job, reviewResponse *admissionv1.AdmissionR
 		}
 	}
 
+	// the domain names of the pods with stable identities are served by the headless service of the svc plugin
+	if jobhelpers.StablePodIdentity(job) {
+		if _, found := job.Spec.Plugins["svc"]; !found {
+			msg += fmt.Sprintf(" job with annotation %s requires plugin svc;", jobhelpers.StablePodIdentityAnnotation)
+		}
+	}
+
 	if err := validateIO(job.Spec.Volumes); err != nil {
 		msg += err.Error()
 	}
 
-	queue, err := config.QueueLister.Get(job.Spec.Queue)
-	if err != nil {
-		msg += fmt.Sprintf(" unable to find job queue: %v;", err)
-	} else {
-		if queue.Status.State != schedulingv1beta1.QueueStateOpen {
-			msg += fmt.Sprintf(" can only submit job to queue with state `Open`, "+
-				"queue `%s` status is `%s`;", queue.Name, queue.Status.State)
-		}
+	msg += validateJobParameters(job)
 
-		// validate hierarchical queue
-		if queue.Name == "root" {
-			msg += " can not submit job to root queue;"
-		} else {
-			queueList, err := config.QueueLister.List(labels.Everything())
-			if err != nil {
-				msg += fmt.Sprintf("failed to get list queues: %v;", err)
-			}
-			childQueues := make([]*schedulingv1beta1.Queue, 0)
-			for _, childQueue := range queueList {
-				if childQueue.Spec.Parent == queue.Name {
-					childQueues = append(childQueues, childQueue)
-				}
-			}
-			if len(childQueues) > 0 {
-				msg += fmt.Sprintf(" can only submit job to leaf queue, "+"queue `%s` has %d child queues;", queue.Name, len(childQueues))
-			}
-		}
+	if err := jobhelpers.ValidateTaskAffinities(job); err != nil {
+		msg += fmt.Sprintf(" %v;", err)
+	}
+
+	if _, err := jobhelpers.GetCheckpointHook(job.Annotations); err != nil {
+		msg += fmt.Sprintf(" %v;", err)
+	}
+
+	if validateQueue != nil {
+		msg += validateQueue(job)
 	}
 
 	if hasDependenciesBetweenTasks {
@@ -258,13 +293,96 @@ func validateJobCreate(job *v1alpha1.Job, reviewResponse *admissionv1.AdmissionR
 		}
 	}
 
-	if msg != "" {
-		reviewResponse.Allowed = false
+	return msg
+}
+
+// validateJobQueue validates the job against its queue. The violations of the queue state are returned as warnings
+// instead if the admission configuration only warns about them.
+func validateJobQueue(job *v1alpha1.Job) (string, []string) {
+	var msg string
+
+	queue, err := config.QueueLister.Get(job.Spec.Queue)
+	if err != nil {
+		return fmt.Sprintf(" unable to find job queue: %v;", err), nil
 	}
 
+	msg += validateActiveDeadlineSeconds(job, queue)
+	msg += validateGPUModels(job, queue)
+	msg += validateJobPreflight(job, queue)
+
+	violations := util.ValidateQueueState("job", queue, config.QueueLister)
+	if util.QueueStateWarnOnly(config.ConfigData) {
+		return msg, violations
+	}
+	for _, violation := range violations {
+		msg += " " + violation + ";"
+	}
+	return msg, nil
+}
+
+// validateGPUModels checks the tasks requesting gpus select a gpu model granted quota by the queue, if the queue
+// grants the quota per gpu model, the gpus of the tasks are not limited by the quota otherwise.
+func validateGPUModels(job *v1alpha1.Job, queue *schedulingv1beta1.Queue) string {
+	models := sets.New[string]()
+	for _, list := range []v1.ResourceList{queue.Spec.Capability, queue.Spec.Deserved, queue.Spec.Guarantee.Resource} {
+		for name := range list {
+			if model, isModel := api.GetGPUModelOfResource(name); isModel {
+				models.Insert(model)
+			}
+		}
+	}
+	if models.Len() == 0 {
+		return ""
+	}
+
+	var msg string
+	for _, task := range job.Spec.Tasks {
+		if !requestsGPU(&task.Template.Spec) {
+			continue
+		}
+		model := task.Template.Spec.NodeSelector[api.GPUModelLabel]
+		if !models.Has(model) {
+			msg += fmt.Sprintf(" task %s requesting %s must select one of the gpu models %v granted by queue `%s` "+
+				"by the node selector %s;", task.Name, api.GPUResourceName, sets.List(models), queue.Name, api.GPUModelLabel)
+		}
+	}
 	return msg
 }
 
+func requestsGPU(spec *v1.PodSpec) bool {
+	containers := append(append([]v1.Container{}, spec.Containers...), spec.InitContainers...)
+	for _, container := range containers {
+		for _, list := range []v1.ResourceList{container.Resources.Requests, container.Resources.Limits} {
+			if quantity, found := list[api.GPUResourceName]; found && !quantity.IsZero() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateActiveDeadlineSeconds checks the active deadline of the job against the maximum allowed
+// by the queue, the job without active deadline has been defaulted by the mutating webhook.
+func validateActiveDeadlineSeconds(job *v1alpha1.Job, queue *schedulingv1beta1.Queue) string {
+	deadline, err := api.GetJobActiveDeadlineSeconds(job.Annotations)
+	if err != nil {
+		return fmt.Sprintf(" %v;", err)
+	}
+	_, maxSeconds, err := util.GetQueueActiveDeadlineSeconds(queue)
+	if err != nil {
+		return fmt.Sprintf(" invalid activeDeadlineSeconds settings of queue `%s`: %v;", queue.Name, err)
+	}
+	if maxSeconds == nil {
+		return ""
+	}
+
+	if deadline == nil || *deadline > *maxSeconds {
+		return fmt.Sprintf(" annotation %s must be set and <= %d required by queue `%s`;",
+			api.JobActiveDeadlineSeconds, *maxSeconds, queue.Name)
+	}
+	return ""
+}
+
 func validateJobUpdate(old, new *v1alpha1.Job) error {
 	var totalReplicas int32
 	for _, task := range new.Spec.Tasks {
@@ -289,10 +407,27 @@ func validateJobUpdate(old, new *v1alpha1.Job) error {
 	if new.Spec.MinAvailable < 0 {
 		return fmt.Errorf("job 'minAvailable' must be >= 0")
 	}
+	// the replicas are not allowed to be scaled beyond the nodes allowed to join the rendezvous
+	if arguments, ok := new.Spec.Plugins[pytorchelastic.PytorchElasticPluginName]; ok {
+		if err := pytorchelastic.ValidateMaxNodes(arguments, new); err != nil {
+			return err
+		}
+	}
+
+	// the active deadline is limited by the queue at creation only
+	if new.Annotations[api.JobActiveDeadlineSeconds] != old.Annotations[api.JobActiveDeadlineSeconds] {
+		return fmt.Errorf("job updates may not change annotation %s", api.JobActiveDeadlineSeconds)
+	}
 
 	if len(old.Spec.Tasks) != len(new.Spec.Tasks) {
 		return fmt.Errorf("job updates may not add or remove tasks")
 	}
+	if new.Spec.PriorityClassName != old.Spec.PriorityClassName {
+		if msg := validatePriorityClassName("spec.priorityClassName", new.Spec.PriorityClassName); msg != "" {
+			return fmt.Errorf("%s", strings.TrimSuffix(msg, ";"))
+		}
+	}
+
 	// other fields under spec are not allowed to mutate
 	new.Spec.MinAvailable = old.Spec.MinAvailable
 	new.Spec.PriorityClassName = old.Spec.PriorityClassName
@@ -354,6 +489,176 @@ func validateTaskTemplate(task v1alpha1.TaskSpec, job *v1alpha1.Job, index int)
 		return msg
 	}
 
+	msg = validateTaskOS(task, index)
+	if msg != "" {
+		return msg
+	}
+
+	msg = validateTaskArch(task, index)
+	if msg != "" {
+		return msg
+	}
+
+	msg = validatePriorityClassName(fmt.Sprintf("spec.task[%d].template.spec.priorityClassName", index),
+		task.Template.Spec.PriorityClassName)
+	if msg != "" {
+		return msg
+	}
+
+	msg = validateTaskGPUSharing(task, index)
+	if msg != "" {
+		return msg
+	}
+
+	msg = validateTaskVolumeClaimTemplates(task, index)
+	if msg != "" {
+		return msg
+	}
+
+	return validateTaskRetryStrategy(task, index)
+}
+
+// validateTaskVolumeClaimTemplates checks the volume claim templates of the task, from which the job controller
+// creates the PVCs of every replica.
+func validateTaskVolumeClaimTemplates(task v1alpha1.TaskSpec, index int) string {
+	if _, err := jobhelpers.GetVolumeClaimTemplates(&task.Template); err != nil {
+		return fmt.Sprintf("spec.task[%d].template.metadata.annotations has %v;", index, err)
+	}
+	return ""
+}
+
+// validateTaskGPUSharing checks the gpu sharing resources and annotations of the task, so that a misconfigured job
+// fails at admission instead of creating pods which can never be allocated the gpus.
+func validateTaskGPUSharing(task v1alpha1.TaskSpec, index int) string {
+	if err := util.ValidateGPUSharing(task.Template.Annotations, &task.Template.Spec); err != nil {
+		return fmt.Sprintf("spec.task[%d].template has invalid gpu sharing configuration: %v;", index, err)
+	}
+	return ""
+}
+
+// validateTaskRetryStrategy checks the retry patch and resource scale of the task can be applied to its template.
+func validateTaskRetryStrategy(task v1alpha1.TaskSpec, index int) string {
+	if _, err := jobhelpers.ApplyRetryStrategy(&task.Template, 1); err != nil {
+		return fmt.Sprintf("spec.task[%d].template.metadata.annotations has invalid retry strategy: %v;", index, err)
+	}
+	return ""
+}
+
+// validateTaskCompletionMode checks the completion mode and the parallelism of the task. The pods of an indexed
+// task beyond its parallelism are not created until the running ones finish, so its minAvailable must not be
+// greater than its parallelism, otherwise the gang of the task could never be scheduled.
+func validateTaskCompletionMode(task v1alpha1.TaskSpec, index int) string {
+	mode, found := task.Template.Annotations[jobhelpers.CompletionModeAnnotation]
+	if !found {
+		return ""
+	}
+	if mode != jobhelpers.IndexedCompletionMode {
+		return fmt.Sprintf("spec.task[%d].template.metadata.annotations has invalid completion mode %q, only %s is supported;",
+			index, mode, jobhelpers.IndexedCompletionMode)
+	}
+	parallelism, err := jobhelpers.GetTaskParallelism(&task)
+	if err != nil {
+		return fmt.Sprintf("spec.task[%d].template.metadata.annotations has %v;", index, err)
+	}
+	if task.MinAvailable != nil && *task.MinAvailable > int32(parallelism) {
+		return fmt.Sprintf("spec.task[%d].minAvailable %d should not be greater than the parallelism %d;",
+			index, *task.MinAvailable, parallelism)
+	}
+	return ""
+}
+
+// parallelReplicas returns the max number of pods of the job running at the same time, which counts the
+// parallelism instead of the replicas of the indexed tasks.
+func parallelReplicas(job *v1alpha1.Job) int32 {
+	var replicas int32
+	for i := range job.Spec.Tasks {
+		task := &job.Spec.Tasks[i]
+		if !jobhelpers.IsIndexedTask(task) {
+			replicas += task.Replicas
+			continue
+		}
+		if parallelism, err := jobhelpers.GetTaskParallelism(task); err == nil {
+			replicas += int32(parallelism)
+		}
+	}
+	return replicas
+}
+
+// validateTaskServiceAccount returns a warning if the service account of the task is not found in the namespace of
+// the job, the pods of the task are rejected on creation until it is created. The job is not denied since the service
+// account is often applied along with the job. Each task can run with its own service account and
+// automountServiceAccountToken, e.g. only the driver task is given the access to the API server.
+func validateTaskServiceAccount(task v1alpha1.TaskSpec, job *v1alpha1.Job, index int) string {
+	name := task.Template.Spec.ServiceAccountName
+	if name == "" || config.ServiceAccountLister == nil {
+		return ""
+	}
+	if _, err := config.ServiceAccountLister.ServiceAccounts(job.Namespace).Get(name); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("spec.task[%d].template.spec.serviceAccountName %s is not found in namespace %s, "+
+				"the pods of the task are rejected until it is created", index, name, job.Namespace)
+		}
+		klog.Warningf("Skip checking service account %s of task %s in job <%s/%s>: %v", name, task.Name, job.Namespace, job.Name, err)
+	}
+	return ""
+}
+
+// validatePriorityClassName checks the priority class exists. The priorityClassName of the job is set to its
+// podgroup and to the pods of the tasks not specifying one, so the job would stay pending with a podgroup of
+// the default priority if it is not found, while the creation of its pods is rejected.
+func validatePriorityClassName(fieldPath, name string) string {
+	if name == "" || config.PriorityClassLister == nil {
+		return ""
+	}
+	if _, err := config.PriorityClassLister.Get(name); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("%s %s is not found;", fieldPath, name)
+		}
+		klog.Warningf("Skip checking priority class %s of %s: %v", name, fieldPath, err)
+	}
+	return ""
+}
+
+// validateTaskOS checks that spec.os of the task does not conflict with the kubernetes.io/os node selector,
+// the pods could never be scheduled otherwise.
+func validateTaskOS(task v1alpha1.TaskSpec, index int) string {
+	spec := task.Template.Spec
+	if spec.OS == nil {
+		return ""
+	}
+	if nodeOS, found := spec.NodeSelector[v1.LabelOSStable]; found && nodeOS != string(spec.OS.Name) {
+		return fmt.Sprintf("spec.task[%d].template.spec.os.name %s conflicts with node selector %s=%s;",
+			index, spec.OS.Name, v1.LabelOSStable, nodeOS)
+	}
+	return ""
+}
+
+// supportedArchitectures are the values of the kubernetes.io/arch node label, i.e. GOARCH of the kubelet.
+var supportedArchitectures = sets.New[string]("amd64", "arm64", "arm", "386", "ppc64le", "s390x", "riscv64", "loong64")
+
+// validateTaskArch checks the architectures of the task annotation are known and do not
+// conflict with the kubernetes.io/arch node selector.
+func validateTaskArch(task v1alpha1.TaskSpec, index int) string {
+	value, found := task.Template.Annotations[api.TaskArchitectures]
+	if !found {
+		return ""
+	}
+
+	archs := api.GetTaskArchitectures(task.Template.Annotations)
+	if len(archs) == 0 {
+		return fmt.Sprintf("spec.task[%d].template.metadata.annotations[%s] %q must list at least one architecture;",
+			index, api.TaskArchitectures, value)
+	}
+	for _, arch := range archs {
+		if !supportedArchitectures.Has(arch) {
+			return fmt.Sprintf("spec.task[%d].template.metadata.annotations[%s] has unsupported architecture %s, valid values are %v;",
+				index, api.TaskArchitectures, arch, sets.List(supportedArchitectures))
+		}
+	}
+	if nodeArch, found := task.Template.Spec.NodeSelector[v1.LabelArchStable]; found && !sets.New(archs...).Has(nodeArch) {
+		return fmt.Sprintf("spec.task[%d].template.metadata.annotations[%s] %s conflicts with node selector %s=%s;",
+			index, api.TaskArchitectures, value, v1.LabelArchStable, nodeArch)
+	}
 	return ""
 }
 
@@ -409,3 +714,16 @@ func guaranteedCPUs(container v1.Container) int {
 
 	return int(cpuQuantity.Value())
 }
+
+// validateJobParameters rejects the job referring to the parameters of a JobTemplate which are not substituted,
+// e.g. the job is copied from the JobTemplate without instantiating it.
+func validateJobParameters(job *v1alpha1.Job) string {
+	unresolved, err := parameters.Unresolved(&job.Spec)
+	if err != nil {
+		return fmt.Sprintf(" failed to check template parameters: %v;", err)
+	}
+	if len(unresolved) > 0 {
+		return fmt.Sprintf(" job spec has unresolved template parameters %v;", unresolved)
+	}
+	return ""
+}
@@ -18,20 +18,29 @@ package validate
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 
 	admissionv1 "k8s.io/api/admission/v1"
 
 	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	schedulinglisters "k8s.io/client-go/listers/scheduling/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
 
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
 	schedulingv1beta2 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	fakeclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
 	informers "volcano.sh/apis/pkg/client/informers/externalversions"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/webhooks/util"
 )
 
 func TestValidateJobCreate(t *testing.T) {
@@ -532,7 +541,7 @@ func TestValidateJobCreate(t *testing.T) {
 					},
 					Policies: []v1alpha1.LifecyclePolicy{
 						{
-							Event:    busv1alpha1.PodFailedEvent,
+							Event:    busv1alpha1.PodEvictedEvent,
 							Action:   busv1alpha1.AbortJobAction,
 							ExitCode: &policyExitCode,
 						},
@@ -540,9 +549,56 @@ func TestValidateJobCreate(t *testing.T) {
 				},
 			},
 			reviewResponse: admissionv1.AdmissionResponse{Allowed: true},
-			ret:            "must not specify event and exitCode simultaneously",
+			ret:            "exitCode can only be specified together with event PodFailed",
 			ExpectErr:      true,
 		},
+		// Policy PodFailed event with exit code
+		{
+			Name: "job-policy-podFailed-withExitCode",
+			Job: v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job-policy-podFailed-withExitCode",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 1,
+					Queue:        "default",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "task-1",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"name": "test"},
+								},
+								Spec: v1.PodSpec{
+									Containers: []v1.Container{
+										{
+											Name:  "fake-name",
+											Image: "busybox:1.24",
+										},
+									},
+								},
+							},
+						},
+					},
+					Policies: []v1alpha1.LifecyclePolicy{
+						{
+							Event:  busv1alpha1.PodFailedEvent,
+							Action: busv1alpha1.RestartJobAction,
+						},
+						{
+							Event:    busv1alpha1.PodFailedEvent,
+							Action:   busv1alpha1.RestartPodAction,
+							ExitCode: &policyExitCode,
+						},
+					},
+				},
+			},
+			reviewResponse: admissionv1.AdmissionResponse{Allowed: true},
+			ret:            "",
+			ExpectErr:      false,
+		},
 		// Both policy event and exit code are nil
 		{
 			Name: "policy-noEvent-noExCode",
@@ -1688,3 +1744,484 @@ func TestValidateTaskTopoPolicy(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateActiveDeadlineSeconds(t *testing.T) {
+	var short, long int64 = 60, 7200
+	queue := &schedulingv1beta2.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "limited",
+			Annotations: map[string]string{util.MaxActiveDeadlineSecondsAnnotationKey: "3600"},
+		},
+	}
+	buildJob := func(deadline *int64) *v1alpha1.Job {
+		job := &v1alpha1.Job{}
+		if deadline != nil {
+			job.Annotations = map[string]string{api.JobActiveDeadlineSeconds: strconv.FormatInt(*deadline, 10)}
+		}
+		return job
+	}
+
+	testCases := []struct {
+		name   string
+		job    *v1alpha1.Job
+		expect string
+	}{
+		{
+			name:   "deadline within the maximum",
+			job:    buildJob(&short),
+			expect: "",
+		},
+		{
+			name:   "deadline exceeds the maximum",
+			job:    buildJob(&long),
+			expect: "annotation volcano.sh/job-active-deadline-seconds must be set and <= 3600",
+		},
+		{
+			name:   "deadline not set",
+			job:    buildJob(nil),
+			expect: "annotation volcano.sh/job-active-deadline-seconds must be set and <= 3600",
+		},
+		{
+			name: "invalid deadline",
+			job: &v1alpha1.Job{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{api.JobActiveDeadlineSeconds: "-1"},
+			}},
+			expect: "volcano.sh/job-active-deadline-seconds must be larger than 0",
+		},
+	}
+
+	for _, testcase := range testCases {
+		msg := validateActiveDeadlineSeconds(testcase.job, queue)
+		if testcase.expect == "" && msg != "" || !strings.Contains(msg, testcase.expect) {
+			t.Errorf("%s failed: %s", testcase.name, msg)
+		}
+	}
+}
+
+func TestValidateGPUModels(t *testing.T) {
+	queue := &schedulingv1beta2.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+		Spec: schedulingv1beta2.QueueSpec{Capability: v1.ResourceList{
+			api.GPUModelResourceName("a100"): resource.MustParse("8"),
+		}},
+	}
+	buildJob := func(gpus string, model string) *v1alpha1.Job {
+		spec := v1.PodSpec{Containers: []v1.Container{{Name: "main", Resources: v1.ResourceRequirements{
+			Limits: v1.ResourceList{api.GPUResourceName: resource.MustParse(gpus)},
+		}}}}
+		if model != "" {
+			spec.NodeSelector = map[string]string{api.GPUModelLabel: model}
+		}
+		return &v1alpha1.Job{Spec: v1alpha1.JobSpec{Tasks: []v1alpha1.TaskSpec{
+			{Name: "task", Template: v1.PodTemplateSpec{Spec: spec}},
+		}}}
+	}
+
+	testCases := []struct {
+		name   string
+		job    *v1alpha1.Job
+		queue  *schedulingv1beta2.Queue
+		expect string
+	}{
+		{
+			name:  "model granted by queue",
+			job:   buildJob("1", "a100"),
+			queue: queue,
+		},
+		{
+			name:   "no model selected",
+			job:    buildJob("1", ""),
+			queue:  queue,
+			expect: "task task requesting nvidia.com/gpu must select one of the gpu models [a100]",
+		},
+		{
+			name:   "model not granted by queue",
+			job:    buildJob("1", "h100"),
+			queue:  queue,
+			expect: "task task requesting nvidia.com/gpu must select one of the gpu models [a100]",
+		},
+		{
+			name:  "no gpu requested",
+			job:   buildJob("0", ""),
+			queue: queue,
+		},
+		{
+			name:  "queue without gpu model quota",
+			job:   buildJob("1", ""),
+			queue: &schedulingv1beta2.Queue{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		},
+	}
+
+	for _, testcase := range testCases {
+		msg := validateGPUModels(testcase.job, testcase.queue)
+		if testcase.expect == "" && msg != "" || !strings.Contains(msg, testcase.expect) {
+			t.Errorf("%s failed: %s", testcase.name, msg)
+		}
+	}
+}
+
+func TestValidateTaskOS(t *testing.T) {
+	testCases := []struct {
+		name   string
+		spec   v1.PodSpec
+		expect string
+	}{
+		{
+			name: "os matches node selector",
+			spec: v1.PodSpec{OS: &v1.PodOS{Name: v1.Windows}, NodeSelector: map[string]string{v1.LabelOSStable: "windows"}},
+		},
+		{
+			name:   "os conflicts with node selector",
+			spec:   v1.PodSpec{OS: &v1.PodOS{Name: v1.Windows}, NodeSelector: map[string]string{v1.LabelOSStable: "linux"}},
+			expect: "spec.task[0].template.spec.os.name windows conflicts with node selector kubernetes.io/os=linux;",
+		},
+	}
+
+	for _, testcase := range testCases {
+		task := v1alpha1.TaskSpec{Name: "task", Template: v1.PodTemplateSpec{Spec: testcase.spec}}
+		if msg := validateTaskOS(task, 0); msg != testcase.expect {
+			t.Errorf("%s failed: expected %q, got %q", testcase.name, testcase.expect, msg)
+		}
+	}
+}
+
+func TestValidateTaskArch(t *testing.T) {
+	testCases := []struct {
+		name         string
+		annotations  map[string]string
+		nodeSelector map[string]string
+		expect       string
+	}{
+		{
+			name: "no architecture required",
+		},
+		{
+			name:         "architectures match node selector",
+			annotations:  map[string]string{"volcano.sh/task-arch": "arm64,amd64"},
+			nodeSelector: map[string]string{v1.LabelArchStable: "amd64"},
+		},
+		{
+			name:        "empty architectures",
+			annotations: map[string]string{"volcano.sh/task-arch": " , "},
+			expect:      "must list at least one architecture;",
+		},
+		{
+			name:        "unsupported architecture",
+			annotations: map[string]string{"volcano.sh/task-arch": "arm64,x86_64"},
+			expect:      "has unsupported architecture x86_64",
+		},
+		{
+			name:         "architectures conflict with node selector",
+			annotations:  map[string]string{"volcano.sh/task-arch": "arm64"},
+			nodeSelector: map[string]string{v1.LabelArchStable: "amd64"},
+			expect:       "arm64 conflicts with node selector kubernetes.io/arch=amd64;",
+		},
+	}
+
+	for _, testcase := range testCases {
+		task := v1alpha1.TaskSpec{Name: "task", Template: v1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Annotations: testcase.annotations},
+			Spec:       v1.PodSpec{NodeSelector: testcase.nodeSelector},
+		}}
+		msg := validateTaskArch(task, 0)
+		if testcase.expect == "" && msg != "" || !strings.Contains(msg, testcase.expect) {
+			t.Errorf("%s failed: expected %q, got %q", testcase.name, testcase.expect, msg)
+		}
+	}
+}
+
+func TestValidateTaskRetryStrategy(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expect      string
+	}{
+		{
+			name: "no retry strategy",
+		},
+		{
+			name: "valid retry strategy",
+			annotations: map[string]string{
+				jobhelpers.RetryPatchAnnotation:         `{"spec":{"nodeSelector":{"pool":"highmem"}}}`,
+				jobhelpers.RetryResourceScaleAnnotation: "memory=1.2",
+			},
+		},
+		{
+			name:        "invalid retry patch",
+			annotations: map[string]string{jobhelpers.RetryPatchAnnotation: `{"spec":`},
+			expect:      "spec.task[0].template.metadata.annotations has invalid retry strategy: invalid retry patch",
+		},
+		{
+			name:        "invalid resource scale",
+			annotations: map[string]string{jobhelpers.RetryResourceScaleAnnotation: "memory=0"},
+			expect:      "invalid factor of resource memory",
+		},
+	}
+
+	for _, testcase := range testCases {
+		task := v1alpha1.TaskSpec{Name: "task", Template: v1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Annotations: testcase.annotations},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "c"}}},
+		}}
+		msg := validateTaskRetryStrategy(task, 0)
+		if testcase.expect == "" && msg != "" || !strings.Contains(msg, testcase.expect) {
+			t.Errorf("%s failed: expected %q, got %q", testcase.name, testcase.expect, msg)
+		}
+	}
+}
+
+func TestValidateTaskServiceAccount(t *testing.T) {
+	oldServiceAccountLister := config.ServiceAccountLister
+	defer func() {
+		config.ServiceAccountLister = oldServiceAccountLister
+	}()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(&v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "driver", Namespace: "test"}}); err != nil {
+		t.Fatalf("failed to add service account: %v", err)
+	}
+	config.ServiceAccountLister = corelisters.NewServiceAccountLister(indexer)
+	job := &v1alpha1.Job{ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "test"}}
+
+	testCases := []struct {
+		name           string
+		serviceAccount string
+		expect         string
+	}{
+		{
+			name: "default service account",
+		},
+		{
+			name:           "existing service account",
+			serviceAccount: "driver",
+		},
+		{
+			name:           "service account not found",
+			serviceAccount: "worker",
+			expect:         "spec.task[0].template.spec.serviceAccountName worker is not found in namespace test, the pods of the task are rejected until it is created",
+		},
+	}
+
+	for _, testcase := range testCases {
+		task := v1alpha1.TaskSpec{Name: "task", Template: v1.PodTemplateSpec{
+			Spec: v1.PodSpec{ServiceAccountName: testcase.serviceAccount},
+		}}
+		if warning := validateTaskServiceAccount(task, job, 0); warning != testcase.expect {
+			t.Errorf("%s failed: expected warning %q, got %q", testcase.name, testcase.expect, warning)
+		}
+	}
+}
+
+func TestValidatePriorityClassName(t *testing.T) {
+	oldPriorityClassLister := config.PriorityClassLister
+	defer func() {
+		config.PriorityClassLister = oldPriorityClassLister
+	}()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "high"}, Value: 1000}); err != nil {
+		t.Fatalf("failed to add priority class: %v", err)
+	}
+	config.PriorityClassLister = schedulinglisters.NewPriorityClassLister(indexer)
+
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "test"},
+		Spec: v1alpha1.JobSpec{
+			PriorityClassName: "high",
+			Tasks: []v1alpha1.TaskSpec{{
+				Name:     "task",
+				Replicas: 1,
+				Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+					PriorityClassName: "low",
+					Containers:        []v1.Container{{Name: "c", Image: "busybox"}},
+				}},
+			}},
+		},
+	}
+	if msg := validateTaskTemplate(job.Spec.Tasks[0], job, 0); msg != "spec.task[0].template.spec.priorityClassName low is not found;" {
+		t.Errorf("expected the priority class of the task not found, got %q", msg)
+	}
+
+	job.Spec.Tasks[0].Template.Spec.PriorityClassName = ""
+	if msg := validatePriorityClassName("spec.priorityClassName", job.Spec.PriorityClassName); msg != "" {
+		t.Errorf("expected the priority class of the job found, got %q", msg)
+	}
+
+	updated := job.DeepCopy()
+	updated.Spec.PriorityClassName = "low"
+	if err := validateJobUpdate(job, updated); err == nil || err.Error() != "spec.priorityClassName low is not found" {
+		t.Errorf("expected the update to a missing priority class rejected, got %v", err)
+	}
+	updated.Spec.PriorityClassName = ""
+	if err := validateJobUpdate(job, updated); err != nil {
+		t.Errorf("expected the priority class of the job removed, got %v", err)
+	}
+}
+
+func TestValidateTaskGPUSharing(t *testing.T) {
+	testCases := []struct {
+		name   string
+		limits v1.ResourceList
+		expect string
+	}{
+		{
+			name:   "shared gpu memory",
+			limits: v1.ResourceList{"volcano.sh/gpu-memory": resource.MustParse("1024")},
+		},
+		{
+			name: "vgpu cores without vgpu number",
+			limits: v1.ResourceList{
+				"volcano.sh/vgpu-cores": resource.MustParse("50"),
+			},
+			expect: "spec.task[0].template has invalid gpu sharing configuration: container c1: " +
+				"volcano.sh/vgpu-cores and volcano.sh/vgpu-memory-percentage take no effect without volcano.sh/vgpu-number or volcano.sh/vgpu-memory;",
+		},
+	}
+
+	for _, testcase := range testCases {
+		task := v1alpha1.TaskSpec{Name: "task", Template: v1.PodTemplateSpec{
+			Spec: v1.PodSpec{Containers: []v1.Container{{
+				Name:      "c1",
+				Resources: v1.ResourceRequirements{Limits: testcase.limits},
+			}}},
+		}}
+		if msg := validateTaskGPUSharing(task, 0); msg != testcase.expect {
+			t.Errorf("%s failed: expected %q, got %q", testcase.name, testcase.expect, msg)
+		}
+	}
+}
+
+func TestValidateJobParameters(t *testing.T) {
+	testCases := []struct {
+		name   string
+		image  string
+		expect string
+	}{
+		{
+			name:  "parameters substituted",
+			image: "trainer:v2",
+		},
+		{
+			name:   "unresolved parameter",
+			image:  "trainer:{{.params.tag}}",
+			expect: " job spec has unresolved template parameters [tag];",
+		},
+	}
+
+	for _, testcase := range testCases {
+		job := &v1alpha1.Job{Spec: v1alpha1.JobSpec{Tasks: []v1alpha1.TaskSpec{{
+			Name: "task",
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{
+				Name:  "c1",
+				Image: testcase.image,
+			}}}},
+		}}}}
+		if msg := validateJobParameters(job); msg != testcase.expect {
+			t.Errorf("%s failed: expected %q, got %q", testcase.name, testcase.expect, msg)
+		}
+	}
+}
+
+func TestValidateTaskCompletionMode(t *testing.T) {
+	testCases := []struct {
+		name         string
+		annotations  map[string]string
+		minAvailable *int32
+		expect       string
+	}{
+		{
+			name: "default completion mode",
+		},
+		{
+			name:         "indexed task within parallelism",
+			annotations:  map[string]string{jobhelpers.CompletionModeAnnotation: "Indexed", jobhelpers.ParallelismAnnotation: "4"},
+			minAvailable: ptr.To[int32](4),
+		},
+		{
+			name:        "unsupported completion mode",
+			annotations: map[string]string{jobhelpers.CompletionModeAnnotation: "NonIndexed"},
+			expect:      "spec.task[0].template.metadata.annotations has invalid completion mode \"NonIndexed\", only Indexed is supported;",
+		},
+		{
+			name:        "invalid parallelism",
+			annotations: map[string]string{jobhelpers.CompletionModeAnnotation: "Indexed", jobhelpers.ParallelismAnnotation: "0"},
+			expect:      "spec.task[0].template.metadata.annotations has invalid parallelism \"0\", expected a positive integer;",
+		},
+		{
+			name:         "minAvailable greater than parallelism",
+			annotations:  map[string]string{jobhelpers.CompletionModeAnnotation: "Indexed", jobhelpers.ParallelismAnnotation: "2"},
+			minAvailable: ptr.To[int32](4),
+			expect:       "spec.task[0].minAvailable 4 should not be greater than the parallelism 2;",
+		},
+	}
+
+	for _, testcase := range testCases {
+		task := v1alpha1.TaskSpec{Name: "trial", Replicas: 100, MinAvailable: testcase.minAvailable, Template: v1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Annotations: testcase.annotations},
+		}}
+		if msg := validateTaskCompletionMode(task, 0); msg != testcase.expect {
+			t.Errorf("%s failed: expected %q, got %q", testcase.name, testcase.expect, msg)
+		}
+	}
+}
+
+func TestParallelReplicas(t *testing.T) {
+	job := &v1alpha1.Job{Spec: v1alpha1.JobSpec{Tasks: []v1alpha1.TaskSpec{
+		{Name: "tuner", Replicas: 1},
+		{Name: "trial", Replicas: 100, Template: v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			jobhelpers.CompletionModeAnnotation: jobhelpers.IndexedCompletionMode,
+			jobhelpers.ParallelismAnnotation:    "8",
+		}}}},
+	}}}
+	if replicas := parallelReplicas(job); replicas != 9 {
+		t.Errorf("expected 9 replicas running in parallel, got %d", replicas)
+	}
+}
+
+func TestValidateMPIPlugin(t *testing.T) {
+	testCases := []struct {
+		name         string
+		arguments    []string
+		minAvailable int32
+		expect       string
+	}{
+		{
+			name:         "default flavor",
+			arguments:    []string{"--master=mpimaster", "--worker=mpiworker"},
+			minAvailable: 3,
+		},
+		{
+			name:         "unsupported flavor",
+			arguments:    []string{"--master=mpimaster", "--worker=mpiworker", "--flavor=mvapich"},
+			minAvailable: 3,
+			expect:       "The specified mpi flavor mvapich is not supported",
+		},
+		{
+			name:         "master not counted when waiting for workers",
+			arguments:    []string{"--master=mpimaster", "--worker=mpiworker", "--flavor=intelmpi", "--wait-workers"},
+			minAvailable: 2,
+		},
+		{
+			name:         "master counted when waiting for workers",
+			arguments:    []string{"--master=mpimaster", "--worker=mpiworker", "--wait-workers"},
+			minAvailable: 3,
+			expect:       "job 'minAvailable' must not count the mpi master task when waiting for workers",
+		},
+	}
+
+	for _, testcase := range testCases {
+		job := &v1alpha1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "ns1"},
+			Spec: v1alpha1.JobSpec{
+				MinAvailable: testcase.minAvailable,
+				Plugins:      map[string][]string{"mpi": testcase.arguments},
+				Tasks: []v1alpha1.TaskSpec{
+					{Name: "mpimaster", Replicas: 1},
+					{Name: "mpiworker", Replicas: 2},
+				},
+			},
+		}
+		msg := validateJob(job, func(*v1alpha1.Job) string { return "" })
+		if testcase.expect == "" && strings.Contains(msg, "mpi") || !strings.Contains(msg, testcase.expect) {
+			t.Errorf("%s failed: %s", testcase.name, msg)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// JobPolicyResource is the resource of the JobPolicy CRD, see config/crd/volcano/bases/batch.volcano.sh_jobpolicies.yaml.
+var JobPolicyResource = schema.GroupVersionResource{
+	Group:    "batch.volcano.sh",
+	Version:  "v1alpha1",
+	Resource: "jobpolicies",
+}
+
+// JobPolicy caps the jobs submitted to its namespace, e.g.
+//
+//	apiVersion: batch.volcano.sh/v1alpha1
+//	kind: JobPolicy
+//	metadata:
+//	  name: quota
+//	  namespace: team-a
+//	spec:
+//	  userLabel: volcano.sh/user
+//	  maxConcurrentJobs: 10
+//	  maxTotalReplicas: 64
+//	  maxGPU: "16"
+type JobPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec JobPolicySpec `json:"spec"`
+}
+
+// JobPolicySpec is the specification of a JobPolicy, the unset limits are not enforced.
+type JobPolicySpec struct {
+	// UserLabel is the label of jobs identifying their user. If set, the limits apply to the jobs
+	// of each user separately, and the jobs without the label are limited together as one user.
+	UserLabel string `json:"userLabel,omitempty"`
+	// MaxConcurrentJobs is the maximal number of unfinished jobs.
+	MaxConcurrentJobs *int32 `json:"maxConcurrentJobs,omitempty"`
+	// MaxTotalReplicas is the maximal sum of the replicas of the unfinished jobs.
+	MaxTotalReplicas *int32 `json:"maxTotalReplicas,omitempty"`
+	// MaxGPU is the maximal amount of GPU requested by the unfinished jobs.
+	MaxGPU *resource.Quantity `json:"maxGPU,omitempty"`
+	// GPUResourceName is the resource name of GPU, nvidia.com/gpu by default.
+	GPUResourceName v1.ResourceName `json:"gpuResourceName,omitempty"`
+}
+
+// jobUsage is the jobs, replicas and GPU counted against a policy.
+type jobUsage struct {
+	jobs     int32
+	replicas int32
+	// gpu is in milli units as api.Resource records scalar resources
+	gpu float64
+}
+
+func (u *jobUsage) add(job *v1alpha1.Job, gpuName v1.ResourceName) {
+	u.jobs++
+	for _, task := range job.Spec.Tasks {
+		u.replicas += task.Replicas
+		u.gpu += podRequest(&task).Get(gpuName) * float64(task.Replicas)
+	}
+}
+
+// isJobFinished returns whether the job does not count against the policies anymore.
+func isJobFinished(job *v1alpha1.Job) bool {
+	switch job.Status.State.Phase {
+	case v1alpha1.Completed, v1alpha1.Failed, v1alpha1.Terminated, v1alpha1.Aborted:
+		return true
+	}
+	return false
+}
+
+// validateJobPolicies rejects the job if admitting it would exceed a JobPolicy of its namespace.
+func validateJobPolicies(job *v1alpha1.Job) string {
+	policies, err := listJobPolicies(job.Namespace)
+	if err != nil {
+		// do not block job submission if the policies are unavailable, e.g. the CRD is not installed
+		klog.Warningf("Skip job policy check of job <%s/%s>: %v", job.Namespace, job.Name, err)
+		return ""
+	}
+	if len(policies) == 0 {
+		return ""
+	}
+
+	if config.JobLister == nil {
+		return " unable to list jobs to check job policies: job lister is not set;"
+	}
+	jobs, err := config.JobLister.Jobs(job.Namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Sprintf(" unable to list jobs to check job policies: %v;", err)
+	}
+
+	var msg string
+	for _, policy := range policies {
+		msg += checkJobPolicy(policy, job, jobs)
+	}
+	return msg
+}
+
+// checkJobPolicy checks the usage of the existing jobs plus the new job against the limits of the policy.
+func checkJobPolicy(policy *JobPolicy, job *v1alpha1.Job, existing []*v1alpha1.Job) string {
+	scope := fmt.Sprintf("namespace `%s`", job.Namespace)
+	var user string
+	if policy.Spec.UserLabel != "" {
+		// the jobs without the label are counted together, so that the limits can not be bypassed by dropping the label
+		user = job.Labels[policy.Spec.UserLabel]
+		scope = fmt.Sprintf("user `%s` in namespace `%s`", user, job.Namespace)
+		if user == "" {
+			scope = fmt.Sprintf("jobs without label `%s` in namespace `%s`", policy.Spec.UserLabel, job.Namespace)
+		}
+	}
+	gpuName := policy.Spec.GPUResourceName
+	if gpuName == "" {
+		gpuName = api.GPUResourceName
+	}
+
+	usage := &jobUsage{}
+	for _, other := range existing {
+		if other.Name == job.Name || isJobFinished(other) {
+			continue
+		}
+		if policy.Spec.UserLabel != "" && other.Labels[policy.Spec.UserLabel] != user {
+			continue
+		}
+		usage.add(other, gpuName)
+	}
+	requested := &jobUsage{}
+	requested.add(job, gpuName)
+
+	var msg string
+	if limit := policy.Spec.MaxConcurrentJobs; limit != nil && usage.jobs+requested.jobs > *limit {
+		msg += fmt.Sprintf(" job policy `%s` allows at most %d concurrent jobs for %s, %d jobs are not finished;",
+			policy.Name, *limit, scope, usage.jobs)
+	}
+	if limit := policy.Spec.MaxTotalReplicas; limit != nil && usage.replicas+requested.replicas > *limit {
+		msg += fmt.Sprintf(" job policy `%s` allows at most %d total replicas for %s, %d are used and the job requests %d;",
+			policy.Name, *limit, scope, usage.replicas, requested.replicas)
+	}
+	if limit := policy.Spec.MaxGPU; limit != nil && usage.gpu+requested.gpu > float64(limit.MilliValue()) {
+		msg += fmt.Sprintf(" job policy `%s` allows at most %s %s for %s, %s are used and the job requests %s;",
+			policy.Name, limit.String(), gpuName, scope, formatQuantity(gpuName, usage.gpu), formatQuantity(gpuName, requested.gpu))
+	}
+	return msg
+}
+
+// listJobPolicies lists the policies of the namespace from the informer, it fails until the informer is synced.
+func listJobPolicies(namespace string) ([]*JobPolicy, error) {
+	if config.PolicyInformer == nil {
+		return nil, fmt.Errorf("job policy informer is not set")
+	}
+	if !config.PolicyInformer.Informer().HasSynced() {
+		return nil, fmt.Errorf("job policy informer is not synced")
+	}
+	objs, err := config.PolicyInformer.Lister().ByNamespace(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]*JobPolicy, 0, len(objs))
+	for _, obj := range objs {
+		item, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			klog.Warningf("Ignore job policy of unexpected type %T", obj)
+			continue
+		}
+		policy := &JobPolicy{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.UnstructuredContent(), policy); err != nil {
+			klog.Warningf("Ignore job policy <%s/%s>: %v", item.GetNamespace(), item.GetName(), err)
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
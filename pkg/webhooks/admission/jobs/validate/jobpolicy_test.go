@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/utils/ptr"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	fakeclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+	informers "volcano.sh/apis/pkg/client/informers/externalversions"
+)
+
+func buildPolicyJob(name, user string, phase v1alpha1.JobPhase, replicas int32, gpu string) *v1alpha1.Job {
+	requests := v1.ResourceList{}
+	if gpu != "" {
+		requests["nvidia.com/gpu"] = resource.MustParse(gpu)
+	}
+	job := buildPreflightJob(1, buildPreflightTask("worker", replicas, nil, requests))
+	job.Name = name
+	if user != "" {
+		job.Labels = map[string]string{"volcano.sh/user": user}
+	}
+	job.Status.State.Phase = phase
+	return job
+}
+
+func TestCheckJobPolicy(t *testing.T) {
+	existing := []*v1alpha1.Job{
+		buildPolicyJob("running-a", "alice", v1alpha1.Running, 4, "2"),
+		buildPolicyJob("pending-b", "bob", v1alpha1.Pending, 2, "1"),
+		buildPolicyJob("completed-a", "alice", v1alpha1.Completed, 8, "8"),
+		buildPolicyJob("running-none", "", v1alpha1.Running, 1, ""),
+	}
+
+	testCases := []struct {
+		name   string
+		spec   JobPolicySpec
+		job    *v1alpha1.Job
+		expect string
+	}{
+		{
+			name:   "concurrent jobs exceeded in namespace",
+			spec:   JobPolicySpec{MaxConcurrentJobs: ptr.To[int32](2)},
+			job:    buildPolicyJob("new", "alice", "", 1, ""),
+			expect: "allows at most 2 concurrent jobs for namespace `default`, 3 jobs are not finished",
+		},
+		{
+			name: "concurrent jobs counted per user",
+			spec: JobPolicySpec{UserLabel: "volcano.sh/user", MaxConcurrentJobs: ptr.To[int32](2)},
+			job:  buildPolicyJob("new", "alice", "", 1, ""),
+		},
+		{
+			name:   "total replicas exceeded for user",
+			spec:   JobPolicySpec{UserLabel: "volcano.sh/user", MaxTotalReplicas: ptr.To[int32](6)},
+			job:    buildPolicyJob("new", "alice", "", 3, ""),
+			expect: "allows at most 6 total replicas for user `alice` in namespace `default`, 4 are used and the job requests 3",
+		},
+		{
+			name:   "gpu exceeded in namespace",
+			spec:   JobPolicySpec{MaxGPU: ptr.To(resource.MustParse("11"))},
+			job:    buildPolicyJob("new", "", "", 1, "2"),
+			expect: "allows at most 11 nvidia.com/gpu for namespace `default`, 10 are used and the job requests 2",
+		},
+		{
+			name:   "jobs without user label are limited together",
+			spec:   JobPolicySpec{UserLabel: "volcano.sh/user", MaxConcurrentJobs: ptr.To[int32](1)},
+			job:    buildPolicyJob("new", "", "", 1, ""),
+			expect: "allows at most 1 concurrent jobs for jobs without label `volcano.sh/user` in namespace `default`, 1 jobs are not finished",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := &JobPolicy{ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "default"}, Spec: tc.spec}
+			msg := checkJobPolicy(policy, tc.job, existing)
+			if tc.expect == "" && msg != "" {
+				t.Errorf("expected job to be admitted, got %q", msg)
+			}
+			if tc.expect != "" && !strings.Contains(msg, tc.expect) {
+				t.Errorf("expected message containing %q, got %q", tc.expect, msg)
+			}
+		})
+	}
+}
+
+func TestValidateJobPolicies(t *testing.T) {
+	policy := &JobPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "batch.volcano.sh/v1alpha1", Kind: "JobPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: "default"},
+		Spec:       JobPolicySpec{MaxConcurrentJobs: ptr.To[int32](1)},
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldJobLister, oldPolicyInformer := config.JobLister, config.PolicyInformer
+	defer func() {
+		config.JobLister, config.PolicyInformer = oldJobLister, oldPolicyInformer
+	}()
+	informerFactory := informers.NewSharedInformerFactory(fakeclient.NewSimpleClientset(), 0)
+	jobInformer := informerFactory.Batch().V1alpha1().Jobs()
+	if err := jobInformer.Informer().GetIndexer().Add(buildPolicyJob("running", "", v1alpha1.Running, 1, "")); err != nil {
+		t.Fatal(err)
+	}
+	config.JobLister = jobInformer.Lister()
+
+	config.PolicyInformer = nil
+	if msg := validateJobPolicies(buildPolicyJob("new", "", "", 1, "")); msg != "" {
+		t.Errorf("expected no check without job policy informer, got %q", msg)
+	}
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{JobPolicyResource: "JobPolicyList"},
+		&unstructured.Unstructured{Object: content})
+	dynamicFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 0)
+	config.PolicyInformer = dynamicFactory.ForResource(JobPolicyResource)
+	if msg := validateJobPolicies(buildPolicyJob("new", "", "", 1, "")); msg != "" {
+		t.Errorf("expected no check before job policy informer is synced, got %q", msg)
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	dynamicFactory.Start(stopCh)
+	for informerType, ok := range dynamicFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			t.Fatalf("failed to sync cache: %v", informerType)
+		}
+	}
+	if msg := validateJobPolicies(buildPolicyJob("new", "", "", 1, "")); !strings.Contains(msg, "job policy `quota` allows at most 1 concurrent jobs") {
+		t.Errorf("expected job to be rejected by job policy, got %q", msg)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/klog/v2"
+	psaapi "k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+var (
+	podSecurityEvaluatorOnce sync.Once
+	podSecurityEvaluator     policy.Evaluator
+)
+
+func getPodSecurityEvaluator() policy.Evaluator {
+	podSecurityEvaluatorOnce.Do(func() {
+		evaluator, err := policy.NewEvaluator(policy.DefaultChecks())
+		if err != nil {
+			klog.Errorf("Failed to create the PodSecurity evaluator, the pod templates of jobs are not checked: %v", err)
+			return
+		}
+		podSecurityEvaluator = evaluator
+	})
+	return podSecurityEvaluator
+}
+
+// validateJobPodSecurity checks the pod templates of the tasks against the PodSecurity level enforced on the namespace
+// of the job. The pods created by the job controller would be rejected by the PodSecurity admission otherwise, and the
+// job would stay pending without any error visible to the user. Exemptions of the PodSecurity admission configuration
+// are not known to the webhook, so the namespaces enforcing a level should not rely on them for jobs.
+func validateJobPodSecurity(job *v1alpha1.Job) string {
+	if config.NamespaceLister == nil {
+		return ""
+	}
+	ns, err := config.NamespaceLister.Get(job.Namespace)
+	if err != nil {
+		klog.Warningf("Skip checking PodSecurity of job <%s/%s>: %v", job.Namespace, job.Name, err)
+		return ""
+	}
+	levelLabel, found := ns.Labels[psaapi.EnforceLevelLabel]
+	if !found {
+		return ""
+	}
+	level, err := psaapi.ParseLevel(levelLabel)
+	if err != nil || level == psaapi.LevelPrivileged {
+		return ""
+	}
+	version := psaapi.LatestVersion()
+	if versionLabel, found := ns.Labels[psaapi.EnforceVersionLabel]; found {
+		if version, err = psaapi.ParseVersion(versionLabel); err != nil {
+			version = psaapi.LatestVersion()
+		}
+	}
+
+	evaluator := getPodSecurityEvaluator()
+	if evaluator == nil {
+		return ""
+	}
+	var msg string
+	for _, task := range job.Spec.Tasks {
+		results := evaluator.EvaluatePod(psaapi.LevelVersion{Level: level, Version: version},
+			&task.Template.ObjectMeta, &task.Template.Spec)
+		if result := policy.AggregateCheckResults(results); !result.Allowed {
+			msg += fmt.Sprintf(" task %s violates PodSecurity %q of namespace %s: %s (%s);",
+				task.Name, psaapi.LevelVersion{Level: level, Version: version}.String(), job.Namespace,
+				result.ForbiddenReason(), result.ForbiddenDetail())
+		}
+	}
+	return msg
+}
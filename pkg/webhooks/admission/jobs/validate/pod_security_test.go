@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	psaapi "k8s.io/pod-security-admission/api"
+	"k8s.io/utils/ptr"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+func TestValidateJobPodSecurity(t *testing.T) {
+	oldNamespaceLister := config.NamespaceLister
+	defer func() {
+		config.NamespaceLister = oldNamespaceLister
+	}()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ns := range []*v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:   "restricted",
+			Labels: map[string]string{psaapi.EnforceLevelLabel: string(psaapi.LevelRestricted)},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:   "baseline",
+			Labels: map[string]string{psaapi.EnforceLevelLabel: string(psaapi.LevelBaseline)},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled"}},
+	} {
+		if err := indexer.Add(ns); err != nil {
+			t.Fatalf("Failed to add namespace %s: %v", ns.Name, err)
+		}
+	}
+	config.NamespaceLister = corelisters.NewNamespaceLister(indexer)
+
+	privileged := v1.PodSpec{Containers: []v1.Container{{
+		Name:            "main",
+		Image:           "busybox",
+		SecurityContext: &v1.SecurityContext{Privileged: ptr.To(true)},
+	}}}
+	restricted := v1.PodSpec{
+		SecurityContext: &v1.PodSecurityContext{
+			RunAsNonRoot:   ptr.To(true),
+			SeccompProfile: &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault},
+		},
+		Containers: []v1.Container{{
+			Name:  "main",
+			Image: "busybox",
+			SecurityContext: &v1.SecurityContext{
+				AllowPrivilegeEscalation: ptr.To(false),
+				Capabilities:             &v1.Capabilities{Drop: []v1.Capability{"ALL"}},
+			},
+		}},
+	}
+	plain := v1.PodSpec{Containers: []v1.Container{{Name: "main", Image: "busybox"}}}
+
+	testCases := []struct {
+		name      string
+		namespace string
+		spec      v1.PodSpec
+		expect    string
+	}{
+		{
+			name:      "privileged container in restricted namespace",
+			namespace: "restricted",
+			spec:      privileged,
+			expect:    "task worker violates PodSecurity \"restricted:latest\" of namespace restricted",
+		},
+		{
+			name:      "privileged container in baseline namespace",
+			namespace: "baseline",
+			spec:      privileged,
+			expect:    "privileged",
+		},
+		{
+			name:      "plain container in restricted namespace",
+			namespace: "restricted",
+			spec:      plain,
+			expect:    "allowPrivilegeEscalation != false",
+		},
+		{
+			name:      "plain container in baseline namespace",
+			namespace: "baseline",
+			spec:      plain,
+		},
+		{
+			name:      "restricted container in restricted namespace",
+			namespace: "restricted",
+			spec:      restricted,
+		},
+		{
+			name:      "privileged container in namespace without level",
+			namespace: "unlabeled",
+			spec:      privileged,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: tc.namespace},
+				Spec: v1alpha1.JobSpec{Tasks: []v1alpha1.TaskSpec{{
+					Name:     "worker",
+					Template: v1.PodTemplateSpec{Spec: tc.spec},
+				}}},
+			}
+			msg := validateJobPodSecurity(job)
+			if tc.expect == "" && msg != "" {
+				t.Errorf("expected no error, got %s", msg)
+			}
+			if tc.expect != "" && !strings.Contains(msg, tc.expect) {
+				t.Errorf("expected error containing %q, got %q", tc.expect, msg)
+			}
+		})
+	}
+}
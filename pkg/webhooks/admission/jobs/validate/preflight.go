@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// preflightEnabled returns whether the job preflight check is enabled in the admission configuration.
+func preflightEnabled() bool {
+	if config.ConfigData == nil {
+		return false
+	}
+	config.ConfigData.Lock()
+	defer config.ConfigData.Unlock()
+	return config.ConfigData.JobPreflight
+}
+
+// validateJobPreflight rejects the job which can never be scheduled, that is the minimal resources
+// of the job exceed the capability of the queue, or a pod of some task does not fit into any node.
+func validateJobPreflight(job *v1alpha1.Job, queue *schedulingv1beta1.Queue) string {
+	if !preflightEnabled() {
+		return ""
+	}
+
+	msg := validateQueueCapabilityFit(job, queue)
+	nodes, err := listSchedulableNodes()
+	if err != nil {
+		// do not block job submission on an unavailable node list
+		klog.Warningf("Skip node fit check of job <%s/%s>: %v", job.Namespace, job.Name, err)
+		return msg
+	}
+	return msg + validateNodeFit(job, nodes)
+}
+
+// jobMinResources returns a lower bound of the resources required to start the job.
+func jobMinResources(job *v1alpha1.Job) *api.Resource {
+	var totalMinAvailable int32
+	for _, task := range job.Spec.Tasks {
+		if task.MinAvailable != nil {
+			totalMinAvailable += *task.MinAvailable
+		} else {
+			totalMinAvailable += task.Replicas
+		}
+	}
+
+	// job.MinAvailable >= sum(task.MinAvailable), every task must reach its own minAvailable.
+	if job.Spec.MinAvailable >= totalMinAvailable {
+		minReq := api.EmptyResource()
+		for _, task := range job.Spec.Tasks {
+			count := task.Replicas
+			if task.MinAvailable != nil {
+				count = *task.MinAvailable
+			}
+			minReq.Add(podRequest(&task).Multi(float64(count)))
+		}
+		return minReq
+	}
+
+	// job.MinAvailable < sum(task.MinAvailable), the pods to start depend on the priority of tasks,
+	// so take the smallest request of each dimension among tasks.
+	var smallest *api.Resource
+	for _, task := range job.Spec.Tasks {
+		req := podRequest(&task)
+		if smallest == nil {
+			smallest = req
+			continue
+		}
+		smallest.MinDimensionResource(req, api.Zero)
+	}
+	if smallest == nil {
+		return api.EmptyResource()
+	}
+	return smallest.Multi(float64(job.Spec.MinAvailable))
+}
+
+func podRequest(task *v1alpha1.TaskSpec) *api.Resource {
+	return api.GetPodResourceRequest(&v1.Pod{Spec: task.Template.Spec})
+}
+
+// validateQueueCapabilityFit checks the minimal resources of the job against the capability of the queue,
+// only the resources set in the capability are limited.
+func validateQueueCapabilityFit(job *v1alpha1.Job, queue *schedulingv1beta1.Queue) string {
+	if len(queue.Spec.Capability) == 0 {
+		return ""
+	}
+
+	minReq := jobMinResources(job)
+	capability := api.NewResource(queue.Spec.Capability)
+	var exceeded []string
+	for name := range queue.Spec.Capability {
+		if minReq.Get(name) > capability.Get(name) {
+			exceeded = append(exceeded, fmt.Sprintf("%s (requires %s, capability %s)",
+				name, formatQuantity(name, minReq.Get(name)), formatQuantity(name, capability.Get(name))))
+		}
+	}
+	if len(exceeded) == 0 {
+		return ""
+	}
+	sort.Strings(exceeded)
+	return fmt.Sprintf(" job can never be scheduled, its minimal resources exceed the capability of queue `%s`: %s, "+
+		"reduce minAvailable or the resource requests of tasks;", queue.Name, strings.Join(exceeded, ", "))
+}
+
+// validateNodeFit checks that a pod of each task fits into the allocatable resources of at least one node.
+func validateNodeFit(job *v1alpha1.Job, nodes []*v1.Node) string {
+	if len(nodes) == 0 {
+		return ""
+	}
+
+	var msg string
+	for _, task := range job.Spec.Tasks {
+		req := podRequest(&task)
+		fits := false
+		largest := api.EmptyResource()
+		for _, node := range nodes {
+			allocatable := api.NewResource(node.Status.Allocatable)
+			if ok, _ := req.LessEqualWithResourcesName(allocatable, api.Zero); ok {
+				fits = true
+				break
+			}
+			largest.SetMaxResource(allocatable)
+		}
+		if fits {
+			continue
+		}
+
+		_, insufficient := req.LessEqualWithResourcesName(largest, api.Zero)
+		if len(insufficient) == 0 {
+			msg += fmt.Sprintf(" pod of task `%s` can never be scheduled, no single node has enough allocatable "+
+				"resources for all of its requests;", task.Name)
+			continue
+		}
+		var details []string
+		for _, name := range insufficient {
+			rn := v1.ResourceName(name)
+			details = append(details, fmt.Sprintf("%s (requests %s, largest node %s)",
+				name, formatQuantity(rn, req.Get(rn)), formatQuantity(rn, largest.Get(rn))))
+		}
+		msg += fmt.Sprintf(" pod of task `%s` can never be scheduled, it requests more than the allocatable resources "+
+			"of the largest node: %s;", task.Name, strings.Join(details, ", "))
+	}
+	return msg
+}
+
+func listSchedulableNodes() ([]*v1.Node, error) {
+	if config.NodeLister == nil {
+		return nil, fmt.Errorf("node lister is not set")
+	}
+	nodeList, err := config.NodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*v1.Node, 0, len(nodeList))
+	for _, node := range nodeList {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// formatQuantity converts the value of api.Resource back to a readable quantity, cpu and scalar
+// resources except pods are recorded in milli units.
+func formatQuantity(name v1.ResourceName, value float64) string {
+	switch name {
+	case v1.ResourceMemory:
+		return resource.NewQuantity(int64(value), resource.BinarySI).String()
+	case v1.ResourcePods:
+		return resource.NewQuantity(int64(value), resource.DecimalSI).String()
+	default:
+		return resource.NewMilliQuantity(int64(value), resource.DecimalSI).String()
+	}
+}
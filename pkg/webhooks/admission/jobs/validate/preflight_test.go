@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	webhookconfig "volcano.sh/volcano/pkg/webhooks/config"
+)
+
+func buildPreflightJob(minAvailable int32, tasks ...v1alpha1.TaskSpec) *v1alpha1.Job {
+	return &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "default"},
+		Spec:       v1alpha1.JobSpec{MinAvailable: minAvailable, Tasks: tasks},
+	}
+}
+
+func buildPreflightTask(name string, replicas int32, minAvailable *int32, requests v1.ResourceList) v1alpha1.TaskSpec {
+	return v1alpha1.TaskSpec{
+		Name:         name,
+		Replicas:     replicas,
+		MinAvailable: minAvailable,
+		Template: v1.PodTemplateSpec{
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "c", Resources: v1.ResourceRequirements{Requests: requests}}},
+			},
+		},
+	}
+}
+
+func buildPreflightNode(name string, allocatable v1.ResourceList) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     v1.NodeStatus{Allocatable: allocatable},
+	}
+}
+
+func TestValidateQueueCapabilityFit(t *testing.T) {
+	cpu := func(q string) v1.ResourceList { return v1.ResourceList{v1.ResourceCPU: resource.MustParse(q)} }
+	queue := &schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: "limited"},
+		Spec:       schedulingv1beta1.QueueSpec{Capability: cpu("8")},
+	}
+
+	testCases := []struct {
+		name   string
+		job    *v1alpha1.Job
+		expect string
+	}{
+		{
+			name: "minimal resources within capability",
+			job:  buildPreflightJob(4, buildPreflightTask("worker", 4, ptr.To[int32](4), cpu("2"))),
+		},
+		{
+			name:   "minimal resources exceed capability",
+			job:    buildPreflightJob(5, buildPreflightTask("worker", 5, ptr.To[int32](5), cpu("2"))),
+			expect: "cpu (requires 10, capability 8)",
+		},
+		{
+			name: "replicas beyond minAvailable are not counted",
+			job:  buildPreflightJob(2, buildPreflightTask("worker", 10, ptr.To[int32](2), cpu("2"))),
+		},
+		{
+			name: "job minAvailable less than task minAvailable takes the smallest task",
+			job: buildPreflightJob(2,
+				buildPreflightTask("ps", 2, ptr.To[int32](2), cpu("1")),
+				buildPreflightTask("worker", 2, ptr.To[int32](2), cpu("8"))),
+		},
+		{
+			name: "resources not limited by capability are ignored",
+			job: buildPreflightJob(1, buildPreflightTask("worker", 1, nil,
+				v1.ResourceList{v1.ResourceCPU: resource.MustParse("1"), v1.ResourceMemory: resource.MustParse("1Ti")})),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := validateQueueCapabilityFit(tc.job, queue)
+			if tc.expect == "" && msg != "" || !strings.Contains(msg, tc.expect) {
+				t.Errorf("expected %q, got %q", tc.expect, msg)
+			}
+		})
+	}
+}
+
+func TestValidateNodeFit(t *testing.T) {
+	nodes := []*v1.Node{
+		buildPreflightNode("cpu-node", v1.ResourceList{
+			v1.ResourceCPU: resource.MustParse("32"), v1.ResourceMemory: resource.MustParse("64Gi"), v1.ResourcePods: resource.MustParse("110"),
+		}),
+		buildPreflightNode("gpu-node", v1.ResourceList{
+			v1.ResourceCPU: resource.MustParse("8"), v1.ResourceMemory: resource.MustParse("256Gi"), v1.ResourcePods: resource.MustParse("110"),
+			"nvidia.com/gpu": resource.MustParse("8"),
+		}),
+	}
+
+	testCases := []struct {
+		name   string
+		job    *v1alpha1.Job
+		expect string
+	}{
+		{
+			name: "pod fits into a node",
+			job: buildPreflightJob(1, buildPreflightTask("worker", 1, nil, v1.ResourceList{
+				v1.ResourceCPU: resource.MustParse("4"), "nvidia.com/gpu": resource.MustParse("8"),
+			})),
+		},
+		{
+			name: "pod larger than the largest node",
+			job: buildPreflightJob(1, buildPreflightTask("worker", 1, nil, v1.ResourceList{
+				v1.ResourceCPU: resource.MustParse("64"),
+			})),
+			expect: "pod of task `worker` can never be scheduled, it requests more than the allocatable resources " +
+				"of the largest node: cpu (requests 64, largest node 32)",
+		},
+		{
+			name: "pod fits each dimension but not a single node",
+			job: buildPreflightJob(1, buildPreflightTask("worker", 1, nil, v1.ResourceList{
+				v1.ResourceCPU: resource.MustParse("16"), "nvidia.com/gpu": resource.MustParse("1"),
+			})),
+			expect: "no single node has enough allocatable resources",
+		},
+		{
+			name: "resource not provided by any node",
+			job: buildPreflightJob(1, buildPreflightTask("worker", 1, nil, v1.ResourceList{
+				"example.com/fpga": resource.MustParse("1"),
+			})),
+			expect: "example.com/fpga (requests 1, largest node 0)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := validateNodeFit(tc.job, nodes)
+			if tc.expect == "" && msg != "" || !strings.Contains(msg, tc.expect) {
+				t.Errorf("expected %q, got %q", tc.expect, msg)
+			}
+		})
+	}
+}
+
+func TestValidateJobPreflight(t *testing.T) {
+	queue := &schedulingv1beta1.Queue{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	job := buildPreflightJob(1, buildPreflightTask("worker", 1, nil, v1.ResourceList{v1.ResourceCPU: resource.MustParse("64")}))
+	unschedulable := buildPreflightNode("cordoned", v1.ResourceList{v1.ResourceCPU: resource.MustParse("128")})
+	unschedulable.Spec.Unschedulable = true
+
+	oldNodeLister, oldConfigData := config.NodeLister, config.ConfigData
+	defer func() {
+		config.NodeLister, config.ConfigData = oldNodeLister, oldConfigData
+	}()
+	nodeInformer := informers.NewSharedInformerFactory(kubefake.NewSimpleClientset(), 0).Core().V1().Nodes()
+	for _, node := range []*v1.Node{buildPreflightNode("node", v1.ResourceList{v1.ResourceCPU: resource.MustParse("32")}), unschedulable} {
+		if err := nodeInformer.Informer().GetIndexer().Add(node); err != nil {
+			t.Fatal(err)
+		}
+	}
+	config.NodeLister = nodeInformer.Lister()
+
+	config.ConfigData = &webhookconfig.AdmissionConfiguration{}
+	if msg := validateJobPreflight(job, queue); msg != "" {
+		t.Errorf("expected no check when preflight is disabled, got %q", msg)
+	}
+
+	config.ConfigData.JobPreflight = true
+	if msg := validateJobPreflight(job, queue); !strings.Contains(msg, "cpu (requests 64, largest node 32)") {
+		t.Errorf("expected job to be rejected by node fit check, got %q", msg)
+	}
+}
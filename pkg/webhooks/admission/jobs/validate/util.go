@@ -18,8 +18,8 @@ package validate
 
 import (
 	"fmt"
+	"sort"
 
-	"github.com/hashicorp/go-multierror"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kubernetes/pkg/apis/core/validation"
 
@@ -58,67 +58,142 @@ var policyActionMap = map[busv1alpha1.Action]bool{
 	busv1alpha1.CloseQueueAction:   false,
 }
 
-func validatePolicies(policies []batchv1alpha1.LifecyclePolicy, fldPath *field.Path) error {
-	var err error
+// taskPolicyEventMap defines the policy events which are raised for a task, the others are raised for the job only
+// and never match the policies of a task.
+var taskPolicyEventMap = map[busv1alpha1.Event]bool{
+	busv1alpha1.AnyEvent:           true,
+	busv1alpha1.PodFailedEvent:     true,
+	busv1alpha1.PodEvictedEvent:    true,
+	busv1alpha1.PodPendingEvent:    true,
+	busv1alpha1.TaskCompletedEvent: true,
+	busv1alpha1.TaskFailedEvent:    true,
+}
+
+// validatePolicies validates the lifecycle policies of a job, or of a task if taskLevel is true.
+func validatePolicies(policies []batchv1alpha1.LifecyclePolicy, fldPath *field.Path, taskLevel bool) error {
+	var allErrs field.ErrorList
 	policyEvents := map[busv1alpha1.Event]struct{}{}
 	exitCodes := map[int32]struct{}{}
+	eventExitCodes := map[eventExitCode]struct{}{}
+
+	for i, policy := range policies {
+		path := fldPath.Index(i)
+		events := getEventList(policy)
+		if len(events) == 0 && policy.ExitCode == nil {
+			allErrs = append(allErrs, field.Required(path, "either event and exitCode should be specified"))
+			continue
+		}
 
-	for _, policy := range policies {
-		if (policy.Event != "" || len(policy.Events) != 0) && policy.ExitCode != nil {
-			err = multierror.Append(err, fmt.Errorf("must not specify event and exitCode simultaneously"))
-			break
+		if allow, ok := policyActionMap[policy.Action]; !ok || !allow {
+			allErrs = append(allErrs, field.Invalid(path.Child("action"), policy.Action, "invalid policy action"))
+		} else if taskLevel && policy.Action == busv1alpha1.ResumeJobAction {
+			allErrs = append(allErrs, field.NotSupported(path.Child("action"), policy.Action, taskPolicyActions()))
 		}
 
-		if policy.Event == "" && len(policy.Events) == 0 && policy.ExitCode == nil {
-			err = multierror.Append(err, fmt.Errorf("either event and exitCode should be specified"))
-			break
+		if policy.Timeout != nil {
+			if len(events) == 0 {
+				allErrs = append(allErrs, field.Invalid(path.Child("timeout"), policy.Timeout.Duration.String(),
+					"timeout can only be specified together with event"))
+			} else if policy.Timeout.Duration < 0 {
+				allErrs = append(allErrs, field.Invalid(path.Child("timeout"), policy.Timeout.Duration.String(),
+					"timeout must not be negative"))
+			}
 		}
 
-		if len(policy.Event) != 0 || len(policy.Events) != 0 {
-			bFlag := false
-			policyEventsList := getEventList(policy)
-			for _, event := range policyEventsList {
+		if len(events) != 0 && policy.ExitCode != nil {
+			allErrs = append(allErrs, validateEventExitCodePolicy(policy, eventExitCodes, path)...)
+			continue
+		}
+
+		if len(events) != 0 {
+			for _, event := range events {
 				if allow, ok := policyEventMap[event]; !ok || !allow {
-					err = multierror.Append(err, field.Invalid(fldPath, event, "invalid policy event"))
-					bFlag = true
-					break
+					allErrs = append(allErrs, field.Invalid(eventPath(path, policy, event), event, "invalid policy event"))
+					continue
 				}
-
-				if allow, ok := policyActionMap[policy.Action]; !ok || !allow {
-					err = multierror.Append(err, field.Invalid(fldPath, policy.Action, "invalid policy action"))
-					bFlag = true
-					break
+				if taskLevel && !taskPolicyEventMap[event] {
+					allErrs = append(allErrs, field.Invalid(eventPath(path, policy, event), event,
+						"the event is raised for the job only, and can not be specified in the policies of a task"))
+					continue
 				}
 				if _, found := policyEvents[event]; found {
-					err = multierror.Append(err, fmt.Errorf("duplicate event %v  across different policy", event))
-					bFlag = true
-					break
-				} else {
-					policyEvents[event] = struct{}{}
+					allErrs = append(allErrs, field.Invalid(eventPath(path, policy, event), event,
+						fmt.Sprintf("duplicate event %v across different policy", event)))
+					continue
 				}
-			}
-			if bFlag {
-				break
+				policyEvents[event] = struct{}{}
 			}
 		} else {
 			if *policy.ExitCode == 0 {
-				err = multierror.Append(err, fmt.Errorf("0 is not a valid error code"))
-				break
+				allErrs = append(allErrs, field.Invalid(path.Child("exitCode"), *policy.ExitCode, "0 is not a valid error code"))
+				continue
 			}
 			if _, found := exitCodes[*policy.ExitCode]; found {
-				err = multierror.Append(err, fmt.Errorf("duplicate exitCode %v", *policy.ExitCode))
-				break
-			} else {
-				exitCodes[*policy.ExitCode] = struct{}{}
+				allErrs = append(allErrs, field.Invalid(path.Child("exitCode"), *policy.ExitCode,
+					fmt.Sprintf("duplicate exitCode %v", *policy.ExitCode)))
+				continue
 			}
+			exitCodes[*policy.ExitCode] = struct{}{}
 		}
 	}
 
 	if _, found := policyEvents[busv1alpha1.AnyEvent]; found && len(policyEvents) > 1 {
-		err = multierror.Append(err, fmt.Errorf("if there's * here, no other policy should be here"))
+		allErrs = append(allErrs, field.Invalid(fldPath, busv1alpha1.AnyEvent, "if there's * here, no other policy should be here"))
 	}
 
-	return err
+	return allErrs.ToAggregate()
+}
+
+// eventPath returns the field path of the event in the policy, which is either in event or in events.
+func eventPath(path *field.Path, policy batchv1alpha1.LifecyclePolicy, event busv1alpha1.Event) *field.Path {
+	for i, e := range policy.Events {
+		if e == event {
+			return path.Child("events").Index(i)
+		}
+	}
+	return path.Child("event")
+}
+
+// taskPolicyActions returns the actions which can be specified in the policies of a task.
+func taskPolicyActions() []string {
+	var actions []string
+	for a, allow := range policyActionMap {
+		if allow && a != busv1alpha1.ResumeJobAction {
+			actions = append(actions, string(a))
+		}
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+// eventExitCode is the event and exit code of a policy specifying both of them.
+type eventExitCode struct {
+	event    busv1alpha1.Event
+	exitCode int32
+}
+
+// validateEventExitCodePolicy validates the policy specifying both event and exitCode, which is applied
+// when a pod fails with the exit code. As the exit code is only known when the pod failed, the event must be PodFailed.
+func validateEventExitCodePolicy(policy batchv1alpha1.LifecyclePolicy, eventExitCodes map[eventExitCode]struct{}, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if *policy.ExitCode == 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("exitCode"), *policy.ExitCode, "0 is not a valid error code"))
+	}
+	for _, event := range getEventList(policy) {
+		if event != busv1alpha1.PodFailedEvent {
+			allErrs = append(allErrs, field.Invalid(eventPath(path, policy, event), event,
+				fmt.Sprintf("exitCode can only be specified together with event %s, but got event %s", busv1alpha1.PodFailedEvent, event)))
+			continue
+		}
+		key := eventExitCode{event: event, exitCode: *policy.ExitCode}
+		if _, found := eventExitCodes[key]; found {
+			allErrs = append(allErrs, field.Invalid(path.Child("exitCode"), *policy.ExitCode,
+				fmt.Sprintf("duplicate event %v with exitCode %v", event, *policy.ExitCode)))
+			continue
+		}
+		eventExitCodes[key] = struct{}{}
+	}
+	return allErrs
 }
 
 func getEventList(policy batchv1alpha1.LifecyclePolicy) []busv1alpha1.Event {
@@ -194,6 +269,17 @@ func validateIO(volumes []batchv1alpha1.VolumeSpec) error {
 
 // topoSort uses topo sort to sort job tasks based on dependsOn field
 // it will return an array contains all sorted task names and a bool which indicates whether it's a valid dag
+// totalReplicasExcept returns the sum of replicas of the tasks other than the given task.
+func totalReplicasExcept(job *batchv1alpha1.Job, taskName string) int32 {
+	var total int32
+	for _, task := range job.Spec.Tasks {
+		if task.Name != taskName {
+			total += task.Replicas
+		}
+	}
+	return total
+}
+
 func topoSort(job *batchv1alpha1.Job) ([]string, bool) {
 	graph, inDegree, taskList := makeGraph(job)
 	var taskStack []string
@@ -17,10 +17,15 @@ limitations under the License.
 package validate
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
 )
 
 func TestTopoSort(t *testing.T) {
@@ -150,3 +155,84 @@ func TestTopoSort(t *testing.T) {
 		}
 	}
 }
+
+func TestValidatePolicies(t *testing.T) {
+	exitCode := int32(3)
+	testCases := []struct {
+		name      string
+		policies  []v1alpha1.LifecyclePolicy
+		taskLevel bool
+		expected  []string
+	}{
+		{
+			name: "valid policies",
+			policies: []v1alpha1.LifecyclePolicy{
+				{Event: busv1alpha1.PodFailedEvent, ExitCode: &exitCode, Action: busv1alpha1.RestartPodAction},
+				{Events: []busv1alpha1.Event{busv1alpha1.PodFailedEvent, busv1alpha1.PodEvictedEvent}, Action: busv1alpha1.RestartJobAction},
+				{Event: busv1alpha1.PodPendingEvent, Action: busv1alpha1.RestartTaskAction, Timeout: &metav1.Duration{Duration: time.Minute}},
+			},
+			taskLevel: true,
+		},
+		{
+			name: "duplicate events",
+			policies: []v1alpha1.LifecyclePolicy{
+				{Event: busv1alpha1.PodFailedEvent, Action: busv1alpha1.RestartJobAction},
+				{Events: []busv1alpha1.Event{busv1alpha1.PodEvictedEvent, busv1alpha1.PodFailedEvent}, Action: busv1alpha1.AbortJobAction},
+			},
+			expected: []string{"spec.policies[1].events[1]: Invalid value: \"PodFailed\": duplicate event PodFailed"},
+		},
+		{
+			name: "exit code with an event other than PodFailed",
+			policies: []v1alpha1.LifecyclePolicy{
+				{Event: busv1alpha1.PodEvictedEvent, ExitCode: &exitCode, Action: busv1alpha1.RestartPodAction},
+			},
+			expected: []string{"spec.policies[0].event: Invalid value: \"PodEvicted\": exitCode can only be specified together with event PodFailed"},
+		},
+		{
+			name: "timeout without event",
+			policies: []v1alpha1.LifecyclePolicy{
+				{ExitCode: &exitCode, Action: busv1alpha1.RestartJobAction, Timeout: &metav1.Duration{Duration: time.Minute}},
+			},
+			expected: []string{"spec.policies[0].timeout: Invalid value: \"1m0s\": timeout can only be specified together with event"},
+		},
+		{
+			name: "unsupported action and event for task level policies",
+			policies: []v1alpha1.LifecyclePolicy{
+				{Event: busv1alpha1.PodFailedEvent, Action: busv1alpha1.ResumeJobAction},
+				{Event: busv1alpha1.JobUnknownEvent, Action: busv1alpha1.RestartJobAction},
+			},
+			taskLevel: true,
+			expected: []string{
+				"spec.policies[0].action: Unsupported value: \"ResumeJob\"",
+				"spec.policies[1].event: Invalid value: \"Unknown\": the event is raised for the job only",
+			},
+		},
+		{
+			name: "missing action",
+			policies: []v1alpha1.LifecyclePolicy{
+				{ExitCode: &exitCode},
+			},
+			expected: []string{"spec.policies[0].action: Invalid value: \"\": invalid policy action"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePolicies(tc.policies, field.NewPath("spec").Child("policies"), tc.taskLevel)
+			if len(tc.expected) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors %v, got nil", tc.expected)
+			}
+			for _, expected := range tc.expected {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error %q, got %q", expected, err.Error())
+				}
+			}
+		})
+	}
+}
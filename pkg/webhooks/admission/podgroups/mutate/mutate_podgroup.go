@@ -17,7 +17,6 @@ limitations under the License.
 package mutate
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 
@@ -102,24 +101,57 @@ func PodGroups(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 }
 
 func createPodGroupPatch(podgroup *schedulingv1beta1.PodGroup) ([]byte, error) {
-	if podgroup.Spec.Queue != schedulingv1beta1.DefaultQueue {
-		return nil, nil
+	var patch []patchOperation
+	if podgroup.Spec.Queue == schedulingv1beta1.DefaultQueue {
+		if queue := getNamespaceDefaultQueue(podgroup.Namespace); queue != "" {
+			// the defaulted queue is used by the following mutations
+			podgroup.Spec.Queue = queue
+			patch = append(patch, patchOperation{
+				Op:    "add",
+				Path:  "/spec/queue",
+				Value: queue,
+			})
+		}
 	}
-	ns, err := config.KubeClient.CoreV1().Namespaces().Get(context.TODO(), podgroup.Namespace, metav1.GetOptions{})
-	if err != nil {
-		klog.ErrorS(err, "Failed to get namespace", "namespace", podgroup.Namespace)
-		return nil, nil
+
+	if podgroup.Spec.PriorityClassName == "" {
+		if name := getQueueDefaultPriorityClassName(podgroup.Spec.Queue); name != "" {
+			patch = append(patch, patchOperation{
+				Op:    "add",
+				Path:  "/spec/priorityClassName",
+				Value: name,
+			})
+		}
 	}
 
-	if val, ok := ns.GetAnnotations()[schedulingv1beta1.QueueNameAnnotationKey]; ok {
-		var patch []patchOperation
-		patch = append(patch, patchOperation{
-			Op:    "add",
-			Path:  "/spec/queue",
-			Value: val,
-		})
-		return json.Marshal(patch)
+	if len(patch) == 0 {
+		return nil, nil
 	}
+	return json.Marshal(patch)
+}
 
-	return nil, nil
+// getNamespaceDefaultQueue returns the default queue of the namespace, empty if not set.
+func getNamespaceDefaultQueue(namespace string) string {
+	return util.GetDefaultQueueOfNamespace(config.NamespaceLister, namespace, config.ConfigData)
+}
+
+// getQueueDefaultPriorityClassName returns the default priorityClassName of the queue, empty if not set.
+func getQueueDefaultPriorityClassName(queueName string) string {
+	if config.QueueLister == nil {
+		return ""
+	}
+	if queueName == "" {
+		queueName = schedulingv1beta1.DefaultQueue
+	}
+	queue, err := config.QueueLister.Get(queueName)
+	if err != nil {
+		klog.V(4).Infof("Failed to get queue %s: %v", queueName, err)
+		return ""
+	}
+	name, err := util.GetQueueDefaultPriorityClassName(queue)
+	if err != nil {
+		klog.Warningf("Ignore invalid default priorityClassName of queue %s: %v", queueName, err)
+		return ""
+	}
+	return name
 }
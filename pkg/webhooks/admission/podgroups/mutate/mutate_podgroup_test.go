@@ -17,7 +17,6 @@ limitations under the License.
 package mutate
 
 import (
-	"context"
 	"encoding/json"
 	"reflect"
 	"testing"
@@ -26,8 +25,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulinglister "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/webhooks/util"
 )
 
 func Test_createPodGroupPatch(t *testing.T) {
@@ -35,6 +38,7 @@ func Test_createPodGroupPatch(t *testing.T) {
 		name          string
 		podgroup      *schedulingv1beta1.PodGroup
 		nsAnnotations map[string]string
+		nsLabels      map[string]string
 		wantPatch     []patchOperation
 		wantErr       bool
 	}{
@@ -88,27 +92,52 @@ func Test_createPodGroupPatch(t *testing.T) {
 			wantPatch:     nil,
 			wantErr:       false,
 		},
+		{
+			name: "podgroup with default queue and namespace with queue label and annotation",
+			podgroup: &schedulingv1beta1.PodGroup{
+				Spec: schedulingv1beta1.PodGroupSpec{
+					Queue: schedulingv1beta1.DefaultQueue,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-ns",
+				},
+			},
+			nsAnnotations: map[string]string{
+				schedulingv1beta1.QueueNameAnnotationKey: "ns-queue",
+			},
+			nsLabels: map[string]string{
+				schedulingv1beta1.QueueNameAnnotationKey: "ns-label-queue",
+			},
+			wantPatch: []patchOperation{
+				{
+					Op:    "add",
+					Path:  "/spec/queue",
+					Value: "ns-label-queue",
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Setup fake client
-			client := fake.NewSimpleClientset()
+			// Setup fake namespace lister
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
 			if tt.nsAnnotations != nil {
 				ns := &corev1.Namespace{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:        "test-ns",
 						Annotations: tt.nsAnnotations,
+						Labels:      tt.nsLabels,
 					},
 				}
-				_, err := client.CoreV1().Namespaces().Create(context.TODO(), ns, metav1.CreateOptions{})
-				if err != nil {
-					t.Fatalf("Failed to create test namespace: %v", err)
+				if err := indexer.Add(ns); err != nil {
+					t.Fatalf("Failed to add test namespace: %v", err)
 				}
 			}
 
 			config = &router.AdmissionServiceConfig{
-				KubeClient: client,
+				NamespaceLister: corelisters.NewNamespaceLister(indexer),
 			}
 
 			got, err := createPodGroupPatch(tt.podgroup)
@@ -136,3 +165,67 @@ func Test_createPodGroupPatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_createPodGroupPatchPriorityClassName(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	_ = indexer.Add(&schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "gold",
+			Annotations: map[string]string{util.DefaultPriorityClassNameAnnotationKey: "gold-priority"},
+		},
+	})
+	config = &router.AdmissionServiceConfig{
+		KubeClient:  fake.NewSimpleClientset(),
+		QueueLister: schedulinglister.NewQueueLister(indexer),
+	}
+
+	tests := []struct {
+		name      string
+		priority  string
+		wantPatch []patchOperation
+	}{
+		{
+			name: "inherit default of queue",
+			wantPatch: []patchOperation{
+				{
+					Op:    "add",
+					Path:  "/spec/priorityClassName",
+					Value: "gold-priority",
+				},
+			},
+		},
+		{
+			name:     "keep priority of podgroup",
+			priority: "high",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podgroup := &schedulingv1beta1.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns"},
+				Spec: schedulingv1beta1.PodGroupSpec{
+					Queue:             "gold",
+					PriorityClassName: tt.priority,
+				},
+			}
+			got, err := createPodGroupPatch(podgroup)
+			if err != nil {
+				t.Fatalf("createPodGroupPatch() error = %v", err)
+			}
+			if tt.wantPatch == nil {
+				if got != nil {
+					t.Errorf("createPodGroupPatch() got = %v, want nil", string(got))
+				}
+				return
+			}
+			var gotPatch []patchOperation
+			if err := json.Unmarshal(got, &gotPatch); err != nil {
+				t.Fatalf("Failed to unmarshal patch: %v", err)
+			}
+			if !reflect.DeepEqual(gotPatch, tt.wantPatch) {
+				t.Errorf("createPodGroupPatch() got = %v, want %v", gotPatch, tt.wantPatch)
+			}
+		})
+	}
+}
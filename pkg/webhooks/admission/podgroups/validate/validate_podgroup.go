@@ -17,14 +17,23 @@ limitations under the License.
 package validate
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	whv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/klog/v2"
 
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/features"
 	"volcano.sh/volcano/pkg/webhooks/router"
 	"volcano.sh/volcano/pkg/webhooks/schema"
 	"volcano.sh/volcano/pkg/webhooks/util"
@@ -44,7 +53,7 @@ var service = &router.AdmissionService{
 			Name: "validatepodgroup.volcano.sh",
 			Rules: []whv1.RuleWithOperations{
 				{
-					Operations: []whv1.OperationType{whv1.Create},
+					Operations: []whv1.OperationType{whv1.Create, whv1.Update},
 					Rule: whv1.Rule{
 						APIGroups:   []string{schedulingv1beta1.SchemeGroupVersion.Group},
 						APIVersions: []string{schedulingv1beta1.SchemeGroupVersion.Version},
@@ -67,9 +76,17 @@ func Validate(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 		return util.ToAdmissionResponse(err)
 	}
 
+	var warnings []string
 	switch ar.Request.Operation {
 	case admissionv1.Create:
-		err = validatePodGroup(podgroup)
+		warnings, err = validatePodGroup(podgroup)
+	case admissionv1.Update:
+		var oldPodgroup *schedulingv1beta1.PodGroup
+		oldPodgroup, err = schema.DecodePodGroup(ar.Request.OldObject, ar.Request.Resource)
+		if err != nil {
+			return util.ToAdmissionResponse(err)
+		}
+		err = validatePodGroupUpdate(oldPodgroup, podgroup)
 	default:
 		err = fmt.Errorf("unsupported operation %s", ar.Request.Operation)
 	}
@@ -82,30 +99,119 @@ func Validate(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 	}
 
 	return &admissionv1.AdmissionResponse{
-		Allowed: true,
+		Allowed:  true,
+		Warnings: warnings,
 	}
 }
 
-// validatePodGroup validates a PodGroup when it's being created
-func validatePodGroup(pg *schedulingv1beta1.PodGroup) error {
+// validatePodGroup validates a PodGroup when it's being created, the spec is checked by the
+// ValidatingAdmissionPolicy instead if PodGroupValidatingAdmissionPolicy is enabled. The warnings
+// about the queue state are returned if the admission configuration only warns about them.
+func validatePodGroup(pg *schedulingv1beta1.PodGroup) ([]string, error) {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.PodGroupValidatingAdmissionPolicy) {
+		if err := validatePodGroupSpec(pg); err != nil {
+			return nil, err
+		}
+	}
+	if err := util.ValidateHierarchyAnnotations(pg.Annotations, field.NewPath("metadata", "annotations")).ToAggregate(); err != nil {
+		return nil, err
+	}
 	return checkQueueState(pg.Spec.Queue)
 }
 
-// checkQueueState verifies if the queue exists and is in the open state
-func checkQueueState(queueName string) error {
-	if queueName == "" {
+// validatePodGroupUpdate validates a PodGroup when it's being updated. The minMember may be lowered at any time, e.g.
+// to let a running job continue with less pods, but it must not be raised above the replicas of the Volcano job
+// owning the PodGroup, the PodGroup could never be scheduled again otherwise.
+func validatePodGroupUpdate(old, pg *schedulingv1beta1.PodGroup) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.PodGroupValidatingAdmissionPolicy) {
+		if err := validatePodGroupSpec(pg); err != nil {
+			return err
+		}
+	}
+	if err := util.ValidateHierarchyAnnotations(pg.Annotations, field.NewPath("metadata", "annotations")).ToAggregate(); err != nil {
+		return err
+	}
+	if pg.Spec.MinMember <= old.Spec.MinMember {
 		return nil
 	}
 
-	queue, err := config.QueueLister.Get(queueName)
+	replicas, found, err := ownerJobReplicas(pg)
 	if err != nil {
-		return fmt.Errorf("unable to find queue: %v", err)
+		klog.Warningf("Skip checking the minMember of PodGroup <%s/%s>: %v", pg.Namespace, pg.Name, err)
+		return nil
+	}
+	if found && pg.Spec.MinMember > replicas {
+		return fmt.Errorf("podgroup 'minMember' %d must not be greater than the %d replicas of its job",
+			pg.Spec.MinMember, replicas)
+	}
+	return nil
+}
+
+// ownerJobReplicas returns the total replicas of the tasks of the Volcano job owning the PodGroup, false is returned
+// if the PodGroup is not owned by a Volcano job.
+func ownerJobReplicas(pg *schedulingv1beta1.PodGroup) (int32, bool, error) {
+	owner := metav1.GetControllerOf(pg)
+	if owner == nil || owner.Kind != "Job" || owner.APIVersion != batchv1alpha1.SchemeGroupVersion.String() ||
+		config.VolcanoClient == nil {
+		return 0, false, nil
+	}
+	job, err := config.VolcanoClient.BatchV1alpha1().Jobs(pg.Namespace).Get(context.TODO(), owner.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
 	}
+	var replicas int32
+	for _, task := range job.Spec.Tasks {
+		replicas += task.Replicas
+	}
+	return replicas, true, nil
+}
 
-	if queue.Status.State != schedulingv1beta1.QueueStateOpen {
-		return fmt.Errorf("can only submit PodGroup to queue with state `Open`, "+
-			"queue `%s` status is `%s`", queue.Name, queue.Status.State)
+// validatePodGroupSpec verifies the members and resources of the PodGroup are not negative,
+// keep it in line with installer/helm/chart/volcano/policy/podgroups-validating.yaml.
+func validatePodGroupSpec(pg *schedulingv1beta1.PodGroup) error {
+	if pg.Spec.MinMember < 0 {
+		return fmt.Errorf("podgroup 'minMember' must be >= 0")
 	}
 
+	tasks := make([]string, 0, len(pg.Spec.MinTaskMember))
+	for task := range pg.Spec.MinTaskMember {
+		tasks = append(tasks, task)
+	}
+	sort.Strings(tasks)
+	for _, task := range tasks {
+		if pg.Spec.MinTaskMember[task] < 0 {
+			return fmt.Errorf("podgroup 'minTaskMember' must be >= 0 in task: %s", task)
+		}
+	}
+
+	if pg.Spec.MinResources != nil {
+		for name, quantity := range *pg.Spec.MinResources {
+			if quantity.Sign() < 0 {
+				return fmt.Errorf("podgroup 'minResources' must be >= 0 for resource: %s", name)
+			}
+		}
+	}
 	return nil
 }
+
+// checkQueueState verifies if the queue exists, is in the open state and is a leaf queue. The violations
+// are returned as warnings instead if the admission configuration only warns about them.
+func checkQueueState(queueName string) ([]string, error) {
+	if queueName == "" {
+		return nil, nil
+	}
+
+	queue, err := config.QueueLister.Get(queueName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find queue: %v", err)
+	}
+
+	violations := util.ValidateQueueState("PodGroup", queue, config.QueueLister)
+	if len(violations) == 0 || util.QueueStateWarnOnly(config.ConfigData) {
+		return violations, nil
+	}
+	return nil, errors.New(strings.Join(violations, "; "))
+}
@@ -22,11 +22,19 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	fakeclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
 	informers "volcano.sh/apis/pkg/client/informers/externalversions"
+
+	"volcano.sh/volcano/pkg/features"
+	webhookconfig "volcano.sh/volcano/pkg/webhooks/config"
 )
 
 func TestValidatePodGroup(t *testing.T) {
@@ -93,6 +101,20 @@ func TestValidatePodGroup(t *testing.T) {
 			queue:       &schedulingv1beta1.Queue{},
 			expectError: false,
 		},
+		{
+			name: "invalid podgroup with malformed hierarchy annotations",
+			podGroup: &schedulingv1beta1.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-podgroup",
+					Annotations: map[string]string{
+						schedulingv1beta1.KubeHierarchyAnnotationKey:       "root/eng/dev",
+						schedulingv1beta1.KubeHierarchyWeightAnnotationKey: "1/2",
+					},
+				},
+			},
+			queue:       &schedulingv1beta1.Queue{},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -137,3 +159,287 @@ func TestValidatePodGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePodGroupSpec(t *testing.T) {
+	openQueue := &schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-queue"},
+		Status:     schedulingv1beta1.QueueStatus{State: schedulingv1beta1.QueueStateOpen},
+	}
+	negativeCPU := v1.ResourceList{v1.ResourceCPU: resource.MustParse("-1")}
+
+	tests := []struct {
+		name        string
+		spec        schedulingv1beta1.PodGroupSpec
+		expectedErr string
+	}{
+		{
+			name: "valid spec",
+			spec: schedulingv1beta1.PodGroupSpec{
+				Queue:         "test-queue",
+				MinMember:     2,
+				MinTaskMember: map[string]int32{"ps": 1, "worker": 2},
+				MinResources:  &v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")},
+			},
+		},
+		{
+			name:        "negative minMember",
+			spec:        schedulingv1beta1.PodGroupSpec{Queue: "test-queue", MinMember: -1},
+			expectedErr: "podgroup 'minMember' must be >= 0",
+		},
+		{
+			name: "negative minTaskMember",
+			spec: schedulingv1beta1.PodGroupSpec{
+				Queue:         "test-queue",
+				MinMember:     1,
+				MinTaskMember: map[string]int32{"ps": 1, "worker": -1},
+			},
+			expectedErr: "podgroup 'minTaskMember' must be >= 0 in task: worker",
+		},
+		{
+			name:        "negative minResources",
+			spec:        schedulingv1beta1.PodGroupSpec{Queue: "test-queue", MinMember: 1, MinResources: &negativeCPU},
+			expectedErr: "podgroup 'minResources' must be >= 0 for resource: cpu",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.VolcanoClient = fakeclient.NewSimpleClientset()
+			informerFactory := informers.NewSharedInformerFactory(config.VolcanoClient, 0)
+			queueInformer := informerFactory.Scheduling().V1beta1().Queues()
+			config.QueueLister = queueInformer.Lister()
+			assert.Nil(t, queueInformer.Informer().GetIndexer().Add(openQueue))
+
+			pg := &schedulingv1beta1.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-podgroup"},
+				Spec:       tt.spec,
+			}
+			warnings, err := validatePodGroup(pg)
+			if tt.expectedErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+			assert.Empty(t, warnings)
+
+			// the spec is left to the ValidatingAdmissionPolicy
+			featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.PodGroupValidatingAdmissionPolicy, true)
+			warnings, err = validatePodGroup(pg)
+			assert.Nil(t, err)
+			assert.Empty(t, warnings)
+		})
+	}
+}
+
+func TestValidatePodGroupUpdate(t *testing.T) {
+	job := &batchv1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "job1"},
+		Spec: batchv1alpha1.JobSpec{Tasks: []batchv1alpha1.TaskSpec{
+			{Name: "ps", Replicas: 1},
+			{Name: "worker", Replicas: 3},
+		}},
+	}
+	controller := true
+	buildPodGroup := func(minMember int32, owned bool) *schedulingv1beta1.PodGroup {
+		pg := &schedulingv1beta1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "job1-pg"},
+			Spec:       schedulingv1beta1.PodGroupSpec{Queue: "default", MinMember: minMember},
+		}
+		if owned {
+			pg.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: batchv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "Job",
+				Name:       job.Name,
+				Controller: &controller,
+			}}
+		}
+		return pg
+	}
+
+	tests := []struct {
+		name        string
+		old         *schedulingv1beta1.PodGroup
+		new         *schedulingv1beta1.PodGroup
+		expectedErr string
+	}{
+		{
+			name: "decrease minMember",
+			old:  buildPodGroup(4, true),
+			new:  buildPodGroup(2, true),
+		},
+		{
+			name: "increase minMember within replicas",
+			old:  buildPodGroup(2, true),
+			new:  buildPodGroup(4, true),
+		},
+		{
+			name:        "increase minMember above replicas",
+			old:         buildPodGroup(2, true),
+			new:         buildPodGroup(5, true),
+			expectedErr: "podgroup 'minMember' 5 must not be greater than the 4 replicas of its job",
+		},
+		{
+			name: "increase minMember of podgroup not owned by job",
+			old:  buildPodGroup(2, false),
+			new:  buildPodGroup(5, false),
+		},
+		{
+			name:        "negative minMember",
+			old:         buildPodGroup(2, true),
+			new:         buildPodGroup(-1, true),
+			expectedErr: "podgroup 'minMember' must be >= 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.VolcanoClient = fakeclient.NewSimpleClientset(job)
+			err := validatePodGroupUpdate(tt.old, tt.new)
+			if tt.expectedErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestCheckQueueState(t *testing.T) {
+	queues := []*schedulingv1beta1.Queue{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "root"},
+			Status:     schedulingv1beta1.QueueStatus{State: schedulingv1beta1.QueueStateOpen},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "parent"},
+			Spec:       schedulingv1beta1.QueueSpec{Parent: "root"},
+			Status:     schedulingv1beta1.QueueStatus{State: schedulingv1beta1.QueueStateOpen},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "leaf"},
+			Spec:       schedulingv1beta1.QueueSpec{Parent: "parent"},
+			Status:     schedulingv1beta1.QueueStatus{State: schedulingv1beta1.QueueStateOpen},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "closed"},
+			Spec:       schedulingv1beta1.QueueSpec{Parent: "parent"},
+			Status:     schedulingv1beta1.QueueStatus{State: schedulingv1beta1.QueueStateClosed},
+		},
+	}
+	config.VolcanoClient = fakeclient.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(config.VolcanoClient, 0)
+	queueInformer := informerFactory.Scheduling().V1beta1().Queues()
+	config.QueueLister = queueInformer.Lister()
+	for _, queue := range queues {
+		assert.Nil(t, queueInformer.Informer().GetIndexer().Add(queue))
+	}
+	defer func() { config.ConfigData = nil }()
+
+	tests := []struct {
+		name             string
+		queue            string
+		mode             string
+		expectError      string
+		expectedWarnings int
+	}{
+		{
+			name:  "leaf queue",
+			queue: "leaf",
+		},
+		{
+			name:        "closed queue rejected",
+			queue:       "closed",
+			expectError: "can only submit PodGroup to queue with state `Open`",
+		},
+		{
+			name:        "non-leaf queue rejected",
+			queue:       "parent",
+			expectError: "can only submit PodGroup to leaf queue, queue `parent` has 2 child queues",
+		},
+		{
+			name:        "root queue rejected",
+			queue:       "root",
+			expectError: "can not submit PodGroup to root queue",
+		},
+		{
+			name:             "closed queue warned",
+			queue:            "closed",
+			mode:             webhookconfig.QueueStateValidationWarn,
+			expectedWarnings: 1,
+		},
+		{
+			name:             "non-leaf queue warned",
+			queue:            "parent",
+			mode:             webhookconfig.QueueStateValidationWarn,
+			expectedWarnings: 1,
+		},
+		{
+			name:        "missing queue rejected in warn mode",
+			queue:       "missing",
+			mode:        webhookconfig.QueueStateValidationWarn,
+			expectError: "unable to find queue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.ConfigData = &webhookconfig.AdmissionConfiguration{QueueStateValidation: tt.mode}
+			warnings, err := checkQueueState(tt.queue)
+			if tt.expectError != "" {
+				assert.ErrorContains(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, warnings, tt.expectedWarnings)
+		})
+	}
+}
+
+func TestValidatePodGroupQueueStateWarn(t *testing.T) {
+	closedQueue := &schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: "closed"},
+		Status:     schedulingv1beta1.QueueStatus{State: schedulingv1beta1.QueueStateClosed},
+	}
+	config.VolcanoClient = fakeclient.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(config.VolcanoClient, 0)
+	queueInformer := informerFactory.Scheduling().V1beta1().Queues()
+	config.QueueLister = queueInformer.Lister()
+	assert.Nil(t, queueInformer.Informer().GetIndexer().Add(closedQueue))
+	defer func() { config.ConfigData = nil }()
+
+	pg := &schedulingv1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-podgroup"},
+		Spec:       schedulingv1beta1.PodGroupSpec{Queue: "closed", MinMember: 1},
+	}
+	pgJson, _ := json.Marshal(pg)
+	ar := admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Name:      pg.Name,
+			Object:    runtime.RawExtension{Raw: pgJson},
+			Resource: metav1.GroupVersionResource{
+				Group:    schedulingv1beta1.SchemeGroupVersion.Group,
+				Version:  schedulingv1beta1.SchemeGroupVersion.Version,
+				Resource: "podgroups",
+			},
+		},
+	}
+
+	// the podgroup is admitted with the violation as the warning
+	config.ConfigData = &webhookconfig.AdmissionConfiguration{QueueStateValidation: webhookconfig.QueueStateValidationWarn}
+	warnings, err := validatePodGroup(pg)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "can only submit PodGroup to queue with state `Open`")
+
+	response := Validate(ar)
+	assert.True(t, response.Allowed)
+	assert.Equal(t, warnings, response.Warnings)
+
+	// the podgroup is rejected without the warn mode
+	config.ConfigData = &webhookconfig.AdmissionConfiguration{}
+	response = Validate(ar)
+	assert.False(t, response.Allowed)
+	assert.Empty(t, response.Warnings)
+	assert.Contains(t, response.Result.Message, "can only submit PodGroup to queue with state `Open`")
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"fmt"
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/webhooks/util"
+)
+
+// patchDefaultResources sets the default resource requirements of the queue of the pod to the containers without
+// requests, so that the best-effort pods participate in the share accounting of the queue. The defaults of the
+// LimitRange of the namespace have been applied before the webhook, so they take precedence over the queue.
+func patchDefaultResources(pod *v1.Pod) []patchOperation {
+	if config.QueueLister == nil || !slices.Contains(config.SchedulerNames, pod.Spec.SchedulerName) {
+		return nil
+	}
+
+	queueName := getPodQueue(pod)
+	queue, err := config.QueueLister.Get(queueName)
+	if err != nil {
+		klog.V(4).Infof("Failed to get queue %s of pod <%s/%s>: %v", queueName, pod.Namespace, pod.Name, err)
+		return nil
+	}
+	requirements, err := util.GetQueueDefaultResourceRequirements(queue)
+	if err != nil {
+		klog.Warningf("Ignore invalid default resource requirements of queue %s: %v", queueName, err)
+		return nil
+	}
+	if requirements == nil {
+		return nil
+	}
+
+	var patch []patchOperation
+	for i, container := range pod.Spec.InitContainers {
+		if len(container.Resources.Requests) == 0 {
+			patch = append(patch, patchContainerResources("initContainers", i, container, requirements))
+		}
+	}
+	for i, container := range pod.Spec.Containers {
+		if len(container.Resources.Requests) == 0 {
+			patch = append(patch, patchContainerResources("containers", i, container, requirements))
+		}
+	}
+	return patch
+}
+
+func patchContainerResources(field string, index int, container v1.Container, requirements *v1.ResourceRequirements) patchOperation {
+	resources := container.Resources.DeepCopy()
+	resources.Requests = requirements.Requests.DeepCopy()
+	for name, limit := range requirements.Limits {
+		if _, found := resources.Limits[name]; found {
+			continue
+		}
+		if resources.Limits == nil {
+			resources.Limits = v1.ResourceList{}
+		}
+		resources.Limits[name] = limit.DeepCopy()
+	}
+	return patchOperation{Op: "add", Path: fmt.Sprintf("/spec/%s/%d/resources", field, index), Value: resources}
+}
+
+// getPodQueue returns the queue of the pod, which is the queue of its job or podgroup, or the default queue
+// of its namespace if not specified.
+func getPodQueue(pod *v1.Pod) string {
+	if queue := pod.Annotations[v1alpha1.QueueNameKey]; queue != "" {
+		return queue
+	}
+	if queue := pod.Annotations[schedulingv1beta1.QueueNameAnnotationKey]; queue != "" {
+		return queue
+	}
+	if queue := util.GetDefaultQueueOfNamespace(config.NamespaceLister, pod.Namespace, config.ConfigData); queue != "" {
+		return queue
+	}
+	return schedulingv1beta1.DefaultQueue
+}
@@ -22,9 +22,14 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	fakeclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+	informers "volcano.sh/apis/pkg/client/informers/externalversions"
 	webconfig "volcano.sh/volcano/pkg/webhooks/config"
+	"volcano.sh/volcano/pkg/webhooks/util"
 )
 
 func TestMutatePods(t *testing.T) {
@@ -216,3 +221,79 @@ func TestMutatePods(t *testing.T) {
 		})
 	}
 }
+
+func TestPatchDefaultResources(t *testing.T) {
+	queue := &schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "best-effort",
+			Annotations: map[string]string{
+				util.DefaultResourceRequirementsAnnotationKey: `{"requests":{"cpu":"500m","memory":"512Mi"},"limits":{"memory":"1Gi"}}`,
+			},
+		},
+	}
+	client := fakeclient.NewSimpleClientset(queue)
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	queueInformer := informerFactory.Scheduling().V1beta1().Queues()
+	if err := queueInformer.Informer().GetIndexer().Add(queue); err != nil {
+		t.Fatal(err)
+	}
+
+	oldQueueLister, oldSchedulerNames := config.QueueLister, config.SchedulerNames
+	defer func() {
+		config.QueueLister, config.SchedulerNames = oldQueueLister, oldSchedulerNames
+	}()
+	config.QueueLister = queueInformer.Lister()
+	config.SchedulerNames = []string{"volcano"}
+
+	buildPod := func(schedulerName, queue string, containers ...v1.Container) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pod",
+				Namespace:   "default",
+				Annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: queue},
+			},
+			Spec: v1.PodSpec{SchedulerName: schedulerName, Containers: containers},
+		}
+	}
+	requests := v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}
+
+	testCases := []struct {
+		name   string
+		pod    *v1.Pod
+		expect []patchOperation
+	}{
+		{
+			name: "containers without requests are defaulted",
+			pod: buildPod("volcano", "best-effort",
+				v1.Container{Name: "c0", Resources: v1.ResourceRequirements{Requests: requests}},
+				v1.Container{Name: "c1"}),
+			expect: []patchOperation{{
+				Op:   "add",
+				Path: "/spec/containers/1/resources",
+				Value: &v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m"), v1.ResourceMemory: resource.MustParse("512Mi")},
+					Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			}},
+		},
+		{
+			name: "queue without default resource requirements",
+			pod:  buildPod("volcano", "unknown", v1.Container{Name: "c0"}),
+		},
+		{
+			name: "pod not scheduled by volcano",
+			pod:  buildPod("default-scheduler", "best-effort", v1.Container{Name: "c0"}),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			patch := patchDefaultResources(testCase.pod)
+			patchBytes, _ := json.Marshal(patch)
+			expectBytes, _ := json.Marshal(testCase.expect)
+			if !equality.Semantic.DeepEqual(patchBytes, expectBytes) {
+				t.Errorf("expect patch %s, got %s", expectBytes, patchBytes)
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 
 	vcv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
@@ -95,6 +96,8 @@ func AdmitPods(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 allow pods to create when
 1. schedulerName of pod isn't volcano
 2. check pod budget annotations configure
+3. check gpu sharing resources and annotations configure
+4. check hierarchy annotations format
 */
 func validatePod(pod *v1.Pod, reviewResponse *admissionv1.AdmissionResponse) string {
 	if !slices.Contains(config.SchedulerNames, pod.Spec.SchedulerName) {
@@ -108,6 +111,18 @@ func validatePod(pod *v1.Pod, reviewResponse *admissionv1.AdmissionResponse) str
 		reviewResponse.Allowed = false
 	}
 
+	// check gpu sharing resources and annotations
+	if err := util.ValidateGPUSharing(pod.Annotations, &pod.Spec); err != nil {
+		msg += " " + err.Error()
+		reviewResponse.Allowed = false
+	}
+
+	// check the format of the hierarchy annotations
+	if err := util.ValidateHierarchyAnnotations(pod.Annotations, field.NewPath("metadata", "annotations")).ToAggregate(); err != nil {
+		msg += " " + err.Error()
+		reviewResponse.Allowed = false
+	}
+
 	return msg
 }
 
@@ -23,6 +23,7 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	vcschedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
@@ -64,6 +65,36 @@ func TestValidatePod(t *testing.T) {
 			ret:            "",
 			ExpectErr:      false,
 		},
+		// validate pod with conflicting gpu sharing resources
+		{
+			Name: "validate pod with exclusive and shared gpus",
+			Pod: v1.Pod{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "Pod",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "gpu-pod-1",
+				},
+				Spec: v1.PodSpec{
+					SchedulerName: "volcano",
+					Containers: []v1.Container{{
+						Name: "c1",
+						Resources: v1.ResourceRequirements{
+							Limits: v1.ResourceList{
+								"volcano.sh/gpu-number": resource.MustParse("1"),
+								"volcano.sh/gpu-memory": resource.MustParse("1024"),
+							},
+						},
+					}},
+				},
+			},
+
+			reviewResponse: admissionv1.AdmissionResponse{Allowed: true},
+			ret:            "exclusive volcano.sh/gpu-number conflicts with shared volcano.sh/gpu-memory",
+			ExpectErr:      true,
+		},
 	}
 
 	for _, testCase := range testCases {
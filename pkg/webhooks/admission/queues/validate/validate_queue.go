@@ -18,7 +18,7 @@ package validate
 
 import (
 	"fmt"
-	"strconv"
+	"sort"
 	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -26,6 +26,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 
@@ -63,6 +64,12 @@ var service = &router.AdmissionService{
 	},
 }
 
+// ForceDeleteQueueAnnotationKey allows deleting the queue which still has active podgroups when it is "true".
+const ForceDeleteQueueAnnotationKey = "volcano.sh/force-delete"
+
+// maxListedPodGroups is the maximal number of active podgroups listed in the message of rejecting queue deletion.
+const maxListedPodGroups = 5
+
 var config = &router.AdmissionServiceConfig{}
 
 // AdmitQueues is to admit queues and return response.
@@ -76,10 +83,6 @@ func AdmitQueues(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse
 
 	switch ar.Request.Operation {
 	case admissionv1.Create, admissionv1.Update:
-		err = validateQueue(queue)
-		if err != nil {
-			break
-		}
 		var oldQueue *schedulingv1beta1.Queue
 		if ar.Request.Operation == admissionv1.Update {
 			oldQueue, err = schema.DecodeQueue(ar.Request.OldObject, ar.Request.Resource)
@@ -87,6 +90,10 @@ func AdmitQueues(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse
 				break
 			}
 		}
+		err = validateQueue(queue, oldQueue)
+		if err != nil {
+			break
+		}
 
 		if ar.Request.Operation == admissionv1.Create || oldQueue.Spec.Parent != queue.Spec.Parent {
 			err = validateHierarchicalQueue(queue)
@@ -111,14 +118,27 @@ func AdmitQueues(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse
 	}
 }
 
-func validateQueue(queue *schedulingv1beta1.Queue) error {
+// validateQueue validates the queue being created, or updated from the old queue. The state is only validated when it
+// changes, so that the queues in the states managed by the controller, e.g. Closing and Draining, can still be updated.
+func validateQueue(queue, oldQueue *schedulingv1beta1.Queue) error {
 	errs := field.ErrorList{}
 	resourcePath := field.NewPath("requestBody")
 
-	errs = append(errs, validateStateOfQueue(queue.Status.State, resourcePath.Child("spec").Child("state"))...)
+	if oldQueue == nil || oldQueue.Status.State != queue.Status.State {
+		errs = append(errs, validateStateOfQueue(queue.Status.State, resourcePath.Child("spec").Child("state"))...)
+	}
 	errs = append(errs, validateWeightOfQueue(queue.Spec.Weight, resourcePath.Child("spec").Child("weight"))...)
 	errs = append(errs, validateResourceOfQueue(queue.Spec, resourcePath.Child("spec"))...)
-	errs = append(errs, validateHierarchicalAttributes(queue, resourcePath.Child("metadata").Child("annotations"))...)
+	errs = append(errs, validateGPUModelResourceOfQueue(queue.Spec, resourcePath.Child("spec"))...)
+	// the hierarchy is validated only if changed, so that the queues with legacy hierarchies can still be updated,
+	// e.g. by the scheduler recording the allocated resources of its shard
+	if oldQueue == nil || hierarchyChanged(queue, oldQueue) {
+		errs = append(errs, validateHierarchicalAttributes(queue, resourcePath.Child("metadata").Child("annotations"))...)
+	}
+	errs = append(errs, validateActiveDeadlineSecondsOfQueue(queue, resourcePath.Child("metadata").Child("annotations"))...)
+	errs = append(errs, validateDefaultPriorityClassNameOfQueue(queue, resourcePath.Child("metadata").Child("annotations"))...)
+	errs = append(errs, validateDefaultResourceRequirementsOfQueue(queue, resourcePath.Child("metadata").Child("annotations"))...)
+	errs = append(errs, validateDefaultTopologyPolicyOfQueue(queue, resourcePath.Child("metadata").Child("annotations"))...)
 
 	if len(errs) > 0 {
 		return errs.ToAggregate()
@@ -126,39 +146,20 @@ func validateQueue(queue *schedulingv1beta1.Queue) error {
 
 	return nil
 }
-func validateHierarchicalAttributes(queue *schedulingv1beta1.Queue, fldPath *field.Path) field.ErrorList {
-	errs := field.ErrorList{}
-	hierarchy := queue.Annotations[schedulingv1beta1.KubeHierarchyAnnotationKey]
-	hierarchicalWeights := queue.Annotations[schedulingv1beta1.KubeHierarchyWeightAnnotationKey]
-	if hierarchy != "" || hierarchicalWeights != "" {
-		paths := strings.Split(hierarchy, "/")
-		weights := strings.Split(hierarchicalWeights, "/")
-		// path length must be the same with weights length
-		if len(paths) != len(weights) {
-			return append(errs, field.Invalid(fldPath, hierarchy,
-				fmt.Sprintf("%s must have the same length with %s",
-					schedulingv1beta1.KubeHierarchyAnnotationKey,
-					schedulingv1beta1.KubeHierarchyWeightAnnotationKey,
-				)))
-		}
 
-		// check weights format
-		for _, weight := range weights {
-			weightFloat, err := strconv.ParseFloat(weight, 64)
-			if err != nil {
-				return append(errs, field.Invalid(fldPath, hierarchicalWeights,
-					fmt.Sprintf("%s in the %s is invalid number: %v",
-						weight, hierarchicalWeights, err,
-					)))
-			}
-			if weightFloat <= 0 {
-				return append(errs, field.Invalid(fldPath, hierarchicalWeights,
-					fmt.Sprintf("%s in the %s must be larger than 0",
-						weight, hierarchicalWeights,
-					)))
-			}
-		}
+// hierarchyChanged returns whether the hierarchy annotations of the queue are changed by the update.
+func hierarchyChanged(queue, oldQueue *schedulingv1beta1.Queue) bool {
+	return queue.Annotations[schedulingv1beta1.KubeHierarchyAnnotationKey] != oldQueue.Annotations[schedulingv1beta1.KubeHierarchyAnnotationKey] ||
+		queue.Annotations[schedulingv1beta1.KubeHierarchyWeightAnnotationKey] != oldQueue.Annotations[schedulingv1beta1.KubeHierarchyWeightAnnotationKey]
+}
 
+func validateHierarchicalAttributes(queue *schedulingv1beta1.Queue, fldPath *field.Path) field.ErrorList {
+	errs := util.ValidateHierarchyAnnotations(queue.Annotations, fldPath)
+	if len(errs) > 0 {
+		return errs
+	}
+	hierarchy := queue.Annotations[schedulingv1beta1.KubeHierarchyAnnotationKey]
+	if hierarchy != "" {
 		// The node is not allowed to be in the sub path of a node.
 		// For example, a queue with "root/sci" conflicts with a queue with "root/sci/dev"
 		queueList, err := config.QueueLister.List(labels.Everything())
@@ -184,6 +185,41 @@ func validateHierarchicalAttributes(queue *schedulingv1beta1.Queue, fldPath *fie
 	return errs
 }
 
+func validateActiveDeadlineSecondsOfQueue(queue *schedulingv1beta1.Queue, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if _, _, err := util.GetQueueActiveDeadlineSeconds(queue); err != nil {
+		return append(errs, field.Invalid(fldPath, queue.Annotations, err.Error()))
+	}
+	return errs
+}
+
+func validateDefaultPriorityClassNameOfQueue(queue *schedulingv1beta1.Queue, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if _, err := util.GetQueueDefaultPriorityClassName(queue); err != nil {
+		return append(errs, field.Invalid(fldPath.Key(util.DefaultPriorityClassNameAnnotationKey),
+			queue.Annotations[util.DefaultPriorityClassNameAnnotationKey], err.Error()))
+	}
+	return errs
+}
+
+func validateDefaultTopologyPolicyOfQueue(queue *schedulingv1beta1.Queue, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if _, err := util.GetQueueDefaultTopologyPolicy(queue); err != nil {
+		return append(errs, field.Invalid(fldPath.Key(util.DefaultTopologyPolicyAnnotationKey),
+			queue.Annotations[util.DefaultTopologyPolicyAnnotationKey], err.Error()))
+	}
+	return errs
+}
+
+func validateDefaultResourceRequirementsOfQueue(queue *schedulingv1beta1.Queue, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if _, err := util.GetQueueDefaultResourceRequirements(queue); err != nil {
+		return append(errs, field.Invalid(fldPath.Key(util.DefaultResourceRequirementsAnnotationKey),
+			queue.Annotations[util.DefaultResourceRequirementsAnnotationKey], err.Error()))
+	}
+	return errs
+}
+
 func validateStateOfQueue(value schedulingv1beta1.QueueState, fldPath *field.Path) field.ErrorList {
 	errs := field.ErrorList{}
 
@@ -240,6 +276,39 @@ func validateResourceOfQueue(resource schedulingv1beta1.QueueSpec, fldPath *fiel
 	return errs
 }
 
+// validateGPUModelResourceOfQueue checks the gpu model resources, e.g. nvidia.com/gpu-a100, are named by valid
+// gpu models, and they are not more than the total gpus if the total is set too.
+func validateGPUModelResourceOfQueue(spec schedulingv1beta1.QueueSpec, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	resourceLists := []struct {
+		name string
+		list v1.ResourceList
+	}{
+		{name: "capability", list: spec.Capability},
+		{name: "deserved", list: spec.Deserved},
+		{name: "guarantee", list: spec.Guarantee.Resource},
+	}
+	for _, rl := range resourceLists {
+		total, totalSet := rl.list[api.GPUResourceName]
+		for name, quantity := range rl.list {
+			model, isModel := api.GetGPUModelOfResource(name)
+			if !isModel {
+				continue
+			}
+			if msgs := validation.IsDNS1123Label(model); len(msgs) != 0 {
+				errs = append(errs, field.Invalid(fldPath.Child(rl.name).Key(string(name)), quantity.String(),
+					fmt.Sprintf("invalid gpu model %q: %s", model, strings.Join(msgs, ","))))
+				continue
+			}
+			if totalSet && quantity.Cmp(total) > 0 {
+				errs = append(errs, field.Invalid(fldPath.Child(rl.name).Key(string(name)), quantity.String(),
+					fmt.Sprintf("gpus of model %s should less equal than %s", model, api.GPUResourceName)))
+			}
+		}
+	}
+	return errs
+}
+
 func validateQueueDeleting(queueName string) error {
 	if queueName == "default" {
 		return fmt.Errorf("`%s` queue can not be deleted", "default")
@@ -264,6 +333,24 @@ func validateQueueDeleting(queueName string) error {
 			queue.Name, len(childQueueNames), strings.Join(childQueueNames, ", "))
 	}
 
+	if queue.Annotations[ForceDeleteQueueAnnotationKey] == "true" {
+		klog.V(3).Infof("Skip checking active podgroups of queue %s as it is deleted forcibly", queue.Name)
+	} else {
+		podGroupNames, err := listActivePodGroups(queue.Name)
+		if err != nil {
+			return fmt.Errorf("failed to list podgroups: %v", err)
+		}
+		if len(podGroupNames) > 0 {
+			listed := podGroupNames
+			if len(listed) > maxListedPodGroups {
+				listed = append(listed[:maxListedPodGroups:maxListedPodGroups], "...")
+			}
+			return fmt.Errorf("queue %s can not be deleted because it has %d active podgroups: %s, "+
+				"annotate the queue with %s=true to delete it forcibly",
+				queue.Name, len(podGroupNames), strings.Join(listed, ", "), ForceDeleteQueueAnnotationKey)
+		}
+	}
+
 	klog.V(3).Infof("Validation passed for deleting hierarchical queue %s", queue.Name)
 
 	return nil
@@ -311,3 +398,38 @@ func listQueueChild(parentQueueName string) ([]string, error) {
 
 	return childQueueNames, nil
 }
+
+// PodGroupQueueIndex indexes the podgroups by the names of their queues.
+const PodGroupQueueIndex = "podgroup-queue"
+
+// PodGroupQueueIndexFunc returns the queue name of the podgroup.
+func PodGroupQueueIndexFunc(obj interface{}) ([]string, error) {
+	podGroup, ok := obj.(*schedulingv1beta1.PodGroup)
+	if !ok {
+		return nil, nil
+	}
+	return []string{podGroup.Spec.Queue}, nil
+}
+
+// listActivePodGroups returns the namespaced names of the podgroups in the queue which are not completed.
+func listActivePodGroups(queueName string) ([]string, error) {
+	if config.PodGroupIndexer == nil {
+		return nil, nil
+	}
+	objs, err := config.PodGroupIndexer.ByIndex(PodGroupQueueIndex, queueName)
+	if err != nil {
+		return nil, err
+	}
+
+	podGroupNames := make([]string, 0)
+	for _, obj := range objs {
+		podGroup, ok := obj.(*schedulingv1beta1.PodGroup)
+		if !ok || podGroup.Status.Phase == schedulingv1beta1.PodGroupCompleted {
+			continue
+		}
+		podGroupNames = append(podGroupNames, podGroup.Namespace+"/"+podGroup.Name)
+	}
+	sort.Strings(podGroupNames)
+
+	return podGroupNames, nil
+}
@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/cache"
 
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	fakeclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
@@ -104,6 +105,57 @@ func TestAdmitQueues(t *testing.T) {
 		t.Errorf("Marshal queue with wrong state failed for %v.", err)
 	}
 
+	drainingState := schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "draining-state",
+			Annotations: map[string]string{"team": "ml"},
+		},
+		Spec: schedulingv1beta1.QueueSpec{
+			Weight: 1,
+		},
+		Status: schedulingv1beta1.QueueStatus{
+			State: "Draining",
+		},
+	}
+
+	drainingStateJSON, err := json.Marshal(drainingState)
+	if err != nil {
+		t.Errorf("Marshal queue with draining state failed for %v.", err)
+	}
+
+	drainingState.Annotations = nil
+	drainingStateOldJSON, err := json.Marshal(drainingState)
+	if err != nil {
+		t.Errorf("Marshal queue with draining state failed for %v.", err)
+	}
+
+	legacyHierarchy := schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "legacy-hierarchy",
+			Annotations: map[string]string{
+				schedulingv1beta1.KubeHierarchyAnnotationKey:       "root/a",
+				schedulingv1beta1.KubeHierarchyWeightAnnotationKey: "1/0",
+			},
+		},
+		Spec: schedulingv1beta1.QueueSpec{
+			Weight: 1,
+		},
+		Status: schedulingv1beta1.QueueStatus{
+			State: schedulingv1beta1.QueueStateOpen,
+		},
+	}
+
+	legacyHierarchyOldJSON, err := json.Marshal(legacyHierarchy)
+	if err != nil {
+		t.Errorf("Marshal queue with legacy hierarchy failed for %v.", err)
+	}
+
+	legacyHierarchy.Annotations["allocated.shard.volcano.sh/a"] = `{"cpu":"1"}`
+	legacyHierarchyJSON, err := json.Marshal(legacyHierarchy)
+	if err != nil {
+		t.Errorf("Marshal queue with legacy hierarchy failed for %v.", err)
+	}
+
 	openStateForDelete := schedulingv1beta1.Queue{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "open-state-for-delete",
@@ -691,6 +743,70 @@ func TestAdmitQueues(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Normal Case Updating Queue In Draining State Without Changing State",
+			AR: admissionv1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "AdmissionReview",
+					APIVersion: "admission.k8s.io/v1beta1",
+				},
+				Request: &admissionv1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "scheduling.volcano.sh",
+						Version: "v1beta1",
+						Kind:    "Queue",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "scheduling.volcano.sh",
+						Version:  "v1beta1",
+						Resource: "queues",
+					},
+					Name:      "normal-case-draining-updating",
+					Operation: "UPDATE",
+					OldObject: runtime.RawExtension{
+						Raw: drainingStateOldJSON,
+					},
+					Object: runtime.RawExtension{
+						Raw: drainingStateJSON,
+					},
+				},
+			},
+			reviewResponse: &admissionv1.AdmissionResponse{
+				Allowed: true,
+			},
+		},
+		{
+			Name: "Normal Case Updating Queue Without Changing Legacy Hierarchy",
+			AR: admissionv1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "AdmissionReview",
+					APIVersion: "admission.k8s.io/v1beta1",
+				},
+				Request: &admissionv1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "scheduling.volcano.sh",
+						Version: "v1beta1",
+						Kind:    "Queue",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "scheduling.volcano.sh",
+						Version:  "v1beta1",
+						Resource: "queues",
+					},
+					Name:      "legacy-hierarchy",
+					Operation: "UPDATE",
+					OldObject: runtime.RawExtension{
+						Raw: legacyHierarchyOldJSON,
+					},
+					Object: runtime.RawExtension{
+						Raw: legacyHierarchyJSON,
+					},
+				},
+			},
+			reviewResponse: &admissionv1.AdmissionResponse{
+				Allowed: true,
+			},
+		},
 		{
 			Name: "Normal Case Queue With Closed State Can Be Deleted",
 			AR: admissionv1.AdmissionReview{
@@ -1654,3 +1770,119 @@ func TestAdmitHierarchicalQueues(t *testing.T) {
 	}
 	close(stopCh)
 }
+
+func TestValidateQueueDeletingWithPodGroups(t *testing.T) {
+	buildQueue := func(name string, annotations map[string]string) *schedulingv1beta1.Queue {
+		return &schedulingv1beta1.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations},
+			Spec:       schedulingv1beta1.QueueSpec{Weight: 1},
+		}
+	}
+	buildPodGroup := func(name, queue string, phase schedulingv1beta1.PodGroupPhase) *schedulingv1beta1.PodGroup {
+		return &schedulingv1beta1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       schedulingv1beta1.PodGroupSpec{Queue: queue},
+			Status:     schedulingv1beta1.PodGroupStatus{Phase: phase},
+		}
+	}
+
+	config.VolcanoClient = fakeclient.NewSimpleClientset(
+		buildQueue("queue-with-running-podgroup", nil),
+		buildQueue("queue-with-completed-podgroup", nil),
+		buildQueue("queue-deleted-forcibly", map[string]string{ForceDeleteQueueAnnotationKey: "true"}),
+		buildPodGroup("pg-running", "queue-with-running-podgroup", schedulingv1beta1.PodGroupRunning),
+		buildPodGroup("pg-completed", "queue-with-completed-podgroup", schedulingv1beta1.PodGroupCompleted),
+		buildPodGroup("pg-inqueue", "queue-deleted-forcibly", schedulingv1beta1.PodGroupInqueue),
+	)
+	informerFactory := informers.NewSharedInformerFactory(config.VolcanoClient, 0)
+	queueInformer := informerFactory.Scheduling().V1beta1().Queues()
+	config.QueueLister = queueInformer.Lister()
+	podGroupInformer := informerFactory.Scheduling().V1beta1().PodGroups().Informer()
+	if err := podGroupInformer.AddIndexers(cache.Indexers{PodGroupQueueIndex: PodGroupQueueIndexFunc}); err != nil {
+		t.Fatalf("failed to add the queue index of podgroups: %v", err)
+	}
+	config.PodGroupIndexer = podGroupInformer.GetIndexer()
+	defer func() {
+		config.PodGroupIndexer = nil
+	}()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+	for informerType, ok := range informerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			panic(fmt.Errorf("failed to sync cache: %v", informerType))
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		queue  string
+		expect string
+	}{
+		{
+			name:   "queue with running podgroup can not be deleted",
+			queue:  "queue-with-running-podgroup",
+			expect: "queue queue-with-running-podgroup can not be deleted because it has 1 active podgroups: default/pg-running, annotate the queue with volcano.sh/force-delete=true to delete it forcibly",
+		},
+		{
+			name:  "queue with completed podgroup can be deleted",
+			queue: "queue-with-completed-podgroup",
+		},
+		{
+			name:  "queue with force delete annotation can be deleted",
+			queue: "queue-deleted-forcibly",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateQueueDeleting(testCase.queue)
+			if testCase.expect == "" && err != nil {
+				t.Errorf("expected queue to be deleted, got %v", err)
+			}
+			if testCase.expect != "" && (err == nil || err.Error() != testCase.expect) {
+				t.Errorf("expected error %q, got %v", testCase.expect, err)
+			}
+		})
+	}
+}
+
+func TestValidateGPUModelResourceOfQueue(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      schedulingv1beta1.QueueSpec
+		expectErr bool
+	}{
+		{
+			name: "valid gpu models",
+			spec: schedulingv1beta1.QueueSpec{Capability: v1.ResourceList{
+				api.GPUResourceName:                  resource.MustParse("16"),
+				api.GPUModelResourceName("a100"):     resource.MustParse("8"),
+				api.GPUModelResourceName("h100-80g"): resource.MustParse("8"),
+			}},
+		},
+		{
+			name: "invalid gpu model",
+			spec: schedulingv1beta1.QueueSpec{Guarantee: schedulingv1beta1.Guarantee{Resource: v1.ResourceList{
+				api.GPUModelResourceName("A100"): resource.MustParse("8"),
+			}}},
+			expectErr: true,
+		},
+		{
+			name: "gpu model more than total gpus",
+			spec: schedulingv1beta1.QueueSpec{Deserved: v1.ResourceList{
+				api.GPUResourceName:              resource.MustParse("4"),
+				api.GPUModelResourceName("a100"): resource.MustParse("8"),
+			}},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		errs := validateGPUModelResourceOfQueue(tc.spec, field.NewPath("spec"))
+		if (len(errs) != 0) != tc.expectErr {
+			t.Errorf("%s: expected error %v, got %v", tc.name, tc.expectErr, errs)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"regexp"
 	"sync"
 
 	"github.com/fsnotify/fsnotify"
@@ -45,10 +46,57 @@ type ResGroupConfig struct {
 	Affinity      string            `yaml:"affinity"`
 }
 
+// NamespaceQueueConfig maps the namespaces matching the regular expression to a default queue.
+type NamespaceQueueConfig struct {
+	Namespace string `yaml:"namespace"`
+	Queue     string `yaml:"queue"`
+	// pattern is the compiled Namespace matching the whole namespace name, nil if Namespace is invalid
+	pattern *regexp.Regexp
+}
+
+// Matches returns whether the whole namespace name matches the compiled Namespace of the mapping.
+func (c *NamespaceQueueConfig) Matches(namespace string) bool {
+	return c.pattern != nil && c.pattern.MatchString(namespace)
+}
+
+// CompileNamespaceQueues compiles the namespaces of the mappings once, the invalid ones never match.
+func CompileNamespaceQueues(mappings []NamespaceQueueConfig) []NamespaceQueueConfig {
+	for i := range mappings {
+		pattern, err := regexp.Compile("^(?:" + mappings[i].Namespace + ")$")
+		if err != nil {
+			klog.Errorf("Invalid namespace %q in namespace queues of admission configuration: %v", mappings[i].Namespace, err)
+			continue
+		}
+		mappings[i].pattern = pattern
+	}
+	return mappings
+}
+
+const (
+	// QueueStateValidationReject rejects the jobs and PodGroups submitted to a closed or non-leaf queue, it is the default.
+	QueueStateValidationReject = "reject"
+	// QueueStateValidationWarn admits the jobs and PodGroups submitted to a closed or non-leaf queue with warnings.
+	QueueStateValidationWarn = "warn"
+)
+
 // AdmissionConfiguration defines the configuration of admission.
 type AdmissionConfiguration struct {
 	sync.Mutex
-	ResGroupsConfig []ResGroupConfig `yaml:"resourceGroups"`
+	ResGroupsConfig []ResGroupConfig       `yaml:"resourceGroups"`
+	NamespaceQueues []NamespaceQueueConfig `yaml:"namespaceQueues"`
+	// JobPreflight enables rejecting jobs which can never fit into the capability of the queue or any node.
+	JobPreflight bool `yaml:"jobPreflight"`
+	// WindowsRuntimeClassName is set to the windows tasks of jobs which do not specify runtimeClassName.
+	WindowsRuntimeClassName string `yaml:"windowsRuntimeClassName"`
+	// DefaultTopologyPolicy is set to the tasks of jobs which do not specify topologyPolicy, unless the queue
+	// of the job sets its own default.
+	DefaultTopologyPolicy string `yaml:"defaultTopologyPolicy"`
+	// QueueStateValidation is how the jobs and PodGroups submitted to a closed or non-leaf queue are handled,
+	// either QueueStateValidationReject or QueueStateValidationWarn.
+	QueueStateValidation string `yaml:"queueStateValidation"`
+	// MutationAuditAnnotation enables recording the paths defaulted by the mutating webhooks in an annotation of
+	// the mutated objects.
+	MutationAuditAnnotation bool `yaml:"mutationAuditAnnotation"`
 }
 
 var admissionConf AdmissionConfiguration
@@ -73,10 +121,24 @@ func LoadAdmissionConf(confPath string) *AdmissionConfiguration {
 
 	admissionConf.Lock()
 	admissionConf.ResGroupsConfig = data.ResGroupsConfig
+	admissionConf.NamespaceQueues = CompileNamespaceQueues(data.NamespaceQueues)
+	admissionConf.JobPreflight = data.JobPreflight
+	admissionConf.WindowsRuntimeClassName = data.WindowsRuntimeClassName
+	admissionConf.DefaultTopologyPolicy = data.DefaultTopologyPolicy
+	admissionConf.QueueStateValidation = data.QueueStateValidation
+	admissionConf.MutationAuditAnnotation = data.MutationAuditAnnotation
 	admissionConf.Unlock()
 	return &admissionConf
 }
 
+// MutationAuditAnnotationEnabled returns whether the mutated objects are annotated with the paths defaulted by
+// the mutating webhooks.
+func MutationAuditAnnotationEnabled() bool {
+	admissionConf.Lock()
+	defer admissionConf.Unlock()
+	return admissionConf.MutationAuditAnnotation
+}
+
 // WatchAdmissionConf listen the changes of the configuration file
 func WatchAdmissionConf(path string, stopCh <-chan struct{}) {
 	dirPath := filepath.Dir(path)
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"encoding/json"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// MutationAuditAnnotation is the annotation recording the paths of the object defaulted by the mutating
+	// webhook, e.g. "/spec/queue,/spec/minAvailable", set if mutationAuditAnnotation is enabled in the admission
+	// configuration.
+	MutationAuditAnnotation = "volcano.sh/admission-defaults"
+
+	// mutatedPathsAuditKey is the key of the audit annotation of the api server recording the patched paths, it
+	// is prefixed by the name of the webhook in the audit events.
+	mutatedPathsAuditKey = "mutated-paths"
+)
+
+type jsonPatchOperation struct {
+	Op   string `json:"op"`
+	Path string `json:"path"`
+	// Value is kept raw, so that the patch of the webhook is encoded again as is.
+	Value json.RawMessage `json:"value,omitempty"`
+	From  string          `json:"from,omitempty"`
+}
+
+// auditMutation records the paths patched by the mutating webhook in the audit annotations of the response, and in
+// the annotation of the object if annotateObject is set, so that the defaults applied by the webhook can be compared
+// with the ones of the ValidatingAdmissionPolicy or MutatingAdmissionPolicy replacing it.
+func auditMutation(request *admissionv1.AdmissionRequest, response *admissionv1.AdmissionResponse, annotateObject bool) {
+	if request == nil || response == nil || !response.Allowed || len(response.Patch) == 0 {
+		return
+	}
+	var patch []jsonPatchOperation
+	if err := json.Unmarshal(response.Patch, &patch); err != nil {
+		klog.Warningf("Failed to decode the patch of %s/%s to audit: %v", request.Namespace, request.Name, err)
+		return
+	}
+	if len(patch) == 0 {
+		return
+	}
+
+	var paths []string
+	annotationsPatched := false
+	for _, op := range patch {
+		paths = append(paths, op.Path)
+		if op.Path == "/metadata/annotations" {
+			annotationsPatched = true
+		}
+	}
+	mutatedPaths := strings.Join(paths, ",")
+	if response.AuditAnnotations == nil {
+		response.AuditAnnotations = map[string]string{}
+	}
+	response.AuditAnnotations[mutatedPathsAuditKey] = mutatedPaths
+
+	if !annotateObject {
+		return
+	}
+	op := jsonPatchOperation{Op: "add", Path: "/metadata/annotations/" + escapeJSONPointer(MutationAuditAnnotation)}
+	value, err := json.Marshal(mutatedPaths)
+	if !annotationsPatched && !hasAnnotations(request.Object.Raw) {
+		op.Path = "/metadata/annotations"
+		value, err = json.Marshal(map[string]string{MutationAuditAnnotation: mutatedPaths})
+	}
+	if err != nil {
+		klog.Warningf("Failed to encode the audit annotation of %s/%s: %v", request.Namespace, request.Name, err)
+		return
+	}
+	op.Value = value
+	patch = append(patch, op)
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		klog.Warningf("Failed to encode the patch of %s/%s to audit: %v", request.Namespace, request.Name, err)
+		return
+	}
+	response.Patch = patchBytes
+}
+
+// hasAnnotations returns whether the raw object has the annotations, to which an annotation can be added.
+func hasAnnotations(raw []byte) bool {
+	var object struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return false
+	}
+	return object.Metadata.Annotations != nil
+}
+
+// escapeJSONPointer escapes the key as a reference token of the json pointer.
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestAuditMutation(t *testing.T) {
+	testCases := []struct {
+		name           string
+		object         string
+		patch          string
+		annotateObject bool
+		expectPatch    string
+		expectAudit    string
+	}{
+		{
+			name:        "no patch",
+			object:      `{"metadata": {"name": "job1"}}`,
+			expectPatch: "",
+		},
+		{
+			name:        "audit annotation of response only",
+			object:      `{"metadata": {"name": "job1"}}`,
+			patch:       `[{"op":"add","path":"/spec/queue","value":"default"}]`,
+			expectPatch: `[{"op":"add","path":"/spec/queue","value":"default"}]`,
+			expectAudit: "/spec/queue",
+		},
+		{
+			name:           "object without annotations",
+			object:         `{"metadata": {"name": "job1"}}`,
+			patch:          `[{"op":"add","path":"/spec/queue","value":"default"},{"op":"add","path":"/spec/maxRetry","value":3}]`,
+			annotateObject: true,
+			expectPatch: `[{"op":"add","path":"/spec/queue","value":"default"},{"op":"add","path":"/spec/maxRetry","value":3},` +
+				`{"op":"add","path":"/metadata/annotations","value":{"volcano.sh/admission-defaults":"/spec/queue,/spec/maxRetry"}}]`,
+			expectAudit: "/spec/queue,/spec/maxRetry",
+		},
+		{
+			name:           "object with annotations",
+			object:         `{"metadata": {"name": "job1", "annotations": {"a": "b"}}}`,
+			patch:          `[{"op":"add","path":"/spec/queue","value":"default"}]`,
+			annotateObject: true,
+			expectPatch: `[{"op":"add","path":"/spec/queue","value":"default"},` +
+				`{"op":"add","path":"/metadata/annotations/volcano.sh~1admission-defaults","value":"/spec/queue"}]`,
+			expectAudit: "/spec/queue",
+		},
+		{
+			name:           "annotations added by the patch",
+			object:         `{"metadata": {"name": "pod1"}}`,
+			patch:          `[{"op":"add","path":"/metadata/annotations","value":{"a":"b"}}]`,
+			annotateObject: true,
+			expectPatch: `[{"op":"add","path":"/metadata/annotations","value":{"a":"b"}},` +
+				`{"op":"add","path":"/metadata/annotations/volcano.sh~1admission-defaults","value":"/metadata/annotations"}]`,
+			expectAudit: "/metadata/annotations",
+		},
+	}
+
+	for _, tc := range testCases {
+		request := &admissionv1.AdmissionRequest{Object: runtime.RawExtension{Raw: []byte(tc.object)}}
+		response := &admissionv1.AdmissionResponse{Allowed: true}
+		if tc.patch != "" {
+			response.Patch = []byte(tc.patch)
+		}
+		auditMutation(request, response, tc.annotateObject)
+		if string(response.Patch) != tc.expectPatch {
+			t.Errorf("%s: expected patch %s, got %s", tc.name, tc.expectPatch, string(response.Patch))
+		}
+		if got := response.AuditAnnotations[mutatedPathsAuditKey]; got != tc.expectAudit {
+			t.Errorf("%s: expected audit annotation %q, got %q", tc.name, tc.expectAudit, got)
+		}
+	}
+}
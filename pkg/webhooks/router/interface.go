@@ -19,10 +19,15 @@ package router
 import (
 	admissionv1 "k8s.io/api/admission/v1"
 	whv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	schedulinglisters "k8s.io/client-go/listers/scheduling/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 
 	"volcano.sh/apis/pkg/client/clientset/versioned"
+	batchlister "volcano.sh/apis/pkg/client/listers/batch/v1alpha1"
 	schedulinglister "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/webhooks/config"
 )
@@ -35,6 +40,19 @@ type AdmissionServiceConfig struct {
 	KubeClient     kubernetes.Interface
 	VolcanoClient  versioned.Interface
 	QueueLister    schedulinglister.QueueLister
+	JobLister      batchlister.JobLister
+	NodeLister     corelisters.NodeLister
+	// NamespaceLister is used by the mutating webhooks to default the queues from the namespaces
+	NamespaceLister corelisters.NamespaceLister
+	// ServiceAccountLister is used by the job validating webhook to warn about the missing service accounts of tasks
+	ServiceAccountLister corelisters.ServiceAccountLister
+	// PriorityClassLister is used by the job validating webhook to check the priority classes of jobs and tasks
+	PriorityClassLister schedulinglisters.PriorityClassLister
+	// PodGroupIndexer is used by the queue validating webhook to find the podgroups of the queues being deleted,
+	// the podgroups are indexed by the names of their queues
+	PodGroupIndexer cache.Indexer
+	// PolicyInformer is the informer of the JobPolicies, which have no typed client
+	PolicyInformer informers.GenericInformer
 	Recorder       record.EventRecorder
 	ConfigData     *config.AdmissionConfiguration
 }
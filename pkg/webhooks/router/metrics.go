@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+const (
+	// VolcanoSubSystemName - subsystem name in prometheus used by volcano
+	VolcanoSubSystemName = "volcano"
+
+	admittedResult = "admitted"
+	rejectedResult = "rejected"
+
+	// deniedReason is the reason of the rejections without a status reason, i.e. denied by the webhook itself.
+	deniedReason = "Denied"
+)
+
+var (
+	admissionRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "admission_requests_total",
+			Help:      "The number of admission requests handled by the webhooks, by the result and the reason of rejection",
+		}, []string{"webhook", "operation", "result", "reason"},
+	)
+
+	admissionLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "admission_duration_milliseconds",
+			Help:      "The latency of the webhooks to admit or mutate a request, in milliseconds",
+			Buckets:   prometheus.ExponentialBuckets(0.5, 2, 12),
+		}, []string{"webhook", "operation"},
+	)
+
+	admissionPatchSize = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "admission_patch_size_bytes",
+			Help:      "The size of the json patches returned by the mutating webhooks, in bytes",
+			Buckets:   prometheus.ExponentialBuckets(64, 2, 10),
+		}, []string{"webhook"},
+	)
+)
+
+// updateAdmissionMetrics records the result, the latency and the patch size of an admission request.
+func updateAdmissionMetrics(webhook string, operation admissionv1.Operation, response *admissionv1.AdmissionResponse, duration time.Duration) {
+	op := string(operation)
+	admissionLatency.WithLabelValues(webhook, op).Observe(float64(duration) / float64(time.Millisecond))
+	if response == nil {
+		return
+	}
+	if response.Allowed {
+		admissionRequests.WithLabelValues(webhook, op, admittedResult, "").Inc()
+	} else {
+		admissionRequests.WithLabelValues(webhook, op, rejectedResult, rejectReason(response)).Inc()
+	}
+	if len(response.Patch) > 0 {
+		admissionPatchSize.WithLabelValues(webhook).Observe(float64(len(response.Patch)))
+	}
+}
+
+// rejectReason returns the status reason of the rejection, the message is not used as it is unbounded.
+func rejectReason(response *admissionv1.AdmissionResponse) string {
+	if response.Result != nil && response.Result.Reason != "" {
+		return string(response.Result.Reason)
+	}
+	return deniedReason
+}
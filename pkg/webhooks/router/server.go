@@ -20,11 +20,14 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 
+	"volcano.sh/volcano/pkg/webhooks/config"
 	"volcano.sh/volcano/pkg/webhooks/schema"
 	"volcano.sh/volcano/pkg/webhooks/util"
 )
@@ -54,11 +57,19 @@ func Serve(w io.Writer, r *http.Request, admit AdmitFunc) {
 	var reviewResponse *admissionv1.AdmissionResponse
 	ar := admissionv1.AdmissionReview{}
 	deserializer := schema.Codecs.UniversalDeserializer()
+	start := time.Now()
+	var operation admissionv1.Operation
 	if _, _, err := deserializer.Decode(body, nil, &ar); err != nil {
 		reviewResponse = util.ToAdmissionResponse(err)
+		reviewResponse.Result.Reason = metav1.StatusReasonBadRequest
 	} else {
 		reviewResponse = admit(ar)
+		if ar.Request != nil {
+			operation = ar.Request.Operation
+		}
+		auditMutation(ar.Request, reviewResponse, config.MutationAuditAnnotationEnabled())
 	}
+	updateAdmissionMetrics(r.URL.Path, operation, reviewResponse, time.Since(start))
 	klog.V(5).Infof("sending response: %v", reviewResponse)
 
 	response := createResponse(reviewResponse, &ar)
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+const (
+	// DefaultActiveDeadlineSecondsAnnotationKey is the annotation key on the queue which sets the
+	// active deadline of jobs submitted to the queue if not specified.
+	DefaultActiveDeadlineSecondsAnnotationKey = "volcano.sh/default-active-deadline-seconds"
+	// MaxActiveDeadlineSecondsAnnotationKey is the annotation key on the queue which limits the
+	// active deadline of jobs submitted to the queue.
+	MaxActiveDeadlineSecondsAnnotationKey = "volcano.sh/max-active-deadline-seconds"
+)
+
+// GetQueueActiveDeadlineSeconds returns the default and maximum activeDeadlineSeconds of the queue,
+// nil is returned for the one which is not set. The maximum is used as default if only it is set.
+func GetQueueActiveDeadlineSeconds(queue *schedulingv1beta1.Queue) (*int64, *int64, error) {
+	defaultSeconds, err := parseActiveDeadlineSeconds(queue, DefaultActiveDeadlineSecondsAnnotationKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	maxSeconds, err := parseActiveDeadlineSeconds(queue, MaxActiveDeadlineSecondsAnnotationKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if maxSeconds != nil {
+		if defaultSeconds == nil {
+			defaultSeconds = maxSeconds
+		} else if *defaultSeconds > *maxSeconds {
+			return nil, nil, fmt.Errorf("%s must be less than or equal to %s",
+				DefaultActiveDeadlineSecondsAnnotationKey, MaxActiveDeadlineSecondsAnnotationKey)
+		}
+	}
+	return defaultSeconds, maxSeconds, nil
+}
+
+func parseActiveDeadlineSeconds(queue *schedulingv1beta1.Queue, key string) (*int64, error) {
+	value, found := queue.Annotations[key]
+	if !found {
+		return nil, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s is invalid number: %v", key, err)
+	}
+	if seconds <= 0 {
+		return nil, fmt.Errorf("%s must be larger than 0", key)
+	}
+	return &seconds, nil
+}
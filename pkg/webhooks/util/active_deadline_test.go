@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func TestGetQueueActiveDeadlineSeconds(t *testing.T) {
+	testCases := []struct {
+		name            string
+		annotations     map[string]string
+		expectedDefault *int64
+		expectedMax     *int64
+		expectedErr     bool
+	}{
+		{
+			name: "nothing set",
+		},
+		{
+			name:            "only default set",
+			annotations:     map[string]string{DefaultActiveDeadlineSecondsAnnotationKey: "3600"},
+			expectedDefault: ptr.To[int64](3600),
+		},
+		{
+			name:            "only max set",
+			annotations:     map[string]string{MaxActiveDeadlineSecondsAnnotationKey: "7200"},
+			expectedDefault: ptr.To[int64](7200),
+			expectedMax:     ptr.To[int64](7200),
+		},
+		{
+			name: "both set",
+			annotations: map[string]string{
+				DefaultActiveDeadlineSecondsAnnotationKey: "3600",
+				MaxActiveDeadlineSecondsAnnotationKey:     "7200",
+			},
+			expectedDefault: ptr.To[int64](3600),
+			expectedMax:     ptr.To[int64](7200),
+		},
+		{
+			name: "default greater than max",
+			annotations: map[string]string{
+				DefaultActiveDeadlineSecondsAnnotationKey: "7200",
+				MaxActiveDeadlineSecondsAnnotationKey:     "3600",
+			},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid number",
+			annotations: map[string]string{MaxActiveDeadlineSecondsAnnotationKey: "1h"},
+			expectedErr: true,
+		},
+		{
+			name:        "not positive",
+			annotations: map[string]string{DefaultActiveDeadlineSecondsAnnotationKey: "0"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := &schedulingv1beta1.Queue{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			defaultSeconds, maxSeconds, err := GetQueueActiveDeadlineSeconds(queue)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if !equalSeconds(defaultSeconds, tc.expectedDefault) || !equalSeconds(maxSeconds, tc.expectedMax) {
+				t.Errorf("expected %v/%v, got %v/%v", ptr.Deref(tc.expectedDefault, 0), ptr.Deref(tc.expectedMax, 0),
+					ptr.Deref(defaultSeconds, 0), ptr.Deref(maxSeconds, 0))
+			}
+		})
+	}
+}
+
+func equalSeconds(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
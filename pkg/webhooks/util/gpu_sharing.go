@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	deviceconfig "volcano.sh/volcano/pkg/scheduler/api/devices/config"
+	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/gpushare"
+	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/vgpu"
+)
+
+// vgpuModes are the valid values of the vgpu mode annotation, i.e. the modes of the vgpu device plugin.
+var vgpuModes = sets.New[string]("hami-core", "mig", "mps")
+
+// ValidateGPUSharing checks the gpu sharing resources of the containers and the gpu sharing annotations of the
+// pod, so that a misconfigured pod is rejected at admission instead of being left pending or ignored by the
+// device plugins:
+//   - a container can only request gpus in one way, exclusive nvidia.com/gpu, gpu share or vgpu;
+//   - a gpu share container can not request both exclusive gpu-number and shared gpu-memory;
+//   - a vgpu container can not request both vgpu-memory and vgpu-memory-percentage, and vgpu-cores or
+//     vgpu-memory-percentage takes no effect without vgpu-number or vgpu-memory;
+//   - the vgpu mode is valid and only set for the pods requesting vgpu, and a gpu type is not both used and unused;
+//   - the mig profile is only set for the pods requesting vgpu not in other modes than mig.
+func ValidateGPUSharing(annotations map[string]string, spec *v1.PodSpec) error {
+	requestVGPU := false
+	for _, containers := range [][]v1.Container{spec.InitContainers, spec.Containers} {
+		for _, container := range containers {
+			vgpuRequested, err := validateContainerGPUSharing(container)
+			if err != nil {
+				return fmt.Errorf("container %s: %v", container.Name, err)
+			}
+			requestVGPU = requestVGPU || vgpuRequested
+		}
+	}
+
+	if mode, found := annotations[vgpu.GPUModeAnnotation]; found {
+		if !vgpuModes.Has(mode) {
+			return fmt.Errorf("invalid %s %q, valid values are %v", vgpu.GPUModeAnnotation, mode, sets.List(vgpuModes))
+		}
+		if !requestVGPU {
+			return fmt.Errorf("%s takes no effect without requesting %s or %s",
+				vgpu.GPUModeAnnotation, deviceconfig.VolcanoVGPUNumber, deviceconfig.VolcanoVGPUMemory)
+		}
+	}
+
+	if profile, found := annotations[vgpu.MigProfileAnnotation]; found {
+		if profile == "" || !requestVGPU {
+			return fmt.Errorf("%s takes no effect without requesting %s or %s",
+				vgpu.MigProfileAnnotation, deviceconfig.VolcanoVGPUNumber, deviceconfig.VolcanoVGPUMemory)
+		}
+		if mode, found := annotations[vgpu.GPUModeAnnotation]; found && mode != "mig" {
+			return fmt.Errorf("%s conflicts with %s %q", vgpu.MigProfileAnnotation, vgpu.GPUModeAnnotation, mode)
+		}
+	}
+
+	inUse := gpuTypes(annotations[vgpu.GPUInUse])
+	if conflicts := inUse.Intersection(gpuTypes(annotations[vgpu.GPUNoUse])); conflicts.Len() > 0 {
+		return fmt.Errorf("gpu types %v are in both %s and %s", sets.List(conflicts), vgpu.GPUInUse, vgpu.GPUNoUse)
+	}
+	return nil
+}
+
+// validateContainerGPUSharing validates the gpu sharing resources of the container, and returns whether it
+// requests vgpu.
+func validateContainerGPUSharing(container v1.Container) (bool, error) {
+	limits := container.Resources.Limits
+	has := func(name string) bool {
+		_, found := limits[v1.ResourceName(name)]
+		return found
+	}
+
+	requestGPUShare := has(gpushare.VolcanoGPUResource) || has(gpushare.VolcanoGPUNumber)
+	requestVGPU := has(deviceconfig.VolcanoVGPUNumber) || has(deviceconfig.VolcanoVGPUMemory) ||
+		has(deviceconfig.VolcanoVGPUMemoryPercentage) || has(deviceconfig.VolcanoVGPUCores)
+
+	if has(api.GPUResourceName) && (requestGPUShare || requestVGPU) {
+		return false, fmt.Errorf("exclusive %s conflicts with the shared gpu resources", api.GPUResourceName)
+	}
+	if requestGPUShare && requestVGPU {
+		return false, fmt.Errorf("gpu share resources %s/%s conflict with vgpu resources",
+			gpushare.VolcanoGPUResource, gpushare.VolcanoGPUNumber)
+	}
+	if has(gpushare.VolcanoGPUNumber) && has(gpushare.VolcanoGPUResource) {
+		return false, fmt.Errorf("exclusive %s conflicts with shared %s",
+			gpushare.VolcanoGPUNumber, gpushare.VolcanoGPUResource)
+	}
+
+	if !requestVGPU {
+		return false, nil
+	}
+	if has(deviceconfig.VolcanoVGPUMemory) && has(deviceconfig.VolcanoVGPUMemoryPercentage) {
+		return false, fmt.Errorf("%s conflicts with %s",
+			deviceconfig.VolcanoVGPUMemory, deviceconfig.VolcanoVGPUMemoryPercentage)
+	}
+	if !has(deviceconfig.VolcanoVGPUNumber) && !has(deviceconfig.VolcanoVGPUMemory) {
+		return false, fmt.Errorf("%s and %s take no effect without %s or %s",
+			deviceconfig.VolcanoVGPUCores, deviceconfig.VolcanoVGPUMemoryPercentage,
+			deviceconfig.VolcanoVGPUNumber, deviceconfig.VolcanoVGPUMemory)
+	}
+	for _, name := range []string{deviceconfig.VolcanoVGPUCores, deviceconfig.VolcanoVGPUMemoryPercentage} {
+		if value, found := limits[v1.ResourceName(name)]; found && value.Value() > 100 {
+			return false, fmt.Errorf("%s %s must not be greater than 100", name, value.String())
+		}
+	}
+	return true, nil
+}
+
+// gpuTypes parses the gpu types separated by comma, case-insensitive as the vgpu device does.
+func gpuTypes(value string) sets.Set[string] {
+	types := sets.New[string]()
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types.Insert(strings.ToUpper(t))
+		}
+	}
+	return types
+}
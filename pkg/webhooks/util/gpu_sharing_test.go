@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func buildGPUPodSpec(limits map[string]string) *v1.PodSpec {
+	resources := v1.ResourceList{}
+	for name, value := range limits {
+		resources[v1.ResourceName(name)] = resource.MustParse(value)
+	}
+	return &v1.PodSpec{
+		Containers: []v1.Container{{Name: "c1", Resources: v1.ResourceRequirements{Limits: resources}}},
+	}
+}
+
+func TestValidateGPUSharing(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		limits      map[string]string
+		expectedErr bool
+	}{
+		{
+			name:   "no gpu",
+			limits: map[string]string{"cpu": "1"},
+		},
+		{
+			name:   "exclusive gpu",
+			limits: map[string]string{"nvidia.com/gpu": "1"},
+		},
+		{
+			name:   "gpu share memory",
+			limits: map[string]string{"volcano.sh/gpu-memory": "1024"},
+		},
+		{
+			name:   "vgpu memory of a single gpu",
+			limits: map[string]string{"volcano.sh/vgpu-memory": "1024", "volcano.sh/vgpu-cores": "50"},
+		},
+		{
+			name:        "vgpu with mode",
+			annotations: map[string]string{"volcano.sh/vgpu-mode": "hami-core", "nvidia.com/use-gputype": "A100"},
+			limits:      map[string]string{"volcano.sh/vgpu-number": "2", "volcano.sh/vgpu-memory-percentage": "50"},
+		},
+		{
+			name:        "exclusive gpu with shared gpu memory",
+			limits:      map[string]string{"nvidia.com/gpu": "1", "volcano.sh/gpu-memory": "1024"},
+			expectedErr: true,
+		},
+		{
+			name:        "gpu share with vgpu",
+			limits:      map[string]string{"volcano.sh/gpu-memory": "1024", "volcano.sh/vgpu-number": "1"},
+			expectedErr: true,
+		},
+		{
+			name:        "exclusive gpu number with shared gpu memory",
+			limits:      map[string]string{"volcano.sh/gpu-number": "1", "volcano.sh/gpu-memory": "1024"},
+			expectedErr: true,
+		},
+		{
+			name:        "vgpu memory with memory percentage",
+			limits:      map[string]string{"volcano.sh/vgpu-number": "1", "volcano.sh/vgpu-memory": "1024", "volcano.sh/vgpu-memory-percentage": "50"},
+			expectedErr: true,
+		},
+		{
+			name:        "vgpu cores without vgpu number",
+			limits:      map[string]string{"volcano.sh/vgpu-cores": "50"},
+			expectedErr: true,
+		},
+		{
+			name:        "vgpu cores greater than 100",
+			limits:      map[string]string{"volcano.sh/vgpu-number": "1", "volcano.sh/vgpu-cores": "150"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid vgpu mode",
+			annotations: map[string]string{"volcano.sh/vgpu-mode": "time-slicing"},
+			limits:      map[string]string{"volcano.sh/vgpu-number": "1"},
+			expectedErr: true,
+		},
+		{
+			name:        "vgpu mode without vgpu",
+			annotations: map[string]string{"volcano.sh/vgpu-mode": "mig"},
+			limits:      map[string]string{"nvidia.com/gpu": "1"},
+			expectedErr: true,
+		},
+		{
+			name:        "mig profile",
+			annotations: map[string]string{"volcano.sh/vgpu-mode": "mig", "volcano.sh/vgpu-mig-profile": "1g.10gb"},
+			limits:      map[string]string{"volcano.sh/vgpu-number": "1"},
+		},
+		{
+			name:        "mig profile without vgpu",
+			annotations: map[string]string{"volcano.sh/vgpu-mig-profile": "1g.10gb"},
+			limits:      map[string]string{"cpu": "1"},
+			expectedErr: true,
+		},
+		{
+			name:        "mig profile in hami-core mode",
+			annotations: map[string]string{"volcano.sh/vgpu-mode": "hami-core", "volcano.sh/vgpu-mig-profile": "1g.10gb"},
+			limits:      map[string]string{"volcano.sh/vgpu-number": "1"},
+			expectedErr: true,
+		},
+		{
+			name:        "gpu type both used and unused",
+			annotations: map[string]string{"nvidia.com/use-gputype": "A100,H100", "nvidia.com/nouse-gputype": "h100"},
+			limits:      map[string]string{"volcano.sh/vgpu-number": "1"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateGPUSharing(tc.annotations, buildGPUPodSpec(tc.limits))
+			if (err != nil) != tc.expectedErr {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
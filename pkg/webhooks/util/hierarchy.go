@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+// hierarchyRoot is the first node of all the hierarchies, the hdrf plugin builds the tree under it.
+const hierarchyRoot = "root"
+
+// ValidateHierarchyAnnotations validates the format of the legacy hierarchy annotations used by the hdrf plugin,
+// e.g. "root/eng/dev" with the weights "1/2/3". The hierarchy must start with root, the nodes in the path must not be
+// empty, and every node must have a positive weight, the hdrf plugin fails to build the tree of the queues otherwise.
+func ValidateHierarchyAnnotations(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	hierarchy := annotations[schedulingv1beta1.KubeHierarchyAnnotationKey]
+	hierarchicalWeights := annotations[schedulingv1beta1.KubeHierarchyWeightAnnotationKey]
+	if hierarchy == "" && hierarchicalWeights == "" {
+		return errs
+	}
+
+	paths := strings.Split(hierarchy, "/")
+	weights := strings.Split(hierarchicalWeights, "/")
+	// path length must be the same with weights length
+	if len(paths) != len(weights) {
+		return append(errs, field.Invalid(fldPath, hierarchy,
+			fmt.Sprintf("%s must have the same length with %s",
+				schedulingv1beta1.KubeHierarchyAnnotationKey,
+				schedulingv1beta1.KubeHierarchyWeightAnnotationKey,
+			)))
+	}
+
+	if paths[0] != hierarchyRoot {
+		return append(errs, field.Invalid(fldPath, hierarchy,
+			fmt.Sprintf("%s must start with %s", schedulingv1beta1.KubeHierarchyAnnotationKey, hierarchyRoot)))
+	}
+	for _, path := range paths {
+		if path == "" {
+			return append(errs, field.Invalid(fldPath, hierarchy,
+				fmt.Sprintf("%s must not have empty node, the names of the nodes must not contain \"/\"",
+					schedulingv1beta1.KubeHierarchyAnnotationKey)))
+		}
+	}
+
+	// check weights format
+	for _, weight := range weights {
+		weightFloat, err := strconv.ParseFloat(weight, 64)
+		if err != nil {
+			return append(errs, field.Invalid(fldPath, hierarchicalWeights,
+				fmt.Sprintf("%s in the %s is invalid number: %v",
+					weight, hierarchicalWeights, err,
+				)))
+		}
+		if math.IsNaN(weightFloat) || math.IsInf(weightFloat, 0) {
+			return append(errs, field.Invalid(fldPath, hierarchicalWeights,
+				fmt.Sprintf("%s in the %s must be a finite number",
+					weight, hierarchicalWeights,
+				)))
+		}
+		if weightFloat <= 0 {
+			return append(errs, field.Invalid(fldPath, hierarchicalWeights,
+				fmt.Sprintf("%s in the %s must be larger than 0",
+					weight, hierarchicalWeights,
+				)))
+		}
+	}
+	return errs
+}
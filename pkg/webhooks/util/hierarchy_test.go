@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func TestValidateHierarchyAnnotations(t *testing.T) {
+	testCases := []struct {
+		name        string
+		hierarchy   string
+		weights     string
+		expectedErr bool
+	}{
+		{
+			name: "nothing set",
+		},
+		{
+			name:      "valid",
+			hierarchy: "root/eng/dev",
+			weights:   "1/2/0.5",
+		},
+		{
+			name:        "weights missing",
+			hierarchy:   "root/eng",
+			expectedErr: true,
+		},
+		{
+			name:        "hierarchy missing",
+			weights:     "1/2",
+			expectedErr: true,
+		},
+		{
+			name:        "length mismatch",
+			hierarchy:   "root/eng/dev",
+			weights:     "1/2",
+			expectedErr: true,
+		},
+		{
+			name:        "not start with root",
+			hierarchy:   "eng/dev",
+			weights:     "1/2",
+			expectedErr: true,
+		},
+		{
+			name:        "empty node",
+			hierarchy:   "root//dev",
+			weights:     "1/2/3",
+			expectedErr: true,
+		},
+		{
+			name:        "trailing slash",
+			hierarchy:   "root/eng/",
+			weights:     "1/2/3",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid number",
+			hierarchy:   "root/eng",
+			weights:     "1/a",
+			expectedErr: true,
+		},
+		{
+			name:        "infinite weight",
+			hierarchy:   "root/eng",
+			weights:     "1/Inf",
+			expectedErr: true,
+		},
+		{
+			name:        "not positive",
+			hierarchy:   "root/eng",
+			weights:     "1/0",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			annotations := map[string]string{}
+			if tc.hierarchy != "" {
+				annotations[schedulingv1beta1.KubeHierarchyAnnotationKey] = tc.hierarchy
+			}
+			if tc.weights != "" {
+				annotations[schedulingv1beta1.KubeHierarchyWeightAnnotationKey] = tc.weights
+			}
+			errs := ValidateHierarchyAnnotations(annotations, field.NewPath("metadata", "annotations"))
+			if (len(errs) > 0) != tc.expectedErr {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, errs)
+			}
+		})
+	}
+}
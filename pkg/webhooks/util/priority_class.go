@@ -0,0 +1,43 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+// DefaultPriorityClassNameAnnotationKey is the annotation key on the queue which sets the priorityClassName
+// of the jobs, podgroups and task pod templates submitted to the queue if not specified, so that the tiers
+// of queues are translated to the pod priority used by kubelet eviction too.
+const DefaultPriorityClassNameAnnotationKey = "volcano.sh/default-priority-class-name"
+
+// GetQueueDefaultPriorityClassName returns the default priorityClassName of the queue, empty if not set.
+func GetQueueDefaultPriorityClassName(queue *schedulingv1beta1.Queue) (string, error) {
+	name, found := queue.Annotations[DefaultPriorityClassNameAnnotationKey]
+	if !found {
+		return "", nil
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("invalid %s %q: %s", DefaultPriorityClassNameAnnotationKey, name, strings.Join(errs, ", "))
+	}
+	return name, nil
+}
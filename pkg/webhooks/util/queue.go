@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulinglister "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/webhooks/config"
+)
+
+// GetNamespaceDefaultQueue returns the default queue of the namespace. The queue name label of the
+// namespace takes precedence over the annotation, which takes precedence over the namespace queues
+// of the admission configuration. An empty string is returned if no default queue is found.
+func GetNamespaceDefaultQueue(ns *v1.Namespace, conf *config.AdmissionConfiguration) string {
+	if queue, ok := ns.Labels[schedulingv1beta1.QueueNameAnnotationKey]; ok && queue != "" {
+		return queue
+	}
+	if queue, ok := ns.Annotations[schedulingv1beta1.QueueNameAnnotationKey]; ok && queue != "" {
+		return queue
+	}
+	if conf == nil {
+		return ""
+	}
+
+	conf.Lock()
+	defer conf.Unlock()
+	for i := range conf.NamespaceQueues {
+		if conf.NamespaceQueues[i].Matches(ns.Name) {
+			return conf.NamespaceQueues[i].Queue
+		}
+	}
+	return ""
+}
+
+// GetDefaultQueueOfNamespace returns the default queue of the namespace got from the lister, an empty string is
+// returned if the lister is not set, the namespace is not found or it has no default queue.
+func GetDefaultQueueOfNamespace(lister corelisters.NamespaceLister, namespace string, conf *config.AdmissionConfiguration) string {
+	if lister == nil {
+		return ""
+	}
+	ns, err := lister.Get(namespace)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get namespace", "namespace", namespace)
+		return ""
+	}
+	return GetNamespaceDefaultQueue(ns, conf)
+}
+
+// ValidateQueueState returns the violations of submitting the kind of object, e.g. job or PodGroup, to the queue:
+// the queue must be open, and it must be a leaf queue other than the root queue if queues are hierarchical.
+func ValidateQueueState(kind string, queue *schedulingv1beta1.Queue, lister schedulinglister.QueueLister) []string {
+	var violations []string
+	if queue.Status.State != schedulingv1beta1.QueueStateOpen {
+		violations = append(violations, fmt.Sprintf("can only submit %s to queue with state `Open`, "+
+			"queue `%s` status is `%s`", kind, queue.Name, queue.Status.State))
+	}
+
+	if queue.Name == "root" {
+		return append(violations, fmt.Sprintf("can not submit %s to root queue", kind))
+	}
+	queues, err := lister.List(labels.Everything())
+	if err != nil {
+		return append(violations, fmt.Sprintf("failed to get list queues: %v", err))
+	}
+	children := 0
+	for _, child := range queues {
+		if child.Spec.Parent == queue.Name {
+			children++
+		}
+	}
+	if children > 0 {
+		violations = append(violations, fmt.Sprintf("can only submit %s to leaf queue, "+
+			"queue `%s` has %d child queues", kind, queue.Name, children))
+	}
+	return violations
+}
+
+// QueueStateWarnOnly returns whether the violations of the queue state are returned as warnings rather than
+// rejecting the jobs and PodGroups, e.g. while migrating the workloads off the closed or non-leaf queues.
+func QueueStateWarnOnly(conf *config.AdmissionConfiguration) bool {
+	if conf == nil {
+		return false
+	}
+	conf.Lock()
+	defer conf.Unlock()
+	return conf.QueueStateValidation == config.QueueStateValidationWarn
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/webhooks/config"
+)
+
+func TestGetNamespaceDefaultQueue(t *testing.T) {
+	conf := &config.AdmissionConfiguration{
+		NamespaceQueues: config.CompileNamespaceQueues([]config.NamespaceQueueConfig{
+			{Namespace: "[", Queue: "invalid"},
+			{Namespace: "team-a", Queue: "team-a-queue"},
+			{Namespace: "ml-.*", Queue: "ml"},
+		}),
+	}
+
+	testCases := []struct {
+		name     string
+		ns       *v1.Namespace
+		conf     *config.AdmissionConfiguration
+		expected string
+	}{
+		{
+			name: "label takes precedence over annotation",
+			ns: &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "team-a",
+				Labels:      map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "from-label"},
+				Annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "from-annotation"},
+			}},
+			conf:     conf,
+			expected: "from-label",
+		},
+		{
+			name: "annotation takes precedence over configuration",
+			ns: &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:        "team-a",
+				Annotations: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "from-annotation"},
+			}},
+			conf:     conf,
+			expected: "from-annotation",
+		},
+		{
+			name:     "exact namespace in configuration",
+			ns:       &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			conf:     conf,
+			expected: "team-a-queue",
+		},
+		{
+			name:     "namespace matches regular expression in configuration",
+			ns:       &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ml-training"}},
+			conf:     conf,
+			expected: "ml",
+		},
+		{
+			name:     "regular expression must match the whole name",
+			ns:       &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-ab"}},
+			conf:     conf,
+			expected: "",
+		},
+		{
+			name:     "no configuration",
+			ns:       &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if queue := GetNamespaceDefaultQueue(tc.ns, tc.conf); queue != tc.expected {
+				t.Errorf("expected queue %q, got %q", tc.expected, queue)
+			}
+		})
+	}
+}
+
+func TestGetDefaultQueueOfNamespace(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	_ = indexer.Add(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "team-a",
+		Labels: map[string]string{schedulingv1beta1.QueueNameAnnotationKey: "from-label"},
+	}})
+	lister := corelisters.NewNamespaceLister(indexer)
+
+	if queue := GetDefaultQueueOfNamespace(nil, "team-a", nil); queue != "" {
+		t.Errorf("expected no queue without lister, got %q", queue)
+	}
+	if queue := GetDefaultQueueOfNamespace(lister, "team-b", nil); queue != "" {
+		t.Errorf("expected no queue of missing namespace, got %q", queue)
+	}
+	if queue := GetDefaultQueueOfNamespace(lister, "team-a", nil); queue != "from-label" {
+		t.Errorf("expected queue %q, got %q", "from-label", queue)
+	}
+}
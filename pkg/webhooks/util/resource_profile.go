@@ -0,0 +1,59 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+// DefaultResourceRequirementsAnnotationKey is the annotation key on the queue which sets the requests and limits
+// of the containers without requests of the pods submitted to the queue, so that best-effort pods are accounted
+// in the share of the queue too, e.g. '{"requests":{"cpu":"500m","memory":"512Mi"},"limits":{"memory":"1Gi"}}'.
+const DefaultResourceRequirementsAnnotationKey = "volcano.sh/default-resource-requirements"
+
+// GetQueueDefaultResourceRequirements returns the default resource requirements of the containers of the queue,
+// nil if not set.
+func GetQueueDefaultResourceRequirements(queue *schedulingv1beta1.Queue) (*v1.ResourceRequirements, error) {
+	value, found := queue.Annotations[DefaultResourceRequirementsAnnotationKey]
+	if !found {
+		return nil, nil
+	}
+
+	requirements := &v1.ResourceRequirements{}
+	if err := json.Unmarshal([]byte(value), requirements); err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %v", DefaultResourceRequirementsAnnotationKey, value, err)
+	}
+	if len(requirements.Requests) == 0 {
+		return nil, fmt.Errorf("invalid %s %q: requests must be set", DefaultResourceRequirementsAnnotationKey, value)
+	}
+	for name, request := range requirements.Requests {
+		if request.Sign() < 0 {
+			return nil, fmt.Errorf("invalid %s %q: request of %s must not be negative",
+				DefaultResourceRequirementsAnnotationKey, value, name)
+		}
+		if limit, found := requirements.Limits[name]; found && request.Cmp(limit) > 0 {
+			return nil, fmt.Errorf("invalid %s %q: request of %s must be less than or equal to its limit",
+				DefaultResourceRequirementsAnnotationKey, value, name)
+		}
+	}
+	return requirements, nil
+}
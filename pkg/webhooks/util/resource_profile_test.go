@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func TestGetQueueDefaultResourceRequirements(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expectedNil bool
+		expectedErr bool
+	}{
+		{
+			name:        "nothing set",
+			expectedNil: true,
+		},
+		{
+			name:        "requests and limits set",
+			annotations: map[string]string{DefaultResourceRequirementsAnnotationKey: `{"requests":{"cpu":"500m"},"limits":{"cpu":"1"}}`},
+		},
+		{
+			name:        "invalid json",
+			annotations: map[string]string{DefaultResourceRequirementsAnnotationKey: `{"requests":`},
+			expectedErr: true,
+		},
+		{
+			name:        "requests not set",
+			annotations: map[string]string{DefaultResourceRequirementsAnnotationKey: `{"limits":{"cpu":"1"}}`},
+			expectedErr: true,
+		},
+		{
+			name:        "request greater than limit",
+			annotations: map[string]string{DefaultResourceRequirementsAnnotationKey: `{"requests":{"cpu":"2"},"limits":{"cpu":"1"}}`},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := &schedulingv1beta1.Queue{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			requirements, err := GetQueueDefaultResourceRequirements(queue)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if !tc.expectedErr && (requirements == nil) != tc.expectedNil {
+				t.Errorf("expected nil requirements %v, got %v", tc.expectedNil, requirements)
+			}
+		})
+	}
+}
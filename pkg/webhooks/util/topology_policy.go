@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+// DefaultTopologyPolicyAnnotationKey is the annotation key on the queue which sets the numa topologyPolicy
+// of the tasks of jobs submitted to the queue if not specified, so that the numa-aware plugin is used for them.
+const DefaultTopologyPolicyAnnotationKey = "volcano.sh/default-topology-policy"
+
+// ParseTopologyPolicy checks the numa topology policy is one supported by the numa-aware plugin.
+func ParseTopologyPolicy(value string) (batchv1alpha1.NumaPolicy, error) {
+	policy := batchv1alpha1.NumaPolicy(value)
+	switch policy {
+	case batchv1alpha1.None, batchv1alpha1.BestEffort, batchv1alpha1.Restricted, batchv1alpha1.SingleNumaNode:
+		return policy, nil
+	}
+	return "", fmt.Errorf("invalid topology policy %q, must be one of %s, %s, %s, %s", value,
+		batchv1alpha1.None, batchv1alpha1.BestEffort, batchv1alpha1.Restricted, batchv1alpha1.SingleNumaNode)
+}
+
+// GetQueueDefaultTopologyPolicy returns the default topologyPolicy of the queue, empty if not set.
+func GetQueueDefaultTopologyPolicy(queue *schedulingv1beta1.Queue) (batchv1alpha1.NumaPolicy, error) {
+	value, found := queue.Annotations[DefaultTopologyPolicyAnnotationKey]
+	if !found {
+		return "", nil
+	}
+	policy, err := ParseTopologyPolicy(value)
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", DefaultTopologyPolicyAnnotationKey, err)
+	}
+	return policy, nil
+}
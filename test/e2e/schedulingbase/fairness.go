@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingbase
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+
+	e2eutil "volcano.sh/volcano/test/e2e/util"
+)
+
+var _ = Describe("Queue Fairness Test", func() {
+	It("allocation converges to the weights of saturated queues", func() {
+		ctx := e2eutil.InitTestContext(e2eutil.Options{})
+		defer e2eutil.CleanupTestContext(ctx)
+
+		slot := e2eutil.HalfCPU
+		rep := e2eutil.ClusterSize(ctx, slot)
+
+		q1, q2 := ctx.Namespace+"-fair-q1", ctx.Namespace+"-fair-q2"
+		e2eutil.CreateQueueHierarchy(ctx, e2eutil.QueueTree{Name: q1, Weight: 1}, "")
+		e2eutil.CreateQueueHierarchy(ctx, e2eutil.QueueTree{Name: q2, Weight: 3}, "")
+
+		// each queue demands the whole cluster, so the cluster is shared by the weights of the queues
+		e2eutil.SaturateQueue(ctx, q1, 1, rep, slot)
+		e2eutil.SaturateQueue(ctx, q2, 1, rep, slot)
+
+		err := e2eutil.WaitAllocationConverged(ctx, map[string]float64{q1: 0.25, q2: 0.75},
+			v1.ResourceCPU, 0.1, e2eutil.FiveMinute)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
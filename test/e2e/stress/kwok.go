@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stress
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	schedulerapi "volcano.sh/volcano/pkg/scheduler/api"
+	e2eutil "volcano.sh/volcano/test/e2e/util"
+)
+
+const (
+	kwokNodePrefix = "kwok-scale-node"
+	kwokNodeCount  = 500
+)
+
+var _ = ginkgo.Describe("[Stress] Kwok Scale Test", func() {
+	var ctx *e2eutil.TestContext
+
+	ginkgo.BeforeEach(func() {
+		ctx = e2eutil.InitTestContext(e2eutil.Options{})
+		e2eutil.CreateKwokNodes(ctx, e2eutil.KwokNodesSpec{
+			NamePrefix: kwokNodePrefix,
+			Count:      kwokNodeCount,
+			GPU:        8,
+		})
+	})
+
+	ginkgo.AfterEach(func() {
+		e2eutil.CleanupTestContext(ctx)
+		e2eutil.DeleteKwokNodes(ctx, kwokNodePrefix)
+	})
+
+	ginkgo.It("should schedule gang job across all kwok nodes", func() {
+		affinity := &v1.Affinity{
+			NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{{
+						MatchExpressions: []v1.NodeSelectorRequirement{{
+							Key:      e2eutil.KwokNodeGroupLabel,
+							Operator: v1.NodeSelectorOpIn,
+							Values:   []string{kwokNodePrefix},
+						}},
+					}},
+				},
+			},
+		}
+		// each pod requests all the GPUs of one node, so the gang needs all the nodes
+		req := v1.ResourceList{
+			v1.ResourceCPU:               resource.MustParse("1"),
+			schedulerapi.GPUResourceName: resource.MustParse("8"),
+		}
+
+		job := e2eutil.CreateJob(ctx, &e2eutil.JobSpec{
+			Name: "kwok-gang",
+			Tasks: []e2eutil.TaskSpec{
+				{
+					Img:         e2eutil.DefaultNginxImage,
+					Req:         req,
+					Limit:       req,
+					Min:         kwokNodeCount,
+					Rep:         kwokNodeCount,
+					Affinity:    affinity,
+					Tolerations: []v1.Toleration{e2eutil.KwokNodeToleration},
+				},
+			},
+		})
+		err := e2eutil.WaitJobReady(ctx, job)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+})
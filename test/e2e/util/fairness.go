@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+// fairnessStablePolls is the number of the consecutive polls the allocation must be within the tolerance in,
+// so that a transient allocation on the way is not taken as converged.
+const fairnessStablePolls = 3
+
+// QueueTree is a queue and its children in a queue hierarchy.
+type QueueTree struct {
+	Name       string
+	Weight     int32
+	Deserved   v1.ResourceList
+	Capability v1.ResourceList
+	Guarantee  v1.ResourceList
+	Children   []QueueTree
+}
+
+// CreateQueueHierarchy creates the queues of the tree under the parent, the root queue if empty, parents before
+// children. The queues are deleted with the test context, children before parents.
+func CreateQueueHierarchy(ctx *TestContext, tree QueueTree, parent string) {
+	By(fmt.Sprintf("Creating queue hierarchy %s", tree.Name))
+
+	createQueueTree(ctx, tree, parent)
+	// wait for all queues state open
+	time.Sleep(3 * time.Second)
+}
+
+func createQueueTree(ctx *TestContext, tree QueueTree, parent string) {
+	weight := tree.Weight
+	if weight == 0 {
+		weight = 1
+	}
+	queue := &schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: tree.Name},
+		Spec: schedulingv1beta1.QueueSpec{
+			Weight:     weight,
+			Parent:     parent,
+			Deserved:   tree.Deserved,
+			Capability: tree.Capability,
+		},
+	}
+	if len(tree.Guarantee) != 0 {
+		queue.Spec.Guarantee.Resource = tree.Guarantee
+	}
+	_, err := ctx.Vcclient.SchedulingV1beta1().Queues().Create(context.TODO(), queue, metav1.CreateOptions{})
+	Expect(err).NotTo(HaveOccurred(), "failed to create queue %s", tree.Name)
+	// the queues are deleted in order, so the children are put before their parents
+	ctx.Queues = append([]string{tree.Name}, ctx.Queues...)
+
+	for _, child := range tree.Children {
+		createQueueTree(ctx, child, tree.Name)
+	}
+}
+
+// SaturateQueue creates the filler jobs in the queue, each of the replicas tasks requesting req, so that the queue
+// keeps demanding more resources than it is given and the allocation converges to its fair share.
+func SaturateQueue(ctx *TestContext, queue string, jobs int, replicas int32, req v1.ResourceList) []*batchv1alpha1.Job {
+	By(fmt.Sprintf("Saturating queue %s with %d filler jobs", queue, jobs))
+
+	fillers := make([]*batchv1alpha1.Job, 0, jobs)
+	for i := 0; i < jobs; i++ {
+		fillers = append(fillers, CreateJob(ctx, &JobSpec{
+			Name:  fmt.Sprintf("%s-filler-%d", queue, i),
+			Queue: queue,
+			Tasks: []TaskSpec{
+				{
+					Name: "filler",
+					Img:  DefaultNginxImage,
+					Req:  req,
+					Min:  1,
+					Rep:  replicas,
+				},
+			},
+		}))
+	}
+	return fillers
+}
+
+// QueuesAllocated returns the resources allocated to the queues by their status.
+func QueuesAllocated(ctx *TestContext, queues []string) (map[string]v1.ResourceList, error) {
+	allocated := make(map[string]v1.ResourceList, len(queues))
+	for _, name := range queues {
+		queue, err := ctx.Vcclient.SchedulingV1beta1().Queues().Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		allocated[name] = queue.Status.Allocated
+	}
+	return allocated, nil
+}
+
+// AllocationShares returns the share of every queue in the total of the resource allocated to the queues.
+func AllocationShares(allocated map[string]v1.ResourceList, name v1.ResourceName) map[string]float64 {
+	total := 0.0
+	for _, resources := range allocated {
+		quantity := resources[name]
+		total += float64(quantity.MilliValue())
+	}
+	shares := make(map[string]float64, len(allocated))
+	for queue, resources := range allocated {
+		if total == 0 {
+			shares[queue] = 0
+			continue
+		}
+		quantity := resources[name]
+		shares[queue] = float64(quantity.MilliValue()) / total
+	}
+	return shares
+}
+
+// CheckAllocationShares returns an error listing the queues whose shares are out of the tolerance of the expected.
+func CheckAllocationShares(expected, actual map[string]float64, tolerance float64) error {
+	var violations []string
+	for queue, share := range expected {
+		if math.Abs(actual[queue]-share) > tolerance {
+			violations = append(violations, fmt.Sprintf("queue %s: expected share %.3f, got %.3f", queue, share, actual[queue]))
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Strings(violations)
+	return fmt.Errorf("allocation shares out of tolerance %.3f: %s", tolerance, strings.Join(violations, "; "))
+}
+
+// WaitAllocationConverged waits until the shares of the resource allocated to the queues of expected are within the
+// tolerance of the expected shares for consecutive polls, e.g. {"q1": 0.25, "q2": 0.75} for queues weighted 1:3.
+// The error on timeout tells the last shares, so that a fairness regression is reported with the allocation.
+func WaitAllocationConverged(ctx *TestContext, expected map[string]float64, name v1.ResourceName, tolerance float64, timeout time.Duration) error {
+	queues := make([]string, 0, len(expected))
+	for queue := range expected {
+		queues = append(queues, queue)
+	}
+
+	var lastErr error
+	stable := 0
+	err := wait.Poll(time.Second, timeout, func() (bool, error) {
+		allocated, err := QueuesAllocated(ctx, queues)
+		if err != nil {
+			return false, err
+		}
+		if lastErr = CheckAllocationShares(expected, AllocationShares(allocated, name), tolerance); lastErr != nil {
+			stable = 0
+			return false, nil
+		}
+		stable++
+		return stable >= fairnessStablePolls, nil
+	})
+	if err != nil && lastErr != nil {
+		return fmt.Errorf("%v: %v", err, lastErr)
+	}
+	return err
+}
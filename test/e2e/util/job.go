@@ -103,7 +103,7 @@ func CreateJobWithPodGroup(ctx *TestContext, jobSpec *JobSpec,
 		},
 		Spec: batchv1alpha1.JobSpec{
 			Policies:                jobSpec.Policies,
-			Queue:                   jobSpec.Queue,
+			Queue:                   ctx.Queue(jobSpec.Queue),
 			Plugins:                 jobSpec.Plugins,
 			TTLSecondsAfterFinished: jobSpec.TTL,
 		},
@@ -199,7 +199,7 @@ func CreateJobInner(ctx *TestContext, jobSpec *JobSpec) (*batchv1alpha1.Job, err
 		Spec: batchv1alpha1.JobSpec{
 			SchedulerName:           "volcano",
 			Policies:                jobSpec.Policies,
-			Queue:                   jobSpec.Queue,
+			Queue:                   ctx.Queue(jobSpec.Queue),
 			Plugins:                 jobSpec.Plugins,
 			TTLSecondsAfterFinished: jobSpec.TTL,
 			MinSuccess:              jobSpec.MinSuccess,
@@ -248,6 +248,9 @@ func CreateJobInner(ctx *TestContext, jobSpec *JobSpec) (*batchv1alpha1.Job, err
 		if jobSpec.NodeName != "" {
 			ts.Template.Spec.NodeName = jobSpec.NodeName
 		}
+		if nodeSelector := ctx.NodeSelector(); nodeSelector != nil {
+			ts.Template.Spec.NodeSelector = nodeSelector
+		}
 
 		if task.DefaultGracefulPeriod != nil {
 			ts.Template.Spec.TerminationGracePeriodSeconds = task.DefaultGracefulPeriod
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+
+	schedulerapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const (
+	// KwokNodeAnnotation marks the nodes simulated by kwok
+	KwokNodeAnnotation = "kwok.x-k8s.io/node"
+	// KwokNodeGroupLabel is the label of the kwok nodes created by CreateKwokNodes, its value is the name prefix of the nodes
+	KwokNodeGroupLabel = "volcano.sh/e2e-kwok-node-group"
+
+	kwokNodeWorkers = 16
+)
+
+// KwokNodeToleration tolerates the taint of kwok nodes, which keeps the pods of the other tests away from them.
+var KwokNodeToleration = v1.Toleration{
+	Key:      KwokNodeAnnotation,
+	Operator: v1.TolerationOpEqual,
+	Value:    "fake",
+	Effect:   v1.TaintEffectNoSchedule,
+}
+
+// KwokNodesSpec describes a group of fake nodes simulated by kwok.
+type KwokNodesSpec struct {
+	// NamePrefix is the prefix of the node names, the nodes are named <NamePrefix>-<index>
+	NamePrefix string
+	// Count is the number of nodes
+	Count int
+	// Capacity is the capacity of each node, 8 cpu, 8Gi memory and 110 pods by default
+	Capacity v1.ResourceList
+	// GPU is the number of nvidia.com/gpu of each node
+	GPU int64
+	// Labels are added to the nodes besides the well-known labels, e.g. the zone or GPU type
+	Labels map[string]string
+}
+
+// CreateKwokNodes creates the kwok nodes of the spec in parallel and waits for them to be ready. It requires
+// the kwok controller running in the cluster, see install-kwok-with-helm in hack/lib/install.sh. The nodes are
+// tainted, so the pods of the tests must tolerate KwokNodeToleration.
+func CreateKwokNodes(ctx *TestContext, spec KwokNodesSpec) []*v1.Node {
+	nodes := make([]*v1.Node, spec.Count)
+	errs := make([]error, spec.Count)
+	workqueue.ParallelizeUntil(context.TODO(), kwokNodeWorkers, spec.Count, func(index int) {
+		nodes[index], errs[index] = ctx.Kubeclient.CoreV1().Nodes().Create(context.TODO(),
+			buildKwokNode(spec, index), metav1.CreateOptions{})
+	})
+	for index, err := range errs {
+		Expect(err).NotTo(HaveOccurred(), "failed to create kwok node %s-%d", spec.NamePrefix, index)
+	}
+
+	err := WaitKwokNodesReady(ctx, spec.NamePrefix, spec.Count)
+	Expect(err).NotTo(HaveOccurred(), "failed to wait for kwok nodes %s ready", spec.NamePrefix)
+	return nodes
+}
+
+// WaitKwokNodesReady waits for count kwok nodes with the name prefix to be ready.
+func WaitKwokNodesReady(ctx *TestContext, namePrefix string, count int) error {
+	var ready int
+	err := wait.Poll(time.Second, FiveMinute, func() (bool, error) {
+		nodes, err := ctx.Kubeclient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{
+			LabelSelector: kwokNodeSelector(namePrefix),
+		})
+		if err != nil {
+			return false, err
+		}
+		ready = 0
+		for i := range nodes.Items {
+			if IsNodeReady(&nodes.Items[i]) {
+				ready++
+			}
+		}
+		return ready >= count, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%d of %d kwok nodes %s are ready: %v", ready, count, namePrefix, err)
+	}
+	return nil
+}
+
+// DeleteKwokNodes deletes the kwok nodes with the name prefix.
+func DeleteKwokNodes(ctx *TestContext, namePrefix string) {
+	err := ctx.Kubeclient.CoreV1().Nodes().DeleteCollection(context.TODO(), metav1.DeleteOptions{}, metav1.ListOptions{
+		LabelSelector: kwokNodeSelector(namePrefix),
+	})
+	Expect(err).NotTo(HaveOccurred(), "failed to delete kwok nodes %s", namePrefix)
+}
+
+func kwokNodeSelector(namePrefix string) string {
+	return labels.SelectorFromSet(labels.Set{KwokNodeGroupLabel: namePrefix}).String()
+}
+
+func buildKwokNode(spec KwokNodesSpec, index int) *v1.Node {
+	name := fmt.Sprintf("%s-%d", spec.NamePrefix, index)
+
+	nodeLabels := map[string]string{
+		v1.LabelArchStable:              "amd64",
+		v1.LabelOSStable:                "linux",
+		v1.LabelHostname:                name,
+		"type":                          "kwok",
+		KwokNodeGroupLabel:              spec.NamePrefix,
+		"kubernetes.io/role":            "agent",
+		"node-role.kubernetes.io/agent": "",
+	}
+	for key, value := range spec.Labels {
+		nodeLabels[key] = value
+	}
+
+	capacity := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("8"),
+		v1.ResourceMemory: resource.MustParse("8Gi"),
+		v1.ResourcePods:   resource.MustParse("110"),
+	}
+	for resourceName, quantity := range spec.Capacity {
+		capacity[resourceName] = quantity
+	}
+	if spec.GPU > 0 {
+		capacity[schedulerapi.GPUResourceName] = *resource.NewQuantity(spec.GPU, resource.DecimalSI)
+	}
+
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: nodeLabels,
+			Annotations: map[string]string{
+				"node.alpha.kubernetes.io/ttl": "0",
+				KwokNodeAnnotation:             "fake",
+			},
+		},
+		Spec: v1.NodeSpec{
+			Taints: []v1.Taint{{
+				Key:    KwokNodeToleration.Key,
+				Value:  KwokNodeToleration.Value,
+				Effect: KwokNodeToleration.Effect,
+			}},
+		},
+		Status: v1.NodeStatus{
+			Capacity:    capacity,
+			Allocatable: capacity.DeepCopy(),
+		},
+	}
+}
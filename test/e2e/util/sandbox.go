@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// NodePoolLabel is the label of the nodes reserved for a test context, the value is the namespace of the context.
+	NodePoolLabel = "volcano.sh/e2e-nodepool"
+	// rootQueue is the root of the queue hierarchy.
+	rootQueue = "root"
+)
+
+// runInParallel returns whether the specs run in parallel processes.
+func runInParallel() bool {
+	suiteConfig, _ := GinkgoConfiguration()
+	return suiteConfig.ParallelTotal > 1
+}
+
+// Queue returns the name of the queue created for the test context, which is prefixed by the namespace if the
+// queues are sandboxed. Names not of the queues of the context, e.g. the default queue, are returned as is.
+func (ctx *TestContext) Queue(name string) string {
+	if queue, found := ctx.queueNames[name]; found {
+		return queue
+	}
+	return name
+}
+
+// NodeSelector returns the node selector of the node pool reserved for the test context.
+func (ctx *TestContext) NodeSelector() map[string]string {
+	if len(ctx.NodePool) == 0 {
+		return nil
+	}
+	return map[string]string{NodePoolLabel: ctx.Namespace}
+}
+
+// sandboxQueues creates the queues of the test context under a root queue named after the namespace, and names
+// them with the namespace as prefix, so that the queues of the specs running in parallel do not interfere.
+func sandboxQueues(ctx *TestContext) {
+	By("Creating sandbox queue")
+
+	ctx.SandboxQueue = ctx.Namespace
+	CreateQueue(ctx, ctx.SandboxQueue, nil, rootQueue)
+
+	ctx.queueNames = make(map[string]string, len(ctx.Queues))
+	for _, queue := range ctx.Queues {
+		ctx.queueNames[queue] = fmt.Sprintf("%s-%s", ctx.Namespace, queue)
+	}
+
+	queues := make([]string, 0, len(ctx.Queues))
+	deserved := make(map[string]v1.ResourceList, len(ctx.DeservedResource))
+	parents := make(map[string]string, len(ctx.Queues))
+	for _, queue := range ctx.Queues {
+		name := ctx.queueNames[queue]
+		queues = append(queues, name)
+		if resource, found := ctx.DeservedResource[queue]; found {
+			deserved[name] = resource
+		}
+		parent := ctx.QueueParent[queue]
+		if parent == "" || parent == rootQueue {
+			parents[name] = ctx.SandboxQueue
+		} else {
+			parents[name] = ctx.Queue(parent)
+		}
+	}
+	ctx.Queues = queues
+	ctx.DeservedResource = deserved
+	ctx.QueueParent = parents
+}
+
+// reserveNodePool labels size nodes with NodePoolLabel for the test context. A node is claimed by an update with
+// its resource version, so that the nodes are never reserved for two contexts running in parallel.
+func reserveNodePool(ctx *TestContext, size int) {
+	By(fmt.Sprintf("Reserving %d nodes for the test context", size))
+
+	nodes, err := ctx.Kubeclient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	Expect(err).NotTo(HaveOccurred(), "failed to list nodes")
+
+	for _, node := range nodes.Items {
+		if len(ctx.NodePool) == size {
+			break
+		}
+		if !IsNodeReady(&node) || len(node.Spec.Taints) != 0 || node.Labels[NodePoolLabel] != "" {
+			continue
+		}
+
+		node := node.DeepCopy()
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+		node.Labels[NodePoolLabel] = ctx.Namespace
+		if _, err := ctx.Kubeclient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{}); err != nil {
+			if errors.IsConflict(err) {
+				// the node is changed, maybe reserved by another context
+				continue
+			}
+			Expect(err).NotTo(HaveOccurred(), "failed to reserve node %s", node.Name)
+		}
+		ctx.NodePool = append(ctx.NodePool, node.Name)
+	}
+
+	if reserved := len(ctx.NodePool); reserved < size {
+		releaseNodePool(ctx)
+		Fail(fmt.Sprintf("Failed to reserve %d nodes for the test context, only %d available", size, reserved))
+	}
+}
+
+// releaseNodePool removes NodePoolLabel from the nodes reserved for the test context.
+func releaseNodePool(ctx *TestContext) {
+	for _, name := range ctx.NodePool {
+		err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			node, err := ctx.Kubeclient.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if node.Labels[NodePoolLabel] != ctx.Namespace {
+				return nil
+			}
+			delete(node.Labels, NodePoolLabel)
+			_, err = ctx.Kubeclient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+			return err
+		})
+		Expect(err).NotTo(HaveOccurred(), "failed to release node %s", name)
+	}
+	ctx.NodePool = nil
+}
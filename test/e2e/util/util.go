@@ -18,6 +18,7 @@ package util
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -130,6 +131,13 @@ type TestContext struct {
 	QueueParent      map[string]string
 	PriorityClasses  map[string]int32
 	UsingPlaceHolder bool
+	// SandboxQueue is the root queue of the queues of the test context if they are sandboxed
+	SandboxQueue string
+	// NodePool is the nodes reserved for the test context
+	NodePool []string
+
+	// queueNames maps the queue names in options to the ones created
+	queueNames map[string]string
 }
 
 type Options struct {
@@ -140,6 +148,11 @@ type Options struct {
 	PriorityClasses    map[string]int32
 	NodesNumLimit      int
 	NodesResourceLimit v1.ResourceList
+	// SandboxQueues creates the queues under a root queue of the test context and prefixes their names with the
+	// namespace, so that the specs running in parallel do not share queues. Use TestContext.Queue to get the names.
+	SandboxQueues bool
+	// NodePoolSize reserves the nodes for the test context, the jobs created by the context are scheduled to them.
+	NodePoolSize int
 }
 
 var VcClient *vcclient.Clientset
@@ -150,6 +163,9 @@ func InitTestContext(o Options) *TestContext {
 
 	if o.Namespace == "" {
 		o.Namespace = helpers.GenRandomStr(8)
+		if runInParallel() {
+			o.Namespace = fmt.Sprintf("%s-%d", o.Namespace, GinkgoParallelProcess())
+		}
 	}
 	ctx := &TestContext{
 		Namespace:        o.Namespace,
@@ -172,9 +188,16 @@ func InitTestContext(o Options) *TestContext {
 	)
 	Expect(err).NotTo(HaveOccurred(), "failed to create namespace")
 
+	if o.SandboxQueues {
+		sandboxQueues(ctx)
+	}
 	CreateQueues(ctx)
 	createPriorityClasses(ctx)
 
+	if o.NodePoolSize > 0 {
+		reserveNodePool(ctx, o.NodePoolSize)
+	}
+
 	if o.NodesNumLimit != 0 && o.NodesResourceLimit != nil {
 		setPlaceHolderForSchedulerTesting(ctx, o.NodesResourceLimit, o.NodesNumLimit)
 		ctx.UsingPlaceHolder = true
@@ -220,7 +243,11 @@ func CleanupTestContext(ctx *TestContext) {
 	Expect(err).NotTo(HaveOccurred(), "failed to delete namespace")
 
 	deleteQueues(ctx)
+	if ctx.SandboxQueue != "" {
+		DeleteQueue(ctx, ctx.SandboxQueue)
+	}
 	deletePriorityClasses(ctx)
+	releaseNodePool(ctx)
 
 	if ctx.UsingPlaceHolder {
 		deletePlaceHolder(ctx)
@@ -242,11 +269,19 @@ func createPriorityClasses(cxt *TestContext) {
 				GlobalDefault: false,
 			},
 			metav1.CreateOptions{})
+		// the priority classes are shared by the specs running in parallel
+		if errors.IsAlreadyExists(err) && runInParallel() {
+			continue
+		}
 		Expect(err).NotTo(HaveOccurred(), "failed to create priority class: %s", name)
 	}
 }
 
 func deletePriorityClasses(cxt *TestContext) {
+	// the priority classes may be still used by the specs running in other processes
+	if runInParallel() {
+		return
+	}
 	for name := range cxt.PriorityClasses {
 		err := cxt.Kubeclient.SchedulingV1().PriorityClasses().Delete(context.TODO(), name, metav1.DeleteOptions{})
 		Expect(err).NotTo(HaveOccurred())
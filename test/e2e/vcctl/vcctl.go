@@ -31,6 +31,7 @@ Usage:
   vcctl [command]
 
 Available Commands:
+  completion  Generate the autocompletion script for the specified shell
   help        Help about any command
   job         vcctl command line operation job
   jobflow     vcctl command line operation jobflow
@@ -95,6 +96,7 @@ Usage:
 
 Flags:
       --all-namespaces      list jobs in all namespaces
+      --context string      (optional) the name of the kubeconfig context to use
   -h, --help                help for list
   -k, --kubeconfig string   (optional) absolute path to the kubeconfig file
   -s, --master string       the address of apiserver
@@ -123,6 +125,7 @@ Usage:
   vcctl job suspend [flags]
 
 Flags:
+      --context string      (optional) the name of the kubeconfig context to use
   -h, --help                help for suspend
   -k, --kubeconfig string   (optional) absolute path to the kubeconfig file
   -s, --master string       the address of apiserver